@@ -3,21 +3,300 @@ create table `users` (
     `name` VARCHAR(20) NOT NULL COMMENT 'ユーザー名',
     `password` VARCHAR(80) NOT NULL COMMENT 'パスワード',
     `role` VARCHAR(80) NOT NULL COMMENT 'ロール',
+    `timezone` VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT 'タイムゾーン',
+    `email` VARCHAR(255) NULL COMMENT 'メールアドレス。パスワードの再設定のメールを送る',
     `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
     `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
     PRIMARY KEY (`id`),
-    UNIQUE KEY `name_unique` (`name`) USING BTREE
+    UNIQUE KEY `name_unique` (`name`) USING BTREE,
+    UNIQUE KEY `email_unique` (`email`) USING BTREE
 );
 
+create table `workspaces` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ワークスペースの識別子',
+    `name` VARCHAR(64) NOT NULL COMMENT 'ワークスペース名',
+    `personal_user_id` BIGINT UNSIGNED NULL COMMENT '個人用ワークスペースの持ち主。チームのワークスペースならNULL',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `personal_user_id_unique` (`personal_user_id`) USING BTREE,
+    CONSTRAINT `fk_workspaces_personal_user_id`
+        FOREIGN KEY (`personal_user_id`) REFERENCES `users` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ワークスペース';
+
+create table `workspace_members` (
+    `workspace_id` BIGINT UNSIGNED NOT NULL COMMENT 'ワークスペースの識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `role` VARCHAR(20) NOT NULL COMMENT 'ワークスペースでのロール',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`workspace_id`, `user_id`),
+    KEY `user_id` (`user_id`) USING BTREE,
+    CONSTRAINT `fk_workspace_members_workspace_id`
+        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_workspace_members_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ワークスペースのメンバー';
+
+create table `projects` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'プロジェクトの識別子',
+    `workspace_id` BIGINT UNSIGNED NOT NULL COMMENT 'ワークスペースの識別子',
+    `name` VARCHAR(64) NOT NULL COMMENT 'プロジェクト名',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `workspace_name_unique` (`workspace_id`, `name`) USING BTREE,
+    CONSTRAINT `fk_projects_workspace_id`
+        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='プロジェクト';
+
 create table `tasks` (
     `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'タスクの識別子',
+    `workspace_id` BIGINT UNSIGNED NOT NULL COMMENT 'ワークスペースの識別子',
     `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `project_id` BIGINT UNSIGNED NULL COMMENT 'プロジェクトの識別子',
+    `assignee_id` BIGINT UNSIGNED NULL COMMENT '担当者のユーザーの識別子',
     `title` VARCHAR(128) NOT NULL COMMENT 'タスクのタイトル',
     `status` VARCHAR(20) NOT NULL COMMENT 'タスクのステータス',
+    `priority` VARCHAR(20) NOT NULL DEFAULT '' COMMENT 'タスクの優先度。空文字なら未指定',
+    `column_id` BIGINT UNSIGNED NULL COMMENT 'ボードの列の識別子。NULLならステータスの先頭の列',
+    `due_at` DATETIME(6) NULL COMMENT '期限',
+    `completed_at` DATETIME(6) NULL COMMENT '完了日時',
     `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
     `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
     PRIMARY KEY (`id`),
+    KEY `workspace_user_id` (`workspace_id`, `user_id`) USING BTREE,
+    KEY `user_created_at` (`user_id`, `created_at`) USING BTREE,
+    KEY `user_completed_at` (`user_id`, `completed_at`) USING BTREE,
+    KEY `column_id` (`column_id`) USING BTREE,
+    KEY `workspace_assignee_id` (`workspace_id`, `assignee_id`) USING BTREE,
+    KEY `project_id` (`project_id`) USING BTREE,
     CONSTRAINT `fk_user_id`
         FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) 
-        ON DELETE RESTRICT ON UPDATE RESTRICT
+        ON DELETE RESTRICT ON UPDATE RESTRICT,
+    CONSTRAINT `fk_tasks_workspace_id`
+        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT,
+    CONSTRAINT `fk_tasks_project_id`
+        FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`)
+        ON DELETE SET NULL ON UPDATE RESTRICT,
+    CONSTRAINT `fk_tasks_assignee_id`
+        FOREIGN KEY (`assignee_id`) REFERENCES `users` (`id`)
+        ON DELETE SET NULL ON UPDATE RESTRICT
 ) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスク';
+
+create table `task_templates` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'テンプレートの識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `title` VARCHAR(128) NOT NULL COMMENT 'テンプレート名',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    CONSTRAINT `fk_task_templates_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスクテンプレート';
+
+create table `task_template_items` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'テンプレート項目の識別子',
+    `template_id` BIGINT UNSIGNED NOT NULL COMMENT 'テンプレートの識別子',
+    `position` INT UNSIGNED NOT NULL COMMENT '並び順',
+    `title` VARCHAR(128) NOT NULL COMMENT '作成するタスクのタイトル',
+    `due_offset_minutes` INT UNSIGNED NULL COMMENT 'インスタンス化時点からの期限(分)',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `template_position_unique` (`template_id`, `position`) USING BTREE,
+    CONSTRAINT `fk_task_template_items_template_id`
+        FOREIGN KEY (`template_id`) REFERENCES `task_templates` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスクテンプレートの項目';
+
+create table `time_entries` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '作業記録の識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `task_id` BIGINT UNSIGNED NOT NULL COMMENT 'タスクの識別子',
+    `started_at` DATETIME(6) NOT NULL COMMENT '開始日時',
+    `stopped_at` DATETIME(6) NULL COMMENT '終了日時。NULLなら計測中',
+    `running` TINYINT(1) AS (IF(`stopped_at` IS NULL, 1, NULL)) STORED COMMENT '計測中なら1',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `user_running_unique` (`user_id`, `running`) USING BTREE,
+    KEY `user_started_at` (`user_id`, `started_at`) USING BTREE,
+    CONSTRAINT `fk_time_entries_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT,
+    CONSTRAINT `fk_time_entries_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスクの作業時間の記録';
+
+create table `labels` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ラベルの識別子',
+    `workspace_id` BIGINT UNSIGNED NOT NULL COMMENT 'ワークスペースの識別子',
+    `name` VARCHAR(64) NOT NULL COMMENT 'ラベル名',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `workspace_name_unique` (`workspace_id`, `name`) USING BTREE,
+    CONSTRAINT `fk_labels_workspace_id`
+        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ラベル';
+
+create table `task_labels` (
+    `task_id` BIGINT UNSIGNED NOT NULL COMMENT 'タスクの識別子',
+    `label_id` BIGINT UNSIGNED NOT NULL COMMENT 'ラベルの識別子',
+    PRIMARY KEY (`task_id`, `label_id`),
+    KEY `label_id` (`label_id`) USING BTREE,
+    CONSTRAINT `fk_task_labels_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_task_labels_label_id`
+        FOREIGN KEY (`label_id`) REFERENCES `labels` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスクとラベルの対応';
+
+create table `board_columns` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '列の識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `name` VARCHAR(64) NOT NULL COMMENT '列の名前',
+    `status` VARCHAR(20) NOT NULL COMMENT '対応するタスクのステータス',
+    `position` INT UNSIGNED NOT NULL COMMENT '並び順',
+    `wip_limit` INT UNSIGNED NULL COMMENT '置けるタスクの上限。NULLなら無制限',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `user_position_unique` (`user_id`, `position`) USING BTREE,
+    CONSTRAINT `fk_board_columns_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE RESTRICT ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='カンバンボードの列';
+
+create table `notifications` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '通知の識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '通知を受け取るユーザーの識別子',
+    `type` VARCHAR(32) NOT NULL COMMENT '通知の種類',
+    `task_id` BIGINT UNSIGNED NULL COMMENT '関連するタスクの識別子',
+    `message` VARCHAR(255) NOT NULL COMMENT '通知の本文',
+    `read_at` DATETIME(6) NULL COMMENT '既読にした日時。NULLなら未読',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`id`),
+    KEY `user_read_at` (`user_id`, `read_at`) USING BTREE,
+    CONSTRAINT `fk_notifications_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_notifications_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='アプリ内通知';
+
+create table `notification_preferences` (
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `type` VARCHAR(32) NOT NULL COMMENT '通知の種類',
+    `enabled` BOOLEAN NOT NULL COMMENT '通知を受け取るか',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`user_id`, `type`),
+    CONSTRAINT `fk_notification_preferences_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='通知の種類ごとの受け取り設定。行がない種類は受け取る';
+
+create table `reminder_offsets` (
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `offset_minutes` INT UNSIGNED NOT NULL COMMENT '期限の何分前に通知するか',
+    PRIMARY KEY (`user_id`, `offset_minutes`),
+    CONSTRAINT `fk_reminder_offsets_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ユーザーが選んだ期限の通知のタイミング';
+
+create table `task_reminders` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '通知の予定の識別子',
+    `task_id` BIGINT UNSIGNED NOT NULL COMMENT 'タスクの識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '通知を受け取るユーザーの識別子',
+    `offset_minutes` INT UNSIGNED NOT NULL COMMENT '期限の何分前に通知するか',
+    `remind_at` DATETIME(6) NOT NULL COMMENT '通知する日時',
+    `sent_at` DATETIME(6) NULL COMMENT '通知した日時。NULLなら未送信',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `task_user_offset_remind_at` (`task_id`, `user_id`, `offset_minutes`, `remind_at`),
+    KEY `sent_at_remind_at` (`sent_at`, `remind_at`) USING BTREE,
+    CONSTRAINT `fk_task_reminders_task_id`
+        FOREIGN KEY (`task_id`) REFERENCES `tasks` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_task_reminders_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='タスクの期限の通知の予定。期限や担当者が変わると新しい行が作られ、古い行は送られない';
+
+create table `saved_searches` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '保存した検索の識別子',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `workspace_id` BIGINT UNSIGNED NOT NULL COMMENT 'ワークスペースの識別子',
+    `name` VARCHAR(64) NOT NULL COMMENT '検索の名前',
+    `definition` JSON NOT NULL COMMENT 'バージョン付きの検索条件',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `user_workspace_name` (`user_id`, `workspace_id`, `name`) USING BTREE,
+    CONSTRAINT `fk_saved_searches_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT,
+    CONSTRAINT `fk_saved_searches_workspace_id`
+        FOREIGN KEY (`workspace_id`) REFERENCES `workspaces` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='名前を付けて保存したタスクの検索条件';
+
+create table `user_identities` (
+    `issuer` VARCHAR(255) NOT NULL COMMENT 'IDプロバイダーのiss',
+    `subject` VARCHAR(255) NOT NULL COMMENT 'IDプロバイダーのsub',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`issuer`, `subject`),
+    KEY `user_id` (`user_id`),
+    CONSTRAINT `fk_user_identities_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='外部のIDプロバイダーでログインするユーザーの対応';
+
+create table `user_mfa` (
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `secret` VARCHAR(64) NOT NULL COMMENT 'TOTPのシークレット(Base32)',
+    `confirmed_at` DATETIME(6) NULL COMMENT '登録を確認した日時。NULLなら登録の途中',
+    `last_used_step` BIGINT NOT NULL DEFAULT 0 COMMENT '最後に受け付けたコードのタイムステップ',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    `modified_at` DATETIME(6) NOT NULL COMMENT '更新日時',
+    PRIMARY KEY (`user_id`),
+    CONSTRAINT `fk_user_mfa_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ユーザーのTOTPの2要素認証';
+
+create table `mfa_recovery_codes` (
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT 'ユーザーの識別子',
+    `code_hash` CHAR(64) NOT NULL COMMENT 'リカバリーコードのSHA-256',
+    `used_at` DATETIME(6) NULL COMMENT '使った日時。NULLなら未使用',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`user_id`, `code_hash`),
+    CONSTRAINT `fk_mfa_recovery_codes_user_id`
+        FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE RESTRICT
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='2要素認証の使い捨てのリカバリーコード';
+
+create table `mfa_required_roles` (
+    `role` VARCHAR(80) NOT NULL COMMENT '2要素認証を必須にするロール',
+    `created_at` DATETIME(6) NOT NULL COMMENT '作成日時',
+    PRIMARY KEY (`role`)
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='2要素認証を必須にするロール';
+
+create table `login_lockouts` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ロックの識別子',
+    `scope` VARCHAR(16) NOT NULL COMMENT 'ロックした単位。userかip',
+    `subject` VARCHAR(255) NOT NULL COMMENT 'ロックしたユーザー名かIPアドレス',
+    `failures` INT NOT NULL COMMENT 'ロックしたときの直近の失敗の回数',
+    `locked_until` DATETIME(6) NOT NULL COMMENT 'ロックが解ける日時',
+    `created_at` DATETIME(6) NOT NULL COMMENT 'ロックした日時',
+    PRIMARY KEY (`id`),
+    KEY `scope_subject` (`scope`, `subject`, `created_at`) USING BTREE
+) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ログインの総当たりでロックした記録';
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/zakisanbaiman/go-handson01/clock"
@@ -22,32 +21,56 @@ var rawPriKey []byte
 var rawPubKey []byte
 
 type JWTer struct {
-	PrivateKey, PublicKey jwk.Key
-	Store                 Store
-	Clocker               clock.Clocker
+	// Keys は署名と検証に使う鍵。NewJWTerは埋め込みの鍵を設定する
+	Keys    *KeySet
+	Store   Store
+	Clocker clock.Clocker
+	// AccessTokenLifetime はアクセストークンの有効期間
+	AccessTokenLifetime time.Duration
+	// IdleTimeout はトークンが使われないまま経つと失効するまでの時間。使われるたびに延長する。0なら無効
+	IdleTimeout time.Duration
+	// Issuer と Audience はトークンのissとaudに設定し、検証でも一致を確かめる
+	Issuer   string
+	Audience string
+	// ClockSkew はexpとnbf、iatの検証で許容する時計のずれ
+	ClockSkew time.Duration
 }
 
+// NewJWTerで設定する値
+const (
+	DefaultAccessTokenLifetime = 30 * time.Minute
+	DefaultIssuer              = "go-handson01"
+	DefaultAudience            = "go-handson01-api"
+)
+
 // ここからは新たにJWTを発行〜保存
 
 //go:generate go run github.com/matryer/moq -out moq_test.go . Store
 type Store interface {
-	Save(ctx context.Context, key string, userID entity.UserID) error
+	// Save はトークンをttlの間保存する
+	Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
 	Load(ctx context.Context, key string) (entity.UserID, error)
+	// Touch は保存したトークンの有効期間をttlに延ばす
+	Touch(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+	// Delete はトークンを1つ削除する
+	Delete(ctx context.Context, key string) error
+	// DeleteByUser はユーザーのトークンをすべて削除する
+	DeleteByUser(ctx context.Context, userID entity.UserID) error
 }
 
 func NewJWTer(s Store, c clock.Clocker) (*JWTer, error) {
-	j := &JWTer{Store: s}
-	privateKey, err := parse(rawPriKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	j := &JWTer{
+		Store:               s,
+		AccessTokenLifetime: DefaultAccessTokenLifetime,
+		Issuer:              DefaultIssuer,
+		Audience:            DefaultAudience,
 	}
-	publicKey, err := parse(rawPubKey)
+	keys, err := defaultKeySet()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse public key: %w", err)
+		return nil, fmt.Errorf("failed to load keys: %w", err)
 	}
 
-	j.PrivateKey = privateKey
-	j.PublicKey = publicKey
+	j.Keys = keys
 	j.Clocker = c
 
 	return j, nil
@@ -67,50 +90,123 @@ const (
 )
 
 func (j *JWTer) GenerateToken(ctx context.Context, user entity.User) ([]byte, error) {
+	now := j.Clocker.Now()
 	tok, err := jwt.NewBuilder().
 		JwtID(uuid.New().String()).
-		Issuer("access_token").
-		IssuedAt(j.Clocker.Now()).
-		Expiration(j.Clocker.Now().Add(30*time.Minute)).
+		Issuer(j.Issuer).
+		Audience([]string{j.Audience}).
+		IssuedAt(now).
+		NotBefore(now).
+		Expiration(now.Add(j.AccessTokenLifetime)).
 		Claim(RoleKey, user.Role).
 		Claim(UserNameKey, user.Name).
 		Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build token: %w", err)
 	}
-	if err := j.Store.Save(ctx, tok.JwtID(), user.ID); err != nil {
+	if err := j.Store.Save(ctx, tok.JwtID(), user.ID, j.sessionTTL(tok.Expiration())); err != nil {
 		return nil, fmt.Errorf("failed to save token: %w", err)
 	}
 
-	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, j.PrivateKey))
+	signed, err := j.Keys.sign(tok)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign token: %w", err)
 	}
 	return signed, nil
 }
 
+// minSessionTTL はセッションを保存しておく最短の期間。0以下の期間でPEXPIREすると、KVSがセッションを消してしまう
+const minSessionTTL = time.Second
+
+// sessionTTL はトークンをKVSに保存しておく期間。ClockSkewの分だけ過ぎたexpまで検証を通るので、そこまで保存する。
+// IdleTimeoutがあればそれより長くせず、minSessionTTLより短くしない
+func (j *JWTer) sessionTTL(exp time.Time) time.Duration {
+	ttl := exp.Add(j.ClockSkew).Sub(j.Clocker.Now())
+	if j.IdleTimeout > 0 && j.IdleTimeout < ttl {
+		ttl = j.IdleTimeout
+	}
+	if ttl < minSessionTTL {
+		ttl = minSessionTTL
+	}
+	return ttl
+}
+
+// PublicKeys は検証に使う公開鍵の集合。/.well-known/jwks.jsonで公開する
+func (j *JWTer) PublicKeys() jwk.Set {
+	return j.Keys.PublicKeys()
+}
+
+// RevokeToken はトークンを失効させる。失効したトークンはGetTokenで検証できなくなる
+func (j *JWTer) RevokeToken(ctx context.Context, jti string) error {
+	if err := j.Store.Delete(ctx, jti); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens はユーザーに発行したトークンをすべて失効させる
+func (j *JWTer) RevokeUserTokens(ctx context.Context, userID entity.UserID) error {
+	if err := j.Store.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete tokens: %w", err)
+	}
+	return nil
+}
+
 // ここからはJWTを取得
 
 func (j *JWTer) GetToken(ctx context.Context, r *http.Request) (jwt.Token, error) {
 	token, err := jwt.ParseRequest(
 		r,
-		jwt.WithKey(jwa.RS256, j.PublicKey),
+		j.Keys.verifyOption(),
+		jwt.WithValidate(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	return j.verify(ctx, token)
+}
+
+// ParseToken はHTTPのリクエスト以外で受け取ったトークンを検証する
+func (j *JWTer) ParseToken(ctx context.Context, raw string) (jwt.Token, error) {
+	token, err := jwt.ParseString(
+		raw,
+		j.Keys.verifyOption(),
 		jwt.WithValidate(false),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
-	if err := jwt.Validate(token, jwt.WithClock(j.Clocker)); err != nil {
+	return j.verify(ctx, token)
+}
+
+func (j *JWTer) verify(ctx context.Context, token jwt.Token) (jwt.Token, error) {
+	err := jwt.Validate(token,
+		jwt.WithClock(j.Clocker),
+		jwt.WithAcceptableSkew(j.ClockSkew),
+		jwt.WithIssuer(j.Issuer),
+		jwt.WithAudience(j.Audience),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithRequiredClaim(jwt.NotBeforeKey),
+	)
+	if err != nil {
 		return nil, fmt.Errorf("failed to validate token: %w", err)
 	}
-	if _, err := j.Store.Load(ctx, token.JwtID()); err != nil {
+	uid, err := j.Store.Load(ctx, token.JwtID())
+	if err != nil {
 		return nil, fmt.Errorf("failed to load token: %w", err)
 	}
+	// 使われたトークンはアイドルタイムアウトを延長する
+	if j.IdleTimeout > 0 {
+		if err := j.Store.Touch(ctx, token.JwtID(), uid, j.sessionTTL(token.Expiration())); err != nil {
+			return nil, fmt.Errorf("failed to touch token: %w", err)
+		}
+	}
 	return token, nil
 }
 
 type userIDKey struct{}
 type roleKey struct{}
+type tokenIDKey struct{}
 
 func SetUserID(ctx context.Context, uid entity.UserID) context.Context {
 	return context.WithValue(ctx, userIDKey{}, uid)
@@ -121,6 +217,16 @@ func GetUserID(ctx context.Context) (entity.UserID, bool) {
 	return id, ok
 }
 
+// SetTokenID はリクエストの認証に使ったトークンのjtiを設定する
+func SetTokenID(ctx context.Context, jti string) context.Context {
+	return context.WithValue(ctx, tokenIDKey{}, jti)
+}
+
+func GetTokenID(ctx context.Context) (string, bool) {
+	jti, ok := ctx.Value(tokenIDKey{}).(string)
+	return jti, ok
+}
+
 func SetRole(ctx context.Context, tok jwt.Token) context.Context {
 	get, ok := tok.Get(RoleKey)
 	if !ok {
@@ -139,15 +245,25 @@ func (j *JWTer) FillContext(r *http.Request) (*http.Request, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get token: %w", err)
 	}
-	uid, err := j.Store.Load(r.Context(), token.JwtID())
+	ctx, err := j.WithToken(r.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+	clone := r.Clone(ctx)
+	return clone, nil
+}
+
+// WithToken は検証済みのトークンのユーザーIDとjti、ロールをコンテキストに設定する
+func (j *JWTer) WithToken(ctx context.Context, token jwt.Token) (context.Context, error) {
+	uid, err := j.Store.Load(ctx, token.JwtID())
 	if err != nil {
 		return nil, fmt.Errorf("failed to load token: %w", err)
 	}
-	ctx := SetUserID(r.Context(), uid)
+	ctx = SetUserID(ctx, uid)
+	ctx = SetTokenID(ctx, token.JwtID())
 
 	ctx = SetRole(ctx, token)
-	clone := r.Clone(ctx)
-	return clone, nil
+	return ctx, nil
 }
 
 func IsAdmin(ctx context.Context) bool {
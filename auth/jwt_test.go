@@ -45,10 +45,14 @@ func TestJWTer_GenerateToken(t *testing.T) {
 	user := fixture.User(func(u *entity.User) {
 		u.ID = wantID
 	})
-	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID) error {
+	moq.SaveFunc = func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 		if userID != wantID {
 			t.Errorf("want %d, but got %d", wantID, userID)
 		}
+		// KVSのトークンはexpと同時に消える
+		if ttl <= 29*time.Minute || ttl > 30*time.Minute {
+			t.Errorf("want ttl about 30m, but got %v", ttl)
+		}
 		return nil
 	}
 	sut, err := NewJWTer(moq, clock.RealClocker{})
@@ -76,9 +80,11 @@ func TestJWTer_GetToken(t *testing.T) {
 	// payload作成
 	want, err := jwt.NewBuilder().
 		JwtID(uuid.New().String()).
-		Issuer("zakisanbaiman").
+		Issuer(DefaultIssuer).
+		Audience([]string{DefaultAudience}).
 		Subject("access_token").
 		IssuedAt(c.Now()).
+		NotBefore(c.Now()).
 		Expiration(c.Now().Add(30*time.Minute)).
 		Claim(RoleKey, "test").
 		Claim(UserNameKey, "test_user").
@@ -91,6 +97,14 @@ func TestJWTer_GetToken(t *testing.T) {
 	if err != nil {
 		t.Fatalf("want no error, but got %v", err)
 	}
+	// 埋め込みの鍵のkidはthumbprint
+	kid, err := thumbprint(pkey)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if err := pkey.Set(jwk.KeyIDKey, kid); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
 
 	// 署名
 	signed, err := jwt.Sign(want, jwt.WithKey(jwa.RS256, pkey))
@@ -124,3 +138,221 @@ func TestJWTer_GetToken(t *testing.T) {
 		t.Fatalf("want not nil, but got nil")
 	}
 }
+
+func TestJWTer_IdleTimeout(t *testing.T) {
+	if os.Getenv("CI") != "" {
+		t.Skip("Skipping JWT test in CI environment")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+	c := clock.NewManualClocker(time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC))
+	var ttls []time.Duration
+	moq := &StoreMock{
+		SaveFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+			ttls = append(ttls, ttl)
+			return nil
+		},
+		LoadFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+			return 20, nil
+		},
+		TouchFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+			if userID != 20 {
+				t.Errorf("want user 20, but got %d", userID)
+			}
+			ttls = append(ttls, ttl)
+			return nil
+		},
+	}
+	sut, err := NewJWTer(moq, c)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	sut.IdleTimeout = 10 * time.Minute
+
+	signed, err := sut.GenerateToken(ctx, entity.User{ID: 20, Name: "alice", Role: "user"})
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	c.Advance(5 * time.Minute)
+	if _, err := sut.ParseToken(ctx, string(signed)); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// expまで5分しかなければ、アイドルタイムアウトより短くする
+	c.Advance(20 * time.Minute)
+	if _, err := sut.ParseToken(ctx, string(signed)); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	// expを過ぎてもClockSkewの間は検証を通るので、その分も残す
+	sut.ClockSkew = 30 * time.Second
+	c.Advance(5*time.Minute + 10*time.Second)
+	if _, err := sut.ParseToken(ctx, string(signed)); err != nil {
+		t.Fatalf("want no error within the clock skew, but got %v", err)
+	}
+	// 残りがごくわずかでも、0以下にしてセッションを消さない
+	c.Advance(20*time.Second - time.Millisecond)
+	if _, err := sut.ParseToken(ctx, string(signed)); err != nil {
+		t.Fatalf("want no error within the clock skew, but got %v", err)
+	}
+
+	want := []time.Duration{10 * time.Minute, 10 * time.Minute, 5 * time.Minute, 20 * time.Second, minSessionTTL}
+	if len(ttls) != len(want) {
+		t.Fatalf("want ttls %v, but got %v", want, ttls)
+	}
+	for i := range want {
+		if ttls[i] != want[i] {
+			t.Errorf("want ttls %v, but got %v", want, ttls)
+			break
+		}
+	}
+}
+
+func TestJWTer_ParseToken_Rejections(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC)
+	sut, err := NewJWTer(&StoreMock{
+		LoadFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+			if key == "revoked" {
+				return 0, fmt.Errorf("not found")
+			}
+			return 1, nil
+		},
+	}, clock.NewManualClocker(now))
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	sut.Issuer = "https://todo.example.com"
+	sut.Audience = "todo-api"
+	sut.ClockSkew = 30 * time.Second
+
+	otherKey, err := jwk.FromRaw([]byte("a-symmetric-secret-that-is-long-enough"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := otherKey.Set(jwk.KeyIDKey, sut.Keys.ActiveKeyID()); err != nil {
+		t.Fatal(err)
+	}
+	unknownKey, err := parse(rawPriKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unknownKey.Set(jwk.KeyIDKey, "unknown"); err != nil {
+		t.Fatal(err)
+	}
+	noKidKey, err := parse(rawPriKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		// modify は有効なトークンのクレームを書き換える
+		modify func(tok jwt.Token)
+		// sign を指定すると有効な鍵の代わりに使う
+		sign    func(tok jwt.Token) ([]byte, error)
+		wantErr bool
+	}{
+		"ok": {},
+		"nbfWithinSkew": {
+			modify: func(tok jwt.Token) { _ = tok.Set(jwt.NotBeforeKey, now.Add(20*time.Second)) },
+		},
+		"expWithinSkew": {
+			modify: func(tok jwt.Token) { _ = tok.Set(jwt.ExpirationKey, now.Add(-20*time.Second)) },
+		},
+		"expired": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.ExpirationKey, now.Add(-time.Minute)) },
+			wantErr: true,
+		},
+		"notYetValid": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.NotBeforeKey, now.Add(time.Minute)) },
+			wantErr: true,
+		},
+		"issuedInFuture": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.IssuedAtKey, now.Add(time.Minute)) },
+			wantErr: true,
+		},
+		"noExp": {
+			modify:  func(tok jwt.Token) { _ = tok.Remove(jwt.ExpirationKey) },
+			wantErr: true,
+		},
+		"noNbf": {
+			modify:  func(tok jwt.Token) { _ = tok.Remove(jwt.NotBeforeKey) },
+			wantErr: true,
+		},
+		"wrongIssuer": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.IssuerKey, "access_token") },
+			wantErr: true,
+		},
+		"noIssuer": {
+			modify:  func(tok jwt.Token) { _ = tok.Remove(jwt.IssuerKey) },
+			wantErr: true,
+		},
+		"wrongAudience": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.AudienceKey, []string{"other-api"}) },
+			wantErr: true,
+		},
+		"noAudience": {
+			modify:  func(tok jwt.Token) { _ = tok.Remove(jwt.AudienceKey) },
+			wantErr: true,
+		},
+		"revoked": {
+			modify:  func(tok jwt.Token) { _ = tok.Set(jwt.JwtIDKey, "revoked") },
+			wantErr: true,
+		},
+		"wrongAlgorithm": {
+			sign:    func(tok jwt.Token) ([]byte, error) { return jwt.Sign(tok, jwt.WithKey(jwa.HS256, otherKey)) },
+			wantErr: true,
+		},
+		"unsigned": {
+			sign:    func(tok jwt.Token) ([]byte, error) { return jwt.Sign(tok, jwt.WithInsecureNoSignature()) },
+			wantErr: true,
+		},
+		"unknownKid": {
+			sign:    func(tok jwt.Token) ([]byte, error) { return jwt.Sign(tok, jwt.WithKey(jwa.RS256, unknownKey)) },
+			wantErr: true,
+		},
+		"noKid": {
+			sign:    func(tok jwt.Token) ([]byte, error) { return jwt.Sign(tok, jwt.WithKey(jwa.RS256, noKidKey)) },
+			wantErr: true,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			tok, err := jwt.NewBuilder().
+				JwtID(uuid.New().String()).
+				Issuer(sut.Issuer).
+				Audience([]string{sut.Audience}).
+				IssuedAt(now).
+				NotBefore(now).
+				Expiration(now.Add(30 * time.Minute)).
+				Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.modify != nil {
+				tt.modify(tok)
+			}
+			sign := sut.Keys.sign
+			if tt.sign != nil {
+				sign = tt.sign
+			}
+			signed, err := sign(tok)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = sut.ParseToken(context.Background(), string(signed))
+			if tt.wantErr && err == nil {
+				t.Errorf("want error, but got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("want no error, but got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// ManifestFile は鍵のディレクトリで、有効な鍵と廃止した鍵を記録するファイル
+const ManifestFile = "keyset.json"
+
+// manifest はManifestFileの内容。鍵はディレクトリの<kid>.pemに置く
+type manifest struct {
+	Active  string   `json:"active"`
+	Retired []string `json:"retired,omitempty"`
+}
+
+// KeySet はトークンの署名と検証に使う鍵の集合。
+// 署名には有効な鍵を使ってkidヘッダを付け、検証には廃止していないすべての鍵を使う。
+type KeySet struct {
+	active jwk.Key
+	alg    jwa.SignatureAlgorithm
+	public jwk.Set
+}
+
+// LoadKeySet はpathから鍵を読み込む。
+// pathがディレクトリならManifestFileと<kid>.pemを、ファイルならPEMの秘密鍵1つを読み込む
+func LoadKeySet(path string) (*KeySet, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat key path: %w", err)
+	}
+	if !fi.IsDir() {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %w", err)
+		}
+		return singleKeySet(raw)
+	}
+
+	m, err := readManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(path, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	keys := map[string]jwk.Key{}
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %w", err)
+		}
+		key, err := parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		keys[strings.TrimSuffix(filepath.Base(f), ".pem")] = key
+	}
+	return newKeySet(keys, m)
+}
+
+// defaultKeySet は埋め込みの鍵だけの鍵の集合
+func defaultKeySet() (*KeySet, error) {
+	return singleKeySet(rawPriKey)
+}
+
+// singleKeySet はPEMの秘密鍵1つだけの鍵の集合を作る。kidは鍵のthumbprint
+func singleKeySet(raw []byte) (*KeySet, error) {
+	key, err := parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := thumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	return newKeySet(map[string]jwk.Key{kid: key}, &manifest{Active: kid})
+}
+
+func newKeySet(keys map[string]jwk.Key, m *manifest) (*KeySet, error) {
+	if _, ok := keys[m.Active]; !ok {
+		return nil, fmt.Errorf("active key %q not found", m.Active)
+	}
+	if slices.Contains(m.Retired, m.Active) {
+		return nil, fmt.Errorf("active key %q is retired", m.Active)
+	}
+	ks := &KeySet{public: jwk.NewSet()}
+	for kid, key := range keys {
+		alg, err := algorithmFor(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+		if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+			return nil, err
+		}
+		if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+			return nil, err
+		}
+		if kid == m.Active {
+			ks.active, ks.alg = key, alg
+		}
+		if slices.Contains(m.Retired, kid) {
+			continue
+		}
+		pub, err := key.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: failed to get public key: %w", kid, err)
+		}
+		if err := pub.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+			return nil, err
+		}
+		if err := ks.public.AddKey(pub); err != nil {
+			return nil, fmt.Errorf("key %q: %w", kid, err)
+		}
+	}
+	return ks, nil
+}
+
+// ParseAlgorithm は設定の文字列を署名のアルゴリズムにする。RS256、ES256、EdDSAに対応する
+func ParseAlgorithm(s string) (jwa.SignatureAlgorithm, error) {
+	switch alg := jwa.SignatureAlgorithm(s); alg {
+	case jwa.RS256, jwa.ES256, jwa.EdDSA:
+		return alg, nil
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q", s)
+	}
+}
+
+// algorithmFor は鍵の種類から署名のアルゴリズムを決める
+func algorithmFor(key jwk.Key) (jwa.SignatureAlgorithm, error) {
+	switch key.KeyType() {
+	case jwa.RSA:
+		return jwa.RS256, nil
+	case jwa.EC:
+		if crv, _ := key.Get(jwk.ECDSACrvKey); crv == jwa.P256 {
+			return jwa.ES256, nil
+		}
+	case jwa.OKP:
+		if crv, _ := key.Get(jwk.OKPCrvKey); crv == jwa.Ed25519 {
+			return jwa.EdDSA, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported key type %s", key.KeyType())
+}
+
+// generateKey はalgで署名する新しい秘密鍵を作る
+func generateKey(alg jwa.SignatureAlgorithm) (any, error) {
+	switch alg {
+	case jwa.RS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case jwa.ES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwa.EdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+// ActiveKeyID は署名に使う鍵のkid
+func (ks *KeySet) ActiveKeyID() string {
+	return ks.active.KeyID()
+}
+
+// Algorithm は署名に使うアルゴリズム。有効な鍵の種類で決まる
+func (ks *KeySet) Algorithm() jwa.SignatureAlgorithm {
+	return ks.alg
+}
+
+// PublicKeys は検証に使う公開鍵の集合。JWKSとして公開できる
+func (ks *KeySet) PublicKeys() jwk.Set {
+	return ks.public
+}
+
+// sign は有効な鍵でトークンに署名する。鍵のkidがヘッダに入る
+func (ks *KeySet) sign(tok jwt.Token) ([]byte, error) {
+	return jwt.Sign(tok, jwt.WithKey(ks.Algorithm(), ks.active))
+}
+
+// verifyOption は廃止していない鍵のうち、kidが一致する鍵で検証するオプション
+func (ks *KeySet) verifyOption() jwt.ParseOption {
+	return jwt.WithKeySet(ks.public, jws.WithRequireKid(true))
+}
+
+func readManifest(dir string) (*manifest, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key manifest: %w", err)
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(raw, m); err != nil {
+		return nil, fmt.Errorf("failed to decode key manifest: %w", err)
+	}
+	return m, nil
+}
+
+func writeManifest(dir string, m *manifest) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 書きかけのファイルを読まれないよう、別名で書いてから置き換える
+	tmp := filepath.Join(dir, ManifestFile+".tmp")
+	if err := os.WriteFile(tmp, append(raw, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write key manifest: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(dir, ManifestFile))
+}
+
+// RotateKey はdirにalgで署名する新しい鍵を作り、有効な鍵にしてそのkidを返す。
+// それまでの鍵は廃止しないので、発行済みのトークンは期限まで検証できる
+func RotateKey(dir string, alg jwa.SignatureAlgorithm) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create key directory: %w", err)
+	}
+	m, err := readManifest(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		m = &manifest{}
+	}
+
+	raw, err := generateKey(alg)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to create jwk: %w", err)
+	}
+	kid, err := thumbprint(key)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal key: %w", err)
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pemBytes, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write key: %w", err)
+	}
+
+	m.Active = kid
+	if err := writeManifest(dir, m); err != nil {
+		return "", err
+	}
+	return kid, nil
+}
+
+// RetireKey はdirの鍵を廃止する。廃止した鍵で署名したトークンは検証できなくなる
+func RetireKey(dir, kid string) error {
+	m, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	if kid == m.Active {
+		return fmt.Errorf("cannot retire the active key %q", kid)
+	}
+	if _, err := os.Stat(filepath.Join(dir, kid+".pem")); err != nil {
+		return fmt.Errorf("key %q not found: %w", kid, err)
+	}
+	if slices.Contains(m.Retired, kid) {
+		return nil
+	}
+	m.Retired = append(m.Retired, kid)
+	return writeManifest(dir, m)
+}
+
+// thumbprint はRFC 7638のthumbprintをbase64urlにしたもの。鍵から決まるのでkidに使う
+func thumbprint(key jwk.Key) (string, error) {
+	tp, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute thumbprint: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(tp), nil
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// jwterWithKeys はdirの鍵で署名と検証をするJWTer
+func jwterWithKeys(t *testing.T, dir string) *JWTer {
+	t.Helper()
+
+	moq := &StoreMock{
+		SaveFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+			return nil
+		},
+		LoadFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+			return 1, nil
+		},
+	}
+	sut, err := NewJWTer(moq, clock.RealClocker{})
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	keys, err := LoadKeySet(dir)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	sut.Keys = keys
+	return sut
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	user := entity.User{ID: 1, Name: "alice", Role: "user"}
+
+	first, err := RotateKey(dir, jwa.RS256)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	before := jwterWithKeys(t, dir)
+	old, err := before.GenerateToken(ctx, user)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	msg, err := jws.Parse(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := msg.Signatures()[0].ProtectedHeaders().KeyID(); kid != first {
+		t.Errorf("want kid %q, but got %q", first, kid)
+	}
+
+	second, err := RotateKey(dir, jwa.ES256)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if second == first {
+		t.Fatalf("want a new key, but got the same kid %q", second)
+	}
+	after := jwterWithKeys(t, dir)
+	if got := after.Keys.ActiveKeyID(); got != second {
+		t.Errorf("want active key %q, but got %q", second, got)
+	}
+	if got := after.PublicKeys().Len(); got != 2 {
+		t.Errorf("want 2 public keys, but got %d", got)
+	}
+	// ローテーション前のトークンも、鍵を廃止するまでは検証できる
+	if _, err := after.ParseToken(ctx, string(old)); err != nil {
+		t.Errorf("want token signed by the previous key to verify, but got %v", err)
+	}
+	current, err := after.GenerateToken(ctx, user)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	if err := RetireKey(dir, second); err == nil {
+		t.Errorf("want error when retiring the active key")
+	}
+	if err := RetireKey(dir, first); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	retired := jwterWithKeys(t, dir)
+	if got := retired.PublicKeys().Len(); got != 1 {
+		t.Errorf("want 1 public key, but got %d", got)
+	}
+	if _, err := retired.ParseToken(ctx, string(old)); err == nil {
+		t.Errorf("want error for token signed by a retired key")
+	}
+	if _, err := retired.ParseToken(ctx, string(current)); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+}
+
+func TestKeySet_Algorithms(t *testing.T) {
+	t.Parallel()
+
+	for _, alg := range []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256, jwa.EdDSA} {
+		alg := alg
+		t.Run(alg.String(), func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			if _, err := RotateKey(dir, alg); err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			sut := jwterWithKeys(t, dir)
+			if got := sut.Keys.Algorithm(); got != alg {
+				t.Errorf("want algorithm %s, but got %s", alg, got)
+			}
+			signed, err := sut.GenerateToken(context.Background(), entity.User{ID: 1, Name: "alice", Role: "user"})
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			msg, err := jws.Parse(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := msg.Signatures()[0].ProtectedHeaders().Algorithm(); got != alg {
+				t.Errorf("want alg header %s, but got %s", alg, got)
+			}
+			if _, err := sut.ParseToken(context.Background(), string(signed)); err != nil {
+				t.Errorf("want no error, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"RS256", "ES256", "EdDSA"} {
+		if _, err := ParseAlgorithm(s); err != nil {
+			t.Errorf("%s: want no error, but got %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "HS256", "none", "rs256"} {
+		if _, err := ParseAlgorithm(s); err == nil {
+			t.Errorf("%q: want error, but got nil", s)
+		}
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret.pem")
+	if err := os.WriteFile(file, rawPriKey, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := t.TempDir()
+	missingActive := t.TempDir()
+	if err := os.WriteFile(filepath.Join(missingActive, ManifestFile), []byte(`{"active":"unknown"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := defaultKeySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadKeySet(file)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got.ActiveKeyID() != want.ActiveKeyID() {
+		t.Errorf("want kid %q, but got %q", want.ActiveKeyID(), got.ActiveKeyID())
+	}
+
+	for n, path := range map[string]string{
+		"notExist":      filepath.Join(dir, "missing"),
+		"noManifest":    empty,
+		"missingActive": missingActive,
+	} {
+		if _, err := LoadKeySet(path); err == nil {
+			t.Errorf("%s: want error, but got nil", n)
+		}
+	}
+}
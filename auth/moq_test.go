@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"sync"
+	"time"
 )
 
 // Ensure, that StoreMock does implement Store.
@@ -19,12 +20,21 @@ var _ Store = &StoreMock{}
 //
 //		// make and configure a mocked Store
 //		mockedStore := &StoreMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			DeleteByUserFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the DeleteByUser method")
+//			},
 //			LoadFunc: func(ctx context.Context, key string) (entity.UserID, error) {
 //				panic("mock out the Load method")
 //			},
-//			SaveFunc: func(ctx context.Context, key string, userID entity.UserID) error {
+//			SaveFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 //				panic("mock out the Save method")
 //			},
+//			TouchFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+//				panic("mock out the Touch method")
+//			},
 //		}
 //
 //		// use mockedStore in code that requires Store
@@ -32,14 +42,37 @@ var _ Store = &StoreMock{}
 //
 //	}
 type StoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// DeleteByUserFunc mocks the DeleteByUser method.
+	DeleteByUserFunc func(ctx context.Context, userID entity.UserID) error
+
 	// LoadFunc mocks the Load method.
 	LoadFunc func(ctx context.Context, key string) (entity.UserID, error)
 
 	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, key string, userID entity.UserID) error
+	SaveFunc func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+
+	// TouchFunc mocks the Touch method.
+	TouchFunc func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// DeleteByUser holds details about calls to the DeleteByUser method.
+		DeleteByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
 		// Load holds details about calls to the Load method.
 		Load []struct {
 			// Ctx is the ctx argument value.
@@ -55,10 +88,98 @@ type StoreMock struct {
 			Key string
 			// UserID is the userID argument value.
 			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// Touch holds details about calls to the Touch method.
+		Touch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
 		}
 	}
-	lockLoad sync.RWMutex
-	lockSave sync.RWMutex
+	lockDelete       sync.RWMutex
+	lockDeleteByUser sync.RWMutex
+	lockLoad         sync.RWMutex
+	lockSave         sync.RWMutex
+	lockTouch        sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *StoreMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("StoreMock.DeleteFunc: method is nil but Store.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedStore.DeleteCalls())
+func (mock *StoreMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// DeleteByUser calls DeleteByUserFunc.
+func (mock *StoreMock) DeleteByUser(ctx context.Context, userID entity.UserID) error {
+	if mock.DeleteByUserFunc == nil {
+		panic("StoreMock.DeleteByUserFunc: method is nil but Store.DeleteByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteByUser.Lock()
+	mock.calls.DeleteByUser = append(mock.calls.DeleteByUser, callInfo)
+	mock.lockDeleteByUser.Unlock()
+	return mock.DeleteByUserFunc(ctx, userID)
+}
+
+// DeleteByUserCalls gets all the calls that were made to DeleteByUser.
+// Check the length with:
+//
+//	len(mockedStore.DeleteByUserCalls())
+func (mock *StoreMock) DeleteByUserCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockDeleteByUser.RLock()
+	calls = mock.calls.DeleteByUser
+	mock.lockDeleteByUser.RUnlock()
+	return calls
 }
 
 // Load calls LoadFunc.
@@ -98,7 +219,7 @@ func (mock *StoreMock) LoadCalls() []struct {
 }
 
 // Save calls SaveFunc.
-func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserID) error {
+func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
 	if mock.SaveFunc == nil {
 		panic("StoreMock.SaveFunc: method is nil but Store.Save was just called")
 	}
@@ -106,15 +227,17 @@ func (mock *StoreMock) Save(ctx context.Context, key string, userID entity.UserI
 		Ctx    context.Context
 		Key    string
 		UserID entity.UserID
+		TTL    time.Duration
 	}{
 		Ctx:    ctx,
 		Key:    key,
 		UserID: userID,
+		TTL:    ttl,
 	}
 	mock.lockSave.Lock()
 	mock.calls.Save = append(mock.calls.Save, callInfo)
 	mock.lockSave.Unlock()
-	return mock.SaveFunc(ctx, key, userID)
+	return mock.SaveFunc(ctx, key, userID, ttl)
 }
 
 // SaveCalls gets all the calls that were made to Save.
@@ -125,14 +248,60 @@ func (mock *StoreMock) SaveCalls() []struct {
 	Ctx    context.Context
 	Key    string
 	UserID entity.UserID
+	TTL    time.Duration
 } {
 	var calls []struct {
 		Ctx    context.Context
 		Key    string
 		UserID entity.UserID
+		TTL    time.Duration
 	}
 	mock.lockSave.RLock()
 	calls = mock.calls.Save
 	mock.lockSave.RUnlock()
 	return calls
 }
+
+// Touch calls TouchFunc.
+func (mock *StoreMock) Touch(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	if mock.TouchFunc == nil {
+		panic("StoreMock.TouchFunc: method is nil but Store.Touch was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		UserID: userID,
+		TTL:    ttl,
+	}
+	mock.lockTouch.Lock()
+	mock.calls.Touch = append(mock.calls.Touch, callInfo)
+	mock.lockTouch.Unlock()
+	return mock.TouchFunc(ctx, key, userID, ttl)
+}
+
+// TouchCalls gets all the calls that were made to Touch.
+// Check the length with:
+//
+//	len(mockedStore.TouchCalls())
+func (mock *StoreMock) TouchCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	UserID entity.UserID
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}
+	mock.lockTouch.RLock()
+	calls = mock.calls.Touch
+	mock.lockTouch.RUnlock()
+	return calls
+}
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+type workspaceIDKey struct{}
+type workspaceRoleKey struct{}
+
+func SetWorkspaceID(ctx context.Context, id entity.WorkspaceID) context.Context {
+	return context.WithValue(ctx, workspaceIDKey{}, id)
+}
+
+func GetWorkspaceID(ctx context.Context) (entity.WorkspaceID, bool) {
+	id, ok := ctx.Value(workspaceIDKey{}).(entity.WorkspaceID)
+	return id, ok
+}
+
+func SetWorkspaceRole(ctx context.Context, role entity.WorkspaceRole) context.Context {
+	return context.WithValue(ctx, workspaceRoleKey{}, role)
+}
+
+func GetWorkspaceRole(ctx context.Context) (entity.WorkspaceRole, bool) {
+	role, ok := ctx.Value(workspaceRoleKey{}).(entity.WorkspaceRole)
+	return role, ok
+}
@@ -1,6 +1,9 @@
 package clock
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type Clocker interface {
 	Now() time.Time
@@ -19,3 +22,33 @@ type FixedClocker struct {
 func (f FixedClocker) Now() time.Time {
 	return time.Date(2022, 5, 10, 12, 34, 56, 0, time.UTC)
 }
+
+// ManualClocker はテストから時刻を進められるClocker。複数のゴルーチンから使える
+type ManualClocker struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func NewManualClocker(t time.Time) *ManualClocker {
+	return &ManualClocker{t: t}
+}
+
+func (m *ManualClocker) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.t
+}
+
+// Set は現在時刻をtにする
+func (m *ManualClocker) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.t = t
+}
+
+// Advance は現在時刻をdだけ進める
+func (m *ManualClocker) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.t = m.t.Add(d)
+}
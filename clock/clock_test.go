@@ -114,3 +114,23 @@ func TestRealClocker_TimeProgression(t *testing.T) {
 		}
 	}
 }
+
+func TestManualClocker(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC)
+	clocker := NewManualClocker(start)
+	var _ Clocker = clocker
+
+	if got := clocker.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	clocker.Advance(90 * time.Minute)
+	if got, want := clocker.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+	clocker.Set(start)
+	if got := clocker.Now(); !got.Equal(start) {
+		t.Errorf("Now() after Set = %v, want %v", got, start)
+	}
+}
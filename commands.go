@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/config"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// cleanupSessions はTTLなしで保存された古いセッションのキーにTTLを設定する一回限りのコマンド。
+// TTLにはアクセストークンの有効期間を使う。どのトークンもそれより長くは有効でないので、ログイン中のユーザーは締め出さない
+func cleanupSessions(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("cleanup-sessions", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "count the keys without changing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	kvs, err := store.NewKVS(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	defer kvs.Cli.Close()
+
+	n, err := kvs.ExpirePersistentSessions(ctx, cfg.AccessTokenLifetime, *dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to expire sessions after %d keys: %w", n, err)
+	}
+	if *dryRun {
+		log.Printf("%d session keys have no ttl", n)
+		return nil
+	}
+	log.Printf("set ttl %v on %d session keys", cfg.AccessTokenLifetime, n)
+	return nil
+}
+
+// rotateKey は署名鍵のディレクトリに新しい鍵を作り、有効な鍵にする。
+// 前の鍵は廃止しないので、サーバーを再起動しても発行済みのトークンはそのまま使える
+func rotateKey(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("rotate-key", flag.ContinueOnError)
+	dir := fs.String("dir", cfg.JWTKeys, "directory of the signing keys")
+	algName := fs.String("alg", cfg.JWTAlgorithm, "signing algorithm of the new key (RS256, ES256 or EdDSA)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("key directory is required; set TODO_JWT_KEYS or -dir")
+	}
+	alg, err := auth.ParseAlgorithm(*algName)
+	if err != nil {
+		return err
+	}
+
+	kid, err := auth.RotateKey(*dir, alg)
+	if err != nil {
+		return err
+	}
+	log.Printf("created key %s and made it active; restart the servers to sign with it", kid)
+	return nil
+}
+
+// retireKey は署名鍵を廃止する。廃止した鍵で署名したトークンは、再起動後に検証できなくなる
+func retireKey(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("retire-key", flag.ContinueOnError)
+	dir := fs.String("dir", cfg.JWTKeys, "directory of the signing keys")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" || fs.NArg() != 1 {
+		return fmt.Errorf("usage: retire-key [-dir dir] <kid>")
+	}
+
+	if err := auth.RetireKey(*dir, fs.Arg(0)); err != nil {
+		return err
+	}
+	log.Printf("retired key %s", fs.Arg(0))
+	return nil
+}
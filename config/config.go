@@ -2,6 +2,7 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
@@ -9,6 +10,7 @@ import (
 type Config struct {
 	Env        string `env:"TODO_ENV" envDefault:"dev"`
 	Port       int    `env:"PORT" envDefault:"80"`
+	GRPCPort   int    `env:"TODO_GRPC_PORT" envDefault:"50051"`
 	DBHost     string `env:"TODO_DB_HOST" envDefault:"localhost"`
 	DBPort     int    `env:"TODO_DB_PORT" envDefault:"33306"`
 	DBUser     string `env:"TODO_DB_USER" envDefault:"todo"`
@@ -21,6 +23,70 @@ type Config struct {
 	CORSAllowedMethods string `env:"CORS_ALLOWED_METHODS" envDefault:"GET,POST,PUT,DELETE,OPTIONS"`
 	CORSAllowedHeaders string `env:"CORS_ALLOWED_HEADERS" envDefault:"Accept,Authorization,Content-Type,X-CSRF-Token,X-Requested-With"`
 	CORSMaxAge         int    `env:"CORS_MAX_AGE" envDefault:"86400"`
+	// メール送信設定。MailBackendがsmtpならSMTPサーバーへ送り、captureなら送らずに保持する
+	MailBackend    string `env:"TODO_MAIL_BACKEND" envDefault:"capture"`
+	MailFrom       string `env:"TODO_MAIL_FROM" envDefault:"todo@localhost"`
+	MailCaptureDir string `env:"TODO_MAIL_CAPTURE_DIR"`
+	SMTPHost       string `env:"TODO_SMTP_HOST" envDefault:"localhost"`
+	SMTPPort       int    `env:"TODO_SMTP_PORT" envDefault:"1025"`
+	SMTPUsername   string `env:"TODO_SMTP_USERNAME"`
+	SMTPPassword   string `env:"TODO_SMTP_PASSWORD"`
+	// ReminderInterval は期限の通知を確認する間隔
+	ReminderInterval time.Duration `env:"TODO_REMINDER_INTERVAL" envDefault:"1m"`
+	// GraphQLのクエリの深さとコストの上限
+	GraphQLMaxDepth      int `env:"TODO_GRAPHQL_MAX_DEPTH" envDefault:"8"`
+	GraphQLMaxComplexity int `env:"TODO_GRAPHQL_MAX_COMPLEXITY" envDefault:"1000"`
+	// ルート直下のパスは/v1の非推奨のエイリアス。非推奨になった日時と廃止する日時
+	LegacyRoutesDeprecatedAt time.Time `env:"TODO_LEGACY_ROUTES_DEPRECATED_AT" envDefault:"2026-10-19T00:00:00Z"`
+	LegacyRoutesSunset       time.Time `env:"TODO_LEGACY_ROUTES_SUNSET" envDefault:"2027-04-30T00:00:00Z"`
+	// アクセストークンとリフレッシュトークンの有効期間
+	AccessTokenLifetime  time.Duration `env:"TODO_ACCESS_TOKEN_LIFETIME" envDefault:"30m"`
+	RefreshTokenLifetime time.Duration `env:"TODO_REFRESH_TOKEN_LIFETIME" envDefault:"720h"`
+	// JWTKeys はトークンの署名に使う鍵のディレクトリかPEMファイル。空なら埋め込みの鍵を使う
+	JWTKeys string `env:"TODO_JWT_KEYS"`
+	// JWTAlgorithm はトークンの署名のアルゴリズム。RS256、ES256、EdDSAのいずれかで、有効な鍵の種類と一致させる
+	JWTAlgorithm string `env:"TODO_JWT_ALGORITHM" envDefault:"RS256"`
+	// JWTIssuer と JWTAudience はトークンのissとaud。検証でも一致を確かめる
+	JWTIssuer   string `env:"TODO_JWT_ISSUER" envDefault:"go-handson01"`
+	JWTAudience string `env:"TODO_JWT_AUDIENCE" envDefault:"go-handson01-api"`
+	// JWTClockSkew はトークンのexpとnbfの検証で許容する時計のずれ
+	JWTClockSkew time.Duration `env:"TODO_JWT_CLOCK_SKEW" envDefault:"30s"`
+	// SessionIdleTimeout はアクセストークンが使われないまま経つと失効するまでの時間。0なら無効
+	SessionIdleTimeout time.Duration `env:"TODO_SESSION_IDLE_TIMEOUT" envDefault:"15m"`
+	// OIDCIssuer は外部のIDプロバイダーのissuer。空ならOpenID Connectでのログインを無効にする
+	OIDCIssuer       string `env:"TODO_OIDC_ISSUER"`
+	OIDCClientID     string `env:"TODO_OIDC_CLIENT_ID"`
+	OIDCClientSecret string `env:"TODO_OIDC_CLIENT_SECRET"`
+	// OIDCRedirectURL はIDプロバイダーに登録した/v1/auth/oidc/callbackのURL
+	OIDCRedirectURL string `env:"TODO_OIDC_REDIRECT_URL"`
+	// OIDCScopes はスペース区切りで要求するスコープ
+	OIDCScopes string `env:"TODO_OIDC_SCOPES" envDefault:"openid profile email"`
+	// OIDCStateTTL はログインを始めてからコールバックまでの制限時間
+	OIDCStateTTL time.Duration `env:"TODO_OIDC_STATE_TTL" envDefault:"10m"`
+	// MFAIssuer は認証アプリに表示するサービスの名前
+	MFAIssuer string `env:"TODO_MFA_ISSUER" envDefault:"go-handson01"`
+	// MFAChallengeTTL はパスワードを確かめてから2要素認証を終えるまでの制限時間
+	MFAChallengeTTL time.Duration `env:"TODO_MFA_CHALLENGE_TTL" envDefault:"5m"`
+	// PasswordResetURL はパスワードの再設定のメールに載せる画面のURL。tokenのクエリを付けて送る
+	PasswordResetURL string `env:"TODO_PASSWORD_RESET_URL" envDefault:"http://localhost/password/reset"`
+	// PasswordResetTTL は再設定トークンの有効期間
+	PasswordResetTTL time.Duration `env:"TODO_PASSWORD_RESET_TTL" envDefault:"30m"`
+	// PasswordForgotWindow の間に、同じIPアドレスとメールアドレスから再設定を求められる回数
+	PasswordForgotWindow     time.Duration `env:"TODO_PASSWORD_FORGOT_WINDOW" envDefault:"1h"`
+	PasswordForgotIPLimit    int64         `env:"TODO_PASSWORD_FORGOT_IP_LIMIT" envDefault:"10"`
+	PasswordForgotEmailLimit int64         `env:"TODO_PASSWORD_FORGOT_EMAIL_LIMIT" envDefault:"3"`
+	// LoginFailureWindow の間のパスワードの失敗を、ユーザー名とIPアドレスごとに数える
+	LoginFailureWindow time.Duration `env:"TODO_LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	// LoginDelayAfter 回を超えて失敗したユーザー名は、LoginBaseDelayから失敗のたびに倍にした時間、LoginMaxDelayまで待たないと試せない
+	LoginDelayAfter int64         `env:"TODO_LOGIN_DELAY_AFTER" envDefault:"3"`
+	LoginBaseDelay  time.Duration `env:"TODO_LOGIN_BASE_DELAY" envDefault:"1s"`
+	LoginMaxDelay   time.Duration `env:"TODO_LOGIN_MAX_DELAY" envDefault:"30s"`
+	// LoginUserLockout 回失敗したユーザー名と、LoginIPLockout 回失敗したIPアドレスは、LoginLockoutDuration の間ログインできない
+	LoginUserLockout     int64         `env:"TODO_LOGIN_USER_LOCKOUT" envDefault:"10"`
+	LoginIPLockout       int64         `env:"TODO_LOGIN_IP_LOCKOUT" envDefault:"100"`
+	LoginLockoutDuration time.Duration `env:"TODO_LOGIN_LOCKOUT_DURATION" envDefault:"15m"`
+	// GRPCWatchInterval はgRPCのWatchTasksがタスクの変更を確認する間隔
+	GRPCWatchInterval time.Duration `env:"TODO_GRPC_WATCH_INTERVAL" envDefault:"5s"`
 }
 
 func New() (*Config, error) {
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type ColumnID int64
+
+// Column はユーザーが定義するカンバンの列。各列は基本ステータスのいずれかに対応する
+type Column struct {
+	ID       ColumnID   `json:"id" db:"id"`
+	UserID   UserID     `json:"user_id" db:"user_id"`
+	Name     string     `json:"name" db:"name"`
+	Status   TaskStatus `json:"status" db:"status"`
+	Position int        `json:"position" db:"position"`
+	// WIPLimit は列に置けるタスクの上限。nilなら無制限
+	WIPLimit   *int      `json:"wip_limit,omitempty" db:"wip_limit"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
+}
+
+type Columns []*Column
+
+// Full は列がWIP上限に達しているかを返す
+func (c *Column) Full(count int) bool {
+	return c.WIPLimit != nil && count >= *c.WIPLimit
+}
+
+// BoardColumn はボード上の1列とそこに置かれたタスク
+type BoardColumn struct {
+	Column
+	Tasks Tasks `json:"tasks"`
+}
+
+type Board struct {
+	Columns []*BoardColumn `json:"columns"`
+}
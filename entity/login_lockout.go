@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type LoginLockoutID int64
+
+// ログインをロックする単位
+const (
+	LoginLockoutScopeUser = "user"
+	LoginLockoutScopeIP   = "ip"
+)
+
+// LoginLockout はパスワードの失敗が続いてログインをロックした記録
+type LoginLockout struct {
+	ID LoginLockoutID `db:"id"`
+	// Scope はLoginLockoutScopeUserかLoginLockoutScopeIP。Subjectはそれぞれユーザー名かIPアドレス
+	Scope       string    `db:"scope"`
+	Subject     string    `db:"subject"`
+	Failures    int64     `db:"failures"`
+	LockedUntil time.Time `db:"locked_until"`
+	CreatedAt   time.Time `db:"created_at"`
+}
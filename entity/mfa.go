@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// UserMFA はユーザーのTOTPの2要素認証の設定。ConfirmedAtがnilなら登録の途中で、ログインでは使わない
+type UserMFA struct {
+	UserID      UserID     `db:"user_id"`
+	Secret      string     `db:"secret"`
+	ConfirmedAt *time.Time `db:"confirmed_at"`
+	// LastUsedStep は最後に受け付けたコードのタイムステップ。同じコードを2度使わせないために使う
+	LastUsedStep int64     `db:"last_used_step"`
+	CreatedAt    time.Time `db:"created_at"`
+	ModifiedAt   time.Time `db:"modified_at"`
+}
+
+// Enabled は登録を確認済みで、ログインで2要素認証を求めるか
+func (m *UserMFA) Enabled() bool {
+	return m != nil && m.ConfirmedAt != nil
+}
+
+// TOTPEnrollment はTOTPの登録を始めたときに返すシークレットと、認証アプリに読み込ませるURI
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// MFAChallenge はパスワードが正しく、2要素認証が必要なときにログインで返す値
+type MFAChallenge struct {
+	// MFAToken は/login/mfaでコードと一緒に送る使い捨てのトークン
+	MFAToken string `json:"mfa_token"`
+	// EnrollmentRequired はロールで2要素認証が必須なのに、まだ登録していないか
+	EnrollmentRequired bool `json:"enrollment_required"`
+}
+
+// LoginResult はログインの結果。2要素認証が必要ならTokensの代わりにChallengeを返す
+type LoginResult struct {
+	Tokens    *Tokens
+	Challenge *MFAChallenge
+	// RecoveryCodes はログインの途中でTOTPの登録を確認したときに発行したリカバリーコード
+	RecoveryCodes []string
+}
+
+// MFAPolicy は2要素認証を必須にするロールの一覧
+type MFAPolicy struct {
+	RequiredRoles []string `json:"required_roles"`
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type NotificationID int64
+
+// NotificationType は通知のきっかけになったイベントの種類
+type NotificationType string
+
+const (
+	NotificationTypeTaskAssigned NotificationType = "task_assigned"
+	// NotificationTypeComment はタスクへのコメント。コメント機能ができるまでは作られない
+	NotificationTypeComment     NotificationType = "comment"
+	NotificationTypeDueReminder NotificationType = "due_reminder"
+)
+
+// NotificationTypes は設定で指定できる通知の種類の一覧
+var NotificationTypes = []NotificationType{
+	NotificationTypeTaskAssigned,
+	NotificationTypeComment,
+	NotificationTypeDueReminder,
+}
+
+// Valid は既知の通知の種類かを返す
+func (t NotificationType) Valid() bool {
+	for _, v := range NotificationTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+type Notification struct {
+	ID      NotificationID   `json:"id" db:"id"`
+	UserID  UserID           `json:"user_id" db:"user_id"`
+	Type    NotificationType `json:"type" db:"type"`
+	TaskID  *TaskID          `json:"task_id,omitempty" db:"task_id"`
+	Message string           `json:"message" db:"message"`
+	// ReadAt は既読にした日時。nilなら未読
+	ReadAt    *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type Notifications []*Notification
+
+// NotificationPreference は通知の種類ごとに通知を受け取るかの設定。
+// 設定がない種類は受け取る扱いになる
+type NotificationPreference struct {
+	Type    NotificationType `json:"type" db:"type"`
+	Enabled bool             `json:"enabled" db:"enabled"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// OIDCIdentity はIDプロバイダーが検証済みのIDトークンで伝えたユーザー
+type OIDCIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	Name              string
+	PreferredUsername string
+}
+
+// UserIdentity はIDプロバイダーのユーザーとusersのユーザーの対応。issとsubの組で一意に決まる
+type UserIdentity struct {
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"`
+	UserID    UserID    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// OIDCState はログインを始めてからコールバックまでに保存しておく値。stateごとに1度だけ使える
+type OIDCState struct {
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReminderOffset は期限の何分前に通知するか
+type ReminderOffset int
+
+const (
+	ReminderAtDue      ReminderOffset = 0
+	ReminderHourBefore ReminderOffset = 60
+	ReminderDayBefore  ReminderOffset = 24 * 60
+)
+
+// Duration は期限から通知までの時間を返す
+func (o ReminderOffset) Duration() time.Duration {
+	return time.Duration(o) * time.Minute
+}
+
+type ReminderID int64
+
+// Reminder はタスクの期限の通知。期限のあるタスクについて、
+// 通知を受け取るユーザー(担当者、いなければ作成者)が選んだオフセットごとに作られる。
+type Reminder struct {
+	ID       ReminderID     `json:"id" db:"id"`
+	TaskID   TaskID         `json:"task_id" db:"task_id"`
+	UserID   UserID         `json:"user_id" db:"user_id"`
+	Offset   ReminderOffset `json:"offset" db:"offset_minutes"`
+	RemindAt time.Time      `json:"remind_at" db:"remind_at"`
+	SentAt   *time.Time     `json:"sent_at,omitempty" db:"sent_at"`
+	// TaskTitle とDueAt は通知の文面に使うタスクの情報
+	TaskTitle string    `json:"task_title" db:"title"`
+	DueAt     time.Time `json:"due_at" db:"due_at"`
+}
+
+// Message は通知の文面を返す
+func (r *Reminder) Message() string {
+	switch r.Offset {
+	case ReminderAtDue:
+		return fmt.Sprintf("%q is due now", r.TaskTitle)
+	case ReminderHourBefore:
+		return fmt.Sprintf("%q is due in 1 hour", r.TaskTitle)
+	case ReminderDayBefore:
+		return fmt.Sprintf("%q is due in 1 day", r.TaskTitle)
+	default:
+		return fmt.Sprintf("%q is due in %s", r.TaskTitle, r.Offset.Duration())
+	}
+}
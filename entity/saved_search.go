@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidTaskFilter は検索条件が不正なときのエラー
+var ErrInvalidTaskFilter = errors.New("invalid task filter")
+
+// CurrentTaskFilterVersion は保存する検索条件の形式のバージョン。
+// 古い検索条件がそのまま使えるよう、追加するフィールドは省略時に絞り込まない意味にする。
+// 既存のフィールドの意味を変えるときはバージョンを上げ、upgradeで古い形式を変換する。
+const CurrentTaskFilterVersion = 1
+
+const maxTaskFilterTextLength = 128
+
+// TaskFilter はタスクの検索条件。指定したすべての条件を満たすタスクを返す
+type TaskFilter struct {
+	Version int `json:"version"`
+	// Statuses はいずれかのステータスのタスクに絞り込む
+	Statuses []TaskStatus `json:"status,omitempty"`
+	// Labels はすべてのラベルが付いたタスクに絞り込む
+	Labels []string `json:"labels,omitempty"`
+	// DueFrom とDueTo は期限がその範囲(両端を含む)にあるタスクに絞り込む
+	DueFrom *time.Time `json:"due_from,omitempty"`
+	DueTo   *time.Time `json:"due_to,omitempty"`
+	// Text はタイトルにその文字列を含むタスクに絞り込む
+	Text string `json:"text,omitempty"`
+}
+
+// ParseTaskFilter は保存しようとしている検索条件を検証する。
+// 知らないフィールドは誤りとして扱い、バージョンを省略したら現在のバージョンにする。
+func ParseTaskFilter(data []byte) (*TaskFilter, error) {
+	f := &TaskFilter{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(f); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTaskFilter, err)
+	}
+	if f.Version == 0 {
+		f.Version = CurrentTaskFilterVersion
+	}
+	if err := f.upgrade(); err != nil {
+		return nil, err
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Validate は検索条件の値を検証する
+func (f *TaskFilter) Validate() error {
+	for _, s := range f.Statuses {
+		if !s.Valid() {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidTaskFilter, s)
+		}
+	}
+	for _, l := range f.Labels {
+		if l == "" {
+			return fmt.Errorf("%w: empty label", ErrInvalidTaskFilter)
+		}
+	}
+	if f.DueFrom != nil && f.DueTo != nil && f.DueFrom.After(*f.DueTo) {
+		return fmt.Errorf("%w: due_from is after due_to", ErrInvalidTaskFilter)
+	}
+	if utf8.RuneCountInString(f.Text) > maxTaskFilterTextLength {
+		return fmt.Errorf("%w: text is longer than %d characters", ErrInvalidTaskFilter, maxTaskFilterTextLength)
+	}
+	return nil
+}
+
+// upgrade は古いバージョンの検索条件を現在の形式に変換する
+func (f *TaskFilter) upgrade() error {
+	switch {
+	case f.Version == 0:
+		// バージョンを持たない形式はバージョン1と同じ
+		f.Version = 1
+	case f.Version > CurrentTaskFilterVersion:
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidTaskFilter, f.Version)
+	}
+	return nil
+}
+
+// Scan はDBに保存された検索条件を読み込む。
+// 保存後に追加されたフィールドや、あとで削除されたフィールドがあっても読み込める。
+func (f *TaskFilter) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into TaskFilter", src)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return err
+	}
+	return f.upgrade()
+}
+
+// Value は検索条件をJSONとして保存する
+func (f TaskFilter) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+type SavedSearchID int64
+
+// SavedSearch は名前を付けて保存した検索条件。ユーザーごと、ワークスペースごとに保存する
+type SavedSearch struct {
+	ID          SavedSearchID `json:"id" db:"id"`
+	UserID      UserID        `json:"user_id" db:"user_id"`
+	WorkspaceID WorkspaceID   `json:"workspace_id" db:"workspace_id"`
+	Name        string        `json:"name" db:"name"`
+	Filter      TaskFilter    `json:"filter" db:"definition"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	ModifiedAt  time.Time     `json:"modified_at" db:"modified_at"`
+}
+
+type SavedSearches []*SavedSearch
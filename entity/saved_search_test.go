@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		input   string
+		want    *TaskFilter
+		wantErr bool
+	}{
+		"all fields": {
+			input: `{"version": 1, "status": ["todo", "doing"], "labels": ["work"], "due_from": "2024-04-01T00:00:00Z", "due_to": "2024-04-30T00:00:00Z", "text": "report"}`,
+			want: &TaskFilter{
+				Version:  1,
+				Statuses: []TaskStatus{TaskStatusTodo, TaskStatusDoing},
+				Labels:   []string{"work"},
+				DueFrom:  &from,
+				DueTo:    &to,
+				Text:     "report",
+			},
+		},
+		"version defaults to current": {
+			input: `{"labels": ["work"]}`,
+			want:  &TaskFilter{Version: CurrentTaskFilterVersion, Labels: []string{"work"}},
+		},
+		"unknown field":       {input: `{"priority": "high"}`, wantErr: true},
+		"unknown status":      {input: `{"status": ["blocked"]}`, wantErr: true},
+		"empty label":         {input: `{"labels": [""]}`, wantErr: true},
+		"reversed due range":  {input: `{"due_from": "2024-04-30T00:00:00Z", "due_to": "2024-04-01T00:00:00Z"}`, wantErr: true},
+		"unsupported version": {input: `{"version": 99}`, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTaskFilter([]byte(tt.input))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTaskFilter) {
+					t.Errorf("want %v, but got %v", ErrInvalidTaskFilter, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseTaskFilter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// 保存済みの検索条件は、保存後にフィールドが増減しても読み込める
+func TestTaskFilter_Scan(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stored string
+		want   TaskFilter
+	}{
+		"saved before version was added": {
+			stored: `{"status": ["todo"]}`,
+			want:   TaskFilter{Version: 1, Statuses: []TaskStatus{TaskStatusTodo}},
+		},
+		"current version": {
+			stored: `{"version": 1, "text": "report"}`,
+			want:   TaskFilter{Version: 1, Text: "report"},
+		},
+		"field removed later is ignored": {
+			stored: `{"version": 1, "text": "report", "archived": false}`,
+			want:   TaskFilter{Version: 1, Text: "report"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got TaskFilter
+			if err := got.Scan([]byte(tt.stored)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Scan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
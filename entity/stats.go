@@ -0,0 +1,17 @@
+package entity
+
+// Stats はユーザーの生産性の統計。Redisにキャッシュするためjsonタグを付けている
+type Stats struct {
+	WindowDays     int                `json:"window_days"`
+	StatusCounts   map[TaskStatus]int `json:"status_counts"`
+	Days           []*DailyStats      `json:"days"`
+	AvgLeadTimeSec *float64           `json:"avg_lead_time_sec"`
+	CurrentStreak  int                `json:"current_streak"`
+}
+
+// DailyStats はユーザーのタイムゾーンでの1日あたりの作成数と完了数
+type DailyStats struct {
+	Date      string `json:"date" db:"day"`
+	Created   int    `json:"created" db:"created"`
+	Completed int    `json:"completed" db:"completed"`
+}
@@ -31,13 +31,52 @@ const (
 	TaskStatusDone  TaskStatus = "done"
 )
 
+// TaskPriority はタスクの優先度。未指定は空文字
+type TaskPriority string
+
+const (
+	TaskPriorityNone   TaskPriority = ""
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+)
+
 type Task struct {
-	ID         TaskID     `json:"id" db:"id"`
-	UserID     UserID     `json:"user_id" db:"user_id"`
-	Title      string     `json:"title" db:"title"`
-	Status     TaskStatus `json:"status" db:"status"`
-	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
-	ModifiedAt time.Time  `json:"modified_at" db:"modified_at"`
+	ID          TaskID       `json:"id" db:"id"`
+	WorkspaceID WorkspaceID  `json:"workspace_id" db:"workspace_id"`
+	UserID      UserID       `json:"user_id" db:"user_id"`
+	ProjectID   *ProjectID   `json:"project_id,omitempty" db:"project_id"`
+	AssigneeID  *UserID      `json:"assignee_id,omitempty" db:"assignee_id"`
+	Title       string       `json:"title" db:"title"`
+	Status      TaskStatus   `json:"status" db:"status"`
+	Priority    TaskPriority `json:"priority,omitempty" db:"priority"`
+	Labels      []string     `json:"labels,omitempty" db:"-"`
+	ColumnID    *ColumnID    `json:"column_id,omitempty" db:"column_id"`
+	DueAt       *time.Time   `json:"due_at,omitempty" db:"due_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	ModifiedAt  time.Time    `json:"modified_at" db:"modified_at"`
 }
 
 type Tasks []*Task
+
+// TaskLabel はタスクに付いているラベル
+type TaskLabel struct {
+	TaskID TaskID `json:"task_id" db:"task_id"`
+	Name   string `json:"name" db:"name"`
+}
+
+func (s TaskStatus) Valid() bool {
+	switch s {
+	case TaskStatusTodo, TaskStatusDoing, TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+// Workload は担当者ごとの未完了タスクの数
+type Workload struct {
+	AssigneeID UserID `json:"assignee_id" db:"assignee_id"`
+	Name       string `json:"name" db:"name"`
+	OpenTasks  int    `json:"open_tasks" db:"open_tasks"`
+}
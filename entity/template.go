@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+type TemplateID int64
+
+// Template は繰り返し作成するタスク群の雛形
+type Template struct {
+	ID         TemplateID      `json:"id" db:"id"`
+	UserID     UserID          `json:"user_id" db:"user_id"`
+	Title      string          `json:"title" db:"title"`
+	Items      []*TemplateItem `json:"items" db:"-"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	ModifiedAt time.Time       `json:"modified_at" db:"modified_at"`
+}
+
+type Templates []*Template
+
+// TemplateItem はテンプレートから作成される1タスク分の定義
+type TemplateItem struct {
+	ID         int64      `json:"id" db:"id"`
+	TemplateID TemplateID `json:"template_id" db:"template_id"`
+	Position   int        `json:"position" db:"position"`
+	Title      string     `json:"title" db:"title"`
+	// DueOffsetMinutes はインスタンス化した時刻から期限までの分数。nilなら期限なし
+	DueOffsetMinutes *int `json:"due_offset_minutes,omitempty" db:"due_offset_minutes"`
+}
+
+// DueAt はインスタンス化した時刻nowを基準にした期限を返す
+func (i *TemplateItem) DueAt(now time.Time) *time.Time {
+	if i.DueOffsetMinutes == nil {
+		return nil
+	}
+	due := now.Add(time.Duration(*i.DueOffsetMinutes) * time.Minute)
+	return &due
+}
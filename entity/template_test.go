@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplateItem_DueAt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	offset := 90
+
+	tests := []struct {
+		name string
+		item *TemplateItem
+		want *time.Time
+	}{
+		{
+			name: "without offset",
+			item: &TemplateItem{Title: "no due"},
+			want: nil,
+		},
+		{
+			name: "with offset",
+			item: &TemplateItem{Title: "due", DueOffsetMinutes: &offset},
+			want: func() *time.Time {
+				d := time.Date(2024, 4, 1, 10, 30, 0, 0, time.UTC)
+				return &d
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.item.DueAt(now)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("DueAt() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(*tt.want) {
+				t.Errorf("DueAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
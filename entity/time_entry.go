@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+type TimeEntryID int64
+
+// TimeEntry はタスクに費やした作業時間の記録。StoppedAtがnilの間は計測中
+type TimeEntry struct {
+	ID         TimeEntryID `json:"id" db:"id"`
+	UserID     UserID      `json:"user_id" db:"user_id"`
+	TaskID     TaskID      `json:"task_id" db:"task_id"`
+	TaskTitle  string      `json:"task_title,omitempty" db:"task_title"`
+	StartedAt  time.Time   `json:"started_at" db:"started_at"`
+	StoppedAt  *time.Time  `json:"stopped_at,omitempty" db:"stopped_at"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	ModifiedAt time.Time   `json:"modified_at" db:"modified_at"`
+}
+
+type TimeEntries []*TimeEntry
+
+func (e *TimeEntry) Running() bool {
+	return e.StoppedAt == nil
+}
+
+// End は記録の終了時刻を返す。計測中の場合はnowを終了時刻とみなす
+func (e *TimeEntry) End(now time.Time) time.Time {
+	if e.StoppedAt == nil {
+		return now
+	}
+	return *e.StoppedAt
+}
+
+// TimeReport は期間内の作業時間をタスク別・日別に集計したもの
+type TimeReport struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+	Total    time.Duration
+	Tasks    []*TaskDuration
+	Days     []*DayDuration
+}
+
+type TaskDuration struct {
+	TaskID   TaskID
+	Title    string
+	Duration time.Duration
+}
+
+type DayDuration struct {
+	// Date はユーザーのタイムゾーンでの日付の0時
+	Date     time.Time
+	Duration time.Duration
+}
@@ -0,0 +1,17 @@
+package entity
+
+// Tokens はログインやリフレッシュで発行するアクセストークンとリフレッシュトークンの組
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken はKVSに保存するリフレッシュトークンの情報。
+// ローテーションで発行し直したトークンは同じFamilyを引き継ぎ、
+// 使用済みのトークンが再び使われたらFamilyごと失効させる
+type RefreshToken struct {
+	UserID UserID
+	Family string
+	// Used はこのトークンが以前にローテーションで使われたか
+	Used bool
+}
@@ -25,11 +25,17 @@ func (u *UserID) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// DefaultTimezone はタイムゾーン未指定のユーザーに使うタイムゾーン
+const DefaultTimezone = "UTC"
+
 type User struct {
-	ID         UserID    `json:"id" db:"id"`
-	Name       string    `json:"name" db:"name"`
-	Password   string    `json:"password" db:"password"`
-	Role       string    `json:"role" db:"role"`
+	ID       UserID `json:"id" db:"id"`
+	Name     string `json:"name" db:"name"`
+	Password string `json:"password" db:"password"`
+	Role     string `json:"role" db:"role"`
+	Timezone string `json:"timezone" db:"timezone"`
+	// Email は任意。空ならパスワードの再設定のメールを送れない
+	Email      string    `json:"email" db:"email"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
 }
@@ -43,6 +49,15 @@ func (u *User) HashPassword() error {
 	return nil
 }
 
+// Location はユーザーのタイムゾーンを返す。不正な値の場合はUTCとみなす
+func (u *User) Location() *time.Location {
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func (u *User) ComparePassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
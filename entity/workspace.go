@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+type WorkspaceID int64
+
+// WorkspaceRole はワークスペース内でのロール。usersテーブルのロールとは独立している
+type WorkspaceRole string
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleMember WorkspaceRole = "member"
+)
+
+// CanManage はメンバーの追加などワークスペースを管理できるロールかを返す
+func (r WorkspaceRole) CanManage() bool {
+	return r == WorkspaceRoleOwner || r == WorkspaceRoleAdmin
+}
+
+type Workspace struct {
+	ID   WorkspaceID `json:"id" db:"id"`
+	Name string      `json:"name" db:"name"`
+	// PersonalUserID はユーザーごとに自動で作られる個人用ワークスペースの持ち主
+	PersonalUserID *UserID   `json:"personal_user_id,omitempty" db:"personal_user_id"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	ModifiedAt     time.Time `json:"modified_at" db:"modified_at"`
+}
+
+// WorkspaceMembership は一覧で返すワークスペースと、そこでの自分のロール
+type WorkspaceMembership struct {
+	Workspace
+	Role WorkspaceRole `json:"role" db:"role"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID WorkspaceID `json:"workspace_id" db:"workspace_id"`
+	UserID      UserID      `json:"user_id" db:"user_id"`
+	// Name はユーザー名。usersテーブルと結合して読んだときだけ入る
+	Name      string        `json:"name,omitempty" db:"name"`
+	Role      WorkspaceRole `json:"role" db:"role"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+}
+
+type ProjectID int64
+
+type Project struct {
+	ID          ProjectID   `json:"id" db:"id"`
+	WorkspaceID WorkspaceID `json:"workspace_id" db:"workspace_id"`
+	Name        string      `json:"name" db:"name"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	ModifiedAt  time.Time   `json:"modified_at" db:"modified_at"`
+}
+
+type Projects []*Project
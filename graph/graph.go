@@ -0,0 +1,134 @@
+// Package graph はタスク・ラベル・プロジェクトを1回の往復で取得するためのGraphQLのスキーマとリゾルバ。
+// リゾルバはRESTのハンドラと同じサービスを呼び、関連はdataloaderでまとめて読む。
+// クエリは深さとコストに上限があり、超えたものは実行しない。
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	// defaultListSize はfirstを指定できないリストの要素数の見積もり
+	defaultListSize = 10
+	// maxQueryLength はクエリの最大のバイト数
+	maxQueryLength = 16 * 1024
+)
+
+// Schema はリゾルバを結び付けたスキーマ
+type Schema struct {
+	schema *graphql.Schema
+	// parsed はコストを見積もるために使うスキーマ
+	parsed        *ast.Schema
+	relations     TaskRelationService
+	maxComplexity int
+}
+
+// NewSchema はmaxDepthより深いクエリと、コストがmaxComplexityを超えるクエリを拒否するスキーマを作る
+func NewSchema(r *Resolver, maxDepth, maxComplexity int) (*Schema, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, r,
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxQueryLength(maxQueryLength),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	parsed, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema: %w", err)
+	}
+	return &Schema{schema: schema, parsed: parsed, relations: r.Relations, maxComplexity: maxComplexity}, nil
+}
+
+// Exec はクエリを実行する。コストが上限を超えていれば実行せずにエラーを返す
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]any) *graphql.Response {
+	if len(query) <= maxQueryLength {
+		if c, ok := s.complexity(query, operationName, variables); ok && c > s.maxComplexity {
+			return &graphql.Response{Errors: []*gqlerrors.QueryError{
+				gqlerrors.Errorf("query complexity %d exceeds the limit of %d", c, s.maxComplexity),
+			}}
+		}
+	}
+	ctx = withLoaders(ctx, newLoaders(s.relations))
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
+
+// complexity はクエリのコストを見積もる。
+// 解析や検証に失敗したクエリは見積もらず、実行時の検証でエラーにする。
+func (s *Schema) complexity(query, operationName string, variables map[string]any) (int, bool) {
+	doc, errs := gqlparser.LoadQuery(s.parsed, query)
+	if len(errs) > 0 {
+		return 0, false
+	}
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return 0, false
+	}
+	return selectionCost(op.SelectionSet, variables), true
+}
+
+// complexityCap は見積もりがあふれないようにするための上限
+const complexityCap = 1 << 30
+
+// selectionCost はフィールド1つを1とし、リストを返すフィールドでは子のコストに要素数を掛ける
+func selectionCost(set ast.SelectionSet, variables map[string]any) int {
+	total := 0
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			child := selectionCost(sel.SelectionSet, variables)
+			if sel.Definition != nil && sel.Definition.Type.Elem != nil {
+				child = min(child*listSize(sel, variables), complexityCap)
+			}
+			total += 1 + child
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				total += selectionCost(sel.Definition.SelectionSet, variables)
+			}
+		case *ast.InlineFragment:
+			total += selectionCost(sel.SelectionSet, variables)
+		}
+		if total >= complexityCap {
+			return complexityCap
+		}
+	}
+	return total
+}
+
+// listSize はfirst引数があればその値、なければdefaultListSizeをリストの要素数とみなす
+func listSize(f *ast.Field, variables map[string]any) int {
+	var v *ast.Value
+	if arg := f.Arguments.ForName("first"); arg != nil {
+		v = arg.Value
+	} else if def := f.Definition.Arguments.ForName("first"); def != nil {
+		v = def.DefaultValue
+	}
+	if v == nil {
+		return defaultListSize
+	}
+	n, err := v.Value(variables)
+	if err != nil {
+		return defaultListSize
+	}
+	switch n := n.(type) {
+	case int64:
+		return clamp(n)
+	case float64:
+		return clamp(int64(n))
+	}
+	return defaultListSize
+}
+
+func clamp(n int64) int {
+	return int(max(0, min(n, maxFirst)))
+}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+// newTestSchema はtasks件のタスクを返すサービスで、スキーマを作る
+func newTestSchema(t *testing.T, tasks entity.Tasks) (*Schema, *TaskRelationServiceMock) {
+	t.Helper()
+	relations := &TaskRelationServiceMock{
+		TaskLabelsFunc: func(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error) {
+			m := map[entity.TaskID][]string{}
+			for _, id := range ids {
+				if id%2 == 1 {
+					m[id] = []string{"work"}
+				}
+			}
+			return m, nil
+		},
+		ProjectsFunc: func(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error) {
+			m := map[entity.ProjectID]*entity.Project{}
+			for _, id := range ids {
+				m[id] = &entity.Project{ID: id, Name: "launch"}
+			}
+			return m, nil
+		},
+		MembersFunc: func(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error) {
+			m := map[entity.UserID]*entity.WorkspaceMember{}
+			for _, id := range ids {
+				m[id] = &entity.WorkspaceMember{UserID: id, Name: "alice"}
+			}
+			return m, nil
+		},
+	}
+	r := &Resolver{
+		TaskLister: &ListTaskServiceMock{
+			ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) { return tasks, nil },
+			SearchTasksFunc: func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+				return tasks[:1], nil
+			},
+		},
+		TaskAdder: &AddTaskServiceMock{
+			AddTaskFunc: func(ctx context.Context, title string) (*entity.Task, error) {
+				return &entity.Task{ID: 100, Title: title, Status: entity.TaskStatusTodo}, nil
+			},
+		},
+		ProjectLister: &ListProjectsServiceMock{
+			ListProjectsFunc: func(ctx context.Context) (entity.Projects, error) {
+				return entity.Projects{{ID: 1, Name: "launch"}}, nil
+			},
+		},
+		Relations: relations,
+	}
+	s, err := NewSchema(r, 5, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, relations
+}
+
+func sampleTasks(n int) entity.Tasks {
+	due := time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC)
+	project := entity.ProjectID(1)
+	assignee := entity.UserID(7)
+	tasks := entity.Tasks{}
+	for i := 1; i <= n; i++ {
+		t := &entity.Task{ID: entity.TaskID(i), Title: "task", Status: entity.TaskStatusTodo}
+		if i == 1 {
+			t = &entity.Task{
+				ID: 1, Title: "quarterly report", Status: entity.TaskStatusDoing, Priority: entity.TaskPriorityHigh,
+				DueAt: &due, ProjectID: &project, AssigneeID: &assignee,
+			}
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+func TestSchema_Exec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query     string
+		variables map[string]any
+		rspFile   string
+	}{
+		"tasks with relations": {
+			query:   `{ tasks(first: 2) { id title status priority dueAt labels project { id name } assignee { id name } } }`,
+			rspFile: "testdata/tasks_rsp.json.golden",
+		},
+		"search": {
+			query:     `query($q: String) { tasks(query: $q) { id title } }`,
+			variables: map[string]any{"q": "status:doing"},
+			rspFile:   "testdata/search_rsp.json.golden",
+		},
+		"search syntax error": {
+			query:     `query($q: String) { tasks(query: $q) { id } }`,
+			variables: map[string]any{"q": "status:doing ("},
+			rspFile:   "testdata/search_error_rsp.json.golden",
+		},
+		"add task": {
+			query:   `mutation { addTask(title: "write docs") { id title status labels } }`,
+			rspFile: "testdata/add_task_rsp.json.golden",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, _ := newTestSchema(t, sampleTasks(3))
+			rsp := s.Exec(context.Background(), tt.query, "", tt.variables)
+			got, err := json.Marshal(rsp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertJSON(t, testutil.LoadFile(t, tt.rspFile), got)
+		})
+	}
+}
+
+func TestSchema_Exec_BatchesRelations(t *testing.T) {
+	t.Parallel()
+
+	// リゾルバの並列数より多いタスクでも、関連はそれぞれ1回で読む
+	s, relations := newTestSchema(t, sampleTasks(40))
+	rsp := s.Exec(context.Background(), `{ tasks { id labels project { name } assignee { name } } }`, "", nil)
+	if len(rsp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", rsp.Errors)
+	}
+	if n := len(relations.TaskLabelsCalls()); n != 1 {
+		t.Errorf("TaskLabels called %d times, want 1", n)
+	} else if ids := relations.TaskLabelsCalls()[0].Ids; len(ids) != 40 {
+		t.Errorf("TaskLabels got %d ids, want 40", len(ids))
+	}
+	if n := len(relations.ProjectsCalls()); n != 1 {
+		t.Errorf("Projects called %d times, want 1", n)
+	}
+	if n := len(relations.MembersCalls()); n != 1 {
+		t.Errorf("Members called %d times, want 1", n)
+	}
+
+	// 選択されていない関連は読まない
+	s, relations = newTestSchema(t, sampleTasks(3))
+	if rsp := s.Exec(context.Background(), `{ tasks { id } }`, "", nil); len(rsp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", rsp.Errors)
+	}
+	if n := len(relations.TaskLabelsCalls()) + len(relations.ProjectsCalls()) + len(relations.MembersCalls()); n != 0 {
+		t.Errorf("relations loaded %d times for unselected fields, want 0", n)
+	}
+}
+
+func TestSchema_Exec_Limits(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		query     string
+		variables map[string]any
+		wantError string
+	}{
+		"too deep": {
+			query:     `{ __schema { types { fields { type { ofType { name } } } } } }`,
+			wantError: `Field "name" has depth 6 that exceeds max depth 5`,
+		},
+		"too complex": {
+			query:     `{ a: tasks(first: 100) { id title labels project { id name } assignee { id name } } b: tasks(first: 100) { id title labels project { id name } assignee { id name } } }`,
+			wantError: "query complexity 1802 exceeds the limit of 1000",
+		},
+		"too complex through variables": {
+			query:     `query($n: Int) { a: tasks(first: $n) { id title project { id name } assignee { id name } } b: tasks(first: $n) { id title project { id name } assignee { id name } } }`,
+			variables: map[string]any{"n": float64(100)},
+			wantError: "query complexity 1602 exceeds the limit of 1000",
+		},
+		"too complex through fragments": {
+			query:     `{ a: tasks(first: 100) { ...f } b: tasks(first: 100) { ...f } } fragment f on Task { id title labels project { id name } assignee { id name } }`,
+			wantError: "query complexity 1802 exceeds the limit of 1000",
+		},
+		"first over the limit": {
+			query:     `{ tasks(first: 101) { id } }`,
+			wantError: "first must be between 0 and 100",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, relations := newTestSchema(t, sampleTasks(3))
+			rsp := s.Exec(context.Background(), tt.query, "", tt.variables)
+			if len(rsp.Errors) != 1 || rsp.Errors[0].Message != tt.wantError {
+				t.Fatalf("want error %q, but got %v", tt.wantError, rsp.Errors)
+			}
+			if len(relations.TaskLabelsCalls()) > 0 {
+				t.Error("query over the limit must not be executed")
+			}
+		})
+	}
+}
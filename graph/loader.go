@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// batchWait はdataloaderが同じバッチにまとめるために読み込みを待つ時間
+const batchWait = 2 * time.Millisecond
+
+// loaders はリクエストごとに作る。キャッシュがリクエストをまたがないようにするため
+type loaders struct {
+	labels   *dataloader.Loader[entity.TaskID, []string]
+	projects *dataloader.Loader[entity.ProjectID, *entity.Project]
+	members  *dataloader.Loader[entity.UserID, *entity.WorkspaceMember]
+}
+
+func newLoaders(s TaskRelationService) *loaders {
+	return &loaders{
+		labels: dataloader.NewBatchedLoader(func(ctx context.Context, ids []entity.TaskID) []*dataloader.Result[[]string] {
+			m, err := s.TaskLabels(ctx, ids)
+			return results(ids, m, err)
+		}, dataloader.WithWait[entity.TaskID, []string](batchWait)),
+		projects: dataloader.NewBatchedLoader(func(ctx context.Context, ids []entity.ProjectID) []*dataloader.Result[*entity.Project] {
+			m, err := s.Projects(ctx, ids)
+			return results(ids, m, err)
+		}, dataloader.WithWait[entity.ProjectID, *entity.Project](batchWait)),
+		members: dataloader.NewBatchedLoader(func(ctx context.Context, ids []entity.UserID) []*dataloader.Result[*entity.WorkspaceMember] {
+			m, err := s.Members(ctx, ids)
+			return results(ids, m, err)
+		}, dataloader.WithWait[entity.UserID, *entity.WorkspaceMember](batchWait)),
+	}
+}
+
+// prime は一覧のタスクについて、選択されている関連の読み込みを先にまとめて登録する。
+// リゾルバの並列数より多いタスクがあっても、1回のバッチで読めるようにするため。
+func (l *loaders) prime(ctx context.Context, tasks entity.Tasks, labels, project, assignee bool) {
+	for _, t := range tasks {
+		if labels {
+			l.labels.Load(ctx, t.ID)
+		}
+		if project && t.ProjectID != nil {
+			l.projects.Load(ctx, *t.ProjectID)
+		}
+		if assignee && t.AssigneeID != nil {
+			l.members.Load(ctx, *t.AssigneeID)
+		}
+	}
+}
+
+// results はバッチの結果をキーの順に並べる。見つからなかったキーはゼロ値になる
+func results[K comparable, V any](keys []K, m map[K]V, err error) []*dataloader.Result[V] {
+	rs := make([]*dataloader.Result[V], len(keys))
+	for i, k := range keys {
+		if err != nil {
+			rs[i] = &dataloader.Result[V]{Error: err}
+			continue
+		}
+		rs[i] = &dataloader.Result[V]{Data: m[k]}
+	}
+	return rs
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,437 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package graph
+
+import (
+	"context"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"sync"
+)
+
+// Ensure, that ListTaskServiceMock does implement ListTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ ListTaskService = &ListTaskServiceMock{}
+
+// ListTaskServiceMock is a mock implementation of ListTaskService.
+//
+//	func TestSomethingThatUsesListTaskService(t *testing.T) {
+//
+//		// make and configure a mocked ListTaskService
+//		mockedListTaskService := &ListTaskServiceMock{
+//			ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+//				panic("mock out the ListTasks method")
+//			},
+//			SearchTasksFunc: func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+//				panic("mock out the SearchTasks method")
+//			},
+//		}
+//
+//		// use mockedListTaskService in code that requires ListTaskService
+//		// and then make assertions.
+//
+//	}
+type ListTaskServiceMock struct {
+	// ListTasksFunc mocks the ListTasks method.
+	ListTasksFunc func(ctx context.Context) (entity.Tasks, error)
+
+	// SearchTasksFunc mocks the SearchTasks method.
+	SearchTasksFunc func(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTasks holds details about calls to the ListTasks method.
+		ListTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SearchTasks holds details about calls to the SearchTasks method.
+		SearchTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Q is the q argument value.
+			Q taskquery.Node
+		}
+	}
+	lockListTasks   sync.RWMutex
+	lockSearchTasks sync.RWMutex
+}
+
+// ListTasks calls ListTasksFunc.
+func (mock *ListTaskServiceMock) ListTasks(ctx context.Context) (entity.Tasks, error) {
+	if mock.ListTasksFunc == nil {
+		panic("ListTaskServiceMock.ListTasksFunc: method is nil but ListTaskService.ListTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListTasks.Lock()
+	mock.calls.ListTasks = append(mock.calls.ListTasks, callInfo)
+	mock.lockListTasks.Unlock()
+	return mock.ListTasksFunc(ctx)
+}
+
+// ListTasksCalls gets all the calls that were made to ListTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.ListTasksCalls())
+func (mock *ListTaskServiceMock) ListTasksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListTasks.RLock()
+	calls = mock.calls.ListTasks
+	mock.lockListTasks.RUnlock()
+	return calls
+}
+
+// SearchTasks calls SearchTasksFunc.
+func (mock *ListTaskServiceMock) SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+	if mock.SearchTasksFunc == nil {
+		panic("ListTaskServiceMock.SearchTasksFunc: method is nil but ListTaskService.SearchTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}{
+		Ctx: ctx,
+		Q:   q,
+	}
+	mock.lockSearchTasks.Lock()
+	mock.calls.SearchTasks = append(mock.calls.SearchTasks, callInfo)
+	mock.lockSearchTasks.Unlock()
+	return mock.SearchTasksFunc(ctx, q)
+}
+
+// SearchTasksCalls gets all the calls that were made to SearchTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.SearchTasksCalls())
+func (mock *ListTaskServiceMock) SearchTasksCalls() []struct {
+	Ctx context.Context
+	Q   taskquery.Node
+} {
+	var calls []struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}
+	mock.lockSearchTasks.RLock()
+	calls = mock.calls.SearchTasks
+	mock.lockSearchTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that AddTaskServiceMock does implement AddTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ AddTaskService = &AddTaskServiceMock{}
+
+// AddTaskServiceMock is a mock implementation of AddTaskService.
+//
+//	func TestSomethingThatUsesAddTaskService(t *testing.T) {
+//
+//		// make and configure a mocked AddTaskService
+//		mockedAddTaskService := &AddTaskServiceMock{
+//			AddTaskFunc: func(ctx context.Context, title string) (*entity.Task, error) {
+//				panic("mock out the AddTask method")
+//			},
+//		}
+//
+//		// use mockedAddTaskService in code that requires AddTaskService
+//		// and then make assertions.
+//
+//	}
+type AddTaskServiceMock struct {
+	// AddTaskFunc mocks the AddTask method.
+	AddTaskFunc func(ctx context.Context, title string) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTask holds details about calls to the AddTask method.
+		AddTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Title is the title argument value.
+			Title string
+		}
+	}
+	lockAddTask sync.RWMutex
+}
+
+// AddTask calls AddTaskFunc.
+func (mock *AddTaskServiceMock) AddTask(ctx context.Context, title string) (*entity.Task, error) {
+	if mock.AddTaskFunc == nil {
+		panic("AddTaskServiceMock.AddTaskFunc: method is nil but AddTaskService.AddTask was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Title string
+	}{
+		Ctx:   ctx,
+		Title: title,
+	}
+	mock.lockAddTask.Lock()
+	mock.calls.AddTask = append(mock.calls.AddTask, callInfo)
+	mock.lockAddTask.Unlock()
+	return mock.AddTaskFunc(ctx, title)
+}
+
+// AddTaskCalls gets all the calls that were made to AddTask.
+// Check the length with:
+//
+//	len(mockedAddTaskService.AddTaskCalls())
+func (mock *AddTaskServiceMock) AddTaskCalls() []struct {
+	Ctx   context.Context
+	Title string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Title string
+	}
+	mock.lockAddTask.RLock()
+	calls = mock.calls.AddTask
+	mock.lockAddTask.RUnlock()
+	return calls
+}
+
+// Ensure, that ListProjectsServiceMock does implement ListProjectsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListProjectsService = &ListProjectsServiceMock{}
+
+// ListProjectsServiceMock is a mock implementation of ListProjectsService.
+//
+//	func TestSomethingThatUsesListProjectsService(t *testing.T) {
+//
+//		// make and configure a mocked ListProjectsService
+//		mockedListProjectsService := &ListProjectsServiceMock{
+//			ListProjectsFunc: func(ctx context.Context) (entity.Projects, error) {
+//				panic("mock out the ListProjects method")
+//			},
+//		}
+//
+//		// use mockedListProjectsService in code that requires ListProjectsService
+//		// and then make assertions.
+//
+//	}
+type ListProjectsServiceMock struct {
+	// ListProjectsFunc mocks the ListProjects method.
+	ListProjectsFunc func(ctx context.Context) (entity.Projects, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjects holds details about calls to the ListProjects method.
+		ListProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListProjects sync.RWMutex
+}
+
+// ListProjects calls ListProjectsFunc.
+func (mock *ListProjectsServiceMock) ListProjects(ctx context.Context) (entity.Projects, error) {
+	if mock.ListProjectsFunc == nil {
+		panic("ListProjectsServiceMock.ListProjectsFunc: method is nil but ListProjectsService.ListProjects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListProjects.Lock()
+	mock.calls.ListProjects = append(mock.calls.ListProjects, callInfo)
+	mock.lockListProjects.Unlock()
+	return mock.ListProjectsFunc(ctx)
+}
+
+// ListProjectsCalls gets all the calls that were made to ListProjects.
+// Check the length with:
+//
+//	len(mockedListProjectsService.ListProjectsCalls())
+func (mock *ListProjectsServiceMock) ListProjectsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListProjects.RLock()
+	calls = mock.calls.ListProjects
+	mock.lockListProjects.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskRelationServiceMock does implement TaskRelationService.
+// If this is not the case, regenerate this file with moq.
+var _ TaskRelationService = &TaskRelationServiceMock{}
+
+// TaskRelationServiceMock is a mock implementation of TaskRelationService.
+//
+//	func TestSomethingThatUsesTaskRelationService(t *testing.T) {
+//
+//		// make and configure a mocked TaskRelationService
+//		mockedTaskRelationService := &TaskRelationServiceMock{
+//			MembersFunc: func(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error) {
+//				panic("mock out the Members method")
+//			},
+//			ProjectsFunc: func(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error) {
+//				panic("mock out the Projects method")
+//			},
+//			TaskLabelsFunc: func(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error) {
+//				panic("mock out the TaskLabels method")
+//			},
+//		}
+//
+//		// use mockedTaskRelationService in code that requires TaskRelationService
+//		// and then make assertions.
+//
+//	}
+type TaskRelationServiceMock struct {
+	// MembersFunc mocks the Members method.
+	MembersFunc func(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error)
+
+	// ProjectsFunc mocks the Projects method.
+	ProjectsFunc func(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error)
+
+	// TaskLabelsFunc mocks the TaskLabels method.
+	TaskLabelsFunc func(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Members holds details about calls to the Members method.
+		Members []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []entity.UserID
+		}
+		// Projects holds details about calls to the Projects method.
+		Projects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []entity.ProjectID
+		}
+		// TaskLabels holds details about calls to the TaskLabels method.
+		TaskLabels []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []entity.TaskID
+		}
+	}
+	lockMembers    sync.RWMutex
+	lockProjects   sync.RWMutex
+	lockTaskLabels sync.RWMutex
+}
+
+// Members calls MembersFunc.
+func (mock *TaskRelationServiceMock) Members(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error) {
+	if mock.MembersFunc == nil {
+		panic("TaskRelationServiceMock.MembersFunc: method is nil but TaskRelationService.Members was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []entity.UserID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockMembers.Lock()
+	mock.calls.Members = append(mock.calls.Members, callInfo)
+	mock.lockMembers.Unlock()
+	return mock.MembersFunc(ctx, ids)
+}
+
+// MembersCalls gets all the calls that were made to Members.
+// Check the length with:
+//
+//	len(mockedTaskRelationService.MembersCalls())
+func (mock *TaskRelationServiceMock) MembersCalls() []struct {
+	Ctx context.Context
+	Ids []entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []entity.UserID
+	}
+	mock.lockMembers.RLock()
+	calls = mock.calls.Members
+	mock.lockMembers.RUnlock()
+	return calls
+}
+
+// Projects calls ProjectsFunc.
+func (mock *TaskRelationServiceMock) Projects(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error) {
+	if mock.ProjectsFunc == nil {
+		panic("TaskRelationServiceMock.ProjectsFunc: method is nil but TaskRelationService.Projects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []entity.ProjectID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockProjects.Lock()
+	mock.calls.Projects = append(mock.calls.Projects, callInfo)
+	mock.lockProjects.Unlock()
+	return mock.ProjectsFunc(ctx, ids)
+}
+
+// ProjectsCalls gets all the calls that were made to Projects.
+// Check the length with:
+//
+//	len(mockedTaskRelationService.ProjectsCalls())
+func (mock *TaskRelationServiceMock) ProjectsCalls() []struct {
+	Ctx context.Context
+	Ids []entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []entity.ProjectID
+	}
+	mock.lockProjects.RLock()
+	calls = mock.calls.Projects
+	mock.lockProjects.RUnlock()
+	return calls
+}
+
+// TaskLabels calls TaskLabelsFunc.
+func (mock *TaskRelationServiceMock) TaskLabels(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error) {
+	if mock.TaskLabelsFunc == nil {
+		panic("TaskRelationServiceMock.TaskLabelsFunc: method is nil but TaskRelationService.TaskLabels was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []entity.TaskID
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	mock.lockTaskLabels.Lock()
+	mock.calls.TaskLabels = append(mock.calls.TaskLabels, callInfo)
+	mock.lockTaskLabels.Unlock()
+	return mock.TaskLabelsFunc(ctx, ids)
+}
+
+// TaskLabelsCalls gets all the calls that were made to TaskLabels.
+// Check the length with:
+//
+//	len(mockedTaskRelationService.TaskLabelsCalls())
+func (mock *TaskRelationServiceMock) TaskLabelsCalls() []struct {
+	Ctx context.Context
+	Ids []entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []entity.TaskID
+	}
+	mock.lockTaskLabels.RLock()
+	calls = mock.calls.TaskLabels
+	mock.lockTaskLabels.RUnlock()
+	return calls
+}
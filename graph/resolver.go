@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+)
+
+// maxFirst はtasksで一度に返せるタスクの数
+const maxFirst = 100
+
+// Resolver はQueryとMutationのリゾルバ。SQLは書かず、RESTのハンドラと同じサービスを呼ぶ
+type Resolver struct {
+	TaskLister    ListTaskService
+	TaskAdder     AddTaskService
+	ProjectLister ListProjectsService
+	Relations     TaskRelationService
+}
+
+type tasksArgs struct {
+	Query *string
+	First int32
+}
+
+func (r *Resolver) Tasks(ctx context.Context, args tasksArgs) ([]*taskResolver, error) {
+	if args.First < 0 || args.First > maxFirst {
+		return nil, fmt.Errorf("first must be between 0 and %d", maxFirst)
+	}
+	var tasks entity.Tasks
+	var err error
+	if args.Query != nil {
+		q, perr := taskquery.Parse(*args.Query)
+		if perr != nil {
+			return nil, fmt.Errorf("invalid query: %w", perr)
+		}
+		tasks, err = r.TaskLister.SearchTasks(ctx, q)
+	} else {
+		tasks, err = r.TaskLister.ListTasks(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > int(args.First) {
+		tasks = tasks[:args.First]
+	}
+	loadersFrom(ctx).prime(ctx, tasks,
+		graphql.HasSelectedField(ctx, "labels"),
+		graphql.HasSelectedField(ctx, "project"),
+		graphql.HasSelectedField(ctx, "assignee"),
+	)
+	rs := make([]*taskResolver, 0, len(tasks))
+	for _, t := range tasks {
+		rs = append(rs, &taskResolver{t: t})
+	}
+	return rs, nil
+}
+
+func (r *Resolver) Projects(ctx context.Context) ([]*projectResolver, error) {
+	projects, err := r.ProjectLister.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rs := make([]*projectResolver, 0, len(projects))
+	for _, p := range projects {
+		rs = append(rs, &projectResolver{p: p})
+	}
+	return rs, nil
+}
+
+func (r *Resolver) AddTask(ctx context.Context, args struct{ Title string }) (*taskResolver, error) {
+	title := strings.TrimSpace(args.Title)
+	if title == "" {
+		return nil, errors.New("title is required")
+	}
+	t, err := r.TaskAdder.AddTask(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	return &taskResolver{t: t}, nil
+}
+
+type taskResolver struct {
+	t *entity.Task
+}
+
+func (r *taskResolver) ID() graphql.ID { return id(int64(r.t.ID)) }
+
+func (r *taskResolver) Title() string { return r.t.Title }
+
+func (r *taskResolver) Status() string { return strings.ToUpper(string(r.t.Status)) }
+
+func (r *taskResolver) Priority() *string {
+	if r.t.Priority == entity.TaskPriorityNone {
+		return nil
+	}
+	p := strings.ToUpper(string(r.t.Priority))
+	return &p
+}
+
+func (r *taskResolver) DueAt() *graphql.Time {
+	if r.t.DueAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.t.DueAt}
+}
+
+func (r *taskResolver) Labels(ctx context.Context) ([]string, error) {
+	labels, err := loadersFrom(ctx).labels.Load(ctx, r.t.ID)()
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		return []string{}, nil
+	}
+	return labels, nil
+}
+
+func (r *taskResolver) Project(ctx context.Context) (*projectResolver, error) {
+	if r.t.ProjectID == nil {
+		return nil, nil
+	}
+	p, err := loadersFrom(ctx).projects.Load(ctx, *r.t.ProjectID)()
+	if err != nil || p == nil {
+		return nil, err
+	}
+	return &projectResolver{p: p}, nil
+}
+
+// Assignee は担当者がワークスペースを抜けていれば返さない
+func (r *taskResolver) Assignee(ctx context.Context) (*userResolver, error) {
+	if r.t.AssigneeID == nil {
+		return nil, nil
+	}
+	m, err := loadersFrom(ctx).members.Load(ctx, *r.t.AssigneeID)()
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &userResolver{m: m}, nil
+}
+
+type projectResolver struct {
+	p *entity.Project
+}
+
+func (r *projectResolver) ID() graphql.ID { return id(int64(r.p.ID)) }
+
+func (r *projectResolver) Name() string { return r.p.Name }
+
+type userResolver struct {
+	m *entity.WorkspaceMember
+}
+
+func (r *userResolver) ID() graphql.ID { return id(int64(r.m.UserID)) }
+
+func (r *userResolver) Name() string { return r.m.Name }
+
+func id(v int64) graphql.ID { return graphql.ID(strconv.FormatInt(v, 10)) }
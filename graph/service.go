@@ -0,0 +1,28 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+)
+
+//go:generate go run github.com/matryer/moq -out moq_test.go . ListTaskService AddTaskService ListProjectsService TaskRelationService
+type ListTaskService interface {
+	ListTasks(ctx context.Context) (entity.Tasks, error)
+	SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
+}
+
+type AddTaskService interface {
+	AddTask(ctx context.Context, title string) (*entity.Task, error)
+}
+
+type ListProjectsService interface {
+	ListProjects(ctx context.Context) (entity.Projects, error)
+}
+
+type TaskRelationService interface {
+	TaskLabels(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error)
+	Projects(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error)
+	Members(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/config"
+	"github.com/zakisanbaiman/go-handson01/rpc"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+)
+
+type GRPCServer struct {
+	srv  *grpc.Server
+	todo *rpc.TodoServer
+	l    net.Listener
+}
+
+// NewGRPCServer はHTTPサーバーとは別のポートで動くgRPCサーバーを作る。DB接続もHTTPサーバーとは別に持つ
+func NewGRPCServer(ctx context.Context, l net.Listener, cfg *config.Config) (*GRPCServer, func(), error) {
+	db, cleanup, err := store.New(ctx, cfg)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	clocker := clock.RealClocker{}
+	r := store.Repository{Clocker: clocker}
+	rcli, err := store.NewKVS(ctx, cfg)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	jwter, err := newJWTer(cfg, rcli, clocker)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	refreshTokens := &service.RefreshTokens{Store: rcli, Lifetime: cfg.RefreshTokenLifetime}
+
+	todo := &rpc.TodoServer{
+		Auth: &service.Login{
+			DB: db, Repo: &r, Users: &r, TokenGenerator: jwter, RefreshTokens: refreshTokens,
+			MFA:        &service.MFA{DB: db, Repo: &r, Clocker: clocker, Issuer: cfg.MFAIssuer},
+			Challenges: rcli, ChallengeTTL: cfg.MFAChallengeTTL,
+			Throttle: newLoginThrottle(cfg, db, &r, rcli, clocker),
+		},
+		TaskAdder:     &service.AddTask{DB: db, Repo: &r, Stats: rcli},
+		TaskLister:    &service.ListTask{DB: db, Repo: &r},
+		WatchInterval: cfg.GRPCWatchInterval,
+	}
+	srv := rpc.NewServer(jwter, &service.ResolveWorkspace{DB: db, Repo: &r}, todo)
+	return &GRPCServer{srv: srv, todo: todo, l: l}, cleanup, nil
+}
+
+// Run はctxが終わるまでリクエストを受け付ける。
+// 終わったらWatchTasksのストリームを閉じてから、処理中のリクエストを待って止める
+func (s *GRPCServer) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		if err := s.srv.Serve(s.l); err != nil {
+			log.Printf("failed to close: %+v", err)
+			return err
+		}
+		return nil
+	})
+
+	<-ctx.Done()
+
+	s.todo.Close()
+	s.srv.GracefulStop()
+
+	return eg.Wait()
+}
@@ -2,11 +2,16 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/jmoiron/sqlx"
 	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/quickadd"
+	"github.com/zakisanbaiman/go-handson01/service"
 	"github.com/zakisanbaiman/go-handson01/store"
 )
 
@@ -14,9 +19,17 @@ type AddTask struct {
 	DB        *sqlx.DB
 	Repo      *store.Repository
 	Service   AddTaskService
+	QuickAdd  QuickAddTaskService
 	Validator *validator.Validate
 }
 
+type parsedTask struct {
+	Title    string              `json:"title"`
+	DueAt    *time.Time          `json:"due_at"`
+	Labels   []string            `json:"labels"`
+	Priority entity.TaskPriority `json:"priority"`
+}
+
 func (h *AddTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var b struct {
@@ -38,12 +51,22 @@ func (h *AddTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// parse=trueならタイトルから期限・ラベル・優先度を取り出す
+	if parse, _ := strconv.ParseBool(r.URL.Query().Get("parse")); parse {
+		h.quickAdd(w, r, b.Title)
+		return
+	}
+
 	task, err := h.Service.AddTask(ctx, b.Title)
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrWIPLimitExceeded) {
+			status = http.StatusConflict
+		}
 		RespondJSON(ctx, w, &ErrResponse{
 			Message: "failed to add task",
 			Details: []string{err.Error()},
-		}, http.StatusInternalServerError)
+		}, status)
 		return
 	}
 	rsp := struct {
@@ -51,3 +74,39 @@ func (h *AddTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}{ID: task.ID}
 	RespondJSON(ctx, w, rsp, http.StatusCreated)
 }
+
+func (h *AddTask) quickAdd(w http.ResponseWriter, r *http.Request, text string) {
+	ctx := r.Context()
+	task, err := h.QuickAdd.QuickAddTask(ctx, text)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, quickadd.ErrEmptyTitle), errors.Is(err, quickadd.ErrLabelTooLong):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add task",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	labels := task.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	rsp := struct {
+		ID     entity.TaskID `json:"id"`
+		Parsed parsedTask    `json:"parsed"`
+	}{
+		ID: task.ID,
+		Parsed: parsedTask{
+			Title:    task.Title,
+			DueAt:    task.DueAt,
+			Labels:   labels,
+			Priority: task.Priority,
+		},
+	}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
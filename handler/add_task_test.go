@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/zakisanbaiman/go-handson01/clock"
 	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/quickadd"
 	"github.com/zakisanbaiman/go-handson01/store"
 	"github.com/zakisanbaiman/go-handson01/testutil"
 )
@@ -24,7 +28,9 @@ func TestAddTaskHandler(t *testing.T) {
 	}
 
 	tests := map[string]struct {
+		query   string
 		reqFile string
+		quick   func(ctx context.Context, text string) (*entity.Task, error)
 		want    want
 	}{
 		"ok": {
@@ -41,6 +47,58 @@ func TestAddTaskHandler(t *testing.T) {
 				rspFile: "testdata/add_task/bad_request_rsp.json",
 			},
 		},
+		"parse": {
+			query:   "?parse=true",
+			reqFile: "testdata/add_task/parse.json",
+			quick: func(ctx context.Context, text string) (*entity.Task, error) {
+				due := time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC)
+				return &entity.Task{
+					ID:       1,
+					Title:    "Pay rent",
+					DueAt:    &due,
+					Labels:   []string{"home"},
+					Priority: entity.TaskPriorityHigh,
+				}, nil
+			},
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/add_task/parse_rsp.json",
+			},
+		},
+		"parse_without_fields": {
+			query:   "?parse=true",
+			reqFile: "testdata/add_task/ok.json",
+			quick: func(ctx context.Context, text string) (*entity.Task, error) {
+				return &entity.Task{ID: 1, Title: text}, nil
+			},
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/add_task/parse_without_fields_rsp.json",
+			},
+		},
+		"parse_empty_title": {
+			query:   "?parse=true",
+			reqFile: "testdata/add_task/parse_empty_title.json",
+			quick: func(ctx context.Context, text string) (*entity.Task, error) {
+				return nil, fmt.Errorf("failed to parse: %w", quickadd.ErrEmptyTitle)
+			},
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_task/parse_empty_title_rsp.json",
+			},
+		},
+		"parse_label_too_long": {
+			query:   "?parse=true",
+			reqFile: "testdata/add_task/parse_label_too_long.json",
+			quick: func(ctx context.Context, text string) (*entity.Task, error) {
+				_, label, _ := strings.Cut(text, "#")
+				return nil, fmt.Errorf("failed to parse: %w: #%s must be at most 64 characters", quickadd.ErrLabelTooLong, label)
+			},
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/add_task/parse_label_too_long_rsp.json",
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -48,7 +106,7 @@ func TestAddTaskHandler(t *testing.T) {
 			t.Parallel()
 
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+			r := httptest.NewRequest(http.MethodPost, "/tasks"+tt.query, bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
 			r.Header.Set("Content-Type", "application/json")
 
 			moq := &AddTaskServiceMock{}
@@ -59,7 +117,8 @@ func TestAddTaskHandler(t *testing.T) {
 				return nil, errors.New("error from mock")
 			}
 			sut := AddTask{
-				Service: moq,
+				Service:  moq,
+				QuickAdd: &QuickAddTaskServiceMock{QuickAddTaskFunc: tt.quick},
 				Repo: &store.Repository{
 					Clocker: clock.RealClocker{},
 				},
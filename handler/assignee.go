@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AssignTask struct {
+	Service AssignTaskService
+}
+
+// ServeHTTP はタスクの担当者を変更する。assignee_idがnullなら担当者を外す
+func (h *AssignTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid task id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var b struct {
+		AssigneeID *entity.UserID `json:"assignee_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.AssignTask(ctx, entity.TaskID(id), b.AssigneeID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrForbidden):
+			status = http.StatusForbidden
+		case errors.Is(err, service.ErrAssigneeNoAccess):
+			status = http.StatusBadRequest
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to assign task",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type SetTaskProject struct {
+	Service SetTaskProjectService
+}
+
+// ServeHTTP はタスクのプロジェクトを変更する。project_idがnullならプロジェクトから外す
+func (h *SetTaskProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid task id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var b struct {
+		ProjectID *entity.ProjectID `json:"project_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.SetTaskProject(ctx, entity.TaskID(id), b.ProjectID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrForbidden):
+			status = http.StatusForbidden
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to set project",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type Workload struct {
+	Service WorkloadService
+}
+
+// ServeHTTP はワークスペースのメンバーごとの未完了の担当タスク数を返す
+func (h *Workload) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workload, err := h.Service.Workload(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get workload",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, workload, http.StatusOK)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestAssignTask_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status   int
+		rspFile  string
+		assignee *entity.UserID
+	}
+	tests := map[string]struct {
+		body string
+		err  error
+		want want
+	}{
+		"ok": {
+			body: `{"assignee_id": 2}`,
+			want: want{status: http.StatusNoContent, assignee: ptr(entity.UserID(2))},
+		},
+		"unassign": {
+			body: `{"assignee_id": null}`,
+			want: want{status: http.StatusNoContent},
+		},
+		"noAccess": {
+			body: `{"assignee_id": 3}`,
+			err:  fmt.Errorf("user 3: %w", service.ErrAssigneeNoAccess),
+			want: want{
+				status:   http.StatusBadRequest,
+				rspFile:  "testdata/assignee/no_access_rsp.json.golden",
+				assignee: ptr(entity.UserID(3)),
+			},
+		},
+		"forbidden": {
+			body: `{"assignee_id": 2}`,
+			err:  fmt.Errorf("task 10: %w", service.ErrForbidden),
+			want: want{
+				status:   http.StatusForbidden,
+				rspFile:  "testdata/assignee/forbidden_rsp.json.golden",
+				assignee: ptr(entity.UserID(2)),
+			},
+		},
+		"taskNotFound": {
+			body: `{"assignee_id": 2}`,
+			err:  fmt.Errorf("failed to get task: task 10: %w", store.ErrNotFound),
+			want: want{
+				status:   http.StatusNotFound,
+				rspFile:  "testdata/assignee/not_found_rsp.json.golden",
+				assignee: ptr(entity.UserID(2)),
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/tasks/10/assignee", bytes.NewReader([]byte(tt.body)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "10")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &AssignTaskServiceMock{}
+			moq.AssignTaskFunc = func(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error {
+				if id != 10 {
+					t.Errorf("want task 10, but got %d", id)
+				}
+				if !sameUserID(assigneeID, tt.want.assignee) {
+					t.Errorf("want assignee %v, but got %v", tt.want.assignee, assigneeID)
+				}
+				return tt.err
+			}
+			sut := AssignTask{Service: moq}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
+
+func TestSetTaskProject_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		body string
+		err  error
+		want want
+	}{
+		"ok": {
+			body: `{"project_id": 4}`,
+			want: want{status: http.StatusNoContent},
+		},
+		"forbidden": {
+			body: `{"project_id": 4}`,
+			err:  fmt.Errorf("task 10: %w", service.ErrForbidden),
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/assignee/set_project_forbidden_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/tasks/10/project", bytes.NewReader([]byte(tt.body)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "10")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &SetTaskProjectServiceMock{}
+			moq.SetTaskProjectFunc = func(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error {
+				if id != 10 || projectID == nil || *projectID != 4 {
+					t.Errorf("unexpected project %v for task %d", projectID, id)
+				}
+				return tt.err
+			}
+			sut := SetTaskProject{Service: moq}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
+
+func TestWorkload_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/tasks/workload", nil)
+
+	moq := &WorkloadServiceMock{}
+	moq.WorkloadFunc = func(ctx context.Context) ([]*entity.Workload, error) {
+		return []*entity.Workload{
+			{AssigneeID: 2, Name: "alice", OpenTasks: 3},
+			{AssigneeID: 1, Name: "bob", OpenTasks: 0},
+		}, nil
+	}
+	sut := Workload{Service: moq}
+	sut.ServeHTTP(w, r)
+	testutil.AssertResponse(t, w.Result(), http.StatusOK, testutil.LoadFile(t, "testdata/assignee/workload_rsp.json.golden"))
+}
+
+func sameUserID(a, b *entity.UserID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
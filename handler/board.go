@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type GetBoard struct {
+	Service GetBoardService
+}
+
+func (h *GetBoard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	board, err := h.Service.GetBoard(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get board",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	type column struct {
+		ID       entity.ColumnID   `json:"id"`
+		Name     string            `json:"name"`
+		Status   entity.TaskStatus `json:"status"`
+		WIPLimit *int              `json:"wip_limit"`
+		Tasks    []task            `json:"tasks"`
+	}
+	rsp := struct {
+		Columns []column `json:"columns"`
+	}{Columns: []column{}}
+	for _, c := range board.Columns {
+		col := column{
+			ID:       c.ID,
+			Name:     c.Name,
+			Status:   c.Status,
+			WIPLimit: c.WIPLimit,
+			Tasks:    []task{},
+		}
+		for _, t := range c.Tasks {
+			col.Tasks = append(col.Tasks, newTask(t))
+		}
+		rsp.Columns = append(rsp.Columns, col)
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type AddColumn struct {
+	Service   AddColumnService
+	Validator *validator.Validate
+}
+
+func (h *AddColumn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name     string            `json:"name" validate:"required,max=64"`
+		Status   entity.TaskStatus `json:"status" validate:"required,oneof=todo doing done"`
+		WIPLimit *int              `json:"wip_limit" validate:"omitempty,min=1"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.Service.AddColumn(ctx, b.Name, b.Status, b.WIPLimit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrAlreadyExists) {
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add column",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := struct {
+		ID       entity.ColumnID `json:"id"`
+		Position int             `json:"position"`
+	}{ID: c.ID, Position: c.Position}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
+
+type MoveTask struct {
+	Service   MoveTaskService
+	Validator *validator.Validate
+}
+
+func (h *MoveTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid task id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var b struct {
+		ColumnID entity.ColumnID `json:"column_id" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.MoveTask(ctx, entity.TaskID(id), b.ColumnID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to move task",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestGetBoard_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	limit := 2
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/board", nil)
+
+	moq := &GetBoardServiceMock{}
+	moq.GetBoardFunc = func(ctx context.Context) (*entity.Board, error) {
+		return &entity.Board{Columns: []*entity.BoardColumn{
+			{
+				Column: entity.Column{ID: 1, Name: "Backlog", Status: entity.TaskStatusTodo},
+				Tasks: entity.Tasks{
+					{ID: 10, Title: "write spec", Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityHigh},
+				},
+			},
+			{
+				Column: entity.Column{ID: 2, Name: "Doing", Status: entity.TaskStatusDoing, WIPLimit: &limit},
+				Tasks:  entity.Tasks{},
+			},
+		}}, nil
+	}
+	sut := GetBoard{Service: moq}
+	sut.ServeHTTP(w, r)
+	testutil.AssertResponse(t, w.Result(), http.StatusOK, testutil.LoadFile(t, "testdata/board/get_ok_rsp.json.golden"))
+}
+
+func TestMoveTask_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		body string
+		err  error
+		want want
+	}{
+		"ok": {
+			body: `{"column_id": 2}`,
+			want: want{status: http.StatusNoContent},
+		},
+		"wipLimitExceeded": {
+			body: `{"column_id": 2}`,
+			err:  fmt.Errorf(`column "Doing" has 2 tasks: %w`, service.ErrWIPLimitExceeded),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/board/move_conflict_rsp.json.golden",
+			},
+		},
+		"columnNotFound": {
+			body: `{"column_id": 99}`,
+			err:  fmt.Errorf("failed to get column: column 99: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/board/move_not_found_rsp.json.golden",
+			},
+		},
+		"missingColumn": {
+			body: `{}`,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/board/move_bad_request_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/tasks/10/column", bytes.NewReader([]byte(tt.body)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "10")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &MoveTaskServiceMock{}
+			moq.MoveTaskFunc = func(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error {
+				return tt.err
+			}
+			sut := MoveTask{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type GraphQL struct {
+	Service GraphQLService
+}
+
+// ServeHTTP はGraphQLのクエリを実行する。
+// クエリの誤りや上限超過はGraphQLの仕様どおり200のレスポンスのerrorsで返す。
+func (h *GraphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	rsp := h.Service.Exec(ctx, b.Query, b.OperationName, b.Variables)
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestGraphQL_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/graphql/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/graphql/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/graphql/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/graphql/bad_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+
+			moq := &GraphQLServiceMock{}
+			moq.ExecFunc = func(ctx context.Context, query, operationName string, variables map[string]any) *graphql.Response {
+				if operationName != "Tasks" {
+					t.Errorf("operationName = %q, want %q", operationName, "Tasks")
+				}
+				if variables["first"] != float64(1) {
+					t.Errorf("variables = %v", variables)
+				}
+				return &graphql.Response{
+					Data: json.RawMessage(`{"tasks":[{"id":"1","title":"Implement a handler"}]}`),
+				}
+			}
+			sut := GraphQL{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
@@ -4,7 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
 )
 
 type ErrResponse struct {
@@ -31,3 +37,22 @@ func RespondJSON(ctx context.Context, w http.ResponseWriter, body interface{}, s
 		log.Printf("failed to write response: %v", err)
 	}
 }
+
+// parseIDParam はURLパスパラメータから数値のIDを取り出す
+func parseIDParam(r *http.Request, key string) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, key), 10, 64)
+}
+
+// clientIP はリクエストの送信元のIPアドレスを返す。X-Forwarded-Forは偽装できるので見ない
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// setRetryAfter はRetry-Afterヘッダーに、待つ時間を秒に切り上げて設定する
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+}
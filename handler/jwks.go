@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// jwksMaxAge はJWKSをキャッシュしてよい秒数。鍵をローテーションしても、
+// 前の鍵は廃止するまで公開し続けるので、これくらい古くても検証できる
+const jwksMaxAge = 300
+
+// JWKS はトークンの検証に使う公開鍵をJWK Setとして返す
+type JWKS struct {
+	Service JWKSService
+}
+
+func (h *JWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(jwksMaxAge))
+	RespondJSON(r.Context(), w, h.Service.PublicKeys(), http.StatusOK)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestJWKS(t *testing.T) {
+	t.Parallel()
+
+	key, err := jwk.ParseKey([]byte(`{"kty":"RSA","kid":"test-key","alg":"RS256","use":"sig","e":"AQAB","n":"ogofmsOKgIUY-f1M2_5r9914LxuTSqnzh4ugOB-9JRH74qHxBc1Izl9Z11MpeWMw2IZuhujKs9Nq4XfjrHWe_LozKbRzsdMw5K_JHZcyIg3_rLexsJBr7OL1V_4-L6Nwzm5rsl2DX6Qe00-S8CsWt2QV_myhyC0kQui-6SA0npzLUCsQjgexWaC7pUq_cVxu_7aLZ3i4AtFy52pgB8ve4U0m6L6ZUncLMfo_rk6buSW_8bmWhhTZnuZyw4ABTaL4Q1y0f1xAuJ_tR6e5hPb4hEPNjFoeRYuUuHEfGDLQ2mlUvAyGeyvd10TFw22-az4gWVXSlKe54DzMsLcdXOFaBQ"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := jwk.NewSet()
+	if err := set.AddKey(key); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	sut := JWKS{Service: &JWKSServiceMock{
+		PublicKeysFunc: func() jwk.Set { return set },
+	}}
+	sut.ServeHTTP(w, r)
+
+	resp := w.Result()
+	if got := resp.Header.Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("want Cache-Control %q, but got %q", "public, max-age=300", got)
+	}
+	testutil.AssertResponse(t, resp, http.StatusOK, testutil.LoadFile(t, "testdata/jwks/ok_rsp.json.golden"))
+}
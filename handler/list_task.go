@@ -1,11 +1,17 @@
 package handler
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 )
 
 type ListTask struct {
@@ -15,14 +21,64 @@ type ListTask struct {
 }
 
 type task struct {
-	ID     entity.TaskID     `json:"id"`
-	Title  string            `json:"title"`
-	Status entity.TaskStatus `json:"status"`
+	ID         entity.TaskID       `json:"id"`
+	Title      string              `json:"title"`
+	Status     entity.TaskStatus   `json:"status"`
+	DueAt      *time.Time          `json:"due_at,omitempty"`
+	Priority   entity.TaskPriority `json:"priority,omitempty"`
+	ProjectID  *entity.ProjectID   `json:"project_id,omitempty"`
+	AssigneeID *entity.UserID      `json:"assignee_id,omitempty"`
 }
 
+func newTask(t *entity.Task) task {
+	return task{
+		ID:         t.ID,
+		Title:      t.Title,
+		Status:     t.Status,
+		DueAt:      t.DueAt,
+		Priority:   t.Priority,
+		ProjectID:  t.ProjectID,
+		AssigneeID: t.AssigneeID,
+	}
+}
+
+// ServeHTTP はタスクの一覧を返す。
+// ?assignee=meまたは?assignee=<ユーザーID>を指定すると、そのユーザーが担当しているタスクだけを返す。
+// ?q=で検索クエリを指定すると、マッチするタスクだけを返す。クエリの誤りは位置付きで400を返す。
 func (lt *ListTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tasks, err := lt.Service.ListTasks(ctx)
+	var tasks entity.Tasks
+	var err error
+	if r.URL.Query().Has("q") {
+		if r.URL.Query().Has("assignee") {
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "invalid query",
+				Details: []string{"q and assignee cannot be combined; use assignee: in q"},
+			}, http.StatusBadRequest)
+			return
+		}
+		q, perr := taskquery.Parse(r.URL.Query().Get("q"))
+		if perr != nil {
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "invalid query",
+				Details: []string{perr.Error()},
+			}, http.StatusBadRequest)
+			return
+		}
+		tasks, err = lt.Service.SearchTasks(ctx, q)
+	} else if q := r.URL.Query().Get("assignee"); q != "" {
+		assigneeID, perr := parseAssignee(ctx, q)
+		if perr != nil {
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "invalid assignee",
+				Details: []string{perr.Error()},
+			}, http.StatusBadRequest)
+			return
+		}
+		tasks, err = lt.Service.ListAssignedTasks(ctx, assigneeID)
+	} else {
+		tasks, err = lt.Service.ListTasks(ctx)
+	}
 	if err != nil {
 		RespondJSON(ctx, w, &ErrResponse{
 			Message: "failed to list tasks",
@@ -32,11 +88,23 @@ func (lt *ListTask) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	rsp := []task{}
 	for _, t := range tasks {
-		rsp = append(rsp, task{
-			ID:     t.ID,
-			Title:  t.Title,
-			Status: t.Status,
-		})
+		rsp = append(rsp, newTask(t))
 	}
 	RespondJSON(ctx, w, rsp, http.StatusOK)
 }
+
+// parseAssignee はassigneeクエリを解釈する。meならログイン中のユーザーを表す
+func parseAssignee(ctx context.Context, q string) (entity.UserID, error) {
+	if q == "me" {
+		id, ok := auth.GetUserID(ctx)
+		if !ok {
+			return 0, fmt.Errorf("user_id not found")
+		}
+		return id, nil
+	}
+	id, err := strconv.ParseInt(q, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("assignee must be \"me\" or a user id: %q", q)
+	}
+	return entity.UserID(id), nil
+}
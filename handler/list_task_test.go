@@ -5,11 +5,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 	"github.com/zakisanbaiman/go-handson01/testutil"
 )
 
@@ -22,8 +25,11 @@ func TestListTaskHandler(t *testing.T) {
 	}
 
 	tests := map[string]struct {
-		tasks []*entity.Task
-		want  want
+		query        string
+		tasks        []*entity.Task
+		wantAssignee entity.UserID
+		wantSearch   string
+		want         want
 	}{
 		"ok": {
 			tasks: []*entity.Task{
@@ -35,6 +41,60 @@ func TestListTaskHandler(t *testing.T) {
 				rspFile: "testdata/list_task/ok_rsp.json",
 			},
 		},
+		"assignee me": {
+			query: "?assignee=me",
+			tasks: []*entity.Task{
+				{ID: 3, Title: "assigned", Status: entity.TaskStatusDoing, ProjectID: ptr(entity.ProjectID(4)), AssigneeID: ptr(entity.UserID(7))},
+			},
+			wantAssignee: 7,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_task/assignee_rsp.json",
+			},
+		},
+		"assignee id": {
+			query: "?assignee=8",
+			tasks: []*entity.Task{
+				{ID: 3, Title: "assigned", Status: entity.TaskStatusDoing, ProjectID: ptr(entity.ProjectID(4)), AssigneeID: ptr(entity.UserID(7))},
+			},
+			wantAssignee: 8,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_task/assignee_rsp.json",
+			},
+		},
+		"invalid assignee": {
+			query: "?assignee=someone",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_task/bad_assignee_rsp.json",
+			},
+		},
+		"search": {
+			query: "?q=" + url.QueryEscape(`status:doing label:work due<7d "quarterly report"`),
+			tasks: []*entity.Task{
+				{ID: 3, Title: "quarterly report", Status: entity.TaskStatusDoing},
+			},
+			wantSearch: `status:doing label:work due<7d "quarterly report"`,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/list_task/search_rsp.json",
+			},
+		},
+		"search syntax error": {
+			query: "?q=" + url.QueryEscape("status:doing (label:work OR"),
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_task/bad_query_rsp.json",
+			},
+		},
+		"search with assignee": {
+			query: "?q=status:todo&assignee=me",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/list_task/query_with_assignee_rsp.json",
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -42,7 +102,8 @@ func TestListTaskHandler(t *testing.T) {
 			t.Parallel()
 
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			r := httptest.NewRequest(http.MethodGet, "/tasks"+tt.query, nil)
+			r = r.WithContext(auth.SetUserID(r.Context(), 7))
 
 			moq := &ListTaskServiceMock{}
 			moq.ListTasksFunc = func(ctx context.Context) (entity.Tasks, error) {
@@ -51,6 +112,18 @@ func TestListTaskHandler(t *testing.T) {
 				}
 				return nil, errors.New("error from mock")
 			}
+			moq.ListAssignedTasksFunc = func(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error) {
+				if assigneeID != tt.wantAssignee {
+					t.Errorf("want assignee %d, but got %d", tt.wantAssignee, assigneeID)
+				}
+				return tt.tasks, nil
+			}
+			moq.SearchTasksFunc = func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+				if q.String() != tt.wantSearch {
+					t.Errorf("want query %q, but got %q", tt.wantSearch, q.String())
+				}
+				return tt.tasks, nil
+			}
 			sut := ListTask{
 				Service: moq,
 				DB:      &sqlx.DB{},
@@ -63,3 +136,5 @@ func TestListTaskHandler(t *testing.T) {
 		})
 	}
 }
+
+func ptr[T any](v T) *T { return &v }
@@ -2,9 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/service"
 )
 
 type Login struct {
@@ -12,10 +14,12 @@ type Login struct {
 	Validator *validator.Validate
 }
 
+// ServeHTTP はユーザー名とパスワードでログインする。違うユーザー名やパスワードには401を返し、
+// 失敗が続いたユーザー名やIPアドレスにはRetry-Afterを付けて429を返す
 func (l *Login) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var body struct {
-		UserName string `json:"user_name" validate:"required"`
+		UserName string `json:"user_name" validate:"required,max=100"`
 		Password string `json:"password" validate:"required"`
 	}
 
@@ -34,19 +38,27 @@ func (l *Login) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jwt, err := l.Service.Login(ctx, body.UserName, body.Password)
+	result, err := l.Service.Login(ctx, body.UserName, body.Password, clientIP(r))
 	if err != nil {
+		status := http.StatusInternalServerError
+		var rle *service.RateLimitError
+		switch {
+		case errors.As(err, &rle):
+			setRetryAfter(w, rle.RetryAfter)
+			status = http.StatusTooManyRequests
+		case errors.Is(err, service.ErrInvalidCredentials):
+			status = http.StatusUnauthorized
+		}
 		RespondJSON(ctx, w, &ErrResponse{
 			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		}, status)
 		return
 	}
-
-	rsp := struct {
-		AccessToken string `json:"access_token"`
-	}{
-		AccessToken: jwt,
+	// 2要素認証が必要なら、トークンの代わりに/login/mfaで使うMFAトークンを返す
+	if result.Challenge != nil {
+		RespondJSON(ctx, w, result.Challenge, http.StatusOK)
+		return
 	}
 
-	RespondJSON(r.Context(), w, rsp, http.StatusOK)
+	RespondJSON(r.Context(), w, result.Tokens, http.StatusOK)
 }
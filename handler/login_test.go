@@ -7,19 +7,23 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
 	"github.com/zakisanbaiman/go-handson01/testutil"
 )
 
 func TestLogin_ServeHTTP(t *testing.T) {
 	type moq struct {
-		token string
-		err   error
+		result *entity.LoginResult
+		err    error
 	}
 	type want struct {
-		status  int
-		repFile string
+		status     int
+		repFile    string
+		retryAfter string
 	}
 	tests := map[string]struct {
 		repFile string
@@ -29,13 +33,27 @@ func TestLogin_ServeHTTP(t *testing.T) {
 		"ok": {
 			repFile: "testdata/login/ok_req.json.golden",
 			moq: moq{
-				token: "from_moq",
+				result: &entity.LoginResult{
+					Tokens: &entity.Tokens{AccessToken: "from_moq", RefreshToken: "refresh_from_moq"},
+				},
 			},
 			want: want{
 				status:  http.StatusOK,
 				repFile: "testdata/login/ok_rsp.json.golden",
 			},
 		},
+		"mfaRequired": {
+			repFile: "testdata/login/ok_req.json.golden",
+			moq: moq{
+				result: &entity.LoginResult{
+					Challenge: &entity.MFAChallenge{MFAToken: "mfa_from_moq", EnrollmentRequired: true},
+				},
+			},
+			want: want{
+				status:  http.StatusOK,
+				repFile: "testdata/login/mfa_rsp.json.golden",
+			},
+		},
 		"badRequest": {
 			repFile: "testdata/login/bad_req.json.golden",
 			moq:     moq{},
@@ -44,6 +62,27 @@ func TestLogin_ServeHTTP(t *testing.T) {
 				repFile: "testdata/login/bad_rsp.json.golden",
 			},
 		},
+		"invalidCredentials": {
+			repFile: "testdata/login/ok_req.json.golden",
+			moq: moq{
+				err: service.ErrInvalidCredentials,
+			},
+			want: want{
+				status:  http.StatusUnauthorized,
+				repFile: "testdata/login/unauthorized_rsp.json.golden",
+			},
+		},
+		"tooManyRequests": {
+			repFile: "testdata/login/ok_req.json.golden",
+			moq: moq{
+				err: &service.RateLimitError{RetryAfter: time.Minute},
+			},
+			want: want{
+				status:     http.StatusTooManyRequests,
+				repFile:    "testdata/login/too_many_requests_rsp.json.golden",
+				retryAfter: "60",
+			},
+		},
 		"internalServerError": {
 			repFile: "testdata/login/internal_server_error.json.golden",
 			moq: moq{
@@ -68,17 +107,24 @@ func TestLogin_ServeHTTP(t *testing.T) {
 				"/login",
 				bytes.NewReader(testutil.LoadFile(t, tt.repFile)),
 			)
+			r.RemoteAddr = "192.0.2.1:54321"
 
 			// mock
 			moq := &LoginServiceMock{}
-			moq.LoginFunc = func(ctx context.Context, name, pw string) (string, error) {
-				return tt.moq.token, tt.moq.err
+			moq.LoginFunc = func(ctx context.Context, name, pw, clientIP string) (*entity.LoginResult, error) {
+				if clientIP != "192.0.2.1" {
+					t.Errorf("want the client ip without the port, but got %q", clientIP)
+				}
+				return tt.moq.result, tt.moq.err
 			}
 
 			sut := Login{Service: moq, Validator: validator.New()}
 			sut.ServeHTTP(w, r)
 
 			resp := w.Result()
+			if got := resp.Header.Get("Retry-After"); got != tt.want.retryAfter {
+				t.Errorf("want Retry-After %q, but got %q", tt.want.retryAfter, got)
+			}
 			testutil.AssertResponse(t,
 				resp,
 				tt.want.status,
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+type Logout struct {
+	Service LogoutService
+}
+
+// ServeHTTP はリクエストの認証に使ったアクセストークンを失効させる。
+// ボディでリフレッシュトークンを渡すと、それも失効させる。ボディは省略できる。
+func (h *Logout) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil && !errors.Is(err, io.EOF) {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.Logout(ctx, b.RefreshToken); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to logout",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type LogoutAll struct {
+	Service LogoutService
+}
+
+// ServeHTTP はログイン中のユーザーのすべてのセッションを失効させる
+func (h *LogoutAll) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := h.Service.LogoutAll(ctx); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to logout",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type RevokeSessions struct {
+	Service RevokeSessionsService
+}
+
+// ServeHTTP は管理者がパスで指定したユーザーのすべてのセッションを失効させる。AdminMiddlewareの後に使うこと。
+func (h *RevokeSessions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil || id <= 0 {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid user id",
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.RevokeSessions(ctx, entity.UserID(id)); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to revoke sessions",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
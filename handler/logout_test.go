@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestLogout_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status       int
+		rspFile      string
+		refreshToken string
+	}
+	tests := map[string]struct {
+		body string
+		want want
+	}{
+		"noBody": {
+			want: want{status: http.StatusNoContent},
+		},
+		"withRefreshToken": {
+			body: `{"refresh_token": "refresh"}`,
+			want: want{status: http.StatusNoContent, refreshToken: "refresh"},
+		},
+		"badRequest": {
+			body: `{"refresh_token": `,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/logout/bad_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/logout", strings.NewReader(tt.body))
+
+			moq := &LogoutServiceMock{}
+			moq.LogoutFunc = func(ctx context.Context, refreshToken string) error {
+				if refreshToken != tt.want.refreshToken {
+					t.Errorf("want refresh token %q, but got %q", tt.want.refreshToken, refreshToken)
+				}
+				return nil
+			}
+			sut := Logout{Service: moq}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
+
+func TestRevokeSessions_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		id   string
+		want want
+	}{
+		"ok": {
+			id:   "2",
+			want: want{status: http.StatusNoContent},
+		},
+		"invalidID": {
+			id: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/logout/invalid_user_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, "/admin/users/"+tt.id+"/sessions", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &RevokeSessionsServiceMock{}
+			moq.RevokeSessionsFunc = func(ctx context.Context, userID entity.UserID) error {
+				if userID != 2 {
+					t.Errorf("want user 2, but got %d", userID)
+				}
+				return nil
+			}
+			sut := RevokeSessions{Service: moq}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+)
+
+type LoginMFA struct {
+	Service   LoginService
+	Validator *validator.Validate
+}
+
+// ServeHTTP はログインの2段階目。MFAトークンと、認証アプリのコードかリカバリーコードでトークンを発行する。
+// 無効なMFAトークンや違うコードには401を返す
+func (h *LoginMFA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		MFAToken     string `json:"mfa_token" validate:"required"`
+		Code         string `json:"code" validate:"required_without=RecoveryCode,excluded_with=RecoveryCode"`
+		RecoveryCode string `json:"recovery_code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Service.LoginMFA(ctx, b.MFAToken, b.Code, b.RecoveryCode)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidMFAToken) || errors.Is(err, service.ErrInvalidMFACode) ||
+			errors.Is(err, service.ErrMFANotEnrolled) {
+			status = http.StatusUnauthorized
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to login",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	// ログインの途中で登録したときは、リカバリーコードを一度だけ返す
+	rsp := struct {
+		*entity.Tokens
+		RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	}{Tokens: result.Tokens, RecoveryCodes: result.RecoveryCodes}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type LoginMFAEnroll struct {
+	Service   LoginService
+	Validator *validator.Validate
+}
+
+// ServeHTTP はロールで2要素認証が必須なのに登録していないユーザーが、ログインの途中でTOTPの登録を始める
+func (h *LoginMFAEnroll) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		MFAToken string `json:"mfa_token" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	enrollment, err := h.Service.EnrollMFA(ctx, b.MFAToken)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidMFAToken):
+			status = http.StatusUnauthorized
+		case errors.Is(err, service.ErrMFAAlreadyEnabled):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to enroll totp",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	RespondJSON(ctx, w, enrollment, http.StatusOK)
+}
+
+type EnrollTOTP struct {
+	Service MFAService
+}
+
+// ServeHTTP はログインしているユーザーのTOTPの登録を始め、シークレットとotpauth://のURIを返す。
+// 登録済みなら409を返す
+func (h *EnrollTOTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	enrollment, err := h.Service.EnrollTOTP(ctx)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrMFAAlreadyEnabled) {
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to enroll totp",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	RespondJSON(ctx, w, enrollment, http.StatusCreated)
+}
+
+type ConfirmTOTP struct {
+	Service   MFAService
+	Validator *validator.Validate
+}
+
+// ServeHTTP は認証アプリのコードで登録を確認し、使い捨てのリカバリーコードを返す。
+// リカバリーコードはこのレスポンスでしか返さない
+func (h *ConfirmTOTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Code string `json:"code" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.Service.ConfirmTOTP(ctx, b.Code)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidMFACode):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrMFANotEnrolled), errors.Is(err, service.ErrMFAAlreadyEnabled):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to confirm totp",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}{RecoveryCodes: codes}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type GetMFAPolicy struct {
+	Service MFAPolicyService
+}
+
+// ServeHTTP は2要素認証を必須にするロールを返す
+func (h *GetMFAPolicy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	policy, err := h.Service.MFAPolicy(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get mfa policy",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, policy, http.StatusOK)
+}
+
+type UpdateMFAPolicy struct {
+	Service   MFAPolicyService
+	Validator *validator.Validate
+}
+
+// ServeHTTP は2要素認証を必須にするロールを置き換える。空の配列ならどのロールでも任意になる
+func (h *UpdateMFAPolicy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		RequiredRoles []string `json:"required_roles" validate:"required,dive,required,max=80"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	policy, err := h.Service.UpdateMFAPolicy(ctx, &entity.MFAPolicy{RequiredRoles: b.RequiredRoles})
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to update mfa policy",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, policy, http.StatusOK)
+}
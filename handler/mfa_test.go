@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestLoginMFA_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile       string
+		recoveryCodes []string
+		err           error
+		want          want
+	}{
+		"ok": {
+			reqFile: "testdata/mfa/login_ok_req.json.golden",
+			want:    want{status: http.StatusOK, rspFile: "testdata/mfa/login_ok_rsp.json.golden"},
+		},
+		"recoveryCode": {
+			reqFile: "testdata/mfa/login_recovery_req.json.golden",
+			want:    want{status: http.StatusOK, rspFile: "testdata/mfa/login_ok_rsp.json.golden"},
+		},
+		"enrolledDuringLogin": {
+			reqFile:       "testdata/mfa/login_ok_req.json.golden",
+			recoveryCodes: []string{"abcde-fghij", "klmno-pqrst"},
+			want:          want{status: http.StatusOK, rspFile: "testdata/mfa/login_enrolled_rsp.json.golden"},
+		},
+		"codeAndRecoveryCode": {
+			reqFile: "testdata/mfa/login_bad_req.json.golden",
+			want:    want{status: http.StatusBadRequest, rspFile: "testdata/mfa/login_bad_rsp.json.golden"},
+		},
+		"invalidCode": {
+			reqFile: "testdata/mfa/login_ok_req.json.golden",
+			err:     service.ErrInvalidMFACode,
+			want:    want{status: http.StatusUnauthorized, rspFile: "testdata/mfa/login_invalid_code_rsp.json.golden"},
+		},
+		"invalidToken": {
+			reqFile: "testdata/mfa/login_ok_req.json.golden",
+			err:     service.ErrInvalidMFAToken,
+			want:    want{status: http.StatusUnauthorized, rspFile: "testdata/mfa/login_invalid_token_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/login/mfa", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+			moq := &LoginServiceMock{
+				LoginMFAFunc: func(ctx context.Context, mfaToken, code, recoveryCode string) (*entity.LoginResult, error) {
+					if mfaToken != "mfa-token" {
+						t.Errorf("want mfa token from the body, but got %q", mfaToken)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &entity.LoginResult{
+						Tokens:        &entity.Tokens{AccessToken: "access", RefreshToken: "refresh"},
+						RecoveryCodes: tt.recoveryCodes,
+					}, nil
+				},
+			}
+			sut := LoginMFA{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
+
+func TestLoginMFAEnroll_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err     error
+		status  int
+		rspFile string
+	}{
+		"ok":             {status: http.StatusOK, rspFile: "testdata/mfa/enroll_rsp.json.golden"},
+		"invalidToken":   {err: service.ErrInvalidMFAToken, status: http.StatusUnauthorized, rspFile: "testdata/mfa/enroll_invalid_token_rsp.json.golden"},
+		"alreadyEnabled": {err: service.ErrMFAAlreadyEnabled, status: http.StatusConflict, rspFile: "testdata/mfa/enroll_conflict_rsp.json.golden"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/login/mfa/enroll", bytes.NewReader(testutil.LoadFile(t, "testdata/mfa/enroll_req.json.golden")))
+			moq := &LoginServiceMock{
+				EnrollMFAFunc: func(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return testEnrollment(), nil
+				},
+			}
+			sut := LoginMFAEnroll{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.status, testutil.LoadFile(t, tt.rspFile))
+		})
+	}
+}
+
+func TestEnrollTOTP_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err     error
+		status  int
+		rspFile string
+	}{
+		"created":        {status: http.StatusCreated, rspFile: "testdata/mfa/enroll_rsp.json.golden"},
+		"alreadyEnabled": {err: service.ErrMFAAlreadyEnabled, status: http.StatusConflict, rspFile: "testdata/mfa/enroll_conflict_rsp.json.golden"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/mfa/totp", nil)
+			moq := &MFAServiceMock{
+				EnrollTOTPFunc: func(ctx context.Context) (*entity.TOTPEnrollment, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return testEnrollment(), nil
+				},
+			}
+			sut := EnrollTOTP{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.status, testutil.LoadFile(t, tt.rspFile))
+		})
+	}
+}
+
+func TestConfirmTOTP_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err     error
+		status  int
+		rspFile string
+	}{
+		"ok":          {status: http.StatusOK, rspFile: "testdata/mfa/confirm_rsp.json.golden"},
+		"invalidCode": {err: service.ErrInvalidMFACode, status: http.StatusBadRequest, rspFile: "testdata/mfa/confirm_invalid_code_rsp.json.golden"},
+		"notEnrolled": {err: service.ErrMFANotEnrolled, status: http.StatusConflict, rspFile: "testdata/mfa/confirm_not_enrolled_rsp.json.golden"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/mfa/totp/confirm", bytes.NewReader(testutil.LoadFile(t, "testdata/mfa/confirm_req.json.golden")))
+			moq := &MFAServiceMock{
+				ConfirmTOTPFunc: func(ctx context.Context, code string) ([]string, error) {
+					if code != "123456" {
+						t.Errorf("want code from the body, but got %q", code)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return []string{"abcde-fghij", "klmno-pqrst"}, nil
+				},
+			}
+			sut := ConfirmTOTP{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.status, testutil.LoadFile(t, tt.rspFile))
+		})
+	}
+}
+
+func TestUpdateMFAPolicy_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		reqFile string
+		status  int
+		rspFile string
+	}{
+		"ok":        {reqFile: "testdata/mfa/policy_req.json.golden", status: http.StatusOK, rspFile: "testdata/mfa/policy_rsp.json.golden"},
+		"emptyRole": {reqFile: "testdata/mfa/policy_bad_req.json.golden", status: http.StatusBadRequest, rspFile: "testdata/mfa/policy_bad_rsp.json.golden"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/admin/mfa-policy", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+			moq := &MFAPolicyServiceMock{
+				UpdateMFAPolicyFunc: func(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error) {
+					return policy, nil
+				},
+			}
+			sut := UpdateMFAPolicy{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.status, testutil.LoadFile(t, tt.rspFile))
+		})
+	}
+}
+
+func testEnrollment() *entity.TOTPEnrollment {
+	return &entity.TOTPEnrollment{
+		Secret: "JBSWY3DPEHPK3PXP",
+		URI:    "otpauth://totp/go-handson01:alice?algorithm=SHA1&digits=6&issuer=go-handson01&period=30&secret=JBSWY3DPEHPK3PXP",
+	}
+}
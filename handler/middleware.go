@@ -1,11 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
 )
 
+// WorkspaceHeader はリクエストで使うワークスペースを指定するヘッダ
+const WorkspaceHeader = "X-Workspace-ID"
+
 func AuthMiddleware(j *auth.JWTer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -33,3 +42,52 @@ func AdminMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// WorkspaceMiddleware はWorkspaceHeaderで指定されたワークスペースをコンテキストに設定する。
+// ヘッダがなければユーザーの個人用ワークスペースを使う。AuthMiddlewareの後に使うこと。
+func WorkspaceMiddleware(s ResolveWorkspaceService) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			var id int64
+			if v := r.Header.Get(WorkspaceHeader); v != "" {
+				var err error
+				id, err = strconv.ParseInt(v, 10, 64)
+				if err != nil || id <= 0 {
+					RespondJSON(ctx, w, &ErrResponse{
+						Message: "invalid workspace id",
+					}, http.StatusBadRequest)
+					return
+				}
+			}
+			m, err := s.ResolveWorkspace(ctx, entity.WorkspaceID(id))
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, service.ErrForbidden) {
+					status = http.StatusForbidden
+				}
+				RespondJSON(ctx, w, &ErrResponse{
+					Message: "failed to resolve workspace",
+					Details: []string{err.Error()},
+				}, status)
+				return
+			}
+			ctx = auth.SetWorkspaceID(ctx, m.WorkspaceID)
+			ctx = auth.SetWorkspaceRole(ctx, m.Role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DeprecationMiddleware は非推奨のルートのレスポンスに、非推奨になった日時(RFC 9745のDeprecation)と
+// 廃止する日時(RFC 8594のSunset)、後継のルート(successorPrefixを付けたパス)を示すLinkヘッダを付ける
+func DeprecationMiddleware(deprecatedAt, sunset time.Time, successorPrefix string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+			w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			w.Header().Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, r.URL.Path))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestWorkspaceMiddleware(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status      int
+		rspFile     string
+		workspaceID entity.WorkspaceID
+	}
+	tests := map[string]struct {
+		header string
+		want   want
+	}{
+		"personal": {
+			want: want{status: http.StatusOK, workspaceID: 1},
+		},
+		"member": {
+			header: "5",
+			want:   want{status: http.StatusOK, workspaceID: 5},
+		},
+		"notMember": {
+			header: "6",
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/workspace/forbidden_rsp.json.golden",
+			},
+		},
+		"invalidHeader": {
+			header: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/workspace/bad_header_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+			if tt.header != "" {
+				r.Header.Set(WorkspaceHeader, tt.header)
+			}
+
+			moq := &ResolveWorkspaceServiceMock{}
+			moq.ResolveWorkspaceFunc = func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+				switch id {
+				case 0:
+					return &entity.WorkspaceMember{WorkspaceID: 1, Role: entity.WorkspaceRoleOwner}, nil
+				case 5:
+					return &entity.WorkspaceMember{WorkspaceID: 5, Role: entity.WorkspaceRoleMember}, nil
+				}
+				return nil, fmt.Errorf("workspace %d: %w", id, service.ErrForbidden)
+			}
+			var got entity.WorkspaceID
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = auth.GetWorkspaceID(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			WorkspaceMiddleware(moq)(next).ServeHTTP(w, r)
+
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+			if got != tt.want.workspaceID {
+				t.Errorf("workspace in context = %d, want %d", got, tt.want.workspaceID)
+			}
+		})
+	}
+}
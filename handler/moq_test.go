@@ -5,8 +5,12 @@ package handler
 
 import (
 	"context"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 	"sync"
+	"time"
 )
 
 // Ensure, that ListTaskServiceMock does implement ListTaskService.
@@ -19,9 +23,15 @@ var _ ListTaskService = &ListTaskServiceMock{}
 //
 //		// make and configure a mocked ListTaskService
 //		mockedListTaskService := &ListTaskServiceMock{
+//			ListAssignedTasksFunc: func(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error) {
+//				panic("mock out the ListAssignedTasks method")
+//			},
 //			ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
 //				panic("mock out the ListTasks method")
 //			},
+//			SearchTasksFunc: func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+//				panic("mock out the SearchTasks method")
+//			},
 //		}
 //
 //		// use mockedListTaskService in code that requires ListTaskService
@@ -29,18 +39,76 @@ var _ ListTaskService = &ListTaskServiceMock{}
 //
 //	}
 type ListTaskServiceMock struct {
+	// ListAssignedTasksFunc mocks the ListAssignedTasks method.
+	ListAssignedTasksFunc func(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error)
+
 	// ListTasksFunc mocks the ListTasks method.
 	ListTasksFunc func(ctx context.Context) (entity.Tasks, error)
 
+	// SearchTasksFunc mocks the SearchTasks method.
+	SearchTasksFunc func(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// ListAssignedTasks holds details about calls to the ListAssignedTasks method.
+		ListAssignedTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AssigneeID is the assigneeID argument value.
+			AssigneeID entity.UserID
+		}
 		// ListTasks holds details about calls to the ListTasks method.
 		ListTasks []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// SearchTasks holds details about calls to the SearchTasks method.
+		SearchTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Q is the q argument value.
+			Q taskquery.Node
+		}
+	}
+	lockListAssignedTasks sync.RWMutex
+	lockListTasks         sync.RWMutex
+	lockSearchTasks       sync.RWMutex
+}
+
+// ListAssignedTasks calls ListAssignedTasksFunc.
+func (mock *ListTaskServiceMock) ListAssignedTasks(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error) {
+	if mock.ListAssignedTasksFunc == nil {
+		panic("ListTaskServiceMock.ListAssignedTasksFunc: method is nil but ListTaskService.ListAssignedTasks was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		AssigneeID entity.UserID
+	}{
+		Ctx:        ctx,
+		AssigneeID: assigneeID,
 	}
-	lockListTasks sync.RWMutex
+	mock.lockListAssignedTasks.Lock()
+	mock.calls.ListAssignedTasks = append(mock.calls.ListAssignedTasks, callInfo)
+	mock.lockListAssignedTasks.Unlock()
+	return mock.ListAssignedTasksFunc(ctx, assigneeID)
+}
+
+// ListAssignedTasksCalls gets all the calls that were made to ListAssignedTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.ListAssignedTasksCalls())
+func (mock *ListTaskServiceMock) ListAssignedTasksCalls() []struct {
+	Ctx        context.Context
+	AssigneeID entity.UserID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		AssigneeID entity.UserID
+	}
+	mock.lockListAssignedTasks.RLock()
+	calls = mock.calls.ListAssignedTasks
+	mock.lockListAssignedTasks.RUnlock()
+	return calls
 }
 
 // ListTasks calls ListTasksFunc.
@@ -75,6 +143,42 @@ func (mock *ListTaskServiceMock) ListTasksCalls() []struct {
 	return calls
 }
 
+// SearchTasks calls SearchTasksFunc.
+func (mock *ListTaskServiceMock) SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+	if mock.SearchTasksFunc == nil {
+		panic("ListTaskServiceMock.SearchTasksFunc: method is nil but ListTaskService.SearchTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}{
+		Ctx: ctx,
+		Q:   q,
+	}
+	mock.lockSearchTasks.Lock()
+	mock.calls.SearchTasks = append(mock.calls.SearchTasks, callInfo)
+	mock.lockSearchTasks.Unlock()
+	return mock.SearchTasksFunc(ctx, q)
+}
+
+// SearchTasksCalls gets all the calls that were made to SearchTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.SearchTasksCalls())
+func (mock *ListTaskServiceMock) SearchTasksCalls() []struct {
+	Ctx context.Context
+	Q   taskquery.Node
+} {
+	var calls []struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}
+	mock.lockSearchTasks.RLock()
+	calls = mock.calls.SearchTasks
+	mock.lockSearchTasks.RUnlock()
+	return calls
+}
+
 // Ensure, that AddTaskServiceMock does implement AddTaskService.
 // If this is not the case, regenerate this file with moq.
 var _ AddTaskService = &AddTaskServiceMock{}
@@ -147,6 +251,78 @@ func (mock *AddTaskServiceMock) AddTaskCalls() []struct {
 	return calls
 }
 
+// Ensure, that QuickAddTaskServiceMock does implement QuickAddTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ QuickAddTaskService = &QuickAddTaskServiceMock{}
+
+// QuickAddTaskServiceMock is a mock implementation of QuickAddTaskService.
+//
+//	func TestSomethingThatUsesQuickAddTaskService(t *testing.T) {
+//
+//		// make and configure a mocked QuickAddTaskService
+//		mockedQuickAddTaskService := &QuickAddTaskServiceMock{
+//			QuickAddTaskFunc: func(ctx context.Context, text string) (*entity.Task, error) {
+//				panic("mock out the QuickAddTask method")
+//			},
+//		}
+//
+//		// use mockedQuickAddTaskService in code that requires QuickAddTaskService
+//		// and then make assertions.
+//
+//	}
+type QuickAddTaskServiceMock struct {
+	// QuickAddTaskFunc mocks the QuickAddTask method.
+	QuickAddTaskFunc func(ctx context.Context, text string) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// QuickAddTask holds details about calls to the QuickAddTask method.
+		QuickAddTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Text is the text argument value.
+			Text string
+		}
+	}
+	lockQuickAddTask sync.RWMutex
+}
+
+// QuickAddTask calls QuickAddTaskFunc.
+func (mock *QuickAddTaskServiceMock) QuickAddTask(ctx context.Context, text string) (*entity.Task, error) {
+	if mock.QuickAddTaskFunc == nil {
+		panic("QuickAddTaskServiceMock.QuickAddTaskFunc: method is nil but QuickAddTaskService.QuickAddTask was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Text string
+	}{
+		Ctx:  ctx,
+		Text: text,
+	}
+	mock.lockQuickAddTask.Lock()
+	mock.calls.QuickAddTask = append(mock.calls.QuickAddTask, callInfo)
+	mock.lockQuickAddTask.Unlock()
+	return mock.QuickAddTaskFunc(ctx, text)
+}
+
+// QuickAddTaskCalls gets all the calls that were made to QuickAddTask.
+// Check the length with:
+//
+//	len(mockedQuickAddTaskService.QuickAddTaskCalls())
+func (mock *QuickAddTaskServiceMock) QuickAddTaskCalls() []struct {
+	Ctx  context.Context
+	Text string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Text string
+	}
+	mock.lockQuickAddTask.RLock()
+	calls = mock.calls.QuickAddTask
+	mock.lockQuickAddTask.RUnlock()
+	return calls
+}
+
 // Ensure, that RegisterUserServiceMock does implement RegisterUserService.
 // If this is not the case, regenerate this file with moq.
 var _ RegisterUserService = &RegisterUserServiceMock{}
@@ -157,7 +333,7 @@ var _ RegisterUserService = &RegisterUserServiceMock{}
 //
 //		// make and configure a mocked RegisterUserService
 //		mockedRegisterUserService := &RegisterUserServiceMock{
-//			RegisterUserFunc: func(ctx context.Context, name string, password string, role string) (*entity.User, error) {
+//			RegisterUserFunc: func(ctx context.Context, name string, password string, role string, timezone string, email string) (*entity.User, error) {
 //				panic("mock out the RegisterUser method")
 //			},
 //		}
@@ -168,7 +344,7 @@ var _ RegisterUserService = &RegisterUserServiceMock{}
 //	}
 type RegisterUserServiceMock struct {
 	// RegisterUserFunc mocks the RegisterUser method.
-	RegisterUserFunc func(ctx context.Context, name string, password string, role string) (*entity.User, error)
+	RegisterUserFunc func(ctx context.Context, name string, password string, role string, timezone string, email string) (*entity.User, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -182,13 +358,17 @@ type RegisterUserServiceMock struct {
 			Password string
 			// Role is the role argument value.
 			Role string
+			// Timezone is the timezone argument value.
+			Timezone string
+			// Email is the email argument value.
+			Email string
 		}
 	}
 	lockRegisterUser sync.RWMutex
 }
 
 // RegisterUser calls RegisterUserFunc.
-func (mock *RegisterUserServiceMock) RegisterUser(ctx context.Context, name string, password string, role string) (*entity.User, error) {
+func (mock *RegisterUserServiceMock) RegisterUser(ctx context.Context, name string, password string, role string, timezone string, email string) (*entity.User, error) {
 	if mock.RegisterUserFunc == nil {
 		panic("RegisterUserServiceMock.RegisterUserFunc: method is nil but RegisterUserService.RegisterUser was just called")
 	}
@@ -197,16 +377,20 @@ func (mock *RegisterUserServiceMock) RegisterUser(ctx context.Context, name stri
 		Name     string
 		Password string
 		Role     string
+		Timezone string
+		Email    string
 	}{
 		Ctx:      ctx,
 		Name:     name,
 		Password: password,
 		Role:     role,
+		Timezone: timezone,
+		Email:    email,
 	}
 	mock.lockRegisterUser.Lock()
 	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
 	mock.lockRegisterUser.Unlock()
-	return mock.RegisterUserFunc(ctx, name, password, role)
+	return mock.RegisterUserFunc(ctx, name, password, role, timezone, email)
 }
 
 // RegisterUserCalls gets all the calls that were made to RegisterUser.
@@ -218,12 +402,16 @@ func (mock *RegisterUserServiceMock) RegisterUserCalls() []struct {
 	Name     string
 	Password string
 	Role     string
+	Timezone string
+	Email    string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		Name     string
 		Password string
 		Role     string
+		Timezone string
+		Email    string
 	}
 	mock.lockRegisterUser.RLock()
 	calls = mock.calls.RegisterUser
@@ -241,9 +429,15 @@ var _ LoginService = &LoginServiceMock{}
 //
 //		// make and configure a mocked LoginService
 //		mockedLoginService := &LoginServiceMock{
-//			LoginFunc: func(ctx context.Context, name string, password string) (string, error) {
+//			EnrollMFAFunc: func(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error) {
+//				panic("mock out the EnrollMFA method")
+//			},
+//			LoginFunc: func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
 //				panic("mock out the Login method")
 //			},
+//			LoginMFAFunc: func(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error) {
+//				panic("mock out the LoginMFA method")
+//			},
 //		}
 //
 //		// use mockedLoginService in code that requires LoginService
@@ -251,11 +445,24 @@ var _ LoginService = &LoginServiceMock{}
 //
 //	}
 type LoginServiceMock struct {
+	// EnrollMFAFunc mocks the EnrollMFA method.
+	EnrollMFAFunc func(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error)
+
 	// LoginFunc mocks the Login method.
-	LoginFunc func(ctx context.Context, name string, password string) (string, error)
+	LoginFunc func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error)
+
+	// LoginMFAFunc mocks the LoginMFA method.
+	LoginMFAFunc func(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// EnrollMFA holds details about calls to the EnrollMFA method.
+		EnrollMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MfaToken is the mfaToken argument value.
+			MfaToken string
+		}
 		// Login holds details about calls to the Login method.
 		Login []struct {
 			// Ctx is the ctx argument value.
@@ -264,13 +471,64 @@ type LoginServiceMock struct {
 			Name string
 			// Password is the password argument value.
 			Password string
+			// ClientIP is the clientIP argument value.
+			ClientIP string
+		}
+		// LoginMFA holds details about calls to the LoginMFA method.
+		LoginMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MfaToken is the mfaToken argument value.
+			MfaToken string
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
 		}
 	}
-	lockLogin sync.RWMutex
+	lockEnrollMFA sync.RWMutex
+	lockLogin     sync.RWMutex
+	lockLoginMFA  sync.RWMutex
+}
+
+// EnrollMFA calls EnrollMFAFunc.
+func (mock *LoginServiceMock) EnrollMFA(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error) {
+	if mock.EnrollMFAFunc == nil {
+		panic("LoginServiceMock.EnrollMFAFunc: method is nil but LoginService.EnrollMFA was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		MfaToken string
+	}{
+		Ctx:      ctx,
+		MfaToken: mfaToken,
+	}
+	mock.lockEnrollMFA.Lock()
+	mock.calls.EnrollMFA = append(mock.calls.EnrollMFA, callInfo)
+	mock.lockEnrollMFA.Unlock()
+	return mock.EnrollMFAFunc(ctx, mfaToken)
+}
+
+// EnrollMFACalls gets all the calls that were made to EnrollMFA.
+// Check the length with:
+//
+//	len(mockedLoginService.EnrollMFACalls())
+func (mock *LoginServiceMock) EnrollMFACalls() []struct {
+	Ctx      context.Context
+	MfaToken string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		MfaToken string
+	}
+	mock.lockEnrollMFA.RLock()
+	calls = mock.calls.EnrollMFA
+	mock.lockEnrollMFA.RUnlock()
+	return calls
 }
 
 // Login calls LoginFunc.
-func (mock *LoginServiceMock) Login(ctx context.Context, name string, password string) (string, error) {
+func (mock *LoginServiceMock) Login(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
 	if mock.LoginFunc == nil {
 		panic("LoginServiceMock.LoginFunc: method is nil but LoginService.Login was just called")
 	}
@@ -278,15 +536,17 @@ func (mock *LoginServiceMock) Login(ctx context.Context, name string, password s
 		Ctx      context.Context
 		Name     string
 		Password string
+		ClientIP string
 	}{
 		Ctx:      ctx,
 		Name:     name,
 		Password: password,
+		ClientIP: clientIP,
 	}
 	mock.lockLogin.Lock()
 	mock.calls.Login = append(mock.calls.Login, callInfo)
 	mock.lockLogin.Unlock()
-	return mock.LoginFunc(ctx, name, password)
+	return mock.LoginFunc(ctx, name, password, clientIP)
 }
 
 // LoginCalls gets all the calls that were made to Login.
@@ -297,14 +557,3213 @@ func (mock *LoginServiceMock) LoginCalls() []struct {
 	Ctx      context.Context
 	Name     string
 	Password string
+	ClientIP string
 } {
 	var calls []struct {
 		Ctx      context.Context
 		Name     string
 		Password string
+		ClientIP string
 	}
 	mock.lockLogin.RLock()
 	calls = mock.calls.Login
 	mock.lockLogin.RUnlock()
 	return calls
 }
+
+// LoginMFA calls LoginMFAFunc.
+func (mock *LoginServiceMock) LoginMFA(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error) {
+	if mock.LoginMFAFunc == nil {
+		panic("LoginServiceMock.LoginMFAFunc: method is nil but LoginService.LoginMFA was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		MfaToken     string
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		MfaToken:     mfaToken,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockLoginMFA.Lock()
+	mock.calls.LoginMFA = append(mock.calls.LoginMFA, callInfo)
+	mock.lockLoginMFA.Unlock()
+	return mock.LoginMFAFunc(ctx, mfaToken, code, recoveryCode)
+}
+
+// LoginMFACalls gets all the calls that were made to LoginMFA.
+// Check the length with:
+//
+//	len(mockedLoginService.LoginMFACalls())
+func (mock *LoginServiceMock) LoginMFACalls() []struct {
+	Ctx          context.Context
+	MfaToken     string
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		MfaToken     string
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockLoginMFA.RLock()
+	calls = mock.calls.LoginMFA
+	mock.lockLoginMFA.RUnlock()
+	return calls
+}
+
+// Ensure, that MFAServiceMock does implement MFAService.
+// If this is not the case, regenerate this file with moq.
+var _ MFAService = &MFAServiceMock{}
+
+// MFAServiceMock is a mock implementation of MFAService.
+//
+//	func TestSomethingThatUsesMFAService(t *testing.T) {
+//
+//		// make and configure a mocked MFAService
+//		mockedMFAService := &MFAServiceMock{
+//			ConfirmTOTPFunc: func(ctx context.Context, code string) ([]string, error) {
+//				panic("mock out the ConfirmTOTP method")
+//			},
+//			EnrollTOTPFunc: func(ctx context.Context) (*entity.TOTPEnrollment, error) {
+//				panic("mock out the EnrollTOTP method")
+//			},
+//		}
+//
+//		// use mockedMFAService in code that requires MFAService
+//		// and then make assertions.
+//
+//	}
+type MFAServiceMock struct {
+	// ConfirmTOTPFunc mocks the ConfirmTOTP method.
+	ConfirmTOTPFunc func(ctx context.Context, code string) ([]string, error)
+
+	// EnrollTOTPFunc mocks the EnrollTOTP method.
+	EnrollTOTPFunc func(ctx context.Context) (*entity.TOTPEnrollment, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConfirmTOTP holds details about calls to the ConfirmTOTP method.
+		ConfirmTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// EnrollTOTP holds details about calls to the EnrollTOTP method.
+		EnrollTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockConfirmTOTP sync.RWMutex
+	lockEnrollTOTP  sync.RWMutex
+}
+
+// ConfirmTOTP calls ConfirmTOTPFunc.
+func (mock *MFAServiceMock) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	if mock.ConfirmTOTPFunc == nil {
+		panic("MFAServiceMock.ConfirmTOTPFunc: method is nil but MFAService.ConfirmTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockConfirmTOTP.Lock()
+	mock.calls.ConfirmTOTP = append(mock.calls.ConfirmTOTP, callInfo)
+	mock.lockConfirmTOTP.Unlock()
+	return mock.ConfirmTOTPFunc(ctx, code)
+}
+
+// ConfirmTOTPCalls gets all the calls that were made to ConfirmTOTP.
+// Check the length with:
+//
+//	len(mockedMFAService.ConfirmTOTPCalls())
+func (mock *MFAServiceMock) ConfirmTOTPCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockConfirmTOTP.RLock()
+	calls = mock.calls.ConfirmTOTP
+	mock.lockConfirmTOTP.RUnlock()
+	return calls
+}
+
+// EnrollTOTP calls EnrollTOTPFunc.
+func (mock *MFAServiceMock) EnrollTOTP(ctx context.Context) (*entity.TOTPEnrollment, error) {
+	if mock.EnrollTOTPFunc == nil {
+		panic("MFAServiceMock.EnrollTOTPFunc: method is nil but MFAService.EnrollTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockEnrollTOTP.Lock()
+	mock.calls.EnrollTOTP = append(mock.calls.EnrollTOTP, callInfo)
+	mock.lockEnrollTOTP.Unlock()
+	return mock.EnrollTOTPFunc(ctx)
+}
+
+// EnrollTOTPCalls gets all the calls that were made to EnrollTOTP.
+// Check the length with:
+//
+//	len(mockedMFAService.EnrollTOTPCalls())
+func (mock *MFAServiceMock) EnrollTOTPCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockEnrollTOTP.RLock()
+	calls = mock.calls.EnrollTOTP
+	mock.lockEnrollTOTP.RUnlock()
+	return calls
+}
+
+// Ensure, that MFAPolicyServiceMock does implement MFAPolicyService.
+// If this is not the case, regenerate this file with moq.
+var _ MFAPolicyService = &MFAPolicyServiceMock{}
+
+// MFAPolicyServiceMock is a mock implementation of MFAPolicyService.
+//
+//	func TestSomethingThatUsesMFAPolicyService(t *testing.T) {
+//
+//		// make and configure a mocked MFAPolicyService
+//		mockedMFAPolicyService := &MFAPolicyServiceMock{
+//			MFAPolicyFunc: func(ctx context.Context) (*entity.MFAPolicy, error) {
+//				panic("mock out the MFAPolicy method")
+//			},
+//			UpdateMFAPolicyFunc: func(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error) {
+//				panic("mock out the UpdateMFAPolicy method")
+//			},
+//		}
+//
+//		// use mockedMFAPolicyService in code that requires MFAPolicyService
+//		// and then make assertions.
+//
+//	}
+type MFAPolicyServiceMock struct {
+	// MFAPolicyFunc mocks the MFAPolicy method.
+	MFAPolicyFunc func(ctx context.Context) (*entity.MFAPolicy, error)
+
+	// UpdateMFAPolicyFunc mocks the UpdateMFAPolicy method.
+	UpdateMFAPolicyFunc func(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MFAPolicy holds details about calls to the MFAPolicy method.
+		MFAPolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdateMFAPolicy holds details about calls to the UpdateMFAPolicy method.
+		UpdateMFAPolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Policy is the policy argument value.
+			Policy *entity.MFAPolicy
+		}
+	}
+	lockMFAPolicy       sync.RWMutex
+	lockUpdateMFAPolicy sync.RWMutex
+}
+
+// MFAPolicy calls MFAPolicyFunc.
+func (mock *MFAPolicyServiceMock) MFAPolicy(ctx context.Context) (*entity.MFAPolicy, error) {
+	if mock.MFAPolicyFunc == nil {
+		panic("MFAPolicyServiceMock.MFAPolicyFunc: method is nil but MFAPolicyService.MFAPolicy was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMFAPolicy.Lock()
+	mock.calls.MFAPolicy = append(mock.calls.MFAPolicy, callInfo)
+	mock.lockMFAPolicy.Unlock()
+	return mock.MFAPolicyFunc(ctx)
+}
+
+// MFAPolicyCalls gets all the calls that were made to MFAPolicy.
+// Check the length with:
+//
+//	len(mockedMFAPolicyService.MFAPolicyCalls())
+func (mock *MFAPolicyServiceMock) MFAPolicyCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockMFAPolicy.RLock()
+	calls = mock.calls.MFAPolicy
+	mock.lockMFAPolicy.RUnlock()
+	return calls
+}
+
+// UpdateMFAPolicy calls UpdateMFAPolicyFunc.
+func (mock *MFAPolicyServiceMock) UpdateMFAPolicy(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error) {
+	if mock.UpdateMFAPolicyFunc == nil {
+		panic("MFAPolicyServiceMock.UpdateMFAPolicyFunc: method is nil but MFAPolicyService.UpdateMFAPolicy was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Policy *entity.MFAPolicy
+	}{
+		Ctx:    ctx,
+		Policy: policy,
+	}
+	mock.lockUpdateMFAPolicy.Lock()
+	mock.calls.UpdateMFAPolicy = append(mock.calls.UpdateMFAPolicy, callInfo)
+	mock.lockUpdateMFAPolicy.Unlock()
+	return mock.UpdateMFAPolicyFunc(ctx, policy)
+}
+
+// UpdateMFAPolicyCalls gets all the calls that were made to UpdateMFAPolicy.
+// Check the length with:
+//
+//	len(mockedMFAPolicyService.UpdateMFAPolicyCalls())
+func (mock *MFAPolicyServiceMock) UpdateMFAPolicyCalls() []struct {
+	Ctx    context.Context
+	Policy *entity.MFAPolicy
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Policy *entity.MFAPolicy
+	}
+	mock.lockUpdateMFAPolicy.RLock()
+	calls = mock.calls.UpdateMFAPolicy
+	mock.lockUpdateMFAPolicy.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenServiceMock does implement RefreshTokenService.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenService = &RefreshTokenServiceMock{}
+
+// RefreshTokenServiceMock is a mock implementation of RefreshTokenService.
+//
+//	func TestSomethingThatUsesRefreshTokenService(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenService
+//		mockedRefreshTokenService := &RefreshTokenServiceMock{
+//			RefreshTokenFunc: func(ctx context.Context, token string) (*entity.Tokens, error) {
+//				panic("mock out the RefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenService in code that requires RefreshTokenService
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenServiceMock struct {
+	// RefreshTokenFunc mocks the RefreshToken method.
+	RefreshTokenFunc func(ctx context.Context, token string) (*entity.Tokens, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RefreshToken holds details about calls to the RefreshToken method.
+		RefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockRefreshToken sync.RWMutex
+}
+
+// RefreshToken calls RefreshTokenFunc.
+func (mock *RefreshTokenServiceMock) RefreshToken(ctx context.Context, token string) (*entity.Tokens, error) {
+	if mock.RefreshTokenFunc == nil {
+		panic("RefreshTokenServiceMock.RefreshTokenFunc: method is nil but RefreshTokenService.RefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockRefreshToken.Lock()
+	mock.calls.RefreshToken = append(mock.calls.RefreshToken, callInfo)
+	mock.lockRefreshToken.Unlock()
+	return mock.RefreshTokenFunc(ctx, token)
+}
+
+// RefreshTokenCalls gets all the calls that were made to RefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenService.RefreshTokenCalls())
+func (mock *RefreshTokenServiceMock) RefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockRefreshToken.RLock()
+	calls = mock.calls.RefreshToken
+	mock.lockRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that LogoutServiceMock does implement LogoutService.
+// If this is not the case, regenerate this file with moq.
+var _ LogoutService = &LogoutServiceMock{}
+
+// LogoutServiceMock is a mock implementation of LogoutService.
+//
+//	func TestSomethingThatUsesLogoutService(t *testing.T) {
+//
+//		// make and configure a mocked LogoutService
+//		mockedLogoutService := &LogoutServiceMock{
+//			LogoutFunc: func(ctx context.Context, refreshToken string) error {
+//				panic("mock out the Logout method")
+//			},
+//			LogoutAllFunc: func(ctx context.Context) error {
+//				panic("mock out the LogoutAll method")
+//			},
+//		}
+//
+//		// use mockedLogoutService in code that requires LogoutService
+//		// and then make assertions.
+//
+//	}
+type LogoutServiceMock struct {
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, refreshToken string) error
+
+	// LogoutAllFunc mocks the LogoutAll method.
+	LogoutAllFunc func(ctx context.Context) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// LogoutAll holds details about calls to the LogoutAll method.
+		LogoutAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockLogout    sync.RWMutex
+	lockLogoutAll sync.RWMutex
+}
+
+// Logout calls LogoutFunc.
+func (mock *LogoutServiceMock) Logout(ctx context.Context, refreshToken string) error {
+	if mock.LogoutFunc == nil {
+		panic("LogoutServiceMock.LogoutFunc: method is nil but LogoutService.Logout was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	return mock.LogoutFunc(ctx, refreshToken)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedLogoutService.LogoutCalls())
+func (mock *LogoutServiceMock) LogoutCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// LogoutAll calls LogoutAllFunc.
+func (mock *LogoutServiceMock) LogoutAll(ctx context.Context) error {
+	if mock.LogoutAllFunc == nil {
+		panic("LogoutServiceMock.LogoutAllFunc: method is nil but LogoutService.LogoutAll was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLogoutAll.Lock()
+	mock.calls.LogoutAll = append(mock.calls.LogoutAll, callInfo)
+	mock.lockLogoutAll.Unlock()
+	return mock.LogoutAllFunc(ctx)
+}
+
+// LogoutAllCalls gets all the calls that were made to LogoutAll.
+// Check the length with:
+//
+//	len(mockedLogoutService.LogoutAllCalls())
+func (mock *LogoutServiceMock) LogoutAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLogoutAll.RLock()
+	calls = mock.calls.LogoutAll
+	mock.lockLogoutAll.RUnlock()
+	return calls
+}
+
+// Ensure, that RevokeSessionsServiceMock does implement RevokeSessionsService.
+// If this is not the case, regenerate this file with moq.
+var _ RevokeSessionsService = &RevokeSessionsServiceMock{}
+
+// RevokeSessionsServiceMock is a mock implementation of RevokeSessionsService.
+//
+//	func TestSomethingThatUsesRevokeSessionsService(t *testing.T) {
+//
+//		// make and configure a mocked RevokeSessionsService
+//		mockedRevokeSessionsService := &RevokeSessionsServiceMock{
+//			RevokeSessionsFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the RevokeSessions method")
+//			},
+//		}
+//
+//		// use mockedRevokeSessionsService in code that requires RevokeSessionsService
+//		// and then make assertions.
+//
+//	}
+type RevokeSessionsServiceMock struct {
+	// RevokeSessionsFunc mocks the RevokeSessions method.
+	RevokeSessionsFunc func(ctx context.Context, userID entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeSessions holds details about calls to the RevokeSessions method.
+		RevokeSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockRevokeSessions sync.RWMutex
+}
+
+// RevokeSessions calls RevokeSessionsFunc.
+func (mock *RevokeSessionsServiceMock) RevokeSessions(ctx context.Context, userID entity.UserID) error {
+	if mock.RevokeSessionsFunc == nil {
+		panic("RevokeSessionsServiceMock.RevokeSessionsFunc: method is nil but RevokeSessionsService.RevokeSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeSessions.Lock()
+	mock.calls.RevokeSessions = append(mock.calls.RevokeSessions, callInfo)
+	mock.lockRevokeSessions.Unlock()
+	return mock.RevokeSessionsFunc(ctx, userID)
+}
+
+// RevokeSessionsCalls gets all the calls that were made to RevokeSessions.
+// Check the length with:
+//
+//	len(mockedRevokeSessionsService.RevokeSessionsCalls())
+func (mock *RevokeSessionsServiceMock) RevokeSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockRevokeSessions.RLock()
+	calls = mock.calls.RevokeSessions
+	mock.lockRevokeSessions.RUnlock()
+	return calls
+}
+
+// Ensure, that PasswordResetServiceMock does implement PasswordResetService.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetService = &PasswordResetServiceMock{}
+
+// PasswordResetServiceMock is a mock implementation of PasswordResetService.
+//
+//	func TestSomethingThatUsesPasswordResetService(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetService
+//		mockedPasswordResetService := &PasswordResetServiceMock{
+//			ForgotPasswordFunc: func(ctx context.Context, email string, clientIP string) error {
+//				panic("mock out the ForgotPassword method")
+//			},
+//			ResetPasswordFunc: func(ctx context.Context, token string, password string) error {
+//				panic("mock out the ResetPassword method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetService in code that requires PasswordResetService
+//		// and then make assertions.
+//
+//	}
+type PasswordResetServiceMock struct {
+	// ForgotPasswordFunc mocks the ForgotPassword method.
+	ForgotPasswordFunc func(ctx context.Context, email string, clientIP string) error
+
+	// ResetPasswordFunc mocks the ResetPassword method.
+	ResetPasswordFunc func(ctx context.Context, token string, password string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ForgotPassword holds details about calls to the ForgotPassword method.
+		ForgotPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+			// ClientIP is the clientIP argument value.
+			ClientIP string
+		}
+		// ResetPassword holds details about calls to the ResetPassword method.
+		ResetPassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockForgotPassword sync.RWMutex
+	lockResetPassword  sync.RWMutex
+}
+
+// ForgotPassword calls ForgotPasswordFunc.
+func (mock *PasswordResetServiceMock) ForgotPassword(ctx context.Context, email string, clientIP string) error {
+	if mock.ForgotPasswordFunc == nil {
+		panic("PasswordResetServiceMock.ForgotPasswordFunc: method is nil but PasswordResetService.ForgotPassword was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Email    string
+		ClientIP string
+	}{
+		Ctx:      ctx,
+		Email:    email,
+		ClientIP: clientIP,
+	}
+	mock.lockForgotPassword.Lock()
+	mock.calls.ForgotPassword = append(mock.calls.ForgotPassword, callInfo)
+	mock.lockForgotPassword.Unlock()
+	return mock.ForgotPasswordFunc(ctx, email, clientIP)
+}
+
+// ForgotPasswordCalls gets all the calls that were made to ForgotPassword.
+// Check the length with:
+//
+//	len(mockedPasswordResetService.ForgotPasswordCalls())
+func (mock *PasswordResetServiceMock) ForgotPasswordCalls() []struct {
+	Ctx      context.Context
+	Email    string
+	ClientIP string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Email    string
+		ClientIP string
+	}
+	mock.lockForgotPassword.RLock()
+	calls = mock.calls.ForgotPassword
+	mock.lockForgotPassword.RUnlock()
+	return calls
+}
+
+// ResetPassword calls ResetPasswordFunc.
+func (mock *PasswordResetServiceMock) ResetPassword(ctx context.Context, token string, password string) error {
+	if mock.ResetPasswordFunc == nil {
+		panic("PasswordResetServiceMock.ResetPasswordFunc: method is nil but PasswordResetService.ResetPassword was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Token    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Token:    token,
+		Password: password,
+	}
+	mock.lockResetPassword.Lock()
+	mock.calls.ResetPassword = append(mock.calls.ResetPassword, callInfo)
+	mock.lockResetPassword.Unlock()
+	return mock.ResetPasswordFunc(ctx, token, password)
+}
+
+// ResetPasswordCalls gets all the calls that were made to ResetPassword.
+// Check the length with:
+//
+//	len(mockedPasswordResetService.ResetPasswordCalls())
+func (mock *PasswordResetServiceMock) ResetPasswordCalls() []struct {
+	Ctx      context.Context
+	Token    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Token    string
+		Password string
+	}
+	mock.lockResetPassword.RLock()
+	calls = mock.calls.ResetPassword
+	mock.lockResetPassword.RUnlock()
+	return calls
+}
+
+// Ensure, that JWKSServiceMock does implement JWKSService.
+// If this is not the case, regenerate this file with moq.
+var _ JWKSService = &JWKSServiceMock{}
+
+// JWKSServiceMock is a mock implementation of JWKSService.
+//
+//	func TestSomethingThatUsesJWKSService(t *testing.T) {
+//
+//		// make and configure a mocked JWKSService
+//		mockedJWKSService := &JWKSServiceMock{
+//			PublicKeysFunc: func() jwk.Set {
+//				panic("mock out the PublicKeys method")
+//			},
+//		}
+//
+//		// use mockedJWKSService in code that requires JWKSService
+//		// and then make assertions.
+//
+//	}
+type JWKSServiceMock struct {
+	// PublicKeysFunc mocks the PublicKeys method.
+	PublicKeysFunc func() jwk.Set
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// PublicKeys holds details about calls to the PublicKeys method.
+		PublicKeys []struct {
+		}
+	}
+	lockPublicKeys sync.RWMutex
+}
+
+// PublicKeys calls PublicKeysFunc.
+func (mock *JWKSServiceMock) PublicKeys() jwk.Set {
+	if mock.PublicKeysFunc == nil {
+		panic("JWKSServiceMock.PublicKeysFunc: method is nil but JWKSService.PublicKeys was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockPublicKeys.Lock()
+	mock.calls.PublicKeys = append(mock.calls.PublicKeys, callInfo)
+	mock.lockPublicKeys.Unlock()
+	return mock.PublicKeysFunc()
+}
+
+// PublicKeysCalls gets all the calls that were made to PublicKeys.
+// Check the length with:
+//
+//	len(mockedJWKSService.PublicKeysCalls())
+func (mock *JWKSServiceMock) PublicKeysCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPublicKeys.RLock()
+	calls = mock.calls.PublicKeys
+	mock.lockPublicKeys.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCLoginServiceMock does implement OIDCLoginService.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCLoginService = &OIDCLoginServiceMock{}
+
+// OIDCLoginServiceMock is a mock implementation of OIDCLoginService.
+//
+//	func TestSomethingThatUsesOIDCLoginService(t *testing.T) {
+//
+//		// make and configure a mocked OIDCLoginService
+//		mockedOIDCLoginService := &OIDCLoginServiceMock{
+//			OIDCAuthURLFunc: func(ctx context.Context) (string, string, error) {
+//				panic("mock out the OIDCAuthURL method")
+//			},
+//			OIDCCallbackFunc: func(ctx context.Context, code string, state string) (*entity.LoginResult, error) {
+//				panic("mock out the OIDCCallback method")
+//			},
+//		}
+//
+//		// use mockedOIDCLoginService in code that requires OIDCLoginService
+//		// and then make assertions.
+//
+//	}
+type OIDCLoginServiceMock struct {
+	// OIDCAuthURLFunc mocks the OIDCAuthURL method.
+	OIDCAuthURLFunc func(ctx context.Context) (string, string, error)
+
+	// OIDCCallbackFunc mocks the OIDCCallback method.
+	OIDCCallbackFunc func(ctx context.Context, code string, state string) (*entity.LoginResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// OIDCAuthURL holds details about calls to the OIDCAuthURL method.
+		OIDCAuthURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// OIDCCallback holds details about calls to the OIDCCallback method.
+		OIDCCallback []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+			// State is the state argument value.
+			State string
+		}
+	}
+	lockOIDCAuthURL  sync.RWMutex
+	lockOIDCCallback sync.RWMutex
+}
+
+// OIDCAuthURL calls OIDCAuthURLFunc.
+func (mock *OIDCLoginServiceMock) OIDCAuthURL(ctx context.Context) (string, string, error) {
+	if mock.OIDCAuthURLFunc == nil {
+		panic("OIDCLoginServiceMock.OIDCAuthURLFunc: method is nil but OIDCLoginService.OIDCAuthURL was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockOIDCAuthURL.Lock()
+	mock.calls.OIDCAuthURL = append(mock.calls.OIDCAuthURL, callInfo)
+	mock.lockOIDCAuthURL.Unlock()
+	return mock.OIDCAuthURLFunc(ctx)
+}
+
+// OIDCAuthURLCalls gets all the calls that were made to OIDCAuthURL.
+// Check the length with:
+//
+//	len(mockedOIDCLoginService.OIDCAuthURLCalls())
+func (mock *OIDCLoginServiceMock) OIDCAuthURLCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockOIDCAuthURL.RLock()
+	calls = mock.calls.OIDCAuthURL
+	mock.lockOIDCAuthURL.RUnlock()
+	return calls
+}
+
+// OIDCCallback calls OIDCCallbackFunc.
+func (mock *OIDCLoginServiceMock) OIDCCallback(ctx context.Context, code string, state string) (*entity.LoginResult, error) {
+	if mock.OIDCCallbackFunc == nil {
+		panic("OIDCLoginServiceMock.OIDCCallbackFunc: method is nil but OIDCLoginService.OIDCCallback was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Code  string
+		State string
+	}{
+		Ctx:   ctx,
+		Code:  code,
+		State: state,
+	}
+	mock.lockOIDCCallback.Lock()
+	mock.calls.OIDCCallback = append(mock.calls.OIDCCallback, callInfo)
+	mock.lockOIDCCallback.Unlock()
+	return mock.OIDCCallbackFunc(ctx, code, state)
+}
+
+// OIDCCallbackCalls gets all the calls that were made to OIDCCallback.
+// Check the length with:
+//
+//	len(mockedOIDCLoginService.OIDCCallbackCalls())
+func (mock *OIDCLoginServiceMock) OIDCCallbackCalls() []struct {
+	Ctx   context.Context
+	Code  string
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Code  string
+		State string
+	}
+	mock.lockOIDCCallback.RLock()
+	calls = mock.calls.OIDCCallback
+	mock.lockOIDCCallback.RUnlock()
+	return calls
+}
+
+// Ensure, that AddTemplateServiceMock does implement AddTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ AddTemplateService = &AddTemplateServiceMock{}
+
+// AddTemplateServiceMock is a mock implementation of AddTemplateService.
+//
+//	func TestSomethingThatUsesAddTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked AddTemplateService
+//		mockedAddTemplateService := &AddTemplateServiceMock{
+//			AddTemplateFunc: func(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error) {
+//				panic("mock out the AddTemplate method")
+//			},
+//		}
+//
+//		// use mockedAddTemplateService in code that requires AddTemplateService
+//		// and then make assertions.
+//
+//	}
+type AddTemplateServiceMock struct {
+	// AddTemplateFunc mocks the AddTemplate method.
+	AddTemplateFunc func(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTemplate holds details about calls to the AddTemplate method.
+		AddTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Title is the title argument value.
+			Title string
+			// Items is the items argument value.
+			Items []*entity.TemplateItem
+		}
+	}
+	lockAddTemplate sync.RWMutex
+}
+
+// AddTemplate calls AddTemplateFunc.
+func (mock *AddTemplateServiceMock) AddTemplate(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error) {
+	if mock.AddTemplateFunc == nil {
+		panic("AddTemplateServiceMock.AddTemplateFunc: method is nil but AddTemplateService.AddTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Title string
+		Items []*entity.TemplateItem
+	}{
+		Ctx:   ctx,
+		Title: title,
+		Items: items,
+	}
+	mock.lockAddTemplate.Lock()
+	mock.calls.AddTemplate = append(mock.calls.AddTemplate, callInfo)
+	mock.lockAddTemplate.Unlock()
+	return mock.AddTemplateFunc(ctx, title, items)
+}
+
+// AddTemplateCalls gets all the calls that were made to AddTemplate.
+// Check the length with:
+//
+//	len(mockedAddTemplateService.AddTemplateCalls())
+func (mock *AddTemplateServiceMock) AddTemplateCalls() []struct {
+	Ctx   context.Context
+	Title string
+	Items []*entity.TemplateItem
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Title string
+		Items []*entity.TemplateItem
+	}
+	mock.lockAddTemplate.RLock()
+	calls = mock.calls.AddTemplate
+	mock.lockAddTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that ListTemplatesServiceMock does implement ListTemplatesService.
+// If this is not the case, regenerate this file with moq.
+var _ ListTemplatesService = &ListTemplatesServiceMock{}
+
+// ListTemplatesServiceMock is a mock implementation of ListTemplatesService.
+//
+//	func TestSomethingThatUsesListTemplatesService(t *testing.T) {
+//
+//		// make and configure a mocked ListTemplatesService
+//		mockedListTemplatesService := &ListTemplatesServiceMock{
+//			ListTemplatesFunc: func(ctx context.Context) (entity.Templates, error) {
+//				panic("mock out the ListTemplates method")
+//			},
+//		}
+//
+//		// use mockedListTemplatesService in code that requires ListTemplatesService
+//		// and then make assertions.
+//
+//	}
+type ListTemplatesServiceMock struct {
+	// ListTemplatesFunc mocks the ListTemplates method.
+	ListTemplatesFunc func(ctx context.Context) (entity.Templates, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTemplates holds details about calls to the ListTemplates method.
+		ListTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListTemplates sync.RWMutex
+}
+
+// ListTemplates calls ListTemplatesFunc.
+func (mock *ListTemplatesServiceMock) ListTemplates(ctx context.Context) (entity.Templates, error) {
+	if mock.ListTemplatesFunc == nil {
+		panic("ListTemplatesServiceMock.ListTemplatesFunc: method is nil but ListTemplatesService.ListTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListTemplates.Lock()
+	mock.calls.ListTemplates = append(mock.calls.ListTemplates, callInfo)
+	mock.lockListTemplates.Unlock()
+	return mock.ListTemplatesFunc(ctx)
+}
+
+// ListTemplatesCalls gets all the calls that were made to ListTemplates.
+// Check the length with:
+//
+//	len(mockedListTemplatesService.ListTemplatesCalls())
+func (mock *ListTemplatesServiceMock) ListTemplatesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListTemplates.RLock()
+	calls = mock.calls.ListTemplates
+	mock.lockListTemplates.RUnlock()
+	return calls
+}
+
+// Ensure, that InstantiateTemplateServiceMock does implement InstantiateTemplateService.
+// If this is not the case, regenerate this file with moq.
+var _ InstantiateTemplateService = &InstantiateTemplateServiceMock{}
+
+// InstantiateTemplateServiceMock is a mock implementation of InstantiateTemplateService.
+//
+//	func TestSomethingThatUsesInstantiateTemplateService(t *testing.T) {
+//
+//		// make and configure a mocked InstantiateTemplateService
+//		mockedInstantiateTemplateService := &InstantiateTemplateServiceMock{
+//			InstantiateTemplateFunc: func(ctx context.Context, id entity.TemplateID) (entity.Tasks, error) {
+//				panic("mock out the InstantiateTemplate method")
+//			},
+//		}
+//
+//		// use mockedInstantiateTemplateService in code that requires InstantiateTemplateService
+//		// and then make assertions.
+//
+//	}
+type InstantiateTemplateServiceMock struct {
+	// InstantiateTemplateFunc mocks the InstantiateTemplate method.
+	InstantiateTemplateFunc func(ctx context.Context, id entity.TemplateID) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// InstantiateTemplate holds details about calls to the InstantiateTemplate method.
+		InstantiateTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TemplateID
+		}
+	}
+	lockInstantiateTemplate sync.RWMutex
+}
+
+// InstantiateTemplate calls InstantiateTemplateFunc.
+func (mock *InstantiateTemplateServiceMock) InstantiateTemplate(ctx context.Context, id entity.TemplateID) (entity.Tasks, error) {
+	if mock.InstantiateTemplateFunc == nil {
+		panic("InstantiateTemplateServiceMock.InstantiateTemplateFunc: method is nil but InstantiateTemplateService.InstantiateTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.TemplateID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockInstantiateTemplate.Lock()
+	mock.calls.InstantiateTemplate = append(mock.calls.InstantiateTemplate, callInfo)
+	mock.lockInstantiateTemplate.Unlock()
+	return mock.InstantiateTemplateFunc(ctx, id)
+}
+
+// InstantiateTemplateCalls gets all the calls that were made to InstantiateTemplate.
+// Check the length with:
+//
+//	len(mockedInstantiateTemplateService.InstantiateTemplateCalls())
+func (mock *InstantiateTemplateServiceMock) InstantiateTemplateCalls() []struct {
+	Ctx context.Context
+	ID  entity.TemplateID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.TemplateID
+	}
+	mock.lockInstantiateTemplate.RLock()
+	calls = mock.calls.InstantiateTemplate
+	mock.lockInstantiateTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that StartTimerServiceMock does implement StartTimerService.
+// If this is not the case, regenerate this file with moq.
+var _ StartTimerService = &StartTimerServiceMock{}
+
+// StartTimerServiceMock is a mock implementation of StartTimerService.
+//
+//	func TestSomethingThatUsesStartTimerService(t *testing.T) {
+//
+//		// make and configure a mocked StartTimerService
+//		mockedStartTimerService := &StartTimerServiceMock{
+//			StartTimerFunc: func(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+//				panic("mock out the StartTimer method")
+//			},
+//		}
+//
+//		// use mockedStartTimerService in code that requires StartTimerService
+//		// and then make assertions.
+//
+//	}
+type StartTimerServiceMock struct {
+	// StartTimerFunc mocks the StartTimer method.
+	StartTimerFunc func(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// StartTimer holds details about calls to the StartTimer method.
+		StartTimer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+		}
+	}
+	lockStartTimer sync.RWMutex
+}
+
+// StartTimer calls StartTimerFunc.
+func (mock *StartTimerServiceMock) StartTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+	if mock.StartTimerFunc == nil {
+		panic("StartTimerServiceMock.StartTimerFunc: method is nil but StartTimerService.StartTimer was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+	}
+	mock.lockStartTimer.Lock()
+	mock.calls.StartTimer = append(mock.calls.StartTimer, callInfo)
+	mock.lockStartTimer.Unlock()
+	return mock.StartTimerFunc(ctx, taskID)
+}
+
+// StartTimerCalls gets all the calls that were made to StartTimer.
+// Check the length with:
+//
+//	len(mockedStartTimerService.StartTimerCalls())
+func (mock *StartTimerServiceMock) StartTimerCalls() []struct {
+	Ctx    context.Context
+	TaskID entity.TaskID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+	}
+	mock.lockStartTimer.RLock()
+	calls = mock.calls.StartTimer
+	mock.lockStartTimer.RUnlock()
+	return calls
+}
+
+// Ensure, that StopTimerServiceMock does implement StopTimerService.
+// If this is not the case, regenerate this file with moq.
+var _ StopTimerService = &StopTimerServiceMock{}
+
+// StopTimerServiceMock is a mock implementation of StopTimerService.
+//
+//	func TestSomethingThatUsesStopTimerService(t *testing.T) {
+//
+//		// make and configure a mocked StopTimerService
+//		mockedStopTimerService := &StopTimerServiceMock{
+//			StopTimerFunc: func(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+//				panic("mock out the StopTimer method")
+//			},
+//		}
+//
+//		// use mockedStopTimerService in code that requires StopTimerService
+//		// and then make assertions.
+//
+//	}
+type StopTimerServiceMock struct {
+	// StopTimerFunc mocks the StopTimer method.
+	StopTimerFunc func(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// StopTimer holds details about calls to the StopTimer method.
+		StopTimer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+		}
+	}
+	lockStopTimer sync.RWMutex
+}
+
+// StopTimer calls StopTimerFunc.
+func (mock *StopTimerServiceMock) StopTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+	if mock.StopTimerFunc == nil {
+		panic("StopTimerServiceMock.StopTimerFunc: method is nil but StopTimerService.StopTimer was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+	}{
+		Ctx:    ctx,
+		TaskID: taskID,
+	}
+	mock.lockStopTimer.Lock()
+	mock.calls.StopTimer = append(mock.calls.StopTimer, callInfo)
+	mock.lockStopTimer.Unlock()
+	return mock.StopTimerFunc(ctx, taskID)
+}
+
+// StopTimerCalls gets all the calls that were made to StopTimer.
+// Check the length with:
+//
+//	len(mockedStopTimerService.StopTimerCalls())
+func (mock *StopTimerServiceMock) StopTimerCalls() []struct {
+	Ctx    context.Context
+	TaskID entity.TaskID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		TaskID entity.TaskID
+	}
+	mock.lockStopTimer.RLock()
+	calls = mock.calls.StopTimer
+	mock.lockStopTimer.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateTimeEntryServiceMock does implement UpdateTimeEntryService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateTimeEntryService = &UpdateTimeEntryServiceMock{}
+
+// UpdateTimeEntryServiceMock is a mock implementation of UpdateTimeEntryService.
+//
+//	func TestSomethingThatUsesUpdateTimeEntryService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateTimeEntryService
+//		mockedUpdateTimeEntryService := &UpdateTimeEntryServiceMock{
+//			UpdateTimeEntryFunc: func(ctx context.Context, id entity.TimeEntryID, startedAt time.Time, stoppedAt *time.Time) (*entity.TimeEntry, error) {
+//				panic("mock out the UpdateTimeEntry method")
+//			},
+//		}
+//
+//		// use mockedUpdateTimeEntryService in code that requires UpdateTimeEntryService
+//		// and then make assertions.
+//
+//	}
+type UpdateTimeEntryServiceMock struct {
+	// UpdateTimeEntryFunc mocks the UpdateTimeEntry method.
+	UpdateTimeEntryFunc func(ctx context.Context, id entity.TimeEntryID, startedAt time.Time, stoppedAt *time.Time) (*entity.TimeEntry, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateTimeEntry holds details about calls to the UpdateTimeEntry method.
+		UpdateTimeEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TimeEntryID
+			// StartedAt is the startedAt argument value.
+			StartedAt time.Time
+			// StoppedAt is the stoppedAt argument value.
+			StoppedAt *time.Time
+		}
+	}
+	lockUpdateTimeEntry sync.RWMutex
+}
+
+// UpdateTimeEntry calls UpdateTimeEntryFunc.
+func (mock *UpdateTimeEntryServiceMock) UpdateTimeEntry(ctx context.Context, id entity.TimeEntryID, startedAt time.Time, stoppedAt *time.Time) (*entity.TimeEntry, error) {
+	if mock.UpdateTimeEntryFunc == nil {
+		panic("UpdateTimeEntryServiceMock.UpdateTimeEntryFunc: method is nil but UpdateTimeEntryService.UpdateTimeEntry was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        entity.TimeEntryID
+		StartedAt time.Time
+		StoppedAt *time.Time
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		StartedAt: startedAt,
+		StoppedAt: stoppedAt,
+	}
+	mock.lockUpdateTimeEntry.Lock()
+	mock.calls.UpdateTimeEntry = append(mock.calls.UpdateTimeEntry, callInfo)
+	mock.lockUpdateTimeEntry.Unlock()
+	return mock.UpdateTimeEntryFunc(ctx, id, startedAt, stoppedAt)
+}
+
+// UpdateTimeEntryCalls gets all the calls that were made to UpdateTimeEntry.
+// Check the length with:
+//
+//	len(mockedUpdateTimeEntryService.UpdateTimeEntryCalls())
+func (mock *UpdateTimeEntryServiceMock) UpdateTimeEntryCalls() []struct {
+	Ctx       context.Context
+	ID        entity.TimeEntryID
+	StartedAt time.Time
+	StoppedAt *time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        entity.TimeEntryID
+		StartedAt time.Time
+		StoppedAt *time.Time
+	}
+	mock.lockUpdateTimeEntry.RLock()
+	calls = mock.calls.UpdateTimeEntry
+	mock.lockUpdateTimeEntry.RUnlock()
+	return calls
+}
+
+// Ensure, that TimeReportServiceMock does implement TimeReportService.
+// If this is not the case, regenerate this file with moq.
+var _ TimeReportService = &TimeReportServiceMock{}
+
+// TimeReportServiceMock is a mock implementation of TimeReportService.
+//
+//	func TestSomethingThatUsesTimeReportService(t *testing.T) {
+//
+//		// make and configure a mocked TimeReportService
+//		mockedTimeReportService := &TimeReportServiceMock{
+//			TimeReportFunc: func(ctx context.Context, from time.Time, to time.Time) (*entity.TimeReport, error) {
+//				panic("mock out the TimeReport method")
+//			},
+//		}
+//
+//		// use mockedTimeReportService in code that requires TimeReportService
+//		// and then make assertions.
+//
+//	}
+type TimeReportServiceMock struct {
+	// TimeReportFunc mocks the TimeReport method.
+	TimeReportFunc func(ctx context.Context, from time.Time, to time.Time) (*entity.TimeReport, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// TimeReport holds details about calls to the TimeReport method.
+		TimeReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+	}
+	lockTimeReport sync.RWMutex
+}
+
+// TimeReport calls TimeReportFunc.
+func (mock *TimeReportServiceMock) TimeReport(ctx context.Context, from time.Time, to time.Time) (*entity.TimeReport, error) {
+	if mock.TimeReportFunc == nil {
+		panic("TimeReportServiceMock.TimeReportFunc: method is nil but TimeReportService.TimeReport was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}{
+		Ctx:  ctx,
+		From: from,
+		To:   to,
+	}
+	mock.lockTimeReport.Lock()
+	mock.calls.TimeReport = append(mock.calls.TimeReport, callInfo)
+	mock.lockTimeReport.Unlock()
+	return mock.TimeReportFunc(ctx, from, to)
+}
+
+// TimeReportCalls gets all the calls that were made to TimeReport.
+// Check the length with:
+//
+//	len(mockedTimeReportService.TimeReportCalls())
+func (mock *TimeReportServiceMock) TimeReportCalls() []struct {
+	Ctx  context.Context
+	From time.Time
+	To   time.Time
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}
+	mock.lockTimeReport.RLock()
+	calls = mock.calls.TimeReport
+	mock.lockTimeReport.RUnlock()
+	return calls
+}
+
+// Ensure, that UpdateTaskStatusServiceMock does implement UpdateTaskStatusService.
+// If this is not the case, regenerate this file with moq.
+var _ UpdateTaskStatusService = &UpdateTaskStatusServiceMock{}
+
+// UpdateTaskStatusServiceMock is a mock implementation of UpdateTaskStatusService.
+//
+//	func TestSomethingThatUsesUpdateTaskStatusService(t *testing.T) {
+//
+//		// make and configure a mocked UpdateTaskStatusService
+//		mockedUpdateTaskStatusService := &UpdateTaskStatusServiceMock{
+//			UpdateTaskStatusFunc: func(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error {
+//				panic("mock out the UpdateTaskStatus method")
+//			},
+//		}
+//
+//		// use mockedUpdateTaskStatusService in code that requires UpdateTaskStatusService
+//		// and then make assertions.
+//
+//	}
+type UpdateTaskStatusServiceMock struct {
+	// UpdateTaskStatusFunc mocks the UpdateTaskStatus method.
+	UpdateTaskStatusFunc func(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// UpdateTaskStatus holds details about calls to the UpdateTaskStatus method.
+		UpdateTaskStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
+	}
+	lockUpdateTaskStatus sync.RWMutex
+}
+
+// UpdateTaskStatus calls UpdateTaskStatusFunc.
+func (mock *UpdateTaskStatusServiceMock) UpdateTaskStatus(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error {
+	if mock.UpdateTaskStatusFunc == nil {
+		panic("UpdateTaskStatusServiceMock.UpdateTaskStatusFunc: method is nil but UpdateTaskStatusService.UpdateTaskStatus was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     entity.TaskID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Status: status,
+	}
+	mock.lockUpdateTaskStatus.Lock()
+	mock.calls.UpdateTaskStatus = append(mock.calls.UpdateTaskStatus, callInfo)
+	mock.lockUpdateTaskStatus.Unlock()
+	return mock.UpdateTaskStatusFunc(ctx, id, status)
+}
+
+// UpdateTaskStatusCalls gets all the calls that were made to UpdateTaskStatus.
+// Check the length with:
+//
+//	len(mockedUpdateTaskStatusService.UpdateTaskStatusCalls())
+func (mock *UpdateTaskStatusServiceMock) UpdateTaskStatusCalls() []struct {
+	Ctx    context.Context
+	ID     entity.TaskID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     entity.TaskID
+		Status entity.TaskStatus
+	}
+	mock.lockUpdateTaskStatus.RLock()
+	calls = mock.calls.UpdateTaskStatus
+	mock.lockUpdateTaskStatus.RUnlock()
+	return calls
+}
+
+// Ensure, that GetBoardServiceMock does implement GetBoardService.
+// If this is not the case, regenerate this file with moq.
+var _ GetBoardService = &GetBoardServiceMock{}
+
+// GetBoardServiceMock is a mock implementation of GetBoardService.
+//
+//	func TestSomethingThatUsesGetBoardService(t *testing.T) {
+//
+//		// make and configure a mocked GetBoardService
+//		mockedGetBoardService := &GetBoardServiceMock{
+//			GetBoardFunc: func(ctx context.Context) (*entity.Board, error) {
+//				panic("mock out the GetBoard method")
+//			},
+//		}
+//
+//		// use mockedGetBoardService in code that requires GetBoardService
+//		// and then make assertions.
+//
+//	}
+type GetBoardServiceMock struct {
+	// GetBoardFunc mocks the GetBoard method.
+	GetBoardFunc func(ctx context.Context) (*entity.Board, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetBoard holds details about calls to the GetBoard method.
+		GetBoard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGetBoard sync.RWMutex
+}
+
+// GetBoard calls GetBoardFunc.
+func (mock *GetBoardServiceMock) GetBoard(ctx context.Context) (*entity.Board, error) {
+	if mock.GetBoardFunc == nil {
+		panic("GetBoardServiceMock.GetBoardFunc: method is nil but GetBoardService.GetBoard was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetBoard.Lock()
+	mock.calls.GetBoard = append(mock.calls.GetBoard, callInfo)
+	mock.lockGetBoard.Unlock()
+	return mock.GetBoardFunc(ctx)
+}
+
+// GetBoardCalls gets all the calls that were made to GetBoard.
+// Check the length with:
+//
+//	len(mockedGetBoardService.GetBoardCalls())
+func (mock *GetBoardServiceMock) GetBoardCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetBoard.RLock()
+	calls = mock.calls.GetBoard
+	mock.lockGetBoard.RUnlock()
+	return calls
+}
+
+// Ensure, that AddColumnServiceMock does implement AddColumnService.
+// If this is not the case, regenerate this file with moq.
+var _ AddColumnService = &AddColumnServiceMock{}
+
+// AddColumnServiceMock is a mock implementation of AddColumnService.
+//
+//	func TestSomethingThatUsesAddColumnService(t *testing.T) {
+//
+//		// make and configure a mocked AddColumnService
+//		mockedAddColumnService := &AddColumnServiceMock{
+//			AddColumnFunc: func(ctx context.Context, name string, status entity.TaskStatus, wipLimit *int) (*entity.Column, error) {
+//				panic("mock out the AddColumn method")
+//			},
+//		}
+//
+//		// use mockedAddColumnService in code that requires AddColumnService
+//		// and then make assertions.
+//
+//	}
+type AddColumnServiceMock struct {
+	// AddColumnFunc mocks the AddColumn method.
+	AddColumnFunc func(ctx context.Context, name string, status entity.TaskStatus, wipLimit *int) (*entity.Column, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddColumn holds details about calls to the AddColumn method.
+		AddColumn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Status is the status argument value.
+			Status entity.TaskStatus
+			// WipLimit is the wipLimit argument value.
+			WipLimit *int
+		}
+	}
+	lockAddColumn sync.RWMutex
+}
+
+// AddColumn calls AddColumnFunc.
+func (mock *AddColumnServiceMock) AddColumn(ctx context.Context, name string, status entity.TaskStatus, wipLimit *int) (*entity.Column, error) {
+	if mock.AddColumnFunc == nil {
+		panic("AddColumnServiceMock.AddColumnFunc: method is nil but AddColumnService.AddColumn was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Name     string
+		Status   entity.TaskStatus
+		WipLimit *int
+	}{
+		Ctx:      ctx,
+		Name:     name,
+		Status:   status,
+		WipLimit: wipLimit,
+	}
+	mock.lockAddColumn.Lock()
+	mock.calls.AddColumn = append(mock.calls.AddColumn, callInfo)
+	mock.lockAddColumn.Unlock()
+	return mock.AddColumnFunc(ctx, name, status, wipLimit)
+}
+
+// AddColumnCalls gets all the calls that were made to AddColumn.
+// Check the length with:
+//
+//	len(mockedAddColumnService.AddColumnCalls())
+func (mock *AddColumnServiceMock) AddColumnCalls() []struct {
+	Ctx      context.Context
+	Name     string
+	Status   entity.TaskStatus
+	WipLimit *int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Name     string
+		Status   entity.TaskStatus
+		WipLimit *int
+	}
+	mock.lockAddColumn.RLock()
+	calls = mock.calls.AddColumn
+	mock.lockAddColumn.RUnlock()
+	return calls
+}
+
+// Ensure, that MoveTaskServiceMock does implement MoveTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ MoveTaskService = &MoveTaskServiceMock{}
+
+// MoveTaskServiceMock is a mock implementation of MoveTaskService.
+//
+//	func TestSomethingThatUsesMoveTaskService(t *testing.T) {
+//
+//		// make and configure a mocked MoveTaskService
+//		mockedMoveTaskService := &MoveTaskServiceMock{
+//			MoveTaskFunc: func(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error {
+//				panic("mock out the MoveTask method")
+//			},
+//		}
+//
+//		// use mockedMoveTaskService in code that requires MoveTaskService
+//		// and then make assertions.
+//
+//	}
+type MoveTaskServiceMock struct {
+	// MoveTaskFunc mocks the MoveTask method.
+	MoveTaskFunc func(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MoveTask holds details about calls to the MoveTask method.
+		MoveTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ColumnID is the columnID argument value.
+			ColumnID entity.ColumnID
+		}
+	}
+	lockMoveTask sync.RWMutex
+}
+
+// MoveTask calls MoveTaskFunc.
+func (mock *MoveTaskServiceMock) MoveTask(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error {
+	if mock.MoveTaskFunc == nil {
+		panic("MoveTaskServiceMock.MoveTaskFunc: method is nil but MoveTaskService.MoveTask was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       entity.TaskID
+		ColumnID entity.ColumnID
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		ColumnID: columnID,
+	}
+	mock.lockMoveTask.Lock()
+	mock.calls.MoveTask = append(mock.calls.MoveTask, callInfo)
+	mock.lockMoveTask.Unlock()
+	return mock.MoveTaskFunc(ctx, id, columnID)
+}
+
+// MoveTaskCalls gets all the calls that were made to MoveTask.
+// Check the length with:
+//
+//	len(mockedMoveTaskService.MoveTaskCalls())
+func (mock *MoveTaskServiceMock) MoveTaskCalls() []struct {
+	Ctx      context.Context
+	ID       entity.TaskID
+	ColumnID entity.ColumnID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       entity.TaskID
+		ColumnID entity.ColumnID
+	}
+	mock.lockMoveTask.RLock()
+	calls = mock.calls.MoveTask
+	mock.lockMoveTask.RUnlock()
+	return calls
+}
+
+// Ensure, that ResolveWorkspaceServiceMock does implement ResolveWorkspaceService.
+// If this is not the case, regenerate this file with moq.
+var _ ResolveWorkspaceService = &ResolveWorkspaceServiceMock{}
+
+// ResolveWorkspaceServiceMock is a mock implementation of ResolveWorkspaceService.
+//
+//	func TestSomethingThatUsesResolveWorkspaceService(t *testing.T) {
+//
+//		// make and configure a mocked ResolveWorkspaceService
+//		mockedResolveWorkspaceService := &ResolveWorkspaceServiceMock{
+//			ResolveWorkspaceFunc: func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+//				panic("mock out the ResolveWorkspace method")
+//			},
+//		}
+//
+//		// use mockedResolveWorkspaceService in code that requires ResolveWorkspaceService
+//		// and then make assertions.
+//
+//	}
+type ResolveWorkspaceServiceMock struct {
+	// ResolveWorkspaceFunc mocks the ResolveWorkspace method.
+	ResolveWorkspaceFunc func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ResolveWorkspace holds details about calls to the ResolveWorkspace method.
+		ResolveWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.WorkspaceID
+		}
+	}
+	lockResolveWorkspace sync.RWMutex
+}
+
+// ResolveWorkspace calls ResolveWorkspaceFunc.
+func (mock *ResolveWorkspaceServiceMock) ResolveWorkspace(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+	if mock.ResolveWorkspaceFunc == nil {
+		panic("ResolveWorkspaceServiceMock.ResolveWorkspaceFunc: method is nil but ResolveWorkspaceService.ResolveWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.WorkspaceID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockResolveWorkspace.Lock()
+	mock.calls.ResolveWorkspace = append(mock.calls.ResolveWorkspace, callInfo)
+	mock.lockResolveWorkspace.Unlock()
+	return mock.ResolveWorkspaceFunc(ctx, id)
+}
+
+// ResolveWorkspaceCalls gets all the calls that were made to ResolveWorkspace.
+// Check the length with:
+//
+//	len(mockedResolveWorkspaceService.ResolveWorkspaceCalls())
+func (mock *ResolveWorkspaceServiceMock) ResolveWorkspaceCalls() []struct {
+	Ctx context.Context
+	ID  entity.WorkspaceID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.WorkspaceID
+	}
+	mock.lockResolveWorkspace.RLock()
+	calls = mock.calls.ResolveWorkspace
+	mock.lockResolveWorkspace.RUnlock()
+	return calls
+}
+
+// Ensure, that AddWorkspaceServiceMock does implement AddWorkspaceService.
+// If this is not the case, regenerate this file with moq.
+var _ AddWorkspaceService = &AddWorkspaceServiceMock{}
+
+// AddWorkspaceServiceMock is a mock implementation of AddWorkspaceService.
+//
+//	func TestSomethingThatUsesAddWorkspaceService(t *testing.T) {
+//
+//		// make and configure a mocked AddWorkspaceService
+//		mockedAddWorkspaceService := &AddWorkspaceServiceMock{
+//			AddWorkspaceFunc: func(ctx context.Context, name string) (*entity.Workspace, error) {
+//				panic("mock out the AddWorkspace method")
+//			},
+//		}
+//
+//		// use mockedAddWorkspaceService in code that requires AddWorkspaceService
+//		// and then make assertions.
+//
+//	}
+type AddWorkspaceServiceMock struct {
+	// AddWorkspaceFunc mocks the AddWorkspace method.
+	AddWorkspaceFunc func(ctx context.Context, name string) (*entity.Workspace, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddWorkspace holds details about calls to the AddWorkspace method.
+		AddWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAddWorkspace sync.RWMutex
+}
+
+// AddWorkspace calls AddWorkspaceFunc.
+func (mock *AddWorkspaceServiceMock) AddWorkspace(ctx context.Context, name string) (*entity.Workspace, error) {
+	if mock.AddWorkspaceFunc == nil {
+		panic("AddWorkspaceServiceMock.AddWorkspaceFunc: method is nil but AddWorkspaceService.AddWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockAddWorkspace.Lock()
+	mock.calls.AddWorkspace = append(mock.calls.AddWorkspace, callInfo)
+	mock.lockAddWorkspace.Unlock()
+	return mock.AddWorkspaceFunc(ctx, name)
+}
+
+// AddWorkspaceCalls gets all the calls that were made to AddWorkspace.
+// Check the length with:
+//
+//	len(mockedAddWorkspaceService.AddWorkspaceCalls())
+func (mock *AddWorkspaceServiceMock) AddWorkspaceCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockAddWorkspace.RLock()
+	calls = mock.calls.AddWorkspace
+	mock.lockAddWorkspace.RUnlock()
+	return calls
+}
+
+// Ensure, that ListWorkspacesServiceMock does implement ListWorkspacesService.
+// If this is not the case, regenerate this file with moq.
+var _ ListWorkspacesService = &ListWorkspacesServiceMock{}
+
+// ListWorkspacesServiceMock is a mock implementation of ListWorkspacesService.
+//
+//	func TestSomethingThatUsesListWorkspacesService(t *testing.T) {
+//
+//		// make and configure a mocked ListWorkspacesService
+//		mockedListWorkspacesService := &ListWorkspacesServiceMock{
+//			ListWorkspacesFunc: func(ctx context.Context) ([]*entity.WorkspaceMembership, error) {
+//				panic("mock out the ListWorkspaces method")
+//			},
+//		}
+//
+//		// use mockedListWorkspacesService in code that requires ListWorkspacesService
+//		// and then make assertions.
+//
+//	}
+type ListWorkspacesServiceMock struct {
+	// ListWorkspacesFunc mocks the ListWorkspaces method.
+	ListWorkspacesFunc func(ctx context.Context) ([]*entity.WorkspaceMembership, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListWorkspaces holds details about calls to the ListWorkspaces method.
+		ListWorkspaces []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListWorkspaces sync.RWMutex
+}
+
+// ListWorkspaces calls ListWorkspacesFunc.
+func (mock *ListWorkspacesServiceMock) ListWorkspaces(ctx context.Context) ([]*entity.WorkspaceMembership, error) {
+	if mock.ListWorkspacesFunc == nil {
+		panic("ListWorkspacesServiceMock.ListWorkspacesFunc: method is nil but ListWorkspacesService.ListWorkspaces was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListWorkspaces.Lock()
+	mock.calls.ListWorkspaces = append(mock.calls.ListWorkspaces, callInfo)
+	mock.lockListWorkspaces.Unlock()
+	return mock.ListWorkspacesFunc(ctx)
+}
+
+// ListWorkspacesCalls gets all the calls that were made to ListWorkspaces.
+// Check the length with:
+//
+//	len(mockedListWorkspacesService.ListWorkspacesCalls())
+func (mock *ListWorkspacesServiceMock) ListWorkspacesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListWorkspaces.RLock()
+	calls = mock.calls.ListWorkspaces
+	mock.lockListWorkspaces.RUnlock()
+	return calls
+}
+
+// Ensure, that AddWorkspaceMemberServiceMock does implement AddWorkspaceMemberService.
+// If this is not the case, regenerate this file with moq.
+var _ AddWorkspaceMemberService = &AddWorkspaceMemberServiceMock{}
+
+// AddWorkspaceMemberServiceMock is a mock implementation of AddWorkspaceMemberService.
+//
+//	func TestSomethingThatUsesAddWorkspaceMemberService(t *testing.T) {
+//
+//		// make and configure a mocked AddWorkspaceMemberService
+//		mockedAddWorkspaceMemberService := &AddWorkspaceMemberServiceMock{
+//			AddWorkspaceMemberFunc: func(ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error) {
+//				panic("mock out the AddWorkspaceMember method")
+//			},
+//		}
+//
+//		// use mockedAddWorkspaceMemberService in code that requires AddWorkspaceMemberService
+//		// and then make assertions.
+//
+//	}
+type AddWorkspaceMemberServiceMock struct {
+	// AddWorkspaceMemberFunc mocks the AddWorkspaceMember method.
+	AddWorkspaceMemberFunc func(ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddWorkspaceMember holds details about calls to the AddWorkspaceMember method.
+		AddWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.WorkspaceID
+			// UserName is the userName argument value.
+			UserName string
+			// Role is the role argument value.
+			Role entity.WorkspaceRole
+		}
+	}
+	lockAddWorkspaceMember sync.RWMutex
+}
+
+// AddWorkspaceMember calls AddWorkspaceMemberFunc.
+func (mock *AddWorkspaceMemberServiceMock) AddWorkspaceMember(ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error) {
+	if mock.AddWorkspaceMemberFunc == nil {
+		panic("AddWorkspaceMemberServiceMock.AddWorkspaceMemberFunc: method is nil but AddWorkspaceMemberService.AddWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       entity.WorkspaceID
+		UserName string
+		Role     entity.WorkspaceRole
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		UserName: userName,
+		Role:     role,
+	}
+	mock.lockAddWorkspaceMember.Lock()
+	mock.calls.AddWorkspaceMember = append(mock.calls.AddWorkspaceMember, callInfo)
+	mock.lockAddWorkspaceMember.Unlock()
+	return mock.AddWorkspaceMemberFunc(ctx, id, userName, role)
+}
+
+// AddWorkspaceMemberCalls gets all the calls that were made to AddWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedAddWorkspaceMemberService.AddWorkspaceMemberCalls())
+func (mock *AddWorkspaceMemberServiceMock) AddWorkspaceMemberCalls() []struct {
+	Ctx      context.Context
+	ID       entity.WorkspaceID
+	UserName string
+	Role     entity.WorkspaceRole
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       entity.WorkspaceID
+		UserName string
+		Role     entity.WorkspaceRole
+	}
+	mock.lockAddWorkspaceMember.RLock()
+	calls = mock.calls.AddWorkspaceMember
+	mock.lockAddWorkspaceMember.RUnlock()
+	return calls
+}
+
+// Ensure, that AddProjectServiceMock does implement AddProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ AddProjectService = &AddProjectServiceMock{}
+
+// AddProjectServiceMock is a mock implementation of AddProjectService.
+//
+//	func TestSomethingThatUsesAddProjectService(t *testing.T) {
+//
+//		// make and configure a mocked AddProjectService
+//		mockedAddProjectService := &AddProjectServiceMock{
+//			AddProjectFunc: func(ctx context.Context, name string) (*entity.Project, error) {
+//				panic("mock out the AddProject method")
+//			},
+//		}
+//
+//		// use mockedAddProjectService in code that requires AddProjectService
+//		// and then make assertions.
+//
+//	}
+type AddProjectServiceMock struct {
+	// AddProjectFunc mocks the AddProject method.
+	AddProjectFunc func(ctx context.Context, name string) (*entity.Project, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddProject holds details about calls to the AddProject method.
+		AddProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+	}
+	lockAddProject sync.RWMutex
+}
+
+// AddProject calls AddProjectFunc.
+func (mock *AddProjectServiceMock) AddProject(ctx context.Context, name string) (*entity.Project, error) {
+	if mock.AddProjectFunc == nil {
+		panic("AddProjectServiceMock.AddProjectFunc: method is nil but AddProjectService.AddProject was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	mock.lockAddProject.Lock()
+	mock.calls.AddProject = append(mock.calls.AddProject, callInfo)
+	mock.lockAddProject.Unlock()
+	return mock.AddProjectFunc(ctx, name)
+}
+
+// AddProjectCalls gets all the calls that were made to AddProject.
+// Check the length with:
+//
+//	len(mockedAddProjectService.AddProjectCalls())
+func (mock *AddProjectServiceMock) AddProjectCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	mock.lockAddProject.RLock()
+	calls = mock.calls.AddProject
+	mock.lockAddProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ListProjectsServiceMock does implement ListProjectsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListProjectsService = &ListProjectsServiceMock{}
+
+// ListProjectsServiceMock is a mock implementation of ListProjectsService.
+//
+//	func TestSomethingThatUsesListProjectsService(t *testing.T) {
+//
+//		// make and configure a mocked ListProjectsService
+//		mockedListProjectsService := &ListProjectsServiceMock{
+//			ListProjectsFunc: func(ctx context.Context) (entity.Projects, error) {
+//				panic("mock out the ListProjects method")
+//			},
+//		}
+//
+//		// use mockedListProjectsService in code that requires ListProjectsService
+//		// and then make assertions.
+//
+//	}
+type ListProjectsServiceMock struct {
+	// ListProjectsFunc mocks the ListProjects method.
+	ListProjectsFunc func(ctx context.Context) (entity.Projects, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjects holds details about calls to the ListProjects method.
+		ListProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListProjects sync.RWMutex
+}
+
+// ListProjects calls ListProjectsFunc.
+func (mock *ListProjectsServiceMock) ListProjects(ctx context.Context) (entity.Projects, error) {
+	if mock.ListProjectsFunc == nil {
+		panic("ListProjectsServiceMock.ListProjectsFunc: method is nil but ListProjectsService.ListProjects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListProjects.Lock()
+	mock.calls.ListProjects = append(mock.calls.ListProjects, callInfo)
+	mock.lockListProjects.Unlock()
+	return mock.ListProjectsFunc(ctx)
+}
+
+// ListProjectsCalls gets all the calls that were made to ListProjects.
+// Check the length with:
+//
+//	len(mockedListProjectsService.ListProjectsCalls())
+func (mock *ListProjectsServiceMock) ListProjectsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListProjects.RLock()
+	calls = mock.calls.ListProjects
+	mock.lockListProjects.RUnlock()
+	return calls
+}
+
+// Ensure, that StatsServiceMock does implement StatsService.
+// If this is not the case, regenerate this file with moq.
+var _ StatsService = &StatsServiceMock{}
+
+// StatsServiceMock is a mock implementation of StatsService.
+//
+//	func TestSomethingThatUsesStatsService(t *testing.T) {
+//
+//		// make and configure a mocked StatsService
+//		mockedStatsService := &StatsServiceMock{
+//			StatsFunc: func(ctx context.Context, windowDays int) (*entity.Stats, error) {
+//				panic("mock out the Stats method")
+//			},
+//		}
+//
+//		// use mockedStatsService in code that requires StatsService
+//		// and then make assertions.
+//
+//	}
+type StatsServiceMock struct {
+	// StatsFunc mocks the Stats method.
+	StatsFunc func(ctx context.Context, windowDays int) (*entity.Stats, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Stats holds details about calls to the Stats method.
+		Stats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WindowDays is the windowDays argument value.
+			WindowDays int
+		}
+	}
+	lockStats sync.RWMutex
+}
+
+// Stats calls StatsFunc.
+func (mock *StatsServiceMock) Stats(ctx context.Context, windowDays int) (*entity.Stats, error) {
+	if mock.StatsFunc == nil {
+		panic("StatsServiceMock.StatsFunc: method is nil but StatsService.Stats was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		WindowDays int
+	}{
+		Ctx:        ctx,
+		WindowDays: windowDays,
+	}
+	mock.lockStats.Lock()
+	mock.calls.Stats = append(mock.calls.Stats, callInfo)
+	mock.lockStats.Unlock()
+	return mock.StatsFunc(ctx, windowDays)
+}
+
+// StatsCalls gets all the calls that were made to Stats.
+// Check the length with:
+//
+//	len(mockedStatsService.StatsCalls())
+func (mock *StatsServiceMock) StatsCalls() []struct {
+	Ctx        context.Context
+	WindowDays int
+} {
+	var calls []struct {
+		Ctx        context.Context
+		WindowDays int
+	}
+	mock.lockStats.RLock()
+	calls = mock.calls.Stats
+	mock.lockStats.RUnlock()
+	return calls
+}
+
+// Ensure, that AssignTaskServiceMock does implement AssignTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ AssignTaskService = &AssignTaskServiceMock{}
+
+// AssignTaskServiceMock is a mock implementation of AssignTaskService.
+//
+//	func TestSomethingThatUsesAssignTaskService(t *testing.T) {
+//
+//		// make and configure a mocked AssignTaskService
+//		mockedAssignTaskService := &AssignTaskServiceMock{
+//			AssignTaskFunc: func(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error {
+//				panic("mock out the AssignTask method")
+//			},
+//		}
+//
+//		// use mockedAssignTaskService in code that requires AssignTaskService
+//		// and then make assertions.
+//
+//	}
+type AssignTaskServiceMock struct {
+	// AssignTaskFunc mocks the AssignTask method.
+	AssignTaskFunc func(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AssignTask holds details about calls to the AssignTask method.
+		AssignTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// AssigneeID is the assigneeID argument value.
+			AssigneeID *entity.UserID
+		}
+	}
+	lockAssignTask sync.RWMutex
+}
+
+// AssignTask calls AssignTaskFunc.
+func (mock *AssignTaskServiceMock) AssignTask(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error {
+	if mock.AssignTaskFunc == nil {
+		panic("AssignTaskServiceMock.AssignTaskFunc: method is nil but AssignTaskService.AssignTask was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ID         entity.TaskID
+		AssigneeID *entity.UserID
+	}{
+		Ctx:        ctx,
+		ID:         id,
+		AssigneeID: assigneeID,
+	}
+	mock.lockAssignTask.Lock()
+	mock.calls.AssignTask = append(mock.calls.AssignTask, callInfo)
+	mock.lockAssignTask.Unlock()
+	return mock.AssignTaskFunc(ctx, id, assigneeID)
+}
+
+// AssignTaskCalls gets all the calls that were made to AssignTask.
+// Check the length with:
+//
+//	len(mockedAssignTaskService.AssignTaskCalls())
+func (mock *AssignTaskServiceMock) AssignTaskCalls() []struct {
+	Ctx        context.Context
+	ID         entity.TaskID
+	AssigneeID *entity.UserID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ID         entity.TaskID
+		AssigneeID *entity.UserID
+	}
+	mock.lockAssignTask.RLock()
+	calls = mock.calls.AssignTask
+	mock.lockAssignTask.RUnlock()
+	return calls
+}
+
+// Ensure, that SetTaskProjectServiceMock does implement SetTaskProjectService.
+// If this is not the case, regenerate this file with moq.
+var _ SetTaskProjectService = &SetTaskProjectServiceMock{}
+
+// SetTaskProjectServiceMock is a mock implementation of SetTaskProjectService.
+//
+//	func TestSomethingThatUsesSetTaskProjectService(t *testing.T) {
+//
+//		// make and configure a mocked SetTaskProjectService
+//		mockedSetTaskProjectService := &SetTaskProjectServiceMock{
+//			SetTaskProjectFunc: func(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error {
+//				panic("mock out the SetTaskProject method")
+//			},
+//		}
+//
+//		// use mockedSetTaskProjectService in code that requires SetTaskProjectService
+//		// and then make assertions.
+//
+//	}
+type SetTaskProjectServiceMock struct {
+	// SetTaskProjectFunc mocks the SetTaskProject method.
+	SetTaskProjectFunc func(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SetTaskProject holds details about calls to the SetTaskProject method.
+		SetTaskProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ProjectID is the projectID argument value.
+			ProjectID *entity.ProjectID
+		}
+	}
+	lockSetTaskProject sync.RWMutex
+}
+
+// SetTaskProject calls SetTaskProjectFunc.
+func (mock *SetTaskProjectServiceMock) SetTaskProject(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error {
+	if mock.SetTaskProjectFunc == nil {
+		panic("SetTaskProjectServiceMock.SetTaskProjectFunc: method is nil but SetTaskProjectService.SetTaskProject was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        entity.TaskID
+		ProjectID *entity.ProjectID
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		ProjectID: projectID,
+	}
+	mock.lockSetTaskProject.Lock()
+	mock.calls.SetTaskProject = append(mock.calls.SetTaskProject, callInfo)
+	mock.lockSetTaskProject.Unlock()
+	return mock.SetTaskProjectFunc(ctx, id, projectID)
+}
+
+// SetTaskProjectCalls gets all the calls that were made to SetTaskProject.
+// Check the length with:
+//
+//	len(mockedSetTaskProjectService.SetTaskProjectCalls())
+func (mock *SetTaskProjectServiceMock) SetTaskProjectCalls() []struct {
+	Ctx       context.Context
+	ID        entity.TaskID
+	ProjectID *entity.ProjectID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        entity.TaskID
+		ProjectID *entity.ProjectID
+	}
+	mock.lockSetTaskProject.RLock()
+	calls = mock.calls.SetTaskProject
+	mock.lockSetTaskProject.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkloadServiceMock does implement WorkloadService.
+// If this is not the case, regenerate this file with moq.
+var _ WorkloadService = &WorkloadServiceMock{}
+
+// WorkloadServiceMock is a mock implementation of WorkloadService.
+//
+//	func TestSomethingThatUsesWorkloadService(t *testing.T) {
+//
+//		// make and configure a mocked WorkloadService
+//		mockedWorkloadService := &WorkloadServiceMock{
+//			WorkloadFunc: func(ctx context.Context) ([]*entity.Workload, error) {
+//				panic("mock out the Workload method")
+//			},
+//		}
+//
+//		// use mockedWorkloadService in code that requires WorkloadService
+//		// and then make assertions.
+//
+//	}
+type WorkloadServiceMock struct {
+	// WorkloadFunc mocks the Workload method.
+	WorkloadFunc func(ctx context.Context) ([]*entity.Workload, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Workload holds details about calls to the Workload method.
+		Workload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockWorkload sync.RWMutex
+}
+
+// Workload calls WorkloadFunc.
+func (mock *WorkloadServiceMock) Workload(ctx context.Context) ([]*entity.Workload, error) {
+	if mock.WorkloadFunc == nil {
+		panic("WorkloadServiceMock.WorkloadFunc: method is nil but WorkloadService.Workload was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockWorkload.Lock()
+	mock.calls.Workload = append(mock.calls.Workload, callInfo)
+	mock.lockWorkload.Unlock()
+	return mock.WorkloadFunc(ctx)
+}
+
+// WorkloadCalls gets all the calls that were made to Workload.
+// Check the length with:
+//
+//	len(mockedWorkloadService.WorkloadCalls())
+func (mock *WorkloadServiceMock) WorkloadCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockWorkload.RLock()
+	calls = mock.calls.Workload
+	mock.lockWorkload.RUnlock()
+	return calls
+}
+
+// Ensure, that ListNotificationsServiceMock does implement ListNotificationsService.
+// If this is not the case, regenerate this file with moq.
+var _ ListNotificationsService = &ListNotificationsServiceMock{}
+
+// ListNotificationsServiceMock is a mock implementation of ListNotificationsService.
+//
+//	func TestSomethingThatUsesListNotificationsService(t *testing.T) {
+//
+//		// make and configure a mocked ListNotificationsService
+//		mockedListNotificationsService := &ListNotificationsServiceMock{
+//			ListNotificationsFunc: func(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error) {
+//				panic("mock out the ListNotifications method")
+//			},
+//		}
+//
+//		// use mockedListNotificationsService in code that requires ListNotificationsService
+//		// and then make assertions.
+//
+//	}
+type ListNotificationsServiceMock struct {
+	// ListNotificationsFunc mocks the ListNotifications method.
+	ListNotificationsFunc func(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListNotifications holds details about calls to the ListNotifications method.
+		ListNotifications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UnreadOnly is the unreadOnly argument value.
+			UnreadOnly bool
+		}
+	}
+	lockListNotifications sync.RWMutex
+}
+
+// ListNotifications calls ListNotificationsFunc.
+func (mock *ListNotificationsServiceMock) ListNotifications(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error) {
+	if mock.ListNotificationsFunc == nil {
+		panic("ListNotificationsServiceMock.ListNotificationsFunc: method is nil but ListNotificationsService.ListNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		UnreadOnly bool
+	}{
+		Ctx:        ctx,
+		UnreadOnly: unreadOnly,
+	}
+	mock.lockListNotifications.Lock()
+	mock.calls.ListNotifications = append(mock.calls.ListNotifications, callInfo)
+	mock.lockListNotifications.Unlock()
+	return mock.ListNotificationsFunc(ctx, unreadOnly)
+}
+
+// ListNotificationsCalls gets all the calls that were made to ListNotifications.
+// Check the length with:
+//
+//	len(mockedListNotificationsService.ListNotificationsCalls())
+func (mock *ListNotificationsServiceMock) ListNotificationsCalls() []struct {
+	Ctx        context.Context
+	UnreadOnly bool
+} {
+	var calls []struct {
+		Ctx        context.Context
+		UnreadOnly bool
+	}
+	mock.lockListNotifications.RLock()
+	calls = mock.calls.ListNotifications
+	mock.lockListNotifications.RUnlock()
+	return calls
+}
+
+// Ensure, that ReadNotificationsServiceMock does implement ReadNotificationsService.
+// If this is not the case, regenerate this file with moq.
+var _ ReadNotificationsService = &ReadNotificationsServiceMock{}
+
+// ReadNotificationsServiceMock is a mock implementation of ReadNotificationsService.
+//
+//	func TestSomethingThatUsesReadNotificationsService(t *testing.T) {
+//
+//		// make and configure a mocked ReadNotificationsService
+//		mockedReadNotificationsService := &ReadNotificationsServiceMock{
+//			MarkAllReadFunc: func(ctx context.Context) (int64, error) {
+//				panic("mock out the MarkAllRead method")
+//			},
+//			MarkReadFunc: func(ctx context.Context, id entity.NotificationID) error {
+//				panic("mock out the MarkRead method")
+//			},
+//		}
+//
+//		// use mockedReadNotificationsService in code that requires ReadNotificationsService
+//		// and then make assertions.
+//
+//	}
+type ReadNotificationsServiceMock struct {
+	// MarkAllReadFunc mocks the MarkAllRead method.
+	MarkAllReadFunc func(ctx context.Context) (int64, error)
+
+	// MarkReadFunc mocks the MarkRead method.
+	MarkReadFunc func(ctx context.Context, id entity.NotificationID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MarkAllRead holds details about calls to the MarkAllRead method.
+		MarkAllRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MarkRead holds details about calls to the MarkRead method.
+		MarkRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.NotificationID
+		}
+	}
+	lockMarkAllRead sync.RWMutex
+	lockMarkRead    sync.RWMutex
+}
+
+// MarkAllRead calls MarkAllReadFunc.
+func (mock *ReadNotificationsServiceMock) MarkAllRead(ctx context.Context) (int64, error) {
+	if mock.MarkAllReadFunc == nil {
+		panic("ReadNotificationsServiceMock.MarkAllReadFunc: method is nil but ReadNotificationsService.MarkAllRead was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMarkAllRead.Lock()
+	mock.calls.MarkAllRead = append(mock.calls.MarkAllRead, callInfo)
+	mock.lockMarkAllRead.Unlock()
+	return mock.MarkAllReadFunc(ctx)
+}
+
+// MarkAllReadCalls gets all the calls that were made to MarkAllRead.
+// Check the length with:
+//
+//	len(mockedReadNotificationsService.MarkAllReadCalls())
+func (mock *ReadNotificationsServiceMock) MarkAllReadCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockMarkAllRead.RLock()
+	calls = mock.calls.MarkAllRead
+	mock.lockMarkAllRead.RUnlock()
+	return calls
+}
+
+// MarkRead calls MarkReadFunc.
+func (mock *ReadNotificationsServiceMock) MarkRead(ctx context.Context, id entity.NotificationID) error {
+	if mock.MarkReadFunc == nil {
+		panic("ReadNotificationsServiceMock.MarkReadFunc: method is nil but ReadNotificationsService.MarkRead was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.NotificationID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockMarkRead.Lock()
+	mock.calls.MarkRead = append(mock.calls.MarkRead, callInfo)
+	mock.lockMarkRead.Unlock()
+	return mock.MarkReadFunc(ctx, id)
+}
+
+// MarkReadCalls gets all the calls that were made to MarkRead.
+// Check the length with:
+//
+//	len(mockedReadNotificationsService.MarkReadCalls())
+func (mock *ReadNotificationsServiceMock) MarkReadCalls() []struct {
+	Ctx context.Context
+	ID  entity.NotificationID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.NotificationID
+	}
+	mock.lockMarkRead.RLock()
+	calls = mock.calls.MarkRead
+	mock.lockMarkRead.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationPreferencesServiceMock does implement NotificationPreferencesService.
+// If this is not the case, regenerate this file with moq.
+var _ NotificationPreferencesService = &NotificationPreferencesServiceMock{}
+
+// NotificationPreferencesServiceMock is a mock implementation of NotificationPreferencesService.
+//
+//	func TestSomethingThatUsesNotificationPreferencesService(t *testing.T) {
+//
+//		// make and configure a mocked NotificationPreferencesService
+//		mockedNotificationPreferencesService := &NotificationPreferencesServiceMock{
+//			PreferencesFunc: func(ctx context.Context) ([]*entity.NotificationPreference, error) {
+//				panic("mock out the Preferences method")
+//			},
+//			UpdatePreferencesFunc: func(ctx context.Context, prefs []*entity.NotificationPreference) ([]*entity.NotificationPreference, error) {
+//				panic("mock out the UpdatePreferences method")
+//			},
+//		}
+//
+//		// use mockedNotificationPreferencesService in code that requires NotificationPreferencesService
+//		// and then make assertions.
+//
+//	}
+type NotificationPreferencesServiceMock struct {
+	// PreferencesFunc mocks the Preferences method.
+	PreferencesFunc func(ctx context.Context) ([]*entity.NotificationPreference, error)
+
+	// UpdatePreferencesFunc mocks the UpdatePreferences method.
+	UpdatePreferencesFunc func(ctx context.Context, prefs []*entity.NotificationPreference) ([]*entity.NotificationPreference, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Preferences holds details about calls to the Preferences method.
+		Preferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdatePreferences holds details about calls to the UpdatePreferences method.
+		UpdatePreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefs is the prefs argument value.
+			Prefs []*entity.NotificationPreference
+		}
+	}
+	lockPreferences       sync.RWMutex
+	lockUpdatePreferences sync.RWMutex
+}
+
+// Preferences calls PreferencesFunc.
+func (mock *NotificationPreferencesServiceMock) Preferences(ctx context.Context) ([]*entity.NotificationPreference, error) {
+	if mock.PreferencesFunc == nil {
+		panic("NotificationPreferencesServiceMock.PreferencesFunc: method is nil but NotificationPreferencesService.Preferences was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPreferences.Lock()
+	mock.calls.Preferences = append(mock.calls.Preferences, callInfo)
+	mock.lockPreferences.Unlock()
+	return mock.PreferencesFunc(ctx)
+}
+
+// PreferencesCalls gets all the calls that were made to Preferences.
+// Check the length with:
+//
+//	len(mockedNotificationPreferencesService.PreferencesCalls())
+func (mock *NotificationPreferencesServiceMock) PreferencesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockPreferences.RLock()
+	calls = mock.calls.Preferences
+	mock.lockPreferences.RUnlock()
+	return calls
+}
+
+// UpdatePreferences calls UpdatePreferencesFunc.
+func (mock *NotificationPreferencesServiceMock) UpdatePreferences(ctx context.Context, prefs []*entity.NotificationPreference) ([]*entity.NotificationPreference, error) {
+	if mock.UpdatePreferencesFunc == nil {
+		panic("NotificationPreferencesServiceMock.UpdatePreferencesFunc: method is nil but NotificationPreferencesService.UpdatePreferences was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Prefs []*entity.NotificationPreference
+	}{
+		Ctx:   ctx,
+		Prefs: prefs,
+	}
+	mock.lockUpdatePreferences.Lock()
+	mock.calls.UpdatePreferences = append(mock.calls.UpdatePreferences, callInfo)
+	mock.lockUpdatePreferences.Unlock()
+	return mock.UpdatePreferencesFunc(ctx, prefs)
+}
+
+// UpdatePreferencesCalls gets all the calls that were made to UpdatePreferences.
+// Check the length with:
+//
+//	len(mockedNotificationPreferencesService.UpdatePreferencesCalls())
+func (mock *NotificationPreferencesServiceMock) UpdatePreferencesCalls() []struct {
+	Ctx   context.Context
+	Prefs []*entity.NotificationPreference
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Prefs []*entity.NotificationPreference
+	}
+	mock.lockUpdatePreferences.RLock()
+	calls = mock.calls.UpdatePreferences
+	mock.lockUpdatePreferences.RUnlock()
+	return calls
+}
+
+// Ensure, that ReminderOffsetsServiceMock does implement ReminderOffsetsService.
+// If this is not the case, regenerate this file with moq.
+var _ ReminderOffsetsService = &ReminderOffsetsServiceMock{}
+
+// ReminderOffsetsServiceMock is a mock implementation of ReminderOffsetsService.
+//
+//	func TestSomethingThatUsesReminderOffsetsService(t *testing.T) {
+//
+//		// make and configure a mocked ReminderOffsetsService
+//		mockedReminderOffsetsService := &ReminderOffsetsServiceMock{
+//			ReminderOffsetsFunc: func(ctx context.Context) ([]entity.ReminderOffset, error) {
+//				panic("mock out the ReminderOffsets method")
+//			},
+//			UpdateReminderOffsetsFunc: func(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error) {
+//				panic("mock out the UpdateReminderOffsets method")
+//			},
+//		}
+//
+//		// use mockedReminderOffsetsService in code that requires ReminderOffsetsService
+//		// and then make assertions.
+//
+//	}
+type ReminderOffsetsServiceMock struct {
+	// ReminderOffsetsFunc mocks the ReminderOffsets method.
+	ReminderOffsetsFunc func(ctx context.Context) ([]entity.ReminderOffset, error)
+
+	// UpdateReminderOffsetsFunc mocks the UpdateReminderOffsets method.
+	UpdateReminderOffsetsFunc func(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ReminderOffsets holds details about calls to the ReminderOffsets method.
+		ReminderOffsets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UpdateReminderOffsets holds details about calls to the UpdateReminderOffsets method.
+		UpdateReminderOffsets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offsets is the offsets argument value.
+			Offsets []entity.ReminderOffset
+		}
+	}
+	lockReminderOffsets       sync.RWMutex
+	lockUpdateReminderOffsets sync.RWMutex
+}
+
+// ReminderOffsets calls ReminderOffsetsFunc.
+func (mock *ReminderOffsetsServiceMock) ReminderOffsets(ctx context.Context) ([]entity.ReminderOffset, error) {
+	if mock.ReminderOffsetsFunc == nil {
+		panic("ReminderOffsetsServiceMock.ReminderOffsetsFunc: method is nil but ReminderOffsetsService.ReminderOffsets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockReminderOffsets.Lock()
+	mock.calls.ReminderOffsets = append(mock.calls.ReminderOffsets, callInfo)
+	mock.lockReminderOffsets.Unlock()
+	return mock.ReminderOffsetsFunc(ctx)
+}
+
+// ReminderOffsetsCalls gets all the calls that were made to ReminderOffsets.
+// Check the length with:
+//
+//	len(mockedReminderOffsetsService.ReminderOffsetsCalls())
+func (mock *ReminderOffsetsServiceMock) ReminderOffsetsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockReminderOffsets.RLock()
+	calls = mock.calls.ReminderOffsets
+	mock.lockReminderOffsets.RUnlock()
+	return calls
+}
+
+// UpdateReminderOffsets calls UpdateReminderOffsetsFunc.
+func (mock *ReminderOffsetsServiceMock) UpdateReminderOffsets(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error) {
+	if mock.UpdateReminderOffsetsFunc == nil {
+		panic("ReminderOffsetsServiceMock.UpdateReminderOffsetsFunc: method is nil but ReminderOffsetsService.UpdateReminderOffsets was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Offsets []entity.ReminderOffset
+	}{
+		Ctx:     ctx,
+		Offsets: offsets,
+	}
+	mock.lockUpdateReminderOffsets.Lock()
+	mock.calls.UpdateReminderOffsets = append(mock.calls.UpdateReminderOffsets, callInfo)
+	mock.lockUpdateReminderOffsets.Unlock()
+	return mock.UpdateReminderOffsetsFunc(ctx, offsets)
+}
+
+// UpdateReminderOffsetsCalls gets all the calls that were made to UpdateReminderOffsets.
+// Check the length with:
+//
+//	len(mockedReminderOffsetsService.UpdateReminderOffsetsCalls())
+func (mock *ReminderOffsetsServiceMock) UpdateReminderOffsetsCalls() []struct {
+	Ctx     context.Context
+	Offsets []entity.ReminderOffset
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Offsets []entity.ReminderOffset
+	}
+	mock.lockUpdateReminderOffsets.RLock()
+	calls = mock.calls.UpdateReminderOffsets
+	mock.lockUpdateReminderOffsets.RUnlock()
+	return calls
+}
+
+// Ensure, that AddSavedSearchServiceMock does implement AddSavedSearchService.
+// If this is not the case, regenerate this file with moq.
+var _ AddSavedSearchService = &AddSavedSearchServiceMock{}
+
+// AddSavedSearchServiceMock is a mock implementation of AddSavedSearchService.
+//
+//	func TestSomethingThatUsesAddSavedSearchService(t *testing.T) {
+//
+//		// make and configure a mocked AddSavedSearchService
+//		mockedAddSavedSearchService := &AddSavedSearchServiceMock{
+//			AddSavedSearchFunc: func(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error) {
+//				panic("mock out the AddSavedSearch method")
+//			},
+//		}
+//
+//		// use mockedAddSavedSearchService in code that requires AddSavedSearchService
+//		// and then make assertions.
+//
+//	}
+type AddSavedSearchServiceMock struct {
+	// AddSavedSearchFunc mocks the AddSavedSearch method.
+	AddSavedSearchFunc func(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddSavedSearch holds details about calls to the AddSavedSearch method.
+		AddSavedSearch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Filter is the filter argument value.
+			Filter *entity.TaskFilter
+		}
+	}
+	lockAddSavedSearch sync.RWMutex
+}
+
+// AddSavedSearch calls AddSavedSearchFunc.
+func (mock *AddSavedSearchServiceMock) AddSavedSearch(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error) {
+	if mock.AddSavedSearchFunc == nil {
+		panic("AddSavedSearchServiceMock.AddSavedSearchFunc: method is nil but AddSavedSearchService.AddSavedSearch was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Name   string
+		Filter *entity.TaskFilter
+	}{
+		Ctx:    ctx,
+		Name:   name,
+		Filter: filter,
+	}
+	mock.lockAddSavedSearch.Lock()
+	mock.calls.AddSavedSearch = append(mock.calls.AddSavedSearch, callInfo)
+	mock.lockAddSavedSearch.Unlock()
+	return mock.AddSavedSearchFunc(ctx, name, filter)
+}
+
+// AddSavedSearchCalls gets all the calls that were made to AddSavedSearch.
+// Check the length with:
+//
+//	len(mockedAddSavedSearchService.AddSavedSearchCalls())
+func (mock *AddSavedSearchServiceMock) AddSavedSearchCalls() []struct {
+	Ctx    context.Context
+	Name   string
+	Filter *entity.TaskFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Name   string
+		Filter *entity.TaskFilter
+	}
+	mock.lockAddSavedSearch.RLock()
+	calls = mock.calls.AddSavedSearch
+	mock.lockAddSavedSearch.RUnlock()
+	return calls
+}
+
+// Ensure, that ListSavedSearchesServiceMock does implement ListSavedSearchesService.
+// If this is not the case, regenerate this file with moq.
+var _ ListSavedSearchesService = &ListSavedSearchesServiceMock{}
+
+// ListSavedSearchesServiceMock is a mock implementation of ListSavedSearchesService.
+//
+//	func TestSomethingThatUsesListSavedSearchesService(t *testing.T) {
+//
+//		// make and configure a mocked ListSavedSearchesService
+//		mockedListSavedSearchesService := &ListSavedSearchesServiceMock{
+//			ListSavedSearchesFunc: func(ctx context.Context) (entity.SavedSearches, error) {
+//				panic("mock out the ListSavedSearches method")
+//			},
+//		}
+//
+//		// use mockedListSavedSearchesService in code that requires ListSavedSearchesService
+//		// and then make assertions.
+//
+//	}
+type ListSavedSearchesServiceMock struct {
+	// ListSavedSearchesFunc mocks the ListSavedSearches method.
+	ListSavedSearchesFunc func(ctx context.Context) (entity.SavedSearches, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListSavedSearches holds details about calls to the ListSavedSearches method.
+		ListSavedSearches []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockListSavedSearches sync.RWMutex
+}
+
+// ListSavedSearches calls ListSavedSearchesFunc.
+func (mock *ListSavedSearchesServiceMock) ListSavedSearches(ctx context.Context) (entity.SavedSearches, error) {
+	if mock.ListSavedSearchesFunc == nil {
+		panic("ListSavedSearchesServiceMock.ListSavedSearchesFunc: method is nil but ListSavedSearchesService.ListSavedSearches was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListSavedSearches.Lock()
+	mock.calls.ListSavedSearches = append(mock.calls.ListSavedSearches, callInfo)
+	mock.lockListSavedSearches.Unlock()
+	return mock.ListSavedSearchesFunc(ctx)
+}
+
+// ListSavedSearchesCalls gets all the calls that were made to ListSavedSearches.
+// Check the length with:
+//
+//	len(mockedListSavedSearchesService.ListSavedSearchesCalls())
+func (mock *ListSavedSearchesServiceMock) ListSavedSearchesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListSavedSearches.RLock()
+	calls = mock.calls.ListSavedSearches
+	mock.lockListSavedSearches.RUnlock()
+	return calls
+}
+
+// Ensure, that SavedSearchTasksServiceMock does implement SavedSearchTasksService.
+// If this is not the case, regenerate this file with moq.
+var _ SavedSearchTasksService = &SavedSearchTasksServiceMock{}
+
+// SavedSearchTasksServiceMock is a mock implementation of SavedSearchTasksService.
+//
+//	func TestSomethingThatUsesSavedSearchTasksService(t *testing.T) {
+//
+//		// make and configure a mocked SavedSearchTasksService
+//		mockedSavedSearchTasksService := &SavedSearchTasksServiceMock{
+//			SavedSearchTasksFunc: func(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error) {
+//				panic("mock out the SavedSearchTasks method")
+//			},
+//		}
+//
+//		// use mockedSavedSearchTasksService in code that requires SavedSearchTasksService
+//		// and then make assertions.
+//
+//	}
+type SavedSearchTasksServiceMock struct {
+	// SavedSearchTasksFunc mocks the SavedSearchTasks method.
+	SavedSearchTasksFunc func(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SavedSearchTasks holds details about calls to the SavedSearchTasks method.
+		SavedSearchTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.SavedSearchID
+		}
+	}
+	lockSavedSearchTasks sync.RWMutex
+}
+
+// SavedSearchTasks calls SavedSearchTasksFunc.
+func (mock *SavedSearchTasksServiceMock) SavedSearchTasks(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error) {
+	if mock.SavedSearchTasksFunc == nil {
+		panic("SavedSearchTasksServiceMock.SavedSearchTasksFunc: method is nil but SavedSearchTasksService.SavedSearchTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.SavedSearchID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockSavedSearchTasks.Lock()
+	mock.calls.SavedSearchTasks = append(mock.calls.SavedSearchTasks, callInfo)
+	mock.lockSavedSearchTasks.Unlock()
+	return mock.SavedSearchTasksFunc(ctx, id)
+}
+
+// SavedSearchTasksCalls gets all the calls that were made to SavedSearchTasks.
+// Check the length with:
+//
+//	len(mockedSavedSearchTasksService.SavedSearchTasksCalls())
+func (mock *SavedSearchTasksServiceMock) SavedSearchTasksCalls() []struct {
+	Ctx context.Context
+	ID  entity.SavedSearchID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.SavedSearchID
+	}
+	mock.lockSavedSearchTasks.RLock()
+	calls = mock.calls.SavedSearchTasks
+	mock.lockSavedSearchTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that DeleteSavedSearchServiceMock does implement DeleteSavedSearchService.
+// If this is not the case, regenerate this file with moq.
+var _ DeleteSavedSearchService = &DeleteSavedSearchServiceMock{}
+
+// DeleteSavedSearchServiceMock is a mock implementation of DeleteSavedSearchService.
+//
+//	func TestSomethingThatUsesDeleteSavedSearchService(t *testing.T) {
+//
+//		// make and configure a mocked DeleteSavedSearchService
+//		mockedDeleteSavedSearchService := &DeleteSavedSearchServiceMock{
+//			DeleteSavedSearchFunc: func(ctx context.Context, id entity.SavedSearchID) error {
+//				panic("mock out the DeleteSavedSearch method")
+//			},
+//		}
+//
+//		// use mockedDeleteSavedSearchService in code that requires DeleteSavedSearchService
+//		// and then make assertions.
+//
+//	}
+type DeleteSavedSearchServiceMock struct {
+	// DeleteSavedSearchFunc mocks the DeleteSavedSearch method.
+	DeleteSavedSearchFunc func(ctx context.Context, id entity.SavedSearchID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteSavedSearch holds details about calls to the DeleteSavedSearch method.
+		DeleteSavedSearch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.SavedSearchID
+		}
+	}
+	lockDeleteSavedSearch sync.RWMutex
+}
+
+// DeleteSavedSearch calls DeleteSavedSearchFunc.
+func (mock *DeleteSavedSearchServiceMock) DeleteSavedSearch(ctx context.Context, id entity.SavedSearchID) error {
+	if mock.DeleteSavedSearchFunc == nil {
+		panic("DeleteSavedSearchServiceMock.DeleteSavedSearchFunc: method is nil but DeleteSavedSearchService.DeleteSavedSearch was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.SavedSearchID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteSavedSearch.Lock()
+	mock.calls.DeleteSavedSearch = append(mock.calls.DeleteSavedSearch, callInfo)
+	mock.lockDeleteSavedSearch.Unlock()
+	return mock.DeleteSavedSearchFunc(ctx, id)
+}
+
+// DeleteSavedSearchCalls gets all the calls that were made to DeleteSavedSearch.
+// Check the length with:
+//
+//	len(mockedDeleteSavedSearchService.DeleteSavedSearchCalls())
+func (mock *DeleteSavedSearchServiceMock) DeleteSavedSearchCalls() []struct {
+	Ctx context.Context
+	ID  entity.SavedSearchID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.SavedSearchID
+	}
+	mock.lockDeleteSavedSearch.RLock()
+	calls = mock.calls.DeleteSavedSearch
+	mock.lockDeleteSavedSearch.RUnlock()
+	return calls
+}
+
+// Ensure, that GraphQLServiceMock does implement GraphQLService.
+// If this is not the case, regenerate this file with moq.
+var _ GraphQLService = &GraphQLServiceMock{}
+
+// GraphQLServiceMock is a mock implementation of GraphQLService.
+//
+//	func TestSomethingThatUsesGraphQLService(t *testing.T) {
+//
+//		// make and configure a mocked GraphQLService
+//		mockedGraphQLService := &GraphQLServiceMock{
+//			ExecFunc: func(ctx context.Context, query string, operationName string, variables map[string]any) *graphql.Response {
+//				panic("mock out the Exec method")
+//			},
+//		}
+//
+//		// use mockedGraphQLService in code that requires GraphQLService
+//		// and then make assertions.
+//
+//	}
+type GraphQLServiceMock struct {
+	// ExecFunc mocks the Exec method.
+	ExecFunc func(ctx context.Context, query string, operationName string, variables map[string]any) *graphql.Response
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Exec holds details about calls to the Exec method.
+		Exec []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query string
+			// OperationName is the operationName argument value.
+			OperationName string
+			// Variables is the variables argument value.
+			Variables map[string]any
+		}
+	}
+	lockExec sync.RWMutex
+}
+
+// Exec calls ExecFunc.
+func (mock *GraphQLServiceMock) Exec(ctx context.Context, query string, operationName string, variables map[string]any) *graphql.Response {
+	if mock.ExecFunc == nil {
+		panic("GraphQLServiceMock.ExecFunc: method is nil but GraphQLService.Exec was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		Query         string
+		OperationName string
+		Variables     map[string]any
+	}{
+		Ctx:           ctx,
+		Query:         query,
+		OperationName: operationName,
+		Variables:     variables,
+	}
+	mock.lockExec.Lock()
+	mock.calls.Exec = append(mock.calls.Exec, callInfo)
+	mock.lockExec.Unlock()
+	return mock.ExecFunc(ctx, query, operationName, variables)
+}
+
+// ExecCalls gets all the calls that were made to Exec.
+// Check the length with:
+//
+//	len(mockedGraphQLService.ExecCalls())
+func (mock *GraphQLServiceMock) ExecCalls() []struct {
+	Ctx           context.Context
+	Query         string
+	OperationName string
+	Variables     map[string]any
+} {
+	var calls []struct {
+		Ctx           context.Context
+		Query         string
+		OperationName string
+		Variables     map[string]any
+	}
+	mock.lockExec.RLock()
+	calls = mock.calls.Exec
+	mock.lockExec.RUnlock()
+	return calls
+}
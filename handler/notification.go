@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type ListNotifications struct {
+	Service ListNotificationsService
+}
+
+// ServeHTTP は通知の一覧と未読の件数を返す。?unread=trueなら未読の通知だけを返す
+func (h *ListNotifications) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	unreadOnly := false
+	if v := r.URL.Query().Get("unread"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "invalid unread",
+				Details: []string{"unread must be true or false"},
+			}, http.StatusBadRequest)
+			return
+		}
+		unreadOnly = b
+	}
+
+	notifications, unread, err := h.Service.ListNotifications(ctx, unreadOnly)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to list notifications",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	type notification struct {
+		ID        entity.NotificationID   `json:"id"`
+		Type      entity.NotificationType `json:"type"`
+		TaskID    *entity.TaskID          `json:"task_id,omitempty"`
+		Message   string                  `json:"message"`
+		Read      bool                    `json:"read"`
+		CreatedAt time.Time               `json:"created_at"`
+	}
+	rsp := struct {
+		Unread        int            `json:"unread"`
+		Notifications []notification `json:"notifications"`
+	}{Unread: unread, Notifications: []notification{}}
+	for _, n := range notifications {
+		rsp.Notifications = append(rsp.Notifications, notification{
+			ID:        n.ID,
+			Type:      n.Type,
+			TaskID:    n.TaskID,
+			Message:   n.Message,
+			Read:      n.ReadAt != nil,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type ReadNotification struct {
+	Service ReadNotificationsService
+}
+
+// ServeHTTP は通知を既読にする
+func (h *ReadNotification) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid notification id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.MarkRead(ctx, entity.NotificationID(id)); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to mark notification read",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ReadAllNotifications struct {
+	Service ReadNotificationsService
+}
+
+// ServeHTTP は未読の通知をすべて既読にし、既読にした件数を返す
+func (h *ReadAllNotifications) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	n, err := h.Service.MarkAllRead(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to mark notifications read",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		Updated int64 `json:"updated"`
+	}{Updated: n}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type GetNotificationPreferences struct {
+	Service NotificationPreferencesService
+}
+
+func (h *GetNotificationPreferences) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	prefs, err := h.Service.Preferences(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get notification preferences",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, prefs, http.StatusOK)
+}
+
+type UpdateNotificationPreferences struct {
+	Service NotificationPreferencesService
+}
+
+// ServeHTTP は{"task_assigned": false}のように、通知の種類ごとに受け取るかを変更する。
+// 指定しなかった種類の設定は変わらない
+func (h *UpdateNotificationPreferences) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b map[entity.NotificationType]bool
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	// マップの順序に依存しないよう、既知の種類の順に並べる
+	prefs := []*entity.NotificationPreference{}
+	for _, t := range entity.NotificationTypes {
+		if enabled, ok := b[t]; ok {
+			prefs = append(prefs, &entity.NotificationPreference{Type: t, Enabled: enabled})
+		}
+	}
+	if len(prefs) != len(b) {
+		var unknown []string
+		for t := range b {
+			if !t.Valid() {
+				unknown = append(unknown, fmt.Sprintf("unknown notification type %q", t))
+			}
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: unknown,
+		}, http.StatusBadRequest)
+		return
+	}
+
+	all, err := h.Service.UpdatePreferences(ctx, prefs)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to update notification preferences",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, all, http.StatusOK)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestListNotifications_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status     int
+		rspFile    string
+		unreadOnly bool
+	}
+	tests := map[string]struct {
+		query string
+		want  want
+	}{
+		"ok": {
+			want: want{status: http.StatusOK, rspFile: "testdata/notification/list_ok_rsp.json.golden"},
+		},
+		"unreadOnly": {
+			query: "?unread=true",
+			want:  want{status: http.StatusOK, rspFile: "testdata/notification/list_ok_rsp.json.golden", unreadOnly: true},
+		},
+		"invalidUnread": {
+			query: "?unread=maybe",
+			want:  want{status: http.StatusBadRequest, rspFile: "testdata/notification/list_bad_request_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/notifications"+tt.query, nil)
+
+			created := time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC)
+			taskID := entity.TaskID(10)
+			moq := &ListNotificationsServiceMock{}
+			moq.ListNotificationsFunc = func(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error) {
+				if unreadOnly != tt.want.unreadOnly {
+					t.Errorf("want unreadOnly %v, but got %v", tt.want.unreadOnly, unreadOnly)
+				}
+				return entity.Notifications{
+					{ID: 2, Type: entity.NotificationTypeTaskAssigned, TaskID: &taskID, Message: `You were assigned to "write spec"`, CreatedAt: created},
+					{ID: 1, Type: entity.NotificationTypeDueReminder, Message: "due soon", ReadAt: &created, CreatedAt: created},
+				}, 1, nil
+			}
+			sut := ListNotifications{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
+
+func TestUpdateNotificationPreferences_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		body string
+		want want
+	}{
+		"ok": {
+			body: `{"due_reminder": false}`,
+			want: want{status: http.StatusOK, rspFile: "testdata/notification/preferences_ok_rsp.json.golden"},
+		},
+		"unknownType": {
+			body: `{"due_reminder": false, "newsletter": true}`,
+			want: want{status: http.StatusBadRequest, rspFile: "testdata/notification/preferences_bad_request_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewReader([]byte(tt.body)))
+
+			moq := &NotificationPreferencesServiceMock{}
+			moq.UpdatePreferencesFunc = func(ctx context.Context, prefs []*entity.NotificationPreference) ([]*entity.NotificationPreference, error) {
+				if len(prefs) != 1 || prefs[0].Type != entity.NotificationTypeDueReminder || prefs[0].Enabled {
+					t.Errorf("unexpected preferences %+v", prefs)
+				}
+				return []*entity.NotificationPreference{
+					{Type: entity.NotificationTypeTaskAssigned, Enabled: true},
+					{Type: entity.NotificationTypeComment, Enabled: true},
+					{Type: entity.NotificationTypeDueReminder, Enabled: false},
+				}, nil
+			}
+			sut := UpdateNotificationPreferences{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
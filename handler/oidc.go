@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/service"
+)
+
+// oidcStateCookie はログインを始めたブラウザのstateを覚えておくクッキー。
+// コールバックのstateと一致しなければ、ほかのブラウザで始めたログインとして拒否する(ログインCSRF対策)
+const oidcStateCookie = "oidc_state"
+
+type OIDCLogin struct {
+	Service OIDCLoginService
+	// StateTTL はstateのクッキーの有効期間。サービスのstateの制限時間とそろえる
+	StateTTL time.Duration
+	// SecureCookie がtrueなら、stateのクッキーをHTTPSでだけ送らせる
+	SecureCookie bool
+}
+
+// ServeHTTP はstateをクッキーに入れ、IDプロバイダーの認可エンドポイントにリダイレクトする
+func (h *OIDCLogin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u, state, err := h.Service.OIDCAuthURL(ctx)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrOIDCNotConfigured) {
+			status = http.StatusNotFound
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to start oidc login",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	// コールバックは同じディレクトリにあるので、/v1でも非推奨のエイリアスでもクッキーが届く。
+	// IDプロバイダーからのトップレベルのリダイレクトでも送られるようSameSite=Laxにする
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     path.Dir(r.URL.Path),
+		MaxAge:   int(h.StateTTL.Seconds()),
+		Secure:   h.SecureCookie,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+type OIDCCallback struct {
+	Service OIDCLoginService
+}
+
+// ServeHTTP はIDプロバイダーからリダイレクトされた認可コードでログインし、
+// アクセストークンとリフレッシュトークン、または2要素認証が必要ならMFAトークンを返す。
+// IDプロバイダーが返したエラーや、無効またはクッキーと一致しないstateには401を返す。
+func (h *OIDCCallback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		details := []string{e}
+		if d := q.Get("error_description"); d != "" {
+			details = append(details, d)
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "oidc login was rejected",
+			Details: details,
+		}, http.StatusUnauthorized)
+		return
+	}
+	code, state := q.Get("code"), q.Get("state")
+	if code == "" || state == "" {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{"code and state are required"},
+		}, http.StatusBadRequest)
+		return
+	}
+	// stateを使う前に、このブラウザで始めたログインかを確かめる
+	c, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(c.Value), []byte(state)) != 1 {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to login",
+			Details: []string{service.ErrInvalidOIDCState.Error()},
+		}, http.StatusUnauthorized)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     path.Dir(r.URL.Path),
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	result, err := h.Service.OIDCCallback(ctx, code, state)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrOIDCNotConfigured):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInvalidOIDCState), errors.Is(err, service.ErrOIDCAuthentication):
+			status = http.StatusUnauthorized
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to login",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	// 2要素認証が必要なら、パスワードでのログインと同じく/login/mfaで使うMFAトークンを返す
+	if result.Challenge != nil {
+		RespondJSON(ctx, w, result.Challenge, http.StatusOK)
+		return
+	}
+	RespondJSON(ctx, w, result.Tokens, http.StatusOK)
+}
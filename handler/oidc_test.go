@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestOIDCLogin_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("redirect", func(t *testing.T) {
+		t.Parallel()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/login", nil)
+		sut := OIDCLogin{Service: &OIDCLoginServiceMock{
+			OIDCAuthURLFunc: func(ctx context.Context) (string, string, error) {
+				return "https://idp.example.com/authorize?state=s", "s", nil
+			},
+		}, StateTTL: 10 * time.Minute, SecureCookie: true}
+		sut.ServeHTTP(w, r)
+
+		resp := w.Result()
+		if resp.StatusCode != http.StatusFound {
+			t.Errorf("want status %d, but got %d", http.StatusFound, resp.StatusCode)
+		}
+		if got := resp.Header.Get("Location"); got != "https://idp.example.com/authorize?state=s" {
+			t.Errorf("want redirect to the idp, but got %q", got)
+		}
+		// stateはコールバックにだけ届く、JavaScriptから読めないクッキーでブラウザに結び付ける
+		cookies := resp.Cookies()
+		if len(cookies) != 1 {
+			t.Fatalf("want 1 cookie, but got %d", len(cookies))
+		}
+		want := http.Cookie{
+			Name: "oidc_state", Value: "s", Path: "/v1/auth/oidc", MaxAge: 600,
+			Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode,
+		}
+		if got := cookies[0].String(); got != want.String() {
+			t.Errorf("want cookie %q, but got %q", want.String(), got)
+		}
+	})
+	t.Run("notConfigured", func(t *testing.T) {
+		t.Parallel()
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/auth/oidc/login", nil)
+		sut := OIDCLogin{Service: &OIDCLoginServiceMock{
+			OIDCAuthURLFunc: func(ctx context.Context) (string, string, error) {
+				return "", "", service.ErrOIDCNotConfigured
+			},
+		}}
+		sut.ServeHTTP(w, r)
+		testutil.AssertResponse(t, w.Result(), http.StatusNotFound, testutil.LoadFile(t, "testdata/oidc/not_configured_rsp.json.golden"))
+	})
+}
+
+func TestOIDCCallback_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		query string
+		// cookie はstateのクッキーの値。空ならクッキーを送らない
+		cookie    string
+		challenge *entity.MFAChallenge
+		err       error
+		want      want
+	}{
+		"ok": {
+			query:  "?code=code&state=state",
+			cookie: "state",
+			want:   want{status: http.StatusOK, rspFile: "testdata/oidc/ok_rsp.json.golden"},
+		},
+		"mfaRequired": {
+			query:     "?code=code&state=state",
+			cookie:    "state",
+			challenge: &entity.MFAChallenge{MFAToken: "mfa_from_moq", EnrollmentRequired: true},
+			want:      want{status: http.StatusOK, rspFile: "testdata/oidc/mfa_rsp.json.golden"},
+		},
+		"missingCookie": {
+			query: "?code=code&state=state",
+			want:  want{status: http.StatusUnauthorized, rspFile: "testdata/oidc/invalid_state_rsp.json.golden"},
+		},
+		"cookieMismatch": {
+			query:  "?code=code&state=state",
+			cookie: "other",
+			want:   want{status: http.StatusUnauthorized, rspFile: "testdata/oidc/invalid_state_rsp.json.golden"},
+		},
+		"rejectedByIdP": {
+			query: "?error=access_denied&error_description=user+cancelled&state=state",
+			want:  want{status: http.StatusUnauthorized, rspFile: "testdata/oidc/rejected_rsp.json.golden"},
+		},
+		"missingCode": {
+			query: "?state=state",
+			want:  want{status: http.StatusBadRequest, rspFile: "testdata/oidc/bad_rsp.json.golden"},
+		},
+		"invalidState": {
+			query:  "?code=code&state=state",
+			cookie: "state",
+			err:    service.ErrInvalidOIDCState,
+			want:   want{status: http.StatusUnauthorized, rspFile: "testdata/oidc/invalid_state_rsp.json.golden"},
+		},
+		"authenticationFailed": {
+			query:  "?code=code&state=state",
+			cookie: "state",
+			err:    service.ErrOIDCAuthentication,
+			want:   want{status: http.StatusUnauthorized, rspFile: "testdata/oidc/auth_failed_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback"+tt.query, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "oidc_state", Value: tt.cookie})
+			}
+			moq := &OIDCLoginServiceMock{
+				OIDCCallbackFunc: func(ctx context.Context, code, state string) (*entity.LoginResult, error) {
+					if code != "code" || state != "state" {
+						t.Errorf("want code and state from the query, but got %q and %q", code, state)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					if tt.challenge != nil {
+						return &entity.LoginResult{Challenge: tt.challenge}, nil
+					}
+					return &entity.LoginResult{Tokens: &entity.Tokens{AccessToken: "access", RefreshToken: "refresh"}}, nil
+				},
+			}
+			sut := OIDCCallback{Service: moq}
+			sut.ServeHTTP(w, r)
+			if tt.cookie != "" && tt.cookie != "state" && len(moq.OIDCCallbackCalls()) != 0 {
+				t.Errorf("must not use the state of a login started in another browser")
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/service"
+)
+
+type ForgotPassword struct {
+	Service   PasswordResetService
+	Validator *validator.Validate
+}
+
+// ServeHTTP はパスワードの再設定のメールを送る。アカウントがあるかを知られないよう、
+// メールアドレスのユーザーがいなくても同じく202を返す。求めすぎると429を返す
+func (h *ForgotPassword) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Email string `json:"email" validate:"required,email,max=255"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.ForgotPassword(ctx, b.Email, clientIP(r)); err != nil {
+		var rle *service.RateLimitError
+		if errors.As(err, &rle) {
+			setRetryAfter(w, rle.RetryAfter)
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "too many requests",
+			}, http.StatusTooManyRequests)
+			return
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to send password reset mail",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type ResetPassword struct {
+	Service   PasswordResetService
+	Validator *validator.Validate
+}
+
+// ServeHTTP はメールで送ったトークンでパスワードを置き換え、すべてのセッションを失効させる
+func (h *ResetPassword) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Token    string `json:"token" validate:"required"`
+		Password string `json:"password" validate:"required,max=100"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.ResetPassword(ctx, b.Token, b.Password); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			status = http.StatusBadRequest
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to reset password",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
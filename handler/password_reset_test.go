@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestForgotPassword_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status     int
+		rspFile    string
+		retryAfter string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"accepted": {
+			reqFile: "testdata/password/forgot_req.json.golden",
+			want:    want{status: http.StatusAccepted},
+		},
+		"badEmail": {
+			reqFile: "testdata/password/forgot_bad_req.json.golden",
+			want:    want{status: http.StatusBadRequest, rspFile: "testdata/password/forgot_bad_rsp.json.golden"},
+		},
+		"tooManyRequests": {
+			reqFile: "testdata/password/forgot_req.json.golden",
+			err:     &service.RateLimitError{RetryAfter: 90500 * time.Millisecond},
+			want: want{
+				status:     http.StatusTooManyRequests,
+				rspFile:    "testdata/password/forgot_too_many_rsp.json.golden",
+				retryAfter: "91",
+			},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/password/forgot", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+			r.RemoteAddr = "192.0.2.1:54321"
+			moq := &PasswordResetServiceMock{
+				ForgotPasswordFunc: func(ctx context.Context, email, clientIP string) error {
+					if email != "alice@example.com" || clientIP != "192.0.2.1" {
+						t.Errorf("unexpected email %q from %q", email, clientIP)
+					}
+					return tt.err
+				},
+			}
+			sut := ForgotPassword{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			rsp := w.Result()
+			if got := rsp.Header.Get("Retry-After"); got != tt.want.retryAfter {
+				t.Errorf("want Retry-After %q, but got %q", tt.want.retryAfter, got)
+			}
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, rsp, tt.want.status, body)
+		})
+	}
+}
+
+func TestResetPassword_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/password/reset_req.json.golden",
+			want:    want{status: http.StatusNoContent},
+		},
+		"noPassword": {
+			reqFile: "testdata/password/reset_bad_req.json.golden",
+			want:    want{status: http.StatusBadRequest, rspFile: "testdata/password/reset_bad_rsp.json.golden"},
+		},
+		"invalidToken": {
+			reqFile: "testdata/password/reset_req.json.golden",
+			err:     service.ErrInvalidResetToken,
+			want:    want{status: http.StatusBadRequest, rspFile: "testdata/password/reset_invalid_token_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/password/reset", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+			moq := &PasswordResetServiceMock{
+				ResetPasswordFunc: func(ctx context.Context, token, password string) error {
+					if token != "reset-token" || password != "new-password" {
+						t.Errorf("unexpected token %q and password %q", token, password)
+					}
+					return tt.err
+				},
+			}
+			sut := ResetPassword{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
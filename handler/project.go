@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddProject struct {
+	Service   AddProjectService
+	Validator *validator.Validate
+}
+
+func (h *AddProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name string `json:"name" validate:"required,max=64"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	p, err := h.Service.AddProject(ctx, b.Name)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrAlreadyExists) {
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add project",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := struct {
+		ID entity.ProjectID `json:"id"`
+	}{ID: p.ID}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
+
+type ListProjects struct {
+	Service ListProjectsService
+}
+
+func (h *ListProjects) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	projects, err := h.Service.ListProjects(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to list projects",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	type project struct {
+		ID   entity.ProjectID `json:"id"`
+		Name string           `json:"name"`
+	}
+	rsp := []project{}
+	for _, p := range projects {
+		rsp = append(rsp, project{ID: p.ID, Name: p.Name})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/service"
+)
+
+type RefreshToken struct {
+	Service   RefreshTokenService
+	Validator *validator.Validate
+}
+
+// ServeHTTP はリフレッシュトークンをローテーションし、新しいアクセストークンとリフレッシュトークンを返す。
+// 無効なトークンや使用済みのトークンには401を返す。
+func (h *RefreshToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		RefreshToken string `json:"refresh_token" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.Service.RefreshToken(ctx, b.RefreshToken)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to refresh token",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	RespondJSON(ctx, w, tokens, http.StatusOK)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRefreshToken_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/refresh_token/ok_req.json.golden",
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/refresh_token/ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/refresh_token/bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/refresh_token/bad_rsp.json.golden",
+			},
+		},
+		"invalid": {
+			reqFile: "testdata/refresh_token/ok_req.json.golden",
+			err:     service.ErrInvalidRefreshToken,
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/refresh_token/invalid_rsp.json.golden",
+			},
+		},
+		"reused": {
+			reqFile: "testdata/refresh_token/ok_req.json.golden",
+			err:     service.ErrRefreshTokenReused,
+			want: want{
+				status:  http.StatusUnauthorized,
+				rspFile: "testdata/refresh_token/reused_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/token/refresh", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+
+			moq := &RefreshTokenServiceMock{}
+			moq.RefreshTokenFunc = func(ctx context.Context, token string) (*entity.Tokens, error) {
+				if token != "old" {
+					t.Errorf("token = %q, want %q", token, "old")
+				}
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.Tokens{AccessToken: "access", RefreshToken: "new"}, nil
+			}
+			sut := RefreshToken{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
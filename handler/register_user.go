@@ -19,6 +19,8 @@ func (ru *RegisterUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Name     string `json:"name" validate:"required,max=100"`
 		Password string `json:"password" validate:"required,max=100"`
 		Role     string `json:"role" validate:"required,max=100"`
+		Timezone string `json:"timezone" validate:"omitempty,timezone"`
+		Email    string `json:"email" validate:"omitempty,email,max=255"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		RespondJSON(ctx, w, &ErrResponse{
@@ -34,7 +36,7 @@ func (ru *RegisterUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := ru.Service.RegisterUser(ctx, b.Name, b.Password, b.Role)
+	user, err := ru.Service.RegisterUser(ctx, b.Name, b.Password, b.Role, b.Timezone, b.Email)
 	if err != nil {
 		RespondJSON(ctx, w, &ErrResponse{
 			Message: err.Error(),
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+type reminderOffsets struct {
+	// Offsets は期限の何分前に通知するか。0(期限ちょうど)・60(1時間前)・1440(1日前)から選ぶ
+	Offsets []entity.ReminderOffset `json:"offsets" validate:"required,unique,dive,oneof=0 60 1440"`
+}
+
+type GetReminderOffsets struct {
+	Service ReminderOffsetsService
+}
+
+func (h *GetReminderOffsets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	offsets, err := h.Service.ReminderOffsets(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get reminder offsets",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, reminderOffsets{Offsets: offsets}, http.StatusOK)
+}
+
+type UpdateReminderOffsets struct {
+	Service   ReminderOffsetsService
+	Validator *validator.Validate
+}
+
+// ServeHTTP は期限の通知のタイミングを置き換える。空の配列なら期限の通知を受け取らない
+func (h *UpdateReminderOffsets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b reminderOffsets
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	offsets, err := h.Service.UpdateReminderOffsets(ctx, b.Offsets)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to update reminder offsets",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, reminderOffsets{Offsets: offsets}, http.StatusOK)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestUpdateReminderOffsets_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+		offsets []entity.ReminderOffset
+	}
+	tests := map[string]struct {
+		body string
+		want want
+	}{
+		"ok": {
+			body: `{"offsets": [0, 1440]}`,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/reminder/ok_rsp.json.golden",
+				offsets: []entity.ReminderOffset{entity.ReminderAtDue, entity.ReminderDayBefore},
+			},
+		},
+		"disableAll": {
+			body: `{"offsets": []}`,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/reminder/empty_rsp.json.golden",
+				offsets: []entity.ReminderOffset{},
+			},
+		},
+		"unsupportedOffset": {
+			body: `{"offsets": [30]}`,
+			want: want{status: http.StatusBadRequest, rspFile: "testdata/reminder/bad_offset_rsp.json.golden"},
+		},
+		"duplicateOffset": {
+			body: `{"offsets": [60, 60]}`,
+			want: want{status: http.StatusBadRequest, rspFile: "testdata/reminder/duplicate_rsp.json.golden"},
+		},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/reminders/offsets", bytes.NewReader([]byte(tt.body)))
+
+			moq := &ReminderOffsetsServiceMock{}
+			moq.UpdateReminderOffsetsFunc = func(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error) {
+				if diff := cmp.Diff(tt.want.offsets, offsets); diff != "" {
+					t.Errorf("offsets mismatch (-want +got):\n%s", diff)
+				}
+				// 保存後は遅い順に返る
+				saved := []entity.ReminderOffset{}
+				for i := len(offsets) - 1; i >= 0; i-- {
+					saved = append(saved, offsets[i])
+				}
+				return saved, nil
+			}
+			sut := UpdateReminderOffsets{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
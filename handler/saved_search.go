@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type savedSearch struct {
+	ID     entity.SavedSearchID `json:"id"`
+	Name   string               `json:"name"`
+	Filter entity.TaskFilter    `json:"filter"`
+}
+
+type AddSavedSearch struct {
+	Service   AddSavedSearchService
+	Validator *validator.Validate
+}
+
+// ServeHTTP は検索条件に名前を付けて保存する。
+// 検索条件は保存する前に検証し、知らないフィールドや不正な値があれば400を返す。
+func (h *AddSavedSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name   string          `json:"name" validate:"required,max=64"`
+		Filter json.RawMessage `json:"filter" validate:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	filter, err := entity.ParseTaskFilter(b.Filter)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	s, err := h.Service.AddSavedSearch(ctx, b.Name, filter)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrAlreadyExists) {
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add saved search",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := struct {
+		ID entity.SavedSearchID `json:"id"`
+	}{ID: s.ID}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
+
+type ListSavedSearches struct {
+	Service ListSavedSearchesService
+}
+
+func (h *ListSavedSearches) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	searches, err := h.Service.ListSavedSearches(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to list saved searches",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	rsp := []savedSearch{}
+	for _, s := range searches {
+		rsp = append(rsp, savedSearch{ID: s.ID, Name: s.Name, Filter: s.Filter})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type SavedSearchTasks struct {
+	Service SavedSearchTasksService
+}
+
+// ServeHTTP は保存した検索条件で、いま条件を満たすタスクを返す
+func (h *SavedSearchTasks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid saved search id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	tasks, err := h.Service.SavedSearchTasks(ctx, entity.SavedSearchID(id))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to run saved search",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := []task{}
+	for _, t := range tasks {
+		rsp = append(rsp, newTask(t))
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type DeleteSavedSearch struct {
+	Service DeleteSavedSearchService
+}
+
+func (h *DeleteSavedSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid saved search id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Service.DeleteSavedSearch(ctx, entity.SavedSearchID(id)); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to delete saved search",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
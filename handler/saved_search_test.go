@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestAddSavedSearch_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		err     error
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/saved_search/add_ok_req.json.golden",
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/saved_search/add_ok_rsp.json.golden",
+			},
+		},
+		"unknownField": {
+			reqFile: "testdata/saved_search/add_bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/saved_search/add_bad_rsp.json.golden",
+			},
+		},
+		"conflict": {
+			reqFile: "testdata/saved_search/add_ok_req.json.golden",
+			err:     fmt.Errorf("failed to add saved search: %w", store.ErrAlreadyExists),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/saved_search/add_conflict_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/saved-searches", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+
+			moq := &AddSavedSearchServiceMock{}
+			moq.AddSavedSearchFunc = func(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error) {
+				if filter.Version != entity.CurrentTaskFilterVersion {
+					t.Errorf("filter version = %d, want %d", filter.Version, entity.CurrentTaskFilterVersion)
+				}
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.SavedSearch{ID: 1, Name: name, Filter: *filter}, nil
+			}
+			sut := AddSavedSearch{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
+
+func TestSavedSearchTasks_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	due := time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		tasks entity.Tasks
+		err   error
+		want  want
+	}{
+		"ok": {
+			tasks: entity.Tasks{
+				{ID: 1, Title: "quarterly report", Status: entity.TaskStatusTodo, DueAt: &due},
+			},
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/saved_search/tasks_ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			err: fmt.Errorf("failed to get saved search: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/saved_search/tasks_not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/saved-searches/1/tasks", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "1")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &SavedSearchTasksServiceMock{}
+			moq.SavedSearchTasksFunc = func(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error) {
+				return tt.tasks, tt.err
+			}
+			sut := SavedSearchTasks{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
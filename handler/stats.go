@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultStatsWindowDays = 30
+	maxStatsWindowDays     = 365
+)
+
+type Stats struct {
+	Service StatsService
+}
+
+func (h *Stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	days := defaultStatsWindowDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxStatsWindowDays {
+			RespondJSON(ctx, w, &ErrResponse{
+				Message: "invalid days",
+				Details: []string{fmt.Sprintf("days must be between 1 and %d", maxStatsWindowDays)},
+			}, http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	stats, err := h.Service.Stats(ctx, days)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to get stats",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	RespondJSON(ctx, w, stats, http.StatusOK)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestStats_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		query    string
+		wantDays int
+		want     want
+	}{
+		"default window": {
+			query:    "",
+			wantDays: 30,
+			want: want{
+				status:  http.StatusOK,
+				rspFile: "testdata/stats/ok_rsp.json.golden",
+			},
+		},
+		"invalid days": {
+			query: "?days=0",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/stats/bad_days_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/stats"+tt.query, nil)
+
+			moq := &StatsServiceMock{}
+			moq.StatsFunc = func(ctx context.Context, windowDays int) (*entity.Stats, error) {
+				if windowDays != tt.wantDays {
+					t.Errorf("want window %d, but got %d", tt.wantDays, windowDays)
+				}
+				return &entity.Stats{
+					WindowDays: windowDays,
+					StatusCounts: map[entity.TaskStatus]int{
+						entity.TaskStatusTodo:  1,
+						entity.TaskStatusDoing: 0,
+						entity.TaskStatusDone:  2,
+					},
+					Days: []*entity.DailyStats{
+						{Date: "2022-05-10", Created: 3, Completed: 2},
+					},
+					CurrentStreak: 1,
+				}, nil
+			}
+			sut := Stats{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
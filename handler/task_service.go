@@ -2,23 +2,195 @@ package handler
 
 import (
 	"context"
+	"time"
 
+	"github.com/graph-gophers/graphql-go"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 )
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . ListTaskService AddTaskService RegisterUserService LoginService
+//go:generate go run github.com/matryer/moq -out moq_test.go . ListTaskService AddTaskService QuickAddTaskService RegisterUserService LoginService MFAService MFAPolicyService RefreshTokenService LogoutService RevokeSessionsService PasswordResetService JWKSService OIDCLoginService AddTemplateService ListTemplatesService InstantiateTemplateService StartTimerService StopTimerService UpdateTimeEntryService TimeReportService UpdateTaskStatusService GetBoardService AddColumnService MoveTaskService ResolveWorkspaceService AddWorkspaceService ListWorkspacesService AddWorkspaceMemberService AddProjectService ListProjectsService StatsService AssignTaskService SetTaskProjectService WorkloadService ListNotificationsService ReadNotificationsService NotificationPreferencesService ReminderOffsetsService AddSavedSearchService ListSavedSearchesService SavedSearchTasksService DeleteSavedSearchService GraphQLService
 type ListTaskService interface {
 	ListTasks(ctx context.Context) (entity.Tasks, error)
+	ListAssignedTasks(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error)
+	SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
 }
 
 type AddTaskService interface {
 	AddTask(ctx context.Context, title string) (*entity.Task, error)
 }
 
+type QuickAddTaskService interface {
+	QuickAddTask(ctx context.Context, text string) (*entity.Task, error)
+}
+
 type RegisterUserService interface {
-	RegisterUser(ctx context.Context, name string, password string, role string) (*entity.User, error)
+	RegisterUser(ctx context.Context, name string, password string, role string, timezone string, email string) (*entity.User, error)
 }
 
 type LoginService interface {
-	Login(ctx context.Context, name string, password string) (string, error)
+	Login(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error)
+	EnrollMFA(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error)
+	LoginMFA(ctx context.Context, mfaToken, code, recoveryCode string) (*entity.LoginResult, error)
+}
+
+type MFAService interface {
+	EnrollTOTP(ctx context.Context) (*entity.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]string, error)
+}
+
+type MFAPolicyService interface {
+	MFAPolicy(ctx context.Context) (*entity.MFAPolicy, error)
+	UpdateMFAPolicy(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error)
+}
+
+type RefreshTokenService interface {
+	RefreshToken(ctx context.Context, token string) (*entity.Tokens, error)
+}
+
+type LogoutService interface {
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context) error
+}
+
+type RevokeSessionsService interface {
+	RevokeSessions(ctx context.Context, userID entity.UserID) error
+}
+
+type PasswordResetService interface {
+	ForgotPassword(ctx context.Context, email, clientIP string) error
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type JWKSService interface {
+	PublicKeys() jwk.Set
+}
+
+type OIDCLoginService interface {
+	OIDCAuthURL(ctx context.Context) (authURL, state string, err error)
+	OIDCCallback(ctx context.Context, code, state string) (*entity.LoginResult, error)
+}
+
+type AddTemplateService interface {
+	AddTemplate(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error)
+}
+
+type ListTemplatesService interface {
+	ListTemplates(ctx context.Context) (entity.Templates, error)
+}
+
+type InstantiateTemplateService interface {
+	InstantiateTemplate(ctx context.Context, id entity.TemplateID) (entity.Tasks, error)
+}
+
+type StartTimerService interface {
+	StartTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error)
+}
+
+type StopTimerService interface {
+	StopTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error)
+}
+
+type UpdateTimeEntryService interface {
+	UpdateTimeEntry(ctx context.Context, id entity.TimeEntryID, startedAt time.Time, stoppedAt *time.Time) (*entity.TimeEntry, error)
+}
+
+type TimeReportService interface {
+	TimeReport(ctx context.Context, from, to time.Time) (*entity.TimeReport, error)
+}
+
+type UpdateTaskStatusService interface {
+	UpdateTaskStatus(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error
+}
+
+type StatsService interface {
+	Stats(ctx context.Context, windowDays int) (*entity.Stats, error)
+}
+
+type GetBoardService interface {
+	GetBoard(ctx context.Context) (*entity.Board, error)
+}
+
+type AddColumnService interface {
+	AddColumn(ctx context.Context, name string, status entity.TaskStatus, wipLimit *int) (*entity.Column, error)
+}
+
+type MoveTaskService interface {
+	MoveTask(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error
+}
+
+type ResolveWorkspaceService interface {
+	ResolveWorkspace(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error)
+}
+
+type AddWorkspaceService interface {
+	AddWorkspace(ctx context.Context, name string) (*entity.Workspace, error)
+}
+
+type ListWorkspacesService interface {
+	ListWorkspaces(ctx context.Context) ([]*entity.WorkspaceMembership, error)
+}
+
+type AddWorkspaceMemberService interface {
+	AddWorkspaceMember(ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error)
+}
+
+type AddProjectService interface {
+	AddProject(ctx context.Context, name string) (*entity.Project, error)
+}
+
+type ListProjectsService interface {
+	ListProjects(ctx context.Context) (entity.Projects, error)
+}
+
+type AssignTaskService interface {
+	AssignTask(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error
+}
+
+type SetTaskProjectService interface {
+	SetTaskProject(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error
+}
+
+type WorkloadService interface {
+	Workload(ctx context.Context) ([]*entity.Workload, error)
+}
+
+type ListNotificationsService interface {
+	ListNotifications(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error)
+}
+
+type ReadNotificationsService interface {
+	MarkRead(ctx context.Context, id entity.NotificationID) error
+	MarkAllRead(ctx context.Context) (int64, error)
+}
+
+type NotificationPreferencesService interface {
+	Preferences(ctx context.Context) ([]*entity.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, prefs []*entity.NotificationPreference) ([]*entity.NotificationPreference, error)
+}
+
+type ReminderOffsetsService interface {
+	ReminderOffsets(ctx context.Context) ([]entity.ReminderOffset, error)
+	UpdateReminderOffsets(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error)
+}
+
+type AddSavedSearchService interface {
+	AddSavedSearch(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error)
+}
+
+type ListSavedSearchesService interface {
+	ListSavedSearches(ctx context.Context) (entity.SavedSearches, error)
+}
+
+type SavedSearchTasksService interface {
+	SavedSearchTasks(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error)
+}
+
+type DeleteSavedSearchService interface {
+	DeleteSavedSearch(ctx context.Context, id entity.SavedSearchID) error
+}
+
+type GraphQLService interface {
+	Exec(ctx context.Context, query, operationName string, variables map[string]any) *graphql.Response
 }
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddTemplate struct {
+	Service   AddTemplateService
+	Validator *validator.Validate
+}
+
+func (h *AddTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Title string `json:"title" validate:"required,max=100"`
+		Items []struct {
+			Title            string `json:"title" validate:"required,max=100"`
+			DueOffsetMinutes *int   `json:"due_offset_minutes" validate:"omitempty,gte=0"`
+		} `json:"items" validate:"required,min=1,max=100,dive"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	items := make([]*entity.TemplateItem, 0, len(b.Items))
+	for _, item := range b.Items {
+		items = append(items, &entity.TemplateItem{
+			Title:            item.Title,
+			DueOffsetMinutes: item.DueOffsetMinutes,
+		})
+	}
+	tpl, err := h.Service.AddTemplate(ctx, b.Title, items)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add template",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		ID entity.TemplateID `json:"id"`
+	}{ID: tpl.ID}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
+
+type ListTemplates struct {
+	Service ListTemplatesService
+}
+
+func (h *ListTemplates) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templates, err := h.Service.ListTemplates(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to list templates",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	type template struct {
+		ID    entity.TemplateID `json:"id"`
+		Title string            `json:"title"`
+	}
+	rsp := []template{}
+	for _, t := range templates {
+		rsp = append(rsp, template{ID: t.ID, Title: t.Title})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type InstantiateTemplate struct {
+	Service InstantiateTemplateService
+}
+
+func (h *InstantiateTemplate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid template id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	tasks, err := h.Service.InstantiateTemplate(ctx, entity.TemplateID(id))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrWIPLimitExceeded):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to instantiate template",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := []task{}
+	for _, t := range tasks {
+		rsp = append(rsp, task{
+			ID:     t.ID,
+			Title:  t.Title,
+			Status: t.Status,
+			DueAt:  t.DueAt,
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestAddTemplate_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		reqFile string
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/template/add_ok_req.json.golden",
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/template/add_ok_rsp.json.golden",
+			},
+		},
+		"badRequest": {
+			reqFile: "testdata/template/add_bad_req.json.golden",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/template/add_bad_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/templates", bytes.NewReader(testutil.LoadFile(t, tt.reqFile)))
+
+			moq := &AddTemplateServiceMock{}
+			moq.AddTemplateFunc = func(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error) {
+				return &entity.Template{ID: 1, Title: title, Items: items}, nil
+			}
+			sut := AddTemplate{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
+
+func TestInstantiateTemplate_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		id    string
+		tasks entity.Tasks
+		err   error
+		want  want
+	}{
+		"ok": {
+			id: "1",
+			tasks: entity.Tasks{
+				{ID: 1, Title: "create account", Status: entity.TaskStatusTodo},
+				{ID: 2, Title: "read handbook", Status: entity.TaskStatusTodo, DueAt: func() *time.Time {
+					d := clock.FixedClocker{}.Now().Add(24 * time.Hour)
+					return &d
+				}()},
+			},
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/template/instantiate_ok_rsp.json.golden",
+			},
+		},
+		"notFound": {
+			id:  "2",
+			err: fmt.Errorf("failed to get template: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/template/instantiate_not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/templates/"+tt.id+"/instantiate", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &InstantiateTemplateServiceMock{}
+			moq.InstantiateTemplateFunc = func(ctx context.Context, id entity.TemplateID) (entity.Tasks, error) {
+				return tt.tasks, tt.err
+			}
+			sut := InstantiateTemplate{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
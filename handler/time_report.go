@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+type TimeReport struct {
+	Service TimeReportService
+}
+
+func (h *TimeReport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid from",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid to",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.Service.TimeReport(ctx, from, to)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to build time report",
+			Details: []string{err.Error()},
+		}, timeEntryErrorStatus(err))
+		return
+	}
+
+	type taskTime struct {
+		TaskID  entity.TaskID `json:"task_id"`
+		Title   string        `json:"title"`
+		Seconds int64         `json:"seconds"`
+	}
+	type dayTime struct {
+		Date    string `json:"date"`
+		Seconds int64  `json:"seconds"`
+	}
+	rsp := struct {
+		From         string     `json:"from"`
+		To           string     `json:"to"`
+		Timezone     string     `json:"timezone"`
+		TotalSeconds int64      `json:"total_seconds"`
+		Tasks        []taskTime `json:"tasks"`
+		Days         []dayTime  `json:"days"`
+	}{
+		From:         report.From.Format(time.DateOnly),
+		To:           report.To.AddDate(0, 0, -1).Format(time.DateOnly),
+		Timezone:     report.Location.String(),
+		TotalSeconds: int64(report.Total / time.Second),
+		Tasks:        []taskTime{},
+		Days:         []dayTime{},
+	}
+	for _, t := range report.Tasks {
+		rsp.Tasks = append(rsp.Tasks, taskTime{
+			TaskID:  t.TaskID,
+			Title:   t.Title,
+			Seconds: int64(t.Duration / time.Second),
+		})
+	}
+	for _, d := range report.Days {
+		rsp.Days = append(rsp.Days, dayTime{
+			Date:    d.Date.Format(time.DateOnly),
+			Seconds: int64(d.Duration / time.Second),
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
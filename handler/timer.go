@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type timeEntry struct {
+	ID        entity.TimeEntryID `json:"id"`
+	TaskID    entity.TaskID      `json:"task_id"`
+	StartedAt time.Time          `json:"started_at"`
+	StoppedAt *time.Time         `json:"stopped_at,omitempty"`
+}
+
+func newTimeEntry(e *entity.TimeEntry) timeEntry {
+	return timeEntry{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		StartedAt: e.StartedAt,
+		StoppedAt: e.StoppedAt,
+	}
+}
+
+// timeEntryErrorStatus はタイマー操作のエラーをHTTPステータスに変換する
+func timeEntryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidTimeRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type StartTimer struct {
+	Service StartTimerService
+}
+
+func (h *StartTimer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid task id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.Service.StartTimer(ctx, entity.TaskID(id))
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to start timer",
+			Details: []string{err.Error()},
+		}, timeEntryErrorStatus(err))
+		return
+	}
+	RespondJSON(ctx, w, newTimeEntry(e), http.StatusCreated)
+}
+
+type StopTimer struct {
+	Service StopTimerService
+}
+
+func (h *StopTimer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid task id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.Service.StopTimer(ctx, entity.TaskID(id))
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to stop timer",
+			Details: []string{err.Error()},
+		}, timeEntryErrorStatus(err))
+		return
+	}
+	RespondJSON(ctx, w, newTimeEntry(e), http.StatusOK)
+}
+
+type UpdateTimeEntry struct {
+	Service   UpdateTimeEntryService
+	Validator *validator.Validate
+}
+
+func (h *UpdateTimeEntry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid time entry id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var b struct {
+		StartedAt *time.Time `json:"started_at" validate:"required"`
+		StoppedAt *time.Time `json:"stopped_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	e, err := h.Service.UpdateTimeEntry(ctx, entity.TimeEntryID(id), *b.StartedAt, b.StoppedAt)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to update time entry",
+			Details: []string{err.Error()},
+		}, timeEntryErrorStatus(err))
+		return
+	}
+	RespondJSON(ctx, w, newTimeEntry(e), http.StatusOK)
+}
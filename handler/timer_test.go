@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestStartTimer_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		id   string
+		err  error
+		want want
+	}{
+		"ok": {
+			id: "10",
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/timer/start_ok_rsp.json.golden",
+			},
+		},
+		"alreadyRunning": {
+			id:  "10",
+			err: fmt.Errorf("failed to start timer: timer is already running: %w", store.ErrAlreadyExists),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/timer/start_conflict_rsp.json.golden",
+			},
+		},
+		"invalidID": {
+			id: "abc",
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/timer/start_bad_id_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/tasks/"+tt.id+"/timer/start", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &StartTimerServiceMock{}
+			moq.StartTimerFunc = func(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.TimeEntry{ID: 1, TaskID: taskID, StartedAt: clock.FixedClocker{}.Now()}, nil
+			}
+			sut := StartTimer{Service: moq}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
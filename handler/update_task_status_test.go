@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestUpdateTaskStatus_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		err  error
+		want want
+	}{
+		"ok": {
+			want: want{status: http.StatusNoContent},
+		},
+		"wipLimitExceeded": {
+			err: fmt.Errorf(`column "Doing" has 2 tasks: %w`, service.ErrWIPLimitExceeded),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/update_task_status/conflict_rsp.json.golden",
+			},
+		},
+		"taskNotFound": {
+			err: fmt.Errorf("failed to get task: task 10: %w", store.ErrNotFound),
+			want: want{
+				status:  http.StatusNotFound,
+				rspFile: "testdata/update_task_status/not_found_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/tasks/10/status", bytes.NewReader([]byte(`{"status": "doing"}`)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "10")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &UpdateTaskStatusServiceMock{}
+			moq.UpdateTaskStatusFunc = func(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error {
+				if id != 10 || status != entity.TaskStatusDoing {
+					t.Errorf("unexpected update of task %d to %q", id, status)
+				}
+				return tt.err
+			}
+			sut := UpdateTaskStatus{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, w.Result(), tt.want.status, body)
+		})
+	}
+}
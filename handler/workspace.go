@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddWorkspace struct {
+	Service   AddWorkspaceService
+	Validator *validator.Validate
+}
+
+func (h *AddWorkspace) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b struct {
+		Name string `json:"name" validate:"required,max=64"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	ws, err := h.Service.AddWorkspace(ctx, b.Name)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add workspace",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	rsp := struct {
+		ID entity.WorkspaceID `json:"id"`
+	}{ID: ws.ID}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
+
+type ListWorkspaces struct {
+	Service ListWorkspacesService
+}
+
+func (h *ListWorkspaces) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workspaces, err := h.Service.ListWorkspaces(ctx)
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to list workspaces",
+			Details: []string{err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	type workspace struct {
+		ID       entity.WorkspaceID   `json:"id"`
+		Name     string               `json:"name"`
+		Personal bool                 `json:"personal"`
+		Role     entity.WorkspaceRole `json:"role"`
+	}
+	rsp := []workspace{}
+	for _, ws := range workspaces {
+		rsp = append(rsp, workspace{
+			ID:       ws.ID,
+			Name:     ws.Name,
+			Personal: ws.PersonalUserID != nil,
+			Role:     ws.Role,
+		})
+	}
+	RespondJSON(ctx, w, rsp, http.StatusOK)
+}
+
+type AddWorkspaceMember struct {
+	Service   AddWorkspaceMemberService
+	Validator *validator.Validate
+}
+
+func (h *AddWorkspaceMember) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := parseIDParam(r, "id")
+	if err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "invalid workspace id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var b struct {
+		Name string               `json:"name" validate:"required"`
+		Role entity.WorkspaceRole `json:"role" validate:"required,oneof=admin member"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to decode request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+	if err := h.Validator.Struct(b); err != nil {
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to validate request",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	m, err := h.Service.AddWorkspaceMember(ctx, entity.WorkspaceID(id), b.Name, b.Role)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			status = http.StatusForbidden
+		case errors.Is(err, store.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrAlreadyExists):
+			status = http.StatusConflict
+		}
+		RespondJSON(ctx, w, &ErrResponse{
+			Message: "failed to add workspace member",
+			Details: []string{err.Error()},
+		}, status)
+		return
+	}
+	rsp := struct {
+		UserID entity.UserID        `json:"user_id"`
+		Role   entity.WorkspaceRole `json:"role"`
+	}{UserID: m.UserID, Role: m.Role}
+	RespondJSON(ctx, w, rsp, http.StatusCreated)
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator/v10"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestAddWorkspaceMember_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+	tests := map[string]struct {
+		body string
+		err  error
+		want want
+	}{
+		"ok": {
+			body: `{"name": "bob", "role": "member"}`,
+			want: want{
+				status:  http.StatusCreated,
+				rspFile: "testdata/workspace/add_member_ok_rsp.json.golden",
+			},
+		},
+		"forbidden": {
+			body: `{"name": "bob", "role": "member"}`,
+			err:  fmt.Errorf(`role "member" cannot add members: %w`, service.ErrForbidden),
+			want: want{
+				status:  http.StatusForbidden,
+				rspFile: "testdata/workspace/add_member_forbidden_rsp.json.golden",
+			},
+		},
+		"alreadyMember": {
+			body: `{"name": "bob", "role": "member"}`,
+			err:  fmt.Errorf("failed to add member: user 2 is already a member: %w", store.ErrAlreadyExists),
+			want: want{
+				status:  http.StatusConflict,
+				rspFile: "testdata/workspace/add_member_conflict_rsp.json.golden",
+			},
+		},
+		"ownerRoleCannotBeGranted": {
+			body: `{"name": "bob", "role": "owner"}`,
+			want: want{
+				status:  http.StatusBadRequest,
+				rspFile: "testdata/workspace/add_member_bad_role_rsp.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/workspaces/5/members", bytes.NewReader([]byte(tt.body)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "5")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			moq := &AddWorkspaceMemberServiceMock{}
+			moq.AddWorkspaceMemberFunc = func(ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole) (*entity.WorkspaceMember, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &entity.WorkspaceMember{WorkspaceID: id, UserID: 2, Role: role}, nil
+			}
+			sut := AddWorkspaceMember{Service: moq, Validator: validator.New()}
+			sut.ServeHTTP(w, r)
+			testutil.AssertResponse(t, w.Result(), tt.want.status, testutil.LoadFile(t, tt.want.rspFile))
+		})
+	}
+}
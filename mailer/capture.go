@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+)
+
+// Capture はメールを送らずにメモリへ保持するMailer。開発環境とテストで使う。
+// Dirを指定すると、メールを.emlファイルとしても書き出す。
+type Capture struct {
+	From    string
+	Dir     string
+	Clocker clock.Clocker
+
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func (c *Capture) Send(ctx context.Context, msg *Message) error {
+	if _, _, err := msg.envelope(c.From); err != nil {
+		return err
+	}
+	m := *msg
+	m.To = append([]string(nil), msg.To...)
+	if m.From == "" {
+		m.From = c.From
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, &m)
+	if c.Dir == "" {
+		return nil
+	}
+
+	now := c.Clocker.Now()
+	body, err := m.encode(m.From, now)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	name := fmt.Sprintf("%s-%04d.eml", now.UTC().Format("20060102T150405"), len(c.messages))
+	if err := os.WriteFile(filepath.Join(c.Dir, name), body, 0o644); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
+
+// Messages はこれまでに送られたメールを送られた順に返す
+func (c *Capture) Messages() []*Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Message(nil), c.messages...)
+}
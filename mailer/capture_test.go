@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/config"
+)
+
+func TestCapture_Send(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m, err := New(&config.Config{MailBackend: BackendCapture, MailFrom: "todo@example.com", MailCaptureDir: dir}, clock.FixedClocker{})
+	if err != nil {
+		t.Fatalf("failed to create mailer: %v", err)
+	}
+	sut, ok := m.(*Capture)
+	if !ok {
+		t.Fatalf("New() returned %T, want *Capture", m)
+	}
+
+	if err := sut.Send(context.Background(), &Message{To: []string{"alice@example.com"}, Subject: "件名", HTML: "<p>本文</p>"}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	if err := sut.Send(context.Background(), &Message{Subject: "no recipient"}); err == nil {
+		t.Error("want error for message without recipient")
+	}
+
+	got := sut.Messages()
+	if len(got) != 1 || got[0].From != "todo@example.com" || got[0].Subject != "件名" {
+		t.Errorf("Messages() = %+v", got)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("captured files = %v, %v, want 1 file", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", files[0], err)
+	}
+	if subject, body := parseMail(t, data); subject != "件名" || body != "<p>本文</p>" {
+		t.Errorf("captured file has subject %q and body %q", subject, body)
+	}
+
+	if _, err := New(&config.Config{MailBackend: "sendmail"}, clock.FixedClocker{}); err == nil {
+		t.Error("want error for unknown backend")
+	}
+}
@@ -0,0 +1,108 @@
+// Package mailer はメールの送信を扱う。
+// 本番ではSMTPサーバーへ送り、開発やテストでは送らずにCaptureへ保持する。
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/config"
+)
+
+// ErrInvalidMessage は宛先や件名が不正で、何度送っても失敗するメッセージのエラー
+var ErrInvalidMessage = errors.New("invalid message")
+
+const (
+	BackendSMTP    = "smtp"
+	BackendCapture = "capture"
+)
+
+type Message struct {
+	// From は差出人。空ならMailerに設定された差出人を使う
+	From    string
+	To      []string
+	Subject string
+	HTML    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New は設定のMailBackendに応じたMailerを返す
+func New(cfg *config.Config, clocker clock.Clocker) (Mailer, error) {
+	switch cfg.MailBackend {
+	case BackendSMTP:
+		return &SMTP{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+			Clocker:  clocker,
+		}, nil
+	case BackendCapture:
+		return &Capture{From: cfg.MailFrom, Dir: cfg.MailCaptureDir, Clocker: clocker}, nil
+	default:
+		return nil, fmt.Errorf("unknown mail backend %q", cfg.MailBackend)
+	}
+}
+
+// envelope は差出人と宛先のアドレスを検証して、SMTPのエンベロープに使うアドレスを返す
+func (m *Message) envelope(defaultFrom string) (string, []string, error) {
+	from := m.From
+	if from == "" {
+		from = defaultFrom
+	}
+	f, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", nil, fmt.Errorf("from %q: %w", from, ErrInvalidMessage)
+	}
+	if len(m.To) == 0 {
+		return "", nil, fmt.Errorf("no recipient: %w", ErrInvalidMessage)
+	}
+	to := make([]string, 0, len(m.To))
+	for _, addr := range m.To {
+		a, err := mail.ParseAddress(addr)
+		if err != nil {
+			return "", nil, fmt.Errorf("to %q: %w", addr, ErrInvalidMessage)
+		}
+		to = append(to, a.Address)
+	}
+	// ヘッダーインジェクションを防ぐ
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return "", nil, fmt.Errorf("subject contains a line break: %w", ErrInvalidMessage)
+	}
+	return f.Address, to, nil
+}
+
+// encode はメッセージをRFC 5322の形式にする。本文はquoted-printableのHTMLにする
+func (m *Message) encode(from string, now time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", from)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.BEncoding.Encode("UTF-8", m.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", `text/html; charset="UTF-8"`)
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&b)
+	if _, err := w.Write([]byte(m.HTML)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
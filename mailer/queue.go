@@ -0,0 +1,89 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/textproto"
+	"time"
+)
+
+// ErrQueueFull は送信待ちのメールが多すぎて受け付けられないときのエラー
+var ErrQueueFull = errors.New("mail queue is full")
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+)
+
+// Queue はメールを非同期に送るMailer。Sendはキューに積むだけで、Runが順に送る。
+// 一時的な失敗はBackoffを倍にしながらMaxAttempts回まで再送する。
+// 停止時にキューに残っているメールは送られない。
+type Queue struct {
+	Mailer      Mailer
+	MaxAttempts int
+	Backoff     time.Duration
+
+	ch chan *Message
+}
+
+// NewQueue はsize件まで送信待ちにできるQueueを返す
+func NewQueue(m Mailer, size int) *Queue {
+	return &Queue{
+		Mailer:      m,
+		MaxAttempts: defaultMaxAttempts,
+		Backoff:     defaultBackoff,
+		ch:          make(chan *Message, size),
+	}
+}
+
+// Send はメールを送信待ちにする。キューがいっぱいならErrQueueFullを返す
+func (q *Queue) Send(ctx context.Context, msg *Message) error {
+	select {
+	case q.ch <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run はctxがキャンセルされるまでキューのメールを送り続ける
+func (q *Queue) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-q.ch:
+			if err := q.deliver(ctx, msg); err != nil {
+				log.Printf("failed to send mail to %v: %v", msg.To, err)
+			}
+		}
+	}
+}
+
+func (q *Queue) deliver(ctx context.Context, msg *Message) error {
+	backoff := q.Backoff
+	for attempt := 1; ; attempt++ {
+		err := q.Mailer.Send(ctx, msg)
+		if err == nil || permanent(err) || attempt >= q.MaxAttempts {
+			return err
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+		backoff *= 2
+	}
+}
+
+// permanent は再送しても成功しない失敗かを返す。SMTPの5xx応答は恒久的な失敗として扱う
+func permanent(err error) bool {
+	if errors.Is(err, ErrInvalidMessage) {
+		return true
+	}
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code >= 500
+}
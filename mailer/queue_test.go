@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestQueue_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		failures     []int
+		wantReceived int
+	}{
+		"sent at first attempt": {
+			wantReceived: 1,
+		},
+		"retried after temporary failures": {
+			failures:     []int{451, 421},
+			wantReceived: 1,
+		},
+		"gave up after max attempts": {
+			failures: []int{451, 451, 451},
+		},
+		"permanent failure is not retried": {
+			failures: []int{550},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := testutil.StartSMTPServer(t)
+			srv.FailNext(tt.failures...)
+			smtp := &SMTP{Host: srv.Host, Port: srv.Port, From: "todo@example.com", Clocker: clock.FixedClocker{}}
+			sut := NewQueue(smtp, 1)
+			sut.MaxAttempts = 3
+			sut.Backoff = time.Millisecond
+
+			msg := &Message{To: []string{"alice@example.com"}, Subject: "hello", HTML: "<p>hello</p>"}
+			if err := sut.Send(context.Background(), msg); err != nil {
+				t.Fatalf("failed to enqueue: %v", err)
+			}
+			if err := sut.Send(context.Background(), msg); err != ErrQueueFull {
+				t.Errorf("second message: want %v, but got %v", ErrQueueFull, err)
+			}
+
+			// キューが空になったらRunを止めて、サーバーが受け取った数を確認する
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- sut.Run(ctx) }()
+			msg2 := &Message{To: []string{"sentinel@example.com"}, Subject: "sentinel"}
+			for sut.Send(ctx, msg2) != nil {
+				time.Sleep(time.Millisecond)
+			}
+			waitFor(t, func() bool { return len(sut.ch) == 0 && lastTo(srv) == "sentinel@example.com" })
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("Run() returned %v", err)
+			}
+
+			if got := len(srv.Messages()) - 1; got != tt.wantReceived {
+				t.Errorf("server received %d messages, want %d", got, tt.wantReceived)
+			}
+		})
+	}
+}
+
+func lastTo(srv *testutil.FakeSMTPServer) string {
+	msgs := srv.Messages()
+	if len(msgs) == 0 || len(msgs[len(msgs)-1].To) == 0 {
+		return ""
+	}
+	return msgs[len(msgs)-1].To[0]
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
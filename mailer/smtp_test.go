@@ -0,0 +1,98 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestSMTP_Send(t *testing.T) {
+	t.Parallel()
+
+	srv := testutil.StartSMTPServer(t)
+	sut := &SMTP{Host: srv.Host, Port: srv.Port, From: "Todo <todo@example.com>", Clocker: clock.FixedClocker{}}
+
+	msg := &Message{
+		To:      []string{"alice@example.com", "Bob <bob@example.com>"},
+		Subject: "【期限が近づいています】報告書",
+		HTML:    "<p>タスク「報告書」の期限は明日です。</p>",
+	}
+	if err := sut.Send(context.Background(), msg); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+
+	got := srv.Messages()
+	if len(got) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(got))
+	}
+	if got[0].From != "todo@example.com" {
+		t.Errorf("MAIL FROM = %q, want todo@example.com", got[0].From)
+	}
+	if diff := cmp.Diff([]string{"alice@example.com", "bob@example.com"}, got[0].To); diff != "" {
+		t.Errorf("RCPT TO mismatch (-want +got):\n%s", diff)
+	}
+
+	subject, body := parseMail(t, got[0].Data)
+	if subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", subject, msg.Subject)
+	}
+	if body != msg.HTML {
+		t.Errorf("body = %q, want %q", body, msg.HTML)
+	}
+}
+
+func TestSMTP_SendErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := testutil.StartSMTPServer(t)
+	sut := &SMTP{Host: srv.Host, Port: srv.Port, From: "todo@example.com", Clocker: clock.FixedClocker{}}
+
+	err := sut.Send(context.Background(), &Message{To: []string{"not an address"}, Subject: "s"})
+	if !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("invalid recipient: want %v, but got %v", ErrInvalidMessage, err)
+	}
+	err = sut.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "s\r\nBcc: evil@example.com"})
+	if !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("subject with line break: want %v, but got %v", ErrInvalidMessage, err)
+	}
+
+	srv.FailNext(550)
+	err = sut.Send(context.Background(), &Message{To: []string{"a@example.com"}, Subject: "s"})
+	var tpErr *textproto.Error
+	if !errors.As(err, &tpErr) || tpErr.Code != 550 {
+		t.Errorf("rejected by server: want smtp 550, but got %v", err)
+	}
+	if len(srv.Messages()) != 0 {
+		t.Errorf("server stored %d rejected messages", len(srv.Messages()))
+	}
+}
+
+// parseMail は受け取ったメールの件名と本文をデコードする
+func parseMail(t *testing.T, data []byte) (string, string) {
+	t.Helper()
+
+	m, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	// DATAの終端の改行は本文に含めない
+	return subject, strings.TrimSuffix(string(body), "\n")
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// ErrUnknownTemplate は存在しないテンプレートを指定したときのエラー
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+// Lang はメールの言語
+type Lang string
+
+const (
+	LangJa Lang = "ja"
+	LangEn Lang = "en"
+	// DefaultLang は指定された言語のテンプレートがないときに使う言語
+	DefaultLang = LangJa
+)
+
+// テンプレート名。templates/<名前>.<言語>.htmlに対応する
+const (
+	TemplateDueReminder         = "due_reminder"
+	TemplatePasswordReset       = "password_reset"
+	TemplateWorkspaceInvitation = "workspace_invitation"
+)
+
+// DueReminderData はdue_reminderテンプレートに渡すデータ
+type DueReminderData struct {
+	Name      string
+	TaskTitle string
+	DueAt     time.Time
+}
+
+// PasswordResetData はpassword_resetテンプレートに渡すデータ
+type PasswordResetData struct {
+	Name      string
+	ResetURL  string
+	ExpiresIn time.Duration
+}
+
+// WorkspaceInvitationData はworkspace_invitationテンプレートに渡すデータ
+type WorkspaceInvitationData struct {
+	Inviter       string
+	WorkspaceName string
+	URL           string
+}
+
+// Templates は言語ごとのメールのテンプレート。
+// 各テンプレートは件名をsubject、本文をbodyとしてdefineする。
+type Templates struct {
+	templates map[string]*template.Template
+}
+
+// LoadTemplates は埋め込まれたテンプレートをすべて読み込む
+func LoadTemplates() (*Templates, error) {
+	files, err := fs.Glob(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	t := &Templates{templates: map[string]*template.Template{}}
+	funcs := template.FuncMap{
+		"minutes": func(d time.Duration) int { return int(d.Minutes()) },
+	}
+	for _, f := range files {
+		tmpl, err := template.New(path.Base(f)).Funcs(funcs).ParseFS(templateFS, f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f, err)
+		}
+		for _, block := range []string{"subject", "body"} {
+			if tmpl.Lookup(block) == nil {
+				return nil, fmt.Errorf("%s does not define %q", f, block)
+			}
+		}
+		t.templates[strings.TrimSuffix(path.Base(f), ".html")] = tmpl
+	}
+	return t, nil
+}
+
+// Render はテンプレートから件名と本文を作る。宛先は呼び出し側で設定する。
+// langのテンプレートがなければDefaultLangのテンプレートを使う。
+func (t *Templates) Render(name string, lang Lang, data any) (*Message, error) {
+	tmpl, ok := t.templates[name+"."+string(lang)]
+	if !ok {
+		tmpl, ok = t.templates[name+"."+string(DefaultLang)]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrUnknownTemplate)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, fmt.Errorf("failed to render body of %s: %w", name, err)
+	}
+	return &Message{
+		// 件名はHTMLではないので、html/templateのエスケープを戻す
+		Subject: html.UnescapeString(strings.TrimSpace(subject.String())),
+		HTML:    body.String(),
+	}, nil
+}
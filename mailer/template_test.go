@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	t.Parallel()
+
+	sut, err := LoadTemplates()
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+	due := DueReminderData{
+		Name:      "<alice>",
+		TaskTitle: `"quarterly" report`,
+		DueAt:     time.Date(2024, 4, 11, 17, 0, 0, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		name        string
+		lang        Lang
+		data        any
+		wantSubject string
+		wantBody    []string
+	}{
+		"japanese": {
+			name:        TemplateDueReminder,
+			lang:        LangJa,
+			data:        due,
+			wantSubject: `【期限が近づいています】"quarterly" report`,
+			wantBody:    []string{"&lt;alice&gt; さん", "2024年4月11日 17:00"},
+		},
+		"english": {
+			name:        TemplateDueReminder,
+			lang:        LangEn,
+			data:        due,
+			wantSubject: `Reminder: ""quarterly" report" is due soon`,
+			wantBody:    []string{"Hi &lt;alice&gt;,", "Apr 11, 2024 17:00"},
+		},
+		"unknown language falls back to default": {
+			name:        TemplatePasswordReset,
+			lang:        Lang("fr"),
+			data:        PasswordResetData{Name: "alice", ResetURL: "https://example.com/reset?token=abc", ExpiresIn: 30 * time.Minute},
+			wantSubject: "パスワードの再設定",
+			wantBody:    []string{`href="https://example.com/reset?token=abc"`, "有効期限は30分"},
+		},
+		"unsafe url is not linked": {
+			name:        TemplateWorkspaceInvitation,
+			lang:        LangEn,
+			data:        WorkspaceInvitationData{Inviter: "bob", WorkspaceName: "team", URL: "javascript:alert(1)"},
+			wantSubject: `bob invited you to "team"`,
+			wantBody:    []string{`href="#ZgotmplZ"`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := sut.Render(tt.name, tt.lang, tt.data)
+			if err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+			if got.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(got.HTML, want) {
+					t.Errorf("body does not contain %q:\n%s", want, got.HTML)
+				}
+			}
+		})
+	}
+
+	if _, err := sut.Render("newsletter", LangJa, nil); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("unknown template: want %v, but got %v", ErrUnknownTemplate, err)
+	}
+}
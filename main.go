@@ -6,6 +6,9 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	_ "time/tzdata"
 
 	"golang.org/x/sync/errgroup"
 
@@ -59,6 +62,12 @@ import (
 // 	return eg.Wait()
 // }
 
+// main はサブコマンドを実行する。省略するとserveでサーバーを起動する
+//
+//	serve             HTTPとgRPCのサーバーとスケジューラーを起動する
+//	cleanup-sessions  TTLなしで保存された古いセッションのキーにTTLを設定する
+//	rotate-key        署名鍵を新しく作り、有効な鍵にする
+//	retire-key        署名鍵を廃止する
 func main() {
 	cfg, err := config.New()
 	if err != nil {
@@ -66,28 +75,75 @@ func main() {
 		os.Exit(1)
 	}
 
-	l, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+	cmd, args := "serve", []string{}
+	if len(os.Args) > 1 {
+		cmd, args = os.Args[1], os.Args[2:]
+	}
+	switch cmd {
+	case "serve":
+		err = serve(cfg)
+	case "cleanup-sessions":
+		err = cleanupSessions(context.Background(), cfg, args)
+	case "rotate-key":
+		err = rotateKey(cfg, args)
+	case "retire-key":
+		err = retireKey(cfg, args)
+	default:
+		log.Printf("unknown command %q", cmd)
+		os.Exit(2)
+	}
 	if err != nil {
-		log.Printf("failed to listen port %d: %v", cfg.Port, err)
+		log.Printf("failed to run %s: %v", cmd, err)
 		os.Exit(1)
 	}
+}
+
+func serve(cfg *config.Config) error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen port %d: %w", cfg.Port, err)
+	}
 
 	mux, cleanup, err := NewMux(context.Background(), cfg)
 	if err != nil {
-		log.Printf("failed to create mux: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create mux: %w", err)
 	}
 	defer cleanup()
 
 	server := NewServer(l, mux)
 
-	eg, ctx := errgroup.WithContext(context.Background())
+	gl, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen port %d: %w", cfg.GRPCPort, err)
+	}
+	grpcServer, cleanupGRPC, err := NewGRPCServer(context.Background(), gl, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create grpc server: %w", err)
+	}
+	defer cleanupGRPC()
+
+	scheduler, cleanupScheduler, err := NewReminderScheduler(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create reminder scheduler: %w", err)
+	}
+	defer cleanupScheduler()
+
+	// シグナルを受けたらHTTPとgRPCのサーバーとスケジューラーを止める
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
 		return server.Run(ctx)
 	})
+	eg.Go(func() error {
+		return grpcServer.Run(ctx)
+	})
+	eg.Go(func() error {
+		return scheduler.Run(ctx)
+	})
 
 	if err := eg.Wait(); err != nil {
-		log.Printf("failed to terminate server: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to terminate server: %w", err)
 	}
+	return nil
 }
@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi"
 	"github.com/go-playground/validator/v10"
 	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/clock"
 	"github.com/zakisanbaiman/go-handson01/config"
+	"github.com/zakisanbaiman/go-handson01/graph"
 	"github.com/zakisanbaiman/go-handson01/handler"
+	"github.com/zakisanbaiman/go-handson01/mailer"
+	"github.com/zakisanbaiman/go-handson01/oidc"
+	"github.com/zakisanbaiman/go-handson01/openapi"
 	"github.com/zakisanbaiman/go-handson01/service"
 	"github.com/zakisanbaiman/go-handson01/store"
 )
@@ -20,6 +26,22 @@ func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, func(), erro
 	// CORSミドルウェアを適用
 	mux.Use(handler.CORSMiddleware(cfg.GetCORSOptions()))
 
+	// OpenAPIのドキュメントでリクエストを検証する。開発とテストではレスポンスも検証する
+	doc, err := openapi.Load(ctx)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	oam, err := openapi.Middleware(doc, cfg.Env == "dev" || cfg.Env == "test")
+	if err != nil {
+		return nil, func() {}, err
+	}
+	mux.Use(oam)
+	oah, err := openapi.Handler(doc)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	mux.Get("/openapi.json", oah)
+
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		_, _ = w.Write([]byte(`{"status": "ok"}`))
@@ -40,48 +62,279 @@ func NewMux(ctx context.Context, cfg *config.Config) (http.Handler, func(), erro
 	if err != nil {
 		return nil, cleanup, err
 	}
-	jwter, err := auth.NewJWTer(rcli, clocker)
+	jwter, err := newJWTer(cfg, rcli, clocker)
 	if err != nil {
 		return nil, cleanup, err
 	}
+	mux.Method(http.MethodGet, "/.well-known/jwks.json", &handler.JWKS{Service: jwter})
+	refreshTokens := &service.RefreshTokens{Store: rcli, Lifetime: cfg.RefreshTokenLifetime}
+	logout := &service.Logout{Tokens: jwter, RefreshTokens: refreshTokens}
+	mfa := &service.MFA{DB: db, Repo: &r, Clocker: clocker, Issuer: cfg.MFAIssuer}
+	login := &service.Login{
+		DB: db, Repo: &repo, Users: &r, TokenGenerator: jwter, RefreshTokens: refreshTokens,
+		MFA: mfa, Challenges: rcli, ChallengeTTL: cfg.MFAChallengeTTL,
+		Throttle: newLoginThrottle(cfg, db, &r, rcli, clocker),
+	}
 
-	// login
-	login := &handler.Login{
-		Service:   &service.Login{DB: db, Repo: &repo, TokenGenerator: jwter},
-		Validator: v,
+	// メールはキューから非同期に送る。アカウントがあるかでパスワードの再設定の応答時間が変わらないようにするため
+	m, err := mailer.New(cfg, clocker)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	templates, err := mailer.LoadTemplates()
+	if err != nil {
+		return nil, cleanup, err
+	}
+	mailQueue := mailer.NewQueue(m, 100)
+	qctx, stopQueue := context.WithCancel(ctx)
+	go func() { _ = mailQueue.Run(qctx) }()
+	closeDB := cleanup
+	cleanup = func() {
+		stopQueue()
+		closeDB()
+	}
+	passwordReset := &service.PasswordReset{
+		DB: db, Repo: &r, Tokens: rcli, Limiter: rcli, Sessions: logout,
+		Mailer: mailQueue, Templates: templates, Clocker: clocker,
+		URL: cfg.PasswordResetURL, TTL: cfg.PasswordResetTTL,
+		Window: cfg.PasswordForgotWindow, IPLimit: cfg.PasswordForgotIPLimit, EmailLimit: cfg.PasswordForgotEmailLimit,
 	}
-	mux.Post("/login", login.ServeHTTP)
 
-	// task
-	at := &handler.AddTask{
-		Service:   &service.AddTask{DB: db, Repo: &r},
-		Validator: v,
+	oidcLogin := &service.OIDCLogin{
+		DB: db, Repo: &r, States: rcli, TokenGenerator: jwter, RefreshTokens: refreshTokens, StateTTL: cfg.OIDCStateTTL,
+		MFA: mfa, Challenges: rcli, ChallengeTTL: cfg.MFAChallengeTTL,
 	}
-	lt := &handler.ListTask{
-		Service: &service.ListTask{DB: db, Repo: &r},
+	if cfg.OIDCIssuer != "" {
+		oidcLogin.Provider = oidc.NewProvider(oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       strings.Fields(cfg.OIDCScopes),
+		}, clocker, cfg.JWTClockSkew)
 	}
-	mux.Route("/tasks", func(r chi.Router) {
-		r.Use(handler.AuthMiddleware(jwter))
-		r.Post("/", at.ServeHTTP)
-		r.Get("/", lt.ServeHTTP)
-	})
+	// コールバックがHTTPSなら、stateのクッキーもHTTPSでだけ送らせる
+	oidcSecureCookie := strings.HasPrefix(cfg.OIDCRedirectURL, "https://")
 
-	// user
-	ru := &handler.RegisterUser{
-		Service:   &service.RegisterUser{DB: db, Repo: &r},
-		Validator: v,
+	notifier := &service.Notify{DB: db, Repo: &r}
+	rns := &service.ReadNotifications{DB: db, Repo: &r}
+	nps := &service.NotificationPreferences{DB: db, Repo: &r}
+	ros := &service.ReminderOffsets{DB: db, Repo: &r}
+	schema, err := graph.NewSchema(&graph.Resolver{
+		TaskLister:    &service.ListTask{DB: db, Repo: &r},
+		TaskAdder:     &service.AddTask{DB: db, Repo: &r, Stats: rcli},
+		ProjectLister: &service.ListProjects{DB: db, Repo: &r},
+		Relations:     &service.TaskRelations{DB: db, Repo: &r},
+	}, cfg.GraphQLMaxDepth, cfg.GraphQLMaxComplexity)
+	if err != nil {
+		return nil, cleanup, err
 	}
-	mux.Post("/users", ru.ServeHTTP)
 
-	// admin
-	mux.Route("/admin", func(r chi.Router) {
-		r.Use(handler.AuthMiddleware(jwter))
-		r.Use(handler.AdminMiddleware)
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+	v1 := apiRoutes{
+		authn:     handler.AuthMiddleware(jwter),
+		workspace: handler.WorkspaceMiddleware(&service.ResolveWorkspace{DB: db, Repo: &r}),
+		adminOnly: handler.AdminMiddleware,
+
+		// user
+		registerUser: &handler.RegisterUser{
+			Service:   &service.RegisterUser{DB: db, Repo: &r},
+			Validator: v,
+		},
+		login:          &handler.Login{Service: login, Validator: v},
+		loginMFA:       &handler.LoginMFA{Service: login, Validator: v},
+		loginMFAEnroll: &handler.LoginMFAEnroll{Service: login, Validator: v},
+		enrollTOTP:     &handler.EnrollTOTP{Service: mfa},
+		confirmTOTP:    &handler.ConfirmTOTP{Service: mfa, Validator: v},
+		refreshToken: &handler.RefreshToken{
+			Service:   &service.RefreshToken{DB: db, Repo: &r, RefreshTokens: refreshTokens, TokenGenerator: jwter},
+			Validator: v,
+		},
+		forgotPassword: &handler.ForgotPassword{Service: passwordReset, Validator: v},
+		resetPassword:  &handler.ResetPassword{Service: passwordReset, Validator: v},
+		logout:         &handler.Logout{Service: logout},
+		logoutAll:      &handler.LogoutAll{Service: logout},
+		oidcLogin:      &handler.OIDCLogin{Service: oidcLogin, StateTTL: cfg.OIDCStateTTL, SecureCookie: oidcSecureCookie},
+		oidcCallback:   &handler.OIDCCallback{Service: oidcLogin},
+
+		// workspace
+		addWorkspace: &handler.AddWorkspace{
+			Service:   &service.AddWorkspace{DB: db, Repo: &r},
+			Validator: v,
+		},
+		listWorkspaces: &handler.ListWorkspaces{
+			Service: &service.ListWorkspaces{DB: db, Repo: &r},
+		},
+		addWorkspaceMember: &handler.AddWorkspaceMember{
+			Service:   &service.AddWorkspaceMember{DB: db, Repo: &r},
+			Validator: v,
+		},
+
+		// project
+		addProject: &handler.AddProject{
+			Service:   &service.AddProject{DB: db, Repo: &r},
+			Validator: v,
+		},
+		listProjects: &handler.ListProjects{
+			Service: &service.ListProjects{DB: db, Repo: &r},
+		},
+
+		// notification
+		listNotifications: &handler.ListNotifications{
+			Service: &service.ListNotifications{DB: db, Repo: &r},
+		},
+		readAllNotifications:          &handler.ReadAllNotifications{Service: rns},
+		readNotification:              &handler.ReadNotification{Service: rns},
+		getNotificationPreferences:    &handler.GetNotificationPreferences{Service: nps},
+		updateNotificationPreferences: &handler.UpdateNotificationPreferences{Service: nps},
+
+		// reminder
+		getReminderOffsets:    &handler.GetReminderOffsets{Service: ros},
+		updateReminderOffsets: &handler.UpdateReminderOffsets{Service: ros, Validator: v},
+
+		// task
+		addTask: &handler.AddTask{
+			Service:   &service.AddTask{DB: db, Repo: &r, Stats: rcli},
+			QuickAdd:  &service.QuickAddTask{DB: db, Repo: &r, Clocker: clocker, Stats: rcli},
+			Validator: v,
+		},
+		listTasks: &handler.ListTask{
+			Service: &service.ListTask{DB: db, Repo: &r},
+		},
+		workload: &handler.Workload{
+			Service: &service.Workload{DB: db, Repo: &r},
+		},
+		updateTaskStatus: &handler.UpdateTaskStatus{
+			Service:   &service.UpdateTaskStatus{DB: db, Repo: &r, Stats: rcli},
+			Validator: v,
+		},
+		moveTask: &handler.MoveTask{
+			Service:   &service.MoveTask{DB: db, Repo: &r, Stats: rcli},
+			Validator: v,
+		},
+		assignTask: &handler.AssignTask{
+			Service: &service.AssignTask{DB: db, Repo: &r, Notifier: notifier},
+		},
+		setTaskProject: &handler.SetTaskProject{
+			Service: &service.SetTaskProject{DB: db, Repo: &r},
+		},
+		startTimer: &handler.StartTimer{
+			Service: &service.StartTimer{DB: db, Repo: &r, Clocker: clocker},
+		},
+		stopTimer: &handler.StopTimer{
+			Service: &service.StopTimer{DB: db, Repo: &r, Clocker: clocker},
+		},
+
+		// board
+		getBoard: &handler.GetBoard{
+			Service: &service.GetBoard{DB: db, Repo: &r},
+		},
+		addColumn: &handler.AddColumn{
+			Service:   &service.AddColumn{DB: db, Repo: &r},
+			Validator: v,
+		},
+
+		// time tracking
+		updateTimeEntry: &handler.UpdateTimeEntry{
+			Service:   &service.UpdateTimeEntry{DB: db, Repo: &r, Clocker: clocker},
+			Validator: v,
+		},
+		timeReport: &handler.TimeReport{
+			Service: &service.TimeReport{DB: db, Repo: &r, Clocker: clocker},
+		},
+
+		// template
+		addTemplate: &handler.AddTemplate{
+			Service:   &service.AddTemplate{DB: db, Repo: &r},
+			Validator: v,
+		},
+		listTemplates: &handler.ListTemplates{
+			Service: &service.ListTemplates{DB: db, Repo: &r},
+		},
+		instantiateTemplate: &handler.InstantiateTemplate{
+			Service: &service.InstantiateTemplate{DB: db, Repo: &r, Clocker: clocker, Stats: rcli},
+		},
+
+		// saved searches
+		addSavedSearch: &handler.AddSavedSearch{
+			Service:   &service.AddSavedSearch{DB: db, Repo: &r},
+			Validator: v,
+		},
+		listSavedSearches: &handler.ListSavedSearches{
+			Service: &service.ListSavedSearches{DB: db, Repo: &r},
+		},
+		savedSearchTasks: &handler.SavedSearchTasks{
+			Service: &service.SavedSearchTasks{DB: db, Repo: &r},
+		},
+		deleteSavedSearch: &handler.DeleteSavedSearch{
+			Service: &service.DeleteSavedSearch{DB: db, Repo: &r},
+		},
+
+		graphQL: &handler.GraphQL{Service: schema},
+		stats: &handler.Stats{
+			Service: &service.Stats{DB: db, Repo: &r, Cache: rcli},
+		},
+		admin: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			_, _ = w.Write([]byte(`{"status": "ok"}`))
-		})
-	})
+		}),
+
+		revokeSessions:  &handler.RevokeSessions{Service: logout},
+		getMFAPolicy:    &handler.GetMFAPolicy{Service: mfa},
+		updateMFAPolicy: &handler.UpdateMFAPolicy{Service: mfa, Validator: v},
+	}
+	mountVersions(mux, cfg, v1)
 
 	return mux, cleanup, nil
 }
+
+// mountVersions はバージョンごとのルートを登録する。
+// ルート直下のパスは/v1の非推奨のエイリアスとして残し、DeprecationとSunsetのヘッダを付ける
+func mountVersions(mux chi.Router, cfg *config.Config, v1 apiRoutes) {
+	mux.Route("/v1", v1.mount)
+	mux.Group(func(r chi.Router) {
+		r.Use(handler.DeprecationMiddleware(cfg.LegacyRoutesDeprecatedAt, cfg.LegacyRoutesSunset, "/v1"))
+		v1.mount(r)
+	})
+}
+
+// newJWTer は設定の鍵と有効期間でJWTerを作る。HTTPとgRPCで同じ設定を使う
+func newJWTer(cfg *config.Config, s auth.Store, c clock.Clocker) (*auth.JWTer, error) {
+	jwter, err := auth.NewJWTer(s, c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.JWTKeys != "" {
+		keys, err := auth.LoadKeySet(cfg.JWTKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load jwt keys: %w", err)
+		}
+		jwter.Keys = keys
+	}
+	alg, err := auth.ParseAlgorithm(cfg.JWTAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	if got := jwter.Keys.Algorithm(); got != alg {
+		return nil, fmt.Errorf("active jwt key %s signs with %s, but %s is configured", jwter.Keys.ActiveKeyID(), got, alg)
+	}
+	jwter.AccessTokenLifetime = cfg.AccessTokenLifetime
+	jwter.IdleTimeout = cfg.SessionIdleTimeout
+	jwter.Issuer = cfg.JWTIssuer
+	jwter.Audience = cfg.JWTAudience
+	jwter.ClockSkew = cfg.JWTClockSkew
+	return jwter, nil
+}
+
+// newLoginThrottle は設定のしきい値でログインの試行回数を制限する。HTTPとgRPCで同じ設定を使う
+func newLoginThrottle(cfg *config.Config, db store.Execer, r *store.Repository, kvs *store.KVS, c clock.Clocker) *service.LoginThrottle {
+	return &service.LoginThrottle{
+		DB: db, Repo: r, Store: kvs, Clocker: c,
+		Window:          cfg.LoginFailureWindow,
+		DelayAfter:      cfg.LoginDelayAfter,
+		BaseDelay:       cfg.LoginBaseDelay,
+		MaxDelay:        cfg.LoginMaxDelay,
+		UserLockout:     cfg.LoginUserLockout,
+		IPLockout:       cfg.LoginIPLockout,
+		LockoutDuration: cfg.LoginLockoutDuration,
+	}
+}
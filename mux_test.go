@@ -2,13 +2,34 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime/quotedprintable"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
 	"github.com/zakisanbaiman/go-handson01/config"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/openapi"
+	"github.com/zakisanbaiman/go-handson01/testutil"
 )
 
 func TestNewMux(t *testing.T) {
@@ -24,6 +45,9 @@ func TestNewMux(t *testing.T) {
 		DBName:     "todo",
 		RedisHost:  "127.0.0.1",
 		RedisPort:  36379,
+
+		JWTAlgorithm: "RS256",
+		MailBackend:  "capture",
 	}
 	sut, cleanup, err := NewMux(context.Background(), cfg)
 	if err != nil {
@@ -49,3 +73,527 @@ func TestNewMux(t *testing.T) {
 		t.Errorf("expected body %s, but got %s", `{"status": "ok"}`, string(got))
 	}
 }
+
+// newTestMux はテスト用の設定でNewMuxを作る。optsで設定を書き換えられる
+func newTestMux(t *testing.T, opts ...func(cfg *config.Config)) http.Handler {
+	t.Helper()
+
+	cfg := &config.Config{
+		Env:                  "test",
+		DBHost:               "127.0.0.1",
+		DBPort:               33306,
+		DBUser:               "todo",
+		DBPassword:           "todo",
+		DBName:               "todo",
+		RedisHost:            "127.0.0.1",
+		RedisPort:            36379,
+		GraphQLMaxDepth:      8,
+		GraphQLMaxComplexity: 1000,
+		AccessTokenLifetime:  30 * time.Minute,
+		RefreshTokenLifetime: time.Hour,
+		SessionIdleTimeout:   15 * time.Minute,
+		JWTAlgorithm:         "RS256",
+		JWTIssuer:            "go-handson01-test",
+		JWTAudience:          "go-handson01-test-api",
+		JWTClockSkew:         30 * time.Second,
+		OIDCStateTTL:         10 * time.Minute,
+		MailBackend:          "capture",
+		MailFrom:             "todo@example.com",
+		PasswordResetURL:     "http://localhost/password/reset",
+		PasswordResetTTL:     30 * time.Minute,
+		PasswordForgotWindow: time.Hour,
+		// テストはすべて同じIPアドレスから送るので、IPアドレスごとの上限は掛からないようにする
+		PasswordForgotIPLimit:    1000000,
+		PasswordForgotEmailLimit: 3,
+		LoginFailureWindow:       15 * time.Minute,
+		LoginDelayAfter:          3,
+		LoginBaseDelay:           time.Second,
+		LoginMaxDelay:            30 * time.Second,
+		LoginUserLockout:         10,
+		LoginIPLockout:           1000000,
+		LoginLockoutDuration:     15 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	mux, cleanup, err := NewMux(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create mux: %v", err)
+	}
+	t.Cleanup(cleanup)
+	return mux
+}
+
+// TestNewMux_OpenAPIRoutes はNewMuxに登録したルートとOpenAPIのドキュメントのルートが一致することを確かめる。
+// ドキュメントには/v1のルートだけを書き、ルート直下のエイリアスは/v1と同じルートがあることを確かめる
+func TestNewMux_OpenAPIRoutes(t *testing.T) {
+	doc, err := openapi.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// HandleFuncで全メソッドに登録しているルートは、GETだけをドキュメントに書く
+	anyMethod := map[string]bool{"/health": true}
+	// バージョンの外に置くルート
+	unversioned := map[string]bool{"/health": true, "/openapi.json": true, "/.well-known/jwks.json": true}
+
+	var got, legacy []string
+	err = chi.Walk(newTestMux(t).(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		if anyMethod[route] && method != http.MethodGet {
+			return nil
+		}
+		if strings.HasPrefix(route, "/v1/") || unversioned[route] {
+			got = append(got, method+" "+route)
+		} else {
+			legacy = append(legacy, method+" /v1"+route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := openapi.Routes(doc)
+	sort.Strings(got)
+	sort.Strings(want)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("routes do not match openapi.yaml (-documented +routed):\n%s", diff)
+	}
+
+	var v1 []string
+	for _, r := range got {
+		if strings.Contains(r, " /v1/") {
+			v1 = append(v1, r)
+		}
+	}
+	sort.Strings(legacy)
+	if diff := cmp.Diff(v1, legacy); diff != "" {
+		t.Errorf("legacy aliases do not match /v1 routes (-v1 +legacy):\n%s", diff)
+	}
+}
+
+// apiClient はNewMuxにリクエストを送り、ステータスコードを確かめる
+type apiClient struct {
+	t     *testing.T
+	mux   http.Handler
+	token string
+	// cookies はブラウザのように各リクエストに付けるクッキー
+	cookies []*http.Cookie
+}
+
+func (c *apiClient) do(method, path, body string, want int) []byte {
+	c.t.Helper()
+
+	var r *http.Request
+	if body == "" {
+		r = httptest.NewRequest(method, path, nil)
+	} else {
+		r = httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		r.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	for _, cookie := range c.cookies {
+		r.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	c.mux.ServeHTTP(w, r)
+	if w.Code != want {
+		c.t.Fatalf("%s %s: want status %d, but got %d: %s", method, path, want, w.Code, w.Body.String())
+	}
+	return w.Body.Bytes()
+}
+
+// id はレスポンスのidを返す
+func (c *apiClient) id(rsp []byte) int64 {
+	c.t.Helper()
+
+	var b struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(rsp, &b); err != nil {
+		c.t.Fatalf("failed to decode %s: %v", rsp, err)
+	}
+	return b.ID
+}
+
+// TestNewMux_OpenAPIResponses は各ルートを呼び、ハンドラのレスポンスがドキュメントに合うことを確かめる。
+// テスト環境ではレスポンスを検証するので、ずれたハンドラは500を返してテストが失敗する。
+func TestNewMux_OpenAPIResponses(t *testing.T) {
+	c := &apiClient{t: t, mux: newTestMux(t)}
+	name := "oa" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	c.do(http.MethodGet, "/health", "", http.StatusOK)
+	c.do(http.MethodGet, "/openapi.json", "", http.StatusOK)
+	c.do(http.MethodGet, "/.well-known/jwks.json", "", http.StatusOK)
+	uid := c.id(c.do(http.MethodPost, "/v1/users", fmt.Sprintf(`{"name":%q,"password":"test","role":"user"}`, name), http.StatusCreated))
+	c.do(http.MethodPost, "/v1/users", fmt.Sprintf(`{"name":%q,"password":"test","role":"user"}`, name+"m"), http.StatusCreated)
+	c.do(http.MethodPost, "/v1/login", `{"user_name":"nobody"}`, http.StatusBadRequest)
+	var login struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"test"}`, name), http.StatusOK), &login); err != nil {
+		t.Fatal(err)
+	}
+	used := login.RefreshToken
+	if err := json.Unmarshal(c.do(http.MethodPost, "/v1/token/refresh", fmt.Sprintf(`{"refresh_token":%q}`, used), http.StatusOK), &login); err != nil {
+		t.Fatal(err)
+	}
+	c.token = login.AccessToken
+	c.do(http.MethodPost, "/v1/token/refresh", `{}`, http.StatusBadRequest)
+	c.do(http.MethodPost, "/v1/token/refresh", `{"refresh_token":"unknown"}`, http.StatusUnauthorized)
+
+	// workspaces
+	c.do(http.MethodGet, "/v1/workspaces", "", http.StatusOK)
+	wsID := c.id(c.do(http.MethodPost, "/v1/workspaces", `{"name":"team"}`, http.StatusCreated))
+	c.do(http.MethodPost, fmt.Sprintf("/v1/workspaces/%d/members", wsID), fmt.Sprintf(`{"name":%q,"role":"member"}`, name+"m"), http.StatusCreated)
+	c.do(http.MethodPost, fmt.Sprintf("/v1/workspaces/%d/members", wsID), `{"name":"nobody","role":"owner"}`, http.StatusBadRequest)
+
+	// projects
+	pid := c.id(c.do(http.MethodPost, "/v1/projects", `{"name":"docs"}`, http.StatusCreated))
+	c.do(http.MethodGet, "/v1/projects", "", http.StatusOK)
+
+	// tasks
+	tid := c.id(c.do(http.MethodPost, "/v1/tasks", `{"title":"write spec"}`, http.StatusCreated))
+	c.do(http.MethodPost, "/v1/tasks?parse=true", `{"title":"pay bills tomorrow #home !high"}`, http.StatusCreated)
+	c.do(http.MethodPost, "/v1/tasks", `{}`, http.StatusBadRequest)
+	c.do(http.MethodPut, fmt.Sprintf("/v1/tasks/%d/status", tid), `{"status":"doing"}`, http.StatusNoContent)
+	c.do(http.MethodPut, fmt.Sprintf("/v1/tasks/%d/status", tid), `{"status":"later"}`, http.StatusBadRequest)
+	c.do(http.MethodPut, fmt.Sprintf("/v1/tasks/%d/assignee", tid), fmt.Sprintf(`{"assignee_id":%d}`, uid), http.StatusNoContent)
+	c.do(http.MethodPut, fmt.Sprintf("/v1/tasks/%d/project", tid), fmt.Sprintf(`{"project_id":%d}`, pid), http.StatusNoContent)
+	c.do(http.MethodGet, "/v1/tasks", "", http.StatusOK)
+	c.do(http.MethodGet, "/v1/tasks?assignee=me", "", http.StatusOK)
+	c.do(http.MethodGet, "/v1/tasks?q="+url.QueryEscape("status:doing OR label:home"), "", http.StatusOK)
+	c.do(http.MethodGet, "/v1/tasks?q="+url.QueryEscape("status:"), "", http.StatusBadRequest)
+	c.do(http.MethodGet, "/v1/tasks/workload", "", http.StatusOK)
+	c.do(http.MethodPut, "/v1/tasks/0/status", `{"status":"done"}`, http.StatusNotFound)
+
+	// board
+	c.do(http.MethodGet, "/v1/board", "", http.StatusOK)
+	col := c.id(c.do(http.MethodPost, "/v1/board/columns", `{"name":"Review","status":"doing","wip_limit":3}`, http.StatusCreated))
+	c.do(http.MethodPut, fmt.Sprintf("/v1/tasks/%d/column", tid), fmt.Sprintf(`{"column_id":%d}`, col), http.StatusNoContent)
+	c.do(http.MethodGet, "/v1/board", "", http.StatusOK)
+
+	// timer
+	eid := c.id(c.do(http.MethodPost, fmt.Sprintf("/v1/tasks/%d/timer/start", tid), "", http.StatusCreated))
+	c.do(http.MethodPost, fmt.Sprintf("/v1/tasks/%d/timer/stop", tid), "", http.StatusOK)
+	now := time.Now().UTC()
+	c.do(http.MethodPut, fmt.Sprintf("/v1/time-entries/%d", eid), fmt.Sprintf(`{"started_at":%q,"stopped_at":%q}`,
+		now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339)), http.StatusOK)
+	c.do(http.MethodGet, fmt.Sprintf("/v1/reports/time?from=%s&to=%s",
+		now.AddDate(0, 0, -1).Format(time.DateOnly), now.AddDate(0, 0, 1).Format(time.DateOnly)), "", http.StatusOK)
+
+	// templates
+	tpl := c.id(c.do(http.MethodPost, "/v1/templates", `{"title":"release","items":[{"title":"tag"},{"title":"announce","due_offset_minutes":60}]}`, http.StatusCreated))
+	c.do(http.MethodGet, "/v1/templates", "", http.StatusOK)
+	c.do(http.MethodPost, fmt.Sprintf("/v1/templates/%d/instantiate", tpl), "", http.StatusCreated)
+
+	// notifications
+	c.do(http.MethodGet, "/v1/notifications", "", http.StatusOK)
+	c.do(http.MethodGet, "/v1/notifications?unread=true", "", http.StatusOK)
+	c.do(http.MethodPut, "/v1/notifications/read", "", http.StatusOK)
+	c.do(http.MethodPut, "/v1/notifications/0/read", "", http.StatusNotFound)
+	c.do(http.MethodGet, "/v1/notifications/preferences", "", http.StatusOK)
+	c.do(http.MethodPut, "/v1/notifications/preferences", `{"comment":false}`, http.StatusOK)
+	c.do(http.MethodPut, "/v1/notifications/preferences", `{"unknown":false}`, http.StatusBadRequest)
+
+	// reminders
+	c.do(http.MethodGet, "/v1/reminders/offsets", "", http.StatusOK)
+	c.do(http.MethodPut, "/v1/reminders/offsets", `{"offsets":[60,1440]}`, http.StatusOK)
+	c.do(http.MethodPut, "/v1/reminders/offsets", `{"offsets":[]}`, http.StatusOK)
+
+	// saved searches
+	ss := c.id(c.do(http.MethodPost, "/v1/saved-searches", `{"name":"doing","filter":{"status":["doing"]}}`, http.StatusCreated))
+	c.do(http.MethodGet, "/v1/saved-searches", "", http.StatusOK)
+	c.do(http.MethodGet, fmt.Sprintf("/v1/saved-searches/%d/tasks", ss), "", http.StatusOK)
+	c.do(http.MethodDelete, fmt.Sprintf("/v1/saved-searches/%d", ss), "", http.StatusNoContent)
+
+	// others
+	c.do(http.MethodGet, "/v1/stats", "", http.StatusOK)
+	c.do(http.MethodGet, "/v1/stats?days=0", "", http.StatusBadRequest)
+	c.do(http.MethodPost, "/v1/graphql", `{"query":"{ tasks { id title labels project { name } assignee { name } } }"}`, http.StatusOK)
+	c.do(http.MethodGet, "/v1/admin", "", http.StatusUnauthorized)
+	c.do(http.MethodDelete, fmt.Sprintf("/v1/admin/users/%d/sessions", uid), "", http.StatusUnauthorized)
+
+	// logout
+	c.do(http.MethodPost, "/v1/logout", fmt.Sprintf(`{"refresh_token":%q}`, login.RefreshToken), http.StatusNoContent)
+	c.do(http.MethodGet, "/v1/stats", "", http.StatusUnauthorized)
+	c.token = ""
+	c.do(http.MethodPost, "/v1/token/refresh", fmt.Sprintf(`{"refresh_token":%q}`, login.RefreshToken), http.StatusUnauthorized)
+	if err := json.Unmarshal(c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"test"}`, name), http.StatusOK), &login); err != nil {
+		t.Fatal(err)
+	}
+	c.token = login.AccessToken
+	c.do(http.MethodPost, "/v1/logout/all", "", http.StatusNoContent)
+	c.do(http.MethodGet, "/v1/stats", "", http.StatusUnauthorized)
+}
+
+func TestNewJWTer(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, err := auth.RotateKey(dir, jwa.ES256); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		keys    string
+		alg     string
+		wantErr bool
+	}{
+		"embedded":      {alg: "RS256"},
+		"keys":          {keys: dir, alg: "ES256"},
+		"mismatch":      {keys: dir, alg: "RS256", wantErr: true},
+		"embeddedIsRSA": {alg: "EdDSA", wantErr: true},
+		"unsupported":   {alg: "HS256", wantErr: true},
+		"missingKeys":   {keys: filepath.Join(dir, "missing"), alg: "RS256", wantErr: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Config{
+				JWTKeys:      tt.keys,
+				JWTAlgorithm: tt.alg,
+				JWTIssuer:    "issuer",
+				JWTAudience:  "audience",
+			}
+			got, err := newJWTer(cfg, nil, clock.RealClocker{})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("want error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got.Issuer != "issuer" || got.Audience != "audience" {
+				t.Errorf("want issuer and audience from config, but got %q and %q", got.Issuer, got.Audience)
+			}
+		})
+	}
+}
+
+// TestNewMux_OIDC はFakeIdPでログインし、発行したトークンでAPIを呼べることを確かめる
+func TestNewMux_OIDC(t *testing.T) {
+	idp := testutil.StartIdP(t)
+	name := "sso" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	idp.SetUser(entity.OIDCIdentity{Subject: name, Email: name + "@example.com", PreferredUsername: name})
+	mux := newTestMux(t, func(cfg *config.Config) {
+		cfg.OIDCIssuer = idp.URL
+		cfg.OIDCClientID = idp.ClientID
+		cfg.OIDCClientSecret = idp.ClientSecret
+		cfg.OIDCRedirectURL = "http://localhost/v1/auth/oidc/callback"
+		cfg.OIDCScopes = "openid email"
+	})
+	c := &apiClient{t: t, mux: mux}
+
+	// login はログインを始め、IdPからリダイレクトされたコールバックのパスを返す。
+	// stateのクッキーはcに保存し、コールバックに付けて送る
+	login := func() string {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/login", nil))
+		if w.Code != http.StatusFound {
+			t.Fatalf("want status %d, but got %d: %s", http.StatusFound, w.Code, w.Body.String())
+		}
+		c.cookies = w.Result().Cookies()
+		client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}
+		rsp, err := client.Get(w.Header().Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = rsp.Body.Close()
+		callback, err := url.Parse(rsp.Header.Get("Location"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return callback.RequestURI()
+	}
+	// tokenName はアクセストークンのユーザー名
+	tokenName := func(rsp []byte) string {
+		var tokens entity.Tokens
+		if err := json.Unmarshal(rsp, &tokens); err != nil {
+			t.Fatal(err)
+		}
+		tok, err := jwt.ParseString(tokens.AccessToken, jwt.WithVerify(false), jwt.WithValidate(false))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := tok.Get(auth.UserNameKey)
+		c.token = tokens.AccessToken
+		return fmt.Sprint(got)
+	}
+
+	// ほかのブラウザで始めたログインのコールバックは、stateのクッキーがないので使えない
+	callback := login()
+	cookies := c.cookies
+	c.cookies = nil
+	c.do(http.MethodGet, callback, "", http.StatusUnauthorized)
+
+	c.cookies = cookies
+	if got := tokenName(c.do(http.MethodGet, callback, "", http.StatusOK)); got != name {
+		t.Errorf("want user %q, but got %q", name, got)
+	}
+	c.do(http.MethodGet, "/v1/workspaces", "", http.StatusOK)
+	// stateは1度しか使えない
+	c.do(http.MethodGet, callback, "", http.StatusUnauthorized)
+	c.do(http.MethodGet, "/v1/auth/oidc/callback?error=access_denied", "", http.StatusUnauthorized)
+
+	// IDプロバイダーで作ったユーザーはパスワードを持たないので、パスワードでは401になる
+	c.token = ""
+	c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"guess"}`, name), http.StatusUnauthorized)
+
+	// 2回目は同じユーザーでログインする
+	if got := tokenName(c.do(http.MethodGet, login(), "", http.StatusOK)); got != name {
+		t.Errorf("want the same user %q, but got %q", name, got)
+	}
+
+	// 設定していなければ404
+	c = &apiClient{t: t, mux: newTestMux(t)}
+	c.do(http.MethodGet, "/v1/auth/oidc/login", "", http.StatusNotFound)
+}
+
+// TestNewMux_PasswordReset はメールで届いたトークンでパスワードを再設定し、古いセッションが使えなくなることを確かめる
+func TestNewMux_PasswordReset(t *testing.T) {
+	dir := t.TempDir()
+	c := &apiClient{t: t, mux: newTestMux(t, func(cfg *config.Config) {
+		cfg.MailCaptureDir = dir
+	})}
+	name := "pw" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	email := name + "@example.com"
+	c.do(http.MethodPost, "/v1/users", fmt.Sprintf(`{"name":%q,"password":"old","role":"user","email":%q}`, name, email), http.StatusCreated)
+	var login struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"old"}`, name), http.StatusOK), &login); err != nil {
+		t.Fatal(err)
+	}
+
+	// アカウントがあってもなくても同じ応答
+	c.do(http.MethodPost, "/v1/password/forgot", fmt.Sprintf(`{"email":%q}`, "nobody-"+email), http.StatusAccepted)
+	c.do(http.MethodPost, "/v1/password/forgot", fmt.Sprintf(`{"email":%q}`, email), http.StatusAccepted)
+	c.do(http.MethodPost, "/v1/password/forgot", `{"email":"not an email"}`, http.StatusBadRequest)
+	token := waitPasswordResetToken(t, dir)
+
+	c.do(http.MethodPost, "/v1/password/reset", `{"token":"unknown","password":"new"}`, http.StatusBadRequest)
+	c.do(http.MethodPost, "/v1/password/reset", fmt.Sprintf(`{"token":%q,"password":"new"}`, token), http.StatusNoContent)
+	c.do(http.MethodPost, "/v1/password/reset", fmt.Sprintf(`{"token":%q,"password":"again"}`, token), http.StatusBadRequest)
+
+	c.token = login.AccessToken
+	c.do(http.MethodGet, "/v1/stats", "", http.StatusUnauthorized)
+	c.token = ""
+	c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"old"}`, name), http.StatusUnauthorized)
+	c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"new"}`, name), http.StatusOK)
+
+	// 同じメールアドレスには1時間に3回まで
+	c.do(http.MethodPost, "/v1/password/forgot", fmt.Sprintf(`{"email":%q}`, email), http.StatusAccepted)
+	c.do(http.MethodPost, "/v1/password/forgot", fmt.Sprintf(`{"email":%q}`, email), http.StatusAccepted)
+	r := httptest.NewRequest(http.MethodPost, "/v1/password/forgot", strings.NewReader(fmt.Sprintf(`{"email":%q}`, email)))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c.mux.ServeHTTP(w, r)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("want status %d, but got %d: %s", http.StatusTooManyRequests, w.Code, w.Body.String())
+	}
+	if s, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || s <= 0 || s > 3600 {
+		t.Errorf("want Retry-After within an hour, but got %q", w.Header().Get("Retry-After"))
+	}
+}
+
+// TestNewMux_LoginLockout はパスワードを続けて間違えたユーザーが、正しいパスワードでもしばらくログインできないことを確かめる
+func TestNewMux_LoginLockout(t *testing.T) {
+	c := &apiClient{t: t, mux: newTestMux(t, func(cfg *config.Config) {
+		cfg.LoginUserLockout = 3
+	})}
+	name := "lock" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	c.do(http.MethodPost, "/v1/users", fmt.Sprintf(`{"name":%q,"password":"right","role":"user"}`, name), http.StatusCreated)
+	for i := 0; i < 3; i++ {
+		c.do(http.MethodPost, "/v1/login", fmt.Sprintf(`{"user_name":%q,"password":"wrong"}`, name), http.StatusUnauthorized)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(fmt.Sprintf(`{"user_name":%q,"password":"right"}`, name)))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c.mux.ServeHTTP(w, r)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("want status %d, but got %d: %s", http.StatusTooManyRequests, w.Code, w.Body.String())
+	}
+	if s, err := strconv.Atoi(w.Header().Get("Retry-After")); err != nil || s <= 0 || s > 15*60 {
+		t.Errorf("want Retry-After within the lockout duration, but got %q", w.Header().Get("Retry-After"))
+	}
+}
+
+// TestNewMux_LoginConcurrentFailures は同時に送られた間違ったパスワードのうち、
+// 待つ時間をすり抜けてパスワードを確かめられるのが1つだけなことを確かめる
+func TestNewMux_LoginConcurrentFailures(t *testing.T) {
+	mux := newTestMux(t, func(cfg *config.Config) {
+		cfg.LoginDelayAfter = 0
+	})
+	c := &apiClient{t: t, mux: mux}
+	name := "race" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	c.do(http.MethodPost, "/v1/users", fmt.Sprintf(`{"name":%q,"password":"right","role":"user"}`, name), http.StatusCreated)
+
+	const attempts = 10
+	codes := make([]int, attempts)
+	var wg sync.WaitGroup
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader(fmt.Sprintf(`{"user_name":%q,"password":"wrong"}`, name)))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+			codes[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	got := map[int]int{}
+	for _, code := range codes {
+		got[code]++
+	}
+	want := map[int]int{http.StatusUnauthorized: 1, http.StatusTooManyRequests: attempts - 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("status codes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// waitPasswordResetToken はdirに書き出されたパスワードの再設定のメールを待ち、トークンを取り出す
+func waitPasswordResetToken(t *testing.T, dir string) string {
+	t.Helper()
+
+	re := regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) > 0 {
+			b, err := os.ReadFile(files[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, body, _ := strings.Cut(string(b), "\r\n\r\n")
+			decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := re.FindSubmatch(decoded)
+			if m == nil {
+				t.Fatalf("no token in the mail: %s", decoded)
+			}
+			return string(m[1])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("no password reset mail was sent")
+	return ""
+}
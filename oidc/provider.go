@@ -0,0 +1,207 @@
+// Package oidc はOpenID Connectの認可コードフローで外部のIDプロバイダーにログインする。
+// PKCE(S256)を使い、IDトークンはプロバイダーのJWKSで署名を検証する。
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// Config はIDプロバイダーとこのアプリのクライアントの設定
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// metadata はIDプロバイダーのディスカバリードキュメントのうち使う項目
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider はIDプロバイダーとのやり取りをする。
+// ディスカバリーは最初に使うときに行うので、起動時にIDプロバイダーが落ちていてもサーバーは起動できる。
+type Provider struct {
+	Config    Config
+	Client    *http.Client
+	Clocker   clock.Clocker
+	ClockSkew time.Duration
+
+	mu   sync.Mutex
+	meta *metadata
+}
+
+// NewProvider はcfgのIDプロバイダーのProviderを作る
+func NewProvider(cfg Config, c clock.Clocker, skew time.Duration) *Provider {
+	return &Provider{
+		Config:    cfg,
+		Client:    &http.Client{Timeout: 10 * time.Second},
+		Clocker:   c,
+		ClockSkew: skew,
+	}
+}
+
+// CodeChallenge はPKCEのcode_verifierからS256のcode_challengeを作る
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL はユーザーをリダイレクトする認可エンドポイントのURLを返す
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(meta.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.Config.ClientID)
+	q.Set("redirect_uri", p.Config.RedirectURL)
+	q.Set("scope", strings.Join(p.Config.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", CodeChallenge(verifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Exchange は認可コードをトークンと交換し、IDトークンを検証してユーザーを返す
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*entity.OIDCIdentity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.Config.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+
+	var rsp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.getJSON(req, &rsp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned %d: %s %s", status, rsp.Error, rsp.ErrorDescription)
+	}
+	if rsp.IDToken == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+	return p.verify(ctx, meta, rsp.IDToken, nonce)
+}
+
+// verify はIDトークンの署名とiss、aud、exp、nonceを検証する。
+// ログインのたびにJWKSを取得するので、IDプロバイダーが鍵をローテーションしても追従できる
+func (p *Provider) verify(ctx context.Context, meta *metadata, raw, nonce string) (*entity.OIDCIdentity, error) {
+	keys, err := jwk.Fetch(ctx, meta.JWKSURI, jwk.WithHTTPClient(p.Client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	tok, err := jwt.ParseString(raw,
+		jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+		jwt.WithClock(p.Clocker),
+		jwt.WithAcceptableSkew(p.ClockSkew),
+		jwt.WithIssuer(p.Config.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithRequiredClaim(jwt.SubjectKey),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithClaimValue("nonce", nonce),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	id := &entity.OIDCIdentity{Issuer: tok.Issuer(), Subject: tok.Subject()}
+	for k, dst := range map[string]*string{"email": &id.Email, "name": &id.Name, "preferred_username": &id.PreferredUsername} {
+		if v, ok := tok.Get(k); ok {
+			*dst, _ = v.(string)
+		}
+	}
+	return id, nil
+}
+
+// discover はディスカバリードキュメントを取得する。成功したら結果を使い回す
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	u := strings.TrimSuffix(p.Config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	meta := &metadata{}
+	status, err := p.getJSON(req, meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("discovery returned %d", status)
+	}
+	// なりすましを防ぐため、ドキュメントのissuerは設定と完全に一致しなければならない
+	if meta.Issuer != p.Config.Issuer {
+		return nil, fmt.Errorf("issuer %q does not match configured issuer %q", meta.Issuer, p.Config.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document is missing endpoints")
+	}
+	p.meta = meta
+	return meta, nil
+}
+
+func (p *Provider) getJSON(req *http.Request, v any) (int, error) {
+	rsp, err := p.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(rsp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return rsp.StatusCode, fmt.Errorf("failed to decode response (status %d): %w", rsp.StatusCode, err)
+	}
+	return rsp.StatusCode, nil
+}
@@ -0,0 +1,160 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+// authorize は認可エンドポイントにアクセスし、リダイレクト先のcodeとstateを返す
+func authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	rsp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusFound {
+		t.Fatalf("want status %d, but got %d", http.StatusFound, rsp.StatusCode)
+	}
+	loc, err := url.Parse(rsp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// setup はIdPとProviderを書き換える
+		setup func(idp *testutil.FakeIdP, p *Provider)
+		// nonce と verifier を指定すると、Exchangeでログイン開始時と違う値を使う
+		nonce, verifier string
+		reuseCode       bool
+		wantErr         bool
+	}{
+		"ok": {},
+		"wrongNonce": {
+			nonce:   "other-nonce",
+			wantErr: true,
+		},
+		"wrongVerifier": {
+			verifier: "other-verifier",
+			wantErr:  true,
+		},
+		"reusedCode": {
+			reuseCode: true,
+			wantErr:   true,
+		},
+		"wrongClientSecret": {
+			setup:   func(idp *testutil.FakeIdP, p *Provider) { p.Config.ClientSecret = "wrong" },
+			wantErr: true,
+		},
+		"wrongAudience": {
+			setup: func(idp *testutil.FakeIdP, p *Provider) {
+				idp.TamperIDToken = func(tok jwt.Token) { _ = tok.Set(jwt.AudienceKey, []string{"other-client"}) }
+			},
+			wantErr: true,
+		},
+		"wrongIssuer": {
+			setup: func(idp *testutil.FakeIdP, p *Provider) {
+				idp.TamperIDToken = func(tok jwt.Token) { _ = tok.Set(jwt.IssuerKey, "https://evil.example.com") }
+			},
+			wantErr: true,
+		},
+		"expired": {
+			setup: func(idp *testutil.FakeIdP, p *Provider) {
+				idp.TamperIDToken = func(tok jwt.Token) { _ = tok.Set(jwt.ExpirationKey, time.Now().Add(-time.Hour)) }
+			},
+			wantErr: true,
+		},
+		"noSubject": {
+			setup: func(idp *testutil.FakeIdP, p *Provider) {
+				idp.TamperIDToken = func(tok jwt.Token) { _ = tok.Remove(jwt.SubjectKey) }
+			},
+			wantErr: true,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			idp := testutil.StartIdP(t)
+			sut := NewProvider(Config{
+				Issuer:       idp.URL,
+				ClientID:     idp.ClientID,
+				ClientSecret: idp.ClientSecret,
+				RedirectURL:  "http://app.example.com/callback",
+				Scopes:       []string{"openid", "email"},
+			}, clock.RealClocker{}, 30*time.Second)
+			if tt.setup != nil {
+				tt.setup(idp, sut)
+			}
+
+			authURL, err := sut.AuthCodeURL(ctx, "state", "nonce", "verifier")
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			code, state := authorize(t, authURL)
+			if state != "state" {
+				t.Errorf("want state %q, but got %q", "state", state)
+			}
+			nonce, verifier := "nonce", "verifier"
+			if tt.nonce != "" {
+				nonce = tt.nonce
+			}
+			if tt.verifier != "" {
+				verifier = tt.verifier
+			}
+			if tt.reuseCode {
+				if _, err := sut.Exchange(ctx, code, verifier, nonce); err != nil {
+					t.Fatalf("want no error on first exchange, but got %v", err)
+				}
+			}
+
+			got, err := sut.Exchange(ctx, code, verifier, nonce)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("want error, but got identity %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			want := &entity.OIDCIdentity{
+				Issuer: idp.URL, Subject: "fake-user", Email: "fake@example.com", Name: "Fake User", PreferredUsername: "fake",
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("identity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	t.Parallel()
+
+	idp := testutil.StartIdP(t)
+	// 末尾のスラッシュが違うだけでも別のIDプロバイダーとみなす
+	sut := NewProvider(Config{Issuer: idp.URL + "/", ClientID: idp.ClientID}, clock.RealClocker{}, 0)
+	if _, err := sut.AuthCodeURL(context.Background(), "state", "nonce", "verifier"); err == nil {
+		t.Errorf("want error for issuer mismatch")
+	}
+}
@@ -0,0 +1,249 @@
+// Package openapi はAPIのOpenAPI 3のドキュメントと、リクエストとレスポンスをドキュメントで検証するミドルウェア。
+// ドキュメントはopenapi.yamlに手で書き、/openapi.jsonで配る。
+package openapi
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Load は埋め込んだドキュメントを読み込んで検証する
+func Load(ctx context.Context) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi document: %w", err)
+	}
+	if err := doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+	return doc, nil
+}
+
+// Handler はドキュメントをJSONで返す
+func Handler(doc *openapi3.T) (http.HandlerFunc, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openapi document: %w", err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.Write(body); err != nil {
+			log.Printf("failed to write response: %v", err)
+		}
+	}, nil
+}
+
+type errResponse struct {
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Middleware はドキュメントに合わないリクエストを400で返す。
+// ドキュメントにないルートは検証せずに次に渡し、ルーターに404や405を返させる。
+// 認証はAuthMiddlewareに任せ、ここではAuthorizationヘッダを見ない。
+// validateResponsesがtrueならレスポンスも検証し、合わないものを500に置き換える。
+// レスポンスはドキュメントにないプロパティも誤りとする。
+// レスポンスをすべてバッファするので、開発とテストでだけ有効にする。
+func Middleware(doc *openapi3.T, validateResponses bool) (func(next http.Handler) http.Handler, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openapi router: %w", err)
+	}
+	rspRouter, err := strictResponses(doc)
+	if err != nil {
+		return nil, err
+	}
+	opts := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, params, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+			in := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: params,
+				Route:      route,
+				Options:    opts,
+			}
+			if err := openapi3filter.ValidateRequest(ctx, in); err != nil {
+				respond(w, &errResponse{
+					Message: "request does not match the API specification",
+					Details: details(err),
+				}, http.StatusBadRequest)
+				return
+			}
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			rspRoute, _, err := rspRouter.FindRoute(r)
+			if err != nil {
+				rec.flush(w)
+				return
+			}
+			out := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    r,
+					PathParams: params,
+					Route:      rspRoute,
+					Options:    opts,
+				},
+				Status:  rec.status,
+				Header:  rec.header,
+				Body:    io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+				Options: &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+			}
+			if err := openapi3filter.ValidateResponse(ctx, out); err != nil {
+				log.Printf("response of %s %s does not match the API specification: %v", r.Method, r.URL.Path, err)
+				respond(w, &errResponse{
+					Message: "response does not match the API specification",
+					Details: details(err),
+				}, http.StatusInternalServerError)
+				return
+			}
+			rec.flush(w)
+		})
+	}, nil
+}
+
+// strictResponses はレスポンスのオブジェクトのスキーマが書かれていないプロパティを許さないドキュメントを作り、
+// そのルーターを返す。リクエストでは知らないプロパティを無視するので、元のドキュメントは変えない
+func strictResponses(doc *openapi3.T) (routers.Router, error) {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal openapi document: %w", err)
+	}
+	strict, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi document: %w", err)
+	}
+	seen := map[*openapi3.Schema]bool{}
+	for _, item := range strict.Paths.Map() {
+		for _, op := range item.Operations() {
+			for _, rsp := range op.Responses.Map() {
+				if rsp.Value == nil {
+					continue
+				}
+				for _, mt := range rsp.Value.Content {
+					disallowAdditional(mt.Schema, seen)
+				}
+			}
+		}
+	}
+	router, err := gorillamux.NewRouter(strict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openapi router: %w", err)
+	}
+	return router, nil
+}
+
+// disallowAdditional はプロパティを列挙したオブジェクトに、additionalPropertiesがなければfalseを設定する
+func disallowAdditional(ref *openapi3.SchemaRef, seen map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || seen[ref.Value] {
+		return
+	}
+	s := ref.Value
+	seen[s] = true
+	if len(s.Properties) > 0 && s.AdditionalProperties.Has == nil && s.AdditionalProperties.Schema == nil {
+		s.AdditionalProperties.Has = openapi3.Ptr(false)
+	}
+	for _, p := range s.Properties {
+		disallowAdditional(p, seen)
+	}
+	disallowAdditional(s.Items, seen)
+	disallowAdditional(s.AdditionalProperties.Schema, seen)
+	for _, refs := range []openapi3.SchemaRefs{s.OneOf, s.AnyOf, s.AllOf} {
+		for _, r := range refs {
+			disallowAdditional(r, seen)
+		}
+	}
+}
+
+func respond(w http.ResponseWriter, body *errResponse, status int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+// details はMultiErrorをエラーごとの文字列にする
+func details(err error) []string {
+	var me openapi3.MultiError
+	if !errors.As(err, &me) {
+		return []string{err.Error()}
+	}
+	var ds []string
+	for _, e := range me {
+		ds = append(ds, details(e)...)
+	}
+	return ds
+}
+
+// recorder はレスポンスを検証するまで書き出さずに保持する
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (r *recorder) Header() http.Header { return r.header }
+
+func (r *recorder) WriteHeader(status int) {
+	if r.wrote {
+		return
+	}
+	r.status = status
+	r.wrote = true
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wrote = true
+	return r.body.Write(b)
+}
+
+func (r *recorder) flush(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(r.status)
+	if _, err := w.Write(r.body.Bytes()); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+// Routes はドキュメントに書かれたルートを "GET /tasks" の形で返す
+func Routes(doc *openapi3.T) []string {
+	var routes []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			routes = append(routes, method+" "+path)
+		}
+	}
+	return routes
+}
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	doc, err := Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := Handler(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var got struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode document: %v", err)
+	}
+	if got.OpenAPI != "3.0.3" || got.Paths["/v1/tasks"] == nil {
+		t.Errorf("unexpected document: openapi=%q, %d paths", got.OpenAPI, len(got.Paths))
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		method, path, body string
+		// rspBody はハンドラが返すボディ。空ならハンドラは呼ばれてはいけない
+		rspBody           string
+		rspStatus         int
+		validateResponses bool
+		wantStatus        int
+		wantMessage       string
+	}{
+		"valid": {
+			method: http.MethodGet, path: "/health",
+			rspBody: `{"status":"ok"}`, rspStatus: http.StatusOK,
+			validateResponses: true,
+			wantStatus:        http.StatusOK,
+		},
+		"invalidRequest": {
+			method: http.MethodPut, path: "/v1/tasks/1/status", body: `{"status":"later"}`,
+			validateResponses: true,
+			wantStatus:        http.StatusBadRequest,
+			wantMessage:       "request does not match the API specification",
+		},
+		"invalidPathParam": {
+			method: http.MethodPut, path: "/v1/tasks/abc/status", body: `{"status":"done"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "request does not match the API specification",
+		},
+		"undocumentedProperty": {
+			method: http.MethodGet, path: "/health",
+			rspBody: `{"status":"ok","version":"1"}`, rspStatus: http.StatusOK,
+			validateResponses: true,
+			wantStatus:        http.StatusInternalServerError,
+			wantMessage:       "response does not match the API specification",
+		},
+		"undocumentedStatus": {
+			method: http.MethodPost, path: "/v1/users", body: `{"name":"a","password":"b","role":"user"}`,
+			rspBody: `{"id":1}`, rspStatus: http.StatusOK,
+			validateResponses: true,
+			wantStatus:        http.StatusInternalServerError,
+			wantMessage:       "response does not match the API specification",
+		},
+		"responsesNotValidated": {
+			method: http.MethodGet, path: "/health",
+			rspBody: `{"status":"ok","version":"1"}`, rspStatus: http.StatusOK,
+			wantStatus: http.StatusOK,
+		},
+		"undocumentedRoute": {
+			method: http.MethodGet, path: "/unknown",
+			rspBody: `{"message":"not found"}`, rspStatus: http.StatusNotFound,
+			validateResponses: true,
+			wantStatus:        http.StatusNotFound,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := Middleware(doc, tt.validateResponses)
+			if err != nil {
+				t.Fatal(err)
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.rspBody == "" {
+					t.Errorf("handler should not be called")
+				}
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(tt.rspStatus)
+				_, _ = w.Write([]byte(tt.rspBody))
+			})
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			m(next).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantMessage == "" {
+				if w.Body.String() != tt.rspBody {
+					t.Errorf("body = %s, want %s", w.Body.String(), tt.rspBody)
+				}
+				return
+			}
+			var got errResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode %s: %v", w.Body.String(), err)
+			}
+			if got.Message != tt.wantMessage || len(got.Details) == 0 {
+				t.Errorf("response = %+v, want message %q with details", got, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Package quickadd は "Pay rent tomorrow 9am #home !high" のような1行の入力から
+// タイトル・期限・ラベル・優先度を取り出す。日付表現は英語と日本語に対応する。
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"golang.org/x/text/width"
+)
+
+var ErrEmptyTitle = errors.New("title is empty after parsing")
+
+// ErrLabelTooLong はMaxLabelLengthより長いラベルのエラー
+var ErrLabelTooLong = errors.New("label is too long")
+
+const (
+	// DefaultHour は日付だけが指定されたときの期限の時刻
+	DefaultHour = 9
+	// TonightHour は「今夜」「tonight」で時刻が指定されなかったときの時刻
+	TonightHour = 20
+	// MaxLabelLength はラベル名の文字数の上限。labels.nameのVARCHAR(64)に合わせる
+	MaxLabelLength = 64
+)
+
+type Result struct {
+	Title    string
+	DueAt    *time.Time
+	Labels   []string
+	Priority entity.TaskPriority
+}
+
+type Parser struct {
+	Clocker clock.Clocker
+}
+
+var (
+	labelRe    = regexp.MustCompile(`(?:^|\s)#([^\s#!]+)`)
+	priorityRe = regexp.MustCompile(`(?i)(?:^|\s)!(high|medium|med|low|高|中|低|[123])(?:\s|$)`)
+)
+
+// Parse はinputを解析する。相対的な日付はClockerの現在時刻をlocで解釈して計算する
+func (p *Parser) Parse(input string, loc *time.Location) (*Result, error) {
+	now := p.Clocker.Now().In(loc)
+	text := newFoldedText(input)
+	res := &Result{}
+
+	replaceAll(labelRe, text, func(m []string) {
+		if !contains(res.Labels, m[1]) {
+			res.Labels = append(res.Labels, m[1])
+		}
+	})
+	for _, l := range res.Labels {
+		if utf8.RuneCountInString(l) > MaxLabelLength {
+			return nil, fmt.Errorf("%w: #%s must be at most %d characters", ErrLabelTooLong, l, MaxLabelLength)
+		}
+	}
+	replaceAll(priorityRe, text, func(m []string) {
+		res.Priority = parsePriority(m[1])
+	})
+
+	var d *dateMatch
+	for _, r := range dateRules {
+		s := text.String()
+		idx := r.re.FindStringSubmatchIndex(s)
+		if idx == nil {
+			continue
+		}
+		if m, ok := r.fn(now, submatches(s, idx)); ok {
+			d = &m
+			text.blank(idx[0], idx[1])
+			break
+		}
+	}
+
+	var t *timeMatch
+	if d == nil || !d.exact {
+		for _, r := range timeRules {
+			s := text.String()
+			idx := r.re.FindStringSubmatchIndex(s)
+			if idx == nil {
+				continue
+			}
+			if m, ok := r.fn(submatches(s, idx)); ok {
+				t = &m
+				text.blank(idx[0], idx[1])
+				break
+			}
+		}
+	}
+
+	res.DueAt = resolveDue(now, d, t)
+
+	res.Title = text.title()
+	if res.Title == "" {
+		return nil, ErrEmptyTitle
+	}
+	return res, nil
+}
+
+// resolveDue は日付と時刻の表現を組み合わせて期限を決める
+func resolveDue(now time.Time, d *dateMatch, t *timeMatch) *time.Time {
+	var due time.Time
+	switch {
+	case d != nil && d.exact:
+		due = d.date
+	case d != nil && t != nil:
+		due = time.Date(d.date.Year(), d.date.Month(), d.date.Day(), t.hour, t.min, 0, 0, now.Location())
+	case d != nil:
+		hour := DefaultHour
+		if d.defaultHour != 0 {
+			hour = d.defaultHour
+		}
+		due = time.Date(d.date.Year(), d.date.Month(), d.date.Day(), hour, 0, 0, 0, now.Location())
+	case t != nil:
+		// 時刻だけなら次に来るその時刻
+		due = time.Date(now.Year(), now.Month(), now.Day(), t.hour, t.min, 0, 0, now.Location())
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+	default:
+		return nil
+	}
+	return &due
+}
+
+func parsePriority(s string) entity.TaskPriority {
+	switch strings.ToLower(s) {
+	case "high", "高", "1":
+		return entity.TaskPriorityHigh
+	case "medium", "med", "中", "2":
+		return entity.TaskPriorityMedium
+	default:
+		return entity.TaskPriorityLow
+	}
+}
+
+// replaceAll はreにマッチした部分を空白に置き換え、マッチごとにfnを呼ぶ
+func replaceAll(re *regexp.Regexp, t *foldedText, fn func(m []string)) {
+	s := t.String()
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	for _, idx := range matches {
+		fn(submatches(s, idx))
+	}
+	// 後ろから置き換えれば、前のマッチの位置はずれない
+	for i := len(matches) - 1; i >= 0; i-- {
+		t.blank(matches[i][0], matches[i][1])
+	}
+}
+
+// foldedText は入力の文字ごとに、全角英数字・記号を半角にそろえた文字と元の文字を持つ。
+// 日付や優先度、ラベルは半角にそろえた文字列で探し、タイトルには元の文字を使う
+type foldedText struct {
+	folded []rune
+	orig   []rune
+}
+
+func newFoldedText(s string) *foldedText {
+	t := &foldedText{}
+	for _, r := range s {
+		f := r
+		if folded := width.LookupRune(r).Folded(); folded != 0 {
+			f = folded
+		}
+		t.folded = append(t.folded, f)
+		t.orig = append(t.orig, r)
+	}
+	return t
+}
+
+// String は半角にそろえた文字列を返す
+func (t *foldedText) String() string {
+	return string(t.folded)
+}
+
+// blank はString()のバイト位置[i, j)の文字を空白ひとつに置き換える
+func (t *foldedText) blank(i, j int) {
+	s := t.String()
+	ri := utf8.RuneCountInString(s[:i])
+	rj := ri + utf8.RuneCountInString(s[i:j])
+	t.folded = append(append(t.folded[:ri:ri], ' '), t.folded[rj:]...)
+	t.orig = append(append(t.orig[:ri:ri], ' '), t.orig[rj:]...)
+}
+
+// title は続く空白をひとつにまとめ、元の文字でタイトルを作る。
+// 単独の全角の空白は、取り除いた表現の跡ではないのでそのまま残す
+func (t *foldedText) title() string {
+	var b strings.Builder
+	for i := 0; i < len(t.folded); {
+		if !isSpace(t.folded[i]) {
+			b.WriteRune(t.orig[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(t.folded) && isSpace(t.folded[j]) {
+			j++
+		}
+		if j-i == 1 && t.orig[i] != t.folded[i] {
+			b.WriteRune(t.orig[i])
+		} else {
+			b.WriteByte(' ')
+		}
+		i = j
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// isSpace は正規表現の\sと同じ空白かを返す
+func isSpace(r rune) bool {
+	return strings.ContainsRune("\t\n\f\r ", r)
+}
+
+func submatches(s string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return m
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
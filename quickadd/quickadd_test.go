@@ -0,0 +1,193 @@
+package quickadd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// fixedNow は任意の時刻を返すClocker
+type fixedNow time.Time
+
+func (f fixedNow) Now() time.Time { return time.Time(f) }
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 東京では2024-04-11(木) 05:00、UTCでは2024-04-10(水) 20:00
+	sut := &Parser{Clocker: fixedNow(time.Date(2024, 4, 10, 20, 0, 0, 0, time.UTC))}
+	jst := func(month time.Month, day, hour, min int) *time.Time {
+		t := time.Date(2024, month, day, hour, min, 0, 0, tokyo)
+		return &t
+	}
+
+	tests := map[string]struct {
+		input string
+		loc   *time.Location
+		want  *Result
+	}{
+		"full example": {
+			input: "Pay rent tomorrow 9am #home !high",
+			want:  &Result{Title: "Pay rent", DueAt: jst(4, 12, 9, 0), Labels: []string{"home"}, Priority: entity.TaskPriorityHigh},
+		},
+		"title only": {
+			input: "Call mom",
+			want:  &Result{Title: "Call mom"},
+		},
+		"today uses default hour": {
+			input: "Write report today",
+			want:  &Result{Title: "Write report", DueAt: jst(4, 11, 9, 0)},
+		},
+		"tonight": {
+			input: "Dinner tonight",
+			want:  &Result{Title: "Dinner", DueAt: jst(4, 11, 20, 0)},
+		},
+		"time only later today": {
+			input: "Meeting at 3pm",
+			want:  &Result{Title: "Meeting", DueAt: jst(4, 11, 15, 0)},
+		},
+		"time only already passed": {
+			input: "Standup 4:30",
+			want:  &Result{Title: "Standup", DueAt: jst(4, 12, 4, 30)},
+		},
+		"noon": {
+			input: "Lunch with Ann noon",
+			want:  &Result{Title: "Lunch with Ann", DueAt: jst(4, 11, 12, 0)},
+		},
+		"in hours is exact": {
+			input: "Check oven in 2 hours",
+			want:  &Result{Title: "Check oven", DueAt: jst(4, 11, 7, 0)},
+		},
+		"weekday": {
+			input: "Ship release by friday",
+			want:  &Result{Title: "Ship release", DueAt: jst(4, 12, 9, 0)},
+		},
+		"same weekday is today": {
+			input: "Gym thursday 7pm",
+			want:  &Result{Title: "Gym", DueAt: jst(4, 11, 19, 0)},
+		},
+		"next weekday skips today": {
+			input: "Gym next Thursday",
+			want:  &Result{Title: "Gym", DueAt: jst(4, 18, 9, 0)},
+		},
+		"iso date": {
+			input: "File taxes 2024-04-15 5pm",
+			want:  &Result{Title: "File taxes", DueAt: jst(4, 15, 17, 0)},
+		},
+		"month/day in the past rolls over to next year": {
+			input: "New year party 1/5",
+			want: &Result{Title: "New year party", DueAt: func() *time.Time {
+				t := time.Date(2025, 1, 5, 9, 0, 0, 0, tokyo)
+				return &t
+			}()},
+		},
+		"invalid date is kept in title": {
+			input: "Fix 2/30 bug",
+			want:  &Result{Title: "Fix 2/30 bug"},
+		},
+		"next week with low priority": {
+			input: "Retro next week !low",
+			want:  &Result{Title: "Retro", DueAt: jst(4, 15, 9, 0), Priority: entity.TaskPriorityLow},
+		},
+		"multiple labels": {
+			input: "Buy milk #shopping #home #shopping",
+			want:  &Result{Title: "Buy milk", Labels: []string{"shopping", "home"}},
+		},
+		"japanese tomorrow": {
+			input: "明日9時に家賃を払う #家 !高",
+			want:  &Result{Title: "家賃を払う", DueAt: jst(4, 12, 9, 0), Labels: []string{"家"}, Priority: entity.TaskPriorityHigh},
+		},
+		"japanese afternoon half hour": {
+			input: "午後3時半に会議",
+			want:  &Result{Title: "会議", DueAt: jst(4, 11, 15, 30)},
+		},
+		"japanese days later": {
+			input: "3日後までにレポート提出",
+			want:  &Result{Title: "レポート提出", DueAt: jst(4, 14, 9, 0)},
+		},
+		"japanese next week weekday": {
+			input: "来週の金曜日に飲み会",
+			want:  &Result{Title: "飲み会", DueAt: jst(4, 19, 9, 0)},
+		},
+		"japanese weekday": {
+			input: "金曜までに資料作成",
+			want:  &Result{Title: "資料作成", DueAt: jst(4, 12, 9, 0)},
+		},
+		"japanese month day": {
+			input: "5月1日 連休の準備",
+			want:  &Result{Title: "連休の準備", DueAt: jst(5, 1, 9, 0)},
+		},
+		"full-width characters": {
+			input: "資料作成　＃仕事　！２",
+			want:  &Result{Title: "資料作成", Labels: []string{"仕事"}, Priority: entity.TaskPriorityMedium},
+		},
+		"full-width title is kept as typed": {
+			input: "ＡＢＣ社に見積もり送付　明日　＃仕事",
+			want:  &Result{Title: "ＡＢＣ社に見積もり送付", DueAt: jst(4, 12, 9, 0), Labels: []string{"仕事"}},
+		},
+		"full-width space inside the title": {
+			input: "ｉＰｈｏｎｅ　修理 !high",
+			want:  &Result{Title: "ｉＰｈｏｎｅ　修理", Priority: entity.TaskPriorityHigh},
+		},
+		"relative dates use the given location": {
+			input: "Write report tomorrow",
+			loc:   time.UTC,
+			want: &Result{Title: "Write report", DueAt: func() *time.Time {
+				t := time.Date(2024, 4, 11, 9, 0, 0, 0, time.UTC)
+				return &t
+			}()},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loc := tt.loc
+			if loc == nil {
+				loc = tokyo
+			}
+			got, err := sut.Parse(tt.input, loc)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_EmptyTitle(t *testing.T) {
+	t.Parallel()
+
+	sut := &Parser{Clocker: fixedNow(time.Date(2024, 4, 10, 20, 0, 0, 0, time.UTC))}
+	if _, err := sut.Parse("tomorrow #home !high", time.UTC); !errors.Is(err, ErrEmptyTitle) {
+		t.Errorf("Parse() error = %v, want %v", err, ErrEmptyTitle)
+	}
+}
+
+func TestParser_Parse_LabelTooLong(t *testing.T) {
+	t.Parallel()
+
+	sut := &Parser{Clocker: fixedNow(time.Date(2024, 4, 10, 20, 0, 0, 0, time.UTC))}
+	label := strings.Repeat("あ", MaxLabelLength)
+	got, err := sut.Parse("Pay rent #"+label, time.UTC)
+	if err != nil {
+		t.Fatalf("Parse() with a label of %d characters: unexpected error %v", MaxLabelLength, err)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != label {
+		t.Errorf("Parse() labels = %v, want [%s]", got.Labels, label)
+	}
+	if _, err := sut.Parse("Pay rent #"+label+"い", time.UTC); !errors.Is(err, ErrLabelTooLong) {
+		t.Errorf("Parse() error = %v, want %v", err, ErrLabelTooLong)
+	}
+}
@@ -0,0 +1,238 @@
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateMatch は日付表現の解析結果。exactがtrueなら時刻まで確定している("in 2 hours"など)
+type dateMatch struct {
+	date        time.Time
+	exact       bool
+	defaultHour int
+}
+
+type timeMatch struct {
+	hour, min int
+}
+
+type dateRule struct {
+	re *regexp.Regexp
+	fn func(now time.Time, m []string) (dateMatch, bool)
+}
+
+type timeRule struct {
+	re *regexp.Regexp
+	fn func(m []string) (timeMatch, bool)
+}
+
+const (
+	// 英語の日付の前に付く前置詞
+	enDatePrefix = `(?i)(?:^|\s)(?:(?:on|by|due)\s+)?`
+	enSuffix     = `(?:\s|$)`
+	// 日本語の日付・時刻の後に付く助詞
+	jaSuffix = `(?:までに|まで|の|に)?`
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+	"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+	"saturday": time.Saturday,
+	"日":        time.Sunday, "月": time.Monday, "火": time.Tuesday, "水": time.Wednesday,
+	"木": time.Thursday, "金": time.Friday, "土": time.Saturday,
+}
+
+// 長い表現から順に評価する
+var dateRules = []dateRule{
+	{
+		re: regexp.MustCompile(`(?i)(?:^|\s)in\s+(\d+)\s*(minutes?|mins?|hours?|hrs?|days?|weeks?)` + enSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return relative(now, m[1], strings.ToLower(m[2]))
+		},
+	},
+	{
+		re: regexp.MustCompile(`(\d+)\s*(分|時間|日|週間)後` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return relative(now, m[1], m[2])
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `(\d{4})-(\d{1,2})-(\d{1,2})` + enSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return absolute(now, m[1], m[2], m[3])
+		},
+	},
+	{
+		re: regexp.MustCompile(`(?:(\d{4})年)?(\d{1,2})月(\d{1,2})日` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return absolute(now, m[1], m[2], m[3])
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `(\d{1,2})/(\d{1,2})` + enSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return absolute(now, "", m[1], m[2])
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `(?:the\s+)?day\s+after\s+tomorrow` + enSuffix + `|(?:明後日|あさって)` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: now.AddDate(0, 0, 2)}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `(?:tomorrow|tmrw)` + enSuffix + `|(?:明日|あした)` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: now.AddDate(0, 0, 1)}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `tonight` + enSuffix + `|(?:今夜|今晩)` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: now, defaultHour: TonightHour}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `today` + enSuffix + `|今日` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: now}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(`(来週|今週)?の?(月|火|水|木|金|土|日)曜日?` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			wd := weekdays[m[2]]
+			switch m[1] {
+			case "来週":
+				return dateMatch{date: weekStart(now).AddDate(0, 0, 7+mondayOffset(wd))}, true
+			case "今週":
+				return dateMatch{date: weekStart(now).AddDate(0, 0, mondayOffset(wd))}, true
+			}
+			return dateMatch{date: upcoming(now, wd, false)}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `(next\s+)?(sunday|monday|tuesday|wednesday|thursday|friday|saturday)` + enSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: upcoming(now, weekdays[strings.ToLower(m[2])], m[1] != "")}, true
+		},
+	},
+	{
+		re: regexp.MustCompile(enDatePrefix + `next\s+week` + enSuffix + `|来週` + jaSuffix),
+		fn: func(now time.Time, m []string) (dateMatch, bool) {
+			return dateMatch{date: weekStart(now).AddDate(0, 0, 7)}, true
+		},
+	},
+}
+
+var timeRules = []timeRule{
+	{
+		re: regexp.MustCompile(`(?i)(?:^|\s)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)` + enSuffix),
+		fn: func(m []string) (timeMatch, bool) {
+			h, _ := strconv.Atoi(m[1])
+			min, _ := strconv.Atoi(m[2])
+			if h < 1 || h > 12 {
+				return timeMatch{}, false
+			}
+			h %= 12
+			if strings.EqualFold(m[3], "pm") {
+				h += 12
+			}
+			return clockTime(h, min)
+		},
+	},
+	{
+		re: regexp.MustCompile(`(?i)(?:^|\s)(?:at\s+)?(\d{1,2}):(\d{2})` + enSuffix),
+		fn: func(m []string) (timeMatch, bool) {
+			h, _ := strconv.Atoi(m[1])
+			min, _ := strconv.Atoi(m[2])
+			return clockTime(h, min)
+		},
+	},
+	{
+		re: regexp.MustCompile(`(?i)(?:^|\s)(?:at\s+)?noon` + enSuffix + `|正午` + jaSuffix),
+		fn: func(m []string) (timeMatch, bool) {
+			return clockTime(12, 0)
+		},
+	},
+	{
+		re: regexp.MustCompile(`(午前|午後)?(\d{1,2})時(?:(半)|(\d{1,2})分)?` + jaSuffix),
+		fn: func(m []string) (timeMatch, bool) {
+			h, _ := strconv.Atoi(m[2])
+			min, _ := strconv.Atoi(m[4])
+			if m[3] != "" {
+				min = 30
+			}
+			if m[1] == "午後" && h < 12 {
+				h += 12
+			}
+			return clockTime(h, min)
+		},
+	},
+}
+
+// relative は「n単位後」を計算する。分・時間は時刻まで確定する
+func relative(now time.Time, n, unit string) (dateMatch, bool) {
+	v, err := strconv.Atoi(n)
+	if err != nil {
+		return dateMatch{}, false
+	}
+	switch {
+	case strings.HasPrefix(unit, "min"), unit == "分":
+		return dateMatch{date: now.Add(time.Duration(v) * time.Minute), exact: true}, true
+	case strings.HasPrefix(unit, "h"), unit == "時間":
+		return dateMatch{date: now.Add(time.Duration(v) * time.Hour), exact: true}, true
+	case strings.HasPrefix(unit, "day"), unit == "日":
+		return dateMatch{date: now.AddDate(0, 0, v)}, true
+	case strings.HasPrefix(unit, "week"), unit == "週間":
+		return dateMatch{date: now.AddDate(0, 0, 7*v)}, true
+	}
+	return dateMatch{}, false
+}
+
+// absolute は年月日から日付を作る。年がなければ今日以降で最も近い日付にする
+func absolute(now time.Time, year, month, day string) (dateMatch, bool) {
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	y := now.Year()
+	if year != "" {
+		y, _ = strconv.Atoi(year)
+	}
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, now.Location())
+	// 2月30日のような存在しない日付は正規化されて月日が変わる
+	if date.Month() != time.Month(m) || date.Day() != d {
+		return dateMatch{}, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if year == "" && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return dateMatch{date: date}, true
+}
+
+// upcoming は次のwd曜日を返す。今日がwd曜日ならstrictでない限り今日を返す
+func upcoming(now time.Time, wd time.Weekday, strict bool) time.Time {
+	days := (int(wd) - int(now.Weekday()) + 7) % 7
+	if days == 0 && strict {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
+
+// weekStart は月曜始まりで今週の月曜日を返す
+func weekStart(now time.Time) time.Time {
+	return now.AddDate(0, 0, -mondayOffset(now.Weekday()))
+}
+
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+func clockTime(h, min int) (timeMatch, bool) {
+	if h < 0 || h > 23 || min < 0 || min > 59 {
+		return timeMatch{}, false
+	}
+	return timeMatch{hour: h, min: min}, true
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+)
+
+// apiRoutes はあるバージョンのAPIのハンドラ。ルートごとに1つのフィールドを持つ。
+// 新しいバージョンはひとつ前のバージョンをコピーし、変わるハンドラだけを差し替えて作る。
+//
+//	v2 := v1
+//	v2.listTasks = &handler.ListTaskV2{...}
+//	mux.Route("/v2", v2.mount)
+type apiRoutes struct {
+	// authn は認証、workspace はワークスペースを決める、adminOnly は管理者だけを通すミドルウェア
+	authn     func(http.Handler) http.Handler
+	workspace func(http.Handler) http.Handler
+	adminOnly func(http.Handler) http.Handler
+
+	registerUser http.Handler
+	login        http.Handler
+	refreshToken http.Handler
+	// loginMFA と loginMFAEnroll はログインの2段階目。enrollTOTP と confirmTOTP はログインしているユーザーの2要素認証の登録
+	loginMFA       http.Handler
+	loginMFAEnroll http.Handler
+	enrollTOTP     http.Handler
+	confirmTOTP    http.Handler
+	// forgotPassword と resetPassword はメールで送るトークンでのパスワードの再設定
+	forgotPassword http.Handler
+	resetPassword  http.Handler
+	logout         http.Handler
+	logoutAll      http.Handler
+	oidcLogin      http.Handler
+	oidcCallback   http.Handler
+
+	addWorkspace       http.Handler
+	listWorkspaces     http.Handler
+	addWorkspaceMember http.Handler
+
+	addProject   http.Handler
+	listProjects http.Handler
+
+	listNotifications             http.Handler
+	readAllNotifications          http.Handler
+	readNotification              http.Handler
+	getNotificationPreferences    http.Handler
+	updateNotificationPreferences http.Handler
+
+	getReminderOffsets    http.Handler
+	updateReminderOffsets http.Handler
+
+	addTask          http.Handler
+	listTasks        http.Handler
+	workload         http.Handler
+	updateTaskStatus http.Handler
+	moveTask         http.Handler
+	assignTask       http.Handler
+	setTaskProject   http.Handler
+	startTimer       http.Handler
+	stopTimer        http.Handler
+
+	getBoard  http.Handler
+	addColumn http.Handler
+
+	updateTimeEntry http.Handler
+	timeReport      http.Handler
+
+	addTemplate         http.Handler
+	listTemplates       http.Handler
+	instantiateTemplate http.Handler
+
+	addSavedSearch    http.Handler
+	listSavedSearches http.Handler
+	savedSearchTasks  http.Handler
+	deleteSavedSearch http.Handler
+
+	graphQL http.Handler
+	stats   http.Handler
+	admin   http.Handler
+
+	revokeSessions  http.Handler
+	getMFAPolicy    http.Handler
+	updateMFAPolicy http.Handler
+}
+
+// mount はハンドラをrに登録する
+func (a apiRoutes) mount(r chi.Router) {
+	r.Post("/users", a.registerUser.ServeHTTP)
+	r.Post("/login", a.login.ServeHTTP)
+	r.Post("/login/mfa", a.loginMFA.ServeHTTP)
+	r.Post("/login/mfa/enroll", a.loginMFAEnroll.ServeHTTP)
+	r.Post("/token/refresh", a.refreshToken.ServeHTTP)
+	r.Post("/password/forgot", a.forgotPassword.ServeHTTP)
+	r.Post("/password/reset", a.resetPassword.ServeHTTP)
+	r.Route("/auth/oidc", func(r chi.Router) {
+		r.Get("/login", a.oidcLogin.ServeHTTP)
+		r.Get("/callback", a.oidcCallback.ServeHTTP)
+	})
+	r.Route("/logout", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Post("/", a.logout.ServeHTTP)
+		r.Post("/all", a.logoutAll.ServeHTTP)
+	})
+
+	r.Route("/mfa", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Post("/totp", a.enrollTOTP.ServeHTTP)
+		r.Post("/totp/confirm", a.confirmTOTP.ServeHTTP)
+	})
+
+	r.Route("/workspaces", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Post("/", a.addWorkspace.ServeHTTP)
+		r.Get("/", a.listWorkspaces.ServeHTTP)
+		r.Post("/{id}/members", a.addWorkspaceMember.ServeHTTP)
+	})
+
+	r.Route("/projects", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Post("/", a.addProject.ServeHTTP)
+		r.Get("/", a.listProjects.ServeHTTP)
+	})
+
+	r.Route("/notifications", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Get("/", a.listNotifications.ServeHTTP)
+		r.Put("/read", a.readAllNotifications.ServeHTTP)
+		r.Put("/{id}/read", a.readNotification.ServeHTTP)
+		r.Get("/preferences", a.getNotificationPreferences.ServeHTTP)
+		r.Put("/preferences", a.updateNotificationPreferences.ServeHTTP)
+	})
+
+	r.Route("/reminders", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Get("/offsets", a.getReminderOffsets.ServeHTTP)
+		r.Put("/offsets", a.updateReminderOffsets.ServeHTTP)
+	})
+
+	r.Route("/tasks", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Post("/", a.addTask.ServeHTTP)
+		r.Get("/", a.listTasks.ServeHTTP)
+		r.Get("/workload", a.workload.ServeHTTP)
+		r.Put("/{id}/status", a.updateTaskStatus.ServeHTTP)
+		r.Put("/{id}/column", a.moveTask.ServeHTTP)
+		r.Put("/{id}/assignee", a.assignTask.ServeHTTP)
+		r.Put("/{id}/project", a.setTaskProject.ServeHTTP)
+		r.Post("/{id}/timer/start", a.startTimer.ServeHTTP)
+		r.Post("/{id}/timer/stop", a.stopTimer.ServeHTTP)
+	})
+
+	r.Route("/board", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Get("/", a.getBoard.ServeHTTP)
+		r.Post("/columns", a.addColumn.ServeHTTP)
+	})
+
+	r.Route("/time-entries", func(r chi.Router) {
+		r.Use(a.authn)
+		r.Put("/{id}", a.updateTimeEntry.ServeHTTP)
+	})
+	r.Route("/reports", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Get("/time", a.timeReport.ServeHTTP)
+	})
+
+	r.Route("/templates", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Post("/", a.addTemplate.ServeHTTP)
+		r.Get("/", a.listTemplates.ServeHTTP)
+		r.Post("/{id}/instantiate", a.instantiateTemplate.ServeHTTP)
+	})
+
+	r.Route("/saved-searches", func(r chi.Router) {
+		r.Use(a.authn, a.workspace)
+		r.Post("/", a.addSavedSearch.ServeHTTP)
+		r.Get("/", a.listSavedSearches.ServeHTTP)
+		r.Get("/{id}/tasks", a.savedSearchTasks.ServeHTTP)
+		r.Delete("/{id}", a.deleteSavedSearch.ServeHTTP)
+	})
+
+	r.With(a.authn, a.workspace).Post("/graphql", a.graphQL.ServeHTTP)
+	r.With(a.authn, a.workspace).Get("/stats", a.stats.ServeHTTP)
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(a.authn, a.adminOnly)
+		r.Get("/", a.admin.ServeHTTP)
+		r.Delete("/users/{id}/sessions", a.revokeSessions.ServeHTTP)
+		r.Get("/mfa-policy", a.getMFAPolicy.ServeHTTP)
+		r.Put("/mfa-policy", a.updateMFAPolicy.ServeHTTP)
+	})
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/zakisanbaiman/go-handson01/config"
+)
+
+// stub はnameをボディに書くハンドラ
+func stub(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, name)
+	})
+}
+
+// stubRoutes はすべてのルートをフィールド名を返すハンドラにしたapiRoutes
+func stubRoutes() apiRoutes {
+	pass := func(next http.Handler) http.Handler { return next }
+	return apiRoutes{
+		authn:     pass,
+		workspace: pass,
+		adminOnly: pass,
+
+		registerUser:   stub("registerUser"),
+		login:          stub("login"),
+		refreshToken:   stub("refreshToken"),
+		loginMFA:       stub("loginMFA"),
+		loginMFAEnroll: stub("loginMFAEnroll"),
+		enrollTOTP:     stub("enrollTOTP"),
+		confirmTOTP:    stub("confirmTOTP"),
+		logout:         stub("logout"),
+		logoutAll:      stub("logoutAll"),
+		forgotPassword: stub("forgotPassword"),
+		resetPassword:  stub("resetPassword"),
+		oidcLogin:      stub("oidcLogin"),
+		oidcCallback:   stub("oidcCallback"),
+
+		addWorkspace:       stub("addWorkspace"),
+		listWorkspaces:     stub("listWorkspaces"),
+		addWorkspaceMember: stub("addWorkspaceMember"),
+
+		addProject:   stub("addProject"),
+		listProjects: stub("listProjects"),
+
+		listNotifications:             stub("listNotifications"),
+		readAllNotifications:          stub("readAllNotifications"),
+		readNotification:              stub("readNotification"),
+		getNotificationPreferences:    stub("getNotificationPreferences"),
+		updateNotificationPreferences: stub("updateNotificationPreferences"),
+
+		getReminderOffsets:    stub("getReminderOffsets"),
+		updateReminderOffsets: stub("updateReminderOffsets"),
+
+		addTask:          stub("addTask"),
+		listTasks:        stub("listTasks"),
+		workload:         stub("workload"),
+		updateTaskStatus: stub("updateTaskStatus"),
+		moveTask:         stub("moveTask"),
+		assignTask:       stub("assignTask"),
+		setTaskProject:   stub("setTaskProject"),
+		startTimer:       stub("startTimer"),
+		stopTimer:        stub("stopTimer"),
+
+		getBoard:  stub("getBoard"),
+		addColumn: stub("addColumn"),
+
+		updateTimeEntry: stub("updateTimeEntry"),
+		timeReport:      stub("timeReport"),
+
+		addTemplate:         stub("addTemplate"),
+		listTemplates:       stub("listTemplates"),
+		instantiateTemplate: stub("instantiateTemplate"),
+
+		addSavedSearch:    stub("addSavedSearch"),
+		listSavedSearches: stub("listSavedSearches"),
+		savedSearchTasks:  stub("savedSearchTasks"),
+		deleteSavedSearch: stub("deleteSavedSearch"),
+
+		graphQL: stub("graphQL"),
+		stats:   stub("stats"),
+		admin:   stub("admin"),
+
+		revokeSessions:  stub("revokeSessions"),
+		getMFAPolicy:    stub("getMFAPolicy"),
+		updateMFAPolicy: stub("updateMFAPolicy"),
+	}
+}
+
+func TestMountVersions(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		LegacyRoutesDeprecatedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		LegacyRoutesSunset:       time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	v1 := stubRoutes()
+	v2 := v1
+	v2.listTasks = stub("listTasksV2")
+
+	mux := chi.NewRouter()
+	mountVersions(mux, cfg, v1)
+	mux.Route("/v2", v2.mount)
+
+	type want struct {
+		status     int
+		body       string
+		deprecated bool
+		link       string
+	}
+	tests := map[string]struct {
+		method string
+		path   string
+		want   want
+	}{
+		"v1": {
+			method: http.MethodGet,
+			path:   "/v1/tasks",
+			want:   want{status: http.StatusOK, body: "listTasks"},
+		},
+		"v1Param": {
+			method: http.MethodPut,
+			path:   "/v1/tasks/1/status",
+			want:   want{status: http.StatusOK, body: "updateTaskStatus"},
+		},
+		"legacy": {
+			method: http.MethodGet,
+			path:   "/tasks",
+			want: want{
+				status:     http.StatusOK,
+				body:       "listTasks",
+				deprecated: true,
+				link:       `</v1/tasks>; rel="successor-version"`,
+			},
+		},
+		"legacyParam": {
+			method: http.MethodPut,
+			path:   "/tasks/1/status",
+			want: want{
+				status:     http.StatusOK,
+				body:       "updateTaskStatus",
+				deprecated: true,
+				link:       `</v1/tasks/1/status>; rel="successor-version"`,
+			},
+		},
+		"v2Replaced": {
+			method: http.MethodGet,
+			path:   "/v2/tasks",
+			want:   want{status: http.StatusOK, body: "listTasksV2"},
+		},
+		"v2Inherited": {
+			method: http.MethodPost,
+			path:   "/v2/tasks",
+			want:   want{status: http.StatusOK, body: "addTask"},
+		},
+		"unknownVersion": {
+			method: http.MethodGet,
+			path:   "/v3/tasks",
+			want:   want{status: http.StatusNotFound, body: "404 page not found\n"},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			mux.ServeHTTP(w, r)
+			resp := w.Result()
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			if resp.StatusCode != tt.want.status {
+				t.Errorf("want status %d, but got %d", tt.want.status, resp.StatusCode)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.want.body {
+				t.Errorf("want body %q, but got %q", tt.want.body, body)
+			}
+			wantDeprecation, wantSunset := "", ""
+			if tt.want.deprecated {
+				wantDeprecation, wantSunset = "@1792368000", "Fri, 30 Apr 2027 00:00:00 GMT"
+			}
+			if got := resp.Header.Get("Deprecation"); got != wantDeprecation {
+				t.Errorf("want Deprecation %q, but got %q", wantDeprecation, got)
+			}
+			if got := resp.Header.Get("Sunset"); got != wantSunset {
+				t.Errorf("want Sunset %q, but got %q", wantSunset, got)
+			}
+			if got := resp.Header.Get("Link"); got != tt.want.link {
+				t.Errorf("want Link %q, but got %q", tt.want.link, got)
+			}
+		})
+	}
+}
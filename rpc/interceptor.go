@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/rpc/todov1"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// WorkspaceMetadata はワークスペースを指定するメタデータ。HTTPのX-Workspace-IDヘッダにあたる
+const WorkspaceMetadata = "x-workspace-id"
+
+// publicMethods は認証なしで呼べるメソッド
+var publicMethods = map[string]bool{
+	todov1.TodoService_Login_FullMethodName:    true,
+	todov1.TodoService_LoginMFA_FullMethodName: true,
+}
+
+// UnaryAuthInterceptor はHTTPのAuthMiddlewareとWorkspaceMiddlewareを合わせたもの
+func UnaryAuthInterceptor(j *auth.JWTer, ws ResolveWorkspaceService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, j, ws)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor はストリームのメソッドでUnaryAuthInterceptorと同じ認証をする
+func StreamAuthInterceptor(j *auth.JWTer, ws ResolveWorkspaceService) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), j, ws)
+		if err != nil {
+			return err
+		}
+		// ストリームは開いている間にトークンが失効しうるので、ハンドラーから認証をやり直せるようにする
+		ctx = context.WithValue(ctx, reauthenticateKey{}, func() error {
+			_, err := authenticate(ss.Context(), j, ws)
+			return err
+		})
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type reauthenticateKey struct{}
+
+// reauthenticate はストリームを開いたときと同じ認証をやり直し、失効やログアウトしたトークンならエラーを返す。
+// StreamAuthInterceptorを通っていなければ何もしない
+func reauthenticate(ctx context.Context) error {
+	f, ok := ctx.Value(reauthenticateKey{}).(func() error)
+	if !ok {
+		return nil
+	}
+	return f()
+}
+
+// serverStream はコンテキストを差し替えたストリーム
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, j *auth.JWTer, ws ResolveWorkspaceService) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	raw, ok := bearer(md.Get("authorization"))
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	token, err := j.ParseToken(ctx, raw)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	ctx, err = j.WithToken(ctx, token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	var id int64
+	if v := md.Get(WorkspaceMetadata); len(v) > 0 {
+		id, err = strconv.ParseInt(v[0], 10, 64)
+		if err != nil || id <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid workspace id")
+		}
+	}
+	m, err := ws.ResolveWorkspace(ctx, entity.WorkspaceID(id))
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			return nil, status.Errorf(codes.PermissionDenied, "failed to resolve workspace: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to resolve workspace: %v", err)
+	}
+	ctx = auth.SetWorkspaceID(ctx, m.WorkspaceID)
+	ctx = auth.SetWorkspaceRole(ctx, m.Role)
+	return ctx, nil
+}
+
+// bearer は "Bearer <token>" からトークンを取り出す
+func bearer(values []string) (string, bool) {
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,417 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package rpc
+
+import (
+	"context"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"sync"
+)
+
+// Ensure, that LoginServiceMock does implement LoginService.
+// If this is not the case, regenerate this file with moq.
+var _ LoginService = &LoginServiceMock{}
+
+// LoginServiceMock is a mock implementation of LoginService.
+//
+//	func TestSomethingThatUsesLoginService(t *testing.T) {
+//
+//		// make and configure a mocked LoginService
+//		mockedLoginService := &LoginServiceMock{
+//			LoginFunc: func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
+//				panic("mock out the Login method")
+//			},
+//			LoginMFAFunc: func(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error) {
+//				panic("mock out the LoginMFA method")
+//			},
+//		}
+//
+//		// use mockedLoginService in code that requires LoginService
+//		// and then make assertions.
+//
+//	}
+type LoginServiceMock struct {
+	// LoginFunc mocks the Login method.
+	LoginFunc func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error)
+
+	// LoginMFAFunc mocks the LoginMFA method.
+	LoginMFAFunc func(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Password is the password argument value.
+			Password string
+			// ClientIP is the clientIP argument value.
+			ClientIP string
+		}
+		// LoginMFA holds details about calls to the LoginMFA method.
+		LoginMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MfaToken is the mfaToken argument value.
+			MfaToken string
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
+		}
+	}
+	lockLogin    sync.RWMutex
+	lockLoginMFA sync.RWMutex
+}
+
+// Login calls LoginFunc.
+func (mock *LoginServiceMock) Login(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
+	if mock.LoginFunc == nil {
+		panic("LoginServiceMock.LoginFunc: method is nil but LoginService.Login was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Name     string
+		Password string
+		ClientIP string
+	}{
+		Ctx:      ctx,
+		Name:     name,
+		Password: password,
+		ClientIP: clientIP,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, name, password, clientIP)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedLoginService.LoginCalls())
+func (mock *LoginServiceMock) LoginCalls() []struct {
+	Ctx      context.Context
+	Name     string
+	Password string
+	ClientIP string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Name     string
+		Password string
+		ClientIP string
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// LoginMFA calls LoginMFAFunc.
+func (mock *LoginServiceMock) LoginMFA(ctx context.Context, mfaToken string, code string, recoveryCode string) (*entity.LoginResult, error) {
+	if mock.LoginMFAFunc == nil {
+		panic("LoginServiceMock.LoginMFAFunc: method is nil but LoginService.LoginMFA was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		MfaToken     string
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		MfaToken:     mfaToken,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockLoginMFA.Lock()
+	mock.calls.LoginMFA = append(mock.calls.LoginMFA, callInfo)
+	mock.lockLoginMFA.Unlock()
+	return mock.LoginMFAFunc(ctx, mfaToken, code, recoveryCode)
+}
+
+// LoginMFACalls gets all the calls that were made to LoginMFA.
+// Check the length with:
+//
+//	len(mockedLoginService.LoginMFACalls())
+func (mock *LoginServiceMock) LoginMFACalls() []struct {
+	Ctx          context.Context
+	MfaToken     string
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		MfaToken     string
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockLoginMFA.RLock()
+	calls = mock.calls.LoginMFA
+	mock.lockLoginMFA.RUnlock()
+	return calls
+}
+
+// Ensure, that AddTaskServiceMock does implement AddTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ AddTaskService = &AddTaskServiceMock{}
+
+// AddTaskServiceMock is a mock implementation of AddTaskService.
+//
+//	func TestSomethingThatUsesAddTaskService(t *testing.T) {
+//
+//		// make and configure a mocked AddTaskService
+//		mockedAddTaskService := &AddTaskServiceMock{
+//			AddTaskFunc: func(ctx context.Context, title string) (*entity.Task, error) {
+//				panic("mock out the AddTask method")
+//			},
+//		}
+//
+//		// use mockedAddTaskService in code that requires AddTaskService
+//		// and then make assertions.
+//
+//	}
+type AddTaskServiceMock struct {
+	// AddTaskFunc mocks the AddTask method.
+	AddTaskFunc func(ctx context.Context, title string) (*entity.Task, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTask holds details about calls to the AddTask method.
+		AddTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Title is the title argument value.
+			Title string
+		}
+	}
+	lockAddTask sync.RWMutex
+}
+
+// AddTask calls AddTaskFunc.
+func (mock *AddTaskServiceMock) AddTask(ctx context.Context, title string) (*entity.Task, error) {
+	if mock.AddTaskFunc == nil {
+		panic("AddTaskServiceMock.AddTaskFunc: method is nil but AddTaskService.AddTask was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Title string
+	}{
+		Ctx:   ctx,
+		Title: title,
+	}
+	mock.lockAddTask.Lock()
+	mock.calls.AddTask = append(mock.calls.AddTask, callInfo)
+	mock.lockAddTask.Unlock()
+	return mock.AddTaskFunc(ctx, title)
+}
+
+// AddTaskCalls gets all the calls that were made to AddTask.
+// Check the length with:
+//
+//	len(mockedAddTaskService.AddTaskCalls())
+func (mock *AddTaskServiceMock) AddTaskCalls() []struct {
+	Ctx   context.Context
+	Title string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Title string
+	}
+	mock.lockAddTask.RLock()
+	calls = mock.calls.AddTask
+	mock.lockAddTask.RUnlock()
+	return calls
+}
+
+// Ensure, that ListTaskServiceMock does implement ListTaskService.
+// If this is not the case, regenerate this file with moq.
+var _ ListTaskService = &ListTaskServiceMock{}
+
+// ListTaskServiceMock is a mock implementation of ListTaskService.
+//
+//	func TestSomethingThatUsesListTaskService(t *testing.T) {
+//
+//		// make and configure a mocked ListTaskService
+//		mockedListTaskService := &ListTaskServiceMock{
+//			ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+//				panic("mock out the ListTasks method")
+//			},
+//			SearchTasksFunc: func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+//				panic("mock out the SearchTasks method")
+//			},
+//		}
+//
+//		// use mockedListTaskService in code that requires ListTaskService
+//		// and then make assertions.
+//
+//	}
+type ListTaskServiceMock struct {
+	// ListTasksFunc mocks the ListTasks method.
+	ListTasksFunc func(ctx context.Context) (entity.Tasks, error)
+
+	// SearchTasksFunc mocks the SearchTasks method.
+	SearchTasksFunc func(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTasks holds details about calls to the ListTasks method.
+		ListTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SearchTasks holds details about calls to the SearchTasks method.
+		SearchTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Q is the q argument value.
+			Q taskquery.Node
+		}
+	}
+	lockListTasks   sync.RWMutex
+	lockSearchTasks sync.RWMutex
+}
+
+// ListTasks calls ListTasksFunc.
+func (mock *ListTaskServiceMock) ListTasks(ctx context.Context) (entity.Tasks, error) {
+	if mock.ListTasksFunc == nil {
+		panic("ListTaskServiceMock.ListTasksFunc: method is nil but ListTaskService.ListTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListTasks.Lock()
+	mock.calls.ListTasks = append(mock.calls.ListTasks, callInfo)
+	mock.lockListTasks.Unlock()
+	return mock.ListTasksFunc(ctx)
+}
+
+// ListTasksCalls gets all the calls that were made to ListTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.ListTasksCalls())
+func (mock *ListTaskServiceMock) ListTasksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListTasks.RLock()
+	calls = mock.calls.ListTasks
+	mock.lockListTasks.RUnlock()
+	return calls
+}
+
+// SearchTasks calls SearchTasksFunc.
+func (mock *ListTaskServiceMock) SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+	if mock.SearchTasksFunc == nil {
+		panic("ListTaskServiceMock.SearchTasksFunc: method is nil but ListTaskService.SearchTasks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}{
+		Ctx: ctx,
+		Q:   q,
+	}
+	mock.lockSearchTasks.Lock()
+	mock.calls.SearchTasks = append(mock.calls.SearchTasks, callInfo)
+	mock.lockSearchTasks.Unlock()
+	return mock.SearchTasksFunc(ctx, q)
+}
+
+// SearchTasksCalls gets all the calls that were made to SearchTasks.
+// Check the length with:
+//
+//	len(mockedListTaskService.SearchTasksCalls())
+func (mock *ListTaskServiceMock) SearchTasksCalls() []struct {
+	Ctx context.Context
+	Q   taskquery.Node
+} {
+	var calls []struct {
+		Ctx context.Context
+		Q   taskquery.Node
+	}
+	mock.lockSearchTasks.RLock()
+	calls = mock.calls.SearchTasks
+	mock.lockSearchTasks.RUnlock()
+	return calls
+}
+
+// Ensure, that ResolveWorkspaceServiceMock does implement ResolveWorkspaceService.
+// If this is not the case, regenerate this file with moq.
+var _ ResolveWorkspaceService = &ResolveWorkspaceServiceMock{}
+
+// ResolveWorkspaceServiceMock is a mock implementation of ResolveWorkspaceService.
+//
+//	func TestSomethingThatUsesResolveWorkspaceService(t *testing.T) {
+//
+//		// make and configure a mocked ResolveWorkspaceService
+//		mockedResolveWorkspaceService := &ResolveWorkspaceServiceMock{
+//			ResolveWorkspaceFunc: func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+//				panic("mock out the ResolveWorkspace method")
+//			},
+//		}
+//
+//		// use mockedResolveWorkspaceService in code that requires ResolveWorkspaceService
+//		// and then make assertions.
+//
+//	}
+type ResolveWorkspaceServiceMock struct {
+	// ResolveWorkspaceFunc mocks the ResolveWorkspace method.
+	ResolveWorkspaceFunc func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ResolveWorkspace holds details about calls to the ResolveWorkspace method.
+		ResolveWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID entity.WorkspaceID
+		}
+	}
+	lockResolveWorkspace sync.RWMutex
+}
+
+// ResolveWorkspace calls ResolveWorkspaceFunc.
+func (mock *ResolveWorkspaceServiceMock) ResolveWorkspace(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+	if mock.ResolveWorkspaceFunc == nil {
+		panic("ResolveWorkspaceServiceMock.ResolveWorkspaceFunc: method is nil but ResolveWorkspaceService.ResolveWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  entity.WorkspaceID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockResolveWorkspace.Lock()
+	mock.calls.ResolveWorkspace = append(mock.calls.ResolveWorkspace, callInfo)
+	mock.lockResolveWorkspace.Unlock()
+	return mock.ResolveWorkspaceFunc(ctx, id)
+}
+
+// ResolveWorkspaceCalls gets all the calls that were made to ResolveWorkspace.
+// Check the length with:
+//
+//	len(mockedResolveWorkspaceService.ResolveWorkspaceCalls())
+func (mock *ResolveWorkspaceServiceMock) ResolveWorkspaceCalls() []struct {
+	Ctx context.Context
+	ID  entity.WorkspaceID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  entity.WorkspaceID
+	}
+	mock.lockResolveWorkspace.RLock()
+	calls = mock.calls.ResolveWorkspace
+	mock.lockResolveWorkspace.RUnlock()
+	return calls
+}
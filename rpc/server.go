@@ -0,0 +1,303 @@
+// Package rpc はタスクと認証のgRPCサーバー。
+// メソッドはRESTのハンドラと同じサービスを呼び、認証はauth.JWTerで行う。
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/rpc/todov1"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewServer はTodoServerを登録し、認証のインターセプターを付けたgRPCサーバーを作る
+func NewServer(j *auth.JWTer, ws ResolveWorkspaceService, todo *TodoServer) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(j, ws)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(j, ws)),
+	)
+	todov1.RegisterTodoServiceServer(s, todo)
+	return s
+}
+
+type TodoServer struct {
+	todov1.UnimplementedTodoServiceServer
+
+	Auth       LoginService
+	TaskAdder  AddTaskService
+	TaskLister ListTaskService
+	// WatchInterval はWatchTasksがタスクの変更を確認する間隔。0以下ならdefaultWatchIntervalを使う
+	WatchInterval time.Duration
+
+	initOnce  sync.Once
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (s *TodoServer) Login(ctx context.Context, req *todov1.LoginRequest) (*todov1.LoginResponse, error) {
+	if req.GetUserName() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_name and password are required")
+	}
+	if len(req.GetUserName()) > maxUserNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "user_name must be at most %d characters", maxUserNameLength)
+	}
+	result, err := s.Auth.Login(ctx, req.GetUserName(), req.GetPassword(), peerIP(ctx))
+	if err != nil {
+		var rle *service.RateLimitError
+		if errors.As(err, &rle) {
+			// RESTのRetry-Afterの代わりに、待つ時間をRetryInfoで返す
+			st, derr := status.New(codes.ResourceExhausted, err.Error()).
+				WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(rle.RetryAfter)})
+			if derr != nil {
+				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return nil, st.Err()
+		}
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "invalid user name or password")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to login: %v", err)
+	}
+	return loginResponse(result), nil
+}
+
+// maxUserNameLength はRESTのログインと同じユーザー名の長さの上限
+const maxUserNameLength = 100
+
+// peerIP はgRPCのクライアントのIPアドレスを返す
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (s *TodoServer) LoginMFA(ctx context.Context, req *todov1.LoginMFARequest) (*todov1.LoginResponse, error) {
+	if req.GetMfaToken() == "" || (req.GetCode() == "") == (req.GetRecoveryCode() == "") {
+		return nil, status.Error(codes.InvalidArgument, "mfa_token and either code or recovery_code are required")
+	}
+	result, err := s.Auth.LoginMFA(ctx, req.GetMfaToken(), req.GetCode(), req.GetRecoveryCode())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFAToken) || errors.Is(err, service.ErrInvalidMFACode) ||
+			errors.Is(err, service.ErrMFANotEnrolled) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to login: %v", err)
+	}
+	return loginResponse(result), nil
+}
+
+func loginResponse(result *entity.LoginResult) *todov1.LoginResponse {
+	if c := result.Challenge; c != nil {
+		return &todov1.LoginResponse{MfaToken: c.MFAToken, MfaEnrollmentRequired: c.EnrollmentRequired}
+	}
+	return &todov1.LoginResponse{
+		AccessToken:   result.Tokens.AccessToken,
+		RefreshToken:  result.Tokens.RefreshToken,
+		RecoveryCodes: result.RecoveryCodes,
+	}
+}
+
+func (s *TodoServer) AddTask(ctx context.Context, req *todov1.AddTaskRequest) (*todov1.Task, error) {
+	title := strings.TrimSpace(req.GetTitle())
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	t, err := s.TaskAdder.AddTask(ctx, title)
+	if err != nil {
+		if errors.Is(err, service.ErrWIPLimitExceeded) {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to add task: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to add task: %v", err)
+	}
+	return toTask(t), nil
+}
+
+func (s *TodoServer) ListTasks(ctx context.Context, req *todov1.ListTasksRequest) (*todov1.ListTasksResponse, error) {
+	list, err := s.lister(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := list(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+	}
+	rsp := &todov1.ListTasksResponse{Tasks: make([]*todov1.Task, 0, len(tasks))}
+	for _, t := range tasks {
+		rsp.Tasks = append(rsp.Tasks, toTask(t))
+	}
+	return rsp, nil
+}
+
+// defaultWatchInterval はWatchIntervalを設定していないときに、WatchTasksがタスクの変更を確認する間隔
+const defaultWatchInterval = 5 * time.Second
+
+func (s *TodoServer) watchInterval() time.Duration {
+	if s.WatchInterval <= 0 {
+		return defaultWatchInterval
+	}
+	return s.WatchInterval
+}
+
+// WatchTasks はWatchIntervalごとにタスクを読み直し、前回との差分を送る。
+// 読み直す前に認証をやり直し、トークンが期限切れになったりセッションが破棄されたりしていればストリームを閉じる
+func (s *TodoServer) WatchTasks(req *todov1.WatchTasksRequest, stream grpc.ServerStreamingServer[todov1.TaskEvent]) error {
+	ctx := stream.Context()
+	list, err := s.lister(req.GetQuery())
+	if err != nil {
+		return err
+	}
+	tasks, err := list(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+	}
+	prev := make(map[entity.TaskID]*todov1.Task, len(tasks))
+	for _, t := range tasks {
+		task := toTask(t)
+		prev[t.ID] = task
+		if err := stream.Send(&todov1.TaskEvent{Type: todov1.TaskEvent_TYPE_SNAPSHOT, Task: task}); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(s.watchInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+		if err := reauthenticate(ctx); err != nil {
+			return err
+		}
+		tasks, err := list(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list tasks: %v", err)
+		}
+		var events []*todov1.TaskEvent
+		events, prev = diffTasks(prev, tasks)
+		for _, e := range events {
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close は実行中のWatchTasksを終わらせる。grpc.Server.GracefulStopの前に呼ぶ
+func (s *TodoServer) Close() {
+	s.done()
+	s.closeOnce.Do(func() { close(s.closed) })
+}
+
+func (s *TodoServer) done() <-chan struct{} {
+	s.initOnce.Do(func() { s.closed = make(chan struct{}) })
+	return s.closed
+}
+
+// lister は検索クエリがあればSearchTasks、なければListTasksを呼ぶ関数を返す
+func (s *TodoServer) lister(query string) (func(context.Context) (entity.Tasks, error), error) {
+	if strings.TrimSpace(query) == "" {
+		return s.TaskLister.ListTasks, nil
+	}
+	q, err := taskquery.Parse(query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+	return func(ctx context.Context) (entity.Tasks, error) {
+		return s.TaskLister.SearchTasks(ctx, q)
+	}, nil
+}
+
+// diffTasks は追加と変更をtasksの順に、削除をIDの順に並べたイベントと、次に比べるためのタスクを返す
+func diffTasks(prev map[entity.TaskID]*todov1.Task, tasks entity.Tasks) ([]*todov1.TaskEvent, map[entity.TaskID]*todov1.Task) {
+	var events []*todov1.TaskEvent
+	cur := make(map[entity.TaskID]*todov1.Task, len(tasks))
+	for _, t := range tasks {
+		task := toTask(t)
+		cur[t.ID] = task
+		old, ok := prev[t.ID]
+		switch {
+		case !ok:
+			events = append(events, &todov1.TaskEvent{Type: todov1.TaskEvent_TYPE_ADDED, Task: task})
+		case !proto.Equal(old, task):
+			events = append(events, &todov1.TaskEvent{Type: todov1.TaskEvent_TYPE_UPDATED, Task: task})
+		}
+	}
+	var removed []entity.TaskID
+	for id := range prev {
+		if _, ok := cur[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	for _, id := range removed {
+		events = append(events, &todov1.TaskEvent{Type: todov1.TaskEvent_TYPE_REMOVED, Task: &todov1.Task{Id: int64(id)}})
+	}
+	return events, cur
+}
+
+var (
+	taskStatuses = map[entity.TaskStatus]todov1.TaskStatus{
+		entity.TaskStatusTodo:  todov1.TaskStatus_TASK_STATUS_TODO,
+		entity.TaskStatusDoing: todov1.TaskStatus_TASK_STATUS_DOING,
+		entity.TaskStatusDone:  todov1.TaskStatus_TASK_STATUS_DONE,
+	}
+	taskPriorities = map[entity.TaskPriority]todov1.TaskPriority{
+		entity.TaskPriorityLow:    todov1.TaskPriority_TASK_PRIORITY_LOW,
+		entity.TaskPriorityMedium: todov1.TaskPriority_TASK_PRIORITY_MEDIUM,
+		entity.TaskPriorityHigh:   todov1.TaskPriority_TASK_PRIORITY_HIGH,
+	}
+)
+
+func toTask(t *entity.Task) *todov1.Task {
+	task := &todov1.Task{
+		Id:          int64(t.ID),
+		Title:       t.Title,
+		Status:      taskStatuses[t.Status],
+		Priority:    taskPriorities[t.Priority],
+		Labels:      t.Labels,
+		DueAt:       timestamp(t.DueAt),
+		CompletedAt: timestamp(t.CompletedAt),
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		ModifiedAt:  timestamppb.New(t.ModifiedAt),
+	}
+	if t.ProjectID != nil {
+		task.ProjectId = proto.Int64(int64(*t.ProjectID))
+	}
+	if t.AssigneeID != nil {
+		task.AssigneeId = proto.Int64(int64(*t.AssigneeID))
+	}
+	return task
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
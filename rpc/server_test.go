@@ -0,0 +1,480 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/rpc/todov1"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// tokenStore はメモリ上のauth.Store
+type tokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]entity.UserID
+}
+
+func (s *tokenStore) Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = userID
+	return nil
+}
+
+func (s *tokenStore) Load(ctx context.Context, key string) (entity.UserID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.tokens[key]
+	if !ok {
+		return 0, fmt.Errorf("token %q not found", key)
+	}
+	return id, nil
+}
+
+func (s *tokenStore) Touch(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	return nil
+}
+
+func (s *tokenStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
+
+func (s *tokenStore) DeleteByUser(ctx context.Context, userID entity.UserID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, id := range s.tokens {
+		if id == userID {
+			delete(s.tokens, k)
+		}
+	}
+	return nil
+}
+
+// startServer はbufconnでサーバーを起動し、クライアントとユーザーID 10のトークンを返す
+func startServer(t *testing.T, todo *TodoServer, ws ResolveWorkspaceService) (todov1.TodoServiceClient, string) {
+	t.Helper()
+	return startServerWithStore(t, todo, ws, &tokenStore{tokens: map[string]entity.UserID{}})
+}
+
+// startServerWithStore はトークンをtokensに保存してstartServerと同じようにサーバーを起動する
+func startServerWithStore(
+	t *testing.T, todo *TodoServer, ws ResolveWorkspaceService, tokens *tokenStore,
+) (todov1.TodoServiceClient, string) {
+	t.Helper()
+
+	j, err := auth.NewJWTer(tokens, clock.FixedClocker{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := j.GenerateToken(context.Background(), entity.User{ID: 10, Name: "alice", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := bufconn.Listen(1 << 20)
+	srv := NewServer(j, ws, todo)
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() {
+		todo.Close()
+		srv.GracefulStop()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return todov1.NewTodoServiceClient(conn), string(token)
+}
+
+// personalWorkspace はユーザーの個人用ワークスペースを1、それ以外を禁止にするResolveWorkspaceService
+func personalWorkspace() *ResolveWorkspaceServiceMock {
+	return &ResolveWorkspaceServiceMock{
+		ResolveWorkspaceFunc: func(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+			uid, _ := auth.GetUserID(ctx)
+			if id != 0 && id != 1 {
+				return nil, fmt.Errorf("workspace %d: %w", id, service.ErrForbidden)
+			}
+			return &entity.WorkspaceMember{WorkspaceID: 1, UserID: uid, Role: entity.WorkspaceRoleOwner}, nil
+		},
+	}
+}
+
+func withToken(ctx context.Context, token string, kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, append([]string{"authorization", "Bearer " + token}, kv...)...)
+}
+
+func TestTodoServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	lister := &ListTaskServiceMock{
+		ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+			uid, _ := auth.GetUserID(ctx)
+			wsID, _ := auth.GetWorkspaceID(ctx)
+			if uid != 10 || wsID != 1 {
+				return nil, fmt.Errorf("unexpected context: user %d, workspace %d", uid, wsID)
+			}
+			return entity.Tasks{{ID: 1, Title: "first", Status: entity.TaskStatusTodo}}, nil
+		},
+	}
+	client, token := startServer(t, &TodoServer{TaskLister: lister}, personalWorkspace())
+
+	tests := map[string]struct {
+		ctx  context.Context
+		want codes.Code
+	}{
+		"ok":                 {ctx: withToken(context.Background(), token), want: codes.OK},
+		"workspace":          {ctx: withToken(context.Background(), token, WorkspaceMetadata, "1"), want: codes.OK},
+		"noToken":            {ctx: context.Background(), want: codes.Unauthenticated},
+		"notBearer":          {ctx: metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic "+token), want: codes.Unauthenticated},
+		"badToken":           {ctx: withToken(context.Background(), "invalid"), want: codes.Unauthenticated},
+		"badWorkspace":       {ctx: withToken(context.Background(), token, WorkspaceMetadata, "abc"), want: codes.InvalidArgument},
+		"forbiddenWorkspace": {ctx: withToken(context.Background(), token, WorkspaceMetadata, "2"), want: codes.PermissionDenied},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := client.ListTasks(tt.ctx, &todov1.ListTasksRequest{})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("code = %v, want %v: %v", got, tt.want, err)
+			}
+		})
+	}
+
+	// ストリームも同じように認証する
+	stream, err := client.WatchTasks(context.Background(), &todov1.WatchTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("WatchTasks without token: want Unauthenticated, but got %v", err)
+	}
+}
+
+func TestTodoServer_Login(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		req  *todov1.LoginRequest
+		err  error
+		want codes.Code
+	}{
+		"ok":           {req: &todov1.LoginRequest{UserName: "alice", Password: "pass"}, want: codes.OK},
+		"empty":        {req: &todov1.LoginRequest{UserName: "alice"}, want: codes.InvalidArgument},
+		"tooLongName":  {req: &todov1.LoginRequest{UserName: strings.Repeat("a", 101), Password: "pass"}, want: codes.InvalidArgument},
+		"unknownUser":  {req: &todov1.LoginRequest{UserName: "bob", Password: "pass"}, err: service.ErrInvalidCredentials, want: codes.Unauthenticated},
+		"rateLimited":  {req: &todov1.LoginRequest{UserName: "alice", Password: "pass"}, err: &service.RateLimitError{RetryAfter: 30 * time.Second}, want: codes.ResourceExhausted},
+		"serviceError": {req: &todov1.LoginRequest{UserName: "alice", Password: "pass"}, err: errors.New("connection refused"), want: codes.Internal},
+	}
+	for n, tt := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			moq := &LoginServiceMock{
+				LoginFunc: func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &entity.LoginResult{
+						Tokens: &entity.Tokens{AccessToken: "token-for-" + name, RefreshToken: "refresh-for-" + name},
+					}, nil
+				},
+			}
+			// Loginはトークンなしで呼べる
+			client, _ := startServer(t, &TodoServer{Auth: moq}, personalWorkspace())
+			rsp, err := client.Login(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %v, want %v: %v", got, tt.want, err)
+			}
+			if err == nil && (rsp.GetAccessToken() != "token-for-alice" || rsp.GetRefreshToken() != "refresh-for-alice") {
+				t.Errorf("access_token = %q, refresh_token = %q", rsp.GetAccessToken(), rsp.GetRefreshToken())
+			}
+			if tt.want == codes.ResourceExhausted {
+				var delay time.Duration
+				for _, d := range status.Convert(err).Details() {
+					if ri, ok := d.(*errdetails.RetryInfo); ok {
+						delay = ri.GetRetryDelay().AsDuration()
+					}
+				}
+				if delay != 30*time.Second {
+					t.Errorf("want RetryInfo of 30s, but got %v", delay)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoServer_LoginMFA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("challenge", func(t *testing.T) {
+		t.Parallel()
+
+		moq := &LoginServiceMock{
+			LoginFunc: func(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error) {
+				return &entity.LoginResult{Challenge: &entity.MFAChallenge{MFAToken: "mfa-token", EnrollmentRequired: true}}, nil
+			},
+		}
+		client, _ := startServer(t, &TodoServer{Auth: moq}, personalWorkspace())
+		rsp, err := client.Login(context.Background(), &todov1.LoginRequest{UserName: "alice", Password: "pass"})
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if rsp.GetAccessToken() != "" || rsp.GetMfaToken() != "mfa-token" || !rsp.GetMfaEnrollmentRequired() {
+			t.Errorf("want only the mfa token, but got %v", rsp)
+		}
+	})
+
+	tests := map[string]struct {
+		req  *todov1.LoginMFARequest
+		err  error
+		want codes.Code
+	}{
+		"ok":           {req: &todov1.LoginMFARequest{MfaToken: "mfa-token", Code: "123456"}, want: codes.OK},
+		"recoveryCode": {req: &todov1.LoginMFARequest{MfaToken: "mfa-token", RecoveryCode: "abcde-fghij"}, want: codes.OK},
+		"noCode":       {req: &todov1.LoginMFARequest{MfaToken: "mfa-token"}, want: codes.InvalidArgument},
+		"bothCodes":    {req: &todov1.LoginMFARequest{MfaToken: "mfa-token", Code: "123456", RecoveryCode: "abcde-fghij"}, want: codes.InvalidArgument},
+		"wrongCode":    {req: &todov1.LoginMFARequest{MfaToken: "mfa-token", Code: "000000"}, err: service.ErrInvalidMFACode, want: codes.Unauthenticated},
+		"invalidToken": {req: &todov1.LoginMFARequest{MfaToken: "expired", Code: "123456"}, err: service.ErrInvalidMFAToken, want: codes.Unauthenticated},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			moq := &LoginServiceMock{
+				LoginMFAFunc: func(ctx context.Context, mfaToken, code, recoveryCode string) (*entity.LoginResult, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &entity.LoginResult{Tokens: &entity.Tokens{AccessToken: "access", RefreshToken: "refresh"}}, nil
+				},
+			}
+			// LoginMFAもトークンなしで呼べる
+			client, _ := startServer(t, &TodoServer{Auth: moq}, personalWorkspace())
+			rsp, err := client.LoginMFA(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("code = %v, want %v: %v", got, tt.want, err)
+			}
+			if err == nil && (rsp.GetAccessToken() != "access" || rsp.GetRefreshToken() != "refresh") {
+				t.Errorf("access_token = %q, refresh_token = %q", rsp.GetAccessToken(), rsp.GetRefreshToken())
+			}
+		})
+	}
+}
+
+func TestTodoServer_AddTask(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2022, 5, 20, 0, 0, 0, 0, time.UTC)
+	moq := &AddTaskServiceMock{
+		AddTaskFunc: func(ctx context.Context, title string) (*entity.Task, error) {
+			return &entity.Task{ID: 3, Title: title, Status: entity.TaskStatusTodo, Priority: entity.TaskPriorityHigh, DueAt: &due}, nil
+		},
+	}
+	client, token := startServer(t, &TodoServer{TaskAdder: moq}, personalWorkspace())
+
+	got, err := client.AddTask(withToken(context.Background(), token), &todov1.AddTaskRequest{Title: "  write docs "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &todov1.Task{
+		Id:         3,
+		Title:      "write docs",
+		Status:     todov1.TaskStatus_TASK_STATUS_TODO,
+		Priority:   todov1.TaskPriority_TASK_PRIORITY_HIGH,
+		DueAt:      timestamp(&due),
+		CreatedAt:  timestamp(&time.Time{}),
+		ModifiedAt: timestamp(&time.Time{}),
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("AddTask mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = client.AddTask(withToken(context.Background(), token), &todov1.AddTaskRequest{Title: " "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty title: want InvalidArgument, but got %v", err)
+	}
+}
+
+func TestTodoServer_ListTasks(t *testing.T) {
+	t.Parallel()
+
+	moq := &ListTaskServiceMock{
+		SearchTasksFunc: func(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+			if q.String() != "status:doing" {
+				return nil, fmt.Errorf("unexpected query %q", q)
+			}
+			return entity.Tasks{{ID: 2, Title: "doing", Status: entity.TaskStatusDoing, Labels: []string{"work"}}}, nil
+		},
+	}
+	client, token := startServer(t, &TodoServer{TaskLister: moq}, personalWorkspace())
+	ctx := withToken(context.Background(), token)
+
+	rsp, err := client.ListTasks(ctx, &todov1.ListTasksRequest{Query: "status:doing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.GetTasks()) != 1 || rsp.GetTasks()[0].GetId() != 2 || rsp.GetTasks()[0].GetLabels()[0] != "work" {
+		t.Errorf("unexpected tasks: %v", rsp.GetTasks())
+	}
+
+	_, err = client.ListTasks(ctx, &todov1.ListTasksRequest{Query: "status:"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad query: want InvalidArgument, but got %v", err)
+	}
+}
+
+func TestTodoServer_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	// 呼ばれるたびに次のスナップショットを返し、最後のものを返し続ける
+	snapshots := []entity.Tasks{
+		{{ID: 1, Title: "a", Status: entity.TaskStatusTodo}, {ID: 2, Title: "b", Status: entity.TaskStatusTodo}},
+		{{ID: 1, Title: "a", Status: entity.TaskStatusTodo}, {ID: 2, Title: "b", Status: entity.TaskStatusTodo}},
+		{{ID: 2, Title: "b", Status: entity.TaskStatusDone}, {ID: 3, Title: "c", Status: entity.TaskStatusTodo}},
+	}
+	var mu sync.Mutex
+	calls := 0
+	moq := &ListTaskServiceMock{
+		ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			s := snapshots[min(calls, len(snapshots)-1)]
+			calls++
+			return s, nil
+		},
+	}
+	todo := &TodoServer{TaskLister: moq, WatchInterval: time.Millisecond}
+	client, token := startServer(t, todo, personalWorkspace())
+
+	stream, err := client.WatchTasks(withToken(context.Background(), token), &todov1.WatchTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type event struct {
+		Type   todov1.TaskEvent_Type
+		ID     int64
+		Status todov1.TaskStatus
+	}
+	want := []event{
+		{todov1.TaskEvent_TYPE_SNAPSHOT, 1, todov1.TaskStatus_TASK_STATUS_TODO},
+		{todov1.TaskEvent_TYPE_SNAPSHOT, 2, todov1.TaskStatus_TASK_STATUS_TODO},
+		{todov1.TaskEvent_TYPE_UPDATED, 2, todov1.TaskStatus_TASK_STATUS_DONE},
+		{todov1.TaskEvent_TYPE_ADDED, 3, todov1.TaskStatus_TASK_STATUS_TODO},
+		{todov1.TaskEvent_TYPE_REMOVED, 1, todov1.TaskStatus_TASK_STATUS_UNSPECIFIED},
+	}
+	var got []event
+	for range want {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, event{e.GetType(), e.GetTask().GetId(), e.GetTask().GetStatus()})
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WatchTasks mismatch (-want +got):\n%s", diff)
+	}
+
+	// サーバーを止めるとストリームが終わる
+	todo.Close()
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if status.Code(err) != codes.Unavailable {
+				t.Errorf("want Unavailable after Close, but got %v", err)
+			}
+			break
+		}
+	}
+}
+
+// TestTodoServer_WatchTasks_Revoked はストリームを開いた後にセッションを破棄すると、ストリームが閉じることを確かめる
+func TestTodoServer_WatchTasks_Revoked(t *testing.T) {
+	t.Parallel()
+
+	moq := &ListTaskServiceMock{
+		ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+			return entity.Tasks{{ID: 1, Title: "a", Status: entity.TaskStatusTodo}}, nil
+		},
+	}
+	todo := &TodoServer{TaskLister: moq, WatchInterval: time.Millisecond}
+	tokens := &tokenStore{tokens: map[string]entity.UserID{}}
+	client, token := startServerWithStore(t, todo, personalWorkspace(), tokens)
+
+	stream, err := client.WatchTasks(withToken(context.Background(), token), &todov1.WatchTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tokens.DeleteByUser(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if status.Code(err) != codes.Unauthenticated {
+				t.Errorf("want Unauthenticated after revoke, but got %v", err)
+			}
+			break
+		}
+	}
+}
+
+// TestTodoServer_WatchTasks_DefaultInterval は間隔を設定していなくても、既定の間隔で変更を確認し続けることを確かめる
+func TestTodoServer_WatchTasks_DefaultInterval(t *testing.T) {
+	t.Parallel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if got := (&TodoServer{WatchInterval: interval}).watchInterval(); got != defaultWatchInterval {
+			t.Errorf("interval %v: want %v, but got %v", interval, defaultWatchInterval, got)
+		}
+	}
+
+	moq := &ListTaskServiceMock{
+		ListTasksFunc: func(ctx context.Context) (entity.Tasks, error) {
+			return entity.Tasks{{ID: 1, Title: "a", Status: entity.TaskStatusTodo}}, nil
+		},
+	}
+	todo := &TodoServer{TaskLister: moq}
+	client, token := startServer(t, todo, personalWorkspace())
+
+	stream, err := client.WatchTasks(withToken(context.Background(), token), &todov1.WatchTasksRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err := stream.Recv(); err != nil || e.GetType() != todov1.TaskEvent_TYPE_SNAPSHOT {
+		t.Fatalf("want snapshot, but got %v, %v", e, err)
+	}
+	todo.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("want Unavailable after Close, but got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+)
+
+//go:generate go run github.com/matryer/moq -out moq_test.go . LoginService AddTaskService ListTaskService ResolveWorkspaceService
+type LoginService interface {
+	Login(ctx context.Context, name string, password string, clientIP string) (*entity.LoginResult, error)
+	LoginMFA(ctx context.Context, mfaToken, code, recoveryCode string) (*entity.LoginResult, error)
+}
+
+type AddTaskService interface {
+	AddTask(ctx context.Context, title string) (*entity.Task, error)
+}
+
+type ListTaskService interface {
+	ListTasks(ctx context.Context) (entity.Tasks, error)
+	SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error)
+}
+
+type ResolveWorkspaceService interface {
+	ResolveWorkspace(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error)
+}
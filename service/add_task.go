@@ -4,30 +4,48 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
 )
 
 type AddTask struct {
-	DB   *sqlx.DB
-	Repo TaskAdder
+	DB    store.TxBeginner
+	Repo  TaskAdder
+	Stats StatsInvalidator
 }
 
+// AddTask はタスクを作成する。タスクが置かれるtodoの先頭の列がWIP上限に達していればErrWIPLimitExceededを返す
 func (a *AddTask) AddTask(ctx context.Context, title string) (*entity.Task, error) {
 	userID, ok := auth.GetUserID(ctx)
 	if !ok {
 		return nil, fmt.Errorf("user_id not found")
 	}
 
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	c, err := lockDefaultColumn(ctx, tx, a.Repo, userID, entity.TaskStatusTodo)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWIPLimit(ctx, tx, a.Repo, c, 0, 1); err != nil {
+		return nil, err
+	}
 	task := &entity.Task{
 		UserID: userID,
 		Title:  title,
 		Status: entity.TaskStatusTodo,
 	}
-	err := a.Repo.AddTask(ctx, a.DB, task)
-	if err != nil {
+	if err := a.Repo.AddTask(ctx, tx, task); err != nil {
 		return nil, fmt.Errorf("failed to register: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	invalidateStats(ctx, a.Stats, userID)
 	return task, nil
 }
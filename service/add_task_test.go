@@ -5,6 +5,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
 	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
@@ -13,13 +15,17 @@ import (
 func TestAddTask_AddTask(t *testing.T) {
 	t.Parallel()
 
+	limit := 2
 	tests := []struct {
 		name        string
 		title       string
 		userID      entity.UserID
 		userIDFound bool
+		column      *entity.Column
+		count       int
 		mockError   error
 		wantError   bool
+		wantErrIs   error
 		wantTask    *entity.Task
 	}{
 		{
@@ -41,6 +47,29 @@ func TestAddTask_AddTask(t *testing.T) {
 			userIDFound: false,
 			wantError:   true,
 		},
+		{
+			name:        "below wip limit",
+			title:       "Test Task",
+			userID:      1,
+			userIDFound: true,
+			column:      &entity.Column{ID: 3, Name: "Ready", Status: entity.TaskStatusTodo, WIPLimit: &limit},
+			count:       1,
+			wantTask: &entity.Task{
+				UserID: 1,
+				Title:  "Test Task",
+				Status: entity.TaskStatusTodo,
+			},
+		},
+		{
+			name:        "wip limit reached",
+			title:       "Test Task",
+			userID:      1,
+			userIDFound: true,
+			column:      &entity.Column{ID: 3, Name: "Ready", Status: entity.TaskStatusTodo, WIPLimit: &limit},
+			count:       2,
+			wantError:   true,
+			wantErrIs:   ErrWIPLimitExceeded,
+		},
 		{
 			name:        "repository error",
 			title:       "Test Task",
@@ -55,8 +84,34 @@ func TestAddTask_AddTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			if tt.userIDFound {
+				mock.ExpectBegin()
+				if tt.wantError {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+
 			// モックの設定
 			mockRepo := &TaskAdderMock{
+				GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+					if status != entity.TaskStatusTodo {
+						t.Errorf("want the todo column, but got %q", status)
+					}
+					if tt.column == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.column, nil
+				},
+				CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+					return tt.count, nil
+				},
 				AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
 					if tt.mockError != nil {
 						return tt.mockError
@@ -69,6 +124,7 @@ func TestAddTask_AddTask(t *testing.T) {
 
 			// サービスインスタンスの作成
 			addTaskService := &AddTask{
+				DB:   sqlx.NewDb(db, "mysql"),
 				Repo: mockRepo,
 			}
 
@@ -86,6 +142,12 @@ func TestAddTask_AddTask(t *testing.T) {
 				if err == nil {
 					t.Errorf("AddTask() expected error but got none")
 				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("AddTask() error = %v, want %v", err, tt.wantErrIs)
+				}
+				if tt.wantErrIs != nil && len(mockRepo.AddTaskCalls()) != 0 {
+					t.Errorf("AddTask() must not add a task over the wip limit")
+				}
 				return
 			}
 
@@ -111,6 +173,10 @@ func TestAddTask_AddTask(t *testing.T) {
 				t.Errorf("AddTask() got status = %v, want %v", gotTask.Status, tt.wantTask.Status)
 			}
 
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+
 			// モックの呼び出し回数を検証
 			if tt.userIDFound && tt.mockError == nil {
 				if len(mockRepo.AddTaskCalls()) != 1 {
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// ErrAssigneeNoAccess は担当者にしようとしたユーザーがタスクのプロジェクトにアクセスできないときのエラー
+var ErrAssigneeNoAccess = errors.New("assignee has no access to the project")
+
+type AssignTask struct {
+	DB       store.TxBeginner
+	Repo     TaskAssigner
+	Notifier Notifier
+}
+
+// AssignTask はタスクの担当者を変更する。assigneeIDがnilなら担当者を外す。
+// 変更できるのはタスクの作成者、現在の担当者、ワークスペースの管理者だけ。
+// プロジェクトはワークスペースのメンバー全員が使えるので、担当者はワークスペースのメンバーでなければならない。
+func (a *AssignTask) AssignTask(ctx context.Context, id entity.TaskID, assigneeID *entity.UserID) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := a.Repo.GetTask(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if !canAssign(ctx, t, userID) {
+		return fmt.Errorf("task %d: %w", id, ErrForbidden)
+	}
+	if assigneeID != nil {
+		_, err := a.Repo.GetWorkspaceMember(ctx, tx, t.WorkspaceID, *assigneeID)
+		if errors.Is(err, store.ErrNotFound) {
+			return fmt.Errorf("user %d: %w", *assigneeID, ErrAssigneeNoAccess)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get workspace member: %w", err)
+		}
+	}
+	if err := a.Repo.AssignTask(ctx, tx, id, assigneeID); err != nil {
+		return fmt.Errorf("failed to assign task: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	// 自分自身を担当者にしたときや、担当者が変わらないときは通知しない
+	if assigneeID == nil || *assigneeID == userID || sameAssignee(t.AssigneeID, assigneeID) {
+		return nil
+	}
+	// 担当者の変更は確定しているので、通知に失敗してもログに残すだけにする
+	n := &entity.Notification{
+		UserID:  *assigneeID,
+		Type:    entity.NotificationTypeTaskAssigned,
+		TaskID:  &t.ID,
+		Message: fmt.Sprintf("You were assigned to %q", t.Title),
+	}
+	if err := a.Notifier.Notify(ctx, n); err != nil {
+		log.Printf("failed to notify assignee: %v", err)
+	}
+	return nil
+}
+
+func canAssign(ctx context.Context, t *entity.Task, userID entity.UserID) bool {
+	if t.UserID == userID || sameAssignee(t.AssigneeID, &userID) {
+		return true
+	}
+	role, ok := auth.GetWorkspaceRole(ctx)
+	return ok && role.CanManage()
+}
+
+func sameAssignee(a, b *entity.UserID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+type SetTaskProject struct {
+	DB   store.TxBeginner
+	Repo TaskProjectSetter
+}
+
+// SetTaskProject はタスクを同じワークスペースのプロジェクトに入れる。projectIDがnilならプロジェクトから外す。
+// 変更できるのは担当者の変更と同じく、タスクの作成者、現在の担当者、ワークスペースの管理者だけ。
+func (s *SetTaskProject) SetTaskProject(ctx context.Context, id entity.TaskID, projectID *entity.ProjectID) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	t, err := s.Repo.GetTask(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if !canAssign(ctx, t, userID) {
+		return fmt.Errorf("task %d: %w", id, ErrForbidden)
+	}
+	if projectID != nil {
+		if _, err := s.Repo.GetProject(ctx, tx, *projectID); err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+	}
+	if err := s.Repo.SetTaskProject(ctx, tx, id, projectID); err != nil {
+		return fmt.Errorf("failed to set project: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+type Workload struct {
+	DB   store.Queryer
+	Repo WorkloadGetter
+}
+
+// Workload はワークスペースのメンバーごとの未完了の担当タスク数を返す
+func (w *Workload) Workload(ctx context.Context) ([]*entity.Workload, error) {
+	workload, err := w.Repo.GetWorkload(ctx, w.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload: %w", err)
+	}
+	return workload, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestAssignTask_AssignTask(t *testing.T) {
+	t.Parallel()
+
+	const (
+		author   entity.UserID = 1
+		member   entity.UserID = 2
+		outsider entity.UserID = 3
+	)
+	assignee := func(id entity.UserID) *entity.UserID { return &id }
+
+	tests := []struct {
+		name       string
+		userID     entity.UserID
+		role       entity.WorkspaceRole
+		current    *entity.UserID
+		assignee   *entity.UserID
+		wantAssign bool
+		wantNotify bool
+		wantErrIs  error
+	}{
+		{
+			name:       "author assigns member",
+			userID:     author,
+			role:       entity.WorkspaceRoleMember,
+			assignee:   assignee(member),
+			wantAssign: true,
+			wantNotify: true,
+		},
+		{
+			name:       "author assigns self",
+			userID:     author,
+			role:       entity.WorkspaceRoleMember,
+			assignee:   assignee(author),
+			wantAssign: true,
+		},
+		{
+			name:       "unassign",
+			userID:     member,
+			role:       entity.WorkspaceRoleMember,
+			current:    assignee(member),
+			wantAssign: true,
+		},
+		{
+			name:       "admin reassigns",
+			userID:     outsider,
+			role:       entity.WorkspaceRoleAdmin,
+			current:    assignee(author),
+			assignee:   assignee(member),
+			wantAssign: true,
+			wantNotify: true,
+		},
+		{
+			name:      "other member cannot assign",
+			userID:    member,
+			role:      entity.WorkspaceRoleMember,
+			assignee:  assignee(member),
+			wantErrIs: ErrForbidden,
+		},
+		{
+			name:      "assignee is not a workspace member",
+			userID:    author,
+			role:      entity.WorkspaceRoleMember,
+			assignee:  assignee(outsider),
+			wantErrIs: ErrAssigneeNoAccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantAssign {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			assigned := false
+			repo := &TaskAssignerMock{
+				GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+					return &entity.Task{ID: id, WorkspaceID: 5, UserID: author, AssigneeID: tt.current}, nil
+				},
+				GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+					if workspaceID != 5 {
+						t.Errorf("want workspace 5, but got %d", workspaceID)
+					}
+					if userID == outsider {
+						return nil, store.ErrNotFound
+					}
+					return &entity.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: entity.WorkspaceRoleMember}, nil
+				},
+				AssignTaskFunc: func(ctx context.Context, db store.Execer, id entity.TaskID, assigneeID *entity.UserID) error {
+					assigned = true
+					return nil
+				},
+			}
+			notifier := &NotifierMock{
+				NotifyFunc: func(ctx context.Context, n *entity.Notification) error {
+					if n.UserID != *tt.assignee || n.Type != entity.NotificationTypeTaskAssigned || *n.TaskID != 10 {
+						t.Errorf("unexpected notification %+v", n)
+					}
+					return nil
+				},
+			}
+
+			ctx := auth.SetUserID(context.Background(), tt.userID)
+			ctx = auth.SetWorkspaceRole(ctx, tt.role)
+			sut := &AssignTask{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Notifier: notifier}
+			err = sut.AssignTask(ctx, 10, tt.assignee)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("want error %v, but got %v", tt.wantErrIs, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if assigned != tt.wantAssign {
+				t.Errorf("assigned = %v, want %v", assigned, tt.wantAssign)
+			}
+			if got := len(notifier.NotifyCalls()) == 1; got != tt.wantNotify {
+				t.Errorf("notified = %v, want %v", got, tt.wantNotify)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSetTaskProject_SetTaskProject(t *testing.T) {
+	t.Parallel()
+
+	const (
+		author   entity.UserID = 1
+		member   entity.UserID = 2
+		assignee entity.UserID = 3
+	)
+	project := entity.ProjectID(4)
+
+	tests := []struct {
+		name      string
+		userID    entity.UserID
+		role      entity.WorkspaceRole
+		projectID *entity.ProjectID
+		wantSet   bool
+		wantErrIs error
+	}{
+		{name: "author sets project", userID: author, role: entity.WorkspaceRoleMember, projectID: &project, wantSet: true},
+		{name: "assignee removes project", userID: assignee, role: entity.WorkspaceRoleMember, wantSet: true},
+		{name: "admin sets project", userID: member, role: entity.WorkspaceRoleAdmin, projectID: &project, wantSet: true},
+		{name: "other member cannot set project", userID: member, role: entity.WorkspaceRoleMember, projectID: &project, wantErrIs: ErrForbidden},
+		{name: "other member cannot remove project", userID: member, role: entity.WorkspaceRoleMember, wantErrIs: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantSet {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			current := assignee
+			repo := &TaskProjectSetterMock{
+				GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+					return &entity.Task{ID: id, WorkspaceID: 5, UserID: author, AssigneeID: &current}, nil
+				},
+				GetProjectFunc: func(ctx context.Context, db store.Queryer, id entity.ProjectID) (*entity.Project, error) {
+					return &entity.Project{ID: id}, nil
+				},
+				SetTaskProjectFunc: func(ctx context.Context, db store.Execer, id entity.TaskID, projectID *entity.ProjectID) error {
+					return nil
+				},
+			}
+
+			ctx := auth.SetUserID(context.Background(), tt.userID)
+			ctx = auth.SetWorkspaceRole(ctx, tt.role)
+			sut := &SetTaskProject{DB: sqlx.NewDb(db, "mysql"), Repo: repo}
+			err = sut.SetTaskProject(ctx, 10, tt.projectID)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("want error %v, but got %v", tt.wantErrIs, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(repo.SetTaskProjectCalls()) == 1; got != tt.wantSet {
+				t.Errorf("set = %v, want %v", got, tt.wantSet)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+var ErrWIPLimitExceeded = errors.New("wip limit exceeded")
+
+type GetBoard struct {
+	DB   store.Queryer
+	Repo BoardGetter
+}
+
+func (g *GetBoard) GetBoard(ctx context.Context) (*entity.Board, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	board, err := g.Repo.GetBoard(ctx, g.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+	return board, nil
+}
+
+type AddColumn struct {
+	DB   store.TxBeginner
+	Repo ColumnAdder
+}
+
+// AddColumn はボードの右端に列を追加する
+func (a *AddColumn) AddColumn(ctx context.Context, name string, status entity.TaskStatus, wipLimit *int) (*entity.Column, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	columns, err := a.Repo.ListColumns(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns: %w", err)
+	}
+	c := &entity.Column{
+		UserID:   userID,
+		Name:     name,
+		Status:   status,
+		WIPLimit: wipLimit,
+	}
+	if len(columns) > 0 {
+		c.Position = columns[len(columns)-1].Position + 1
+	}
+	// 同時に追加された場合は位置の一意制約でErrAlreadyExistsになる
+	if err := a.Repo.AddColumn(ctx, tx, c); err != nil {
+		return nil, fmt.Errorf("failed to add column: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return c, nil
+}
+
+type MoveTask struct {
+	DB    store.TxBeginner
+	Repo  TaskMover
+	Stats StatsInvalidator
+}
+
+// MoveTask はタスクを列に移動する。移動先の列がWIP上限に達していればErrWIPLimitExceededを返す。
+// 既にその列にあるタスクの移動は上限に関係なく成功する。
+func (m *MoveTask) MoveTask(ctx context.Context, id entity.TaskID, columnID entity.ColumnID) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := m.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	c, err := m.Repo.GetColumnForUpdate(ctx, tx, userID, columnID)
+	if err != nil {
+		return fmt.Errorf("failed to get column: %w", err)
+	}
+	t, err := m.Repo.GetTask(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if err := checkWIPLimit(ctx, tx, m.Repo, c, id, 1); err != nil {
+		return err
+	}
+	if err := m.Repo.MoveTask(ctx, tx, userID, id, c); err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	invalidateTaskStats(ctx, m.Stats, t)
+	return nil
+}
+
+type columnTaskCounter interface {
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+}
+
+type defaultColumnLocker interface {
+	GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+}
+
+// checkWIPLimit は行ロックを取った列cに、excludeのタスクのほかにn件のタスクを置けるかを確かめる。
+// 置けなければErrWIPLimitExceededを返す。cがnilなら上限はない。
+func checkWIPLimit(ctx context.Context, db store.Queryer, repo columnTaskCounter, c *entity.Column, exclude entity.TaskID, n int) error {
+	if c == nil || c.WIPLimit == nil {
+		return nil
+	}
+	count, err := repo.CountColumnTasks(ctx, db, c, exclude)
+	if err != nil {
+		return fmt.Errorf("failed to count tasks: %w", err)
+	}
+	if count+n > *c.WIPLimit {
+		return fmt.Errorf("column %q has %d tasks: %w", c.Name, count, ErrWIPLimitExceeded)
+	}
+	return nil
+}
+
+// lockDefaultColumn は列を明示せずにstatusのタスクを置く先頭の列を行ロックを取って返す。
+// 同じステータスの列がなければnilを返す。
+// ロックを取る前に読んだ内容で数えると他のトランザクションが置いたタスクを見落とすので、
+// トランザクションで最初に呼び、checkWIPLimitで上限を確かめること。
+func lockDefaultColumn(
+	ctx context.Context, db store.Queryer, repo defaultColumnLocker, userID entity.UserID, status entity.TaskStatus,
+) (*entity.Column, error) {
+	c, err := repo.GetDefaultColumnForUpdate(ctx, db, userID, status)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get column: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestMoveTask_MoveTask(t *testing.T) {
+	t.Parallel()
+
+	limit := 2
+	assignee := entity.UserID(1)
+	tests := []struct {
+		name       string
+		wipLimit   *int
+		count      int
+		getErr     error
+		wantMove   bool
+		wantCommit bool
+		wantErrIs  error
+	}{
+		{
+			name:       "no wip limit",
+			wantMove:   true,
+			wantCommit: true,
+		},
+		{
+			name:       "below wip limit",
+			wipLimit:   &limit,
+			count:      1,
+			wantMove:   true,
+			wantCommit: true,
+		},
+		{
+			name:      "wip limit reached",
+			wipLimit:  &limit,
+			count:     2,
+			wantErrIs: ErrWIPLimitExceeded,
+		},
+		{
+			name:      "column not found",
+			getErr:    store.ErrNotFound,
+			wantErrIs: store.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			col := &entity.Column{ID: 3, UserID: 1, Name: "Ready", Status: entity.TaskStatusTodo, WIPLimit: tt.wipLimit}
+			moved := false
+			repo := &TaskMoverMock{
+				GetColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.ColumnID) (*entity.Column, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					return col, nil
+				},
+				CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+					if exclude != 10 {
+						t.Errorf("want to exclude task 10, but got %d", exclude)
+					}
+					return tt.count, nil
+				},
+				GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+					return &entity.Task{ID: id, UserID: 5, AssigneeID: &assignee}, nil
+				},
+				MoveTaskFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, c *entity.Column) error {
+					moved = true
+					return nil
+				},
+			}
+			inv := &StatsInvalidatorMock{
+				InvalidateStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error {
+					return nil
+				},
+			}
+			sut := &MoveTask{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Stats: inv}
+			err = sut.MoveTask(auth.SetWorkspaceID(auth.SetUserID(context.Background(), assignee), 2), 10, col.ID)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("MoveTask() error = %v, want %v", err, tt.wantErrIs)
+				}
+			} else if err != nil {
+				t.Fatalf("MoveTask() unexpected error: %v", err)
+			}
+			if moved != tt.wantMove {
+				t.Errorf("MoveTask() moved = %v, want %v", moved, tt.wantMove)
+			}
+			// 担当者が動かしたタスクは作成者の統計にも数えられている
+			var invalidated []entity.UserID
+			for _, c := range inv.InvalidateStatsCalls() {
+				invalidated = append(invalidated, c.UserID)
+			}
+			var wantInvalidated []entity.UserID
+			if tt.wantCommit {
+				wantInvalidated = []entity.UserID{5, assignee}
+			}
+			if diff := cmp.Diff(wantInvalidated, invalidated); diff != "" {
+				t.Errorf("InvalidateStats() users mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddColumn_AddColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		existing     entity.Columns
+		wantPosition int
+	}{
+		"first column": {
+			wantPosition: 0,
+		},
+		"appended to the right": {
+			existing:     entity.Columns{{Position: 0}, {Position: 4}},
+			wantPosition: 5,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+
+			repo := &ColumnAdderMock{
+				ListColumnsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Columns, error) {
+					return tt.existing, nil
+				},
+				AddColumnFunc: func(ctx context.Context, db store.Execer, c *entity.Column) error {
+					c.ID = 1
+					return nil
+				},
+			}
+			sut := &AddColumn{DB: sqlx.NewDb(db, "mysql"), Repo: repo}
+			got, err := sut.AddColumn(auth.SetUserID(context.Background(), 1), "Review", entity.TaskStatusDoing, nil)
+			if err != nil {
+				t.Fatalf("AddColumn() unexpected error: %v", err)
+			}
+			if got.Position != tt.wantPosition {
+				t.Errorf("AddColumn() position = %d, want %d", got.Position, tt.wantPosition)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
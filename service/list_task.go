@@ -7,6 +7,7 @@ import (
 	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 )
 
 type ListTask struct {
@@ -26,3 +27,30 @@ func (l *ListTask) ListTasks(ctx context.Context) (entity.Tasks, error) {
 	}
 	return tasks, nil
 }
+
+// ListAssignedTasks はワークスペースでassigneeIDが担当しているタスクを返す
+func (l *ListTask) ListAssignedTasks(ctx context.Context, assigneeID entity.UserID) (entity.Tasks, error) {
+	tasks, err := l.Repo.ListAssignedTasks(ctx, l.DB, assigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assigned tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// SearchTasks はワークスペースのタスクのうち、検索クエリにマッチするものを返す。
+// 期限の日付はユーザーのタイムゾーンで解釈する。
+func (l *ListTask) SearchTasks(ctx context.Context, q taskquery.Node) (entity.Tasks, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	u, err := l.Repo.GetUserByID(ctx, l.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	tasks, err := l.Repo.SearchTasks(ctx, l.DB, userID, q, u.Location())
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	return tasks, nil
+}
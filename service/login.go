@@ -2,30 +2,184 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials はユーザーがいないか、パスワードが違うときのエラー。どちらかは区別しない
+var ErrInvalidCredentials = errors.New("invalid user name or password")
+
+// ErrInvalidMFAToken は存在しない、期限切れ、使用済み、または試行回数を超えたMFAトークンのエラー
+var ErrInvalidMFAToken = errors.New("invalid mfa token")
+
+// MaxMFAAttempts はひとつのMFAトークンでコードを試せる回数
+const MaxMFAAttempts = 5
+
+// dummyPasswordHash はユーザーがいないときに比べる、ユーザーのパスワードと同じコストのbcryptのハッシュ。
+// 応答までの時間の差で、ユーザー名があるかを知られないようにする
+const dummyPasswordHash = "$2a$10$jOXJRSKt3UqYEYalRLFSh.y7bpxUqHvgCu1QoyEQWWqADZD12K9Xy"
+
 type Login struct {
 	DB             *sqlx.DB
 	Repo           UserGetter
+	Users          UserByIDGetter
 	TokenGenerator TokenGenerator
+	RefreshTokens  RefreshTokenIssuer
+	// MFA がnilなら2要素認証をせずにトークンを発行する
+	MFA        MFAVerifier
+	Challenges MFAChallengeStore
+	// ChallengeTTL はパスワードを確かめてから2要素認証を終えるまでの制限時間
+	ChallengeTTL time.Duration
+	// Throttle がnilなら、パスワードの試行を制限しない
+	Throttle LoginThrottler
 }
 
-func (l *Login) Login(ctx context.Context, userName, password string) (string, error) {
+// Login はパスワードを確かめ、アクセストークンとリフレッシュトークンを発行する。
+// 2要素認証を登録済みか、ロールで必須なら、トークンの代わりにMFAトークンを返す。
+// 失敗が続いたユーザー名やIPアドレスには、パスワードを確かめずにRateLimitErrorを返す
+func (l *Login) Login(ctx context.Context, userName, password, clientIP string) (*entity.LoginResult, error) {
+	if l.Throttle != nil {
+		if err := l.Throttle.Check(ctx, userName, clientIP); err != nil {
+			return nil, err
+		}
+	}
 	user, err := l.Repo.GetUser(ctx, l.DB, userName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+			return nil, l.fail(ctx, userName, clientIP)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	if err := user.ComparePassword(password); err != nil {
-		return "", fmt.Errorf("failed to compare password: %w", err)
+		// IDプロバイダーで作ったユーザーのようにパスワードのハッシュがなければ、bcryptはすぐにエラーを返す。
+		// 間違ったパスワードと同じく失敗として数え、応答までの時間もそろえる
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		}
+		return nil, l.fail(ctx, userName, clientIP)
+	}
+	if l.Throttle != nil {
+		if err := l.Throttle.Succeed(ctx, userName); err != nil {
+			return nil, err
+		}
 	}
 
-	token, err := l.TokenGenerator.GenerateToken(ctx, *user)
+	challenge, err := startMFAChallenge(ctx, l.MFA, l.Challenges, l.ChallengeTTL, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &entity.LoginResult{Challenge: challenge}, nil
+	}
+
+	tokens, err := l.issue(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{Tokens: tokens}, nil
+}
+
+// EnrollMFA はロールで2要素認証が必須なのに登録していないユーザーが、ログインの途中でTOTPの登録を始める
+func (l *Login) EnrollMFA(ctx context.Context, mfaToken string) (*entity.TOTPEnrollment, error) {
+	userID, err := l.Challenges.LoadMFAChallenge(ctx, hashToken(mfaToken))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, fmt.Errorf("failed to load mfa challenge: %w", err)
+	}
+	user, err := l.Users.GetUserByID(ctx, l.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return l.MFA.EnrollTOTPFor(ctx, user)
+}
+
+// LoginMFA はログインの2段階目で、MFAトークンと認証アプリのコードかリカバリーコードを確かめてトークンを発行する。
+// ログインの途中で登録したときは、確認と同時に発行したリカバリーコードも返す
+func (l *Login) LoginMFA(ctx context.Context, mfaToken, code, recoveryCode string) (*entity.LoginResult, error) {
+	key := hashToken(mfaToken)
+	userID, attempts, err := l.Challenges.AttemptMFAChallenge(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, fmt.Errorf("failed to attempt mfa challenge: %w", err)
+	}
+	if attempts > MaxMFAAttempts {
+		_ = l.Challenges.DeleteMFAChallenge(ctx, key)
+		return nil, ErrInvalidMFAToken
+	}
+	user, err := l.Users.GetUserByID(ctx, l.DB, userID)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	recoveryCodes, err := l.MFA.VerifyMFA(ctx, user, code, recoveryCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Challenges.DeleteMFAChallenge(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+
+	tokens, err := l.issue(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{Tokens: tokens, RecoveryCodes: recoveryCodes}, nil
+}
+
+// startMFAChallenge は2要素認証を登録済みか、ロールで必須なユーザーのMFAトークンを作って保存する。
+// 2要素認証が要らないか、mfaがnilならnilを返す。パスワードでもIDプロバイダーでも、ログインはここを通す
+func startMFAChallenge(
+	ctx context.Context, mfa MFAVerifier, challenges MFAChallengeStore, ttl time.Duration, user *entity.User,
+) (*entity.MFAChallenge, error) {
+	if mfa == nil {
+		return nil, nil
+	}
+	enabled, required, err := mfa.MFAStatus(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled && !required {
+		return nil, nil
+	}
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := challenges.SaveMFAChallenge(ctx, hashToken(token), user.ID, ttl); err != nil {
+		return nil, fmt.Errorf("failed to save mfa challenge: %w", err)
+	}
+	return &entity.MFAChallenge{MFAToken: token, EnrollmentRequired: !enabled}, nil
+}
+
+// fail はパスワードの失敗を記録してErrInvalidCredentialsを返す
+func (l *Login) fail(ctx context.Context, userName, clientIP string) error {
+	if l.Throttle != nil {
+		if err := l.Throttle.Fail(ctx, userName, clientIP); err != nil {
+			return err
+		}
+	}
+	return ErrInvalidCredentials
+}
 
-	return string(token), nil
+func (l *Login) issue(ctx context.Context, user *entity.User) (*entity.Tokens, error) {
+	token, err := l.TokenGenerator.GenerateToken(ctx, *user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	refresh, err := l.RefreshTokens.IssueRefreshToken(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue refresh token: %w", err)
+	}
+	return &entity.Tokens{AccessToken: string(token), RefreshToken: refresh}, nil
 }
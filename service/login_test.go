@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestLogin_Login(t *testing.T) {
@@ -47,7 +52,7 @@ func TestLogin_Login(t *testing.T) {
 			name:          "user not found",
 			userName:      "nonexistent",
 			password:      "password123",
-			mockUserError: errors.New("user not found"),
+			mockUserError: fmt.Errorf("failed to get user: %w", sql.ErrNoRows),
 			wantError:     true,
 		},
 		{
@@ -112,15 +117,22 @@ func TestLogin_Login(t *testing.T) {
 				},
 			}
 
+			mockRefreshTokens := &RefreshTokenIssuerMock{
+				IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+					return "mock-refresh-token", nil
+				},
+			}
+
 			// サービスインスタンスの作成
 			loginService := &Login{
 				Repo:           mockUserGetter,
 				TokenGenerator: mockTokenGenerator,
+				RefreshTokens:  mockRefreshTokens,
 			}
 
 			// テスト実行
 			ctx := context.Background()
-			gotToken, err := loginService.Login(ctx, tt.userName, tt.password)
+			gotToken, err := loginService.Login(ctx, tt.userName, tt.password, "192.0.2.1")
 
 			// 結果の検証
 			if tt.wantError {
@@ -135,8 +147,11 @@ func TestLogin_Login(t *testing.T) {
 				return
 			}
 
-			if gotToken != tt.wantToken {
-				t.Errorf("Login() got token = %v, want %v", gotToken, tt.wantToken)
+			if gotToken.Tokens.AccessToken != tt.wantToken {
+				t.Errorf("Login() got token = %v, want %v", gotToken.Tokens.AccessToken, tt.wantToken)
+			}
+			if gotToken.Tokens.RefreshToken != "mock-refresh-token" {
+				t.Errorf("Login() got refresh token = %v, want %v", gotToken.Tokens.RefreshToken, "mock-refresh-token")
 			}
 
 			// モックの呼び出し回数を検証
@@ -150,3 +165,293 @@ func TestLogin_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestLogin_Login_Throttle(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: 1, Name: "alice", Password: "password123", Role: "user"}
+	if err := user.HashPassword(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		userName    string
+		password    string
+		checkErr    error
+		wantErr     error
+		wantFail    bool
+		wantSucceed bool
+	}{
+		"ok":            {userName: "alice", password: "password123", wantSucceed: true},
+		"wrongPassword": {userName: "alice", password: "wrong", wantErr: ErrInvalidCredentials, wantFail: true},
+		"unknownUser":   {userName: "bob", password: "password123", wantErr: ErrInvalidCredentials, wantFail: true},
+		// IDプロバイダーで作ったユーザーはパスワードのハッシュがないが、ほかのユーザーと同じく401にする
+		"oidcUser": {userName: "carol", password: "", wantErr: ErrInvalidCredentials, wantFail: true},
+		"throttled": {
+			userName: "alice", password: "password123",
+			checkErr: &RateLimitError{RetryAfter: time.Minute},
+			wantErr:  ErrTooManyRequests,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &UserGetterMock{
+				GetUserFunc: func(ctx context.Context, db store.Queryer, userName string) (*entity.User, error) {
+					switch userName {
+					case "alice":
+						return user, nil
+					case "carol":
+						return &entity.User{ID: 3, Name: "carol", Role: "user"}, nil
+					}
+					return nil, fmt.Errorf("failed to get user: %w", sql.ErrNoRows)
+				},
+			}
+			throttle := &LoginThrottlerMock{
+				CheckFunc: func(ctx context.Context, userName, clientIP string) error {
+					return tt.checkErr
+				},
+				FailFunc: func(ctx context.Context, userName, clientIP string) error {
+					if userName != tt.userName || clientIP != "192.0.2.1" {
+						t.Errorf("unexpected failure of %q from %q", userName, clientIP)
+					}
+					return nil
+				},
+				SucceedFunc: func(ctx context.Context, userName string) error {
+					return nil
+				},
+			}
+			sut := &Login{
+				Repo: repo,
+				TokenGenerator: &TokenGeneratorMock{
+					GenerateTokenFunc: func(ctx context.Context, user entity.User) ([]byte, error) {
+						return []byte("token"), nil
+					},
+				},
+				RefreshTokens: &RefreshTokenIssuerMock{
+					IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+						return "refresh", nil
+					},
+				},
+				Throttle: throttle,
+			}
+
+			_, err := sut.Login(context.Background(), tt.userName, tt.password, "192.0.2.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want %v, but got %v", tt.wantErr, err)
+			}
+			if tt.checkErr != nil && len(repo.GetUserCalls()) != 0 {
+				t.Errorf("must not check the password while throttled")
+			}
+			if got := len(throttle.FailCalls()) == 1; got != tt.wantFail {
+				t.Errorf("want failure recorded %v, but got %v", tt.wantFail, got)
+			}
+			if got := len(throttle.SucceedCalls()) == 1; got != tt.wantSucceed {
+				t.Errorf("want success recorded %v, but got %v", tt.wantSucceed, got)
+			}
+		})
+	}
+}
+
+// TestDummyPasswordHash はユーザーがいないときに比べるハッシュが、ユーザーのパスワードと同じコストなことを確かめる
+func TestDummyPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	if err != nil {
+		t.Fatalf("want a bcrypt hash, but got %v", err)
+	}
+	if cost != bcrypt.DefaultCost {
+		t.Errorf("want cost %d like user passwords, but got %d", bcrypt.DefaultCost, cost)
+	}
+}
+
+func TestLogin_Login_MFA(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: 1, Name: "admin", Password: "password123", Role: "admin"}
+	if err := user.HashPassword(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		enabled, required bool
+		wantChallenge     *entity.MFAChallenge
+	}{
+		"notEnrolled": {},
+		"enabled":     {enabled: true, wantChallenge: &entity.MFAChallenge{}},
+		"required":    {required: true, wantChallenge: &entity.MFAChallenge{EnrollmentRequired: true}},
+		"both":        {enabled: true, required: true, wantChallenge: &entity.MFAChallenge{}},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var savedKey string
+			challenges := &MFAChallengeStoreMock{
+				SaveMFAChallengeFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+					if userID != user.ID || ttl != 5*time.Minute {
+						t.Errorf("want user %d and ttl 5m, but got %d and %v", user.ID, userID, ttl)
+					}
+					savedKey = key
+					return nil
+				},
+			}
+			sut := &Login{
+				Repo: &UserGetterMock{
+					GetUserFunc: func(ctx context.Context, db store.Queryer, userName string) (*entity.User, error) {
+						return user, nil
+					},
+				},
+				TokenGenerator: &TokenGeneratorMock{
+					GenerateTokenFunc: func(ctx context.Context, user entity.User) ([]byte, error) {
+						return []byte("access"), nil
+					},
+				},
+				RefreshTokens: &RefreshTokenIssuerMock{
+					IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+						return "refresh", nil
+					},
+				},
+				MFA: &MFAVerifierMock{
+					MFAStatusFunc: func(ctx context.Context, user *entity.User) (bool, bool, error) {
+						return tt.enabled, tt.required, nil
+					},
+				},
+				Challenges:   challenges,
+				ChallengeTTL: 5 * time.Minute,
+			}
+
+			got, err := sut.Login(context.Background(), "admin", "password123", "192.0.2.1")
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if tt.wantChallenge == nil {
+				if got.Challenge != nil || got.Tokens == nil {
+					t.Errorf("want tokens without challenge, but got %+v", got)
+				}
+				return
+			}
+			if got.Tokens != nil || got.Challenge == nil {
+				t.Fatalf("want challenge without tokens, but got %+v", got)
+			}
+			if got.Challenge.EnrollmentRequired != tt.wantChallenge.EnrollmentRequired {
+				t.Errorf("want enrollment required %v, but got %v", tt.wantChallenge.EnrollmentRequired, got.Challenge.EnrollmentRequired)
+			}
+			// KVSには生のトークンではなくハッシュを保存する
+			if got.Challenge.MFAToken == "" || savedKey != hashToken(got.Challenge.MFAToken) {
+				t.Errorf("want the hash of the mfa token as the key, but got %q", savedKey)
+			}
+		})
+	}
+}
+
+func TestLogin_LoginMFA(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: 1, Name: "admin", Role: "admin"}
+	tests := map[string]struct {
+		attemptErr    error
+		attempts      int64
+		verifyErr     error
+		recoveryCodes []string
+		wantDelete    bool
+		wantErr       error
+	}{
+		"ok": {
+			attempts:   1,
+			wantDelete: true,
+		},
+		"enrolledDuringLogin": {
+			attempts:      1,
+			recoveryCodes: []string{"aaaaa-bbbbb"},
+			wantDelete:    true,
+		},
+		"invalidToken": {
+			attemptErr: store.ErrNotFound,
+			wantErr:    ErrInvalidMFAToken,
+		},
+		"wrongCode": {
+			attempts:  1,
+			verifyErr: ErrInvalidMFACode,
+			wantErr:   ErrInvalidMFACode,
+		},
+		"tooManyAttempts": {
+			attempts:   MaxMFAAttempts + 1,
+			wantDelete: true,
+			wantErr:    ErrInvalidMFAToken,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			challenges := &MFAChallengeStoreMock{
+				AttemptMFAChallengeFunc: func(ctx context.Context, key string) (entity.UserID, int64, error) {
+					if key != hashToken("mfa-token") {
+						t.Errorf("want the hash of the mfa token, but got %q", key)
+					}
+					return user.ID, tt.attempts, tt.attemptErr
+				},
+				DeleteMFAChallengeFunc: func(ctx context.Context, key string) error {
+					return nil
+				},
+			}
+			mfa := &MFAVerifierMock{
+				VerifyMFAFunc: func(ctx context.Context, u *entity.User, code, recoveryCode string) ([]string, error) {
+					if u.ID != user.ID || code != "123456" {
+						t.Errorf("want user %d and code 123456, but got %d and %q", user.ID, u.ID, code)
+					}
+					return tt.recoveryCodes, tt.verifyErr
+				},
+			}
+			sut := &Login{
+				Users: &UserByIDGetterMock{
+					GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+						return user, nil
+					},
+				},
+				TokenGenerator: &TokenGeneratorMock{
+					GenerateTokenFunc: func(ctx context.Context, user entity.User) ([]byte, error) {
+						return []byte("access"), nil
+					},
+				},
+				RefreshTokens: &RefreshTokenIssuerMock{
+					IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+						return "refresh", nil
+					},
+				},
+				MFA:        mfa,
+				Challenges: challenges,
+			}
+
+			got, err := sut.LoginMFA(context.Background(), "mfa-token", "123456", "")
+			if deleted := len(challenges.DeleteMFAChallengeCalls()) > 0; deleted != tt.wantDelete {
+				t.Errorf("want challenge deleted %v, but got %v", tt.wantDelete, deleted)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				if tt.attempts > MaxMFAAttempts && len(mfa.VerifyMFACalls()) != 0 {
+					t.Errorf("must not verify the code after too many attempts")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			want := &entity.LoginResult{
+				Tokens:        &entity.Tokens{AccessToken: "access", RefreshToken: "refresh"},
+				RecoveryCodes: tt.recoveryCodes,
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// LoginThrottle はパスワードの総当たりを防ぐ。ユーザー名とIPアドレスごとに直近Windowの失敗を数える。
+// DelayAfter回を超えて失敗したユーザー名は、BaseDelayから失敗のたびに倍にした時間、MaxDelayまで待たないと試せない。
+// UserLockout回失敗したユーザー名とIPLockout回失敗したIPアドレスは、LockoutDurationの間ロックして記録を残す
+type LoginThrottle struct {
+	DB      store.Execer
+	Repo    LoginLockoutRecorder
+	Store   LoginFailureStore
+	Clocker clock.Clocker
+
+	Window          time.Duration
+	DelayAfter      int64
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	UserLockout     int64
+	IPLockout       int64
+	LockoutDuration time.Duration
+}
+
+// userThrottleKey は存在しないユーザー名でもキーの長さが決まるよう、ユーザー名のハッシュを使う
+func userThrottleKey(userName string) string {
+	return "user:" + hashToken(userName)
+}
+
+func ipThrottleKey(clientIP string) string {
+	return "ip:" + clientIP
+}
+
+// Check はパスワードを確かめる前に呼ぶ。ロック中か、前の失敗から待つ時間が経っていなければRateLimitErrorを返す。
+// ユーザー名の試行は、確かめると同時に失敗として数えておく。同時に送られたリクエストが、
+// どれも前の失敗だけを見て待つ時間やロックをすり抜けないようにするため
+func (t *LoginThrottle) Check(ctx context.Context, userName, clientIP string) error {
+	now := t.Clocker.Now()
+	_, _, locked, err := t.Store.LoginFailures(ctx, ipThrottleKey(clientIP), t.Window, now)
+	if err != nil {
+		return fmt.Errorf("failed to get login failures: %w", err)
+	}
+	if locked > 0 {
+		return &RateLimitError{RetryAfter: locked}
+	}
+	wait, err := t.Store.ReserveLoginAttempt(ctx, userThrottleKey(userName), t.Window, now, t.delays(), t.UserLockout, t.LockoutDuration)
+	if err != nil {
+		return fmt.Errorf("failed to reserve login attempt: %w", err)
+	}
+	if wait > 0 {
+		return &RateLimitError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// Fail はパスワードの失敗を記録し、上限に達したユーザー名やIPアドレスのロックを記録する。
+// ユーザー名の失敗はCheckで数えてあるので、Checkがかけたロックを記録するだけにする
+func (t *LoginThrottle) Fail(ctx context.Context, userName, clientIP string) error {
+	now := t.Clocker.Now()
+	n, lockedFor, locked, err := t.Store.TakeLoginLockout(ctx, userThrottleKey(userName))
+	if err != nil {
+		return fmt.Errorf("failed to take login lockout: %w", err)
+	}
+	if locked {
+		if err := t.lockout(ctx, entity.LoginLockoutScopeUser, userName, n, now, lockedFor); err != nil {
+			return err
+		}
+	}
+
+	n, locked, err = t.Store.AddLoginFailure(ctx, ipThrottleKey(clientIP), t.Window, now, t.IPLockout, t.LockoutDuration)
+	if err != nil {
+		return fmt.Errorf("failed to add login failure: %w", err)
+	}
+	if locked {
+		return t.lockout(ctx, entity.LoginLockoutScopeIP, clientIP, n, now, t.LockoutDuration)
+	}
+	return nil
+}
+
+// lockout はロックしたユーザー名やIPアドレスを記録する
+func (t *LoginThrottle) lockout(
+	ctx context.Context, scope, subject string, failures int64, now time.Time, d time.Duration,
+) error {
+	log.Printf("locked out login of %s %q for %v after %d failures", scope, subject, d, failures)
+	l := &entity.LoginLockout{
+		Scope:       scope,
+		Subject:     subject,
+		Failures:    failures,
+		LockedUntil: now.Add(d),
+	}
+	if err := t.Repo.AddLoginLockout(ctx, t.DB, l); err != nil {
+		return fmt.Errorf("failed to record login lockout: %w", err)
+	}
+	return nil
+}
+
+// Succeed はログインに成功したユーザー名の失敗とロックを消す。IPアドレスの失敗は他のユーザーへの試行もあるので残す
+func (t *LoginThrottle) Succeed(ctx context.Context, userName string) error {
+	if err := t.Store.ClearLoginFailures(ctx, userThrottleKey(userName)); err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+	return nil
+}
+
+// delays はdelay(1)、delay(2)、...を、それ以上失敗しても待つ時間が変わらなくなるまで並べる
+func (t *LoginThrottle) delays() []time.Duration {
+	var ds []time.Duration
+	for n := int64(1); ; n++ {
+		d := t.delay(n)
+		ds = append(ds, d)
+		if n > t.DelayAfter && t.delay(n+1) == d {
+			return ds
+		}
+	}
+}
+
+// delay はfailures回失敗したユーザー名が、最後の失敗から待つ時間
+func (t *LoginThrottle) delay(failures int64) time.Duration {
+	over := failures - t.DelayAfter
+	if over <= 0 {
+		return 0
+	}
+	// 桁あふれしないよう、MaxDelayを超えたら倍にするのをやめる
+	d := t.BaseDelay
+	for i := int64(1); i < over && d < t.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > t.MaxDelay {
+		return t.MaxDelay
+	}
+	return d
+}
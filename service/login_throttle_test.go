@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func newTestLoginThrottle(s LoginFailureStore, r LoginLockoutRecorder, now time.Time) *LoginThrottle {
+	return &LoginThrottle{
+		Repo:            r,
+		Store:           s,
+		Clocker:         clock.NewManualClocker(now),
+		Window:          15 * time.Minute,
+		DelayAfter:      3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		UserLockout:     10,
+		IPLockout:       100,
+		LockoutDuration: 15 * time.Minute,
+	}
+}
+
+func TestLoginThrottle_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		userWait time.Duration
+		ipLocked time.Duration
+		want     time.Duration
+	}{
+		"reserved":   {},
+		"userWaits":  {userWait: 800 * time.Millisecond, want: 800 * time.Millisecond},
+		"userLocked": {userWait: 14 * time.Minute, want: 14 * time.Minute},
+		"ipLocked":   {ipLocked: 5 * time.Minute, want: 5 * time.Minute},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			s := &LoginFailureStoreMock{
+				LoginFailuresFunc: func(ctx context.Context, key string, window time.Duration, at time.Time) (int64, time.Time, time.Duration, error) {
+					if key != ipThrottleKey("192.0.2.1") {
+						t.Errorf("unexpected key %q", key)
+					}
+					return 0, time.Time{}, tt.ipLocked, nil
+				},
+				ReserveLoginAttemptFunc: func(
+					ctx context.Context, key string, window time.Duration, at time.Time,
+					delays []time.Duration, lockAfter int64, lockFor time.Duration,
+				) (time.Duration, error) {
+					if key != userThrottleKey("alice") || !at.Equal(now) || lockAfter != 10 || lockFor != 15*time.Minute {
+						t.Errorf("unexpected reservation of %q at %v locking after %d for %v", key, at, lockAfter, lockFor)
+					}
+					want := []time.Duration{0, 0, 0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second}
+					if !cmp.Equal(delays, want) {
+						t.Errorf("want delays %v, but got %v", want, delays)
+					}
+					return tt.userWait, nil
+				},
+			}
+			sut := newTestLoginThrottle(s, nil, now)
+
+			err := sut.Check(context.Background(), "alice", "192.0.2.1")
+			if tt.ipLocked > 0 && len(s.ReserveLoginAttemptCalls()) != 0 {
+				t.Errorf("must not reserve an attempt from a locked ip")
+			}
+			if tt.want == 0 {
+				if err != nil {
+					t.Errorf("want no error, but got %v", err)
+				}
+				return
+			}
+			var rle *RateLimitError
+			if !errors.As(err, &rle) || rle.RetryAfter != tt.want {
+				t.Errorf("want retry after %v, but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoginThrottle_delays(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		delayAfter          int64
+		baseDelay, maxDelay time.Duration
+		want                []time.Duration
+	}{
+		"doubledUntilMax": {
+			delayAfter: 1, baseDelay: time.Second, maxDelay: 5 * time.Second,
+			want: []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+		},
+		"noDelay": {
+			delayAfter: 2,
+			want:       []time.Duration{0, 0, 0},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			sut := &LoginThrottle{DelayAfter: tt.delayAfter, BaseDelay: tt.baseDelay, MaxDelay: tt.maxDelay}
+			if got := sut.delays(); !cmp.Equal(got, tt.want) {
+				t.Errorf("want %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoginThrottle_Fail(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		lockUser, lockIP bool
+		want             []*entity.LoginLockout
+	}{
+		"notLocked": {},
+		"userLocked": {
+			lockUser: true,
+			want: []*entity.LoginLockout{
+				{Scope: entity.LoginLockoutScopeUser, Subject: "alice", Failures: 10, LockedUntil: now.Add(15 * time.Minute)},
+			},
+		},
+		"bothLocked": {
+			lockUser: true, lockIP: true,
+			want: []*entity.LoginLockout{
+				{Scope: entity.LoginLockoutScopeUser, Subject: "alice", Failures: 10, LockedUntil: now.Add(15 * time.Minute)},
+				{Scope: entity.LoginLockoutScopeIP, Subject: "192.0.2.1", Failures: 100, LockedUntil: now.Add(15 * time.Minute)},
+			},
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			s := &LoginFailureStoreMock{
+				TakeLoginLockoutFunc: func(ctx context.Context, key string) (int64, time.Duration, bool, error) {
+					if key != userThrottleKey("alice") {
+						t.Errorf("unexpected key %q", key)
+					}
+					if !tt.lockUser {
+						return 0, 0, false, nil
+					}
+					return 10, 15 * time.Minute, true, nil
+				},
+				AddLoginFailureFunc: func(ctx context.Context, key string, window time.Duration, at time.Time, lockAfter int64, lockFor time.Duration) (int64, bool, error) {
+					if !at.Equal(now) || lockFor != 15*time.Minute {
+						t.Errorf("unexpected failure at %v locking for %v", at, lockFor)
+					}
+					if key != ipThrottleKey("192.0.2.1") {
+						t.Errorf("unexpected key %q", key)
+					}
+					return lockAfter, tt.lockIP, nil
+				},
+			}
+			var got []*entity.LoginLockout
+			r := &LoginLockoutRecorderMock{
+				AddLoginLockoutFunc: func(ctx context.Context, db store.Execer, l *entity.LoginLockout) error {
+					got = append(got, l)
+					return nil
+				},
+			}
+			sut := newTestLoginThrottle(s, r, now)
+
+			if err := sut.Fail(context.Background(), "alice", "192.0.2.1"); err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			// ユーザー名の失敗はCheckで数えてあるので、IPアドレスの失敗だけを記録する
+			if len(s.AddLoginFailureCalls()) != 1 {
+				t.Errorf("want a failure recorded for the ip, but got %d", len(s.AddLoginFailureCalls()))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d lockouts, but got %d", len(tt.want), len(got))
+			}
+			for i := range got {
+				if *got[i] != *tt.want[i] {
+					t.Errorf("want %+v, but got %+v", tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// Logout はアクセストークンとリフレッシュトークンを失効させる
+type Logout struct {
+	Tokens        TokenRevoker
+	RefreshTokens RefreshTokenRevoker
+}
+
+// Logout はリクエストの認証に使ったトークンを失効させる。
+// refreshTokenが空でなければ、そのリフレッシュトークンも同じFamilyごと失効させる
+func (l *Logout) Logout(ctx context.Context, refreshToken string) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	jti, ok := auth.GetTokenID(ctx)
+	if !ok {
+		return fmt.Errorf("token id not found")
+	}
+	if refreshToken != "" {
+		if err := l.RefreshTokens.RevokeRefreshToken(ctx, userID, refreshToken); err != nil {
+			return err
+		}
+	}
+	return l.Tokens.RevokeToken(ctx, jti)
+}
+
+// LogoutAll はログイン中のユーザーのすべてのセッションを失効させる
+func (l *Logout) LogoutAll(ctx context.Context) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	return l.RevokeSessions(ctx, userID)
+}
+
+// RevokeSessions はユーザーのすべてのアクセストークンとリフレッシュトークンを失効させる。
+// 管理者が他のユーザーのセッションを強制的に終わらせるのにも使う
+func (l *Logout) RevokeSessions(ctx context.Context, userID entity.UserID) error {
+	// 先にリフレッシュトークンを消し、アクセストークンを再発行できないようにする
+	if err := l.RefreshTokens.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	return l.Tokens.RevokeUserTokens(ctx, userID)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func TestLogout_Logout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		refreshToken    string
+		wantRefreshCall int
+	}{
+		"accessTokenOnly":  {},
+		"withRefreshToken": {refreshToken: "refresh", wantRefreshCall: 1},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			tokens := &TokenRevokerMock{
+				RevokeTokenFunc: func(ctx context.Context, jti string) error {
+					if jti != "jti-1" {
+						t.Errorf("want jti-1, but got %s", jti)
+					}
+					return nil
+				},
+			}
+			refresh := &RefreshTokenRevokerMock{
+				RevokeRefreshTokenFunc: func(ctx context.Context, userID entity.UserID, token string) error {
+					if userID != 1 || token != tt.refreshToken {
+						t.Errorf("unexpected revocation of %s for user %d", token, userID)
+					}
+					return nil
+				},
+			}
+			sut := &Logout{Tokens: tokens, RefreshTokens: refresh}
+
+			ctx := auth.SetTokenID(auth.SetUserID(context.Background(), 1), "jti-1")
+			if err := sut.Logout(ctx, tt.refreshToken); err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got := len(tokens.RevokeTokenCalls()); got != 1 {
+				t.Errorf("RevokeToken was called %d times, want 1", got)
+			}
+			if got := len(refresh.RevokeRefreshTokenCalls()); got != tt.wantRefreshCall {
+				t.Errorf("RevokeRefreshToken was called %d times, want %d", got, tt.wantRefreshCall)
+			}
+		})
+	}
+}
+
+func TestLogout_RevokeSessions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	tokens := &TokenRevokerMock{
+		RevokeUserTokensFunc: func(ctx context.Context, userID entity.UserID) error {
+			if userID != 2 {
+				t.Errorf("want user 2, but got %d", userID)
+			}
+			calls = append(calls, "access")
+			return nil
+		},
+	}
+	refresh := &RefreshTokenRevokerMock{
+		RevokeUserRefreshTokensFunc: func(ctx context.Context, userID entity.UserID) error {
+			if userID != 2 {
+				t.Errorf("want user 2, but got %d", userID)
+			}
+			calls = append(calls, "refresh")
+			return nil
+		},
+	}
+	sut := &Logout{Tokens: tokens, RefreshTokens: refresh}
+
+	if err := sut.LogoutAll(auth.SetUserID(context.Background(), 2)); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "refresh" || calls[1] != "access" {
+		t.Errorf("want refresh tokens revoked before access tokens, but got %v", calls)
+	}
+
+	// リフレッシュトークンを消せなければアクセストークンは残す
+	refresh.RevokeUserRefreshTokensFunc = func(ctx context.Context, userID entity.UserID) error {
+		return errors.New("redis is down")
+	}
+	calls = nil
+	if err := sut.RevokeSessions(context.Background(), 2); err == nil {
+		t.Errorf("want error, but got nil")
+	}
+	if len(calls) != 0 {
+		t.Errorf("want no access token revocation, but got %v", calls)
+	}
+}
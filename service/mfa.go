@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/totp"
+)
+
+var (
+	// ErrInvalidMFACode は認証アプリのコードやリカバリーコードが違う、または使用済みのときのエラー
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFAAlreadyEnabled は2要素認証を登録済みなのに登録しようとしたときのエラー
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrMFANotEnrolled はTOTPの登録を始めていないのに確認しようとしたときのエラー
+	ErrMFANotEnrolled = errors.New("two-factor authentication is not enrolled")
+)
+
+const (
+	// totpSkew は時計のずれを考えて、前後に受け付けるタイムステップの数
+	totpSkew = 1
+	// recoveryCodeCount は登録を確認したときに発行するリカバリーコードの数
+	recoveryCodeCount = 10
+)
+
+// MFA はTOTPの2要素認証の登録と検証、2要素認証を必須にするロールを扱う
+type MFA struct {
+	DB      *sqlx.DB
+	Repo    MFARepository
+	Clocker clock.Clocker
+	// Issuer は認証アプリに表示するサービスの名前
+	Issuer string
+}
+
+// EnrollTOTP はログインしているユーザーのTOTPの登録を始める
+func (m *MFA) EnrollTOTP(ctx context.Context) (*entity.TOTPEnrollment, error) {
+	user, err := m.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.EnrollTOTPFor(ctx, user)
+}
+
+// ConfirmTOTP はログインしているユーザーの登録を認証アプリのコードで確認し、リカバリーコードを返す
+func (m *MFA) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	user, err := m.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.confirm(ctx, user.ID, code)
+}
+
+// EnrollTOTPFor はシークレットを作って確認前の状態で保存し、認証アプリに読み込ませるURIと一緒に返す。
+// 登録の途中ならシークレットを作り直す
+func (m *MFA) EnrollTOTPFor(ctx context.Context, user *entity.User) (*entity.TOTPEnrollment, error) {
+	current, err := m.Repo.GetUserMFA(ctx, m.DB, user.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if current.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Repo.SaveUserMFA(ctx, m.DB, &entity.UserMFA{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, fmt.Errorf("failed to save mfa: %w", err)
+	}
+	return &entity.TOTPEnrollment{Secret: secret, URI: totp.URI(m.Issuer, user.Name, secret)}, nil
+}
+
+// MFAStatus はユーザーが2要素認証を登録済みか、ロールで必須になっているかを返す
+func (m *MFA) MFAStatus(ctx context.Context, user *entity.User) (enabled, required bool, err error) {
+	current, err := m.Repo.GetUserMFA(ctx, m.DB, user.ID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return false, false, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	required, err = m.Repo.IsMFARequired(ctx, m.DB, user.Role)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get mfa policy: %w", err)
+	}
+	return current.Enabled(), required, nil
+}
+
+// VerifyMFA はログインの2段階目でコードかリカバリーコードを確かめる。
+// 登録を確認していなければ、コードで登録を確認してリカバリーコードを返す
+func (m *MFA) VerifyMFA(ctx context.Context, user *entity.User, code, recoveryCode string) ([]string, error) {
+	current, err := m.Repo.GetUserMFA(ctx, m.DB, user.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrMFANotEnrolled
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if !current.Enabled() {
+		return m.confirm(ctx, user.ID, code)
+	}
+
+	if recoveryCode != "" {
+		if err := m.Repo.UseRecoveryCode(ctx, m.DB, user.ID, hashRecoveryCode(recoveryCode)); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrInvalidMFACode
+			}
+			return nil, fmt.Errorf("failed to use recovery code: %w", err)
+		}
+		return nil, nil
+	}
+	step, ok, err := totp.Validate(current.Secret, code, m.Clocker.Now(), totpSkew)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	// 受け付けたコードより前のコードは、ずれの範囲内でも使えなくする
+	if err := m.Repo.UseTOTPStep(ctx, m.DB, user.ID, step); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidMFACode
+		}
+		return nil, fmt.Errorf("failed to use totp code: %w", err)
+	}
+	return nil, nil
+}
+
+// MFAPolicy は2要素認証を必須にするロールを返す
+func (m *MFA) MFAPolicy(ctx context.Context) (*entity.MFAPolicy, error) {
+	roles, err := m.Repo.ListMFARequiredRoles(ctx, m.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mfa required roles: %w", err)
+	}
+	return &entity.MFAPolicy{RequiredRoles: roles}, nil
+}
+
+// UpdateMFAPolicy は2要素認証を必須にするロールを置き換える。必須にしても登録済みのユーザーのセッションはそのまま使える
+func (m *MFA) UpdateMFAPolicy(ctx context.Context, policy *entity.MFAPolicy) (*entity.MFAPolicy, error) {
+	roles := make([]string, 0, len(policy.RequiredRoles))
+	seen := map[string]bool{}
+	for _, r := range policy.RequiredRoles {
+		if !seen[r] {
+			seen[r] = true
+			roles = append(roles, r)
+		}
+	}
+	sort.Strings(roles)
+
+	tx, err := m.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := m.Repo.SetMFARequiredRoles(ctx, tx, roles); err != nil {
+		return nil, fmt.Errorf("failed to set mfa required roles: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return &entity.MFAPolicy{RequiredRoles: roles}, nil
+}
+
+// confirm は登録の途中のシークレットをコードで確認し、リカバリーコードを発行し直す
+func (m *MFA) confirm(ctx context.Context, userID entity.UserID, code string) ([]string, error) {
+	current, err := m.Repo.GetUserMFA(ctx, m.DB, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrMFANotEnrolled
+		}
+		return nil, fmt.Errorf("failed to get mfa: %w", err)
+	}
+	if current.Enabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	step, ok, err := totp.Validate(current.Secret, code, m.Clocker.Now(), totpSkew)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := m.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := m.Repo.ConfirmUserMFA(ctx, tx, userID, step); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrMFAAlreadyEnabled
+		}
+		return nil, fmt.Errorf("failed to confirm mfa: %w", err)
+	}
+	if err := m.Repo.ReplaceRecoveryCodes(ctx, tx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return codes, nil
+}
+
+func (m *MFA) currentUser(ctx context.Context) (*entity.User, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	user, err := m.Repo.GetUserByID(ctx, m.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCodes は"xxxxx-xxxxx"の形のリカバリーコードと、保存するハッシュを作る
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		s := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+		code := s[:5] + "-" + s[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode は大文字小文字と区切りの"-"や空白を無視してハッシュにする
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/totp"
+)
+
+// testSecret はテストで使うTOTPのシークレット
+const testSecret = "JBSWY3DPEHPK3PXP"
+
+func totpCode(t *testing.T, at time.Time) string {
+	t.Helper()
+	c, err := totp.Code(testSecret, totp.Step(at))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMFA_EnrollTOTPFor(t *testing.T) {
+	t.Parallel()
+
+	confirmed := time.Now()
+	tests := map[string]struct {
+		current *entity.UserMFA
+		wantErr error
+	}{
+		"new":            {},
+		"pending":        {current: &entity.UserMFA{Secret: "OLD"}},
+		"alreadyEnabled": {current: &entity.UserMFA{Secret: "OLD", ConfirmedAt: &confirmed}, wantErr: ErrMFAAlreadyEnabled},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var saved *entity.UserMFA
+			repo := &MFARepositoryMock{
+				GetUserMFAFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+					if tt.current == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.current, nil
+				},
+				SaveUserMFAFunc: func(ctx context.Context, db store.Execer, m *entity.UserMFA) error {
+					saved = m
+					return nil
+				},
+			}
+			sut := &MFA{Repo: repo, Issuer: "go-handson01"}
+
+			got, err := sut.EnrollTOTPFor(context.Background(), &entity.User{ID: 1, Name: "alice"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				if saved != nil {
+					t.Errorf("must not replace the confirmed secret")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if saved == nil || saved.UserID != 1 || saved.Secret != got.Secret || saved.Secret == "OLD" {
+				t.Errorf("want a new secret saved for user 1, but got %+v", saved)
+			}
+			if got.URI != totp.URI("go-handson01", "alice", got.Secret) {
+				t.Errorf("unexpected uri %q", got.URI)
+			}
+		})
+	}
+}
+
+func TestMFA_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 15, 0, time.UTC)
+	confirmed := now.Add(-24 * time.Hour)
+	enabled := &entity.UserMFA{UserID: 1, Secret: testSecret, ConfirmedAt: &confirmed}
+	pending := &entity.UserMFA{UserID: 1, Secret: testSecret}
+
+	tests := map[string]struct {
+		current      *entity.UserMFA
+		code         string
+		recoveryCode string
+		// stepUsed はUseTOTPStepが、そのステップを使用済みとして扱うか
+		stepUsed       bool
+		recoveryUnused bool
+		wantStep       int64
+		wantCodes      bool
+		wantErr        error
+	}{
+		"currentCode": {
+			current:  enabled,
+			code:     totpCode(t, now),
+			wantStep: totp.Step(now),
+		},
+		"previousStep": {
+			current:  enabled,
+			code:     totpCode(t, now.Add(-totp.Period)),
+			wantStep: totp.Step(now) - 1,
+		},
+		"nextStep": {
+			current:  enabled,
+			code:     totpCode(t, now.Add(totp.Period)),
+			wantStep: totp.Step(now) + 1,
+		},
+		"tooOld": {
+			current: enabled,
+			code:    totpCode(t, now.Add(-2*totp.Period)),
+			wantErr: ErrInvalidMFACode,
+		},
+		"replayed": {
+			current:  enabled,
+			code:     totpCode(t, now),
+			stepUsed: true,
+			wantStep: totp.Step(now),
+			wantErr:  ErrInvalidMFACode,
+		},
+		"recoveryCode": {
+			current:        enabled,
+			recoveryCode:   "ABCDE-fghij",
+			recoveryUnused: true,
+		},
+		"usedRecoveryCode": {
+			current:      enabled,
+			recoveryCode: "abcde-fghij",
+			wantErr:      ErrInvalidMFACode,
+		},
+		"confirmPending": {
+			current:   pending,
+			code:      totpCode(t, now),
+			wantStep:  totp.Step(now),
+			wantCodes: true,
+		},
+		"notEnrolled": {
+			code:    totpCode(t, now),
+			wantErr: ErrMFANotEnrolled,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			if tt.wantCodes {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			}
+
+			var usedStep int64
+			var savedHashes []string
+			repo := &MFARepositoryMock{
+				GetUserMFAFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+					if tt.current == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.current, nil
+				},
+				UseTOTPStepFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+					usedStep = step
+					if tt.stepUsed {
+						return store.ErrNotFound
+					}
+					return nil
+				},
+				UseRecoveryCodeFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, hash string) error {
+					// 大文字小文字と区切りは無視する
+					if hash != hashRecoveryCode("abcdefghij") || !tt.recoveryUnused {
+						return store.ErrNotFound
+					}
+					return nil
+				},
+				ConfirmUserMFAFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+					usedStep = step
+					return nil
+				},
+				ReplaceRecoveryCodesFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, hashes []string) error {
+					savedHashes = hashes
+					return nil
+				},
+			}
+			sut := &MFA{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Clocker: clock.NewManualClocker(now)}
+
+			codes, err := sut.VerifyMFA(context.Background(), &entity.User{ID: 1}, tt.code, tt.recoveryCode)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if usedStep != tt.wantStep {
+				t.Errorf("want step %d, but got %d", tt.wantStep, usedStep)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if !tt.wantCodes {
+				if codes != nil {
+					t.Errorf("want no recovery codes, but got %v", codes)
+				}
+				return
+			}
+			if len(codes) != recoveryCodeCount || len(savedHashes) != recoveryCodeCount {
+				t.Fatalf("want %d recovery codes, but got %d codes and %d hashes", recoveryCodeCount, len(codes), len(savedHashes))
+			}
+			format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+			for i, c := range codes {
+				if !format.MatchString(c) {
+					t.Errorf("unexpected recovery code %q", c)
+				}
+				if savedHashes[i] != hashRecoveryCode(c) {
+					t.Errorf("want the hash of %q to be saved", c)
+				}
+			}
+		})
+	}
+}
+
+func TestMFA_MFAStatus(t *testing.T) {
+	t.Parallel()
+
+	confirmed := time.Now()
+	repo := &MFARepositoryMock{
+		GetUserMFAFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+			if userID == 1 {
+				return &entity.UserMFA{ConfirmedAt: &confirmed}, nil
+			}
+			return nil, store.ErrNotFound
+		},
+		IsMFARequiredFunc: func(ctx context.Context, db store.Queryer, role string) (bool, error) {
+			return role == "admin", nil
+		},
+	}
+	sut := &MFA{Repo: repo}
+
+	tests := map[string]struct {
+		user                      *entity.User
+		wantEnabled, wantRequired bool
+	}{
+		"enabledUser":       {user: &entity.User{ID: 1, Role: "user"}, wantEnabled: true},
+		"adminNotEnrolled":  {user: &entity.User{ID: 2, Role: "admin"}, wantRequired: true},
+		"userNotEnrolled":   {user: &entity.User{ID: 3, Role: "user"}},
+		"adminWithEnrolled": {user: &entity.User{ID: 1, Role: "admin"}, wantEnabled: true, wantRequired: true},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			enabled, required, err := sut.MFAStatus(context.Background(), tt.user)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if enabled != tt.wantEnabled || required != tt.wantRequired {
+				t.Errorf("want %v %v, but got %v %v", tt.wantEnabled, tt.wantRequired, enabled, required)
+			}
+		})
+	}
+}
+
+func TestMFA_UpdateMFAPolicy(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	var saved []string
+	repo := &MFARepositoryMock{
+		SetMFARequiredRolesFunc: func(ctx context.Context, db store.Execer, roles []string) error {
+			saved = roles
+			return nil
+		},
+	}
+	sut := &MFA{DB: sqlx.NewDb(db, "mysql"), Repo: repo}
+
+	got, err := sut.UpdateMFAPolicy(context.Background(), &entity.MFAPolicy{RequiredRoles: []string{"user", "admin", "user"}})
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	want := []string{"admin", "user"}
+	if diff := cmp.Diff(want, saved); diff != "" {
+		t.Errorf("saved roles (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, got.RequiredRoles); diff != "" {
+		t.Errorf("policy (-want +got):\n%s", diff)
+	}
+}
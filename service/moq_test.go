@@ -7,7 +7,9 @@ import (
 	"context"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 	"sync"
+	"time"
 )
 
 // Ensure, that TaskAdderMock does implement TaskAdder.
@@ -23,6 +25,12 @@ var _ TaskAdder = &TaskAdderMock{}
 //			AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
 //				panic("mock out the AddTask method")
 //			},
+//			CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+//				panic("mock out the CountColumnTasks method")
+//			},
+//			GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+//				panic("mock out the GetDefaultColumnForUpdate method")
+//			},
 //		}
 //
 //		// use mockedTaskAdder in code that requires TaskAdder
@@ -33,6 +41,12 @@ type TaskAdderMock struct {
 	// AddTaskFunc mocks the AddTask method.
 	AddTaskFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
 
+	// CountColumnTasksFunc mocks the CountColumnTasks method.
+	CountColumnTasksFunc func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+
+	// GetDefaultColumnForUpdateFunc mocks the GetDefaultColumnForUpdate method.
+	GetDefaultColumnForUpdateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AddTask holds details about calls to the AddTask method.
@@ -44,8 +58,32 @@ type TaskAdderMock struct {
 			// T is the t argument value.
 			T *entity.Task
 		}
+		// CountColumnTasks holds details about calls to the CountColumnTasks method.
+		CountColumnTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// C is the c argument value.
+			C *entity.Column
+			// Exclude is the exclude argument value.
+			Exclude entity.TaskID
+		}
+		// GetDefaultColumnForUpdate holds details about calls to the GetDefaultColumnForUpdate method.
+		GetDefaultColumnForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
 	}
-	lockAddTask sync.RWMutex
+	lockAddTask                   sync.RWMutex
+	lockCountColumnTasks          sync.RWMutex
+	lockGetDefaultColumnForUpdate sync.RWMutex
 }
 
 // AddTask calls AddTaskFunc.
@@ -88,6 +126,94 @@ func (mock *TaskAdderMock) AddTaskCalls() []struct {
 	return calls
 }
 
+// CountColumnTasks calls CountColumnTasksFunc.
+func (mock *TaskAdderMock) CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+	if mock.CountColumnTasksFunc == nil {
+		panic("TaskAdderMock.CountColumnTasksFunc: method is nil but TaskAdder.CountColumnTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		C:       c,
+		Exclude: exclude,
+	}
+	mock.lockCountColumnTasks.Lock()
+	mock.calls.CountColumnTasks = append(mock.calls.CountColumnTasks, callInfo)
+	mock.lockCountColumnTasks.Unlock()
+	return mock.CountColumnTasksFunc(ctx, db, c, exclude)
+}
+
+// CountColumnTasksCalls gets all the calls that were made to CountColumnTasks.
+// Check the length with:
+//
+//	len(mockedTaskAdder.CountColumnTasksCalls())
+func (mock *TaskAdderMock) CountColumnTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	C       *entity.Column
+	Exclude entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}
+	mock.lockCountColumnTasks.RLock()
+	calls = mock.calls.CountColumnTasks
+	mock.lockCountColumnTasks.RUnlock()
+	return calls
+}
+
+// GetDefaultColumnForUpdate calls GetDefaultColumnForUpdateFunc.
+func (mock *TaskAdderMock) GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+	if mock.GetDefaultColumnForUpdateFunc == nil {
+		panic("TaskAdderMock.GetDefaultColumnForUpdateFunc: method is nil but TaskAdder.GetDefaultColumnForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockGetDefaultColumnForUpdate.Lock()
+	mock.calls.GetDefaultColumnForUpdate = append(mock.calls.GetDefaultColumnForUpdate, callInfo)
+	mock.lockGetDefaultColumnForUpdate.Unlock()
+	return mock.GetDefaultColumnForUpdateFunc(ctx, db, userID, status)
+}
+
+// GetDefaultColumnForUpdateCalls gets all the calls that were made to GetDefaultColumnForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskAdder.GetDefaultColumnForUpdateCalls())
+func (mock *TaskAdderMock) GetDefaultColumnForUpdateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}
+	mock.lockGetDefaultColumnForUpdate.RLock()
+	calls = mock.calls.GetDefaultColumnForUpdate
+	mock.lockGetDefaultColumnForUpdate.RUnlock()
+	return calls
+}
+
 // Ensure, that TaskListerMock does implement TaskLister.
 // If this is not the case, regenerate this file with moq.
 var _ TaskLister = &TaskListerMock{}
@@ -98,9 +224,18 @@ var _ TaskLister = &TaskListerMock{}
 //
 //		// make and configure a mocked TaskLister
 //		mockedTaskLister := &TaskListerMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			ListAssignedTasksFunc: func(ctx context.Context, db store.Queryer, assigneeID entity.UserID) (entity.Tasks, error) {
+//				panic("mock out the ListAssignedTasks method")
+//			},
 //			ListTasksFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Tasks, error) {
 //				panic("mock out the ListTasks method")
 //			},
+//			SearchTasksFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, q taskquery.Node, loc *time.Location) (entity.Tasks, error) {
+//				panic("mock out the SearchTasks method")
+//			},
 //		}
 //
 //		// use mockedTaskLister in code that requires TaskLister
@@ -108,11 +243,38 @@ var _ TaskLister = &TaskListerMock{}
 //
 //	}
 type TaskListerMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// ListAssignedTasksFunc mocks the ListAssignedTasks method.
+	ListAssignedTasksFunc func(ctx context.Context, db store.Queryer, assigneeID entity.UserID) (entity.Tasks, error)
+
 	// ListTasksFunc mocks the ListTasks method.
 	ListTasksFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Tasks, error)
 
+	// SearchTasksFunc mocks the SearchTasks method.
+	SearchTasksFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, q taskquery.Node, loc *time.Location) (entity.Tasks, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// ListAssignedTasks holds details about calls to the ListAssignedTasks method.
+		ListAssignedTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// AssigneeID is the assigneeID argument value.
+			AssigneeID entity.UserID
+		}
 		// ListTasks holds details about calls to the ListTasks method.
 		ListTasks []struct {
 			// Ctx is the ctx argument value.
@@ -122,8 +284,104 @@ type TaskListerMock struct {
 			// UserID is the userID argument value.
 			UserID entity.UserID
 		}
+		// SearchTasks holds details about calls to the SearchTasks method.
+		SearchTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Q is the q argument value.
+			Q taskquery.Node
+			// Loc is the loc argument value.
+			Loc *time.Location
+		}
+	}
+	lockGetUserByID       sync.RWMutex
+	lockListAssignedTasks sync.RWMutex
+	lockListTasks         sync.RWMutex
+	lockSearchTasks       sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *TaskListerMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("TaskListerMock.GetUserByIDFunc: method is nil but TaskLister.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedTaskLister.GetUserByIDCalls())
+func (mock *TaskListerMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// ListAssignedTasks calls ListAssignedTasksFunc.
+func (mock *TaskListerMock) ListAssignedTasks(ctx context.Context, db store.Queryer, assigneeID entity.UserID) (entity.Tasks, error) {
+	if mock.ListAssignedTasksFunc == nil {
+		panic("TaskListerMock.ListAssignedTasksFunc: method is nil but TaskLister.ListAssignedTasks was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		AssigneeID entity.UserID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		AssigneeID: assigneeID,
 	}
-	lockListTasks sync.RWMutex
+	mock.lockListAssignedTasks.Lock()
+	mock.calls.ListAssignedTasks = append(mock.calls.ListAssignedTasks, callInfo)
+	mock.lockListAssignedTasks.Unlock()
+	return mock.ListAssignedTasksFunc(ctx, db, assigneeID)
+}
+
+// ListAssignedTasksCalls gets all the calls that were made to ListAssignedTasks.
+// Check the length with:
+//
+//	len(mockedTaskLister.ListAssignedTasksCalls())
+func (mock *TaskListerMock) ListAssignedTasksCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	AssigneeID entity.UserID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		AssigneeID entity.UserID
+	}
+	mock.lockListAssignedTasks.RLock()
+	calls = mock.calls.ListAssignedTasks
+	mock.lockListAssignedTasks.RUnlock()
+	return calls
 }
 
 // ListTasks calls ListTasksFunc.
@@ -166,6 +424,54 @@ func (mock *TaskListerMock) ListTasksCalls() []struct {
 	return calls
 }
 
+// SearchTasks calls SearchTasksFunc.
+func (mock *TaskListerMock) SearchTasks(ctx context.Context, db store.Queryer, userID entity.UserID, q taskquery.Node, loc *time.Location) (entity.Tasks, error) {
+	if mock.SearchTasksFunc == nil {
+		panic("TaskListerMock.SearchTasksFunc: method is nil but TaskLister.SearchTasks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Q      taskquery.Node
+		Loc    *time.Location
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Q:      q,
+		Loc:    loc,
+	}
+	mock.lockSearchTasks.Lock()
+	mock.calls.SearchTasks = append(mock.calls.SearchTasks, callInfo)
+	mock.lockSearchTasks.Unlock()
+	return mock.SearchTasksFunc(ctx, db, userID, q, loc)
+}
+
+// SearchTasksCalls gets all the calls that were made to SearchTasks.
+// Check the length with:
+//
+//	len(mockedTaskLister.SearchTasksCalls())
+func (mock *TaskListerMock) SearchTasksCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	Q      taskquery.Node
+	Loc    *time.Location
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Q      taskquery.Node
+		Loc    *time.Location
+	}
+	mock.lockSearchTasks.RLock()
+	calls = mock.calls.SearchTasks
+	mock.lockSearchTasks.RUnlock()
+	return calls
+}
+
 // Ensure, that UserGetterMock does implement UserGetter.
 // If this is not the case, regenerate this file with moq.
 var _ UserGetter = &UserGetterMock{}
@@ -315,3 +621,8401 @@ func (mock *TokenGeneratorMock) GenerateTokenCalls() []struct {
 	mock.lockGenerateToken.RUnlock()
 	return calls
 }
+
+// Ensure, that TemplateAdderMock does implement TemplateAdder.
+// If this is not the case, regenerate this file with moq.
+var _ TemplateAdder = &TemplateAdderMock{}
+
+// TemplateAdderMock is a mock implementation of TemplateAdder.
+//
+//	func TestSomethingThatUsesTemplateAdder(t *testing.T) {
+//
+//		// make and configure a mocked TemplateAdder
+//		mockedTemplateAdder := &TemplateAdderMock{
+//			AddTemplateFunc: func(ctx context.Context, db store.Execer, t *entity.Template) error {
+//				panic("mock out the AddTemplate method")
+//			},
+//		}
+//
+//		// use mockedTemplateAdder in code that requires TemplateAdder
+//		// and then make assertions.
+//
+//	}
+type TemplateAdderMock struct {
+	// AddTemplateFunc mocks the AddTemplate method.
+	AddTemplateFunc func(ctx context.Context, db store.Execer, t *entity.Template) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTemplate holds details about calls to the AddTemplate method.
+		AddTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Template
+		}
+	}
+	lockAddTemplate sync.RWMutex
+}
+
+// AddTemplate calls AddTemplateFunc.
+func (mock *TemplateAdderMock) AddTemplate(ctx context.Context, db store.Execer, t *entity.Template) error {
+	if mock.AddTemplateFunc == nil {
+		panic("TemplateAdderMock.AddTemplateFunc: method is nil but TemplateAdder.AddTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Template
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockAddTemplate.Lock()
+	mock.calls.AddTemplate = append(mock.calls.AddTemplate, callInfo)
+	mock.lockAddTemplate.Unlock()
+	return mock.AddTemplateFunc(ctx, db, t)
+}
+
+// AddTemplateCalls gets all the calls that were made to AddTemplate.
+// Check the length with:
+//
+//	len(mockedTemplateAdder.AddTemplateCalls())
+func (mock *TemplateAdderMock) AddTemplateCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Template
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Template
+	}
+	mock.lockAddTemplate.RLock()
+	calls = mock.calls.AddTemplate
+	mock.lockAddTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that TemplateListerMock does implement TemplateLister.
+// If this is not the case, regenerate this file with moq.
+var _ TemplateLister = &TemplateListerMock{}
+
+// TemplateListerMock is a mock implementation of TemplateLister.
+//
+//	func TestSomethingThatUsesTemplateLister(t *testing.T) {
+//
+//		// make and configure a mocked TemplateLister
+//		mockedTemplateLister := &TemplateListerMock{
+//			ListTemplatesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Templates, error) {
+//				panic("mock out the ListTemplates method")
+//			},
+//		}
+//
+//		// use mockedTemplateLister in code that requires TemplateLister
+//		// and then make assertions.
+//
+//	}
+type TemplateListerMock struct {
+	// ListTemplatesFunc mocks the ListTemplates method.
+	ListTemplatesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Templates, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListTemplates holds details about calls to the ListTemplates method.
+		ListTemplates []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockListTemplates sync.RWMutex
+}
+
+// ListTemplates calls ListTemplatesFunc.
+func (mock *TemplateListerMock) ListTemplates(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Templates, error) {
+	if mock.ListTemplatesFunc == nil {
+		panic("TemplateListerMock.ListTemplatesFunc: method is nil but TemplateLister.ListTemplates was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListTemplates.Lock()
+	mock.calls.ListTemplates = append(mock.calls.ListTemplates, callInfo)
+	mock.lockListTemplates.Unlock()
+	return mock.ListTemplatesFunc(ctx, db, userID)
+}
+
+// ListTemplatesCalls gets all the calls that were made to ListTemplates.
+// Check the length with:
+//
+//	len(mockedTemplateLister.ListTemplatesCalls())
+func (mock *TemplateListerMock) ListTemplatesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListTemplates.RLock()
+	calls = mock.calls.ListTemplates
+	mock.lockListTemplates.RUnlock()
+	return calls
+}
+
+// Ensure, that TemplateInstantiaterMock does implement TemplateInstantiater.
+// If this is not the case, regenerate this file with moq.
+var _ TemplateInstantiater = &TemplateInstantiaterMock{}
+
+// TemplateInstantiaterMock is a mock implementation of TemplateInstantiater.
+//
+//	func TestSomethingThatUsesTemplateInstantiater(t *testing.T) {
+//
+//		// make and configure a mocked TemplateInstantiater
+//		mockedTemplateInstantiater := &TemplateInstantiaterMock{
+//			AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+//				panic("mock out the AddTask method")
+//			},
+//			CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+//				panic("mock out the CountColumnTasks method")
+//			},
+//			GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+//				panic("mock out the GetDefaultColumnForUpdate method")
+//			},
+//			GetTemplateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TemplateID) (*entity.Template, error) {
+//				panic("mock out the GetTemplate method")
+//			},
+//		}
+//
+//		// use mockedTemplateInstantiater in code that requires TemplateInstantiater
+//		// and then make assertions.
+//
+//	}
+type TemplateInstantiaterMock struct {
+	// AddTaskFunc mocks the AddTask method.
+	AddTaskFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
+
+	// CountColumnTasksFunc mocks the CountColumnTasks method.
+	CountColumnTasksFunc func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+
+	// GetDefaultColumnForUpdateFunc mocks the GetDefaultColumnForUpdate method.
+	GetDefaultColumnForUpdateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+
+	// GetTemplateFunc mocks the GetTemplate method.
+	GetTemplateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TemplateID) (*entity.Template, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTask holds details about calls to the AddTask method.
+		AddTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Task
+		}
+		// CountColumnTasks holds details about calls to the CountColumnTasks method.
+		CountColumnTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// C is the c argument value.
+			C *entity.Column
+			// Exclude is the exclude argument value.
+			Exclude entity.TaskID
+		}
+		// GetDefaultColumnForUpdate holds details about calls to the GetDefaultColumnForUpdate method.
+		GetDefaultColumnForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
+		// GetTemplate holds details about calls to the GetTemplate method.
+		GetTemplate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.TemplateID
+		}
+	}
+	lockAddTask                   sync.RWMutex
+	lockCountColumnTasks          sync.RWMutex
+	lockGetDefaultColumnForUpdate sync.RWMutex
+	lockGetTemplate               sync.RWMutex
+}
+
+// AddTask calls AddTaskFunc.
+func (mock *TemplateInstantiaterMock) AddTask(ctx context.Context, db store.Execer, t *entity.Task) error {
+	if mock.AddTaskFunc == nil {
+		panic("TemplateInstantiaterMock.AddTaskFunc: method is nil but TemplateInstantiater.AddTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockAddTask.Lock()
+	mock.calls.AddTask = append(mock.calls.AddTask, callInfo)
+	mock.lockAddTask.Unlock()
+	return mock.AddTaskFunc(ctx, db, t)
+}
+
+// AddTaskCalls gets all the calls that were made to AddTask.
+// Check the length with:
+//
+//	len(mockedTemplateInstantiater.AddTaskCalls())
+func (mock *TemplateInstantiaterMock) AddTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}
+	mock.lockAddTask.RLock()
+	calls = mock.calls.AddTask
+	mock.lockAddTask.RUnlock()
+	return calls
+}
+
+// CountColumnTasks calls CountColumnTasksFunc.
+func (mock *TemplateInstantiaterMock) CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+	if mock.CountColumnTasksFunc == nil {
+		panic("TemplateInstantiaterMock.CountColumnTasksFunc: method is nil but TemplateInstantiater.CountColumnTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		C:       c,
+		Exclude: exclude,
+	}
+	mock.lockCountColumnTasks.Lock()
+	mock.calls.CountColumnTasks = append(mock.calls.CountColumnTasks, callInfo)
+	mock.lockCountColumnTasks.Unlock()
+	return mock.CountColumnTasksFunc(ctx, db, c, exclude)
+}
+
+// CountColumnTasksCalls gets all the calls that were made to CountColumnTasks.
+// Check the length with:
+//
+//	len(mockedTemplateInstantiater.CountColumnTasksCalls())
+func (mock *TemplateInstantiaterMock) CountColumnTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	C       *entity.Column
+	Exclude entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}
+	mock.lockCountColumnTasks.RLock()
+	calls = mock.calls.CountColumnTasks
+	mock.lockCountColumnTasks.RUnlock()
+	return calls
+}
+
+// GetDefaultColumnForUpdate calls GetDefaultColumnForUpdateFunc.
+func (mock *TemplateInstantiaterMock) GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+	if mock.GetDefaultColumnForUpdateFunc == nil {
+		panic("TemplateInstantiaterMock.GetDefaultColumnForUpdateFunc: method is nil but TemplateInstantiater.GetDefaultColumnForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockGetDefaultColumnForUpdate.Lock()
+	mock.calls.GetDefaultColumnForUpdate = append(mock.calls.GetDefaultColumnForUpdate, callInfo)
+	mock.lockGetDefaultColumnForUpdate.Unlock()
+	return mock.GetDefaultColumnForUpdateFunc(ctx, db, userID, status)
+}
+
+// GetDefaultColumnForUpdateCalls gets all the calls that were made to GetDefaultColumnForUpdate.
+// Check the length with:
+//
+//	len(mockedTemplateInstantiater.GetDefaultColumnForUpdateCalls())
+func (mock *TemplateInstantiaterMock) GetDefaultColumnForUpdateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}
+	mock.lockGetDefaultColumnForUpdate.RLock()
+	calls = mock.calls.GetDefaultColumnForUpdate
+	mock.lockGetDefaultColumnForUpdate.RUnlock()
+	return calls
+}
+
+// GetTemplate calls GetTemplateFunc.
+func (mock *TemplateInstantiaterMock) GetTemplate(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TemplateID) (*entity.Template, error) {
+	if mock.GetTemplateFunc == nil {
+		panic("TemplateInstantiaterMock.GetTemplateFunc: method is nil but TemplateInstantiater.GetTemplate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.TemplateID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetTemplate.Lock()
+	mock.calls.GetTemplate = append(mock.calls.GetTemplate, callInfo)
+	mock.lockGetTemplate.Unlock()
+	return mock.GetTemplateFunc(ctx, db, userID, id)
+}
+
+// GetTemplateCalls gets all the calls that were made to GetTemplate.
+// Check the length with:
+//
+//	len(mockedTemplateInstantiater.GetTemplateCalls())
+func (mock *TemplateInstantiaterMock) GetTemplateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	ID     entity.TemplateID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.TemplateID
+	}
+	mock.lockGetTemplate.RLock()
+	calls = mock.calls.GetTemplate
+	mock.lockGetTemplate.RUnlock()
+	return calls
+}
+
+// Ensure, that TimerStarterMock does implement TimerStarter.
+// If this is not the case, regenerate this file with moq.
+var _ TimerStarter = &TimerStarterMock{}
+
+// TimerStarterMock is a mock implementation of TimerStarter.
+//
+//	func TestSomethingThatUsesTimerStarter(t *testing.T) {
+//
+//		// make and configure a mocked TimerStarter
+//		mockedTimerStarter := &TimerStarterMock{
+//			StartTimerFunc: func(ctx context.Context, db store.Execer, e *entity.TimeEntry) error {
+//				panic("mock out the StartTimer method")
+//			},
+//		}
+//
+//		// use mockedTimerStarter in code that requires TimerStarter
+//		// and then make assertions.
+//
+//	}
+type TimerStarterMock struct {
+	// StartTimerFunc mocks the StartTimer method.
+	StartTimerFunc func(ctx context.Context, db store.Execer, e *entity.TimeEntry) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// StartTimer holds details about calls to the StartTimer method.
+		StartTimer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// E is the e argument value.
+			E *entity.TimeEntry
+		}
+	}
+	lockStartTimer sync.RWMutex
+}
+
+// StartTimer calls StartTimerFunc.
+func (mock *TimerStarterMock) StartTimer(ctx context.Context, db store.Execer, e *entity.TimeEntry) error {
+	if mock.StartTimerFunc == nil {
+		panic("TimerStarterMock.StartTimerFunc: method is nil but TimerStarter.StartTimer was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		E   *entity.TimeEntry
+	}{
+		Ctx: ctx,
+		Db:  db,
+		E:   e,
+	}
+	mock.lockStartTimer.Lock()
+	mock.calls.StartTimer = append(mock.calls.StartTimer, callInfo)
+	mock.lockStartTimer.Unlock()
+	return mock.StartTimerFunc(ctx, db, e)
+}
+
+// StartTimerCalls gets all the calls that were made to StartTimer.
+// Check the length with:
+//
+//	len(mockedTimerStarter.StartTimerCalls())
+func (mock *TimerStarterMock) StartTimerCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	E   *entity.TimeEntry
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		E   *entity.TimeEntry
+	}
+	mock.lockStartTimer.RLock()
+	calls = mock.calls.StartTimer
+	mock.lockStartTimer.RUnlock()
+	return calls
+}
+
+// Ensure, that TimeEntryUpdaterMock does implement TimeEntryUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ TimeEntryUpdater = &TimeEntryUpdaterMock{}
+
+// TimeEntryUpdaterMock is a mock implementation of TimeEntryUpdater.
+//
+//	func TestSomethingThatUsesTimeEntryUpdater(t *testing.T) {
+//
+//		// make and configure a mocked TimeEntryUpdater
+//		mockedTimeEntryUpdater := &TimeEntryUpdaterMock{
+//			GetRunningTimeEntryFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.TimeEntry, error) {
+//				panic("mock out the GetRunningTimeEntry method")
+//			},
+//			GetTimeEntryFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TimeEntryID) (*entity.TimeEntry, error) {
+//				panic("mock out the GetTimeEntry method")
+//			},
+//			UpdateTimeEntryFunc: func(ctx context.Context, db store.Execer, e *entity.TimeEntry) error {
+//				panic("mock out the UpdateTimeEntry method")
+//			},
+//		}
+//
+//		// use mockedTimeEntryUpdater in code that requires TimeEntryUpdater
+//		// and then make assertions.
+//
+//	}
+type TimeEntryUpdaterMock struct {
+	// GetRunningTimeEntryFunc mocks the GetRunningTimeEntry method.
+	GetRunningTimeEntryFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.TimeEntry, error)
+
+	// GetTimeEntryFunc mocks the GetTimeEntry method.
+	GetTimeEntryFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TimeEntryID) (*entity.TimeEntry, error)
+
+	// UpdateTimeEntryFunc mocks the UpdateTimeEntry method.
+	UpdateTimeEntryFunc func(ctx context.Context, db store.Execer, e *entity.TimeEntry) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetRunningTimeEntry holds details about calls to the GetRunningTimeEntry method.
+		GetRunningTimeEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// GetTimeEntry holds details about calls to the GetTimeEntry method.
+		GetTimeEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.TimeEntryID
+		}
+		// UpdateTimeEntry holds details about calls to the UpdateTimeEntry method.
+		UpdateTimeEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// E is the e argument value.
+			E *entity.TimeEntry
+		}
+	}
+	lockGetRunningTimeEntry sync.RWMutex
+	lockGetTimeEntry        sync.RWMutex
+	lockUpdateTimeEntry     sync.RWMutex
+}
+
+// GetRunningTimeEntry calls GetRunningTimeEntryFunc.
+func (mock *TimeEntryUpdaterMock) GetRunningTimeEntry(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.TimeEntry, error) {
+	if mock.GetRunningTimeEntryFunc == nil {
+		panic("TimeEntryUpdaterMock.GetRunningTimeEntryFunc: method is nil but TimeEntryUpdater.GetRunningTimeEntry was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockGetRunningTimeEntry.Lock()
+	mock.calls.GetRunningTimeEntry = append(mock.calls.GetRunningTimeEntry, callInfo)
+	mock.lockGetRunningTimeEntry.Unlock()
+	return mock.GetRunningTimeEntryFunc(ctx, db, userID)
+}
+
+// GetRunningTimeEntryCalls gets all the calls that were made to GetRunningTimeEntry.
+// Check the length with:
+//
+//	len(mockedTimeEntryUpdater.GetRunningTimeEntryCalls())
+func (mock *TimeEntryUpdaterMock) GetRunningTimeEntryCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockGetRunningTimeEntry.RLock()
+	calls = mock.calls.GetRunningTimeEntry
+	mock.lockGetRunningTimeEntry.RUnlock()
+	return calls
+}
+
+// GetTimeEntry calls GetTimeEntryFunc.
+func (mock *TimeEntryUpdaterMock) GetTimeEntry(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TimeEntryID) (*entity.TimeEntry, error) {
+	if mock.GetTimeEntryFunc == nil {
+		panic("TimeEntryUpdaterMock.GetTimeEntryFunc: method is nil but TimeEntryUpdater.GetTimeEntry was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.TimeEntryID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetTimeEntry.Lock()
+	mock.calls.GetTimeEntry = append(mock.calls.GetTimeEntry, callInfo)
+	mock.lockGetTimeEntry.Unlock()
+	return mock.GetTimeEntryFunc(ctx, db, userID, id)
+}
+
+// GetTimeEntryCalls gets all the calls that were made to GetTimeEntry.
+// Check the length with:
+//
+//	len(mockedTimeEntryUpdater.GetTimeEntryCalls())
+func (mock *TimeEntryUpdaterMock) GetTimeEntryCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	ID     entity.TimeEntryID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.TimeEntryID
+	}
+	mock.lockGetTimeEntry.RLock()
+	calls = mock.calls.GetTimeEntry
+	mock.lockGetTimeEntry.RUnlock()
+	return calls
+}
+
+// UpdateTimeEntry calls UpdateTimeEntryFunc.
+func (mock *TimeEntryUpdaterMock) UpdateTimeEntry(ctx context.Context, db store.Execer, e *entity.TimeEntry) error {
+	if mock.UpdateTimeEntryFunc == nil {
+		panic("TimeEntryUpdaterMock.UpdateTimeEntryFunc: method is nil but TimeEntryUpdater.UpdateTimeEntry was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		E   *entity.TimeEntry
+	}{
+		Ctx: ctx,
+		Db:  db,
+		E:   e,
+	}
+	mock.lockUpdateTimeEntry.Lock()
+	mock.calls.UpdateTimeEntry = append(mock.calls.UpdateTimeEntry, callInfo)
+	mock.lockUpdateTimeEntry.Unlock()
+	return mock.UpdateTimeEntryFunc(ctx, db, e)
+}
+
+// UpdateTimeEntryCalls gets all the calls that were made to UpdateTimeEntry.
+// Check the length with:
+//
+//	len(mockedTimeEntryUpdater.UpdateTimeEntryCalls())
+func (mock *TimeEntryUpdaterMock) UpdateTimeEntryCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	E   *entity.TimeEntry
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		E   *entity.TimeEntry
+	}
+	mock.lockUpdateTimeEntry.RLock()
+	calls = mock.calls.UpdateTimeEntry
+	mock.lockUpdateTimeEntry.RUnlock()
+	return calls
+}
+
+// Ensure, that TimeReporterMock does implement TimeReporter.
+// If this is not the case, regenerate this file with moq.
+var _ TimeReporter = &TimeReporterMock{}
+
+// TimeReporterMock is a mock implementation of TimeReporter.
+//
+//	func TestSomethingThatUsesTimeReporter(t *testing.T) {
+//
+//		// make and configure a mocked TimeReporter
+//		mockedTimeReporter := &TimeReporterMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			ListTimeEntriesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, from time.Time, to time.Time) (entity.TimeEntries, error) {
+//				panic("mock out the ListTimeEntries method")
+//			},
+//		}
+//
+//		// use mockedTimeReporter in code that requires TimeReporter
+//		// and then make assertions.
+//
+//	}
+type TimeReporterMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// ListTimeEntriesFunc mocks the ListTimeEntries method.
+	ListTimeEntriesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, from time.Time, to time.Time) (entity.TimeEntries, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// ListTimeEntries holds details about calls to the ListTimeEntries method.
+		ListTimeEntries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+	}
+	lockGetUserByID     sync.RWMutex
+	lockListTimeEntries sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *TimeReporterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("TimeReporterMock.GetUserByIDFunc: method is nil but TimeReporter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedTimeReporter.GetUserByIDCalls())
+func (mock *TimeReporterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// ListTimeEntries calls ListTimeEntriesFunc.
+func (mock *TimeReporterMock) ListTimeEntries(ctx context.Context, db store.Queryer, userID entity.UserID, from time.Time, to time.Time) (entity.TimeEntries, error) {
+	if mock.ListTimeEntriesFunc == nil {
+		panic("TimeReporterMock.ListTimeEntriesFunc: method is nil but TimeReporter.ListTimeEntries was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		From   time.Time
+		To     time.Time
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		From:   from,
+		To:     to,
+	}
+	mock.lockListTimeEntries.Lock()
+	mock.calls.ListTimeEntries = append(mock.calls.ListTimeEntries, callInfo)
+	mock.lockListTimeEntries.Unlock()
+	return mock.ListTimeEntriesFunc(ctx, db, userID, from, to)
+}
+
+// ListTimeEntriesCalls gets all the calls that were made to ListTimeEntries.
+// Check the length with:
+//
+//	len(mockedTimeReporter.ListTimeEntriesCalls())
+func (mock *TimeReporterMock) ListTimeEntriesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	From   time.Time
+	To     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		From   time.Time
+		To     time.Time
+	}
+	mock.lockListTimeEntries.RLock()
+	calls = mock.calls.ListTimeEntries
+	mock.lockListTimeEntries.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskStatusUpdaterMock does implement TaskStatusUpdater.
+// If this is not the case, regenerate this file with moq.
+var _ TaskStatusUpdater = &TaskStatusUpdaterMock{}
+
+// TaskStatusUpdaterMock is a mock implementation of TaskStatusUpdater.
+//
+//	func TestSomethingThatUsesTaskStatusUpdater(t *testing.T) {
+//
+//		// make and configure a mocked TaskStatusUpdater
+//		mockedTaskStatusUpdater := &TaskStatusUpdaterMock{
+//			CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+//				panic("mock out the CountColumnTasks method")
+//			},
+//			GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+//				panic("mock out the GetDefaultColumnForUpdate method")
+//			},
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			UpdateTaskStatusFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error {
+//				panic("mock out the UpdateTaskStatus method")
+//			},
+//		}
+//
+//		// use mockedTaskStatusUpdater in code that requires TaskStatusUpdater
+//		// and then make assertions.
+//
+//	}
+type TaskStatusUpdaterMock struct {
+	// CountColumnTasksFunc mocks the CountColumnTasks method.
+	CountColumnTasksFunc func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+
+	// GetDefaultColumnForUpdateFunc mocks the GetDefaultColumnForUpdate method.
+	GetDefaultColumnForUpdateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+
+	// UpdateTaskStatusFunc mocks the UpdateTaskStatus method.
+	UpdateTaskStatusFunc func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CountColumnTasks holds details about calls to the CountColumnTasks method.
+		CountColumnTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// C is the c argument value.
+			C *entity.Column
+			// Exclude is the exclude argument value.
+			Exclude entity.TaskID
+		}
+		// GetDefaultColumnForUpdate holds details about calls to the GetDefaultColumnForUpdate method.
+		GetDefaultColumnForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// UpdateTaskStatus holds details about calls to the UpdateTaskStatus method.
+		UpdateTaskStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
+	}
+	lockCountColumnTasks          sync.RWMutex
+	lockGetDefaultColumnForUpdate sync.RWMutex
+	lockGetTask                   sync.RWMutex
+	lockUpdateTaskStatus          sync.RWMutex
+}
+
+// CountColumnTasks calls CountColumnTasksFunc.
+func (mock *TaskStatusUpdaterMock) CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+	if mock.CountColumnTasksFunc == nil {
+		panic("TaskStatusUpdaterMock.CountColumnTasksFunc: method is nil but TaskStatusUpdater.CountColumnTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		C:       c,
+		Exclude: exclude,
+	}
+	mock.lockCountColumnTasks.Lock()
+	mock.calls.CountColumnTasks = append(mock.calls.CountColumnTasks, callInfo)
+	mock.lockCountColumnTasks.Unlock()
+	return mock.CountColumnTasksFunc(ctx, db, c, exclude)
+}
+
+// CountColumnTasksCalls gets all the calls that were made to CountColumnTasks.
+// Check the length with:
+//
+//	len(mockedTaskStatusUpdater.CountColumnTasksCalls())
+func (mock *TaskStatusUpdaterMock) CountColumnTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	C       *entity.Column
+	Exclude entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}
+	mock.lockCountColumnTasks.RLock()
+	calls = mock.calls.CountColumnTasks
+	mock.lockCountColumnTasks.RUnlock()
+	return calls
+}
+
+// GetDefaultColumnForUpdate calls GetDefaultColumnForUpdateFunc.
+func (mock *TaskStatusUpdaterMock) GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+	if mock.GetDefaultColumnForUpdateFunc == nil {
+		panic("TaskStatusUpdaterMock.GetDefaultColumnForUpdateFunc: method is nil but TaskStatusUpdater.GetDefaultColumnForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockGetDefaultColumnForUpdate.Lock()
+	mock.calls.GetDefaultColumnForUpdate = append(mock.calls.GetDefaultColumnForUpdate, callInfo)
+	mock.lockGetDefaultColumnForUpdate.Unlock()
+	return mock.GetDefaultColumnForUpdateFunc(ctx, db, userID, status)
+}
+
+// GetDefaultColumnForUpdateCalls gets all the calls that were made to GetDefaultColumnForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskStatusUpdater.GetDefaultColumnForUpdateCalls())
+func (mock *TaskStatusUpdaterMock) GetDefaultColumnForUpdateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}
+	mock.lockGetDefaultColumnForUpdate.RLock()
+	calls = mock.calls.GetDefaultColumnForUpdate
+	mock.lockGetDefaultColumnForUpdate.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskStatusUpdaterMock) GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskStatusUpdaterMock.GetTaskFunc: method is nil but TaskStatusUpdater.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskStatusUpdater.GetTaskCalls())
+func (mock *TaskStatusUpdaterMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// UpdateTaskStatus calls UpdateTaskStatusFunc.
+func (mock *TaskStatusUpdaterMock) UpdateTaskStatus(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error {
+	if mock.UpdateTaskStatusFunc == nil {
+		panic("TaskStatusUpdaterMock.UpdateTaskStatusFunc: method is nil but TaskStatusUpdater.UpdateTaskStatus was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.TaskID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+		Status: status,
+	}
+	mock.lockUpdateTaskStatus.Lock()
+	mock.calls.UpdateTaskStatus = append(mock.calls.UpdateTaskStatus, callInfo)
+	mock.lockUpdateTaskStatus.Unlock()
+	return mock.UpdateTaskStatusFunc(ctx, db, userID, id, status)
+}
+
+// UpdateTaskStatusCalls gets all the calls that were made to UpdateTaskStatus.
+// Check the length with:
+//
+//	len(mockedTaskStatusUpdater.UpdateTaskStatusCalls())
+func (mock *TaskStatusUpdaterMock) UpdateTaskStatusCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	ID     entity.TaskID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.TaskID
+		Status entity.TaskStatus
+	}
+	mock.lockUpdateTaskStatus.RLock()
+	calls = mock.calls.UpdateTaskStatus
+	mock.lockUpdateTaskStatus.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskQuickAdderMock does implement TaskQuickAdder.
+// If this is not the case, regenerate this file with moq.
+var _ TaskQuickAdder = &TaskQuickAdderMock{}
+
+// TaskQuickAdderMock is a mock implementation of TaskQuickAdder.
+//
+//	func TestSomethingThatUsesTaskQuickAdder(t *testing.T) {
+//
+//		// make and configure a mocked TaskQuickAdder
+//		mockedTaskQuickAdder := &TaskQuickAdderMock{
+//			AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+//				panic("mock out the AddTask method")
+//			},
+//			AddTaskLabelsFunc: func(ctx context.Context, db store.Execer, taskID entity.TaskID, names []string) error {
+//				panic("mock out the AddTaskLabels method")
+//			},
+//			CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+//				panic("mock out the CountColumnTasks method")
+//			},
+//			GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+//				panic("mock out the GetDefaultColumnForUpdate method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedTaskQuickAdder in code that requires TaskQuickAdder
+//		// and then make assertions.
+//
+//	}
+type TaskQuickAdderMock struct {
+	// AddTaskFunc mocks the AddTask method.
+	AddTaskFunc func(ctx context.Context, db store.Execer, t *entity.Task) error
+
+	// AddTaskLabelsFunc mocks the AddTaskLabels method.
+	AddTaskLabelsFunc func(ctx context.Context, db store.Execer, taskID entity.TaskID, names []string) error
+
+	// CountColumnTasksFunc mocks the CountColumnTasks method.
+	CountColumnTasksFunc func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+
+	// GetDefaultColumnForUpdateFunc mocks the GetDefaultColumnForUpdate method.
+	GetDefaultColumnForUpdateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddTask holds details about calls to the AddTask method.
+		AddTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// T is the t argument value.
+			T *entity.Task
+		}
+		// AddTaskLabels holds details about calls to the AddTaskLabels method.
+		AddTaskLabels []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// TaskID is the taskID argument value.
+			TaskID entity.TaskID
+			// Names is the names argument value.
+			Names []string
+		}
+		// CountColumnTasks holds details about calls to the CountColumnTasks method.
+		CountColumnTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// C is the c argument value.
+			C *entity.Column
+			// Exclude is the exclude argument value.
+			Exclude entity.TaskID
+		}
+		// GetDefaultColumnForUpdate holds details about calls to the GetDefaultColumnForUpdate method.
+		GetDefaultColumnForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Status is the status argument value.
+			Status entity.TaskStatus
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockAddTask                   sync.RWMutex
+	lockAddTaskLabels             sync.RWMutex
+	lockCountColumnTasks          sync.RWMutex
+	lockGetDefaultColumnForUpdate sync.RWMutex
+	lockGetUserByID               sync.RWMutex
+}
+
+// AddTask calls AddTaskFunc.
+func (mock *TaskQuickAdderMock) AddTask(ctx context.Context, db store.Execer, t *entity.Task) error {
+	if mock.AddTaskFunc == nil {
+		panic("TaskQuickAdderMock.AddTaskFunc: method is nil but TaskQuickAdder.AddTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}{
+		Ctx: ctx,
+		Db:  db,
+		T:   t,
+	}
+	mock.lockAddTask.Lock()
+	mock.calls.AddTask = append(mock.calls.AddTask, callInfo)
+	mock.lockAddTask.Unlock()
+	return mock.AddTaskFunc(ctx, db, t)
+}
+
+// AddTaskCalls gets all the calls that were made to AddTask.
+// Check the length with:
+//
+//	len(mockedTaskQuickAdder.AddTaskCalls())
+func (mock *TaskQuickAdderMock) AddTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	T   *entity.Task
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		T   *entity.Task
+	}
+	mock.lockAddTask.RLock()
+	calls = mock.calls.AddTask
+	mock.lockAddTask.RUnlock()
+	return calls
+}
+
+// AddTaskLabels calls AddTaskLabelsFunc.
+func (mock *TaskQuickAdderMock) AddTaskLabels(ctx context.Context, db store.Execer, taskID entity.TaskID, names []string) error {
+	if mock.AddTaskLabelsFunc == nil {
+		panic("TaskQuickAdderMock.AddTaskLabelsFunc: method is nil but TaskQuickAdder.AddTaskLabels was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		TaskID entity.TaskID
+		Names  []string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		TaskID: taskID,
+		Names:  names,
+	}
+	mock.lockAddTaskLabels.Lock()
+	mock.calls.AddTaskLabels = append(mock.calls.AddTaskLabels, callInfo)
+	mock.lockAddTaskLabels.Unlock()
+	return mock.AddTaskLabelsFunc(ctx, db, taskID, names)
+}
+
+// AddTaskLabelsCalls gets all the calls that were made to AddTaskLabels.
+// Check the length with:
+//
+//	len(mockedTaskQuickAdder.AddTaskLabelsCalls())
+func (mock *TaskQuickAdderMock) AddTaskLabelsCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	TaskID entity.TaskID
+	Names  []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		TaskID entity.TaskID
+		Names  []string
+	}
+	mock.lockAddTaskLabels.RLock()
+	calls = mock.calls.AddTaskLabels
+	mock.lockAddTaskLabels.RUnlock()
+	return calls
+}
+
+// CountColumnTasks calls CountColumnTasksFunc.
+func (mock *TaskQuickAdderMock) CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+	if mock.CountColumnTasksFunc == nil {
+		panic("TaskQuickAdderMock.CountColumnTasksFunc: method is nil but TaskQuickAdder.CountColumnTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		C:       c,
+		Exclude: exclude,
+	}
+	mock.lockCountColumnTasks.Lock()
+	mock.calls.CountColumnTasks = append(mock.calls.CountColumnTasks, callInfo)
+	mock.lockCountColumnTasks.Unlock()
+	return mock.CountColumnTasksFunc(ctx, db, c, exclude)
+}
+
+// CountColumnTasksCalls gets all the calls that were made to CountColumnTasks.
+// Check the length with:
+//
+//	len(mockedTaskQuickAdder.CountColumnTasksCalls())
+func (mock *TaskQuickAdderMock) CountColumnTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	C       *entity.Column
+	Exclude entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}
+	mock.lockCountColumnTasks.RLock()
+	calls = mock.calls.CountColumnTasks
+	mock.lockCountColumnTasks.RUnlock()
+	return calls
+}
+
+// GetDefaultColumnForUpdate calls GetDefaultColumnForUpdateFunc.
+func (mock *TaskQuickAdderMock) GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+	if mock.GetDefaultColumnForUpdateFunc == nil {
+		panic("TaskQuickAdderMock.GetDefaultColumnForUpdateFunc: method is nil but TaskQuickAdder.GetDefaultColumnForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Status: status,
+	}
+	mock.lockGetDefaultColumnForUpdate.Lock()
+	mock.calls.GetDefaultColumnForUpdate = append(mock.calls.GetDefaultColumnForUpdate, callInfo)
+	mock.lockGetDefaultColumnForUpdate.Unlock()
+	return mock.GetDefaultColumnForUpdateFunc(ctx, db, userID, status)
+}
+
+// GetDefaultColumnForUpdateCalls gets all the calls that were made to GetDefaultColumnForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskQuickAdder.GetDefaultColumnForUpdateCalls())
+func (mock *TaskQuickAdderMock) GetDefaultColumnForUpdateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	Status entity.TaskStatus
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		Status entity.TaskStatus
+	}
+	mock.lockGetDefaultColumnForUpdate.RLock()
+	calls = mock.calls.GetDefaultColumnForUpdate
+	mock.lockGetDefaultColumnForUpdate.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *TaskQuickAdderMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("TaskQuickAdderMock.GetUserByIDFunc: method is nil but TaskQuickAdder.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedTaskQuickAdder.GetUserByIDCalls())
+func (mock *TaskQuickAdderMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that BoardGetterMock does implement BoardGetter.
+// If this is not the case, regenerate this file with moq.
+var _ BoardGetter = &BoardGetterMock{}
+
+// BoardGetterMock is a mock implementation of BoardGetter.
+//
+//	func TestSomethingThatUsesBoardGetter(t *testing.T) {
+//
+//		// make and configure a mocked BoardGetter
+//		mockedBoardGetter := &BoardGetterMock{
+//			GetBoardFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Board, error) {
+//				panic("mock out the GetBoard method")
+//			},
+//		}
+//
+//		// use mockedBoardGetter in code that requires BoardGetter
+//		// and then make assertions.
+//
+//	}
+type BoardGetterMock struct {
+	// GetBoardFunc mocks the GetBoard method.
+	GetBoardFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Board, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetBoard holds details about calls to the GetBoard method.
+		GetBoard []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockGetBoard sync.RWMutex
+}
+
+// GetBoard calls GetBoardFunc.
+func (mock *BoardGetterMock) GetBoard(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Board, error) {
+	if mock.GetBoardFunc == nil {
+		panic("BoardGetterMock.GetBoardFunc: method is nil but BoardGetter.GetBoard was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockGetBoard.Lock()
+	mock.calls.GetBoard = append(mock.calls.GetBoard, callInfo)
+	mock.lockGetBoard.Unlock()
+	return mock.GetBoardFunc(ctx, db, userID)
+}
+
+// GetBoardCalls gets all the calls that were made to GetBoard.
+// Check the length with:
+//
+//	len(mockedBoardGetter.GetBoardCalls())
+func (mock *BoardGetterMock) GetBoardCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockGetBoard.RLock()
+	calls = mock.calls.GetBoard
+	mock.lockGetBoard.RUnlock()
+	return calls
+}
+
+// Ensure, that ColumnAdderMock does implement ColumnAdder.
+// If this is not the case, regenerate this file with moq.
+var _ ColumnAdder = &ColumnAdderMock{}
+
+// ColumnAdderMock is a mock implementation of ColumnAdder.
+//
+//	func TestSomethingThatUsesColumnAdder(t *testing.T) {
+//
+//		// make and configure a mocked ColumnAdder
+//		mockedColumnAdder := &ColumnAdderMock{
+//			AddColumnFunc: func(ctx context.Context, db store.Execer, c *entity.Column) error {
+//				panic("mock out the AddColumn method")
+//			},
+//			ListColumnsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Columns, error) {
+//				panic("mock out the ListColumns method")
+//			},
+//		}
+//
+//		// use mockedColumnAdder in code that requires ColumnAdder
+//		// and then make assertions.
+//
+//	}
+type ColumnAdderMock struct {
+	// AddColumnFunc mocks the AddColumn method.
+	AddColumnFunc func(ctx context.Context, db store.Execer, c *entity.Column) error
+
+	// ListColumnsFunc mocks the ListColumns method.
+	ListColumnsFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Columns, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddColumn holds details about calls to the AddColumn method.
+		AddColumn []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// C is the c argument value.
+			C *entity.Column
+		}
+		// ListColumns holds details about calls to the ListColumns method.
+		ListColumns []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockAddColumn   sync.RWMutex
+	lockListColumns sync.RWMutex
+}
+
+// AddColumn calls AddColumnFunc.
+func (mock *ColumnAdderMock) AddColumn(ctx context.Context, db store.Execer, c *entity.Column) error {
+	if mock.AddColumnFunc == nil {
+		panic("ColumnAdderMock.AddColumnFunc: method is nil but ColumnAdder.AddColumn was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		C   *entity.Column
+	}{
+		Ctx: ctx,
+		Db:  db,
+		C:   c,
+	}
+	mock.lockAddColumn.Lock()
+	mock.calls.AddColumn = append(mock.calls.AddColumn, callInfo)
+	mock.lockAddColumn.Unlock()
+	return mock.AddColumnFunc(ctx, db, c)
+}
+
+// AddColumnCalls gets all the calls that were made to AddColumn.
+// Check the length with:
+//
+//	len(mockedColumnAdder.AddColumnCalls())
+func (mock *ColumnAdderMock) AddColumnCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	C   *entity.Column
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		C   *entity.Column
+	}
+	mock.lockAddColumn.RLock()
+	calls = mock.calls.AddColumn
+	mock.lockAddColumn.RUnlock()
+	return calls
+}
+
+// ListColumns calls ListColumnsFunc.
+func (mock *ColumnAdderMock) ListColumns(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Columns, error) {
+	if mock.ListColumnsFunc == nil {
+		panic("ColumnAdderMock.ListColumnsFunc: method is nil but ColumnAdder.ListColumns was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListColumns.Lock()
+	mock.calls.ListColumns = append(mock.calls.ListColumns, callInfo)
+	mock.lockListColumns.Unlock()
+	return mock.ListColumnsFunc(ctx, db, userID)
+}
+
+// ListColumnsCalls gets all the calls that were made to ListColumns.
+// Check the length with:
+//
+//	len(mockedColumnAdder.ListColumnsCalls())
+func (mock *ColumnAdderMock) ListColumnsCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListColumns.RLock()
+	calls = mock.calls.ListColumns
+	mock.lockListColumns.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskMoverMock does implement TaskMover.
+// If this is not the case, regenerate this file with moq.
+var _ TaskMover = &TaskMoverMock{}
+
+// TaskMoverMock is a mock implementation of TaskMover.
+//
+//	func TestSomethingThatUsesTaskMover(t *testing.T) {
+//
+//		// make and configure a mocked TaskMover
+//		mockedTaskMover := &TaskMoverMock{
+//			CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+//				panic("mock out the CountColumnTasks method")
+//			},
+//			GetColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.ColumnID) (*entity.Column, error) {
+//				panic("mock out the GetColumnForUpdate method")
+//			},
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			MoveTaskFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, c *entity.Column) error {
+//				panic("mock out the MoveTask method")
+//			},
+//		}
+//
+//		// use mockedTaskMover in code that requires TaskMover
+//		// and then make assertions.
+//
+//	}
+type TaskMoverMock struct {
+	// CountColumnTasksFunc mocks the CountColumnTasks method.
+	CountColumnTasksFunc func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+
+	// GetColumnForUpdateFunc mocks the GetColumnForUpdate method.
+	GetColumnForUpdateFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.ColumnID) (*entity.Column, error)
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+
+	// MoveTaskFunc mocks the MoveTask method.
+	MoveTaskFunc func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, c *entity.Column) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CountColumnTasks holds details about calls to the CountColumnTasks method.
+		CountColumnTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// C is the c argument value.
+			C *entity.Column
+			// Exclude is the exclude argument value.
+			Exclude entity.TaskID
+		}
+		// GetColumnForUpdate holds details about calls to the GetColumnForUpdate method.
+		GetColumnForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.ColumnID
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// MoveTask holds details about calls to the MoveTask method.
+		MoveTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.TaskID
+			// C is the c argument value.
+			C *entity.Column
+		}
+	}
+	lockCountColumnTasks   sync.RWMutex
+	lockGetColumnForUpdate sync.RWMutex
+	lockGetTask            sync.RWMutex
+	lockMoveTask           sync.RWMutex
+}
+
+// CountColumnTasks calls CountColumnTasksFunc.
+func (mock *TaskMoverMock) CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+	if mock.CountColumnTasksFunc == nil {
+		panic("TaskMoverMock.CountColumnTasksFunc: method is nil but TaskMover.CountColumnTasks was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		C:       c,
+		Exclude: exclude,
+	}
+	mock.lockCountColumnTasks.Lock()
+	mock.calls.CountColumnTasks = append(mock.calls.CountColumnTasks, callInfo)
+	mock.lockCountColumnTasks.Unlock()
+	return mock.CountColumnTasksFunc(ctx, db, c, exclude)
+}
+
+// CountColumnTasksCalls gets all the calls that were made to CountColumnTasks.
+// Check the length with:
+//
+//	len(mockedTaskMover.CountColumnTasksCalls())
+func (mock *TaskMoverMock) CountColumnTasksCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	C       *entity.Column
+	Exclude entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		C       *entity.Column
+		Exclude entity.TaskID
+	}
+	mock.lockCountColumnTasks.RLock()
+	calls = mock.calls.CountColumnTasks
+	mock.lockCountColumnTasks.RUnlock()
+	return calls
+}
+
+// GetColumnForUpdate calls GetColumnForUpdateFunc.
+func (mock *TaskMoverMock) GetColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.ColumnID) (*entity.Column, error) {
+	if mock.GetColumnForUpdateFunc == nil {
+		panic("TaskMoverMock.GetColumnForUpdateFunc: method is nil but TaskMover.GetColumnForUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.ColumnID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetColumnForUpdate.Lock()
+	mock.calls.GetColumnForUpdate = append(mock.calls.GetColumnForUpdate, callInfo)
+	mock.lockGetColumnForUpdate.Unlock()
+	return mock.GetColumnForUpdateFunc(ctx, db, userID, id)
+}
+
+// GetColumnForUpdateCalls gets all the calls that were made to GetColumnForUpdate.
+// Check the length with:
+//
+//	len(mockedTaskMover.GetColumnForUpdateCalls())
+func (mock *TaskMoverMock) GetColumnForUpdateCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	ID     entity.ColumnID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.ColumnID
+	}
+	mock.lockGetColumnForUpdate.RLock()
+	calls = mock.calls.GetColumnForUpdate
+	mock.lockGetColumnForUpdate.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskMoverMock) GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskMoverMock.GetTaskFunc: method is nil but TaskMover.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskMover.GetTaskCalls())
+func (mock *TaskMoverMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// MoveTask calls MoveTaskFunc.
+func (mock *TaskMoverMock) MoveTask(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, c *entity.Column) error {
+	if mock.MoveTaskFunc == nil {
+		panic("TaskMoverMock.MoveTaskFunc: method is nil but TaskMover.MoveTask was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.TaskID
+		C      *entity.Column
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+		C:      c,
+	}
+	mock.lockMoveTask.Lock()
+	mock.calls.MoveTask = append(mock.calls.MoveTask, callInfo)
+	mock.lockMoveTask.Unlock()
+	return mock.MoveTaskFunc(ctx, db, userID, id, c)
+}
+
+// MoveTaskCalls gets all the calls that were made to MoveTask.
+// Check the length with:
+//
+//	len(mockedTaskMover.MoveTaskCalls())
+func (mock *TaskMoverMock) MoveTaskCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	ID     entity.TaskID
+	C      *entity.Column
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.TaskID
+		C      *entity.Column
+	}
+	mock.lockMoveTask.RLock()
+	calls = mock.calls.MoveTask
+	mock.lockMoveTask.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkspaceResolverMock does implement WorkspaceResolver.
+// If this is not the case, regenerate this file with moq.
+var _ WorkspaceResolver = &WorkspaceResolverMock{}
+
+// WorkspaceResolverMock is a mock implementation of WorkspaceResolver.
+//
+//	func TestSomethingThatUsesWorkspaceResolver(t *testing.T) {
+//
+//		// make and configure a mocked WorkspaceResolver
+//		mockedWorkspaceResolver := &WorkspaceResolverMock{
+//			AddWorkspaceFunc: func(ctx context.Context, db store.Execer, ws *entity.Workspace) error {
+//				panic("mock out the AddWorkspace method")
+//			},
+//			AddWorkspaceMemberFunc: func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+//				panic("mock out the AddWorkspaceMember method")
+//			},
+//			GetPersonalWorkspaceFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Workspace, error) {
+//				panic("mock out the GetPersonalWorkspace method")
+//			},
+//			GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+//				panic("mock out the GetWorkspaceMember method")
+//			},
+//		}
+//
+//		// use mockedWorkspaceResolver in code that requires WorkspaceResolver
+//		// and then make assertions.
+//
+//	}
+type WorkspaceResolverMock struct {
+	// AddWorkspaceFunc mocks the AddWorkspace method.
+	AddWorkspaceFunc func(ctx context.Context, db store.Execer, ws *entity.Workspace) error
+
+	// AddWorkspaceMemberFunc mocks the AddWorkspaceMember method.
+	AddWorkspaceMemberFunc func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+
+	// GetPersonalWorkspaceFunc mocks the GetPersonalWorkspace method.
+	GetPersonalWorkspaceFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Workspace, error)
+
+	// GetWorkspaceMemberFunc mocks the GetWorkspaceMember method.
+	GetWorkspaceMemberFunc func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddWorkspace holds details about calls to the AddWorkspace method.
+		AddWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ws is the ws argument value.
+			Ws *entity.Workspace
+		}
+		// AddWorkspaceMember holds details about calls to the AddWorkspaceMember method.
+		AddWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// M is the m argument value.
+			M *entity.WorkspaceMember
+		}
+		// GetPersonalWorkspace holds details about calls to the GetPersonalWorkspace method.
+		GetPersonalWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// GetWorkspaceMember holds details about calls to the GetWorkspaceMember method.
+		GetWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockAddWorkspace         sync.RWMutex
+	lockAddWorkspaceMember   sync.RWMutex
+	lockGetPersonalWorkspace sync.RWMutex
+	lockGetWorkspaceMember   sync.RWMutex
+}
+
+// AddWorkspace calls AddWorkspaceFunc.
+func (mock *WorkspaceResolverMock) AddWorkspace(ctx context.Context, db store.Execer, ws *entity.Workspace) error {
+	if mock.AddWorkspaceFunc == nil {
+		panic("WorkspaceResolverMock.AddWorkspaceFunc: method is nil but WorkspaceResolver.AddWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ws  *entity.Workspace
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ws:  ws,
+	}
+	mock.lockAddWorkspace.Lock()
+	mock.calls.AddWorkspace = append(mock.calls.AddWorkspace, callInfo)
+	mock.lockAddWorkspace.Unlock()
+	return mock.AddWorkspaceFunc(ctx, db, ws)
+}
+
+// AddWorkspaceCalls gets all the calls that were made to AddWorkspace.
+// Check the length with:
+//
+//	len(mockedWorkspaceResolver.AddWorkspaceCalls())
+func (mock *WorkspaceResolverMock) AddWorkspaceCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ws  *entity.Workspace
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ws  *entity.Workspace
+	}
+	mock.lockAddWorkspace.RLock()
+	calls = mock.calls.AddWorkspace
+	mock.lockAddWorkspace.RUnlock()
+	return calls
+}
+
+// AddWorkspaceMember calls AddWorkspaceMemberFunc.
+func (mock *WorkspaceResolverMock) AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+	if mock.AddWorkspaceMemberFunc == nil {
+		panic("WorkspaceResolverMock.AddWorkspaceMemberFunc: method is nil but WorkspaceResolver.AddWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}{
+		Ctx: ctx,
+		Db:  db,
+		M:   m,
+	}
+	mock.lockAddWorkspaceMember.Lock()
+	mock.calls.AddWorkspaceMember = append(mock.calls.AddWorkspaceMember, callInfo)
+	mock.lockAddWorkspaceMember.Unlock()
+	return mock.AddWorkspaceMemberFunc(ctx, db, m)
+}
+
+// AddWorkspaceMemberCalls gets all the calls that were made to AddWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedWorkspaceResolver.AddWorkspaceMemberCalls())
+func (mock *WorkspaceResolverMock) AddWorkspaceMemberCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	M   *entity.WorkspaceMember
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}
+	mock.lockAddWorkspaceMember.RLock()
+	calls = mock.calls.AddWorkspaceMember
+	mock.lockAddWorkspaceMember.RUnlock()
+	return calls
+}
+
+// GetPersonalWorkspace calls GetPersonalWorkspaceFunc.
+func (mock *WorkspaceResolverMock) GetPersonalWorkspace(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Workspace, error) {
+	if mock.GetPersonalWorkspaceFunc == nil {
+		panic("WorkspaceResolverMock.GetPersonalWorkspaceFunc: method is nil but WorkspaceResolver.GetPersonalWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockGetPersonalWorkspace.Lock()
+	mock.calls.GetPersonalWorkspace = append(mock.calls.GetPersonalWorkspace, callInfo)
+	mock.lockGetPersonalWorkspace.Unlock()
+	return mock.GetPersonalWorkspaceFunc(ctx, db, userID)
+}
+
+// GetPersonalWorkspaceCalls gets all the calls that were made to GetPersonalWorkspace.
+// Check the length with:
+//
+//	len(mockedWorkspaceResolver.GetPersonalWorkspaceCalls())
+func (mock *WorkspaceResolverMock) GetPersonalWorkspaceCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockGetPersonalWorkspace.RLock()
+	calls = mock.calls.GetPersonalWorkspace
+	mock.lockGetPersonalWorkspace.RUnlock()
+	return calls
+}
+
+// GetWorkspaceMember calls GetWorkspaceMemberFunc.
+func (mock *WorkspaceResolverMock) GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+	if mock.GetWorkspaceMemberFunc == nil {
+		panic("WorkspaceResolverMock.GetWorkspaceMemberFunc: method is nil but WorkspaceResolver.GetWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}{
+		Ctx:         ctx,
+		Db:          db,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	}
+	mock.lockGetWorkspaceMember.Lock()
+	mock.calls.GetWorkspaceMember = append(mock.calls.GetWorkspaceMember, callInfo)
+	mock.lockGetWorkspaceMember.Unlock()
+	return mock.GetWorkspaceMemberFunc(ctx, db, workspaceID, userID)
+}
+
+// GetWorkspaceMemberCalls gets all the calls that were made to GetWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedWorkspaceResolver.GetWorkspaceMemberCalls())
+func (mock *WorkspaceResolverMock) GetWorkspaceMemberCalls() []struct {
+	Ctx         context.Context
+	Db          store.Queryer
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}
+	mock.lockGetWorkspaceMember.RLock()
+	calls = mock.calls.GetWorkspaceMember
+	mock.lockGetWorkspaceMember.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkspaceAdderMock does implement WorkspaceAdder.
+// If this is not the case, regenerate this file with moq.
+var _ WorkspaceAdder = &WorkspaceAdderMock{}
+
+// WorkspaceAdderMock is a mock implementation of WorkspaceAdder.
+//
+//	func TestSomethingThatUsesWorkspaceAdder(t *testing.T) {
+//
+//		// make and configure a mocked WorkspaceAdder
+//		mockedWorkspaceAdder := &WorkspaceAdderMock{
+//			AddWorkspaceFunc: func(ctx context.Context, db store.Execer, ws *entity.Workspace) error {
+//				panic("mock out the AddWorkspace method")
+//			},
+//			AddWorkspaceMemberFunc: func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+//				panic("mock out the AddWorkspaceMember method")
+//			},
+//		}
+//
+//		// use mockedWorkspaceAdder in code that requires WorkspaceAdder
+//		// and then make assertions.
+//
+//	}
+type WorkspaceAdderMock struct {
+	// AddWorkspaceFunc mocks the AddWorkspace method.
+	AddWorkspaceFunc func(ctx context.Context, db store.Execer, ws *entity.Workspace) error
+
+	// AddWorkspaceMemberFunc mocks the AddWorkspaceMember method.
+	AddWorkspaceMemberFunc func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddWorkspace holds details about calls to the AddWorkspace method.
+		AddWorkspace []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Ws is the ws argument value.
+			Ws *entity.Workspace
+		}
+		// AddWorkspaceMember holds details about calls to the AddWorkspaceMember method.
+		AddWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// M is the m argument value.
+			M *entity.WorkspaceMember
+		}
+	}
+	lockAddWorkspace       sync.RWMutex
+	lockAddWorkspaceMember sync.RWMutex
+}
+
+// AddWorkspace calls AddWorkspaceFunc.
+func (mock *WorkspaceAdderMock) AddWorkspace(ctx context.Context, db store.Execer, ws *entity.Workspace) error {
+	if mock.AddWorkspaceFunc == nil {
+		panic("WorkspaceAdderMock.AddWorkspaceFunc: method is nil but WorkspaceAdder.AddWorkspace was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ws  *entity.Workspace
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ws:  ws,
+	}
+	mock.lockAddWorkspace.Lock()
+	mock.calls.AddWorkspace = append(mock.calls.AddWorkspace, callInfo)
+	mock.lockAddWorkspace.Unlock()
+	return mock.AddWorkspaceFunc(ctx, db, ws)
+}
+
+// AddWorkspaceCalls gets all the calls that were made to AddWorkspace.
+// Check the length with:
+//
+//	len(mockedWorkspaceAdder.AddWorkspaceCalls())
+func (mock *WorkspaceAdderMock) AddWorkspaceCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	Ws  *entity.Workspace
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		Ws  *entity.Workspace
+	}
+	mock.lockAddWorkspace.RLock()
+	calls = mock.calls.AddWorkspace
+	mock.lockAddWorkspace.RUnlock()
+	return calls
+}
+
+// AddWorkspaceMember calls AddWorkspaceMemberFunc.
+func (mock *WorkspaceAdderMock) AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+	if mock.AddWorkspaceMemberFunc == nil {
+		panic("WorkspaceAdderMock.AddWorkspaceMemberFunc: method is nil but WorkspaceAdder.AddWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}{
+		Ctx: ctx,
+		Db:  db,
+		M:   m,
+	}
+	mock.lockAddWorkspaceMember.Lock()
+	mock.calls.AddWorkspaceMember = append(mock.calls.AddWorkspaceMember, callInfo)
+	mock.lockAddWorkspaceMember.Unlock()
+	return mock.AddWorkspaceMemberFunc(ctx, db, m)
+}
+
+// AddWorkspaceMemberCalls gets all the calls that were made to AddWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedWorkspaceAdder.AddWorkspaceMemberCalls())
+func (mock *WorkspaceAdderMock) AddWorkspaceMemberCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	M   *entity.WorkspaceMember
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}
+	mock.lockAddWorkspaceMember.RLock()
+	calls = mock.calls.AddWorkspaceMember
+	mock.lockAddWorkspaceMember.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkspaceListerMock does implement WorkspaceLister.
+// If this is not the case, regenerate this file with moq.
+var _ WorkspaceLister = &WorkspaceListerMock{}
+
+// WorkspaceListerMock is a mock implementation of WorkspaceLister.
+//
+//	func TestSomethingThatUsesWorkspaceLister(t *testing.T) {
+//
+//		// make and configure a mocked WorkspaceLister
+//		mockedWorkspaceLister := &WorkspaceListerMock{
+//			ListWorkspacesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.WorkspaceMembership, error) {
+//				panic("mock out the ListWorkspaces method")
+//			},
+//		}
+//
+//		// use mockedWorkspaceLister in code that requires WorkspaceLister
+//		// and then make assertions.
+//
+//	}
+type WorkspaceListerMock struct {
+	// ListWorkspacesFunc mocks the ListWorkspaces method.
+	ListWorkspacesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.WorkspaceMembership, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListWorkspaces holds details about calls to the ListWorkspaces method.
+		ListWorkspaces []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockListWorkspaces sync.RWMutex
+}
+
+// ListWorkspaces calls ListWorkspacesFunc.
+func (mock *WorkspaceListerMock) ListWorkspaces(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.WorkspaceMembership, error) {
+	if mock.ListWorkspacesFunc == nil {
+		panic("WorkspaceListerMock.ListWorkspacesFunc: method is nil but WorkspaceLister.ListWorkspaces was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListWorkspaces.Lock()
+	mock.calls.ListWorkspaces = append(mock.calls.ListWorkspaces, callInfo)
+	mock.lockListWorkspaces.Unlock()
+	return mock.ListWorkspacesFunc(ctx, db, userID)
+}
+
+// ListWorkspacesCalls gets all the calls that were made to ListWorkspaces.
+// Check the length with:
+//
+//	len(mockedWorkspaceLister.ListWorkspacesCalls())
+func (mock *WorkspaceListerMock) ListWorkspacesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListWorkspaces.RLock()
+	calls = mock.calls.ListWorkspaces
+	mock.lockListWorkspaces.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkspaceMemberAdderMock does implement WorkspaceMemberAdder.
+// If this is not the case, regenerate this file with moq.
+var _ WorkspaceMemberAdder = &WorkspaceMemberAdderMock{}
+
+// WorkspaceMemberAdderMock is a mock implementation of WorkspaceMemberAdder.
+//
+//	func TestSomethingThatUsesWorkspaceMemberAdder(t *testing.T) {
+//
+//		// make and configure a mocked WorkspaceMemberAdder
+//		mockedWorkspaceMemberAdder := &WorkspaceMemberAdderMock{
+//			AddWorkspaceMemberFunc: func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+//				panic("mock out the AddWorkspaceMember method")
+//			},
+//			GetUserFunc: func(ctx context.Context, db store.Queryer, userName string) (*entity.User, error) {
+//				panic("mock out the GetUser method")
+//			},
+//			GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+//				panic("mock out the GetWorkspaceMember method")
+//			},
+//		}
+//
+//		// use mockedWorkspaceMemberAdder in code that requires WorkspaceMemberAdder
+//		// and then make assertions.
+//
+//	}
+type WorkspaceMemberAdderMock struct {
+	// AddWorkspaceMemberFunc mocks the AddWorkspaceMember method.
+	AddWorkspaceMemberFunc func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(ctx context.Context, db store.Queryer, userName string) (*entity.User, error)
+
+	// GetWorkspaceMemberFunc mocks the GetWorkspaceMember method.
+	GetWorkspaceMemberFunc func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddWorkspaceMember holds details about calls to the AddWorkspaceMember method.
+		AddWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// M is the m argument value.
+			M *entity.WorkspaceMember
+		}
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserName is the userName argument value.
+			UserName string
+		}
+		// GetWorkspaceMember holds details about calls to the GetWorkspaceMember method.
+		GetWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockAddWorkspaceMember sync.RWMutex
+	lockGetUser            sync.RWMutex
+	lockGetWorkspaceMember sync.RWMutex
+}
+
+// AddWorkspaceMember calls AddWorkspaceMemberFunc.
+func (mock *WorkspaceMemberAdderMock) AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+	if mock.AddWorkspaceMemberFunc == nil {
+		panic("WorkspaceMemberAdderMock.AddWorkspaceMemberFunc: method is nil but WorkspaceMemberAdder.AddWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}{
+		Ctx: ctx,
+		Db:  db,
+		M:   m,
+	}
+	mock.lockAddWorkspaceMember.Lock()
+	mock.calls.AddWorkspaceMember = append(mock.calls.AddWorkspaceMember, callInfo)
+	mock.lockAddWorkspaceMember.Unlock()
+	return mock.AddWorkspaceMemberFunc(ctx, db, m)
+}
+
+// AddWorkspaceMemberCalls gets all the calls that were made to AddWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedWorkspaceMemberAdder.AddWorkspaceMemberCalls())
+func (mock *WorkspaceMemberAdderMock) AddWorkspaceMemberCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	M   *entity.WorkspaceMember
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.WorkspaceMember
+	}
+	mock.lockAddWorkspaceMember.RLock()
+	calls = mock.calls.AddWorkspaceMember
+	mock.lockAddWorkspaceMember.RUnlock()
+	return calls
+}
+
+// GetUser calls GetUserFunc.
+func (mock *WorkspaceMemberAdderMock) GetUser(ctx context.Context, db store.Queryer, userName string) (*entity.User, error) {
+	if mock.GetUserFunc == nil {
+		panic("WorkspaceMemberAdderMock.GetUserFunc: method is nil but WorkspaceMemberAdder.GetUser was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UserName string
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		UserName: userName,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(ctx, db, userName)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedWorkspaceMemberAdder.GetUserCalls())
+func (mock *WorkspaceMemberAdderMock) GetUserCalls() []struct {
+	Ctx      context.Context
+	Db       store.Queryer
+	UserName string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Queryer
+		UserName string
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// GetWorkspaceMember calls GetWorkspaceMemberFunc.
+func (mock *WorkspaceMemberAdderMock) GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+	if mock.GetWorkspaceMemberFunc == nil {
+		panic("WorkspaceMemberAdderMock.GetWorkspaceMemberFunc: method is nil but WorkspaceMemberAdder.GetWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}{
+		Ctx:         ctx,
+		Db:          db,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	}
+	mock.lockGetWorkspaceMember.Lock()
+	mock.calls.GetWorkspaceMember = append(mock.calls.GetWorkspaceMember, callInfo)
+	mock.lockGetWorkspaceMember.Unlock()
+	return mock.GetWorkspaceMemberFunc(ctx, db, workspaceID, userID)
+}
+
+// GetWorkspaceMemberCalls gets all the calls that were made to GetWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedWorkspaceMemberAdder.GetWorkspaceMemberCalls())
+func (mock *WorkspaceMemberAdderMock) GetWorkspaceMemberCalls() []struct {
+	Ctx         context.Context
+	Db          store.Queryer
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}
+	mock.lockGetWorkspaceMember.RLock()
+	calls = mock.calls.GetWorkspaceMember
+	mock.lockGetWorkspaceMember.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectAdderMock does implement ProjectAdder.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectAdder = &ProjectAdderMock{}
+
+// ProjectAdderMock is a mock implementation of ProjectAdder.
+//
+//	func TestSomethingThatUsesProjectAdder(t *testing.T) {
+//
+//		// make and configure a mocked ProjectAdder
+//		mockedProjectAdder := &ProjectAdderMock{
+//			AddProjectFunc: func(ctx context.Context, db store.Execer, p *entity.Project) error {
+//				panic("mock out the AddProject method")
+//			},
+//		}
+//
+//		// use mockedProjectAdder in code that requires ProjectAdder
+//		// and then make assertions.
+//
+//	}
+type ProjectAdderMock struct {
+	// AddProjectFunc mocks the AddProject method.
+	AddProjectFunc func(ctx context.Context, db store.Execer, p *entity.Project) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddProject holds details about calls to the AddProject method.
+		AddProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// P is the p argument value.
+			P *entity.Project
+		}
+	}
+	lockAddProject sync.RWMutex
+}
+
+// AddProject calls AddProjectFunc.
+func (mock *ProjectAdderMock) AddProject(ctx context.Context, db store.Execer, p *entity.Project) error {
+	if mock.AddProjectFunc == nil {
+		panic("ProjectAdderMock.AddProjectFunc: method is nil but ProjectAdder.AddProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}{
+		Ctx: ctx,
+		Db:  db,
+		P:   p,
+	}
+	mock.lockAddProject.Lock()
+	mock.calls.AddProject = append(mock.calls.AddProject, callInfo)
+	mock.lockAddProject.Unlock()
+	return mock.AddProjectFunc(ctx, db, p)
+}
+
+// AddProjectCalls gets all the calls that were made to AddProject.
+// Check the length with:
+//
+//	len(mockedProjectAdder.AddProjectCalls())
+func (mock *ProjectAdderMock) AddProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	P   *entity.Project
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		P   *entity.Project
+	}
+	mock.lockAddProject.RLock()
+	calls = mock.calls.AddProject
+	mock.lockAddProject.RUnlock()
+	return calls
+}
+
+// Ensure, that ProjectListerMock does implement ProjectLister.
+// If this is not the case, regenerate this file with moq.
+var _ ProjectLister = &ProjectListerMock{}
+
+// ProjectListerMock is a mock implementation of ProjectLister.
+//
+//	func TestSomethingThatUsesProjectLister(t *testing.T) {
+//
+//		// make and configure a mocked ProjectLister
+//		mockedProjectLister := &ProjectListerMock{
+//			ListProjectsFunc: func(ctx context.Context, db store.Queryer) (entity.Projects, error) {
+//				panic("mock out the ListProjects method")
+//			},
+//		}
+//
+//		// use mockedProjectLister in code that requires ProjectLister
+//		// and then make assertions.
+//
+//	}
+type ProjectListerMock struct {
+	// ListProjectsFunc mocks the ListProjects method.
+	ListProjectsFunc func(ctx context.Context, db store.Queryer) (entity.Projects, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjects holds details about calls to the ListProjects method.
+		ListProjects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+		}
+	}
+	lockListProjects sync.RWMutex
+}
+
+// ListProjects calls ListProjectsFunc.
+func (mock *ProjectListerMock) ListProjects(ctx context.Context, db store.Queryer) (entity.Projects, error) {
+	if mock.ListProjectsFunc == nil {
+		panic("ProjectListerMock.ListProjectsFunc: method is nil but ProjectLister.ListProjects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}{
+		Ctx: ctx,
+		Db:  db,
+	}
+	mock.lockListProjects.Lock()
+	mock.calls.ListProjects = append(mock.calls.ListProjects, callInfo)
+	mock.lockListProjects.Unlock()
+	return mock.ListProjectsFunc(ctx, db)
+}
+
+// ListProjectsCalls gets all the calls that were made to ListProjects.
+// Check the length with:
+//
+//	len(mockedProjectLister.ListProjectsCalls())
+func (mock *ProjectListerMock) ListProjectsCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}
+	mock.lockListProjects.RLock()
+	calls = mock.calls.ListProjects
+	mock.lockListProjects.RUnlock()
+	return calls
+}
+
+// Ensure, that StatsGetterMock does implement StatsGetter.
+// If this is not the case, regenerate this file with moq.
+var _ StatsGetter = &StatsGetterMock{}
+
+// StatsGetterMock is a mock implementation of StatsGetter.
+//
+//	func TestSomethingThatUsesStatsGetter(t *testing.T) {
+//
+//		// make and configure a mocked StatsGetter
+//		mockedStatsGetter := &StatsGetterMock{
+//			GetStatsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, windowDays int, loc *time.Location) (*entity.Stats, error) {
+//				panic("mock out the GetStats method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedStatsGetter in code that requires StatsGetter
+//		// and then make assertions.
+//
+//	}
+type StatsGetterMock struct {
+	// GetStatsFunc mocks the GetStats method.
+	GetStatsFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, windowDays int, loc *time.Location) (*entity.Stats, error)
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetStats holds details about calls to the GetStats method.
+		GetStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// WindowDays is the windowDays argument value.
+			WindowDays int
+			// Loc is the loc argument value.
+			Loc *time.Location
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockGetStats    sync.RWMutex
+	lockGetUserByID sync.RWMutex
+}
+
+// GetStats calls GetStatsFunc.
+func (mock *StatsGetterMock) GetStats(ctx context.Context, db store.Queryer, userID entity.UserID, windowDays int, loc *time.Location) (*entity.Stats, error) {
+	if mock.GetStatsFunc == nil {
+		panic("StatsGetterMock.GetStatsFunc: method is nil but StatsGetter.GetStats was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		UserID     entity.UserID
+		WindowDays int
+		Loc        *time.Location
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		UserID:     userID,
+		WindowDays: windowDays,
+		Loc:        loc,
+	}
+	mock.lockGetStats.Lock()
+	mock.calls.GetStats = append(mock.calls.GetStats, callInfo)
+	mock.lockGetStats.Unlock()
+	return mock.GetStatsFunc(ctx, db, userID, windowDays, loc)
+}
+
+// GetStatsCalls gets all the calls that were made to GetStats.
+// Check the length with:
+//
+//	len(mockedStatsGetter.GetStatsCalls())
+func (mock *StatsGetterMock) GetStatsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	UserID     entity.UserID
+	WindowDays int
+	Loc        *time.Location
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		UserID     entity.UserID
+		WindowDays int
+		Loc        *time.Location
+	}
+	mock.lockGetStats.RLock()
+	calls = mock.calls.GetStats
+	mock.lockGetStats.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *StatsGetterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("StatsGetterMock.GetUserByIDFunc: method is nil but StatsGetter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedStatsGetter.GetUserByIDCalls())
+func (mock *StatsGetterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that StatsCacheMock does implement StatsCache.
+// If this is not the case, regenerate this file with moq.
+var _ StatsCache = &StatsCacheMock{}
+
+// StatsCacheMock is a mock implementation of StatsCache.
+//
+//	func TestSomethingThatUsesStatsCache(t *testing.T) {
+//
+//		// make and configure a mocked StatsCache
+//		mockedStatsCache := &StatsCacheMock{
+//			LoadStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int) (*entity.Stats, error) {
+//				panic("mock out the LoadStats method")
+//			},
+//			SaveStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats) error {
+//				panic("mock out the SaveStats method")
+//			},
+//		}
+//
+//		// use mockedStatsCache in code that requires StatsCache
+//		// and then make assertions.
+//
+//	}
+type StatsCacheMock struct {
+	// LoadStatsFunc mocks the LoadStats method.
+	LoadStatsFunc func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int) (*entity.Stats, error)
+
+	// SaveStatsFunc mocks the SaveStats method.
+	SaveStatsFunc func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// LoadStats holds details about calls to the LoadStats method.
+		LoadStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// WindowDays is the windowDays argument value.
+			WindowDays int
+		}
+		// SaveStats holds details about calls to the SaveStats method.
+		SaveStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// WindowDays is the windowDays argument value.
+			WindowDays int
+			// Stats is the stats argument value.
+			Stats *entity.Stats
+		}
+	}
+	lockLoadStats sync.RWMutex
+	lockSaveStats sync.RWMutex
+}
+
+// LoadStats calls LoadStatsFunc.
+func (mock *StatsCacheMock) LoadStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int) (*entity.Stats, error) {
+	if mock.LoadStatsFunc == nil {
+		panic("StatsCacheMock.LoadStatsFunc: method is nil but StatsCache.LoadStats was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+		WindowDays  int
+	}{
+		Ctx:         ctx,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		WindowDays:  windowDays,
+	}
+	mock.lockLoadStats.Lock()
+	mock.calls.LoadStats = append(mock.calls.LoadStats, callInfo)
+	mock.lockLoadStats.Unlock()
+	return mock.LoadStatsFunc(ctx, workspaceID, userID, windowDays)
+}
+
+// LoadStatsCalls gets all the calls that were made to LoadStats.
+// Check the length with:
+//
+//	len(mockedStatsCache.LoadStatsCalls())
+func (mock *StatsCacheMock) LoadStatsCalls() []struct {
+	Ctx         context.Context
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+	WindowDays  int
+} {
+	var calls []struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+		WindowDays  int
+	}
+	mock.lockLoadStats.RLock()
+	calls = mock.calls.LoadStats
+	mock.lockLoadStats.RUnlock()
+	return calls
+}
+
+// SaveStats calls SaveStatsFunc.
+func (mock *StatsCacheMock) SaveStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats) error {
+	if mock.SaveStatsFunc == nil {
+		panic("StatsCacheMock.SaveStatsFunc: method is nil but StatsCache.SaveStats was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+		WindowDays  int
+		Stats       *entity.Stats
+	}{
+		Ctx:         ctx,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		WindowDays:  windowDays,
+		Stats:       stats,
+	}
+	mock.lockSaveStats.Lock()
+	mock.calls.SaveStats = append(mock.calls.SaveStats, callInfo)
+	mock.lockSaveStats.Unlock()
+	return mock.SaveStatsFunc(ctx, workspaceID, userID, windowDays, stats)
+}
+
+// SaveStatsCalls gets all the calls that were made to SaveStats.
+// Check the length with:
+//
+//	len(mockedStatsCache.SaveStatsCalls())
+func (mock *StatsCacheMock) SaveStatsCalls() []struct {
+	Ctx         context.Context
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+	WindowDays  int
+	Stats       *entity.Stats
+} {
+	var calls []struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+		WindowDays  int
+		Stats       *entity.Stats
+	}
+	mock.lockSaveStats.RLock()
+	calls = mock.calls.SaveStats
+	mock.lockSaveStats.RUnlock()
+	return calls
+}
+
+// Ensure, that StatsInvalidatorMock does implement StatsInvalidator.
+// If this is not the case, regenerate this file with moq.
+var _ StatsInvalidator = &StatsInvalidatorMock{}
+
+// StatsInvalidatorMock is a mock implementation of StatsInvalidator.
+//
+//	func TestSomethingThatUsesStatsInvalidator(t *testing.T) {
+//
+//		// make and configure a mocked StatsInvalidator
+//		mockedStatsInvalidator := &StatsInvalidatorMock{
+//			InvalidateStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error {
+//				panic("mock out the InvalidateStats method")
+//			},
+//		}
+//
+//		// use mockedStatsInvalidator in code that requires StatsInvalidator
+//		// and then make assertions.
+//
+//	}
+type StatsInvalidatorMock struct {
+	// InvalidateStatsFunc mocks the InvalidateStats method.
+	InvalidateStatsFunc func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// InvalidateStats holds details about calls to the InvalidateStats method.
+		InvalidateStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockInvalidateStats sync.RWMutex
+}
+
+// InvalidateStats calls InvalidateStatsFunc.
+func (mock *StatsInvalidatorMock) InvalidateStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error {
+	if mock.InvalidateStatsFunc == nil {
+		panic("StatsInvalidatorMock.InvalidateStatsFunc: method is nil but StatsInvalidator.InvalidateStats was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}{
+		Ctx:         ctx,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	}
+	mock.lockInvalidateStats.Lock()
+	mock.calls.InvalidateStats = append(mock.calls.InvalidateStats, callInfo)
+	mock.lockInvalidateStats.Unlock()
+	return mock.InvalidateStatsFunc(ctx, workspaceID, userID)
+}
+
+// InvalidateStatsCalls gets all the calls that were made to InvalidateStats.
+// Check the length with:
+//
+//	len(mockedStatsInvalidator.InvalidateStatsCalls())
+func (mock *StatsInvalidatorMock) InvalidateStatsCalls() []struct {
+	Ctx         context.Context
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+} {
+	var calls []struct {
+		Ctx         context.Context
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}
+	mock.lockInvalidateStats.RLock()
+	calls = mock.calls.InvalidateStats
+	mock.lockInvalidateStats.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskAssignerMock does implement TaskAssigner.
+// If this is not the case, regenerate this file with moq.
+var _ TaskAssigner = &TaskAssignerMock{}
+
+// TaskAssignerMock is a mock implementation of TaskAssigner.
+//
+//	func TestSomethingThatUsesTaskAssigner(t *testing.T) {
+//
+//		// make and configure a mocked TaskAssigner
+//		mockedTaskAssigner := &TaskAssignerMock{
+//			AssignTaskFunc: func(ctx context.Context, db store.Execer, id entity.TaskID, assigneeID *entity.UserID) error {
+//				panic("mock out the AssignTask method")
+//			},
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+//				panic("mock out the GetWorkspaceMember method")
+//			},
+//		}
+//
+//		// use mockedTaskAssigner in code that requires TaskAssigner
+//		// and then make assertions.
+//
+//	}
+type TaskAssignerMock struct {
+	// AssignTaskFunc mocks the AssignTask method.
+	AssignTaskFunc func(ctx context.Context, db store.Execer, id entity.TaskID, assigneeID *entity.UserID) error
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+
+	// GetWorkspaceMemberFunc mocks the GetWorkspaceMember method.
+	GetWorkspaceMemberFunc func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AssignTask holds details about calls to the AssignTask method.
+		AssignTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.TaskID
+			// AssigneeID is the assigneeID argument value.
+			AssigneeID *entity.UserID
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// GetWorkspaceMember holds details about calls to the GetWorkspaceMember method.
+		GetWorkspaceMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// WorkspaceID is the workspaceID argument value.
+			WorkspaceID entity.WorkspaceID
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockAssignTask         sync.RWMutex
+	lockGetTask            sync.RWMutex
+	lockGetWorkspaceMember sync.RWMutex
+}
+
+// AssignTask calls AssignTaskFunc.
+func (mock *TaskAssignerMock) AssignTask(ctx context.Context, db store.Execer, id entity.TaskID, assigneeID *entity.UserID) error {
+	if mock.AssignTaskFunc == nil {
+		panic("TaskAssignerMock.AssignTaskFunc: method is nil but TaskAssigner.AssignTask was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Execer
+		ID         entity.TaskID
+		AssigneeID *entity.UserID
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		ID:         id,
+		AssigneeID: assigneeID,
+	}
+	mock.lockAssignTask.Lock()
+	mock.calls.AssignTask = append(mock.calls.AssignTask, callInfo)
+	mock.lockAssignTask.Unlock()
+	return mock.AssignTaskFunc(ctx, db, id, assigneeID)
+}
+
+// AssignTaskCalls gets all the calls that were made to AssignTask.
+// Check the length with:
+//
+//	len(mockedTaskAssigner.AssignTaskCalls())
+func (mock *TaskAssignerMock) AssignTaskCalls() []struct {
+	Ctx        context.Context
+	Db         store.Execer
+	ID         entity.TaskID
+	AssigneeID *entity.UserID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Execer
+		ID         entity.TaskID
+		AssigneeID *entity.UserID
+	}
+	mock.lockAssignTask.RLock()
+	calls = mock.calls.AssignTask
+	mock.lockAssignTask.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskAssignerMock) GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskAssignerMock.GetTaskFunc: method is nil but TaskAssigner.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskAssigner.GetTaskCalls())
+func (mock *TaskAssignerMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// GetWorkspaceMember calls GetWorkspaceMemberFunc.
+func (mock *TaskAssignerMock) GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+	if mock.GetWorkspaceMemberFunc == nil {
+		panic("TaskAssignerMock.GetWorkspaceMemberFunc: method is nil but TaskAssigner.GetWorkspaceMember was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}{
+		Ctx:         ctx,
+		Db:          db,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	}
+	mock.lockGetWorkspaceMember.Lock()
+	mock.calls.GetWorkspaceMember = append(mock.calls.GetWorkspaceMember, callInfo)
+	mock.lockGetWorkspaceMember.Unlock()
+	return mock.GetWorkspaceMemberFunc(ctx, db, workspaceID, userID)
+}
+
+// GetWorkspaceMemberCalls gets all the calls that were made to GetWorkspaceMember.
+// Check the length with:
+//
+//	len(mockedTaskAssigner.GetWorkspaceMemberCalls())
+func (mock *TaskAssignerMock) GetWorkspaceMemberCalls() []struct {
+	Ctx         context.Context
+	Db          store.Queryer
+	WorkspaceID entity.WorkspaceID
+	UserID      entity.UserID
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Db          store.Queryer
+		WorkspaceID entity.WorkspaceID
+		UserID      entity.UserID
+	}
+	mock.lockGetWorkspaceMember.RLock()
+	calls = mock.calls.GetWorkspaceMember
+	mock.lockGetWorkspaceMember.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskProjectSetterMock does implement TaskProjectSetter.
+// If this is not the case, regenerate this file with moq.
+var _ TaskProjectSetter = &TaskProjectSetterMock{}
+
+// TaskProjectSetterMock is a mock implementation of TaskProjectSetter.
+//
+//	func TestSomethingThatUsesTaskProjectSetter(t *testing.T) {
+//
+//		// make and configure a mocked TaskProjectSetter
+//		mockedTaskProjectSetter := &TaskProjectSetterMock{
+//			GetProjectFunc: func(ctx context.Context, db store.Queryer, id entity.ProjectID) (*entity.Project, error) {
+//				panic("mock out the GetProject method")
+//			},
+//			GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+//				panic("mock out the GetTask method")
+//			},
+//			SetTaskProjectFunc: func(ctx context.Context, db store.Execer, id entity.TaskID, projectID *entity.ProjectID) error {
+//				panic("mock out the SetTaskProject method")
+//			},
+//		}
+//
+//		// use mockedTaskProjectSetter in code that requires TaskProjectSetter
+//		// and then make assertions.
+//
+//	}
+type TaskProjectSetterMock struct {
+	// GetProjectFunc mocks the GetProject method.
+	GetProjectFunc func(ctx context.Context, db store.Queryer, id entity.ProjectID) (*entity.Project, error)
+
+	// GetTaskFunc mocks the GetTask method.
+	GetTaskFunc func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+
+	// SetTaskProjectFunc mocks the SetTaskProject method.
+	SetTaskProjectFunc func(ctx context.Context, db store.Execer, id entity.TaskID, projectID *entity.ProjectID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetProject holds details about calls to the GetProject method.
+		GetProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.ProjectID
+		}
+		// GetTask holds details about calls to the GetTask method.
+		GetTask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.TaskID
+		}
+		// SetTaskProject holds details about calls to the SetTaskProject method.
+		SetTaskProject []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.TaskID
+			// ProjectID is the projectID argument value.
+			ProjectID *entity.ProjectID
+		}
+	}
+	lockGetProject     sync.RWMutex
+	lockGetTask        sync.RWMutex
+	lockSetTaskProject sync.RWMutex
+}
+
+// GetProject calls GetProjectFunc.
+func (mock *TaskProjectSetterMock) GetProject(ctx context.Context, db store.Queryer, id entity.ProjectID) (*entity.Project, error) {
+	if mock.GetProjectFunc == nil {
+		panic("TaskProjectSetterMock.GetProjectFunc: method is nil but TaskProjectSetter.GetProject was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetProject.Lock()
+	mock.calls.GetProject = append(mock.calls.GetProject, callInfo)
+	mock.lockGetProject.Unlock()
+	return mock.GetProjectFunc(ctx, db, id)
+}
+
+// GetProjectCalls gets all the calls that were made to GetProject.
+// Check the length with:
+//
+//	len(mockedTaskProjectSetter.GetProjectCalls())
+func (mock *TaskProjectSetterMock) GetProjectCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.ProjectID
+	}
+	mock.lockGetProject.RLock()
+	calls = mock.calls.GetProject
+	mock.lockGetProject.RUnlock()
+	return calls
+}
+
+// GetTask calls GetTaskFunc.
+func (mock *TaskProjectSetterMock) GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+	if mock.GetTaskFunc == nil {
+		panic("TaskProjectSetterMock.GetTaskFunc: method is nil but TaskProjectSetter.GetTask was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetTask.Lock()
+	mock.calls.GetTask = append(mock.calls.GetTask, callInfo)
+	mock.lockGetTask.Unlock()
+	return mock.GetTaskFunc(ctx, db, id)
+}
+
+// GetTaskCalls gets all the calls that were made to GetTask.
+// Check the length with:
+//
+//	len(mockedTaskProjectSetter.GetTaskCalls())
+func (mock *TaskProjectSetterMock) GetTaskCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.TaskID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.TaskID
+	}
+	mock.lockGetTask.RLock()
+	calls = mock.calls.GetTask
+	mock.lockGetTask.RUnlock()
+	return calls
+}
+
+// SetTaskProject calls SetTaskProjectFunc.
+func (mock *TaskProjectSetterMock) SetTaskProject(ctx context.Context, db store.Execer, id entity.TaskID, projectID *entity.ProjectID) error {
+	if mock.SetTaskProjectFunc == nil {
+		panic("TaskProjectSetterMock.SetTaskProjectFunc: method is nil but TaskProjectSetter.SetTaskProject was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Db        store.Execer
+		ID        entity.TaskID
+		ProjectID *entity.ProjectID
+	}{
+		Ctx:       ctx,
+		Db:        db,
+		ID:        id,
+		ProjectID: projectID,
+	}
+	mock.lockSetTaskProject.Lock()
+	mock.calls.SetTaskProject = append(mock.calls.SetTaskProject, callInfo)
+	mock.lockSetTaskProject.Unlock()
+	return mock.SetTaskProjectFunc(ctx, db, id, projectID)
+}
+
+// SetTaskProjectCalls gets all the calls that were made to SetTaskProject.
+// Check the length with:
+//
+//	len(mockedTaskProjectSetter.SetTaskProjectCalls())
+func (mock *TaskProjectSetterMock) SetTaskProjectCalls() []struct {
+	Ctx       context.Context
+	Db        store.Execer
+	ID        entity.TaskID
+	ProjectID *entity.ProjectID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Db        store.Execer
+		ID        entity.TaskID
+		ProjectID *entity.ProjectID
+	}
+	mock.lockSetTaskProject.RLock()
+	calls = mock.calls.SetTaskProject
+	mock.lockSetTaskProject.RUnlock()
+	return calls
+}
+
+// Ensure, that WorkloadGetterMock does implement WorkloadGetter.
+// If this is not the case, regenerate this file with moq.
+var _ WorkloadGetter = &WorkloadGetterMock{}
+
+// WorkloadGetterMock is a mock implementation of WorkloadGetter.
+//
+//	func TestSomethingThatUsesWorkloadGetter(t *testing.T) {
+//
+//		// make and configure a mocked WorkloadGetter
+//		mockedWorkloadGetter := &WorkloadGetterMock{
+//			GetWorkloadFunc: func(ctx context.Context, db store.Queryer) ([]*entity.Workload, error) {
+//				panic("mock out the GetWorkload method")
+//			},
+//		}
+//
+//		// use mockedWorkloadGetter in code that requires WorkloadGetter
+//		// and then make assertions.
+//
+//	}
+type WorkloadGetterMock struct {
+	// GetWorkloadFunc mocks the GetWorkload method.
+	GetWorkloadFunc func(ctx context.Context, db store.Queryer) ([]*entity.Workload, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetWorkload holds details about calls to the GetWorkload method.
+		GetWorkload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+		}
+	}
+	lockGetWorkload sync.RWMutex
+}
+
+// GetWorkload calls GetWorkloadFunc.
+func (mock *WorkloadGetterMock) GetWorkload(ctx context.Context, db store.Queryer) ([]*entity.Workload, error) {
+	if mock.GetWorkloadFunc == nil {
+		panic("WorkloadGetterMock.GetWorkloadFunc: method is nil but WorkloadGetter.GetWorkload was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}{
+		Ctx: ctx,
+		Db:  db,
+	}
+	mock.lockGetWorkload.Lock()
+	mock.calls.GetWorkload = append(mock.calls.GetWorkload, callInfo)
+	mock.lockGetWorkload.Unlock()
+	return mock.GetWorkloadFunc(ctx, db)
+}
+
+// GetWorkloadCalls gets all the calls that were made to GetWorkload.
+// Check the length with:
+//
+//	len(mockedWorkloadGetter.GetWorkloadCalls())
+func (mock *WorkloadGetterMock) GetWorkloadCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}
+	mock.lockGetWorkload.RLock()
+	calls = mock.calls.GetWorkload
+	mock.lockGetWorkload.RUnlock()
+	return calls
+}
+
+// Ensure, that NotifierMock does implement Notifier.
+// If this is not the case, regenerate this file with moq.
+var _ Notifier = &NotifierMock{}
+
+// NotifierMock is a mock implementation of Notifier.
+//
+//	func TestSomethingThatUsesNotifier(t *testing.T) {
+//
+//		// make and configure a mocked Notifier
+//		mockedNotifier := &NotifierMock{
+//			NotifyFunc: func(ctx context.Context, n *entity.Notification) error {
+//				panic("mock out the Notify method")
+//			},
+//		}
+//
+//		// use mockedNotifier in code that requires Notifier
+//		// and then make assertions.
+//
+//	}
+type NotifierMock struct {
+	// NotifyFunc mocks the Notify method.
+	NotifyFunc func(ctx context.Context, n *entity.Notification) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Notify holds details about calls to the Notify method.
+		Notify []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// N is the n argument value.
+			N *entity.Notification
+		}
+	}
+	lockNotify sync.RWMutex
+}
+
+// Notify calls NotifyFunc.
+func (mock *NotifierMock) Notify(ctx context.Context, n *entity.Notification) error {
+	if mock.NotifyFunc == nil {
+		panic("NotifierMock.NotifyFunc: method is nil but Notifier.Notify was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		N   *entity.Notification
+	}{
+		Ctx: ctx,
+		N:   n,
+	}
+	mock.lockNotify.Lock()
+	mock.calls.Notify = append(mock.calls.Notify, callInfo)
+	mock.lockNotify.Unlock()
+	return mock.NotifyFunc(ctx, n)
+}
+
+// NotifyCalls gets all the calls that were made to Notify.
+// Check the length with:
+//
+//	len(mockedNotifier.NotifyCalls())
+func (mock *NotifierMock) NotifyCalls() []struct {
+	Ctx context.Context
+	N   *entity.Notification
+} {
+	var calls []struct {
+		Ctx context.Context
+		N   *entity.Notification
+	}
+	mock.lockNotify.RLock()
+	calls = mock.calls.Notify
+	mock.lockNotify.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationAdderMock does implement NotificationAdder.
+// If this is not the case, regenerate this file with moq.
+var _ NotificationAdder = &NotificationAdderMock{}
+
+// NotificationAdderMock is a mock implementation of NotificationAdder.
+//
+//	func TestSomethingThatUsesNotificationAdder(t *testing.T) {
+//
+//		// make and configure a mocked NotificationAdder
+//		mockedNotificationAdder := &NotificationAdderMock{
+//			AddNotificationFunc: func(ctx context.Context, db store.Execer, n *entity.Notification) error {
+//				panic("mock out the AddNotification method")
+//			},
+//			ListNotificationPreferencesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+//				panic("mock out the ListNotificationPreferences method")
+//			},
+//		}
+//
+//		// use mockedNotificationAdder in code that requires NotificationAdder
+//		// and then make assertions.
+//
+//	}
+type NotificationAdderMock struct {
+	// AddNotificationFunc mocks the AddNotification method.
+	AddNotificationFunc func(ctx context.Context, db store.Execer, n *entity.Notification) error
+
+	// ListNotificationPreferencesFunc mocks the ListNotificationPreferences method.
+	ListNotificationPreferencesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddNotification holds details about calls to the AddNotification method.
+		AddNotification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// N is the n argument value.
+			N *entity.Notification
+		}
+		// ListNotificationPreferences holds details about calls to the ListNotificationPreferences method.
+		ListNotificationPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockAddNotification             sync.RWMutex
+	lockListNotificationPreferences sync.RWMutex
+}
+
+// AddNotification calls AddNotificationFunc.
+func (mock *NotificationAdderMock) AddNotification(ctx context.Context, db store.Execer, n *entity.Notification) error {
+	if mock.AddNotificationFunc == nil {
+		panic("NotificationAdderMock.AddNotificationFunc: method is nil but NotificationAdder.AddNotification was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		N   *entity.Notification
+	}{
+		Ctx: ctx,
+		Db:  db,
+		N:   n,
+	}
+	mock.lockAddNotification.Lock()
+	mock.calls.AddNotification = append(mock.calls.AddNotification, callInfo)
+	mock.lockAddNotification.Unlock()
+	return mock.AddNotificationFunc(ctx, db, n)
+}
+
+// AddNotificationCalls gets all the calls that were made to AddNotification.
+// Check the length with:
+//
+//	len(mockedNotificationAdder.AddNotificationCalls())
+func (mock *NotificationAdderMock) AddNotificationCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	N   *entity.Notification
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		N   *entity.Notification
+	}
+	mock.lockAddNotification.RLock()
+	calls = mock.calls.AddNotification
+	mock.lockAddNotification.RUnlock()
+	return calls
+}
+
+// ListNotificationPreferences calls ListNotificationPreferencesFunc.
+func (mock *NotificationAdderMock) ListNotificationPreferences(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+	if mock.ListNotificationPreferencesFunc == nil {
+		panic("NotificationAdderMock.ListNotificationPreferencesFunc: method is nil but NotificationAdder.ListNotificationPreferences was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListNotificationPreferences.Lock()
+	mock.calls.ListNotificationPreferences = append(mock.calls.ListNotificationPreferences, callInfo)
+	mock.lockListNotificationPreferences.Unlock()
+	return mock.ListNotificationPreferencesFunc(ctx, db, userID)
+}
+
+// ListNotificationPreferencesCalls gets all the calls that were made to ListNotificationPreferences.
+// Check the length with:
+//
+//	len(mockedNotificationAdder.ListNotificationPreferencesCalls())
+func (mock *NotificationAdderMock) ListNotificationPreferencesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListNotificationPreferences.RLock()
+	calls = mock.calls.ListNotificationPreferences
+	mock.lockListNotificationPreferences.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationListerMock does implement NotificationLister.
+// If this is not the case, regenerate this file with moq.
+var _ NotificationLister = &NotificationListerMock{}
+
+// NotificationListerMock is a mock implementation of NotificationLister.
+//
+//	func TestSomethingThatUsesNotificationLister(t *testing.T) {
+//
+//		// make and configure a mocked NotificationLister
+//		mockedNotificationLister := &NotificationListerMock{
+//			CountUnreadNotificationsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (int, error) {
+//				panic("mock out the CountUnreadNotifications method")
+//			},
+//			ListNotificationsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, unreadOnly bool) (entity.Notifications, error) {
+//				panic("mock out the ListNotifications method")
+//			},
+//		}
+//
+//		// use mockedNotificationLister in code that requires NotificationLister
+//		// and then make assertions.
+//
+//	}
+type NotificationListerMock struct {
+	// CountUnreadNotificationsFunc mocks the CountUnreadNotifications method.
+	CountUnreadNotificationsFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (int, error)
+
+	// ListNotificationsFunc mocks the ListNotifications method.
+	ListNotificationsFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, unreadOnly bool) (entity.Notifications, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CountUnreadNotifications holds details about calls to the CountUnreadNotifications method.
+		CountUnreadNotifications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// ListNotifications holds details about calls to the ListNotifications method.
+		ListNotifications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// UnreadOnly is the unreadOnly argument value.
+			UnreadOnly bool
+		}
+	}
+	lockCountUnreadNotifications sync.RWMutex
+	lockListNotifications        sync.RWMutex
+}
+
+// CountUnreadNotifications calls CountUnreadNotificationsFunc.
+func (mock *NotificationListerMock) CountUnreadNotifications(ctx context.Context, db store.Queryer, userID entity.UserID) (int, error) {
+	if mock.CountUnreadNotificationsFunc == nil {
+		panic("NotificationListerMock.CountUnreadNotificationsFunc: method is nil but NotificationLister.CountUnreadNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockCountUnreadNotifications.Lock()
+	mock.calls.CountUnreadNotifications = append(mock.calls.CountUnreadNotifications, callInfo)
+	mock.lockCountUnreadNotifications.Unlock()
+	return mock.CountUnreadNotificationsFunc(ctx, db, userID)
+}
+
+// CountUnreadNotificationsCalls gets all the calls that were made to CountUnreadNotifications.
+// Check the length with:
+//
+//	len(mockedNotificationLister.CountUnreadNotificationsCalls())
+func (mock *NotificationListerMock) CountUnreadNotificationsCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockCountUnreadNotifications.RLock()
+	calls = mock.calls.CountUnreadNotifications
+	mock.lockCountUnreadNotifications.RUnlock()
+	return calls
+}
+
+// ListNotifications calls ListNotificationsFunc.
+func (mock *NotificationListerMock) ListNotifications(ctx context.Context, db store.Queryer, userID entity.UserID, unreadOnly bool) (entity.Notifications, error) {
+	if mock.ListNotificationsFunc == nil {
+		panic("NotificationListerMock.ListNotificationsFunc: method is nil but NotificationLister.ListNotifications was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		UserID     entity.UserID
+		UnreadOnly bool
+	}{
+		Ctx:        ctx,
+		Db:         db,
+		UserID:     userID,
+		UnreadOnly: unreadOnly,
+	}
+	mock.lockListNotifications.Lock()
+	mock.calls.ListNotifications = append(mock.calls.ListNotifications, callInfo)
+	mock.lockListNotifications.Unlock()
+	return mock.ListNotificationsFunc(ctx, db, userID, unreadOnly)
+}
+
+// ListNotificationsCalls gets all the calls that were made to ListNotifications.
+// Check the length with:
+//
+//	len(mockedNotificationLister.ListNotificationsCalls())
+func (mock *NotificationListerMock) ListNotificationsCalls() []struct {
+	Ctx        context.Context
+	Db         store.Queryer
+	UserID     entity.UserID
+	UnreadOnly bool
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Db         store.Queryer
+		UserID     entity.UserID
+		UnreadOnly bool
+	}
+	mock.lockListNotifications.RLock()
+	calls = mock.calls.ListNotifications
+	mock.lockListNotifications.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationReaderMock does implement NotificationReader.
+// If this is not the case, regenerate this file with moq.
+var _ NotificationReader = &NotificationReaderMock{}
+
+// NotificationReaderMock is a mock implementation of NotificationReader.
+//
+//	func TestSomethingThatUsesNotificationReader(t *testing.T) {
+//
+//		// make and configure a mocked NotificationReader
+//		mockedNotificationReader := &NotificationReaderMock{
+//			MarkAllNotificationsReadFunc: func(ctx context.Context, db store.Execer, userID entity.UserID) (int64, error) {
+//				panic("mock out the MarkAllNotificationsRead method")
+//			},
+//			MarkNotificationReadFunc: func(ctx context.Context, db store.ExecQueryer, userID entity.UserID, id entity.NotificationID) error {
+//				panic("mock out the MarkNotificationRead method")
+//			},
+//		}
+//
+//		// use mockedNotificationReader in code that requires NotificationReader
+//		// and then make assertions.
+//
+//	}
+type NotificationReaderMock struct {
+	// MarkAllNotificationsReadFunc mocks the MarkAllNotificationsRead method.
+	MarkAllNotificationsReadFunc func(ctx context.Context, db store.Execer, userID entity.UserID) (int64, error)
+
+	// MarkNotificationReadFunc mocks the MarkNotificationRead method.
+	MarkNotificationReadFunc func(ctx context.Context, db store.ExecQueryer, userID entity.UserID, id entity.NotificationID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// MarkAllNotificationsRead holds details about calls to the MarkAllNotificationsRead method.
+		MarkAllNotificationsRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// MarkNotificationRead holds details about calls to the MarkNotificationRead method.
+		MarkNotificationRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.ExecQueryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.NotificationID
+		}
+	}
+	lockMarkAllNotificationsRead sync.RWMutex
+	lockMarkNotificationRead     sync.RWMutex
+}
+
+// MarkAllNotificationsRead calls MarkAllNotificationsReadFunc.
+func (mock *NotificationReaderMock) MarkAllNotificationsRead(ctx context.Context, db store.Execer, userID entity.UserID) (int64, error) {
+	if mock.MarkAllNotificationsReadFunc == nil {
+		panic("NotificationReaderMock.MarkAllNotificationsReadFunc: method is nil but NotificationReader.MarkAllNotificationsRead was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockMarkAllNotificationsRead.Lock()
+	mock.calls.MarkAllNotificationsRead = append(mock.calls.MarkAllNotificationsRead, callInfo)
+	mock.lockMarkAllNotificationsRead.Unlock()
+	return mock.MarkAllNotificationsReadFunc(ctx, db, userID)
+}
+
+// MarkAllNotificationsReadCalls gets all the calls that were made to MarkAllNotificationsRead.
+// Check the length with:
+//
+//	len(mockedNotificationReader.MarkAllNotificationsReadCalls())
+func (mock *NotificationReaderMock) MarkAllNotificationsReadCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+	}
+	mock.lockMarkAllNotificationsRead.RLock()
+	calls = mock.calls.MarkAllNotificationsRead
+	mock.lockMarkAllNotificationsRead.RUnlock()
+	return calls
+}
+
+// MarkNotificationRead calls MarkNotificationReadFunc.
+func (mock *NotificationReaderMock) MarkNotificationRead(ctx context.Context, db store.ExecQueryer, userID entity.UserID, id entity.NotificationID) error {
+	if mock.MarkNotificationReadFunc == nil {
+		panic("NotificationReaderMock.MarkNotificationReadFunc: method is nil but NotificationReader.MarkNotificationRead was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.ExecQueryer
+		UserID entity.UserID
+		ID     entity.NotificationID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockMarkNotificationRead.Lock()
+	mock.calls.MarkNotificationRead = append(mock.calls.MarkNotificationRead, callInfo)
+	mock.lockMarkNotificationRead.Unlock()
+	return mock.MarkNotificationReadFunc(ctx, db, userID, id)
+}
+
+// MarkNotificationReadCalls gets all the calls that were made to MarkNotificationRead.
+// Check the length with:
+//
+//	len(mockedNotificationReader.MarkNotificationReadCalls())
+func (mock *NotificationReaderMock) MarkNotificationReadCalls() []struct {
+	Ctx    context.Context
+	Db     store.ExecQueryer
+	UserID entity.UserID
+	ID     entity.NotificationID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.ExecQueryer
+		UserID entity.UserID
+		ID     entity.NotificationID
+	}
+	mock.lockMarkNotificationRead.RLock()
+	calls = mock.calls.MarkNotificationRead
+	mock.lockMarkNotificationRead.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationPreferenceStoreMock does implement NotificationPreferenceStore.
+// If this is not the case, regenerate this file with moq.
+var _ NotificationPreferenceStore = &NotificationPreferenceStoreMock{}
+
+// NotificationPreferenceStoreMock is a mock implementation of NotificationPreferenceStore.
+//
+//	func TestSomethingThatUsesNotificationPreferenceStore(t *testing.T) {
+//
+//		// make and configure a mocked NotificationPreferenceStore
+//		mockedNotificationPreferenceStore := &NotificationPreferenceStoreMock{
+//			ListNotificationPreferencesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+//				panic("mock out the ListNotificationPreferences method")
+//			},
+//			SaveNotificationPreferenceFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, p *entity.NotificationPreference) error {
+//				panic("mock out the SaveNotificationPreference method")
+//			},
+//		}
+//
+//		// use mockedNotificationPreferenceStore in code that requires NotificationPreferenceStore
+//		// and then make assertions.
+//
+//	}
+type NotificationPreferenceStoreMock struct {
+	// ListNotificationPreferencesFunc mocks the ListNotificationPreferences method.
+	ListNotificationPreferencesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error)
+
+	// SaveNotificationPreferenceFunc mocks the SaveNotificationPreference method.
+	SaveNotificationPreferenceFunc func(ctx context.Context, db store.Execer, userID entity.UserID, p *entity.NotificationPreference) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListNotificationPreferences holds details about calls to the ListNotificationPreferences method.
+		ListNotificationPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// SaveNotificationPreference holds details about calls to the SaveNotificationPreference method.
+		SaveNotificationPreference []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// P is the p argument value.
+			P *entity.NotificationPreference
+		}
+	}
+	lockListNotificationPreferences sync.RWMutex
+	lockSaveNotificationPreference  sync.RWMutex
+}
+
+// ListNotificationPreferences calls ListNotificationPreferencesFunc.
+func (mock *NotificationPreferenceStoreMock) ListNotificationPreferences(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+	if mock.ListNotificationPreferencesFunc == nil {
+		panic("NotificationPreferenceStoreMock.ListNotificationPreferencesFunc: method is nil but NotificationPreferenceStore.ListNotificationPreferences was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListNotificationPreferences.Lock()
+	mock.calls.ListNotificationPreferences = append(mock.calls.ListNotificationPreferences, callInfo)
+	mock.lockListNotificationPreferences.Unlock()
+	return mock.ListNotificationPreferencesFunc(ctx, db, userID)
+}
+
+// ListNotificationPreferencesCalls gets all the calls that were made to ListNotificationPreferences.
+// Check the length with:
+//
+//	len(mockedNotificationPreferenceStore.ListNotificationPreferencesCalls())
+func (mock *NotificationPreferenceStoreMock) ListNotificationPreferencesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListNotificationPreferences.RLock()
+	calls = mock.calls.ListNotificationPreferences
+	mock.lockListNotificationPreferences.RUnlock()
+	return calls
+}
+
+// SaveNotificationPreference calls SaveNotificationPreferenceFunc.
+func (mock *NotificationPreferenceStoreMock) SaveNotificationPreference(ctx context.Context, db store.Execer, userID entity.UserID, p *entity.NotificationPreference) error {
+	if mock.SaveNotificationPreferenceFunc == nil {
+		panic("NotificationPreferenceStoreMock.SaveNotificationPreferenceFunc: method is nil but NotificationPreferenceStore.SaveNotificationPreference was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		P      *entity.NotificationPreference
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		P:      p,
+	}
+	mock.lockSaveNotificationPreference.Lock()
+	mock.calls.SaveNotificationPreference = append(mock.calls.SaveNotificationPreference, callInfo)
+	mock.lockSaveNotificationPreference.Unlock()
+	return mock.SaveNotificationPreferenceFunc(ctx, db, userID, p)
+}
+
+// SaveNotificationPreferenceCalls gets all the calls that were made to SaveNotificationPreference.
+// Check the length with:
+//
+//	len(mockedNotificationPreferenceStore.SaveNotificationPreferenceCalls())
+func (mock *NotificationPreferenceStoreMock) SaveNotificationPreferenceCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	P      *entity.NotificationPreference
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		P      *entity.NotificationPreference
+	}
+	mock.lockSaveNotificationPreference.RLock()
+	calls = mock.calls.SaveNotificationPreference
+	mock.lockSaveNotificationPreference.RUnlock()
+	return calls
+}
+
+// Ensure, that ReminderDispatcherMock does implement ReminderDispatcher.
+// If this is not the case, regenerate this file with moq.
+var _ ReminderDispatcher = &ReminderDispatcherMock{}
+
+// ReminderDispatcherMock is a mock implementation of ReminderDispatcher.
+//
+//	func TestSomethingThatUsesReminderDispatcher(t *testing.T) {
+//
+//		// make and configure a mocked ReminderDispatcher
+//		mockedReminderDispatcher := &ReminderDispatcherMock{
+//			ClaimDueRemindersFunc: func(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error) {
+//				panic("mock out the ClaimDueReminders method")
+//			},
+//			MarkReminderSentFunc: func(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error {
+//				panic("mock out the MarkReminderSent method")
+//			},
+//			ScheduleRemindersFunc: func(ctx context.Context, db store.Execer, since time.Time) (int64, error) {
+//				panic("mock out the ScheduleReminders method")
+//			},
+//		}
+//
+//		// use mockedReminderDispatcher in code that requires ReminderDispatcher
+//		// and then make assertions.
+//
+//	}
+type ReminderDispatcherMock struct {
+	// ClaimDueRemindersFunc mocks the ClaimDueReminders method.
+	ClaimDueRemindersFunc func(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error)
+
+	// MarkReminderSentFunc mocks the MarkReminderSent method.
+	MarkReminderSentFunc func(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error
+
+	// ScheduleRemindersFunc mocks the ScheduleReminders method.
+	ScheduleRemindersFunc func(ctx context.Context, db store.Execer, since time.Time) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ClaimDueReminders holds details about calls to the ClaimDueReminders method.
+		ClaimDueReminders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Now is the now argument value.
+			Now time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// MarkReminderSent holds details about calls to the MarkReminderSent method.
+		MarkReminderSent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.ReminderID
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// ScheduleReminders holds details about calls to the ScheduleReminders method.
+		ScheduleReminders []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Since is the since argument value.
+			Since time.Time
+		}
+	}
+	lockClaimDueReminders sync.RWMutex
+	lockMarkReminderSent  sync.RWMutex
+	lockScheduleReminders sync.RWMutex
+}
+
+// ClaimDueReminders calls ClaimDueRemindersFunc.
+func (mock *ReminderDispatcherMock) ClaimDueReminders(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error) {
+	if mock.ClaimDueRemindersFunc == nil {
+		panic("ReminderDispatcherMock.ClaimDueRemindersFunc: method is nil but ReminderDispatcher.ClaimDueReminders was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Now   time.Time
+		Limit int
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Now:   now,
+		Limit: limit,
+	}
+	mock.lockClaimDueReminders.Lock()
+	mock.calls.ClaimDueReminders = append(mock.calls.ClaimDueReminders, callInfo)
+	mock.lockClaimDueReminders.Unlock()
+	return mock.ClaimDueRemindersFunc(ctx, db, now, limit)
+}
+
+// ClaimDueRemindersCalls gets all the calls that were made to ClaimDueReminders.
+// Check the length with:
+//
+//	len(mockedReminderDispatcher.ClaimDueRemindersCalls())
+func (mock *ReminderDispatcherMock) ClaimDueRemindersCalls() []struct {
+	Ctx   context.Context
+	Db    store.Queryer
+	Now   time.Time
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Now   time.Time
+		Limit int
+	}
+	mock.lockClaimDueReminders.RLock()
+	calls = mock.calls.ClaimDueReminders
+	mock.lockClaimDueReminders.RUnlock()
+	return calls
+}
+
+// MarkReminderSent calls MarkReminderSentFunc.
+func (mock *ReminderDispatcherMock) MarkReminderSent(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error {
+	if mock.MarkReminderSentFunc == nil {
+		panic("ReminderDispatcherMock.MarkReminderSentFunc: method is nil but ReminderDispatcher.MarkReminderSent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.ReminderID
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+		Now: now,
+	}
+	mock.lockMarkReminderSent.Lock()
+	mock.calls.MarkReminderSent = append(mock.calls.MarkReminderSent, callInfo)
+	mock.lockMarkReminderSent.Unlock()
+	return mock.MarkReminderSentFunc(ctx, db, id, now)
+}
+
+// MarkReminderSentCalls gets all the calls that were made to MarkReminderSent.
+// Check the length with:
+//
+//	len(mockedReminderDispatcher.MarkReminderSentCalls())
+func (mock *ReminderDispatcherMock) MarkReminderSentCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	ID  entity.ReminderID
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		ID  entity.ReminderID
+		Now time.Time
+	}
+	mock.lockMarkReminderSent.RLock()
+	calls = mock.calls.MarkReminderSent
+	mock.lockMarkReminderSent.RUnlock()
+	return calls
+}
+
+// ScheduleReminders calls ScheduleRemindersFunc.
+func (mock *ReminderDispatcherMock) ScheduleReminders(ctx context.Context, db store.Execer, since time.Time) (int64, error) {
+	if mock.ScheduleRemindersFunc == nil {
+		panic("ReminderDispatcherMock.ScheduleRemindersFunc: method is nil but ReminderDispatcher.ScheduleReminders was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Since time.Time
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Since: since,
+	}
+	mock.lockScheduleReminders.Lock()
+	mock.calls.ScheduleReminders = append(mock.calls.ScheduleReminders, callInfo)
+	mock.lockScheduleReminders.Unlock()
+	return mock.ScheduleRemindersFunc(ctx, db, since)
+}
+
+// ScheduleRemindersCalls gets all the calls that were made to ScheduleReminders.
+// Check the length with:
+//
+//	len(mockedReminderDispatcher.ScheduleRemindersCalls())
+func (mock *ReminderDispatcherMock) ScheduleRemindersCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Since time.Time
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Since time.Time
+	}
+	mock.lockScheduleReminders.RLock()
+	calls = mock.calls.ScheduleReminders
+	mock.lockScheduleReminders.RUnlock()
+	return calls
+}
+
+// Ensure, that ReminderOffsetStoreMock does implement ReminderOffsetStore.
+// If this is not the case, regenerate this file with moq.
+var _ ReminderOffsetStore = &ReminderOffsetStoreMock{}
+
+// ReminderOffsetStoreMock is a mock implementation of ReminderOffsetStore.
+//
+//	func TestSomethingThatUsesReminderOffsetStore(t *testing.T) {
+//
+//		// make and configure a mocked ReminderOffsetStore
+//		mockedReminderOffsetStore := &ReminderOffsetStoreMock{
+//			ListReminderOffsetsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]entity.ReminderOffset, error) {
+//				panic("mock out the ListReminderOffsets method")
+//			},
+//			SaveReminderOffsetsFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, offsets []entity.ReminderOffset) error {
+//				panic("mock out the SaveReminderOffsets method")
+//			},
+//		}
+//
+//		// use mockedReminderOffsetStore in code that requires ReminderOffsetStore
+//		// and then make assertions.
+//
+//	}
+type ReminderOffsetStoreMock struct {
+	// ListReminderOffsetsFunc mocks the ListReminderOffsets method.
+	ListReminderOffsetsFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]entity.ReminderOffset, error)
+
+	// SaveReminderOffsetsFunc mocks the SaveReminderOffsets method.
+	SaveReminderOffsetsFunc func(ctx context.Context, db store.Execer, userID entity.UserID, offsets []entity.ReminderOffset) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListReminderOffsets holds details about calls to the ListReminderOffsets method.
+		ListReminderOffsets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// SaveReminderOffsets holds details about calls to the SaveReminderOffsets method.
+		SaveReminderOffsets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Offsets is the offsets argument value.
+			Offsets []entity.ReminderOffset
+		}
+	}
+	lockListReminderOffsets sync.RWMutex
+	lockSaveReminderOffsets sync.RWMutex
+}
+
+// ListReminderOffsets calls ListReminderOffsetsFunc.
+func (mock *ReminderOffsetStoreMock) ListReminderOffsets(ctx context.Context, db store.Queryer, userID entity.UserID) ([]entity.ReminderOffset, error) {
+	if mock.ListReminderOffsetsFunc == nil {
+		panic("ReminderOffsetStoreMock.ListReminderOffsetsFunc: method is nil but ReminderOffsetStore.ListReminderOffsets was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListReminderOffsets.Lock()
+	mock.calls.ListReminderOffsets = append(mock.calls.ListReminderOffsets, callInfo)
+	mock.lockListReminderOffsets.Unlock()
+	return mock.ListReminderOffsetsFunc(ctx, db, userID)
+}
+
+// ListReminderOffsetsCalls gets all the calls that were made to ListReminderOffsets.
+// Check the length with:
+//
+//	len(mockedReminderOffsetStore.ListReminderOffsetsCalls())
+func (mock *ReminderOffsetStoreMock) ListReminderOffsetsCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListReminderOffsets.RLock()
+	calls = mock.calls.ListReminderOffsets
+	mock.lockListReminderOffsets.RUnlock()
+	return calls
+}
+
+// SaveReminderOffsets calls SaveReminderOffsetsFunc.
+func (mock *ReminderOffsetStoreMock) SaveReminderOffsets(ctx context.Context, db store.Execer, userID entity.UserID, offsets []entity.ReminderOffset) error {
+	if mock.SaveReminderOffsetsFunc == nil {
+		panic("ReminderOffsetStoreMock.SaveReminderOffsetsFunc: method is nil but ReminderOffsetStore.SaveReminderOffsets was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Execer
+		UserID  entity.UserID
+		Offsets []entity.ReminderOffset
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		UserID:  userID,
+		Offsets: offsets,
+	}
+	mock.lockSaveReminderOffsets.Lock()
+	mock.calls.SaveReminderOffsets = append(mock.calls.SaveReminderOffsets, callInfo)
+	mock.lockSaveReminderOffsets.Unlock()
+	return mock.SaveReminderOffsetsFunc(ctx, db, userID, offsets)
+}
+
+// SaveReminderOffsetsCalls gets all the calls that were made to SaveReminderOffsets.
+// Check the length with:
+//
+//	len(mockedReminderOffsetStore.SaveReminderOffsetsCalls())
+func (mock *ReminderOffsetStoreMock) SaveReminderOffsetsCalls() []struct {
+	Ctx     context.Context
+	Db      store.Execer
+	UserID  entity.UserID
+	Offsets []entity.ReminderOffset
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Execer
+		UserID  entity.UserID
+		Offsets []entity.ReminderOffset
+	}
+	mock.lockSaveReminderOffsets.RLock()
+	calls = mock.calls.SaveReminderOffsets
+	mock.lockSaveReminderOffsets.RUnlock()
+	return calls
+}
+
+// Ensure, that SavedSearchAdderMock does implement SavedSearchAdder.
+// If this is not the case, regenerate this file with moq.
+var _ SavedSearchAdder = &SavedSearchAdderMock{}
+
+// SavedSearchAdderMock is a mock implementation of SavedSearchAdder.
+//
+//	func TestSomethingThatUsesSavedSearchAdder(t *testing.T) {
+//
+//		// make and configure a mocked SavedSearchAdder
+//		mockedSavedSearchAdder := &SavedSearchAdderMock{
+//			AddSavedSearchFunc: func(ctx context.Context, db store.Execer, s *entity.SavedSearch) error {
+//				panic("mock out the AddSavedSearch method")
+//			},
+//		}
+//
+//		// use mockedSavedSearchAdder in code that requires SavedSearchAdder
+//		// and then make assertions.
+//
+//	}
+type SavedSearchAdderMock struct {
+	// AddSavedSearchFunc mocks the AddSavedSearch method.
+	AddSavedSearchFunc func(ctx context.Context, db store.Execer, s *entity.SavedSearch) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddSavedSearch holds details about calls to the AddSavedSearch method.
+		AddSavedSearch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// S is the s argument value.
+			S *entity.SavedSearch
+		}
+	}
+	lockAddSavedSearch sync.RWMutex
+}
+
+// AddSavedSearch calls AddSavedSearchFunc.
+func (mock *SavedSearchAdderMock) AddSavedSearch(ctx context.Context, db store.Execer, s *entity.SavedSearch) error {
+	if mock.AddSavedSearchFunc == nil {
+		panic("SavedSearchAdderMock.AddSavedSearchFunc: method is nil but SavedSearchAdder.AddSavedSearch was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		S   *entity.SavedSearch
+	}{
+		Ctx: ctx,
+		Db:  db,
+		S:   s,
+	}
+	mock.lockAddSavedSearch.Lock()
+	mock.calls.AddSavedSearch = append(mock.calls.AddSavedSearch, callInfo)
+	mock.lockAddSavedSearch.Unlock()
+	return mock.AddSavedSearchFunc(ctx, db, s)
+}
+
+// AddSavedSearchCalls gets all the calls that were made to AddSavedSearch.
+// Check the length with:
+//
+//	len(mockedSavedSearchAdder.AddSavedSearchCalls())
+func (mock *SavedSearchAdderMock) AddSavedSearchCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	S   *entity.SavedSearch
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		S   *entity.SavedSearch
+	}
+	mock.lockAddSavedSearch.RLock()
+	calls = mock.calls.AddSavedSearch
+	mock.lockAddSavedSearch.RUnlock()
+	return calls
+}
+
+// Ensure, that SavedSearchListerMock does implement SavedSearchLister.
+// If this is not the case, regenerate this file with moq.
+var _ SavedSearchLister = &SavedSearchListerMock{}
+
+// SavedSearchListerMock is a mock implementation of SavedSearchLister.
+//
+//	func TestSomethingThatUsesSavedSearchLister(t *testing.T) {
+//
+//		// make and configure a mocked SavedSearchLister
+//		mockedSavedSearchLister := &SavedSearchListerMock{
+//			ListSavedSearchesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.SavedSearches, error) {
+//				panic("mock out the ListSavedSearches method")
+//			},
+//		}
+//
+//		// use mockedSavedSearchLister in code that requires SavedSearchLister
+//		// and then make assertions.
+//
+//	}
+type SavedSearchListerMock struct {
+	// ListSavedSearchesFunc mocks the ListSavedSearches method.
+	ListSavedSearchesFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.SavedSearches, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListSavedSearches holds details about calls to the ListSavedSearches method.
+		ListSavedSearches []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockListSavedSearches sync.RWMutex
+}
+
+// ListSavedSearches calls ListSavedSearchesFunc.
+func (mock *SavedSearchListerMock) ListSavedSearches(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.SavedSearches, error) {
+	if mock.ListSavedSearchesFunc == nil {
+		panic("SavedSearchListerMock.ListSavedSearchesFunc: method is nil but SavedSearchLister.ListSavedSearches was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockListSavedSearches.Lock()
+	mock.calls.ListSavedSearches = append(mock.calls.ListSavedSearches, callInfo)
+	mock.lockListSavedSearches.Unlock()
+	return mock.ListSavedSearchesFunc(ctx, db, userID)
+}
+
+// ListSavedSearchesCalls gets all the calls that were made to ListSavedSearches.
+// Check the length with:
+//
+//	len(mockedSavedSearchLister.ListSavedSearchesCalls())
+func (mock *SavedSearchListerMock) ListSavedSearchesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockListSavedSearches.RLock()
+	calls = mock.calls.ListSavedSearches
+	mock.lockListSavedSearches.RUnlock()
+	return calls
+}
+
+// Ensure, that SavedSearchRunnerMock does implement SavedSearchRunner.
+// If this is not the case, regenerate this file with moq.
+var _ SavedSearchRunner = &SavedSearchRunnerMock{}
+
+// SavedSearchRunnerMock is a mock implementation of SavedSearchRunner.
+//
+//	func TestSomethingThatUsesSavedSearchRunner(t *testing.T) {
+//
+//		// make and configure a mocked SavedSearchRunner
+//		mockedSavedSearchRunner := &SavedSearchRunnerMock{
+//			FilterTasksFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, f *entity.TaskFilter) (entity.Tasks, error) {
+//				panic("mock out the FilterTasks method")
+//			},
+//			GetSavedSearchFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.SavedSearchID) (*entity.SavedSearch, error) {
+//				panic("mock out the GetSavedSearch method")
+//			},
+//		}
+//
+//		// use mockedSavedSearchRunner in code that requires SavedSearchRunner
+//		// and then make assertions.
+//
+//	}
+type SavedSearchRunnerMock struct {
+	// FilterTasksFunc mocks the FilterTasks method.
+	FilterTasksFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, f *entity.TaskFilter) (entity.Tasks, error)
+
+	// GetSavedSearchFunc mocks the GetSavedSearch method.
+	GetSavedSearchFunc func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.SavedSearchID) (*entity.SavedSearch, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FilterTasks holds details about calls to the FilterTasks method.
+		FilterTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// F is the f argument value.
+			F *entity.TaskFilter
+		}
+		// GetSavedSearch holds details about calls to the GetSavedSearch method.
+		GetSavedSearch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.SavedSearchID
+		}
+	}
+	lockFilterTasks    sync.RWMutex
+	lockGetSavedSearch sync.RWMutex
+}
+
+// FilterTasks calls FilterTasksFunc.
+func (mock *SavedSearchRunnerMock) FilterTasks(ctx context.Context, db store.Queryer, userID entity.UserID, f *entity.TaskFilter) (entity.Tasks, error) {
+	if mock.FilterTasksFunc == nil {
+		panic("SavedSearchRunnerMock.FilterTasksFunc: method is nil but SavedSearchRunner.FilterTasks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		F      *entity.TaskFilter
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		F:      f,
+	}
+	mock.lockFilterTasks.Lock()
+	mock.calls.FilterTasks = append(mock.calls.FilterTasks, callInfo)
+	mock.lockFilterTasks.Unlock()
+	return mock.FilterTasksFunc(ctx, db, userID, f)
+}
+
+// FilterTasksCalls gets all the calls that were made to FilterTasks.
+// Check the length with:
+//
+//	len(mockedSavedSearchRunner.FilterTasksCalls())
+func (mock *SavedSearchRunnerMock) FilterTasksCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	F      *entity.TaskFilter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		F      *entity.TaskFilter
+	}
+	mock.lockFilterTasks.RLock()
+	calls = mock.calls.FilterTasks
+	mock.lockFilterTasks.RUnlock()
+	return calls
+}
+
+// GetSavedSearch calls GetSavedSearchFunc.
+func (mock *SavedSearchRunnerMock) GetSavedSearch(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.SavedSearchID) (*entity.SavedSearch, error) {
+	if mock.GetSavedSearchFunc == nil {
+		panic("SavedSearchRunnerMock.GetSavedSearchFunc: method is nil but SavedSearchRunner.GetSavedSearch was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.SavedSearchID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockGetSavedSearch.Lock()
+	mock.calls.GetSavedSearch = append(mock.calls.GetSavedSearch, callInfo)
+	mock.lockGetSavedSearch.Unlock()
+	return mock.GetSavedSearchFunc(ctx, db, userID, id)
+}
+
+// GetSavedSearchCalls gets all the calls that were made to GetSavedSearch.
+// Check the length with:
+//
+//	len(mockedSavedSearchRunner.GetSavedSearchCalls())
+func (mock *SavedSearchRunnerMock) GetSavedSearchCalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+	ID     entity.SavedSearchID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+		ID     entity.SavedSearchID
+	}
+	mock.lockGetSavedSearch.RLock()
+	calls = mock.calls.GetSavedSearch
+	mock.lockGetSavedSearch.RUnlock()
+	return calls
+}
+
+// Ensure, that SavedSearchDeleterMock does implement SavedSearchDeleter.
+// If this is not the case, regenerate this file with moq.
+var _ SavedSearchDeleter = &SavedSearchDeleterMock{}
+
+// SavedSearchDeleterMock is a mock implementation of SavedSearchDeleter.
+//
+//	func TestSomethingThatUsesSavedSearchDeleter(t *testing.T) {
+//
+//		// make and configure a mocked SavedSearchDeleter
+//		mockedSavedSearchDeleter := &SavedSearchDeleterMock{
+//			DeleteSavedSearchFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.SavedSearchID) error {
+//				panic("mock out the DeleteSavedSearch method")
+//			},
+//		}
+//
+//		// use mockedSavedSearchDeleter in code that requires SavedSearchDeleter
+//		// and then make assertions.
+//
+//	}
+type SavedSearchDeleterMock struct {
+	// DeleteSavedSearchFunc mocks the DeleteSavedSearch method.
+	DeleteSavedSearchFunc func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.SavedSearchID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteSavedSearch holds details about calls to the DeleteSavedSearch method.
+		DeleteSavedSearch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// ID is the id argument value.
+			ID entity.SavedSearchID
+		}
+	}
+	lockDeleteSavedSearch sync.RWMutex
+}
+
+// DeleteSavedSearch calls DeleteSavedSearchFunc.
+func (mock *SavedSearchDeleterMock) DeleteSavedSearch(ctx context.Context, db store.Execer, userID entity.UserID, id entity.SavedSearchID) error {
+	if mock.DeleteSavedSearchFunc == nil {
+		panic("SavedSearchDeleterMock.DeleteSavedSearchFunc: method is nil but SavedSearchDeleter.DeleteSavedSearch was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.SavedSearchID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		ID:     id,
+	}
+	mock.lockDeleteSavedSearch.Lock()
+	mock.calls.DeleteSavedSearch = append(mock.calls.DeleteSavedSearch, callInfo)
+	mock.lockDeleteSavedSearch.Unlock()
+	return mock.DeleteSavedSearchFunc(ctx, db, userID, id)
+}
+
+// DeleteSavedSearchCalls gets all the calls that were made to DeleteSavedSearch.
+// Check the length with:
+//
+//	len(mockedSavedSearchDeleter.DeleteSavedSearchCalls())
+func (mock *SavedSearchDeleterMock) DeleteSavedSearchCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	ID     entity.SavedSearchID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		ID     entity.SavedSearchID
+	}
+	mock.lockDeleteSavedSearch.RLock()
+	calls = mock.calls.DeleteSavedSearch
+	mock.lockDeleteSavedSearch.RUnlock()
+	return calls
+}
+
+// Ensure, that TaskRelationListerMock does implement TaskRelationLister.
+// If this is not the case, regenerate this file with moq.
+var _ TaskRelationLister = &TaskRelationListerMock{}
+
+// TaskRelationListerMock is a mock implementation of TaskRelationLister.
+//
+//	func TestSomethingThatUsesTaskRelationLister(t *testing.T) {
+//
+//		// make and configure a mocked TaskRelationLister
+//		mockedTaskRelationLister := &TaskRelationListerMock{
+//			ListProjectsByIDFunc: func(ctx context.Context, db store.Queryer, ids []entity.ProjectID) (entity.Projects, error) {
+//				panic("mock out the ListProjectsByID method")
+//			},
+//			ListTaskLabelsFunc: func(ctx context.Context, db store.Queryer, taskIDs []entity.TaskID) ([]*entity.TaskLabel, error) {
+//				panic("mock out the ListTaskLabels method")
+//			},
+//			ListWorkspaceMembersFunc: func(ctx context.Context, db store.Queryer, userIDs []entity.UserID) ([]*entity.WorkspaceMember, error) {
+//				panic("mock out the ListWorkspaceMembers method")
+//			},
+//		}
+//
+//		// use mockedTaskRelationLister in code that requires TaskRelationLister
+//		// and then make assertions.
+//
+//	}
+type TaskRelationListerMock struct {
+	// ListProjectsByIDFunc mocks the ListProjectsByID method.
+	ListProjectsByIDFunc func(ctx context.Context, db store.Queryer, ids []entity.ProjectID) (entity.Projects, error)
+
+	// ListTaskLabelsFunc mocks the ListTaskLabels method.
+	ListTaskLabelsFunc func(ctx context.Context, db store.Queryer, taskIDs []entity.TaskID) ([]*entity.TaskLabel, error)
+
+	// ListWorkspaceMembersFunc mocks the ListWorkspaceMembers method.
+	ListWorkspaceMembersFunc func(ctx context.Context, db store.Queryer, userIDs []entity.UserID) ([]*entity.WorkspaceMember, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListProjectsByID holds details about calls to the ListProjectsByID method.
+		ListProjectsByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Ids is the ids argument value.
+			Ids []entity.ProjectID
+		}
+		// ListTaskLabels holds details about calls to the ListTaskLabels method.
+		ListTaskLabels []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// TaskIDs is the taskIDs argument value.
+			TaskIDs []entity.TaskID
+		}
+		// ListWorkspaceMembers holds details about calls to the ListWorkspaceMembers method.
+		ListWorkspaceMembers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserIDs is the userIDs argument value.
+			UserIDs []entity.UserID
+		}
+	}
+	lockListProjectsByID     sync.RWMutex
+	lockListTaskLabels       sync.RWMutex
+	lockListWorkspaceMembers sync.RWMutex
+}
+
+// ListProjectsByID calls ListProjectsByIDFunc.
+func (mock *TaskRelationListerMock) ListProjectsByID(ctx context.Context, db store.Queryer, ids []entity.ProjectID) (entity.Projects, error) {
+	if mock.ListProjectsByIDFunc == nil {
+		panic("TaskRelationListerMock.ListProjectsByIDFunc: method is nil but TaskRelationLister.ListProjectsByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.ProjectID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		Ids: ids,
+	}
+	mock.lockListProjectsByID.Lock()
+	mock.calls.ListProjectsByID = append(mock.calls.ListProjectsByID, callInfo)
+	mock.lockListProjectsByID.Unlock()
+	return mock.ListProjectsByIDFunc(ctx, db, ids)
+}
+
+// ListProjectsByIDCalls gets all the calls that were made to ListProjectsByID.
+// Check the length with:
+//
+//	len(mockedTaskRelationLister.ListProjectsByIDCalls())
+func (mock *TaskRelationListerMock) ListProjectsByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	Ids []entity.ProjectID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		Ids []entity.ProjectID
+	}
+	mock.lockListProjectsByID.RLock()
+	calls = mock.calls.ListProjectsByID
+	mock.lockListProjectsByID.RUnlock()
+	return calls
+}
+
+// ListTaskLabels calls ListTaskLabelsFunc.
+func (mock *TaskRelationListerMock) ListTaskLabels(ctx context.Context, db store.Queryer, taskIDs []entity.TaskID) ([]*entity.TaskLabel, error) {
+	if mock.ListTaskLabelsFunc == nil {
+		panic("TaskRelationListerMock.ListTaskLabelsFunc: method is nil but TaskRelationLister.ListTaskLabels was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		TaskIDs []entity.TaskID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		TaskIDs: taskIDs,
+	}
+	mock.lockListTaskLabels.Lock()
+	mock.calls.ListTaskLabels = append(mock.calls.ListTaskLabels, callInfo)
+	mock.lockListTaskLabels.Unlock()
+	return mock.ListTaskLabelsFunc(ctx, db, taskIDs)
+}
+
+// ListTaskLabelsCalls gets all the calls that were made to ListTaskLabels.
+// Check the length with:
+//
+//	len(mockedTaskRelationLister.ListTaskLabelsCalls())
+func (mock *TaskRelationListerMock) ListTaskLabelsCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	TaskIDs []entity.TaskID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		TaskIDs []entity.TaskID
+	}
+	mock.lockListTaskLabels.RLock()
+	calls = mock.calls.ListTaskLabels
+	mock.lockListTaskLabels.RUnlock()
+	return calls
+}
+
+// ListWorkspaceMembers calls ListWorkspaceMembersFunc.
+func (mock *TaskRelationListerMock) ListWorkspaceMembers(ctx context.Context, db store.Queryer, userIDs []entity.UserID) ([]*entity.WorkspaceMember, error) {
+	if mock.ListWorkspaceMembersFunc == nil {
+		panic("TaskRelationListerMock.ListWorkspaceMembersFunc: method is nil but TaskRelationLister.ListWorkspaceMembers was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		UserIDs []entity.UserID
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		UserIDs: userIDs,
+	}
+	mock.lockListWorkspaceMembers.Lock()
+	mock.calls.ListWorkspaceMembers = append(mock.calls.ListWorkspaceMembers, callInfo)
+	mock.lockListWorkspaceMembers.Unlock()
+	return mock.ListWorkspaceMembersFunc(ctx, db, userIDs)
+}
+
+// ListWorkspaceMembersCalls gets all the calls that were made to ListWorkspaceMembers.
+// Check the length with:
+//
+//	len(mockedTaskRelationLister.ListWorkspaceMembersCalls())
+func (mock *TaskRelationListerMock) ListWorkspaceMembersCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	UserIDs []entity.UserID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		UserIDs []entity.UserID
+	}
+	mock.lockListWorkspaceMembers.RLock()
+	calls = mock.calls.ListWorkspaceMembers
+	mock.lockListWorkspaceMembers.RUnlock()
+	return calls
+}
+
+// Ensure, that UserByIDGetterMock does implement UserByIDGetter.
+// If this is not the case, regenerate this file with moq.
+var _ UserByIDGetter = &UserByIDGetterMock{}
+
+// UserByIDGetterMock is a mock implementation of UserByIDGetter.
+//
+//	func TestSomethingThatUsesUserByIDGetter(t *testing.T) {
+//
+//		// make and configure a mocked UserByIDGetter
+//		mockedUserByIDGetter := &UserByIDGetterMock{
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//		}
+//
+//		// use mockedUserByIDGetter in code that requires UserByIDGetter
+//		// and then make assertions.
+//
+//	}
+type UserByIDGetterMock struct {
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+	}
+	lockGetUserByID sync.RWMutex
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *UserByIDGetterMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("UserByIDGetterMock.GetUserByIDFunc: method is nil but UserByIDGetter.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedUserByIDGetter.GetUserByIDCalls())
+func (mock *UserByIDGetterMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenStoreMock does implement RefreshTokenStore.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenStore = &RefreshTokenStoreMock{}
+
+// RefreshTokenStoreMock is a mock implementation of RefreshTokenStore.
+//
+//	func TestSomethingThatUsesRefreshTokenStore(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenStore
+//		mockedRefreshTokenStore := &RefreshTokenStoreMock{
+//			LoadRefreshTokenFunc: func(ctx context.Context, key string) (*entity.RefreshToken, error) {
+//				panic("mock out the LoadRefreshToken method")
+//			},
+//			RevokeRefreshTokenFamilyFunc: func(ctx context.Context, family string) error {
+//				panic("mock out the RevokeRefreshTokenFamily method")
+//			},
+//			RevokeUserRefreshTokensFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the RevokeUserRefreshTokens method")
+//			},
+//			SaveRefreshTokenFunc: func(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error {
+//				panic("mock out the SaveRefreshToken method")
+//			},
+//			UseRefreshTokenFunc: func(ctx context.Context, key string) (*entity.RefreshToken, error) {
+//				panic("mock out the UseRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenStore in code that requires RefreshTokenStore
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenStoreMock struct {
+	// LoadRefreshTokenFunc mocks the LoadRefreshToken method.
+	LoadRefreshTokenFunc func(ctx context.Context, key string) (*entity.RefreshToken, error)
+
+	// RevokeRefreshTokenFamilyFunc mocks the RevokeRefreshTokenFamily method.
+	RevokeRefreshTokenFamilyFunc func(ctx context.Context, family string) error
+
+	// RevokeUserRefreshTokensFunc mocks the RevokeUserRefreshTokens method.
+	RevokeUserRefreshTokensFunc func(ctx context.Context, userID entity.UserID) error
+
+	// SaveRefreshTokenFunc mocks the SaveRefreshToken method.
+	SaveRefreshTokenFunc func(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error
+
+	// UseRefreshTokenFunc mocks the UseRefreshToken method.
+	UseRefreshTokenFunc func(ctx context.Context, key string) (*entity.RefreshToken, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// LoadRefreshToken holds details about calls to the LoadRefreshToken method.
+		LoadRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// RevokeRefreshTokenFamily holds details about calls to the RevokeRefreshTokenFamily method.
+		RevokeRefreshTokenFamily []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Family is the family argument value.
+			Family string
+		}
+		// RevokeUserRefreshTokens holds details about calls to the RevokeUserRefreshTokens method.
+		RevokeUserRefreshTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// SaveRefreshToken holds details about calls to the SaveRefreshToken method.
+		SaveRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Token is the token argument value.
+			Token *entity.RefreshToken
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// UseRefreshToken holds details about calls to the UseRefreshToken method.
+		UseRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockLoadRefreshToken         sync.RWMutex
+	lockRevokeRefreshTokenFamily sync.RWMutex
+	lockRevokeUserRefreshTokens  sync.RWMutex
+	lockSaveRefreshToken         sync.RWMutex
+	lockUseRefreshToken          sync.RWMutex
+}
+
+// LoadRefreshToken calls LoadRefreshTokenFunc.
+func (mock *RefreshTokenStoreMock) LoadRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error) {
+	if mock.LoadRefreshTokenFunc == nil {
+		panic("RefreshTokenStoreMock.LoadRefreshTokenFunc: method is nil but RefreshTokenStore.LoadRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockLoadRefreshToken.Lock()
+	mock.calls.LoadRefreshToken = append(mock.calls.LoadRefreshToken, callInfo)
+	mock.lockLoadRefreshToken.Unlock()
+	return mock.LoadRefreshTokenFunc(ctx, key)
+}
+
+// LoadRefreshTokenCalls gets all the calls that were made to LoadRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenStore.LoadRefreshTokenCalls())
+func (mock *RefreshTokenStoreMock) LoadRefreshTokenCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockLoadRefreshToken.RLock()
+	calls = mock.calls.LoadRefreshToken
+	mock.lockLoadRefreshToken.RUnlock()
+	return calls
+}
+
+// RevokeRefreshTokenFamily calls RevokeRefreshTokenFamilyFunc.
+func (mock *RefreshTokenStoreMock) RevokeRefreshTokenFamily(ctx context.Context, family string) error {
+	if mock.RevokeRefreshTokenFamilyFunc == nil {
+		panic("RefreshTokenStoreMock.RevokeRefreshTokenFamilyFunc: method is nil but RefreshTokenStore.RevokeRefreshTokenFamily was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Family string
+	}{
+		Ctx:    ctx,
+		Family: family,
+	}
+	mock.lockRevokeRefreshTokenFamily.Lock()
+	mock.calls.RevokeRefreshTokenFamily = append(mock.calls.RevokeRefreshTokenFamily, callInfo)
+	mock.lockRevokeRefreshTokenFamily.Unlock()
+	return mock.RevokeRefreshTokenFamilyFunc(ctx, family)
+}
+
+// RevokeRefreshTokenFamilyCalls gets all the calls that were made to RevokeRefreshTokenFamily.
+// Check the length with:
+//
+//	len(mockedRefreshTokenStore.RevokeRefreshTokenFamilyCalls())
+func (mock *RefreshTokenStoreMock) RevokeRefreshTokenFamilyCalls() []struct {
+	Ctx    context.Context
+	Family string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Family string
+	}
+	mock.lockRevokeRefreshTokenFamily.RLock()
+	calls = mock.calls.RevokeRefreshTokenFamily
+	mock.lockRevokeRefreshTokenFamily.RUnlock()
+	return calls
+}
+
+// RevokeUserRefreshTokens calls RevokeUserRefreshTokensFunc.
+func (mock *RefreshTokenStoreMock) RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error {
+	if mock.RevokeUserRefreshTokensFunc == nil {
+		panic("RefreshTokenStoreMock.RevokeUserRefreshTokensFunc: method is nil but RefreshTokenStore.RevokeUserRefreshTokens was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserRefreshTokens.Lock()
+	mock.calls.RevokeUserRefreshTokens = append(mock.calls.RevokeUserRefreshTokens, callInfo)
+	mock.lockRevokeUserRefreshTokens.Unlock()
+	return mock.RevokeUserRefreshTokensFunc(ctx, userID)
+}
+
+// RevokeUserRefreshTokensCalls gets all the calls that were made to RevokeUserRefreshTokens.
+// Check the length with:
+//
+//	len(mockedRefreshTokenStore.RevokeUserRefreshTokensCalls())
+func (mock *RefreshTokenStoreMock) RevokeUserRefreshTokensCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockRevokeUserRefreshTokens.RLock()
+	calls = mock.calls.RevokeUserRefreshTokens
+	mock.lockRevokeUserRefreshTokens.RUnlock()
+	return calls
+}
+
+// SaveRefreshToken calls SaveRefreshTokenFunc.
+func (mock *RefreshTokenStoreMock) SaveRefreshToken(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error {
+	if mock.SaveRefreshTokenFunc == nil {
+		panic("RefreshTokenStoreMock.SaveRefreshTokenFunc: method is nil but RefreshTokenStore.SaveRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Key   string
+		Token *entity.RefreshToken
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		Key:   key,
+		Token: token,
+		TTL:   ttl,
+	}
+	mock.lockSaveRefreshToken.Lock()
+	mock.calls.SaveRefreshToken = append(mock.calls.SaveRefreshToken, callInfo)
+	mock.lockSaveRefreshToken.Unlock()
+	return mock.SaveRefreshTokenFunc(ctx, key, token, ttl)
+}
+
+// SaveRefreshTokenCalls gets all the calls that were made to SaveRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenStore.SaveRefreshTokenCalls())
+func (mock *RefreshTokenStoreMock) SaveRefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Key   string
+	Token *entity.RefreshToken
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Key   string
+		Token *entity.RefreshToken
+		TTL   time.Duration
+	}
+	mock.lockSaveRefreshToken.RLock()
+	calls = mock.calls.SaveRefreshToken
+	mock.lockSaveRefreshToken.RUnlock()
+	return calls
+}
+
+// UseRefreshToken calls UseRefreshTokenFunc.
+func (mock *RefreshTokenStoreMock) UseRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error) {
+	if mock.UseRefreshTokenFunc == nil {
+		panic("RefreshTokenStoreMock.UseRefreshTokenFunc: method is nil but RefreshTokenStore.UseRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockUseRefreshToken.Lock()
+	mock.calls.UseRefreshToken = append(mock.calls.UseRefreshToken, callInfo)
+	mock.lockUseRefreshToken.Unlock()
+	return mock.UseRefreshTokenFunc(ctx, key)
+}
+
+// UseRefreshTokenCalls gets all the calls that were made to UseRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenStore.UseRefreshTokenCalls())
+func (mock *RefreshTokenStoreMock) UseRefreshTokenCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockUseRefreshToken.RLock()
+	calls = mock.calls.UseRefreshToken
+	mock.lockUseRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenIssuerMock does implement RefreshTokenIssuer.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenIssuer = &RefreshTokenIssuerMock{}
+
+// RefreshTokenIssuerMock is a mock implementation of RefreshTokenIssuer.
+//
+//	func TestSomethingThatUsesRefreshTokenIssuer(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenIssuer
+//		mockedRefreshTokenIssuer := &RefreshTokenIssuerMock{
+//			IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+//				panic("mock out the IssueRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenIssuer in code that requires RefreshTokenIssuer
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenIssuerMock struct {
+	// IssueRefreshTokenFunc mocks the IssueRefreshToken method.
+	IssueRefreshTokenFunc func(ctx context.Context, userID entity.UserID) (string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// IssueRefreshToken holds details about calls to the IssueRefreshToken method.
+		IssueRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockIssueRefreshToken sync.RWMutex
+}
+
+// IssueRefreshToken calls IssueRefreshTokenFunc.
+func (mock *RefreshTokenIssuerMock) IssueRefreshToken(ctx context.Context, userID entity.UserID) (string, error) {
+	if mock.IssueRefreshTokenFunc == nil {
+		panic("RefreshTokenIssuerMock.IssueRefreshTokenFunc: method is nil but RefreshTokenIssuer.IssueRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIssueRefreshToken.Lock()
+	mock.calls.IssueRefreshToken = append(mock.calls.IssueRefreshToken, callInfo)
+	mock.lockIssueRefreshToken.Unlock()
+	return mock.IssueRefreshTokenFunc(ctx, userID)
+}
+
+// IssueRefreshTokenCalls gets all the calls that were made to IssueRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenIssuer.IssueRefreshTokenCalls())
+func (mock *RefreshTokenIssuerMock) IssueRefreshTokenCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockIssueRefreshToken.RLock()
+	calls = mock.calls.IssueRefreshToken
+	mock.lockIssueRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenRotatorMock does implement RefreshTokenRotator.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenRotator = &RefreshTokenRotatorMock{}
+
+// RefreshTokenRotatorMock is a mock implementation of RefreshTokenRotator.
+//
+//	func TestSomethingThatUsesRefreshTokenRotator(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenRotator
+//		mockedRefreshTokenRotator := &RefreshTokenRotatorMock{
+//			RotateRefreshTokenFunc: func(ctx context.Context, token string) (entity.UserID, string, error) {
+//				panic("mock out the RotateRefreshToken method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenRotator in code that requires RefreshTokenRotator
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenRotatorMock struct {
+	// RotateRefreshTokenFunc mocks the RotateRefreshToken method.
+	RotateRefreshTokenFunc func(ctx context.Context, token string) (entity.UserID, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RotateRefreshToken holds details about calls to the RotateRefreshToken method.
+		RotateRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockRotateRefreshToken sync.RWMutex
+}
+
+// RotateRefreshToken calls RotateRefreshTokenFunc.
+func (mock *RefreshTokenRotatorMock) RotateRefreshToken(ctx context.Context, token string) (entity.UserID, string, error) {
+	if mock.RotateRefreshTokenFunc == nil {
+		panic("RefreshTokenRotatorMock.RotateRefreshTokenFunc: method is nil but RefreshTokenRotator.RotateRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockRotateRefreshToken.Lock()
+	mock.calls.RotateRefreshToken = append(mock.calls.RotateRefreshToken, callInfo)
+	mock.lockRotateRefreshToken.Unlock()
+	return mock.RotateRefreshTokenFunc(ctx, token)
+}
+
+// RotateRefreshTokenCalls gets all the calls that were made to RotateRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRotator.RotateRefreshTokenCalls())
+func (mock *RefreshTokenRotatorMock) RotateRefreshTokenCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockRotateRefreshToken.RLock()
+	calls = mock.calls.RotateRefreshToken
+	mock.lockRotateRefreshToken.RUnlock()
+	return calls
+}
+
+// Ensure, that TokenRevokerMock does implement TokenRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ TokenRevoker = &TokenRevokerMock{}
+
+// TokenRevokerMock is a mock implementation of TokenRevoker.
+//
+//	func TestSomethingThatUsesTokenRevoker(t *testing.T) {
+//
+//		// make and configure a mocked TokenRevoker
+//		mockedTokenRevoker := &TokenRevokerMock{
+//			RevokeTokenFunc: func(ctx context.Context, jti string) error {
+//				panic("mock out the RevokeToken method")
+//			},
+//			RevokeUserTokensFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the RevokeUserTokens method")
+//			},
+//		}
+//
+//		// use mockedTokenRevoker in code that requires TokenRevoker
+//		// and then make assertions.
+//
+//	}
+type TokenRevokerMock struct {
+	// RevokeTokenFunc mocks the RevokeToken method.
+	RevokeTokenFunc func(ctx context.Context, jti string) error
+
+	// RevokeUserTokensFunc mocks the RevokeUserTokens method.
+	RevokeUserTokensFunc func(ctx context.Context, userID entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeToken holds details about calls to the RevokeToken method.
+		RevokeToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Jti is the jti argument value.
+			Jti string
+		}
+		// RevokeUserTokens holds details about calls to the RevokeUserTokens method.
+		RevokeUserTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockRevokeToken      sync.RWMutex
+	lockRevokeUserTokens sync.RWMutex
+}
+
+// RevokeToken calls RevokeTokenFunc.
+func (mock *TokenRevokerMock) RevokeToken(ctx context.Context, jti string) error {
+	if mock.RevokeTokenFunc == nil {
+		panic("TokenRevokerMock.RevokeTokenFunc: method is nil but TokenRevoker.RevokeToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Jti string
+	}{
+		Ctx: ctx,
+		Jti: jti,
+	}
+	mock.lockRevokeToken.Lock()
+	mock.calls.RevokeToken = append(mock.calls.RevokeToken, callInfo)
+	mock.lockRevokeToken.Unlock()
+	return mock.RevokeTokenFunc(ctx, jti)
+}
+
+// RevokeTokenCalls gets all the calls that were made to RevokeToken.
+// Check the length with:
+//
+//	len(mockedTokenRevoker.RevokeTokenCalls())
+func (mock *TokenRevokerMock) RevokeTokenCalls() []struct {
+	Ctx context.Context
+	Jti string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Jti string
+	}
+	mock.lockRevokeToken.RLock()
+	calls = mock.calls.RevokeToken
+	mock.lockRevokeToken.RUnlock()
+	return calls
+}
+
+// RevokeUserTokens calls RevokeUserTokensFunc.
+func (mock *TokenRevokerMock) RevokeUserTokens(ctx context.Context, userID entity.UserID) error {
+	if mock.RevokeUserTokensFunc == nil {
+		panic("TokenRevokerMock.RevokeUserTokensFunc: method is nil but TokenRevoker.RevokeUserTokens was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserTokens.Lock()
+	mock.calls.RevokeUserTokens = append(mock.calls.RevokeUserTokens, callInfo)
+	mock.lockRevokeUserTokens.Unlock()
+	return mock.RevokeUserTokensFunc(ctx, userID)
+}
+
+// RevokeUserTokensCalls gets all the calls that were made to RevokeUserTokens.
+// Check the length with:
+//
+//	len(mockedTokenRevoker.RevokeUserTokensCalls())
+func (mock *TokenRevokerMock) RevokeUserTokensCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockRevokeUserTokens.RLock()
+	calls = mock.calls.RevokeUserTokens
+	mock.lockRevokeUserTokens.RUnlock()
+	return calls
+}
+
+// Ensure, that RefreshTokenRevokerMock does implement RefreshTokenRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ RefreshTokenRevoker = &RefreshTokenRevokerMock{}
+
+// RefreshTokenRevokerMock is a mock implementation of RefreshTokenRevoker.
+//
+//	func TestSomethingThatUsesRefreshTokenRevoker(t *testing.T) {
+//
+//		// make and configure a mocked RefreshTokenRevoker
+//		mockedRefreshTokenRevoker := &RefreshTokenRevokerMock{
+//			RevokeRefreshTokenFunc: func(ctx context.Context, userID entity.UserID, token string) error {
+//				panic("mock out the RevokeRefreshToken method")
+//			},
+//			RevokeUserRefreshTokensFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the RevokeUserRefreshTokens method")
+//			},
+//		}
+//
+//		// use mockedRefreshTokenRevoker in code that requires RefreshTokenRevoker
+//		// and then make assertions.
+//
+//	}
+type RefreshTokenRevokerMock struct {
+	// RevokeRefreshTokenFunc mocks the RevokeRefreshToken method.
+	RevokeRefreshTokenFunc func(ctx context.Context, userID entity.UserID, token string) error
+
+	// RevokeUserRefreshTokensFunc mocks the RevokeUserRefreshTokens method.
+	RevokeUserRefreshTokensFunc func(ctx context.Context, userID entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeRefreshToken holds details about calls to the RevokeRefreshToken method.
+		RevokeRefreshToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Token is the token argument value.
+			Token string
+		}
+		// RevokeUserRefreshTokens holds details about calls to the RevokeUserRefreshTokens method.
+		RevokeUserRefreshTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockRevokeRefreshToken      sync.RWMutex
+	lockRevokeUserRefreshTokens sync.RWMutex
+}
+
+// RevokeRefreshToken calls RevokeRefreshTokenFunc.
+func (mock *RefreshTokenRevokerMock) RevokeRefreshToken(ctx context.Context, userID entity.UserID, token string) error {
+	if mock.RevokeRefreshTokenFunc == nil {
+		panic("RefreshTokenRevokerMock.RevokeRefreshTokenFunc: method is nil but RefreshTokenRevoker.RevokeRefreshToken was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Token  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Token:  token,
+	}
+	mock.lockRevokeRefreshToken.Lock()
+	mock.calls.RevokeRefreshToken = append(mock.calls.RevokeRefreshToken, callInfo)
+	mock.lockRevokeRefreshToken.Unlock()
+	return mock.RevokeRefreshTokenFunc(ctx, userID, token)
+}
+
+// RevokeRefreshTokenCalls gets all the calls that were made to RevokeRefreshToken.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRevoker.RevokeRefreshTokenCalls())
+func (mock *RefreshTokenRevokerMock) RevokeRefreshTokenCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+	Token  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+		Token  string
+	}
+	mock.lockRevokeRefreshToken.RLock()
+	calls = mock.calls.RevokeRefreshToken
+	mock.lockRevokeRefreshToken.RUnlock()
+	return calls
+}
+
+// RevokeUserRefreshTokens calls RevokeUserRefreshTokensFunc.
+func (mock *RefreshTokenRevokerMock) RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error {
+	if mock.RevokeUserRefreshTokensFunc == nil {
+		panic("RefreshTokenRevokerMock.RevokeUserRefreshTokensFunc: method is nil but RefreshTokenRevoker.RevokeUserRefreshTokens was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeUserRefreshTokens.Lock()
+	mock.calls.RevokeUserRefreshTokens = append(mock.calls.RevokeUserRefreshTokens, callInfo)
+	mock.lockRevokeUserRefreshTokens.Unlock()
+	return mock.RevokeUserRefreshTokensFunc(ctx, userID)
+}
+
+// RevokeUserRefreshTokensCalls gets all the calls that were made to RevokeUserRefreshTokens.
+// Check the length with:
+//
+//	len(mockedRefreshTokenRevoker.RevokeUserRefreshTokensCalls())
+func (mock *RefreshTokenRevokerMock) RevokeUserRefreshTokensCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockRevokeUserRefreshTokens.RLock()
+	calls = mock.calls.RevokeUserRefreshTokens
+	mock.lockRevokeUserRefreshTokens.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCProviderMock does implement OIDCProvider.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCProvider = &OIDCProviderMock{}
+
+// OIDCProviderMock is a mock implementation of OIDCProvider.
+//
+//	func TestSomethingThatUsesOIDCProvider(t *testing.T) {
+//
+//		// make and configure a mocked OIDCProvider
+//		mockedOIDCProvider := &OIDCProviderMock{
+//			AuthCodeURLFunc: func(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+//				panic("mock out the AuthCodeURL method")
+//			},
+//			ExchangeFunc: func(ctx context.Context, code string, verifier string, nonce string) (*entity.OIDCIdentity, error) {
+//				panic("mock out the Exchange method")
+//			},
+//		}
+//
+//		// use mockedOIDCProvider in code that requires OIDCProvider
+//		// and then make assertions.
+//
+//	}
+type OIDCProviderMock struct {
+	// AuthCodeURLFunc mocks the AuthCodeURL method.
+	AuthCodeURLFunc func(ctx context.Context, state string, nonce string, verifier string) (string, error)
+
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(ctx context.Context, code string, verifier string, nonce string) (*entity.OIDCIdentity, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthCodeURL holds details about calls to the AuthCodeURL method.
+		AuthCodeURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+			// Nonce is the nonce argument value.
+			Nonce string
+			// Verifier is the verifier argument value.
+			Verifier string
+		}
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+			// Verifier is the verifier argument value.
+			Verifier string
+			// Nonce is the nonce argument value.
+			Nonce string
+		}
+	}
+	lockAuthCodeURL sync.RWMutex
+	lockExchange    sync.RWMutex
+}
+
+// AuthCodeURL calls AuthCodeURLFunc.
+func (mock *OIDCProviderMock) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	if mock.AuthCodeURLFunc == nil {
+		panic("OIDCProviderMock.AuthCodeURLFunc: method is nil but OIDCProvider.AuthCodeURL was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		State    string
+		Nonce    string
+		Verifier string
+	}{
+		Ctx:      ctx,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}
+	mock.lockAuthCodeURL.Lock()
+	mock.calls.AuthCodeURL = append(mock.calls.AuthCodeURL, callInfo)
+	mock.lockAuthCodeURL.Unlock()
+	return mock.AuthCodeURLFunc(ctx, state, nonce, verifier)
+}
+
+// AuthCodeURLCalls gets all the calls that were made to AuthCodeURL.
+// Check the length with:
+//
+//	len(mockedOIDCProvider.AuthCodeURLCalls())
+func (mock *OIDCProviderMock) AuthCodeURLCalls() []struct {
+	Ctx      context.Context
+	State    string
+	Nonce    string
+	Verifier string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		State    string
+		Nonce    string
+		Verifier string
+	}
+	mock.lockAuthCodeURL.RLock()
+	calls = mock.calls.AuthCodeURL
+	mock.lockAuthCodeURL.RUnlock()
+	return calls
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *OIDCProviderMock) Exchange(ctx context.Context, code string, verifier string, nonce string) (*entity.OIDCIdentity, error) {
+	if mock.ExchangeFunc == nil {
+		panic("OIDCProviderMock.ExchangeFunc: method is nil but OIDCProvider.Exchange was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Code     string
+		Verifier string
+		Nonce    string
+	}{
+		Ctx:      ctx,
+		Code:     code,
+		Verifier: verifier,
+		Nonce:    nonce,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(ctx, code, verifier, nonce)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedOIDCProvider.ExchangeCalls())
+func (mock *OIDCProviderMock) ExchangeCalls() []struct {
+	Ctx      context.Context
+	Code     string
+	Verifier string
+	Nonce    string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Code     string
+		Verifier string
+		Nonce    string
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCStateStoreMock does implement OIDCStateStore.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCStateStore = &OIDCStateStoreMock{}
+
+// OIDCStateStoreMock is a mock implementation of OIDCStateStore.
+//
+//	func TestSomethingThatUsesOIDCStateStore(t *testing.T) {
+//
+//		// make and configure a mocked OIDCStateStore
+//		mockedOIDCStateStore := &OIDCStateStoreMock{
+//			SaveOIDCStateFunc: func(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error {
+//				panic("mock out the SaveOIDCState method")
+//			},
+//			TakeOIDCStateFunc: func(ctx context.Context, state string) (*entity.OIDCState, error) {
+//				panic("mock out the TakeOIDCState method")
+//			},
+//		}
+//
+//		// use mockedOIDCStateStore in code that requires OIDCStateStore
+//		// and then make assertions.
+//
+//	}
+type OIDCStateStoreMock struct {
+	// SaveOIDCStateFunc mocks the SaveOIDCState method.
+	SaveOIDCStateFunc func(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error
+
+	// TakeOIDCStateFunc mocks the TakeOIDCState method.
+	TakeOIDCStateFunc func(ctx context.Context, state string) (*entity.OIDCState, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SaveOIDCState holds details about calls to the SaveOIDCState method.
+		SaveOIDCState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+			// S is the s argument value.
+			S *entity.OIDCState
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// TakeOIDCState holds details about calls to the TakeOIDCState method.
+		TakeOIDCState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+		}
+	}
+	lockSaveOIDCState sync.RWMutex
+	lockTakeOIDCState sync.RWMutex
+}
+
+// SaveOIDCState calls SaveOIDCStateFunc.
+func (mock *OIDCStateStoreMock) SaveOIDCState(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error {
+	if mock.SaveOIDCStateFunc == nil {
+		panic("OIDCStateStoreMock.SaveOIDCStateFunc: method is nil but OIDCStateStore.SaveOIDCState was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+		S     *entity.OIDCState
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		State: state,
+		S:     s,
+		TTL:   ttl,
+	}
+	mock.lockSaveOIDCState.Lock()
+	mock.calls.SaveOIDCState = append(mock.calls.SaveOIDCState, callInfo)
+	mock.lockSaveOIDCState.Unlock()
+	return mock.SaveOIDCStateFunc(ctx, state, s, ttl)
+}
+
+// SaveOIDCStateCalls gets all the calls that were made to SaveOIDCState.
+// Check the length with:
+//
+//	len(mockedOIDCStateStore.SaveOIDCStateCalls())
+func (mock *OIDCStateStoreMock) SaveOIDCStateCalls() []struct {
+	Ctx   context.Context
+	State string
+	S     *entity.OIDCState
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+		S     *entity.OIDCState
+		TTL   time.Duration
+	}
+	mock.lockSaveOIDCState.RLock()
+	calls = mock.calls.SaveOIDCState
+	mock.lockSaveOIDCState.RUnlock()
+	return calls
+}
+
+// TakeOIDCState calls TakeOIDCStateFunc.
+func (mock *OIDCStateStoreMock) TakeOIDCState(ctx context.Context, state string) (*entity.OIDCState, error) {
+	if mock.TakeOIDCStateFunc == nil {
+		panic("OIDCStateStoreMock.TakeOIDCStateFunc: method is nil but OIDCStateStore.TakeOIDCState was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+	}{
+		Ctx:   ctx,
+		State: state,
+	}
+	mock.lockTakeOIDCState.Lock()
+	mock.calls.TakeOIDCState = append(mock.calls.TakeOIDCState, callInfo)
+	mock.lockTakeOIDCState.Unlock()
+	return mock.TakeOIDCStateFunc(ctx, state)
+}
+
+// TakeOIDCStateCalls gets all the calls that were made to TakeOIDCState.
+// Check the length with:
+//
+//	len(mockedOIDCStateStore.TakeOIDCStateCalls())
+func (mock *OIDCStateStoreMock) TakeOIDCStateCalls() []struct {
+	Ctx   context.Context
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+	}
+	mock.lockTakeOIDCState.RLock()
+	calls = mock.calls.TakeOIDCState
+	mock.lockTakeOIDCState.RUnlock()
+	return calls
+}
+
+// Ensure, that OIDCUserRepositoryMock does implement OIDCUserRepository.
+// If this is not the case, regenerate this file with moq.
+var _ OIDCUserRepository = &OIDCUserRepositoryMock{}
+
+// OIDCUserRepositoryMock is a mock implementation of OIDCUserRepository.
+//
+//	func TestSomethingThatUsesOIDCUserRepository(t *testing.T) {
+//
+//		// make and configure a mocked OIDCUserRepository
+//		mockedOIDCUserRepository := &OIDCUserRepositoryMock{
+//			AddUserIdentityFunc: func(ctx context.Context, db store.Execer, identity *entity.UserIdentity) error {
+//				panic("mock out the AddUserIdentity method")
+//			},
+//			GetUserByIdentityFunc: func(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error) {
+//				panic("mock out the GetUserByIdentity method")
+//			},
+//			RegisterUserFunc: func(ctx context.Context, db store.Execer, user *entity.User) error {
+//				panic("mock out the RegisterUser method")
+//			},
+//		}
+//
+//		// use mockedOIDCUserRepository in code that requires OIDCUserRepository
+//		// and then make assertions.
+//
+//	}
+type OIDCUserRepositoryMock struct {
+	// AddUserIdentityFunc mocks the AddUserIdentity method.
+	AddUserIdentityFunc func(ctx context.Context, db store.Execer, identity *entity.UserIdentity) error
+
+	// GetUserByIdentityFunc mocks the GetUserByIdentity method.
+	GetUserByIdentityFunc func(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error)
+
+	// RegisterUserFunc mocks the RegisterUser method.
+	RegisterUserFunc func(ctx context.Context, db store.Execer, user *entity.User) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddUserIdentity holds details about calls to the AddUserIdentity method.
+		AddUserIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Identity is the identity argument value.
+			Identity *entity.UserIdentity
+		}
+		// GetUserByIdentity holds details about calls to the GetUserByIdentity method.
+		GetUserByIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Issuer is the issuer argument value.
+			Issuer string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// RegisterUser holds details about calls to the RegisterUser method.
+		RegisterUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// User is the user argument value.
+			User *entity.User
+		}
+	}
+	lockAddUserIdentity   sync.RWMutex
+	lockGetUserByIdentity sync.RWMutex
+	lockRegisterUser      sync.RWMutex
+}
+
+// AddUserIdentity calls AddUserIdentityFunc.
+func (mock *OIDCUserRepositoryMock) AddUserIdentity(ctx context.Context, db store.Execer, identity *entity.UserIdentity) error {
+	if mock.AddUserIdentityFunc == nil {
+		panic("OIDCUserRepositoryMock.AddUserIdentityFunc: method is nil but OIDCUserRepository.AddUserIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Execer
+		Identity *entity.UserIdentity
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		Identity: identity,
+	}
+	mock.lockAddUserIdentity.Lock()
+	mock.calls.AddUserIdentity = append(mock.calls.AddUserIdentity, callInfo)
+	mock.lockAddUserIdentity.Unlock()
+	return mock.AddUserIdentityFunc(ctx, db, identity)
+}
+
+// AddUserIdentityCalls gets all the calls that were made to AddUserIdentity.
+// Check the length with:
+//
+//	len(mockedOIDCUserRepository.AddUserIdentityCalls())
+func (mock *OIDCUserRepositoryMock) AddUserIdentityCalls() []struct {
+	Ctx      context.Context
+	Db       store.Execer
+	Identity *entity.UserIdentity
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Execer
+		Identity *entity.UserIdentity
+	}
+	mock.lockAddUserIdentity.RLock()
+	calls = mock.calls.AddUserIdentity
+	mock.lockAddUserIdentity.RUnlock()
+	return calls
+}
+
+// GetUserByIdentity calls GetUserByIdentityFunc.
+func (mock *OIDCUserRepositoryMock) GetUserByIdentity(ctx context.Context, db store.Queryer, issuer string, subject string) (*entity.User, error) {
+	if mock.GetUserByIdentityFunc == nil {
+		panic("OIDCUserRepositoryMock.GetUserByIdentityFunc: method is nil but OIDCUserRepository.GetUserByIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Issuer  string
+		Subject string
+	}{
+		Ctx:     ctx,
+		Db:      db,
+		Issuer:  issuer,
+		Subject: subject,
+	}
+	mock.lockGetUserByIdentity.Lock()
+	mock.calls.GetUserByIdentity = append(mock.calls.GetUserByIdentity, callInfo)
+	mock.lockGetUserByIdentity.Unlock()
+	return mock.GetUserByIdentityFunc(ctx, db, issuer, subject)
+}
+
+// GetUserByIdentityCalls gets all the calls that were made to GetUserByIdentity.
+// Check the length with:
+//
+//	len(mockedOIDCUserRepository.GetUserByIdentityCalls())
+func (mock *OIDCUserRepositoryMock) GetUserByIdentityCalls() []struct {
+	Ctx     context.Context
+	Db      store.Queryer
+	Issuer  string
+	Subject string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Db      store.Queryer
+		Issuer  string
+		Subject string
+	}
+	mock.lockGetUserByIdentity.RLock()
+	calls = mock.calls.GetUserByIdentity
+	mock.lockGetUserByIdentity.RUnlock()
+	return calls
+}
+
+// RegisterUser calls RegisterUserFunc.
+func (mock *OIDCUserRepositoryMock) RegisterUser(ctx context.Context, db store.Execer, user *entity.User) error {
+	if mock.RegisterUserFunc == nil {
+		panic("OIDCUserRepositoryMock.RegisterUserFunc: method is nil but OIDCUserRepository.RegisterUser was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Db   store.Execer
+		User *entity.User
+	}{
+		Ctx:  ctx,
+		Db:   db,
+		User: user,
+	}
+	mock.lockRegisterUser.Lock()
+	mock.calls.RegisterUser = append(mock.calls.RegisterUser, callInfo)
+	mock.lockRegisterUser.Unlock()
+	return mock.RegisterUserFunc(ctx, db, user)
+}
+
+// RegisterUserCalls gets all the calls that were made to RegisterUser.
+// Check the length with:
+//
+//	len(mockedOIDCUserRepository.RegisterUserCalls())
+func (mock *OIDCUserRepositoryMock) RegisterUserCalls() []struct {
+	Ctx  context.Context
+	Db   store.Execer
+	User *entity.User
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Db   store.Execer
+		User *entity.User
+	}
+	mock.lockRegisterUser.RLock()
+	calls = mock.calls.RegisterUser
+	mock.lockRegisterUser.RUnlock()
+	return calls
+}
+
+// Ensure, that MFARepositoryMock does implement MFARepository.
+// If this is not the case, regenerate this file with moq.
+var _ MFARepository = &MFARepositoryMock{}
+
+// MFARepositoryMock is a mock implementation of MFARepository.
+//
+//	func TestSomethingThatUsesMFARepository(t *testing.T) {
+//
+//		// make and configure a mocked MFARepository
+//		mockedMFARepository := &MFARepositoryMock{
+//			ConfirmUserMFAFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+//				panic("mock out the ConfirmUserMFA method")
+//			},
+//			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+//				panic("mock out the GetUserByID method")
+//			},
+//			GetUserMFAFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+//				panic("mock out the GetUserMFA method")
+//			},
+//			IsMFARequiredFunc: func(ctx context.Context, db store.Queryer, role string) (bool, error) {
+//				panic("mock out the IsMFARequired method")
+//			},
+//			ListMFARequiredRolesFunc: func(ctx context.Context, db store.Queryer) ([]string, error) {
+//				panic("mock out the ListMFARequiredRoles method")
+//			},
+//			ReplaceRecoveryCodesFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, hashes []string) error {
+//				panic("mock out the ReplaceRecoveryCodes method")
+//			},
+//			SaveUserMFAFunc: func(ctx context.Context, db store.Execer, m *entity.UserMFA) error {
+//				panic("mock out the SaveUserMFA method")
+//			},
+//			SetMFARequiredRolesFunc: func(ctx context.Context, db store.Execer, roles []string) error {
+//				panic("mock out the SetMFARequiredRoles method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, hash string) error {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//			UseTOTPStepFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+//				panic("mock out the UseTOTPStep method")
+//			},
+//		}
+//
+//		// use mockedMFARepository in code that requires MFARepository
+//		// and then make assertions.
+//
+//	}
+type MFARepositoryMock struct {
+	// ConfirmUserMFAFunc mocks the ConfirmUserMFA method.
+	ConfirmUserMFAFunc func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+
+	// GetUserMFAFunc mocks the GetUserMFA method.
+	GetUserMFAFunc func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error)
+
+	// IsMFARequiredFunc mocks the IsMFARequired method.
+	IsMFARequiredFunc func(ctx context.Context, db store.Queryer, role string) (bool, error)
+
+	// ListMFARequiredRolesFunc mocks the ListMFARequiredRoles method.
+	ListMFARequiredRolesFunc func(ctx context.Context, db store.Queryer) ([]string, error)
+
+	// ReplaceRecoveryCodesFunc mocks the ReplaceRecoveryCodes method.
+	ReplaceRecoveryCodesFunc func(ctx context.Context, db store.Execer, userID entity.UserID, hashes []string) error
+
+	// SaveUserMFAFunc mocks the SaveUserMFA method.
+	SaveUserMFAFunc func(ctx context.Context, db store.Execer, m *entity.UserMFA) error
+
+	// SetMFARequiredRolesFunc mocks the SetMFARequiredRoles method.
+	SetMFARequiredRolesFunc func(ctx context.Context, db store.Execer, roles []string) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, db store.Execer, userID entity.UserID, hash string) error
+
+	// UseTOTPStepFunc mocks the UseTOTPStep method.
+	UseTOTPStepFunc func(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConfirmUserMFA holds details about calls to the ConfirmUserMFA method.
+		ConfirmUserMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Step is the step argument value.
+			Step int64
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// ID is the id argument value.
+			ID entity.UserID
+		}
+		// GetUserMFA holds details about calls to the GetUserMFA method.
+		GetUserMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+		// IsMFARequired holds details about calls to the IsMFARequired method.
+		IsMFARequired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Role is the role argument value.
+			Role string
+		}
+		// ListMFARequiredRoles holds details about calls to the ListMFARequiredRoles method.
+		ListMFARequiredRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+		}
+		// ReplaceRecoveryCodes holds details about calls to the ReplaceRecoveryCodes method.
+		ReplaceRecoveryCodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Hashes is the hashes argument value.
+			Hashes []string
+		}
+		// SaveUserMFA holds details about calls to the SaveUserMFA method.
+		SaveUserMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// M is the m argument value.
+			M *entity.UserMFA
+		}
+		// SetMFARequiredRoles holds details about calls to the SetMFARequiredRoles method.
+		SetMFARequiredRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// Roles is the roles argument value.
+			Roles []string
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Hash is the hash argument value.
+			Hash string
+		}
+		// UseTOTPStep holds details about calls to the UseTOTPStep method.
+		UseTOTPStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// Step is the step argument value.
+			Step int64
+		}
+	}
+	lockConfirmUserMFA       sync.RWMutex
+	lockGetUserByID          sync.RWMutex
+	lockGetUserMFA           sync.RWMutex
+	lockIsMFARequired        sync.RWMutex
+	lockListMFARequiredRoles sync.RWMutex
+	lockReplaceRecoveryCodes sync.RWMutex
+	lockSaveUserMFA          sync.RWMutex
+	lockSetMFARequiredRoles  sync.RWMutex
+	lockUseRecoveryCode      sync.RWMutex
+	lockUseTOTPStep          sync.RWMutex
+}
+
+// ConfirmUserMFA calls ConfirmUserMFAFunc.
+func (mock *MFARepositoryMock) ConfirmUserMFA(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+	if mock.ConfirmUserMFAFunc == nil {
+		panic("MFARepositoryMock.ConfirmUserMFAFunc: method is nil but MFARepository.ConfirmUserMFA was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Step   int64
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Step:   step,
+	}
+	mock.lockConfirmUserMFA.Lock()
+	mock.calls.ConfirmUserMFA = append(mock.calls.ConfirmUserMFA, callInfo)
+	mock.lockConfirmUserMFA.Unlock()
+	return mock.ConfirmUserMFAFunc(ctx, db, userID, step)
+}
+
+// ConfirmUserMFACalls gets all the calls that were made to ConfirmUserMFA.
+// Check the length with:
+//
+//	len(mockedMFARepository.ConfirmUserMFACalls())
+func (mock *MFARepositoryMock) ConfirmUserMFACalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	Step   int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Step   int64
+	}
+	mock.lockConfirmUserMFA.RLock()
+	calls = mock.calls.ConfirmUserMFA
+	mock.lockConfirmUserMFA.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *MFARepositoryMock) GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("MFARepositoryMock.GetUserByIDFunc: method is nil but MFARepository.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}{
+		Ctx: ctx,
+		Db:  db,
+		ID:  id,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, db, id)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedMFARepository.GetUserByIDCalls())
+func (mock *MFARepositoryMock) GetUserByIDCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+	ID  entity.UserID
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+		ID  entity.UserID
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// GetUserMFA calls GetUserMFAFunc.
+func (mock *MFARepositoryMock) GetUserMFA(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+	if mock.GetUserMFAFunc == nil {
+		panic("MFARepositoryMock.GetUserMFAFunc: method is nil but MFARepository.GetUserMFA was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+	}
+	mock.lockGetUserMFA.Lock()
+	mock.calls.GetUserMFA = append(mock.calls.GetUserMFA, callInfo)
+	mock.lockGetUserMFA.Unlock()
+	return mock.GetUserMFAFunc(ctx, db, userID)
+}
+
+// GetUserMFACalls gets all the calls that were made to GetUserMFA.
+// Check the length with:
+//
+//	len(mockedMFARepository.GetUserMFACalls())
+func (mock *MFARepositoryMock) GetUserMFACalls() []struct {
+	Ctx    context.Context
+	Db     store.Queryer
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Queryer
+		UserID entity.UserID
+	}
+	mock.lockGetUserMFA.RLock()
+	calls = mock.calls.GetUserMFA
+	mock.lockGetUserMFA.RUnlock()
+	return calls
+}
+
+// IsMFARequired calls IsMFARequiredFunc.
+func (mock *MFARepositoryMock) IsMFARequired(ctx context.Context, db store.Queryer, role string) (bool, error) {
+	if mock.IsMFARequiredFunc == nil {
+		panic("MFARepositoryMock.IsMFARequiredFunc: method is nil but MFARepository.IsMFARequired was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Role string
+	}{
+		Ctx:  ctx,
+		Db:   db,
+		Role: role,
+	}
+	mock.lockIsMFARequired.Lock()
+	mock.calls.IsMFARequired = append(mock.calls.IsMFARequired, callInfo)
+	mock.lockIsMFARequired.Unlock()
+	return mock.IsMFARequiredFunc(ctx, db, role)
+}
+
+// IsMFARequiredCalls gets all the calls that were made to IsMFARequired.
+// Check the length with:
+//
+//	len(mockedMFARepository.IsMFARequiredCalls())
+func (mock *MFARepositoryMock) IsMFARequiredCalls() []struct {
+	Ctx  context.Context
+	Db   store.Queryer
+	Role string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Db   store.Queryer
+		Role string
+	}
+	mock.lockIsMFARequired.RLock()
+	calls = mock.calls.IsMFARequired
+	mock.lockIsMFARequired.RUnlock()
+	return calls
+}
+
+// ListMFARequiredRoles calls ListMFARequiredRolesFunc.
+func (mock *MFARepositoryMock) ListMFARequiredRoles(ctx context.Context, db store.Queryer) ([]string, error) {
+	if mock.ListMFARequiredRolesFunc == nil {
+		panic("MFARepositoryMock.ListMFARequiredRolesFunc: method is nil but MFARepository.ListMFARequiredRoles was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}{
+		Ctx: ctx,
+		Db:  db,
+	}
+	mock.lockListMFARequiredRoles.Lock()
+	mock.calls.ListMFARequiredRoles = append(mock.calls.ListMFARequiredRoles, callInfo)
+	mock.lockListMFARequiredRoles.Unlock()
+	return mock.ListMFARequiredRolesFunc(ctx, db)
+}
+
+// ListMFARequiredRolesCalls gets all the calls that were made to ListMFARequiredRoles.
+// Check the length with:
+//
+//	len(mockedMFARepository.ListMFARequiredRolesCalls())
+func (mock *MFARepositoryMock) ListMFARequiredRolesCalls() []struct {
+	Ctx context.Context
+	Db  store.Queryer
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Queryer
+	}
+	mock.lockListMFARequiredRoles.RLock()
+	calls = mock.calls.ListMFARequiredRoles
+	mock.lockListMFARequiredRoles.RUnlock()
+	return calls
+}
+
+// ReplaceRecoveryCodes calls ReplaceRecoveryCodesFunc.
+func (mock *MFARepositoryMock) ReplaceRecoveryCodes(ctx context.Context, db store.Execer, userID entity.UserID, hashes []string) error {
+	if mock.ReplaceRecoveryCodesFunc == nil {
+		panic("MFARepositoryMock.ReplaceRecoveryCodesFunc: method is nil but MFARepository.ReplaceRecoveryCodes was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Hashes []string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Hashes: hashes,
+	}
+	mock.lockReplaceRecoveryCodes.Lock()
+	mock.calls.ReplaceRecoveryCodes = append(mock.calls.ReplaceRecoveryCodes, callInfo)
+	mock.lockReplaceRecoveryCodes.Unlock()
+	return mock.ReplaceRecoveryCodesFunc(ctx, db, userID, hashes)
+}
+
+// ReplaceRecoveryCodesCalls gets all the calls that were made to ReplaceRecoveryCodes.
+// Check the length with:
+//
+//	len(mockedMFARepository.ReplaceRecoveryCodesCalls())
+func (mock *MFARepositoryMock) ReplaceRecoveryCodesCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	Hashes []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Hashes []string
+	}
+	mock.lockReplaceRecoveryCodes.RLock()
+	calls = mock.calls.ReplaceRecoveryCodes
+	mock.lockReplaceRecoveryCodes.RUnlock()
+	return calls
+}
+
+// SaveUserMFA calls SaveUserMFAFunc.
+func (mock *MFARepositoryMock) SaveUserMFA(ctx context.Context, db store.Execer, m *entity.UserMFA) error {
+	if mock.SaveUserMFAFunc == nil {
+		panic("MFARepositoryMock.SaveUserMFAFunc: method is nil but MFARepository.SaveUserMFA was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.UserMFA
+	}{
+		Ctx: ctx,
+		Db:  db,
+		M:   m,
+	}
+	mock.lockSaveUserMFA.Lock()
+	mock.calls.SaveUserMFA = append(mock.calls.SaveUserMFA, callInfo)
+	mock.lockSaveUserMFA.Unlock()
+	return mock.SaveUserMFAFunc(ctx, db, m)
+}
+
+// SaveUserMFACalls gets all the calls that were made to SaveUserMFA.
+// Check the length with:
+//
+//	len(mockedMFARepository.SaveUserMFACalls())
+func (mock *MFARepositoryMock) SaveUserMFACalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	M   *entity.UserMFA
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		M   *entity.UserMFA
+	}
+	mock.lockSaveUserMFA.RLock()
+	calls = mock.calls.SaveUserMFA
+	mock.lockSaveUserMFA.RUnlock()
+	return calls
+}
+
+// SetMFARequiredRoles calls SetMFARequiredRolesFunc.
+func (mock *MFARepositoryMock) SetMFARequiredRoles(ctx context.Context, db store.Execer, roles []string) error {
+	if mock.SetMFARequiredRolesFunc == nil {
+		panic("MFARepositoryMock.SetMFARequiredRolesFunc: method is nil but MFARepository.SetMFARequiredRoles was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Roles []string
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Roles: roles,
+	}
+	mock.lockSetMFARequiredRoles.Lock()
+	mock.calls.SetMFARequiredRoles = append(mock.calls.SetMFARequiredRoles, callInfo)
+	mock.lockSetMFARequiredRoles.Unlock()
+	return mock.SetMFARequiredRolesFunc(ctx, db, roles)
+}
+
+// SetMFARequiredRolesCalls gets all the calls that were made to SetMFARequiredRoles.
+// Check the length with:
+//
+//	len(mockedMFARepository.SetMFARequiredRolesCalls())
+func (mock *MFARepositoryMock) SetMFARequiredRolesCalls() []struct {
+	Ctx   context.Context
+	Db    store.Execer
+	Roles []string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Execer
+		Roles []string
+	}
+	mock.lockSetMFARequiredRoles.RLock()
+	calls = mock.calls.SetMFARequiredRoles
+	mock.lockSetMFARequiredRoles.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *MFARepositoryMock) UseRecoveryCode(ctx context.Context, db store.Execer, userID entity.UserID, hash string) error {
+	if mock.UseRecoveryCodeFunc == nil {
+		panic("MFARepositoryMock.UseRecoveryCodeFunc: method is nil but MFARepository.UseRecoveryCode was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Hash   string
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Hash:   hash,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	return mock.UseRecoveryCodeFunc(ctx, db, userID, hash)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedMFARepository.UseRecoveryCodeCalls())
+func (mock *MFARepositoryMock) UseRecoveryCodeCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	Hash   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Hash   string
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
+
+// UseTOTPStep calls UseTOTPStepFunc.
+func (mock *MFARepositoryMock) UseTOTPStep(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error {
+	if mock.UseTOTPStepFunc == nil {
+		panic("MFARepositoryMock.UseTOTPStepFunc: method is nil but MFARepository.UseTOTPStep was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Step   int64
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserID: userID,
+		Step:   step,
+	}
+	mock.lockUseTOTPStep.Lock()
+	mock.calls.UseTOTPStep = append(mock.calls.UseTOTPStep, callInfo)
+	mock.lockUseTOTPStep.Unlock()
+	return mock.UseTOTPStepFunc(ctx, db, userID, step)
+}
+
+// UseTOTPStepCalls gets all the calls that were made to UseTOTPStep.
+// Check the length with:
+//
+//	len(mockedMFARepository.UseTOTPStepCalls())
+func (mock *MFARepositoryMock) UseTOTPStepCalls() []struct {
+	Ctx    context.Context
+	Db     store.Execer
+	UserID entity.UserID
+	Step   int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     store.Execer
+		UserID entity.UserID
+		Step   int64
+	}
+	mock.lockUseTOTPStep.RLock()
+	calls = mock.calls.UseTOTPStep
+	mock.lockUseTOTPStep.RUnlock()
+	return calls
+}
+
+// Ensure, that MFAVerifierMock does implement MFAVerifier.
+// If this is not the case, regenerate this file with moq.
+var _ MFAVerifier = &MFAVerifierMock{}
+
+// MFAVerifierMock is a mock implementation of MFAVerifier.
+//
+//	func TestSomethingThatUsesMFAVerifier(t *testing.T) {
+//
+//		// make and configure a mocked MFAVerifier
+//		mockedMFAVerifier := &MFAVerifierMock{
+//			EnrollTOTPForFunc: func(ctx context.Context, user *entity.User) (*entity.TOTPEnrollment, error) {
+//				panic("mock out the EnrollTOTPFor method")
+//			},
+//			MFAStatusFunc: func(ctx context.Context, user *entity.User) (bool, bool, error) {
+//				panic("mock out the MFAStatus method")
+//			},
+//			VerifyMFAFunc: func(ctx context.Context, user *entity.User, code string, recoveryCode string) ([]string, error) {
+//				panic("mock out the VerifyMFA method")
+//			},
+//		}
+//
+//		// use mockedMFAVerifier in code that requires MFAVerifier
+//		// and then make assertions.
+//
+//	}
+type MFAVerifierMock struct {
+	// EnrollTOTPForFunc mocks the EnrollTOTPFor method.
+	EnrollTOTPForFunc func(ctx context.Context, user *entity.User) (*entity.TOTPEnrollment, error)
+
+	// MFAStatusFunc mocks the MFAStatus method.
+	MFAStatusFunc func(ctx context.Context, user *entity.User) (bool, bool, error)
+
+	// VerifyMFAFunc mocks the VerifyMFA method.
+	VerifyMFAFunc func(ctx context.Context, user *entity.User, code string, recoveryCode string) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// EnrollTOTPFor holds details about calls to the EnrollTOTPFor method.
+		EnrollTOTPFor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *entity.User
+		}
+		// MFAStatus holds details about calls to the MFAStatus method.
+		MFAStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *entity.User
+		}
+		// VerifyMFA holds details about calls to the VerifyMFA method.
+		VerifyMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User *entity.User
+			// Code is the code argument value.
+			Code string
+			// RecoveryCode is the recoveryCode argument value.
+			RecoveryCode string
+		}
+	}
+	lockEnrollTOTPFor sync.RWMutex
+	lockMFAStatus     sync.RWMutex
+	lockVerifyMFA     sync.RWMutex
+}
+
+// EnrollTOTPFor calls EnrollTOTPForFunc.
+func (mock *MFAVerifierMock) EnrollTOTPFor(ctx context.Context, user *entity.User) (*entity.TOTPEnrollment, error) {
+	if mock.EnrollTOTPForFunc == nil {
+		panic("MFAVerifierMock.EnrollTOTPForFunc: method is nil but MFAVerifier.EnrollTOTPFor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		User *entity.User
+	}{
+		Ctx:  ctx,
+		User: user,
+	}
+	mock.lockEnrollTOTPFor.Lock()
+	mock.calls.EnrollTOTPFor = append(mock.calls.EnrollTOTPFor, callInfo)
+	mock.lockEnrollTOTPFor.Unlock()
+	return mock.EnrollTOTPForFunc(ctx, user)
+}
+
+// EnrollTOTPForCalls gets all the calls that were made to EnrollTOTPFor.
+// Check the length with:
+//
+//	len(mockedMFAVerifier.EnrollTOTPForCalls())
+func (mock *MFAVerifierMock) EnrollTOTPForCalls() []struct {
+	Ctx  context.Context
+	User *entity.User
+} {
+	var calls []struct {
+		Ctx  context.Context
+		User *entity.User
+	}
+	mock.lockEnrollTOTPFor.RLock()
+	calls = mock.calls.EnrollTOTPFor
+	mock.lockEnrollTOTPFor.RUnlock()
+	return calls
+}
+
+// MFAStatus calls MFAStatusFunc.
+func (mock *MFAVerifierMock) MFAStatus(ctx context.Context, user *entity.User) (bool, bool, error) {
+	if mock.MFAStatusFunc == nil {
+		panic("MFAVerifierMock.MFAStatusFunc: method is nil but MFAVerifier.MFAStatus was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		User *entity.User
+	}{
+		Ctx:  ctx,
+		User: user,
+	}
+	mock.lockMFAStatus.Lock()
+	mock.calls.MFAStatus = append(mock.calls.MFAStatus, callInfo)
+	mock.lockMFAStatus.Unlock()
+	return mock.MFAStatusFunc(ctx, user)
+}
+
+// MFAStatusCalls gets all the calls that were made to MFAStatus.
+// Check the length with:
+//
+//	len(mockedMFAVerifier.MFAStatusCalls())
+func (mock *MFAVerifierMock) MFAStatusCalls() []struct {
+	Ctx  context.Context
+	User *entity.User
+} {
+	var calls []struct {
+		Ctx  context.Context
+		User *entity.User
+	}
+	mock.lockMFAStatus.RLock()
+	calls = mock.calls.MFAStatus
+	mock.lockMFAStatus.RUnlock()
+	return calls
+}
+
+// VerifyMFA calls VerifyMFAFunc.
+func (mock *MFAVerifierMock) VerifyMFA(ctx context.Context, user *entity.User, code string, recoveryCode string) ([]string, error) {
+	if mock.VerifyMFAFunc == nil {
+		panic("MFAVerifierMock.VerifyMFAFunc: method is nil but MFAVerifier.VerifyMFA was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		User         *entity.User
+		Code         string
+		RecoveryCode string
+	}{
+		Ctx:          ctx,
+		User:         user,
+		Code:         code,
+		RecoveryCode: recoveryCode,
+	}
+	mock.lockVerifyMFA.Lock()
+	mock.calls.VerifyMFA = append(mock.calls.VerifyMFA, callInfo)
+	mock.lockVerifyMFA.Unlock()
+	return mock.VerifyMFAFunc(ctx, user, code, recoveryCode)
+}
+
+// VerifyMFACalls gets all the calls that were made to VerifyMFA.
+// Check the length with:
+//
+//	len(mockedMFAVerifier.VerifyMFACalls())
+func (mock *MFAVerifierMock) VerifyMFACalls() []struct {
+	Ctx          context.Context
+	User         *entity.User
+	Code         string
+	RecoveryCode string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		User         *entity.User
+		Code         string
+		RecoveryCode string
+	}
+	mock.lockVerifyMFA.RLock()
+	calls = mock.calls.VerifyMFA
+	mock.lockVerifyMFA.RUnlock()
+	return calls
+}
+
+// Ensure, that MFAChallengeStoreMock does implement MFAChallengeStore.
+// If this is not the case, regenerate this file with moq.
+var _ MFAChallengeStore = &MFAChallengeStoreMock{}
+
+// MFAChallengeStoreMock is a mock implementation of MFAChallengeStore.
+//
+//	func TestSomethingThatUsesMFAChallengeStore(t *testing.T) {
+//
+//		// make and configure a mocked MFAChallengeStore
+//		mockedMFAChallengeStore := &MFAChallengeStoreMock{
+//			AttemptMFAChallengeFunc: func(ctx context.Context, key string) (entity.UserID, int64, error) {
+//				panic("mock out the AttemptMFAChallenge method")
+//			},
+//			DeleteMFAChallengeFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the DeleteMFAChallenge method")
+//			},
+//			LoadMFAChallengeFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+//				panic("mock out the LoadMFAChallenge method")
+//			},
+//			SaveMFAChallengeFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+//				panic("mock out the SaveMFAChallenge method")
+//			},
+//		}
+//
+//		// use mockedMFAChallengeStore in code that requires MFAChallengeStore
+//		// and then make assertions.
+//
+//	}
+type MFAChallengeStoreMock struct {
+	// AttemptMFAChallengeFunc mocks the AttemptMFAChallenge method.
+	AttemptMFAChallengeFunc func(ctx context.Context, key string) (entity.UserID, int64, error)
+
+	// DeleteMFAChallengeFunc mocks the DeleteMFAChallenge method.
+	DeleteMFAChallengeFunc func(ctx context.Context, key string) error
+
+	// LoadMFAChallengeFunc mocks the LoadMFAChallenge method.
+	LoadMFAChallengeFunc func(ctx context.Context, key string) (entity.UserID, error)
+
+	// SaveMFAChallengeFunc mocks the SaveMFAChallenge method.
+	SaveMFAChallengeFunc func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AttemptMFAChallenge holds details about calls to the AttemptMFAChallenge method.
+		AttemptMFAChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// DeleteMFAChallenge holds details about calls to the DeleteMFAChallenge method.
+		DeleteMFAChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// LoadMFAChallenge holds details about calls to the LoadMFAChallenge method.
+		LoadMFAChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// SaveMFAChallenge holds details about calls to the SaveMFAChallenge method.
+		SaveMFAChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+	}
+	lockAttemptMFAChallenge sync.RWMutex
+	lockDeleteMFAChallenge  sync.RWMutex
+	lockLoadMFAChallenge    sync.RWMutex
+	lockSaveMFAChallenge    sync.RWMutex
+}
+
+// AttemptMFAChallenge calls AttemptMFAChallengeFunc.
+func (mock *MFAChallengeStoreMock) AttemptMFAChallenge(ctx context.Context, key string) (entity.UserID, int64, error) {
+	if mock.AttemptMFAChallengeFunc == nil {
+		panic("MFAChallengeStoreMock.AttemptMFAChallengeFunc: method is nil but MFAChallengeStore.AttemptMFAChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockAttemptMFAChallenge.Lock()
+	mock.calls.AttemptMFAChallenge = append(mock.calls.AttemptMFAChallenge, callInfo)
+	mock.lockAttemptMFAChallenge.Unlock()
+	return mock.AttemptMFAChallengeFunc(ctx, key)
+}
+
+// AttemptMFAChallengeCalls gets all the calls that were made to AttemptMFAChallenge.
+// Check the length with:
+//
+//	len(mockedMFAChallengeStore.AttemptMFAChallengeCalls())
+func (mock *MFAChallengeStoreMock) AttemptMFAChallengeCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockAttemptMFAChallenge.RLock()
+	calls = mock.calls.AttemptMFAChallenge
+	mock.lockAttemptMFAChallenge.RUnlock()
+	return calls
+}
+
+// DeleteMFAChallenge calls DeleteMFAChallengeFunc.
+func (mock *MFAChallengeStoreMock) DeleteMFAChallenge(ctx context.Context, key string) error {
+	if mock.DeleteMFAChallengeFunc == nil {
+		panic("MFAChallengeStoreMock.DeleteMFAChallengeFunc: method is nil but MFAChallengeStore.DeleteMFAChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDeleteMFAChallenge.Lock()
+	mock.calls.DeleteMFAChallenge = append(mock.calls.DeleteMFAChallenge, callInfo)
+	mock.lockDeleteMFAChallenge.Unlock()
+	return mock.DeleteMFAChallengeFunc(ctx, key)
+}
+
+// DeleteMFAChallengeCalls gets all the calls that were made to DeleteMFAChallenge.
+// Check the length with:
+//
+//	len(mockedMFAChallengeStore.DeleteMFAChallengeCalls())
+func (mock *MFAChallengeStoreMock) DeleteMFAChallengeCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDeleteMFAChallenge.RLock()
+	calls = mock.calls.DeleteMFAChallenge
+	mock.lockDeleteMFAChallenge.RUnlock()
+	return calls
+}
+
+// LoadMFAChallenge calls LoadMFAChallengeFunc.
+func (mock *MFAChallengeStoreMock) LoadMFAChallenge(ctx context.Context, key string) (entity.UserID, error) {
+	if mock.LoadMFAChallengeFunc == nil {
+		panic("MFAChallengeStoreMock.LoadMFAChallengeFunc: method is nil but MFAChallengeStore.LoadMFAChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockLoadMFAChallenge.Lock()
+	mock.calls.LoadMFAChallenge = append(mock.calls.LoadMFAChallenge, callInfo)
+	mock.lockLoadMFAChallenge.Unlock()
+	return mock.LoadMFAChallengeFunc(ctx, key)
+}
+
+// LoadMFAChallengeCalls gets all the calls that were made to LoadMFAChallenge.
+// Check the length with:
+//
+//	len(mockedMFAChallengeStore.LoadMFAChallengeCalls())
+func (mock *MFAChallengeStoreMock) LoadMFAChallengeCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockLoadMFAChallenge.RLock()
+	calls = mock.calls.LoadMFAChallenge
+	mock.lockLoadMFAChallenge.RUnlock()
+	return calls
+}
+
+// SaveMFAChallenge calls SaveMFAChallengeFunc.
+func (mock *MFAChallengeStoreMock) SaveMFAChallenge(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	if mock.SaveMFAChallengeFunc == nil {
+		panic("MFAChallengeStoreMock.SaveMFAChallengeFunc: method is nil but MFAChallengeStore.SaveMFAChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		UserID: userID,
+		TTL:    ttl,
+	}
+	mock.lockSaveMFAChallenge.Lock()
+	mock.calls.SaveMFAChallenge = append(mock.calls.SaveMFAChallenge, callInfo)
+	mock.lockSaveMFAChallenge.Unlock()
+	return mock.SaveMFAChallengeFunc(ctx, key, userID, ttl)
+}
+
+// SaveMFAChallengeCalls gets all the calls that were made to SaveMFAChallenge.
+// Check the length with:
+//
+//	len(mockedMFAChallengeStore.SaveMFAChallengeCalls())
+func (mock *MFAChallengeStoreMock) SaveMFAChallengeCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	UserID entity.UserID
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}
+	mock.lockSaveMFAChallenge.RLock()
+	calls = mock.calls.SaveMFAChallenge
+	mock.lockSaveMFAChallenge.RUnlock()
+	return calls
+}
+
+// Ensure, that PasswordResetRepositoryMock does implement PasswordResetRepository.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetRepository = &PasswordResetRepositoryMock{}
+
+// PasswordResetRepositoryMock is a mock implementation of PasswordResetRepository.
+//
+//	func TestSomethingThatUsesPasswordResetRepository(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetRepository
+//		mockedPasswordResetRepository := &PasswordResetRepositoryMock{
+//			GetUserByEmailFunc: func(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+//				panic("mock out the GetUserByEmail method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, db store.Execer, id entity.UserID, password string) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetRepository in code that requires PasswordResetRepository
+//		// and then make assertions.
+//
+//	}
+type PasswordResetRepositoryMock struct {
+	// GetUserByEmailFunc mocks the GetUserByEmail method.
+	GetUserByEmailFunc func(ctx context.Context, db store.Queryer, email string) (*entity.User, error)
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, db store.Execer, id entity.UserID, password string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUserByEmail holds details about calls to the GetUserByEmail method.
+		GetUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Queryer
+			// Email is the email argument value.
+			Email string
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// ID is the id argument value.
+			ID entity.UserID
+			// Password is the password argument value.
+			Password string
+		}
+	}
+	lockGetUserByEmail sync.RWMutex
+	lockUpdatePassword sync.RWMutex
+}
+
+// GetUserByEmail calls GetUserByEmailFunc.
+func (mock *PasswordResetRepositoryMock) GetUserByEmail(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+	if mock.GetUserByEmailFunc == nil {
+		panic("PasswordResetRepositoryMock.GetUserByEmailFunc: method is nil but PasswordResetRepository.GetUserByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Email string
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Email: email,
+	}
+	mock.lockGetUserByEmail.Lock()
+	mock.calls.GetUserByEmail = append(mock.calls.GetUserByEmail, callInfo)
+	mock.lockGetUserByEmail.Unlock()
+	return mock.GetUserByEmailFunc(ctx, db, email)
+}
+
+// GetUserByEmailCalls gets all the calls that were made to GetUserByEmail.
+// Check the length with:
+//
+//	len(mockedPasswordResetRepository.GetUserByEmailCalls())
+func (mock *PasswordResetRepositoryMock) GetUserByEmailCalls() []struct {
+	Ctx   context.Context
+	Db    store.Queryer
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    store.Queryer
+		Email string
+	}
+	mock.lockGetUserByEmail.RLock()
+	calls = mock.calls.GetUserByEmail
+	mock.lockGetUserByEmail.RUnlock()
+	return calls
+}
+
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *PasswordResetRepositoryMock) UpdatePassword(ctx context.Context, db store.Execer, id entity.UserID, password string) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("PasswordResetRepositoryMock.UpdatePasswordFunc: method is nil but PasswordResetRepository.UpdatePassword was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Db       store.Execer
+		ID       entity.UserID
+		Password string
+	}{
+		Ctx:      ctx,
+		Db:       db,
+		ID:       id,
+		Password: password,
+	}
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, db, id, password)
+}
+
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
+// Check the length with:
+//
+//	len(mockedPasswordResetRepository.UpdatePasswordCalls())
+func (mock *PasswordResetRepositoryMock) UpdatePasswordCalls() []struct {
+	Ctx      context.Context
+	Db       store.Execer
+	ID       entity.UserID
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Db       store.Execer
+		ID       entity.UserID
+		Password string
+	}
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
+	return calls
+}
+
+// Ensure, that PasswordResetTokenStoreMock does implement PasswordResetTokenStore.
+// If this is not the case, regenerate this file with moq.
+var _ PasswordResetTokenStore = &PasswordResetTokenStoreMock{}
+
+// PasswordResetTokenStoreMock is a mock implementation of PasswordResetTokenStore.
+//
+//	func TestSomethingThatUsesPasswordResetTokenStore(t *testing.T) {
+//
+//		// make and configure a mocked PasswordResetTokenStore
+//		mockedPasswordResetTokenStore := &PasswordResetTokenStoreMock{
+//			SavePasswordResetTokenFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+//				panic("mock out the SavePasswordResetToken method")
+//			},
+//			TakePasswordResetTokenFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+//				panic("mock out the TakePasswordResetToken method")
+//			},
+//		}
+//
+//		// use mockedPasswordResetTokenStore in code that requires PasswordResetTokenStore
+//		// and then make assertions.
+//
+//	}
+type PasswordResetTokenStoreMock struct {
+	// SavePasswordResetTokenFunc mocks the SavePasswordResetToken method.
+	SavePasswordResetTokenFunc func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+
+	// TakePasswordResetTokenFunc mocks the TakePasswordResetToken method.
+	TakePasswordResetTokenFunc func(ctx context.Context, key string) (entity.UserID, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// SavePasswordResetToken holds details about calls to the SavePasswordResetToken method.
+		SavePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// UserID is the userID argument value.
+			UserID entity.UserID
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// TakePasswordResetToken holds details about calls to the TakePasswordResetToken method.
+		TakePasswordResetToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockSavePasswordResetToken sync.RWMutex
+	lockTakePasswordResetToken sync.RWMutex
+}
+
+// SavePasswordResetToken calls SavePasswordResetTokenFunc.
+func (mock *PasswordResetTokenStoreMock) SavePasswordResetToken(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	if mock.SavePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenStoreMock.SavePasswordResetTokenFunc: method is nil but PasswordResetTokenStore.SavePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		UserID: userID,
+		TTL:    ttl,
+	}
+	mock.lockSavePasswordResetToken.Lock()
+	mock.calls.SavePasswordResetToken = append(mock.calls.SavePasswordResetToken, callInfo)
+	mock.lockSavePasswordResetToken.Unlock()
+	return mock.SavePasswordResetTokenFunc(ctx, key, userID, ttl)
+}
+
+// SavePasswordResetTokenCalls gets all the calls that were made to SavePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenStore.SavePasswordResetTokenCalls())
+func (mock *PasswordResetTokenStoreMock) SavePasswordResetTokenCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	UserID entity.UserID
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		UserID entity.UserID
+		TTL    time.Duration
+	}
+	mock.lockSavePasswordResetToken.RLock()
+	calls = mock.calls.SavePasswordResetToken
+	mock.lockSavePasswordResetToken.RUnlock()
+	return calls
+}
+
+// TakePasswordResetToken calls TakePasswordResetTokenFunc.
+func (mock *PasswordResetTokenStoreMock) TakePasswordResetToken(ctx context.Context, key string) (entity.UserID, error) {
+	if mock.TakePasswordResetTokenFunc == nil {
+		panic("PasswordResetTokenStoreMock.TakePasswordResetTokenFunc: method is nil but PasswordResetTokenStore.TakePasswordResetToken was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockTakePasswordResetToken.Lock()
+	mock.calls.TakePasswordResetToken = append(mock.calls.TakePasswordResetToken, callInfo)
+	mock.lockTakePasswordResetToken.Unlock()
+	return mock.TakePasswordResetTokenFunc(ctx, key)
+}
+
+// TakePasswordResetTokenCalls gets all the calls that were made to TakePasswordResetToken.
+// Check the length with:
+//
+//	len(mockedPasswordResetTokenStore.TakePasswordResetTokenCalls())
+func (mock *PasswordResetTokenStoreMock) TakePasswordResetTokenCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockTakePasswordResetToken.RLock()
+	calls = mock.calls.TakePasswordResetToken
+	mock.lockTakePasswordResetToken.RUnlock()
+	return calls
+}
+
+// Ensure, that RateLimiterMock does implement RateLimiter.
+// If this is not the case, regenerate this file with moq.
+var _ RateLimiter = &RateLimiterMock{}
+
+// RateLimiterMock is a mock implementation of RateLimiter.
+//
+//	func TestSomethingThatUsesRateLimiter(t *testing.T) {
+//
+//		// make and configure a mocked RateLimiter
+//		mockedRateLimiter := &RateLimiterMock{
+//			TakeRateLimitFunc: func(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (time.Duration, error) {
+//				panic("mock out the TakeRateLimit method")
+//			},
+//		}
+//
+//		// use mockedRateLimiter in code that requires RateLimiter
+//		// and then make assertions.
+//
+//	}
+type RateLimiterMock struct {
+	// TakeRateLimitFunc mocks the TakeRateLimit method.
+	TakeRateLimitFunc func(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (time.Duration, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// TakeRateLimit holds details about calls to the TakeRateLimit method.
+		TakeRateLimit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Limit is the limit argument value.
+			Limit int64
+			// Window is the window argument value.
+			Window time.Duration
+			// Now is the now argument value.
+			Now time.Time
+		}
+	}
+	lockTakeRateLimit sync.RWMutex
+}
+
+// TakeRateLimit calls TakeRateLimitFunc.
+func (mock *RateLimiterMock) TakeRateLimit(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (time.Duration, error) {
+	if mock.TakeRateLimitFunc == nil {
+		panic("RateLimiterMock.TakeRateLimitFunc: method is nil but RateLimiter.TakeRateLimit was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		Limit  int64
+		Window time.Duration
+		Now    time.Time
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		Limit:  limit,
+		Window: window,
+		Now:    now,
+	}
+	mock.lockTakeRateLimit.Lock()
+	mock.calls.TakeRateLimit = append(mock.calls.TakeRateLimit, callInfo)
+	mock.lockTakeRateLimit.Unlock()
+	return mock.TakeRateLimitFunc(ctx, key, limit, window, now)
+}
+
+// TakeRateLimitCalls gets all the calls that were made to TakeRateLimit.
+// Check the length with:
+//
+//	len(mockedRateLimiter.TakeRateLimitCalls())
+func (mock *RateLimiterMock) TakeRateLimitCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	Limit  int64
+	Window time.Duration
+	Now    time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		Limit  int64
+		Window time.Duration
+		Now    time.Time
+	}
+	mock.lockTakeRateLimit.RLock()
+	calls = mock.calls.TakeRateLimit
+	mock.lockTakeRateLimit.RUnlock()
+	return calls
+}
+
+// Ensure, that SessionRevokerMock does implement SessionRevoker.
+// If this is not the case, regenerate this file with moq.
+var _ SessionRevoker = &SessionRevokerMock{}
+
+// SessionRevokerMock is a mock implementation of SessionRevoker.
+//
+//	func TestSomethingThatUsesSessionRevoker(t *testing.T) {
+//
+//		// make and configure a mocked SessionRevoker
+//		mockedSessionRevoker := &SessionRevokerMock{
+//			RevokeSessionsFunc: func(ctx context.Context, userID entity.UserID) error {
+//				panic("mock out the RevokeSessions method")
+//			},
+//		}
+//
+//		// use mockedSessionRevoker in code that requires SessionRevoker
+//		// and then make assertions.
+//
+//	}
+type SessionRevokerMock struct {
+	// RevokeSessionsFunc mocks the RevokeSessions method.
+	RevokeSessionsFunc func(ctx context.Context, userID entity.UserID) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RevokeSessions holds details about calls to the RevokeSessions method.
+		RevokeSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID entity.UserID
+		}
+	}
+	lockRevokeSessions sync.RWMutex
+}
+
+// RevokeSessions calls RevokeSessionsFunc.
+func (mock *SessionRevokerMock) RevokeSessions(ctx context.Context, userID entity.UserID) error {
+	if mock.RevokeSessionsFunc == nil {
+		panic("SessionRevokerMock.RevokeSessionsFunc: method is nil but SessionRevoker.RevokeSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRevokeSessions.Lock()
+	mock.calls.RevokeSessions = append(mock.calls.RevokeSessions, callInfo)
+	mock.lockRevokeSessions.Unlock()
+	return mock.RevokeSessionsFunc(ctx, userID)
+}
+
+// RevokeSessionsCalls gets all the calls that were made to RevokeSessions.
+// Check the length with:
+//
+//	len(mockedSessionRevoker.RevokeSessionsCalls())
+func (mock *SessionRevokerMock) RevokeSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID entity.UserID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID entity.UserID
+	}
+	mock.lockRevokeSessions.RLock()
+	calls = mock.calls.RevokeSessions
+	mock.lockRevokeSessions.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginThrottlerMock does implement LoginThrottler.
+// If this is not the case, regenerate this file with moq.
+var _ LoginThrottler = &LoginThrottlerMock{}
+
+// LoginThrottlerMock is a mock implementation of LoginThrottler.
+//
+//	func TestSomethingThatUsesLoginThrottler(t *testing.T) {
+//
+//		// make and configure a mocked LoginThrottler
+//		mockedLoginThrottler := &LoginThrottlerMock{
+//			CheckFunc: func(ctx context.Context, userName string, clientIP string) error {
+//				panic("mock out the Check method")
+//			},
+//			FailFunc: func(ctx context.Context, userName string, clientIP string) error {
+//				panic("mock out the Fail method")
+//			},
+//			SucceedFunc: func(ctx context.Context, userName string) error {
+//				panic("mock out the Succeed method")
+//			},
+//		}
+//
+//		// use mockedLoginThrottler in code that requires LoginThrottler
+//		// and then make assertions.
+//
+//	}
+type LoginThrottlerMock struct {
+	// CheckFunc mocks the Check method.
+	CheckFunc func(ctx context.Context, userName string, clientIP string) error
+
+	// FailFunc mocks the Fail method.
+	FailFunc func(ctx context.Context, userName string, clientIP string) error
+
+	// SucceedFunc mocks the Succeed method.
+	SucceedFunc func(ctx context.Context, userName string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Check holds details about calls to the Check method.
+		Check []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserName is the userName argument value.
+			UserName string
+			// ClientIP is the clientIP argument value.
+			ClientIP string
+		}
+		// Fail holds details about calls to the Fail method.
+		Fail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserName is the userName argument value.
+			UserName string
+			// ClientIP is the clientIP argument value.
+			ClientIP string
+		}
+		// Succeed holds details about calls to the Succeed method.
+		Succeed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserName is the userName argument value.
+			UserName string
+		}
+	}
+	lockCheck   sync.RWMutex
+	lockFail    sync.RWMutex
+	lockSucceed sync.RWMutex
+}
+
+// Check calls CheckFunc.
+func (mock *LoginThrottlerMock) Check(ctx context.Context, userName string, clientIP string) error {
+	if mock.CheckFunc == nil {
+		panic("LoginThrottlerMock.CheckFunc: method is nil but LoginThrottler.Check was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserName string
+		ClientIP string
+	}{
+		Ctx:      ctx,
+		UserName: userName,
+		ClientIP: clientIP,
+	}
+	mock.lockCheck.Lock()
+	mock.calls.Check = append(mock.calls.Check, callInfo)
+	mock.lockCheck.Unlock()
+	return mock.CheckFunc(ctx, userName, clientIP)
+}
+
+// CheckCalls gets all the calls that were made to Check.
+// Check the length with:
+//
+//	len(mockedLoginThrottler.CheckCalls())
+func (mock *LoginThrottlerMock) CheckCalls() []struct {
+	Ctx      context.Context
+	UserName string
+	ClientIP string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserName string
+		ClientIP string
+	}
+	mock.lockCheck.RLock()
+	calls = mock.calls.Check
+	mock.lockCheck.RUnlock()
+	return calls
+}
+
+// Fail calls FailFunc.
+func (mock *LoginThrottlerMock) Fail(ctx context.Context, userName string, clientIP string) error {
+	if mock.FailFunc == nil {
+		panic("LoginThrottlerMock.FailFunc: method is nil but LoginThrottler.Fail was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserName string
+		ClientIP string
+	}{
+		Ctx:      ctx,
+		UserName: userName,
+		ClientIP: clientIP,
+	}
+	mock.lockFail.Lock()
+	mock.calls.Fail = append(mock.calls.Fail, callInfo)
+	mock.lockFail.Unlock()
+	return mock.FailFunc(ctx, userName, clientIP)
+}
+
+// FailCalls gets all the calls that were made to Fail.
+// Check the length with:
+//
+//	len(mockedLoginThrottler.FailCalls())
+func (mock *LoginThrottlerMock) FailCalls() []struct {
+	Ctx      context.Context
+	UserName string
+	ClientIP string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserName string
+		ClientIP string
+	}
+	mock.lockFail.RLock()
+	calls = mock.calls.Fail
+	mock.lockFail.RUnlock()
+	return calls
+}
+
+// Succeed calls SucceedFunc.
+func (mock *LoginThrottlerMock) Succeed(ctx context.Context, userName string) error {
+	if mock.SucceedFunc == nil {
+		panic("LoginThrottlerMock.SucceedFunc: method is nil but LoginThrottler.Succeed was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserName string
+	}{
+		Ctx:      ctx,
+		UserName: userName,
+	}
+	mock.lockSucceed.Lock()
+	mock.calls.Succeed = append(mock.calls.Succeed, callInfo)
+	mock.lockSucceed.Unlock()
+	return mock.SucceedFunc(ctx, userName)
+}
+
+// SucceedCalls gets all the calls that were made to Succeed.
+// Check the length with:
+//
+//	len(mockedLoginThrottler.SucceedCalls())
+func (mock *LoginThrottlerMock) SucceedCalls() []struct {
+	Ctx      context.Context
+	UserName string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserName string
+	}
+	mock.lockSucceed.RLock()
+	calls = mock.calls.Succeed
+	mock.lockSucceed.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginFailureStoreMock does implement LoginFailureStore.
+// If this is not the case, regenerate this file with moq.
+var _ LoginFailureStore = &LoginFailureStoreMock{}
+
+// LoginFailureStoreMock is a mock implementation of LoginFailureStore.
+//
+//	func TestSomethingThatUsesLoginFailureStore(t *testing.T) {
+//
+//		// make and configure a mocked LoginFailureStore
+//		mockedLoginFailureStore := &LoginFailureStoreMock{
+//			AddLoginFailureFunc: func(ctx context.Context, key string, window time.Duration, now time.Time, lockAfter int64, lockFor time.Duration) (int64, bool, error) {
+//				panic("mock out the AddLoginFailure method")
+//			},
+//			ClearLoginFailuresFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the ClearLoginFailures method")
+//			},
+//			LoginFailuresFunc: func(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, time.Duration, error) {
+//				panic("mock out the LoginFailures method")
+//			},
+//			ReserveLoginAttemptFunc: func(ctx context.Context, key string, window time.Duration, now time.Time, delays []time.Duration, lockAfter int64, lockFor time.Duration) (time.Duration, error) {
+//				panic("mock out the ReserveLoginAttempt method")
+//			},
+//			TakeLoginLockoutFunc: func(ctx context.Context, key string) (int64, time.Duration, bool, error) {
+//				panic("mock out the TakeLoginLockout method")
+//			},
+//		}
+//
+//		// use mockedLoginFailureStore in code that requires LoginFailureStore
+//		// and then make assertions.
+//
+//	}
+type LoginFailureStoreMock struct {
+	// AddLoginFailureFunc mocks the AddLoginFailure method.
+	AddLoginFailureFunc func(ctx context.Context, key string, window time.Duration, now time.Time, lockAfter int64, lockFor time.Duration) (int64, bool, error)
+
+	// ClearLoginFailuresFunc mocks the ClearLoginFailures method.
+	ClearLoginFailuresFunc func(ctx context.Context, key string) error
+
+	// LoginFailuresFunc mocks the LoginFailures method.
+	LoginFailuresFunc func(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, time.Duration, error)
+
+	// ReserveLoginAttemptFunc mocks the ReserveLoginAttempt method.
+	ReserveLoginAttemptFunc func(ctx context.Context, key string, window time.Duration, now time.Time, delays []time.Duration, lockAfter int64, lockFor time.Duration) (time.Duration, error)
+
+	// TakeLoginLockoutFunc mocks the TakeLoginLockout method.
+	TakeLoginLockoutFunc func(ctx context.Context, key string) (int64, time.Duration, bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddLoginFailure holds details about calls to the AddLoginFailure method.
+		AddLoginFailure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Window is the window argument value.
+			Window time.Duration
+			// Now is the now argument value.
+			Now time.Time
+			// LockAfter is the lockAfter argument value.
+			LockAfter int64
+			// LockFor is the lockFor argument value.
+			LockFor time.Duration
+		}
+		// ClearLoginFailures holds details about calls to the ClearLoginFailures method.
+		ClearLoginFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// LoginFailures holds details about calls to the LoginFailures method.
+		LoginFailures []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Window is the window argument value.
+			Window time.Duration
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// ReserveLoginAttempt holds details about calls to the ReserveLoginAttempt method.
+		ReserveLoginAttempt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Window is the window argument value.
+			Window time.Duration
+			// Now is the now argument value.
+			Now time.Time
+			// Delays is the delays argument value.
+			Delays []time.Duration
+			// LockAfter is the lockAfter argument value.
+			LockAfter int64
+			// LockFor is the lockFor argument value.
+			LockFor time.Duration
+		}
+		// TakeLoginLockout holds details about calls to the TakeLoginLockout method.
+		TakeLoginLockout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockAddLoginFailure     sync.RWMutex
+	lockClearLoginFailures  sync.RWMutex
+	lockLoginFailures       sync.RWMutex
+	lockReserveLoginAttempt sync.RWMutex
+	lockTakeLoginLockout    sync.RWMutex
+}
+
+// AddLoginFailure calls AddLoginFailureFunc.
+func (mock *LoginFailureStoreMock) AddLoginFailure(ctx context.Context, key string, window time.Duration, now time.Time, lockAfter int64, lockFor time.Duration) (int64, bool, error) {
+	if mock.AddLoginFailureFunc == nil {
+		panic("LoginFailureStoreMock.AddLoginFailureFunc: method is nil but LoginFailureStore.AddLoginFailure was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Key       string
+		Window    time.Duration
+		Now       time.Time
+		LockAfter int64
+		LockFor   time.Duration
+	}{
+		Ctx:       ctx,
+		Key:       key,
+		Window:    window,
+		Now:       now,
+		LockAfter: lockAfter,
+		LockFor:   lockFor,
+	}
+	mock.lockAddLoginFailure.Lock()
+	mock.calls.AddLoginFailure = append(mock.calls.AddLoginFailure, callInfo)
+	mock.lockAddLoginFailure.Unlock()
+	return mock.AddLoginFailureFunc(ctx, key, window, now, lockAfter, lockFor)
+}
+
+// AddLoginFailureCalls gets all the calls that were made to AddLoginFailure.
+// Check the length with:
+//
+//	len(mockedLoginFailureStore.AddLoginFailureCalls())
+func (mock *LoginFailureStoreMock) AddLoginFailureCalls() []struct {
+	Ctx       context.Context
+	Key       string
+	Window    time.Duration
+	Now       time.Time
+	LockAfter int64
+	LockFor   time.Duration
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Key       string
+		Window    time.Duration
+		Now       time.Time
+		LockAfter int64
+		LockFor   time.Duration
+	}
+	mock.lockAddLoginFailure.RLock()
+	calls = mock.calls.AddLoginFailure
+	mock.lockAddLoginFailure.RUnlock()
+	return calls
+}
+
+// ClearLoginFailures calls ClearLoginFailuresFunc.
+func (mock *LoginFailureStoreMock) ClearLoginFailures(ctx context.Context, key string) error {
+	if mock.ClearLoginFailuresFunc == nil {
+		panic("LoginFailureStoreMock.ClearLoginFailuresFunc: method is nil but LoginFailureStore.ClearLoginFailures was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockClearLoginFailures.Lock()
+	mock.calls.ClearLoginFailures = append(mock.calls.ClearLoginFailures, callInfo)
+	mock.lockClearLoginFailures.Unlock()
+	return mock.ClearLoginFailuresFunc(ctx, key)
+}
+
+// ClearLoginFailuresCalls gets all the calls that were made to ClearLoginFailures.
+// Check the length with:
+//
+//	len(mockedLoginFailureStore.ClearLoginFailuresCalls())
+func (mock *LoginFailureStoreMock) ClearLoginFailuresCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockClearLoginFailures.RLock()
+	calls = mock.calls.ClearLoginFailures
+	mock.lockClearLoginFailures.RUnlock()
+	return calls
+}
+
+// LoginFailures calls LoginFailuresFunc.
+func (mock *LoginFailureStoreMock) LoginFailures(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, time.Duration, error) {
+	if mock.LoginFailuresFunc == nil {
+		panic("LoginFailureStoreMock.LoginFailuresFunc: method is nil but LoginFailureStore.LoginFailures was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+		Now    time.Time
+	}{
+		Ctx:    ctx,
+		Key:    key,
+		Window: window,
+		Now:    now,
+	}
+	mock.lockLoginFailures.Lock()
+	mock.calls.LoginFailures = append(mock.calls.LoginFailures, callInfo)
+	mock.lockLoginFailures.Unlock()
+	return mock.LoginFailuresFunc(ctx, key, window, now)
+}
+
+// LoginFailuresCalls gets all the calls that were made to LoginFailures.
+// Check the length with:
+//
+//	len(mockedLoginFailureStore.LoginFailuresCalls())
+func (mock *LoginFailureStoreMock) LoginFailuresCalls() []struct {
+	Ctx    context.Context
+	Key    string
+	Window time.Duration
+	Now    time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Key    string
+		Window time.Duration
+		Now    time.Time
+	}
+	mock.lockLoginFailures.RLock()
+	calls = mock.calls.LoginFailures
+	mock.lockLoginFailures.RUnlock()
+	return calls
+}
+
+// ReserveLoginAttempt calls ReserveLoginAttemptFunc.
+func (mock *LoginFailureStoreMock) ReserveLoginAttempt(ctx context.Context, key string, window time.Duration, now time.Time, delays []time.Duration, lockAfter int64, lockFor time.Duration) (time.Duration, error) {
+	if mock.ReserveLoginAttemptFunc == nil {
+		panic("LoginFailureStoreMock.ReserveLoginAttemptFunc: method is nil but LoginFailureStore.ReserveLoginAttempt was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Key       string
+		Window    time.Duration
+		Now       time.Time
+		Delays    []time.Duration
+		LockAfter int64
+		LockFor   time.Duration
+	}{
+		Ctx:       ctx,
+		Key:       key,
+		Window:    window,
+		Now:       now,
+		Delays:    delays,
+		LockAfter: lockAfter,
+		LockFor:   lockFor,
+	}
+	mock.lockReserveLoginAttempt.Lock()
+	mock.calls.ReserveLoginAttempt = append(mock.calls.ReserveLoginAttempt, callInfo)
+	mock.lockReserveLoginAttempt.Unlock()
+	return mock.ReserveLoginAttemptFunc(ctx, key, window, now, delays, lockAfter, lockFor)
+}
+
+// ReserveLoginAttemptCalls gets all the calls that were made to ReserveLoginAttempt.
+// Check the length with:
+//
+//	len(mockedLoginFailureStore.ReserveLoginAttemptCalls())
+func (mock *LoginFailureStoreMock) ReserveLoginAttemptCalls() []struct {
+	Ctx       context.Context
+	Key       string
+	Window    time.Duration
+	Now       time.Time
+	Delays    []time.Duration
+	LockAfter int64
+	LockFor   time.Duration
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Key       string
+		Window    time.Duration
+		Now       time.Time
+		Delays    []time.Duration
+		LockAfter int64
+		LockFor   time.Duration
+	}
+	mock.lockReserveLoginAttempt.RLock()
+	calls = mock.calls.ReserveLoginAttempt
+	mock.lockReserveLoginAttempt.RUnlock()
+	return calls
+}
+
+// TakeLoginLockout calls TakeLoginLockoutFunc.
+func (mock *LoginFailureStoreMock) TakeLoginLockout(ctx context.Context, key string) (int64, time.Duration, bool, error) {
+	if mock.TakeLoginLockoutFunc == nil {
+		panic("LoginFailureStoreMock.TakeLoginLockoutFunc: method is nil but LoginFailureStore.TakeLoginLockout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockTakeLoginLockout.Lock()
+	mock.calls.TakeLoginLockout = append(mock.calls.TakeLoginLockout, callInfo)
+	mock.lockTakeLoginLockout.Unlock()
+	return mock.TakeLoginLockoutFunc(ctx, key)
+}
+
+// TakeLoginLockoutCalls gets all the calls that were made to TakeLoginLockout.
+// Check the length with:
+//
+//	len(mockedLoginFailureStore.TakeLoginLockoutCalls())
+func (mock *LoginFailureStoreMock) TakeLoginLockoutCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockTakeLoginLockout.RLock()
+	calls = mock.calls.TakeLoginLockout
+	mock.lockTakeLoginLockout.RUnlock()
+	return calls
+}
+
+// Ensure, that LoginLockoutRecorderMock does implement LoginLockoutRecorder.
+// If this is not the case, regenerate this file with moq.
+var _ LoginLockoutRecorder = &LoginLockoutRecorderMock{}
+
+// LoginLockoutRecorderMock is a mock implementation of LoginLockoutRecorder.
+//
+//	func TestSomethingThatUsesLoginLockoutRecorder(t *testing.T) {
+//
+//		// make and configure a mocked LoginLockoutRecorder
+//		mockedLoginLockoutRecorder := &LoginLockoutRecorderMock{
+//			AddLoginLockoutFunc: func(ctx context.Context, db store.Execer, l *entity.LoginLockout) error {
+//				panic("mock out the AddLoginLockout method")
+//			},
+//		}
+//
+//		// use mockedLoginLockoutRecorder in code that requires LoginLockoutRecorder
+//		// and then make assertions.
+//
+//	}
+type LoginLockoutRecorderMock struct {
+	// AddLoginLockoutFunc mocks the AddLoginLockout method.
+	AddLoginLockoutFunc func(ctx context.Context, db store.Execer, l *entity.LoginLockout) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddLoginLockout holds details about calls to the AddLoginLockout method.
+		AddLoginLockout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db store.Execer
+			// L is the l argument value.
+			L *entity.LoginLockout
+		}
+	}
+	lockAddLoginLockout sync.RWMutex
+}
+
+// AddLoginLockout calls AddLoginLockoutFunc.
+func (mock *LoginLockoutRecorderMock) AddLoginLockout(ctx context.Context, db store.Execer, l *entity.LoginLockout) error {
+	if mock.AddLoginLockoutFunc == nil {
+		panic("LoginLockoutRecorderMock.AddLoginLockoutFunc: method is nil but LoginLockoutRecorder.AddLoginLockout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Db  store.Execer
+		L   *entity.LoginLockout
+	}{
+		Ctx: ctx,
+		Db:  db,
+		L:   l,
+	}
+	mock.lockAddLoginLockout.Lock()
+	mock.calls.AddLoginLockout = append(mock.calls.AddLoginLockout, callInfo)
+	mock.lockAddLoginLockout.Unlock()
+	return mock.AddLoginLockoutFunc(ctx, db, l)
+}
+
+// AddLoginLockoutCalls gets all the calls that were made to AddLoginLockout.
+// Check the length with:
+//
+//	len(mockedLoginLockoutRecorder.AddLoginLockoutCalls())
+func (mock *LoginLockoutRecorderMock) AddLoginLockoutCalls() []struct {
+	Ctx context.Context
+	Db  store.Execer
+	L   *entity.LoginLockout
+} {
+	var calls []struct {
+		Ctx context.Context
+		Db  store.Execer
+		L   *entity.LoginLockout
+	}
+	mock.lockAddLoginLockout.RLock()
+	calls = mock.calls.AddLoginLockout
+	mock.lockAddLoginLockout.RUnlock()
+	return calls
+}
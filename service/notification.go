@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type Notify struct {
+	DB   *sqlx.DB
+	Repo NotificationAdder
+}
+
+// Notify は通知を受け取るユーザーの設定を確認して、受け取る設定なら通知を登録する
+func (s *Notify) Notify(ctx context.Context, n *entity.Notification) error {
+	return addNotification(ctx, s.DB, s.Repo, n)
+}
+
+// addNotification はNotifyの本体。呼び出し側のトランザクションで通知を登録するときはtxを渡す
+func addNotification(ctx context.Context, db store.ExecQueryer, repo NotificationAdder, n *entity.Notification) error {
+	prefs, err := repo.ListNotificationPreferences(ctx, db, n.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	if !notificationEnabled(prefs, n.Type) {
+		return nil
+	}
+	if err := repo.AddNotification(ctx, db, n); err != nil {
+		return fmt.Errorf("failed to add notification: %w", err)
+	}
+	return nil
+}
+
+// notificationEnabled は設定で通知の種類が無効にされていないかを返す
+func notificationEnabled(prefs []*entity.NotificationPreference, t entity.NotificationType) bool {
+	for _, p := range prefs {
+		if p.Type == t {
+			return p.Enabled
+		}
+	}
+	return true
+}
+
+type ListNotifications struct {
+	DB   store.Queryer
+	Repo NotificationLister
+}
+
+// ListNotifications は通知の一覧と未読の件数を返す。unreadOnlyなら未読の通知だけを返す
+func (l *ListNotifications) ListNotifications(ctx context.Context, unreadOnly bool) (entity.Notifications, int, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, 0, fmt.Errorf("user_id not found")
+	}
+
+	notifications, err := l.Repo.ListNotifications(ctx, l.DB, userID, unreadOnly)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	unread, err := l.Repo.CountUnreadNotifications(ctx, l.DB, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return notifications, unread, nil
+}
+
+type ReadNotifications struct {
+	DB   store.ExecQueryer
+	Repo NotificationReader
+}
+
+// MarkRead は自分の通知を既読にする
+func (r *ReadNotifications) MarkRead(ctx context.Context, id entity.NotificationID) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := r.Repo.MarkNotificationRead(ctx, r.DB, userID, id); err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead は自分の未読の通知をすべて既読にし、既読にした件数を返す
+func (r *ReadNotifications) MarkAllRead(ctx context.Context) (int64, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return 0, fmt.Errorf("user_id not found")
+	}
+	n, err := r.Repo.MarkAllNotificationsRead(ctx, r.DB, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return n, nil
+}
+
+type NotificationPreferences struct {
+	DB   *sqlx.DB
+	Repo NotificationPreferenceStore
+}
+
+// Preferences はすべての通知の種類について、受け取るかの設定を返す
+func (s *NotificationPreferences) Preferences(ctx context.Context) ([]*entity.NotificationPreference, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	return s.preferences(ctx, s.DB, userID)
+}
+
+// UpdatePreferences は指定された種類の設定だけを変更し、変更後のすべての設定を返す
+func (s *NotificationPreferences) UpdatePreferences(
+	ctx context.Context, prefs []*entity.NotificationPreference,
+) ([]*entity.NotificationPreference, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, p := range prefs {
+		if err := s.Repo.SaveNotificationPreference(ctx, tx, userID, p); err != nil {
+			return nil, fmt.Errorf("failed to save notification preference: %w", err)
+		}
+	}
+	all, err := s.preferences(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return all, nil
+}
+
+func (s *NotificationPreferences) preferences(
+	ctx context.Context, db store.Queryer, userID entity.UserID,
+) ([]*entity.NotificationPreference, error) {
+	saved, err := s.Repo.ListNotificationPreferences(ctx, db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	prefs := make([]*entity.NotificationPreference, 0, len(entity.NotificationTypes))
+	for _, t := range entity.NotificationTypes {
+		prefs = append(prefs, &entity.NotificationPreference{Type: t, Enabled: notificationEnabled(saved, t)})
+	}
+	return prefs, nil
+}
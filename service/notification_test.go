@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestNotify_Notify(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		prefs   []*entity.NotificationPreference
+		wantAdd bool
+	}{
+		"no preferences": {
+			wantAdd: true,
+		},
+		"enabled": {
+			prefs:   []*entity.NotificationPreference{{Type: entity.NotificationTypeTaskAssigned, Enabled: true}},
+			wantAdd: true,
+		},
+		"disabled": {
+			prefs: []*entity.NotificationPreference{{Type: entity.NotificationTypeTaskAssigned, Enabled: false}},
+		},
+		"other type disabled": {
+			prefs:   []*entity.NotificationPreference{{Type: entity.NotificationTypeDueReminder, Enabled: false}},
+			wantAdd: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &NotificationAdderMock{
+				ListNotificationPreferencesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+					if userID != 2 {
+						t.Errorf("want preferences of user 2, but got %d", userID)
+					}
+					return tt.prefs, nil
+				},
+				AddNotificationFunc: func(ctx context.Context, db store.Execer, n *entity.Notification) error {
+					return nil
+				},
+			}
+			sut := &Notify{DB: &sqlx.DB{}, Repo: repo}
+			n := &entity.Notification{UserID: 2, Type: entity.NotificationTypeTaskAssigned, Message: "assigned"}
+			if err := sut.Notify(context.Background(), n); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(repo.AddNotificationCalls()) == 1; got != tt.wantAdd {
+				t.Errorf("added = %v, want %v", got, tt.wantAdd)
+			}
+		})
+	}
+}
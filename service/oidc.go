@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+var (
+	// ErrOIDCNotConfigured はIDプロバイダーを設定していないときのエラー
+	ErrOIDCNotConfigured = errors.New("oidc login is not configured")
+	// ErrInvalidOIDCState は存在しない、期限切れ、または使用済みのstateのエラー
+	ErrInvalidOIDCState = errors.New("invalid oidc state")
+	// ErrOIDCAuthentication はコードの交換やIDトークンの検証に失敗したときのエラー
+	ErrOIDCAuthentication = errors.New("oidc authentication failed")
+)
+
+const (
+	// oidcUserRole はIDプロバイダーで初めてログインしたユーザーのロール
+	oidcUserRole = "user"
+	// maxUserNameLength はusers.nameの長さの上限
+	maxUserNameLength = 20
+)
+
+// OIDCLogin はOpenID Connectの認可コードフローでログインし、アクセストークンとリフレッシュトークンを発行する
+type OIDCLogin struct {
+	DB             store.TxBeginner
+	Repo           OIDCUserRepository
+	States         OIDCStateStore
+	Provider       OIDCProvider
+	TokenGenerator TokenGenerator
+	RefreshTokens  RefreshTokenIssuer
+	// MFA がnilなら2要素認証をせずにトークンを発行する。Loginと同じものを渡す
+	MFA        MFAVerifier
+	Challenges MFAChallengeStore
+	// ChallengeTTL はIDプロバイダーでログインしてから2要素認証を終えるまでの制限時間
+	ChallengeTTL time.Duration
+	// StateTTL はログインを始めてからコールバックまでの制限時間
+	StateTTL time.Duration
+}
+
+// OIDCAuthURL はstate、nonce、PKCEのcode_verifierを作って保存し、IDプロバイダーの認可エンドポイントのURLを返す。
+// 呼び出し側はstateをブラウザに結び付け、コールバックのstateと一致することを確かめる
+func (o *OIDCLogin) OIDCAuthURL(ctx context.Context) (authURL, state string, err error) {
+	if o.Provider == nil {
+		return "", "", ErrOIDCNotConfigured
+	}
+	state, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	s := &entity.OIDCState{}
+	if s.Nonce, err = randomToken(); err != nil {
+		return "", "", err
+	}
+	if s.CodeVerifier, err = randomToken(); err != nil {
+		return "", "", err
+	}
+	if err := o.States.SaveOIDCState(ctx, state, s, o.StateTTL); err != nil {
+		return "", "", fmt.Errorf("failed to save oidc state: %w", err)
+	}
+	u, err := o.Provider.AuthCodeURL(ctx, state, s.Nonce, s.CodeVerifier)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build auth url: %w", err)
+	}
+	return u, state, nil
+}
+
+// OIDCCallback は認可コードを交換してIDトークンのユーザーを探し、いなければ作ってトークンを発行する。
+// パスワードでのログインと同じく、2要素認証を登録済みか、ロールで必須なら、トークンの代わりにMFAトークンを返す
+func (o *OIDCLogin) OIDCCallback(ctx context.Context, code, state string) (*entity.LoginResult, error) {
+	if o.Provider == nil {
+		return nil, ErrOIDCNotConfigured
+	}
+	s, err := o.States.TakeOIDCState(ctx, state)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, fmt.Errorf("failed to take oidc state: %w", err)
+	}
+	identity, err := o.Provider.Exchange(ctx, code, s.CodeVerifier, s.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCAuthentication, err)
+	}
+
+	user, err := o.findOrCreateUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := startMFAChallenge(ctx, o.MFA, o.Challenges, o.ChallengeTTL, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &entity.LoginResult{Challenge: challenge}, nil
+	}
+
+	token, err := o.TokenGenerator.GenerateToken(ctx, *user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	refresh, err := o.RefreshTokens.IssueRefreshToken(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue refresh token: %w", err)
+	}
+	return &entity.LoginResult{Tokens: &entity.Tokens{AccessToken: string(token), RefreshToken: refresh}}, nil
+}
+
+// findOrCreateUser はissとsubに対応するユーザーを返す。いなければユーザーを作って対応付ける。
+// メールアドレスが同じでも既存のユーザーには対応付けない。IDプロバイダーのメールアドレスは検証済みとは限らないため
+func (o *OIDCLogin) findOrCreateUser(ctx context.Context, identity *entity.OIDCIdentity) (*entity.User, error) {
+	tx, err := o.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := o.Repo.GetUserByIdentity(ctx, tx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// パスワードは空にしておく。bcryptのハッシュにならないので、パスワードでのログインは間違ったパスワードと同じく失敗する
+	user = &entity.User{Role: oidcUserRole, Timezone: entity.DefaultTimezone}
+	base := oidcUserName(identity)
+	for i := 0; ; i++ {
+		user.Name = base
+		if i > 0 {
+			suffix, err := randomSuffix()
+			if err != nil {
+				return nil, err
+			}
+			user.Name = truncate(base, maxUserNameLength-len(suffix)) + suffix
+		}
+		err := o.Repo.RegisterUser(ctx, tx, user)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, store.ErrAlreadyExists) || i >= 5 {
+			return nil, fmt.Errorf("failed to register user: %w", err)
+		}
+	}
+	if err := o.Repo.AddUserIdentity(ctx, tx, &entity.UserIdentity{
+		Issuer: identity.Issuer, Subject: identity.Subject, UserID: user.ID,
+	}); err != nil {
+		if errors.Is(err, store.ErrAlreadyExists) {
+			// 同じアカウントで同時に初めてログインしたリクエストが先に対応付けた。
+			// 作ったユーザーはロールバックで捨て、先に作られたユーザーでログインする
+			_ = tx.Rollback()
+			return o.getUserByIdentity(ctx, identity)
+		}
+		return nil, fmt.Errorf("failed to add user identity: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return user, nil
+}
+
+// getUserByIdentity はissとsubに対応するユーザーを新しいトランザクションで読む。
+// 最初のトランザクションのスナップショットには、ほかのトランザクションがコミットした対応付けが見えないため
+func (o *OIDCLogin) getUserByIdentity(ctx context.Context, identity *entity.OIDCIdentity) (*entity.User, error) {
+	tx, err := o.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	user, err := o.Repo.GetUserByIdentity(ctx, tx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+var invalidUserNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// oidcUserName は新しく作るユーザーの名前を、preferred_username、メールアドレスのローカル部、nameの順に探す
+func oidcUserName(identity *entity.OIDCIdentity) string {
+	local, _, _ := strings.Cut(identity.Email, "@")
+	for _, s := range []string{identity.PreferredUsername, local, identity.Name} {
+		if name := truncate(invalidUserNameChars.ReplaceAllString(s, ""), maxUserNameLength); name != "" {
+			return name
+		}
+	}
+	return "user"
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// randomSuffix は名前が重複したときに付ける"-"と16進数4桁
+func randomSuffix() (string, error) {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate suffix: %w", err)
+	}
+	return "-" + hex.EncodeToString(b), nil
+}
+
+// randomToken はstateやnonce、code_verifierに使うランダムな文字列
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestOIDCLogin_OIDCAuthURL(t *testing.T) {
+	t.Parallel()
+
+	var saved *entity.OIDCState
+	var savedState string
+	states := &OIDCStateStoreMock{
+		SaveOIDCStateFunc: func(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error {
+			if ttl != 10*time.Minute {
+				t.Errorf("want ttl %v, but got %v", 10*time.Minute, ttl)
+			}
+			savedState, saved = state, s
+			return nil
+		},
+	}
+	provider := &OIDCProviderMock{
+		AuthCodeURLFunc: func(ctx context.Context, state, nonce, verifier string) (string, error) {
+			if state != savedState || nonce != saved.Nonce || verifier != saved.CodeVerifier {
+				t.Errorf("auth url must use the saved state, nonce and verifier")
+			}
+			return "https://idp.example.com/authorize", nil
+		},
+	}
+	sut := &OIDCLogin{States: states, Provider: provider, StateTTL: 10 * time.Minute}
+
+	got, state, err := sut.OIDCAuthURL(context.Background())
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got != "https://idp.example.com/authorize" {
+		t.Errorf("want the provider's url, but got %q", got)
+	}
+	if state != savedState {
+		t.Errorf("want the saved state %q, but got %q", savedState, state)
+	}
+	if savedState == "" || saved.Nonce == "" || saved.CodeVerifier == "" || saved.Nonce == saved.CodeVerifier {
+		t.Errorf("want distinct random values, but got state %q and %+v", savedState, saved)
+	}
+
+	if _, _, err := (&OIDCLogin{}).OIDCAuthURL(context.Background()); !errors.Is(err, ErrOIDCNotConfigured) {
+		t.Errorf("want ErrOIDCNotConfigured, but got %v", err)
+	}
+}
+
+func TestOIDCLogin_OIDCCallback(t *testing.T) {
+	t.Parallel()
+
+	identity := &entity.OIDCIdentity{Issuer: "https://idp.example.com", Subject: "sub-1", Email: "alice@example.com", PreferredUsername: "alice"}
+	existing := &entity.User{ID: 3, Name: "alice", Role: "user"}
+
+	tests := map[string]struct {
+		takeErr     error
+		exchangeErr error
+		existing    *entity.User
+		// registerErrs はRegisterUserが呼ばれるたびに順に返すエラー
+		registerErrs []error
+		// raced はAddUserIdentityの前に、同時にログインした別のリクエストがユーザーを対応付けたことを表す
+		raced bool
+		// mfaEnabled、mfaRequired はMFAStatusが返す値
+		mfaEnabled    bool
+		mfaRequired   bool
+		wantNames     []string
+		wantBegin     bool
+		wantCommit    bool
+		wantChallenge *entity.MFAChallenge
+		wantErr       error
+	}{
+		"existingUser": {
+			existing:  existing,
+			wantBegin: true,
+		},
+		"mfaEnabled": {
+			existing:      existing,
+			mfaEnabled:    true,
+			wantBegin:     true,
+			wantChallenge: &entity.MFAChallenge{},
+		},
+		"mfaRequiredByRole": {
+			registerErrs:  []error{nil},
+			wantNames:     []string{"^alice$"},
+			mfaRequired:   true,
+			wantBegin:     true,
+			wantCommit:    true,
+			wantChallenge: &entity.MFAChallenge{EnrollmentRequired: true},
+		},
+		"newUser": {
+			registerErrs: []error{nil},
+			wantNames:    []string{"^alice$"},
+			wantBegin:    true,
+			wantCommit:   true,
+		},
+		"nameTaken": {
+			registerErrs: []error{store.ErrAlreadyExists, nil},
+			wantNames:    []string{"^alice$", "^alice-[0-9a-f]{4}$"},
+			wantBegin:    true,
+			wantCommit:   true,
+		},
+		"concurrentFirstLogin": {
+			registerErrs: []error{nil},
+			wantNames:    []string{"^alice$"},
+			raced:        true,
+			wantBegin:    true,
+		},
+		"invalidState": {
+			takeErr: store.ErrNotFound,
+			wantErr: ErrInvalidOIDCState,
+		},
+		"exchangeFailed": {
+			exchangeErr: errors.New("invalid id token"),
+			wantErr:     ErrOIDCAuthentication,
+		},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			if tt.wantBegin {
+				mock.ExpectBegin()
+				if tt.wantCommit {
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+			if tt.raced {
+				// 先にコミットされたユーザーを新しいトランザクションで読み直す
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			}
+
+			states := &OIDCStateStoreMock{
+				TakeOIDCStateFunc: func(ctx context.Context, state string) (*entity.OIDCState, error) {
+					if tt.takeErr != nil {
+						return nil, tt.takeErr
+					}
+					return &entity.OIDCState{Nonce: "nonce", CodeVerifier: "verifier"}, nil
+				},
+			}
+			provider := &OIDCProviderMock{
+				ExchangeFunc: func(ctx context.Context, code, verifier, nonce string) (*entity.OIDCIdentity, error) {
+					if code != "code" || verifier != "verifier" || nonce != "nonce" {
+						t.Errorf("want code, verifier and nonce from the state, but got %q %q %q", code, verifier, nonce)
+					}
+					return identity, tt.exchangeErr
+				},
+			}
+			var names []string
+			repo := &OIDCUserRepositoryMock{
+				GetUserByIdentityFunc: func(ctx context.Context, db store.Queryer, issuer, subject string) (*entity.User, error) {
+					if tt.existing != nil {
+						return tt.existing, nil
+					}
+					if tt.raced && len(names) > 0 {
+						return existing, nil
+					}
+					return nil, store.ErrNotFound
+				},
+				RegisterUserFunc: func(ctx context.Context, db store.Execer, user *entity.User) error {
+					if user.Password != "" || user.Role != "user" {
+						t.Errorf("want user role without password, but got %+v", user)
+					}
+					names = append(names, user.Name)
+					err := tt.registerErrs[len(names)-1]
+					if err == nil {
+						user.ID = 9
+					}
+					return err
+				},
+				AddUserIdentityFunc: func(ctx context.Context, db store.Execer, identity *entity.UserIdentity) error {
+					want := entity.UserIdentity{Issuer: "https://idp.example.com", Subject: "sub-1", UserID: 9}
+					if *identity != want {
+						t.Errorf("want identity %+v, but got %+v", want, *identity)
+					}
+					if tt.raced {
+						return fmt.Errorf("failed to add user identity: %w", store.ErrAlreadyExists)
+					}
+					return nil
+				},
+			}
+			generator := &TokenGeneratorMock{
+				GenerateTokenFunc: func(ctx context.Context, user entity.User) ([]byte, error) {
+					return []byte("access"), nil
+				},
+			}
+			refresh := &RefreshTokenIssuerMock{
+				IssueRefreshTokenFunc: func(ctx context.Context, userID entity.UserID) (string, error) {
+					return "refresh", nil
+				},
+			}
+			var saved string
+			challenges := &MFAChallengeStoreMock{
+				SaveMFAChallengeFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+					if userID == 0 || ttl != 5*time.Minute {
+						t.Errorf("want challenge for the user with ttl 5m, but got user %d, ttl %s", userID, ttl)
+					}
+					saved = key
+					return nil
+				},
+			}
+			sut := &OIDCLogin{
+				DB: sqlx.NewDb(db, "mysql"), Repo: repo, States: states, Provider: provider,
+				TokenGenerator: generator, RefreshTokens: refresh,
+				MFA: &MFAVerifierMock{
+					MFAStatusFunc: func(ctx context.Context, user *entity.User) (bool, bool, error) {
+						return tt.mfaEnabled, tt.mfaRequired, nil
+					},
+				},
+				Challenges:   challenges,
+				ChallengeTTL: 5 * time.Minute,
+			}
+
+			got, err := sut.OIDCCallback(context.Background(), "code", "state")
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if tt.wantChallenge != nil {
+				// 2要素認証が要るなら、トークンを発行せずにMFAトークンだけを返す
+				if got.Tokens != nil || got.Challenge == nil {
+					t.Fatalf("want only mfa challenge, but got %+v", got)
+				}
+				if got.Challenge.EnrollmentRequired != tt.wantChallenge.EnrollmentRequired {
+					t.Errorf("want enrollment required %v, but got %v", tt.wantChallenge.EnrollmentRequired, got.Challenge.EnrollmentRequired)
+				}
+				if saved != hashToken(got.Challenge.MFAToken) {
+					t.Errorf("want challenge saved by hash of the mfa token")
+				}
+				if len(generator.GenerateTokenCalls()) != 0 || len(refresh.IssueRefreshTokenCalls()) != 0 {
+					t.Errorf("want no tokens issued before mfa")
+				}
+			} else if want := (entity.Tokens{AccessToken: "access", RefreshToken: "refresh"}); got.Tokens == nil || *got.Tokens != want {
+				t.Errorf("want %+v, but got %+v", want, got)
+			}
+			if tt.raced {
+				if calls := generator.GenerateTokenCalls(); len(calls) != 1 || calls[0].User.ID != existing.ID {
+					t.Errorf("want tokens for the user created by the other request %d, but got %+v", existing.ID, calls)
+				}
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("want names %v, but got %v", tt.wantNames, names)
+			}
+			for i, pattern := range tt.wantNames {
+				if !regexp.MustCompile(pattern).MatchString(names[i]) {
+					t.Errorf("want name matching %s, but got %q", pattern, names[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_oidcUserName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		identity entity.OIDCIdentity
+		want     string
+	}{
+		"preferredUsername": {identity: entity.OIDCIdentity{PreferredUsername: "alice", Email: "bob@example.com"}, want: "alice"},
+		"emailLocalPart":    {identity: entity.OIDCIdentity{Email: "bob.smith@example.com"}, want: "bob.smith"},
+		"name":              {identity: entity.OIDCIdentity{Name: "Carol Jones"}, want: "CarolJones"},
+		"truncated":         {identity: entity.OIDCIdentity{PreferredUsername: "a-very-long-user-name-from-sso"}, want: "a-very-long-user-nam"},
+		"invalidChars":      {identity: entity.OIDCIdentity{PreferredUsername: "山田", Email: "yamada@example.com"}, want: "yamada"},
+		"fallback":          {identity: entity.OIDCIdentity{Subject: "sub"}, want: "user"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			if got := oidcUserName(&tt.identity); got != tt.want {
+				t.Errorf("want %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/mailer"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// ErrInvalidResetToken は存在しない、期限切れ、または使用済みの再設定トークンのエラー
+var ErrInvalidResetToken = errors.New("invalid password reset token")
+
+// PasswordReset はメールで送る使い捨てのトークンでパスワードを再設定する
+type PasswordReset struct {
+	DB        *sqlx.DB
+	Repo      PasswordResetRepository
+	Tokens    PasswordResetTokenStore
+	Limiter   RateLimiter
+	Sessions  SessionRevoker
+	Mailer    mailer.Mailer
+	Templates *mailer.Templates
+	Clocker   clock.Clocker
+	// URL はメールに載せる再設定の画面のURL。tokenのクエリを付ける
+	URL string
+	// TTL は再設定トークンの有効期間
+	TTL time.Duration
+	// Window の間に、同じIPアドレスからIPLimit回、同じメールアドレスにEmailLimit回まで再設定を求められる
+	Window     time.Duration
+	IPLimit    int64
+	EmailLimit int64
+}
+
+// ForgotPassword はメールアドレスのユーザーに再設定のメールを送る。
+// アカウントがあるかを知られないよう、ユーザーがいなくてもエラーにしない
+func (p *PasswordReset) ForgotPassword(ctx context.Context, email, clientIP string) error {
+	now := p.Clocker.Now()
+	if err := p.limit(ctx, "password_forgot:ip:"+clientIP, p.IPLimit, now); err != nil {
+		return err
+	}
+	// レート制限のキーにはメールアドレスを残さない
+	if err := p.limit(ctx, "password_forgot:email:"+hashToken(strings.ToLower(email)), p.EmailLimit, now); err != nil {
+		return err
+	}
+
+	user, err := p.Repo.GetUserByEmail(ctx, p.DB, email)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	if err := p.Tokens.SavePasswordResetToken(ctx, hashToken(token), user.ID, p.TTL); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse password reset url: %w", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	msg, err := p.Templates.Render(mailer.TemplatePasswordReset, mailer.DefaultLang, &mailer.PasswordResetData{
+		Name:      user.Name,
+		ResetURL:  u.String(),
+		ExpiresIn: p.TTL,
+	})
+	if err != nil {
+		return err
+	}
+	msg.To = []string{user.Email}
+	// 送信の失敗を返すとアカウントがあることが分かるので、ログに残すだけにする
+	if err := p.Mailer.Send(ctx, msg); err != nil {
+		log.Printf("failed to send password reset mail to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword はトークンを使ってパスワードを置き換え、ユーザーのすべてのセッションを失効させる
+func (p *PasswordReset) ResetPassword(ctx context.Context, token, password string) error {
+	userID, err := p.Tokens.TakePasswordResetToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return fmt.Errorf("failed to take password reset token: %w", err)
+	}
+	user := &entity.User{ID: userID, Password: password}
+	if err := user.HashPassword(); err != nil {
+		return err
+	}
+	if err := p.Repo.UpdatePassword(ctx, p.DB, userID, user.Password); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	if err := p.Sessions.RevokeSessions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// limit はkeyの試行を数え、上限を超えていればRateLimitErrorを返す
+func (p *PasswordReset) limit(ctx context.Context, key string, limit int64, now time.Time) error {
+	retry, err := p.Limiter.TakeRateLimit(ctx, key, limit, p.Window, now)
+	if err != nil {
+		return fmt.Errorf("failed to take rate limit: %w", err)
+	}
+	if retry > 0 {
+		return &RateLimitError{RetryAfter: retry}
+	}
+	return nil
+}
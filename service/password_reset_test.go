@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/mailer"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestPasswordReset_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	templates, err := mailer.LoadTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		email string
+		// limited はレート制限に掛かるキーの接頭辞
+		limited   string
+		wantMail  bool
+		wantRetry time.Duration
+	}{
+		"exists":       {email: "alice@example.com", wantMail: true},
+		"notExists":    {email: "nobody@example.com"},
+		"ipLimited":    {email: "alice@example.com", limited: "password_forgot:ip:", wantRetry: time.Minute},
+		"emailLimited": {email: "alice@example.com", limited: "password_forgot:email:", wantRetry: time.Minute},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var savedKey string
+			repo := &PasswordResetRepositoryMock{
+				GetUserByEmailFunc: func(ctx context.Context, db store.Queryer, email string) (*entity.User, error) {
+					if email != "alice@example.com" {
+						return nil, store.ErrNotFound
+					}
+					return &entity.User{ID: 1, Name: "alice", Email: email}, nil
+				},
+			}
+			tokens := &PasswordResetTokenStoreMock{
+				SavePasswordResetTokenFunc: func(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+					if userID != 1 || ttl != 30*time.Minute {
+						t.Errorf("unexpected token for user %d with ttl %v", userID, ttl)
+					}
+					savedKey = key
+					return nil
+				},
+			}
+			limiter := &RateLimiterMock{
+				TakeRateLimitFunc: func(ctx context.Context, key string, limit int64, window time.Duration, at time.Time) (time.Duration, error) {
+					if window != time.Hour || !at.Equal(now) {
+						t.Errorf("unexpected window %v at %v", window, at)
+					}
+					if key == "password_forgot:email:alice@example.com" {
+						t.Errorf("must not keep the email address in the key")
+					}
+					if tt.limited != "" && strings.HasPrefix(key, tt.limited) {
+						return time.Minute, nil
+					}
+					return 0, nil
+				},
+			}
+			capture := &mailer.Capture{From: "todo@example.com", Clocker: clock.NewManualClocker(now)}
+			sut := &PasswordReset{
+				Repo: repo, Tokens: tokens, Limiter: limiter, Mailer: capture, Templates: templates,
+				Clocker: clock.NewManualClocker(now),
+				URL:     "https://todo.example.com/password/reset",
+				TTL:     30 * time.Minute, Window: time.Hour, IPLimit: 10, EmailLimit: 3,
+			}
+
+			err := sut.ForgotPassword(context.Background(), tt.email, "192.0.2.1")
+			if tt.wantRetry > 0 {
+				var rle *RateLimitError
+				if !errors.As(err, &rle) || rle.RetryAfter != tt.wantRetry || !errors.Is(err, ErrTooManyRequests) {
+					t.Fatalf("want RateLimitError retrying after %v, but got %v", tt.wantRetry, err)
+				}
+				if len(repo.GetUserByEmailCalls()) != 0 {
+					t.Errorf("must not look up the user when rate limited")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			messages := capture.Messages()
+			if !tt.wantMail {
+				if len(messages) != 0 || len(tokens.SavePasswordResetTokenCalls()) != 0 {
+					t.Errorf("want no mail and no token, but got %d mails", len(messages))
+				}
+				return
+			}
+			if len(messages) != 1 || messages[0].To[0] != tt.email {
+				t.Fatalf("want a mail to %s, but got %+v", tt.email, messages)
+			}
+			m := regexp.MustCompile(`https://todo\.example\.com/password/reset\?token=([^"<]+)`).FindStringSubmatch(messages[0].HTML)
+			if m == nil {
+				t.Fatalf("want the reset url in the mail, but got %s", messages[0].HTML)
+			}
+			token, err := url.QueryUnescape(m[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 保存するのはトークンのハッシュだけ
+			if savedKey != hashToken(token) {
+				t.Errorf("want the hash of the mailed token to be saved")
+			}
+		})
+	}
+}
+
+func TestPasswordReset_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		token   string
+		wantErr error
+	}{
+		"ok":           {token: "valid"},
+		"invalidToken": {token: "used", wantErr: ErrInvalidResetToken},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var hashed string
+			repo := &PasswordResetRepositoryMock{
+				UpdatePasswordFunc: func(ctx context.Context, db store.Execer, id entity.UserID, password string) error {
+					if id != 1 {
+						t.Errorf("want user 1, but got %d", id)
+					}
+					hashed = password
+					return nil
+				},
+			}
+			tokens := &PasswordResetTokenStoreMock{
+				TakePasswordResetTokenFunc: func(ctx context.Context, key string) (entity.UserID, error) {
+					if key != hashToken("valid") {
+						return 0, store.ErrNotFound
+					}
+					return 1, nil
+				},
+			}
+			sessions := &SessionRevokerMock{
+				RevokeSessionsFunc: func(ctx context.Context, userID entity.UserID) error {
+					return nil
+				},
+			}
+			sut := &PasswordReset{Repo: repo, Tokens: tokens, Sessions: sessions}
+
+			err := sut.ResetPassword(context.Background(), tt.token, "new-password")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				if len(repo.UpdatePasswordCalls()) != 0 || len(sessions.RevokeSessionsCalls()) != 0 {
+					t.Errorf("must not change the password or sessions with an invalid token")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			u := &entity.User{Password: hashed}
+			if err := u.ComparePassword("new-password"); err != nil {
+				t.Errorf("want the new password to be hashed, but got %v", err)
+			}
+			calls := sessions.RevokeSessionsCalls()
+			if len(calls) != 1 || calls[0].UserID != 1 {
+				t.Errorf("want the sessions of user 1 to be revoked, but got %+v", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddProject struct {
+	DB   store.Execer
+	Repo ProjectAdder
+}
+
+// AddProject はリクエストのワークスペースにプロジェクトを作成する
+func (a *AddProject) AddProject(ctx context.Context, name string) (*entity.Project, error) {
+	p := &entity.Project{Name: name}
+	if err := a.Repo.AddProject(ctx, a.DB, p); err != nil {
+		return nil, fmt.Errorf("failed to add project: %w", err)
+	}
+	return p, nil
+}
+
+type ListProjects struct {
+	DB   store.Queryer
+	Repo ProjectLister
+}
+
+func (l *ListProjects) ListProjects(ctx context.Context) (entity.Projects, error) {
+	projects, err := l.Repo.ListProjects(ctx, l.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/quickadd"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type QuickAddTask struct {
+	DB      store.TxBeginner
+	Repo    TaskQuickAdder
+	Clocker clock.Clocker
+	Stats   StatsInvalidator
+}
+
+// QuickAddTask は1行の入力を解析し、期限・ラベル・優先度付きのタスクを作成する。
+// 相対的な日付はユーザーのタイムゾーンで解釈する。
+func (q *QuickAddTask) QuickAddTask(ctx context.Context, text string) (*entity.Task, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := q.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	c, err := lockDefaultColumn(ctx, tx, q.Repo, userID, entity.TaskStatusTodo)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWIPLimit(ctx, tx, q.Repo, c, 0, 1); err != nil {
+		return nil, err
+	}
+	u, err := q.Repo.GetUserByID(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	p := &quickadd.Parser{Clocker: q.Clocker}
+	parsed, err := p.Parse(text, u.Location())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	task := &entity.Task{
+		UserID:   userID,
+		Title:    parsed.Title,
+		Status:   entity.TaskStatusTodo,
+		Priority: parsed.Priority,
+		DueAt:    parsed.DueAt,
+		Labels:   parsed.Labels,
+	}
+	if err := q.Repo.AddTask(ctx, tx, task); err != nil {
+		return nil, fmt.Errorf("failed to register: %w", err)
+	}
+	if len(task.Labels) > 0 {
+		if err := q.Repo.AddTaskLabels(ctx, tx, task.ID, task.Labels); err != nil {
+			return nil, fmt.Errorf("failed to add labels: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	invalidateStats(ctx, q.Stats, userID)
+	return task, nil
+}
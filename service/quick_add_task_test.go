@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/quickadd"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestQuickAddTask_QuickAddTask(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 4, 10, 20, 0, 0, 0, time.UTC)
+
+	limit := 1
+	tests := []struct {
+		name       string
+		text       string
+		column     *entity.Column
+		addErr     error
+		wantLabels []string
+		wantCommit bool
+		wantError  bool
+		wantErrIs  error
+	}{
+		{
+			name:       "parsed task with labels",
+			text:       "Pay rent tomorrow 9am #home !high",
+			wantLabels: []string{"home"},
+			wantCommit: true,
+		},
+		{
+			name:       "no labels",
+			text:       "Call mom",
+			wantCommit: true,
+		},
+		{
+			name:      "empty title",
+			text:      "#home !high",
+			wantError: true,
+			wantErrIs: quickadd.ErrEmptyTitle,
+		},
+		{
+			name:      "wip limit reached",
+			text:      "Call mom",
+			column:    &entity.Column{ID: 3, Name: "Ready", Status: entity.TaskStatusTodo, WIPLimit: &limit},
+			wantError: true,
+			wantErrIs: ErrWIPLimitExceeded,
+		},
+		{
+			name:      "rollback when adding the task fails",
+			text:      "Pay rent #home",
+			addErr:    errors.New("database error"),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			var gotLabels []string
+			repo := &TaskQuickAdderMock{
+				GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+					if tt.column == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.column, nil
+				},
+				CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+					return 1, nil
+				},
+				GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+					return &entity.User{ID: id, Timezone: "Asia/Tokyo"}, nil
+				},
+				AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+					if tt.addErr != nil {
+						return tt.addErr
+					}
+					t.ID = 1
+					return nil
+				},
+				AddTaskLabelsFunc: func(ctx context.Context, db store.Execer, taskID entity.TaskID, names []string) error {
+					gotLabels = names
+					return nil
+				},
+			}
+			sut := &QuickAddTask{
+				DB:      sqlx.NewDb(db, "mysql"),
+				Repo:    repo,
+				Clocker: fixedNow(now),
+			}
+			ctx := auth.SetUserID(context.Background(), 1)
+			got, err := sut.QuickAddTask(ctx, tt.text)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("QuickAddTask() expected error but got none")
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("QuickAddTask() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuickAddTask() unexpected error: %v", err)
+			}
+			if len(gotLabels) != len(tt.wantLabels) {
+				t.Errorf("AddTaskLabels() called with %v, want %v", gotLabels, tt.wantLabels)
+			}
+			if got.ID != 1 || got.UserID != 1 || got.Status != entity.TaskStatusTodo {
+				t.Errorf("unexpected task: %+v", got)
+			}
+		})
+	}
+
+	t.Run("due date is interpreted in the user's timezone", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+
+		repo := &TaskQuickAdderMock{
+			GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+				return nil, store.ErrNotFound
+			},
+			GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+				return &entity.User{ID: id, Timezone: "Asia/Tokyo"}, nil
+			},
+			AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+				return nil
+			},
+		}
+		sut := &QuickAddTask{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Clocker: fixedNow(now)}
+		got, err := sut.QuickAddTask(auth.SetUserID(context.Background(), 1), "Pay rent tomorrow 9am !high")
+		if err != nil {
+			t.Fatalf("QuickAddTask() unexpected error: %v", err)
+		}
+		// 東京では既に4/11なので、明日は4/12 09:00 JST
+		want := time.Date(2024, 4, 12, 0, 0, 0, 0, time.UTC)
+		if got.DueAt == nil || !got.DueAt.Equal(want) {
+			t.Errorf("DueAt = %v, want %v", got.DueAt, want)
+		}
+		if got.Title != "Pay rent" || got.Priority != entity.TaskPriorityHigh {
+			t.Errorf("unexpected task: %+v", got)
+		}
+	})
+}
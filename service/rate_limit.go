@@ -0,0 +1,23 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTooManyRequests は短い間に試行が多すぎるときのエラー
+var ErrTooManyRequests = errors.New("too many requests")
+
+// RateLimitError は試行が多すぎて断ったときのエラー。RetryAfterだけ待てば再び試せる
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: retry after %v", ErrTooManyRequests, e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrTooManyRequests
+}
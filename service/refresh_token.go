@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+var (
+	// ErrInvalidRefreshToken は存在しない、期限切れ、または失効したリフレッシュトークンのエラー
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused はローテーション済みのリフレッシュトークンが再び使われたときのエラー。
+	// 盗まれた可能性があるので、同じFamilyのトークンはすべて失効させる
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// RefreshTokens はリフレッシュトークンを発行し、使われたトークンをローテーションする。
+// トークンはランダムな文字列で、KVSにはそのハッシュだけを保存する
+type RefreshTokens struct {
+	Store    RefreshTokenStore
+	Lifetime time.Duration
+}
+
+// IssueRefreshToken は新しいFamilyのリフレッシュトークンを発行する
+func (rt *RefreshTokens) IssueRefreshToken(ctx context.Context, userID entity.UserID) (string, error) {
+	return rt.issue(ctx, &entity.RefreshToken{UserID: userID, Family: uuid.New().String()})
+}
+
+// RotateRefreshToken はリフレッシュトークンを使用済みにし、同じFamilyの新しいトークンを発行する。
+// 使用済みのトークンが渡されたら、Familyごと失効させてErrRefreshTokenReusedを返す
+func (rt *RefreshTokens) RotateRefreshToken(ctx context.Context, token string) (entity.UserID, string, error) {
+	used, err := rt.Store.UseRefreshToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return 0, "", ErrInvalidRefreshToken
+		}
+		return 0, "", fmt.Errorf("failed to use refresh token: %w", err)
+	}
+	if used.Used {
+		if err := rt.Store.RevokeRefreshTokenFamily(ctx, used.Family); err != nil {
+			log.Printf("failed to revoke refresh token family %s: %v", used.Family, err)
+		}
+		return 0, "", ErrRefreshTokenReused
+	}
+	next, err := rt.issue(ctx, &entity.RefreshToken{UserID: used.UserID, Family: used.Family})
+	if err != nil {
+		return 0, "", err
+	}
+	return used.UserID, next, nil
+}
+
+// RevokeRefreshToken はuserIDのリフレッシュトークンを、同じFamilyのトークンとともに失効させる。
+// 存在しないトークンや他のユーザーのトークンは無視する
+func (rt *RefreshTokens) RevokeRefreshToken(ctx context.Context, userID entity.UserID, token string) error {
+	t, err := rt.Store.LoadRefreshToken(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	if t.UserID != userID {
+		return nil
+	}
+	if err := rt.Store.RevokeRefreshTokenFamily(ctx, t.Family); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserRefreshTokens はユーザーのリフレッシュトークンをすべて失効させる
+func (rt *RefreshTokens) RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error {
+	if err := rt.Store.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (rt *RefreshTokens) issue(ctx context.Context, t *entity.RefreshToken) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	if err := rt.Store.SaveRefreshToken(ctx, hashToken(token), t, rt.Lifetime); err != nil {
+		return "", fmt.Errorf("failed to save refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// hashToken はKVSのキーにするトークンのハッシュ。KVSには生のトークンを保存しない
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RefreshToken はリフレッシュトークンをローテーションし、新しいアクセストークンと組にして返す
+type RefreshToken struct {
+	DB             *sqlx.DB
+	Repo           UserByIDGetter
+	RefreshTokens  RefreshTokenRotator
+	TokenGenerator TokenGenerator
+}
+
+func (r *RefreshToken) RefreshToken(ctx context.Context, token string) (*entity.Tokens, error) {
+	userID, next, err := r.RefreshTokens.RotateRefreshToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.Repo.GetUserByID(ctx, r.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	access, err := r.TokenGenerator.GenerateToken(ctx, *user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return &entity.Tokens{AccessToken: string(access), RefreshToken: next}, nil
+}
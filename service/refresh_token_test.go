@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// refreshTokenStore はKVSの代わりにリフレッシュトークンをメモリに保存するモック
+func refreshTokenStore(t *testing.T) *RefreshTokenStoreMock {
+	t.Helper()
+
+	var mu sync.Mutex
+	tokens := map[string]*entity.RefreshToken{}
+	return &RefreshTokenStoreMock{
+		SaveRefreshTokenFunc: func(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error {
+			if ttl != time.Hour {
+				t.Errorf("want ttl %v, but got %v", time.Hour, ttl)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			tokens[key] = &entity.RefreshToken{UserID: token.UserID, Family: token.Family}
+			return nil
+		},
+		UseRefreshTokenFunc: func(ctx context.Context, key string) (*entity.RefreshToken, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			tok, ok := tokens[key]
+			if !ok {
+				return nil, store.ErrNotFound
+			}
+			got := *tok
+			tok.Used = true
+			return &got, nil
+		},
+		LoadRefreshTokenFunc: func(ctx context.Context, key string) (*entity.RefreshToken, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			tok, ok := tokens[key]
+			if !ok {
+				return nil, store.ErrNotFound
+			}
+			got := *tok
+			return &got, nil
+		},
+		RevokeRefreshTokenFamilyFunc: func(ctx context.Context, family string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for k, tok := range tokens {
+				if tok.Family == family {
+					delete(tokens, k)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestRefreshTokens_RotateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sut := &RefreshTokens{Store: refreshTokenStore(t), Lifetime: time.Hour}
+
+	first, err := sut.IssueRefreshToken(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := sut.IssueRefreshToken(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID, second, err := sut.RotateRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if userID != 1 {
+		t.Errorf("want user 1, but got %d", userID)
+	}
+	if second == first {
+		t.Errorf("rotated token must differ from the used one")
+	}
+
+	// 使用済みのトークンを使うとFamilyごと失効する
+	if _, _, err := sut.RotateRefreshToken(ctx, first); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("want ErrRefreshTokenReused, but got %v", err)
+	}
+	if _, _, err := sut.RotateRefreshToken(ctx, second); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("want ErrInvalidRefreshToken for revoked family, but got %v", err)
+	}
+	// 別のFamilyのトークンは影響を受けない
+	if _, _, err := sut.RotateRefreshToken(ctx, other); err != nil {
+		t.Errorf("want no error for another family, but got %v", err)
+	}
+	if _, _, err := sut.RotateRefreshToken(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("want ErrInvalidRefreshToken, but got %v", err)
+	}
+}
+
+func TestRefreshTokens_RevokeRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sut := &RefreshTokens{Store: refreshTokenStore(t), Lifetime: time.Hour}
+
+	token, err := sut.IssueRefreshToken(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 他のユーザーのトークンは失効させない
+	if err := sut.RevokeRefreshToken(ctx, 2, token); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if err := sut.RevokeRefreshToken(ctx, 1, "unknown"); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	_, token, err = sut.RotateRefreshToken(ctx, token)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+
+	if err := sut.RevokeRefreshToken(ctx, 1, token); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, _, err := sut.RotateRefreshToken(ctx, token); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("want ErrInvalidRefreshToken after revocation, but got %v", err)
+	}
+}
+
+func TestRefreshToken_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rotateErr error
+		wantErr   error
+	}{
+		"ok":      {},
+		"reused":  {rotateErr: ErrRefreshTokenReused, wantErr: ErrRefreshTokenReused},
+		"invalid": {rotateErr: ErrInvalidRefreshToken, wantErr: ErrInvalidRefreshToken},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &UserByIDGetterMock{
+				GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+					return &entity.User{ID: id, Name: "alice", Role: "user"}, nil
+				},
+			}
+			rotator := &RefreshTokenRotatorMock{
+				RotateRefreshTokenFunc: func(ctx context.Context, token string) (entity.UserID, string, error) {
+					if token != "old" {
+						t.Errorf("want token old, but got %s", token)
+					}
+					if tt.rotateErr != nil {
+						return 0, "", tt.rotateErr
+					}
+					return 7, "new", nil
+				},
+			}
+			generator := &TokenGeneratorMock{
+				GenerateTokenFunc: func(ctx context.Context, user entity.User) ([]byte, error) {
+					if user.ID != 7 {
+						t.Errorf("want user 7, but got %d", user.ID)
+					}
+					return []byte("access"), nil
+				},
+			}
+			sut := &RefreshToken{Repo: repo, RefreshTokens: rotator, TokenGenerator: generator}
+
+			got, err := sut.RefreshToken(context.Background(), "old")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				if len(generator.GenerateTokenCalls()) != 0 {
+					t.Errorf("access token must not be generated")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			want := entity.Tokens{AccessToken: "access", RefreshToken: "new"}
+			if *got != want {
+				t.Errorf("want %+v, but got %+v", want, *got)
+			}
+		})
+	}
+}
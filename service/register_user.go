@@ -18,11 +18,16 @@ type RegisterUserRepository interface {
 	RegisterUser(ctx context.Context, db store.Execer, user *entity.User) error
 }
 
-func (r *RegisterUser) RegisterUser(ctx context.Context, name string, password string, role string) (*entity.User, error) {
+func (r *RegisterUser) RegisterUser(ctx context.Context, name string, password string, role string, timezone string, email string) (*entity.User, error) {
+	if timezone == "" {
+		timezone = entity.DefaultTimezone
+	}
 	user := &entity.User{
 		Name:     name,
 		Password: password,
 		Role:     role,
+		Timezone: timezone,
+		Email:    email,
 	}
 	if err := user.HashPassword(); err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
@@ -76,7 +76,7 @@ func TestRegisterUser_RegisterUser(t *testing.T) {
 
 			// テスト実行
 			ctx := context.Background()
-			gotUser, err := registerUserService.RegisterUser(ctx, tt.userName, tt.password, tt.role)
+			gotUser, err := registerUserService.RegisterUser(ctx, tt.userName, tt.password, tt.role, "", "")
 
 			// 結果の検証
 			if tt.wantError {
@@ -131,11 +131,14 @@ func TestRegisterUser_RegisterUser_PasswordHashing(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	user, err := registerUserService.RegisterUser(ctx, "testuser", "password123", "user")
+	user, err := registerUserService.RegisterUser(ctx, "testuser", "password123", "user", "Asia/Tokyo", "testuser@example.com")
 
 	if err != nil {
 		t.Fatalf("RegisterUser() unexpected error: %v", err)
 	}
+	if user.Email != "testuser@example.com" {
+		t.Errorf("RegisterUser() got email = %v, want %v", user.Email, "testuser@example.com")
+	}
 
 	// パスワードがハッシュ化されていることを確認
 	if user.Password == "password123" {
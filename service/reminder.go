@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+const (
+	defaultReminderInterval  = time.Minute
+	defaultReminderBatchSize = 100
+)
+
+// ReminderScheduler はタスクの期限の通知を定期的に送る。
+// 通知はSELECT ... FOR UPDATE SKIP LOCKEDで取得するので、複数のインスタンスで動かしても二重には送らない。
+// 通知の登録と送信済みの記録は同じトランザクションで行うので、途中で失敗しても二重には届かない。
+type ReminderScheduler struct {
+	DB            store.TxBeginner
+	Repo          ReminderDispatcher
+	Notifications NotificationAdder
+	Clocker       clock.Clocker
+	// Interval は通知を確認する間隔
+	Interval time.Duration
+	// BatchSize は1回のトランザクションで送る通知の最大数
+	BatchSize int
+}
+
+// Run はctxがキャンセルされるまでIntervalごとにRunOnceを実行する
+func (s *ReminderScheduler) Run(ctx context.Context) error {
+	t := time.NewTicker(s.interval())
+	defer t.Stop()
+	for {
+		if _, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("failed to send reminders: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func (s *ReminderScheduler) interval() time.Duration {
+	if s.Interval <= 0 {
+		return defaultReminderInterval
+	}
+	return s.Interval
+}
+
+// RunOnce はClockerの現在時刻までに送るべき通知を送り、送った数を返す。
+// 通知に失敗したものは未送信のまま残し、次の実行で再送する。
+func (s *ReminderScheduler) RunOnce(ctx context.Context) (int, error) {
+	now := s.Clocker.Now()
+	batch := s.BatchSize
+	if batch <= 0 {
+		batch = defaultReminderBatchSize
+	}
+
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// 前回の実行から今回までに期限を迎えたタスクの期限ちょうどの通知も送れるよう、1回分さかのぼる
+	if _, err := s.Repo.ScheduleReminders(ctx, tx, now.Add(-s.interval())); err != nil {
+		return 0, fmt.Errorf("failed to schedule reminders: %w", err)
+	}
+	reminders, err := s.Repo.ClaimDueReminders(ctx, tx, now, batch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim reminders: %w", err)
+	}
+	sent := 0
+	for _, r := range reminders {
+		n := &entity.Notification{
+			UserID:  r.UserID,
+			Type:    entity.NotificationTypeDueReminder,
+			TaskID:  &r.TaskID,
+			Message: r.Message(),
+		}
+		if err := addNotification(ctx, tx, s.Notifications, n); err != nil {
+			log.Printf("failed to notify reminder %d: %v", r.ID, err)
+			continue
+		}
+		if err := s.Repo.MarkReminderSent(ctx, tx, r.ID, now); err != nil {
+			return 0, fmt.Errorf("failed to mark reminder %d sent: %w", r.ID, err)
+		}
+		sent++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit: %w", err)
+	}
+	return sent, nil
+}
+
+type ReminderOffsets struct {
+	DB   *sqlx.DB
+	Repo ReminderOffsetStore
+}
+
+// ReminderOffsets はユーザーが選んだ期限の通知のタイミングを返す
+func (s *ReminderOffsets) ReminderOffsets(ctx context.Context) ([]entity.ReminderOffset, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	offsets, err := s.Repo.ListReminderOffsets(ctx, s.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder offsets: %w", err)
+	}
+	return offsets, nil
+}
+
+// UpdateReminderOffsets は期限の通知のタイミングを置き換え、保存後のタイミングを返す。
+// 空にすると期限の通知を受け取らない。
+func (s *ReminderOffsets) UpdateReminderOffsets(ctx context.Context, offsets []entity.ReminderOffset) ([]entity.ReminderOffset, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := s.Repo.SaveReminderOffsets(ctx, tx, userID, offsets); err != nil {
+		return nil, fmt.Errorf("failed to save reminder offsets: %w", err)
+	}
+	saved, err := s.Repo.ListReminderOffsets(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminder offsets: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestReminderScheduler_RunOnce(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, 4, 10, 18, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	reminders := []*entity.Reminder{
+		{ID: 1, TaskID: 10, UserID: 1, Offset: entity.ReminderDayBefore, RemindAt: due.Add(-24 * time.Hour), TaskTitle: "report", DueAt: due},
+		{ID: 2, TaskID: 10, UserID: 1, Offset: entity.ReminderHourBefore, RemindAt: due.Add(-time.Hour), TaskTitle: "report", DueAt: due},
+		{ID: 3, TaskID: 10, UserID: 1, Offset: entity.ReminderAtDue, RemindAt: due, TaskTitle: "report", DueAt: due},
+	}
+	clocker := clock.NewManualClocker(due.Add(-25 * time.Hour))
+	repo := &ReminderDispatcherMock{
+		ScheduleRemindersFunc: func(ctx context.Context, db store.Execer, since time.Time) (int64, error) {
+			// 間隔の間に期限を迎えたタスクも予定に入るよう、1回分さかのぼる
+			if want := clocker.Now().Add(-defaultReminderInterval); !since.Equal(want) {
+				t.Errorf("scheduled since %v, want %v", since, want)
+			}
+			return 0, nil
+		},
+		ClaimDueRemindersFunc: func(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error) {
+			if !now.Equal(clocker.Now()) {
+				t.Errorf("claimed at %v, want clock time %v", now, clocker.Now())
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			var due []*entity.Reminder
+			for _, r := range reminders {
+				if r.SentAt == nil && !r.RemindAt.After(now) {
+					due = append(due, r)
+				}
+			}
+			return due, nil
+		},
+		MarkReminderSentFunc: func(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error {
+			mu.Lock()
+			defer mu.Unlock()
+			reminders[id-1].SentAt = &now
+			return nil
+		},
+	}
+	failNext := false
+	var messages []string
+	notifications := &NotificationAdderMock{
+		ListNotificationPreferencesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+			return nil, nil
+		},
+		AddNotificationFunc: func(ctx context.Context, db store.Execer, n *entity.Notification) error {
+			if _, ok := db.(*sqlx.Tx); !ok {
+				t.Errorf("notification added outside the transaction: %T", db)
+			}
+			if failNext {
+				failNext = false
+				return errors.New("notifier is down")
+			}
+			if n.Type != entity.NotificationTypeDueReminder || n.UserID != 1 || *n.TaskID != 10 {
+				t.Errorf("unexpected notification %+v", n)
+			}
+			messages = append(messages, n.Message)
+			return nil
+		},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	sut := &ReminderScheduler{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Notifications: notifications, Clocker: clocker}
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		fail    bool
+		want    int
+	}{
+		{name: "nothing due yet", want: 0},
+		{name: "1 day before", advance: time.Hour, want: 1},
+		{name: "already sent", advance: time.Minute, want: 0},
+		{name: "notifier failure keeps reminder", advance: 23 * time.Hour, fail: true, want: 0},
+		{name: "retried on next run", advance: time.Minute, want: 1},
+		{name: "at due", advance: time.Hour, want: 1},
+	}
+	for _, s := range steps {
+		clocker.Advance(s.advance)
+		failNext = s.fail
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		got, err := sut.RunOnce(context.Background())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", s.name, err)
+		}
+		if got != s.want {
+			t.Errorf("%s: sent %d reminders, want %d", s.name, got, s.want)
+		}
+	}
+	want := []string{`"report" is due in 1 day`, `"report" is due in 1 hour`, `"report" is due now`}
+	if len(messages) != len(want) {
+		t.Fatalf("messages = %q, want %q", messages, want)
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("messages[%d] = %q, want %q", i, messages[i], want[i])
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestReminderScheduler_RunOnce_MarkSentFailure は送信済みにできなければ、登録した通知もロールバックすることを確かめる
+func TestReminderScheduler_RunOnce_MarkSentFailure(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, 4, 10, 18, 0, 0, 0, time.UTC)
+	repo := &ReminderDispatcherMock{
+		ScheduleRemindersFunc: func(ctx context.Context, db store.Execer, since time.Time) (int64, error) {
+			return 0, nil
+		},
+		ClaimDueRemindersFunc: func(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error) {
+			return []*entity.Reminder{
+				{ID: 1, TaskID: 10, UserID: 1, Offset: entity.ReminderAtDue, RemindAt: due, TaskTitle: "report", DueAt: due},
+			}, nil
+		},
+		MarkReminderSentFunc: func(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error {
+			return errors.New("connection lost")
+		},
+	}
+	notifications := &NotificationAdderMock{
+		ListNotificationPreferencesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error) {
+			return nil, nil
+		},
+		AddNotificationFunc: func(ctx context.Context, db store.Execer, n *entity.Notification) error {
+			return nil
+		},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	sut := &ReminderScheduler{
+		DB: sqlx.NewDb(db, "mysql"), Repo: repo, Notifications: notifications, Clocker: clock.NewManualClocker(due),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	if _, err := sut.RunOnce(context.Background()); err == nil {
+		t.Error("want error, but got nil")
+	}
+	if len(notifications.AddNotificationCalls()) != 1 {
+		t.Errorf("want 1 notification added, but got %d", len(notifications.AddNotificationCalls()))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddSavedSearch struct {
+	DB   store.Execer
+	Repo SavedSearchAdder
+}
+
+// AddSavedSearch は検索条件に名前を付けて保存する。条件は呼び出し側で検証済みであること
+func (a *AddSavedSearch) AddSavedSearch(ctx context.Context, name string, filter *entity.TaskFilter) (*entity.SavedSearch, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	s := &entity.SavedSearch{
+		UserID: userID,
+		Name:   name,
+		Filter: *filter,
+	}
+	if err := a.Repo.AddSavedSearch(ctx, a.DB, s); err != nil {
+		return nil, fmt.Errorf("failed to add saved search: %w", err)
+	}
+	return s, nil
+}
+
+type ListSavedSearches struct {
+	DB   store.Queryer
+	Repo SavedSearchLister
+}
+
+func (l *ListSavedSearches) ListSavedSearches(ctx context.Context) (entity.SavedSearches, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	searches, err := l.Repo.ListSavedSearches(ctx, l.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	return searches, nil
+}
+
+type SavedSearchTasks struct {
+	DB   store.Queryer
+	Repo SavedSearchRunner
+}
+
+// SavedSearchTasks は保存した検索条件で、いま条件を満たすタスクを返す
+func (s *SavedSearchTasks) SavedSearchTasks(ctx context.Context, id entity.SavedSearchID) (entity.Tasks, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	search, err := s.Repo.GetSavedSearch(ctx, s.DB, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	tasks, err := s.Repo.FilterTasks(ctx, s.DB, userID, &search.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+type DeleteSavedSearch struct {
+	DB   store.Execer
+	Repo SavedSearchDeleter
+}
+
+func (d *DeleteSavedSearch) DeleteSavedSearch(ctx context.Context, id entity.SavedSearchID) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+	if err := d.Repo.DeleteSavedSearch(ctx, d.DB, userID, id); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
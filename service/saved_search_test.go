@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestSavedSearchTasks_SavedSearchTasks(t *testing.T) {
+	t.Parallel()
+
+	filter := entity.TaskFilter{
+		Version:  entity.CurrentTaskFilterVersion,
+		Statuses: []entity.TaskStatus{entity.TaskStatusTodo},
+		Labels:   []string{"work"},
+	}
+	tasks := entity.Tasks{{ID: 1, Title: "quarterly report", Status: entity.TaskStatusTodo}}
+
+	tests := []struct {
+		name      string
+		getErr    error
+		wantErrIs error
+	}{
+		{name: "runs the saved filter"},
+		{name: "saved search not found", getErr: store.ErrNotFound, wantErrIs: store.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &SavedSearchRunnerMock{
+				GetSavedSearchFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.SavedSearchID) (*entity.SavedSearch, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					return &entity.SavedSearch{ID: id, UserID: userID, Name: "work todo", Filter: filter}, nil
+				},
+				FilterTasksFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, f *entity.TaskFilter) (entity.Tasks, error) {
+					if userID != 1 {
+						t.Errorf("FilterTasks userID = %d, want 1", userID)
+					}
+					if diff := cmp.Diff(filter, *f); diff != "" {
+						t.Errorf("FilterTasks filter mismatch (-want +got):\n%s", diff)
+					}
+					return tasks, nil
+				},
+			}
+			sut := &SavedSearchTasks{Repo: repo}
+			got, err := sut.SavedSearchTasks(auth.SetUserID(context.Background(), 1), 10)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("want error %v, but got %v", tt.wantErrIs, err)
+				}
+				if len(repo.FilterTasksCalls()) != 0 {
+					t.Error("FilterTasks should not be called when the saved search is missing")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tasks, got); diff != "" {
+				t.Errorf("SavedSearchTasks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
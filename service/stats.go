@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type Stats struct {
+	DB    store.Queryer
+	Repo  StatsGetter
+	Cache StatsCache
+}
+
+// Stats はワークスペースでの直近windowDays日の統計を返す。キャッシュがあればDBは参照しない
+func (s *Stats) Stats(ctx context.Context, windowDays int) (*entity.Stats, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+	wsID, ok := auth.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found")
+	}
+
+	cached, err := s.Cache.LoadStats(ctx, wsID, userID, windowDays)
+	if err == nil {
+		return cached, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		log.Printf("failed to load stats cache: %v", err)
+	}
+
+	user, err := s.Repo.GetUserByID(ctx, s.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	stats, err := s.Repo.GetStats(ctx, s.DB, userID, windowDays, user.Location())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	if err := s.Cache.SaveStats(ctx, wsID, userID, windowDays, stats); err != nil {
+		log.Printf("failed to save stats cache: %v", err)
+	}
+	return stats, nil
+}
+
+// invalidateStats はタスクの変更後に、変更したワークスペースでの統計キャッシュを破棄する。
+// 変更自体は確定しているので、失敗してもログに残すだけにする
+func invalidateStats(ctx context.Context, inv StatsInvalidator, userID entity.UserID) {
+	if inv == nil {
+		return
+	}
+	wsID, ok := auth.GetWorkspaceID(ctx)
+	if !ok {
+		log.Printf("failed to invalidate stats cache: workspace_id not found")
+		return
+	}
+	if err := inv.InvalidateStats(ctx, wsID, userID); err != nil {
+		log.Printf("failed to invalidate stats cache: %v", err)
+	}
+}
+
+// invalidateTaskStats はタスクの変更後に、作成者と担当者の統計キャッシュを破棄する。
+// 統計はタスクの作成者で数えるので、変更したのが担当者でも作成者の統計が変わる
+func invalidateTaskStats(ctx context.Context, inv StatsInvalidator, t *entity.Task) {
+	invalidateStats(ctx, inv, t.UserID)
+	if t.AssigneeID != nil && *t.AssigneeID != t.UserID {
+		invalidateStats(ctx, inv, *t.AssigneeID)
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestStats_Stats(t *testing.T) {
+	t.Parallel()
+
+	cached := &entity.Stats{WindowDays: 7, CurrentStreak: 3}
+	fresh := &entity.Stats{WindowDays: 7, CurrentStreak: 1}
+
+	tests := []struct {
+		name     string
+		cacheErr error
+		want     *entity.Stats
+		wantDB   bool
+	}{
+		{
+			name: "cache hit",
+			want: cached,
+		},
+		{
+			name:     "cache miss",
+			cacheErr: store.ErrNotFound,
+			want:     fresh,
+			wantDB:   true,
+		},
+		{
+			name:     "cache unavailable falls back to db",
+			cacheErr: errors.New("connection refused"),
+			want:     fresh,
+			wantDB:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := &StatsCacheMock{
+				LoadStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int) (*entity.Stats, error) {
+					if workspaceID != 2 {
+						t.Errorf("LoadStats() called with workspace %d", workspaceID)
+					}
+					if tt.cacheErr != nil {
+						return nil, tt.cacheErr
+					}
+					return cached, nil
+				},
+				SaveStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats) error {
+					if workspaceID != 2 {
+						t.Errorf("SaveStats() called with workspace %d", workspaceID)
+					}
+					return nil
+				},
+			}
+			repo := &StatsGetterMock{
+				GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+					return &entity.User{ID: id, Timezone: "Asia/Tokyo"}, nil
+				},
+				GetStatsFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, windowDays int, loc *time.Location) (*entity.Stats, error) {
+					if loc.String() != "Asia/Tokyo" {
+						t.Errorf("GetStats() called with location %s", loc)
+					}
+					return fresh, nil
+				},
+			}
+
+			sut := &Stats{Repo: repo, Cache: cache}
+			ctx := auth.SetWorkspaceID(auth.SetUserID(context.Background(), 1), 2)
+			got, err := sut.Stats(ctx, 7)
+			if err != nil {
+				t.Fatalf("Stats() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Stats() mismatch (-want +got):\n%s", diff)
+			}
+			if gotDB := len(repo.GetStatsCalls()) == 1; gotDB != tt.wantDB {
+				t.Errorf("GetStats() called = %v, want %v", gotDB, tt.wantDB)
+			}
+			if gotSave := len(cache.SaveStatsCalls()) == 1; gotSave != tt.wantDB {
+				t.Errorf("SaveStats() called = %v, want %v", gotSave, tt.wantDB)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskStatus_InvalidatesStats(t *testing.T) {
+	t.Parallel()
+
+	assignee := entity.UserID(2)
+	tests := []struct {
+		name           string
+		assigneeID     *entity.UserID
+		repoErr        error
+		wantInvalidate []entity.UserID
+	}{
+		{name: "updated", wantInvalidate: []entity.UserID{1}},
+		{name: "assigned task", assigneeID: &assignee, wantInvalidate: []entity.UserID{1, 2}},
+		{name: "task not found", repoErr: store.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.repoErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			repo := &TaskStatusUpdaterMock{
+				GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+					return nil, store.ErrNotFound
+				},
+				GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+					return &entity.Task{ID: id, UserID: 1, AssigneeID: tt.assigneeID, Status: entity.TaskStatusTodo}, nil
+				},
+				UpdateTaskStatusFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error {
+					return tt.repoErr
+				},
+			}
+			inv := &StatsInvalidatorMock{
+				InvalidateStatsFunc: func(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error {
+					if workspaceID != 2 {
+						t.Errorf("InvalidateStats() called with workspace %d", workspaceID)
+					}
+					return nil
+				},
+			}
+			sut := &UpdateTaskStatus{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Stats: inv}
+			// 担当者がステータスを変えても、作成者の統計キャッシュを破棄する
+			userID := entity.UserID(1)
+			if tt.assigneeID != nil {
+				userID = *tt.assigneeID
+			}
+			err = sut.UpdateTaskStatus(auth.SetWorkspaceID(auth.SetUserID(context.Background(), userID), 2), 1, entity.TaskStatusDone)
+			if !errors.Is(err, tt.repoErr) {
+				t.Errorf("UpdateTaskStatus() error = %v, want %v", err, tt.repoErr)
+			}
+			var got []entity.UserID
+			for _, c := range inv.InvalidateStatsCalls() {
+				got = append(got, c.UserID)
+			}
+			if diff := cmp.Diff(tt.wantInvalidate, got); diff != "" {
+				t.Errorf("InvalidateStats() users mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
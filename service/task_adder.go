@@ -2,18 +2,25 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/store"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
 )
 
-//go:generate go run github.com/matryer/moq -out moq_test.go . TaskAdder TaskLister UserGetter TokenGenerator
+//go:generate go run github.com/matryer/moq -out moq_test.go . TaskAdder TaskLister UserGetter TokenGenerator TemplateAdder TemplateLister TemplateInstantiater TimerStarter TimeEntryUpdater TimeReporter TaskStatusUpdater TaskQuickAdder BoardGetter ColumnAdder TaskMover WorkspaceResolver WorkspaceAdder WorkspaceLister WorkspaceMemberAdder ProjectAdder ProjectLister StatsGetter StatsCache StatsInvalidator TaskAssigner TaskProjectSetter WorkloadGetter Notifier NotificationAdder NotificationLister NotificationReader NotificationPreferenceStore ReminderDispatcher ReminderOffsetStore SavedSearchAdder SavedSearchLister SavedSearchRunner SavedSearchDeleter TaskRelationLister UserByIDGetter RefreshTokenStore RefreshTokenIssuer RefreshTokenRotator TokenRevoker RefreshTokenRevoker OIDCProvider OIDCStateStore OIDCUserRepository MFARepository MFAVerifier MFAChallengeStore PasswordResetRepository PasswordResetTokenStore RateLimiter SessionRevoker LoginThrottler LoginFailureStore LoginLockoutRecorder
 type TaskAdder interface {
 	AddTask(ctx context.Context, db store.Execer, t *entity.Task) error
+	GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
 }
 
 type TaskLister interface {
 	ListTasks(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Tasks, error)
+	ListAssignedTasks(ctx context.Context, db store.Queryer, assigneeID entity.UserID) (entity.Tasks, error)
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	SearchTasks(ctx context.Context, db store.Queryer, userID entity.UserID, q taskquery.Node, loc *time.Location) (entity.Tasks, error)
 }
 
 type UserGetter interface {
@@ -23,3 +30,297 @@ type UserGetter interface {
 type TokenGenerator interface {
 	GenerateToken(ctx context.Context, user entity.User) ([]byte, error)
 }
+
+type TemplateAdder interface {
+	AddTemplate(ctx context.Context, db store.Execer, t *entity.Template) error
+}
+
+type TemplateLister interface {
+	ListTemplates(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Templates, error)
+}
+
+type TemplateInstantiater interface {
+	GetTemplate(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TemplateID) (*entity.Template, error)
+	AddTask(ctx context.Context, db store.Execer, t *entity.Task) error
+	GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+}
+
+type TimerStarter interface {
+	StartTimer(ctx context.Context, db store.Execer, e *entity.TimeEntry) error
+}
+
+type TimeEntryUpdater interface {
+	GetRunningTimeEntry(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.TimeEntry, error)
+	GetTimeEntry(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TimeEntryID) (*entity.TimeEntry, error)
+	UpdateTimeEntry(ctx context.Context, db store.Execer, e *entity.TimeEntry) error
+}
+
+type TimeReporter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	ListTimeEntries(ctx context.Context, db store.Queryer, userID entity.UserID, from, to time.Time) (entity.TimeEntries, error)
+}
+
+type TaskStatusUpdater interface {
+	GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+	UpdateTaskStatus(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error
+	GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+}
+
+type TaskQuickAdder interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	AddTask(ctx context.Context, db store.Execer, t *entity.Task) error
+	AddTaskLabels(ctx context.Context, db store.Execer, taskID entity.TaskID, names []string) error
+	GetDefaultColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error)
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+}
+
+type BoardGetter interface {
+	GetBoard(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Board, error)
+}
+
+type ColumnAdder interface {
+	ListColumns(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.Columns, error)
+	AddColumn(ctx context.Context, db store.Execer, c *entity.Column) error
+}
+
+type TaskMover interface {
+	GetColumnForUpdate(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.ColumnID) (*entity.Column, error)
+	CountColumnTasks(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error)
+	GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+	MoveTask(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, c *entity.Column) error
+}
+
+type WorkspaceResolver interface {
+	GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+	GetPersonalWorkspace(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Workspace, error)
+	AddWorkspace(ctx context.Context, db store.Execer, ws *entity.Workspace) error
+	AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+}
+
+type WorkspaceAdder interface {
+	AddWorkspace(ctx context.Context, db store.Execer, ws *entity.Workspace) error
+	AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+}
+
+type WorkspaceLister interface {
+	ListWorkspaces(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.WorkspaceMembership, error)
+}
+
+type WorkspaceMemberAdder interface {
+	GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+	GetUser(ctx context.Context, db store.Queryer, userName string) (*entity.User, error)
+	AddWorkspaceMember(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error
+}
+
+type ProjectAdder interface {
+	AddProject(ctx context.Context, db store.Execer, p *entity.Project) error
+}
+
+type TaskAssigner interface {
+	GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+	GetWorkspaceMember(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error)
+	AssignTask(ctx context.Context, db store.Execer, id entity.TaskID, assigneeID *entity.UserID) error
+}
+
+type TaskProjectSetter interface {
+	GetTask(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error)
+	GetProject(ctx context.Context, db store.Queryer, id entity.ProjectID) (*entity.Project, error)
+	SetTaskProject(ctx context.Context, db store.Execer, id entity.TaskID, projectID *entity.ProjectID) error
+}
+
+type WorkloadGetter interface {
+	GetWorkload(ctx context.Context, db store.Queryer) ([]*entity.Workload, error)
+}
+
+type ProjectLister interface {
+	ListProjects(ctx context.Context, db store.Queryer) (entity.Projects, error)
+}
+
+type StatsGetter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	GetStats(ctx context.Context, db store.Queryer, userID entity.UserID, windowDays int, loc *time.Location) (*entity.Stats, error)
+}
+
+type StatsCache interface {
+	LoadStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int) (*entity.Stats, error)
+	SaveStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats) error
+}
+
+// StatsInvalidator はタスクが変更されたときに統計キャッシュを破棄する
+type StatsInvalidator interface {
+	InvalidateStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error
+}
+
+// Notifier はイベントをユーザーへ通知する。他のサービスはこのインターフェースを通して通知を作る
+type Notifier interface {
+	Notify(ctx context.Context, n *entity.Notification) error
+}
+
+type NotificationAdder interface {
+	ListNotificationPreferences(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error)
+	AddNotification(ctx context.Context, db store.Execer, n *entity.Notification) error
+}
+
+type NotificationLister interface {
+	ListNotifications(ctx context.Context, db store.Queryer, userID entity.UserID, unreadOnly bool) (entity.Notifications, error)
+	CountUnreadNotifications(ctx context.Context, db store.Queryer, userID entity.UserID) (int, error)
+}
+
+type NotificationReader interface {
+	MarkNotificationRead(ctx context.Context, db store.ExecQueryer, userID entity.UserID, id entity.NotificationID) error
+	MarkAllNotificationsRead(ctx context.Context, db store.Execer, userID entity.UserID) (int64, error)
+}
+
+type NotificationPreferenceStore interface {
+	ListNotificationPreferences(ctx context.Context, db store.Queryer, userID entity.UserID) ([]*entity.NotificationPreference, error)
+	SaveNotificationPreference(ctx context.Context, db store.Execer, userID entity.UserID, p *entity.NotificationPreference) error
+}
+
+type ReminderDispatcher interface {
+	ScheduleReminders(ctx context.Context, db store.Execer, since time.Time) (int64, error)
+	ClaimDueReminders(ctx context.Context, db store.Queryer, now time.Time, limit int) ([]*entity.Reminder, error)
+	MarkReminderSent(ctx context.Context, db store.Execer, id entity.ReminderID, now time.Time) error
+}
+
+type ReminderOffsetStore interface {
+	ListReminderOffsets(ctx context.Context, db store.Queryer, userID entity.UserID) ([]entity.ReminderOffset, error)
+	SaveReminderOffsets(ctx context.Context, db store.Execer, userID entity.UserID, offsets []entity.ReminderOffset) error
+}
+
+type SavedSearchAdder interface {
+	AddSavedSearch(ctx context.Context, db store.Execer, s *entity.SavedSearch) error
+}
+
+type SavedSearchLister interface {
+	ListSavedSearches(ctx context.Context, db store.Queryer, userID entity.UserID) (entity.SavedSearches, error)
+}
+
+type SavedSearchRunner interface {
+	GetSavedSearch(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.SavedSearchID) (*entity.SavedSearch, error)
+	FilterTasks(ctx context.Context, db store.Queryer, userID entity.UserID, f *entity.TaskFilter) (entity.Tasks, error)
+}
+
+type SavedSearchDeleter interface {
+	DeleteSavedSearch(ctx context.Context, db store.Execer, userID entity.UserID, id entity.SavedSearchID) error
+}
+
+type TaskRelationLister interface {
+	ListTaskLabels(ctx context.Context, db store.Queryer, taskIDs []entity.TaskID) ([]*entity.TaskLabel, error)
+	ListProjectsByID(ctx context.Context, db store.Queryer, ids []entity.ProjectID) (entity.Projects, error)
+	ListWorkspaceMembers(ctx context.Context, db store.Queryer, userIDs []entity.UserID) ([]*entity.WorkspaceMember, error)
+}
+
+type UserByIDGetter interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+}
+
+type RefreshTokenStore interface {
+	SaveRefreshToken(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error
+	UseRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, family string) error
+	LoadRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error)
+	RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error
+}
+
+type RefreshTokenIssuer interface {
+	IssueRefreshToken(ctx context.Context, userID entity.UserID) (string, error)
+}
+
+type RefreshTokenRotator interface {
+	RotateRefreshToken(ctx context.Context, token string) (entity.UserID, string, error)
+}
+
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, jti string) error
+	RevokeUserTokens(ctx context.Context, userID entity.UserID) error
+}
+
+type RefreshTokenRevoker interface {
+	RevokeRefreshToken(ctx context.Context, userID entity.UserID, token string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error
+}
+
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (*entity.OIDCIdentity, error)
+}
+
+type OIDCStateStore interface {
+	SaveOIDCState(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error
+	TakeOIDCState(ctx context.Context, state string) (*entity.OIDCState, error)
+}
+
+type OIDCUserRepository interface {
+	GetUserByIdentity(ctx context.Context, db store.Queryer, issuer, subject string) (*entity.User, error)
+	RegisterUser(ctx context.Context, db store.Execer, user *entity.User) error
+	AddUserIdentity(ctx context.Context, db store.Execer, identity *entity.UserIdentity) error
+}
+
+type MFARepository interface {
+	GetUserByID(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error)
+	GetUserMFA(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.UserMFA, error)
+	SaveUserMFA(ctx context.Context, db store.Execer, m *entity.UserMFA) error
+	ConfirmUserMFA(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error
+	UseTOTPStep(ctx context.Context, db store.Execer, userID entity.UserID, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, db store.Execer, userID entity.UserID, hashes []string) error
+	UseRecoveryCode(ctx context.Context, db store.Execer, userID entity.UserID, hash string) error
+	IsMFARequired(ctx context.Context, db store.Queryer, role string) (bool, error)
+	ListMFARequiredRoles(ctx context.Context, db store.Queryer) ([]string, error)
+	SetMFARequiredRoles(ctx context.Context, db store.Execer, roles []string) error
+}
+
+// MFAVerifier はログインで2要素認証を確かめる
+type MFAVerifier interface {
+	MFAStatus(ctx context.Context, user *entity.User) (enabled, required bool, err error)
+	EnrollTOTPFor(ctx context.Context, user *entity.User) (*entity.TOTPEnrollment, error)
+	VerifyMFA(ctx context.Context, user *entity.User, code, recoveryCode string) ([]string, error)
+}
+
+// MFAChallengeStore はパスワードを確かめて2要素認証を待っているログインを保存する。keyはMFAトークンのハッシュ
+type MFAChallengeStore interface {
+	SaveMFAChallenge(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+	LoadMFAChallenge(ctx context.Context, key string) (entity.UserID, error)
+	AttemptMFAChallenge(ctx context.Context, key string) (entity.UserID, int64, error)
+	DeleteMFAChallenge(ctx context.Context, key string) error
+}
+
+type PasswordResetRepository interface {
+	GetUserByEmail(ctx context.Context, db store.Queryer, email string) (*entity.User, error)
+	UpdatePassword(ctx context.Context, db store.Execer, id entity.UserID, password string) error
+}
+
+// PasswordResetTokenStore はパスワードの再設定トークンを保存する。keyはトークンのハッシュ
+type PasswordResetTokenStore interface {
+	SavePasswordResetToken(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error
+	TakePasswordResetToken(ctx context.Context, key string) (entity.UserID, error)
+}
+
+// RateLimiter はスライディングウィンドウで試行を数え、上限を超えていれば次に試せるまでの時間を返す
+type RateLimiter interface {
+	TakeRateLimit(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (time.Duration, error)
+}
+
+type SessionRevoker interface {
+	RevokeSessions(ctx context.Context, userID entity.UserID) error
+}
+
+// LoginThrottler はパスワードの総当たりを防ぐ。試行が多すぎればCheckがRateLimitErrorを返す
+type LoginThrottler interface {
+	Check(ctx context.Context, userName, clientIP string) error
+	Fail(ctx context.Context, userName, clientIP string) error
+	Succeed(ctx context.Context, userName string) error
+}
+
+type LoginFailureStore interface {
+	LoginFailures(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, time.Duration, error)
+	AddLoginFailure(ctx context.Context, key string, window time.Duration, now time.Time, lockAfter int64, lockFor time.Duration) (int64, bool, error)
+	ReserveLoginAttempt(ctx context.Context, key string, window time.Duration, now time.Time, delays []time.Duration, lockAfter int64, lockFor time.Duration) (time.Duration, error)
+	TakeLoginLockout(ctx context.Context, key string) (int64, time.Duration, bool, error)
+	ClearLoginFailures(ctx context.Context, key string) error
+}
+
+type LoginLockoutRecorder interface {
+	AddLoginLockout(ctx context.Context, db store.Execer, l *entity.LoginLockout) error
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// TaskRelations はタスクに関連するラベル・プロジェクト・担当者を、複数のタスクの分まとめて読む。
+// GraphQLでタスクごとに読むとN+1回のクエリになるのを避けるために使う。
+type TaskRelations struct {
+	DB   store.Queryer
+	Repo TaskRelationLister
+}
+
+// TaskLabels はタスクごとのラベル名を返す。ラベルのないタスクは含まない
+func (s *TaskRelations) TaskLabels(ctx context.Context, ids []entity.TaskID) (map[entity.TaskID][]string, error) {
+	labels, err := s.Repo.ListTaskLabels(ctx, s.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task labels: %w", err)
+	}
+	m := make(map[entity.TaskID][]string, len(ids))
+	for _, l := range labels {
+		m[l.TaskID] = append(m[l.TaskID], l.Name)
+	}
+	return m, nil
+}
+
+// Projects はワークスペースのプロジェクトをIDごとに返す。見つからないIDは含まない
+func (s *TaskRelations) Projects(ctx context.Context, ids []entity.ProjectID) (map[entity.ProjectID]*entity.Project, error) {
+	projects, err := s.Repo.ListProjectsByID(ctx, s.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	m := make(map[entity.ProjectID]*entity.Project, len(projects))
+	for _, p := range projects {
+		m[p.ID] = p
+	}
+	return m, nil
+}
+
+// Members はワークスペースのメンバーをユーザーIDごとに返す。メンバーでないユーザーは含まない
+func (s *TaskRelations) Members(ctx context.Context, ids []entity.UserID) (map[entity.UserID]*entity.WorkspaceMember, error) {
+	members, err := s.Repo.ListWorkspaceMembers(ctx, s.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	m := make(map[entity.UserID]*entity.WorkspaceMember, len(members))
+	for _, mem := range members {
+		m[mem.UserID] = mem
+	}
+	return m, nil
+}
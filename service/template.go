@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type AddTemplate struct {
+	DB   store.TxBeginner
+	Repo TemplateAdder
+}
+
+func (a *AddTemplate) AddTemplate(ctx context.Context, title string, items []*entity.TemplateItem) (*entity.Template, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tpl := &entity.Template{
+		UserID: userID,
+		Title:  title,
+		Items:  items,
+	}
+
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := a.Repo.AddTemplate(ctx, tx, tpl); err != nil {
+		return nil, fmt.Errorf("failed to add template: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return tpl, nil
+}
+
+type ListTemplates struct {
+	DB   store.Queryer
+	Repo TemplateLister
+}
+
+func (l *ListTemplates) ListTemplates(ctx context.Context) (entity.Templates, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	templates, err := l.Repo.ListTemplates(ctx, l.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+type InstantiateTemplate struct {
+	DB      store.TxBeginner
+	Repo    TemplateInstantiater
+	Clocker clock.Clocker
+	Stats   StatsInvalidator
+}
+
+// InstantiateTemplate はテンプレートの全項目をタスクとして1トランザクションで作成する。
+// 期限はClockerの現在時刻を基準に各項目のオフセットから計算する。
+func (it *InstantiateTemplate) InstantiateTemplate(ctx context.Context, id entity.TemplateID) (entity.Tasks, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := it.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// 項目の数はテンプレートを読むまでわからないが、列のロックはその前に取っておく
+	c, err := lockDefaultColumn(ctx, tx, it.Repo, userID, entity.TaskStatusTodo)
+	if err != nil {
+		return nil, err
+	}
+	tpl, err := it.Repo.GetTemplate(ctx, tx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	if err := checkWIPLimit(ctx, tx, it.Repo, c, 0, len(tpl.Items)); err != nil {
+		return nil, err
+	}
+
+	now := it.Clocker.Now()
+	tasks := make(entity.Tasks, 0, len(tpl.Items))
+	for _, item := range tpl.Items {
+		task := &entity.Task{
+			UserID: userID,
+			Title:  item.Title,
+			Status: entity.TaskStatusTodo,
+			DueAt:  item.DueAt(now),
+		}
+		if err := it.Repo.AddTask(ctx, tx, task); err != nil {
+			return nil, fmt.Errorf("failed to add task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	invalidateStats(ctx, it.Stats, userID)
+	return tasks, nil
+}
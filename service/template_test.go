@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestInstantiateTemplate_InstantiateTemplate(t *testing.T) {
+	t.Parallel()
+
+	offset := 24 * 60
+	c := clock.FixedClocker{}
+	tpl := &entity.Template{
+		ID:     1,
+		UserID: 1,
+		Title:  "onboarding",
+		Items: []*entity.TemplateItem{
+			{Title: "create account"},
+			{Title: "read handbook", DueOffsetMinutes: &offset},
+		},
+	}
+
+	limit := 3
+	column := &entity.Column{ID: 3, Name: "Ready", Status: entity.TaskStatusTodo, WIPLimit: &limit}
+	tests := []struct {
+		name       string
+		column     *entity.Column
+		count      int
+		getErr     error
+		addTaskErr error
+		wantCommit bool
+		wantError  bool
+		wantErrIs  error
+	}{
+		{
+			name:       "successful instantiation",
+			wantCommit: true,
+		},
+		{
+			name:       "all items fit in the wip limit",
+			column:     column,
+			count:      1,
+			wantCommit: true,
+		},
+		{
+			name:      "wip limit reached by the items",
+			column:    column,
+			count:     2,
+			wantError: true,
+			wantErrIs: ErrWIPLimitExceeded,
+		},
+		{
+			name:      "template not found",
+			getErr:    store.ErrNotFound,
+			wantError: true,
+		},
+		{
+			name:       "rollback when adding a task fails",
+			addTaskErr: errors.New("database error"),
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			var nextID entity.TaskID
+			mockRepo := &TemplateInstantiaterMock{
+				GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+					if tt.column == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.column, nil
+				},
+				CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+					return tt.count, nil
+				},
+				GetTemplateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, id entity.TemplateID) (*entity.Template, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					return tpl, nil
+				},
+				AddTaskFunc: func(ctx context.Context, db store.Execer, t *entity.Task) error {
+					if tt.addTaskErr != nil {
+						return tt.addTaskErr
+					}
+					nextID++
+					t.ID = nextID
+					return nil
+				},
+			}
+
+			sut := &InstantiateTemplate{
+				DB:      sqlx.NewDb(db, "mysql"),
+				Repo:    mockRepo,
+				Clocker: c,
+			}
+			ctx := auth.SetUserID(context.Background(), 1)
+			got, err := sut.InstantiateTemplate(ctx, tpl.ID)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("InstantiateTemplate() expected error but got none")
+				}
+				if tt.getErr != nil && !errors.Is(err, tt.getErr) {
+					t.Errorf("InstantiateTemplate() error = %v, want %v", err, tt.getErr)
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("InstantiateTemplate() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InstantiateTemplate() unexpected error: %v", err)
+			}
+
+			if len(got) != len(tpl.Items) {
+				t.Fatalf("InstantiateTemplate() created %d tasks, want %d", len(got), len(tpl.Items))
+			}
+			if got[0].DueAt != nil {
+				t.Errorf("first task DueAt = %v, want nil", got[0].DueAt)
+			}
+			wantDue := c.Now().Add(24 * time.Hour)
+			if got[1].DueAt == nil || !got[1].DueAt.Equal(wantDue) {
+				t.Errorf("second task DueAt = %v, want %v", got[1].DueAt, wantDue)
+			}
+			for _, task := range got {
+				if task.UserID != 1 || task.Status != entity.TaskStatusTodo {
+					t.Errorf("unexpected task: %+v", task)
+				}
+			}
+		})
+	}
+}
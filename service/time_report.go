@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// maxReportDays はレポートで集計できる最大日数
+const maxReportDays = 366
+
+type TimeReport struct {
+	DB      store.Queryer
+	Repo    TimeReporter
+	Clocker clock.Clocker
+}
+
+// TimeReport はfromからtoまで(両端を含む)の日付の作業時間を集計する。
+// 日付はユーザーのタイムゾーンで解釈し、日ごとの区切りもそのタイムゾーンの0時とする。
+func (tr *TimeReport) TimeReport(ctx context.Context, fromDate, toDate time.Time) (*entity.TimeReport, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	user, err := tr.Repo.GetUserByID(ctx, tr.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	loc := user.Location()
+
+	from := time.Date(fromDate.Year(), fromDate.Month(), fromDate.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(toDate.Year(), toDate.Month(), toDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	if !to.After(from) {
+		return nil, fmt.Errorf("from must not be after to: %w", ErrInvalidTimeRange)
+	}
+	if to.After(from.AddDate(0, 0, maxReportDays)) {
+		return nil, fmt.Errorf("range must be within %d days: %w", maxReportDays, ErrInvalidTimeRange)
+	}
+
+	entries, err := tr.Repo.ListTimeEntries(ctx, tr.DB, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list time entries: %w", err)
+	}
+	return aggregateTimeEntries(entries, from, to, tr.Clocker.Now()), nil
+}
+
+// aggregateTimeEntries は[from, to)に収まる部分だけを、タスク別とfromのタイムゾーンでの日別に合計する
+func aggregateTimeEntries(entries entity.TimeEntries, from, to, now time.Time) *entity.TimeReport {
+	report := &entity.TimeReport{
+		From:     from,
+		To:       to,
+		Location: from.Location(),
+	}
+
+	dayIndex := map[string]*entity.DayDuration{}
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		day := &entity.DayDuration{Date: d}
+		report.Days = append(report.Days, day)
+		dayIndex[d.Format(time.DateOnly)] = day
+	}
+
+	taskIndex := map[entity.TaskID]*entity.TaskDuration{}
+	for _, e := range entries {
+		start, end := e.StartedAt, e.End(now)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		td, ok := taskIndex[e.TaskID]
+		if !ok {
+			td = &entity.TaskDuration{TaskID: e.TaskID, Title: e.TaskTitle}
+			taskIndex[e.TaskID] = td
+			report.Tasks = append(report.Tasks, td)
+		}
+		td.Duration += end.Sub(start)
+		report.Total += end.Sub(start)
+
+		// 日をまたぐ記録はタイムゾーンの0時で分割する
+		t := start.In(report.Location)
+		for t.Before(end) {
+			dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, report.Location)
+			next := dayStart.AddDate(0, 0, 1)
+			segEnd := end
+			if next.Before(segEnd) {
+				segEnd = next
+			}
+			if day, ok := dayIndex[dayStart.Format(time.DateOnly)]; ok {
+				day.Duration += segEnd.Sub(t)
+			}
+			t = next
+		}
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		return report.Tasks[i].Duration > report.Tasks[j].Duration
+	})
+	return report
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestTimeReport_TimeReport(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2024, 4, day, hour, min, 0, 0, tokyo)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	entries := entity.TimeEntries{
+		// 4/1 23:00〜4/2 01:30 は日付をまたぐので2日に分割される
+		{TaskID: 1, TaskTitle: "report", StartedAt: at(1, 23, 0), StoppedAt: ptr(at(2, 1, 30))},
+		{TaskID: 2, TaskTitle: "review", StartedAt: at(2, 10, 0), StoppedAt: ptr(at(2, 10, 45))},
+		// 期間より前から始まった記録は期間内の部分だけ数える
+		{TaskID: 2, TaskTitle: "review", StartedAt: at(0, 22, 0), StoppedAt: ptr(at(1, 0, 30))},
+		// 計測中の記録はClockerの現在時刻までを数える
+		{TaskID: 1, TaskTitle: "report", StartedAt: at(3, 9, 0)},
+	}
+	now := at(3, 9, 20)
+
+	var gotFrom, gotTo time.Time
+	repo := &TimeReporterMock{
+		GetUserByIDFunc: func(ctx context.Context, db store.Queryer, id entity.UserID) (*entity.User, error) {
+			return &entity.User{ID: id, Timezone: "Asia/Tokyo"}, nil
+		},
+		ListTimeEntriesFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, from, to time.Time) (entity.TimeEntries, error) {
+			gotFrom, gotTo = from, to
+			return entries, nil
+		},
+	}
+	sut := &TimeReport{Repo: repo, Clocker: fixedNow(now)}
+	ctx := auth.SetUserID(context.Background(), 1)
+
+	got, err := sut.TimeReport(ctx, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("TimeReport() unexpected error: %v", err)
+	}
+
+	if !gotFrom.Equal(at(1, 0, 0)) || !gotTo.Equal(at(4, 0, 0)) {
+		t.Errorf("ListTimeEntries() called with [%v, %v)", gotFrom, gotTo)
+	}
+
+	wantDays := []time.Duration{
+		30*time.Minute + time.Hour, // review 0:00-0:30, report 23:00-24:00
+		90*time.Minute + 45*time.Minute,
+		20 * time.Minute,
+	}
+	if len(got.Days) != len(wantDays) {
+		t.Fatalf("got %d days, want %d", len(got.Days), len(wantDays))
+	}
+	for i, want := range wantDays {
+		if got.Days[i].Duration != want {
+			t.Errorf("day %s = %v, want %v", got.Days[i].Date.Format(time.DateOnly), got.Days[i].Duration, want)
+		}
+	}
+
+	wantTasks := map[entity.TaskID]time.Duration{
+		1: 2*time.Hour + 30*time.Minute + 20*time.Minute,
+		2: 45*time.Minute + 30*time.Minute,
+	}
+	for _, td := range got.Tasks {
+		if td.Duration != wantTasks[td.TaskID] {
+			t.Errorf("task %d = %v, want %v", td.TaskID, td.Duration, wantTasks[td.TaskID])
+		}
+	}
+	if got.Tasks[0].TaskID != 1 {
+		t.Errorf("tasks should be sorted by duration, got %+v", got.Tasks[0])
+	}
+	if got.Total != wantTasks[1]+wantTasks[2] {
+		t.Errorf("Total = %v, want %v", got.Total, wantTasks[1]+wantTasks[2])
+	}
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := sut.TimeReport(ctx, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+		if !errors.Is(err, ErrInvalidTimeRange) {
+			t.Errorf("want %v, but got %v", ErrInvalidTimeRange, err)
+		}
+	})
+}
+
+// fixedNow は任意の時刻を返すClocker
+type fixedNow time.Time
+
+func (f fixedNow) Now() time.Time { return time.Time(f) }
+
+var _ clock.Clocker = fixedNow{}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+type StartTimer struct {
+	DB      store.Execer
+	Repo    TimerStarter
+	Clocker clock.Clocker
+}
+
+func (s *StartTimer) StartTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	e := &entity.TimeEntry{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: s.Clocker.Now(),
+	}
+	if err := s.Repo.StartTimer(ctx, s.DB, e); err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+	return e, nil
+}
+
+type StopTimer struct {
+	DB      store.TxBeginner
+	Repo    TimeEntryUpdater
+	Clocker clock.Clocker
+}
+
+func (s *StopTimer) StopTimer(ctx context.Context, taskID entity.TaskID) (*entity.TimeEntry, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	e, err := s.Repo.GetRunningTimeEntry(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+	if e.TaskID != taskID {
+		return nil, fmt.Errorf("no running timer for task %d: %w", taskID, store.ErrNotFound)
+	}
+
+	now := s.Clocker.Now()
+	e.StoppedAt = &now
+	if err := s.Repo.UpdateTimeEntry(ctx, tx, e); err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return e, nil
+}
+
+type UpdateTimeEntry struct {
+	DB      store.TxBeginner
+	Repo    TimeEntryUpdater
+	Clocker clock.Clocker
+}
+
+// UpdateTimeEntry は記録の開始・終了時刻を手動で修正する。
+// stoppedAtをnilにすると計測中に戻す。
+func (u *UpdateTimeEntry) UpdateTimeEntry(
+	ctx context.Context, id entity.TimeEntryID, startedAt time.Time, stoppedAt *time.Time,
+) (*entity.TimeEntry, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	now := u.Clocker.Now()
+	if startedAt.After(now) {
+		return nil, fmt.Errorf("started_at is in the future: %w", ErrInvalidTimeRange)
+	}
+	if stoppedAt != nil && (!stoppedAt.After(startedAt) || stoppedAt.After(now)) {
+		return nil, fmt.Errorf("stopped_at must be after started_at and not in the future: %w", ErrInvalidTimeRange)
+	}
+
+	tx, err := u.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	e, err := u.Repo.GetTimeEntry(ctx, tx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entry: %w", err)
+	}
+	e.StartedAt = startedAt
+	e.StoppedAt = stoppedAt
+	if err := u.Repo.UpdateTimeEntry(ctx, tx, e); err != nil {
+		return nil, fmt.Errorf("failed to update time entry: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestStopTimer_StopTimer(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	tests := []struct {
+		name       string
+		running    *entity.TimeEntry
+		runningErr error
+		taskID     entity.TaskID
+		wantCommit bool
+		wantErr    error
+	}{
+		{
+			name:       "stop running timer",
+			running:    &entity.TimeEntry{ID: 1, UserID: 1, TaskID: 10, StartedAt: c.Now().Add(-time.Hour)},
+			taskID:     10,
+			wantCommit: true,
+		},
+		{
+			name:       "no running timer",
+			runningErr: store.ErrNotFound,
+			taskID:     10,
+			wantErr:    store.ErrNotFound,
+		},
+		{
+			name:    "running timer belongs to another task",
+			running: &entity.TimeEntry{ID: 1, UserID: 1, TaskID: 11, StartedAt: c.Now().Add(-time.Hour)},
+			taskID:  10,
+			wantErr: store.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantCommit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			repo := &TimeEntryUpdaterMock{
+				GetRunningTimeEntryFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.TimeEntry, error) {
+					return tt.running, tt.runningErr
+				},
+				UpdateTimeEntryFunc: func(ctx context.Context, db store.Execer, e *entity.TimeEntry) error {
+					return nil
+				},
+			}
+			sut := &StopTimer{DB: sqlx.NewDb(db, "mysql"), Repo: repo, Clocker: c}
+			got, err := sut.StopTimer(auth.SetUserID(context.Background(), 1), tt.taskID)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("StopTimer() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.UpdateTimeEntryCalls()) != 0 {
+					t.Errorf("UpdateTimeEntry() should not be called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StopTimer() unexpected error: %v", err)
+			}
+			if got.StoppedAt == nil || !got.StoppedAt.Equal(c.Now()) {
+				t.Errorf("StoppedAt = %v, want %v", got.StoppedAt, c.Now())
+			}
+		})
+	}
+}
+
+func TestUpdateTimeEntry_UpdateTimeEntry_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	start := c.Now().Add(-time.Hour)
+	before := start.Add(-time.Second)
+	future := c.Now().Add(time.Second)
+
+	tests := map[string]struct {
+		entry entity.TimeEntry
+	}{
+		"stopped before started": {entry: entity.TimeEntry{StartedAt: start, StoppedAt: &before}},
+		"stopped in the future":  {entry: entity.TimeEntry{StartedAt: start, StoppedAt: &future}},
+		"started in the future":  {entry: entity.TimeEntry{StartedAt: future}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sut := &UpdateTimeEntry{Repo: &TimeEntryUpdaterMock{}, Clocker: c}
+			_, err := sut.UpdateTimeEntry(auth.SetUserID(context.Background(), 1), 1, tt.entry.StartedAt, tt.entry.StoppedAt)
+			if !errors.Is(err, ErrInvalidTimeRange) {
+				t.Errorf("want %v, but got %v", ErrInvalidTimeRange, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+type UpdateTaskStatus struct {
+	DB    store.TxBeginner
+	Repo  TaskStatusUpdater
+	Stats StatsInvalidator
+}
+
+// UpdateTaskStatus はタスクのステータスを変更する。ステータスが変わったタスクは新しいステータスの先頭の列に置かれるので、
+// その列がWIP上限に達していればErrWIPLimitExceededを返す
+func (u *UpdateTaskStatus) UpdateTaskStatus(ctx context.Context, id entity.TaskID, status entity.TaskStatus) error {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return fmt.Errorf("user_id not found")
+	}
+
+	tx, err := u.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// 列のロックはタスクを読む前に取る。ステータスが変わらなければ列も変わらないので、上限は確かめない
+	c, err := lockDefaultColumn(ctx, tx, u.Repo, userID, status)
+	if err != nil {
+		return err
+	}
+	t, err := u.Repo.GetTask(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+	if t.Status != status {
+		if err := checkWIPLimit(ctx, tx, u.Repo, c, id, 1); err != nil {
+			return err
+		}
+	}
+	if err := u.Repo.UpdateTaskStatus(ctx, tx, userID, id, status); err != nil {
+		return fmt.Errorf("failed to update task status: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	invalidateTaskStats(ctx, u.Stats, t)
+	return nil
+}
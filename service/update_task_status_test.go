@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestUpdateTaskStatus_UpdateTaskStatus(t *testing.T) {
+	t.Parallel()
+
+	limit := 2
+	doing := &entity.Column{ID: 4, UserID: 1, Name: "In progress", Status: entity.TaskStatusDoing, WIPLimit: &limit}
+	tests := []struct {
+		name       string
+		current    entity.TaskStatus
+		column     *entity.Column
+		count      int
+		getTaskErr error
+		wantUpdate bool
+		wantCount  bool
+		wantErrIs  error
+	}{
+		{
+			name:       "no column for the status",
+			current:    entity.TaskStatusTodo,
+			wantUpdate: true,
+		},
+		{
+			name:       "below wip limit",
+			current:    entity.TaskStatusTodo,
+			column:     doing,
+			count:      1,
+			wantUpdate: true,
+			wantCount:  true,
+		},
+		{
+			name:      "wip limit reached",
+			current:   entity.TaskStatusTodo,
+			column:    doing,
+			count:     2,
+			wantCount: true,
+			wantErrIs: ErrWIPLimitExceeded,
+		},
+		{
+			// ステータスが変わらなければ列も変わらないので、上限に達していても更新できる
+			name:       "same status",
+			current:    entity.TaskStatusDoing,
+			column:     doing,
+			count:      2,
+			wantUpdate: true,
+		},
+		{
+			name:       "task not found",
+			column:     doing,
+			getTaskErr: store.ErrNotFound,
+			wantErrIs:  store.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			mock.ExpectBegin()
+			if tt.wantUpdate {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			repo := &TaskStatusUpdaterMock{
+				GetDefaultColumnForUpdateFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID, status entity.TaskStatus) (*entity.Column, error) {
+					if status != entity.TaskStatusDoing {
+						t.Errorf("want the doing column, but got %q", status)
+					}
+					if tt.column == nil {
+						return nil, store.ErrNotFound
+					}
+					return tt.column, nil
+				},
+				GetTaskFunc: func(ctx context.Context, db store.Queryer, id entity.TaskID) (*entity.Task, error) {
+					if tt.getTaskErr != nil {
+						return nil, tt.getTaskErr
+					}
+					return &entity.Task{ID: id, UserID: 1, Status: tt.current}, nil
+				},
+				CountColumnTasksFunc: func(ctx context.Context, db store.Queryer, c *entity.Column, exclude entity.TaskID) (int, error) {
+					if exclude != 10 {
+						t.Errorf("want to exclude task 10, but got %d", exclude)
+					}
+					return tt.count, nil
+				},
+				UpdateTaskStatusFunc: func(ctx context.Context, db store.Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus) error {
+					return nil
+				},
+			}
+			sut := &UpdateTaskStatus{DB: sqlx.NewDb(db, "mysql"), Repo: repo}
+			err = sut.UpdateTaskStatus(auth.SetUserID(context.Background(), 1), 10, entity.TaskStatusDoing)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("want error %v, but got %v", tt.wantErrIs, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := len(repo.UpdateTaskStatusCalls()) == 1; got != tt.wantUpdate {
+				t.Errorf("UpdateTaskStatus() called = %v, want %v", got, tt.wantUpdate)
+			}
+			if got := len(repo.CountColumnTasksCalls()) == 1; got != tt.wantCount {
+				t.Errorf("CountColumnTasks() called = %v, want %v", got, tt.wantCount)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// ErrForbidden はワークスペースのメンバーでない、またはロールが足りないときのエラー
+var ErrForbidden = errors.New("forbidden")
+
+// personalWorkspaceName は自動で作る個人用ワークスペースの名前
+const personalWorkspaceName = "personal"
+
+type ResolveWorkspace struct {
+	DB   *sqlx.DB
+	Repo WorkspaceResolver
+}
+
+// ResolveWorkspace はリクエストで使うワークスペースとそこでのロールを決める。
+// idが0ならユーザーの個人用ワークスペースを使い、まだなければ作成する。
+func (r *ResolveWorkspace) ResolveWorkspace(ctx context.Context, id entity.WorkspaceID) (*entity.WorkspaceMember, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	if id != 0 {
+		m, err := r.Repo.GetWorkspaceMember(ctx, r.DB, id, userID)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("workspace %d: %w", id, ErrForbidden)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace member: %w", err)
+		}
+		return m, nil
+	}
+
+	ws, err := r.Repo.GetPersonalWorkspace(ctx, r.DB, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		ws, err = r.createPersonal(ctx, userID)
+		// 同時に作成された場合は先に作られた方を使う
+		if errors.Is(err, store.ErrAlreadyExists) {
+			ws, err = r.Repo.GetPersonalWorkspace(ctx, r.DB, userID)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal workspace: %w", err)
+	}
+	return &entity.WorkspaceMember{
+		WorkspaceID: ws.ID,
+		UserID:      userID,
+		Role:        entity.WorkspaceRoleOwner,
+	}, nil
+}
+
+func (r *ResolveWorkspace) createPersonal(ctx context.Context, userID entity.UserID) (*entity.Workspace, error) {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ws := &entity.Workspace{Name: personalWorkspaceName, PersonalUserID: &userID}
+	if err := r.Repo.AddWorkspace(ctx, tx, ws); err != nil {
+		return nil, err
+	}
+	m := &entity.WorkspaceMember{WorkspaceID: ws.ID, UserID: userID, Role: entity.WorkspaceRoleOwner}
+	if err := r.Repo.AddWorkspaceMember(ctx, tx, m); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return ws, nil
+}
+
+type AddWorkspace struct {
+	DB   store.TxBeginner
+	Repo WorkspaceAdder
+}
+
+// AddWorkspace はチームのワークスペースを作成し、作成者をオーナーにする
+func (a *AddWorkspace) AddWorkspace(ctx context.Context, name string) (*entity.Workspace, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	tx, err := a.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ws := &entity.Workspace{Name: name}
+	if err := a.Repo.AddWorkspace(ctx, tx, ws); err != nil {
+		return nil, fmt.Errorf("failed to add workspace: %w", err)
+	}
+	m := &entity.WorkspaceMember{WorkspaceID: ws.ID, UserID: userID, Role: entity.WorkspaceRoleOwner}
+	if err := a.Repo.AddWorkspaceMember(ctx, tx, m); err != nil {
+		return nil, fmt.Errorf("failed to add owner: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit: %w", err)
+	}
+	return ws, nil
+}
+
+type ListWorkspaces struct {
+	DB   store.Queryer
+	Repo WorkspaceLister
+}
+
+func (l *ListWorkspaces) ListWorkspaces(ctx context.Context) ([]*entity.WorkspaceMembership, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	workspaces, err := l.Repo.ListWorkspaces(ctx, l.DB, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+type AddWorkspaceMember struct {
+	DB   *sqlx.DB
+	Repo WorkspaceMemberAdder
+}
+
+// AddWorkspaceMember はユーザー名で指定したユーザーをワークスペースに追加する。
+// 追加できるのはワークスペースのオーナーと管理者だけ。
+func (a *AddWorkspaceMember) AddWorkspaceMember(
+	ctx context.Context, id entity.WorkspaceID, userName string, role entity.WorkspaceRole,
+) (*entity.WorkspaceMember, error) {
+	userID, ok := auth.GetUserID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user_id not found")
+	}
+
+	me, err := a.Repo.GetWorkspaceMember(ctx, a.DB, id, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("workspace %d: %w", id, ErrForbidden)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+	if !me.Role.CanManage() {
+		return nil, fmt.Errorf("role %q cannot add members: %w", me.Role, ErrForbidden)
+	}
+
+	u, err := a.Repo.GetUser(ctx, a.DB, userName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %q: %w", userName, store.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	m := &entity.WorkspaceMember{WorkspaceID: id, UserID: u.ID, Role: role}
+	if err := a.Repo.AddWorkspaceMember(ctx, a.DB, m); err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+func TestResolveWorkspace_ResolveWorkspace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		id          entity.WorkspaceID
+		memberErr   error
+		personalErr []error
+		addErr      error
+		wantID      entity.WorkspaceID
+		wantRole    entity.WorkspaceRole
+		wantCreate  bool
+		wantErrIs   error
+	}{
+		{
+			name:     "member of the requested workspace",
+			id:       5,
+			wantID:   5,
+			wantRole: entity.WorkspaceRoleMember,
+		},
+		{
+			name:      "not a member of the requested workspace",
+			id:        5,
+			memberErr: store.ErrNotFound,
+			wantErrIs: ErrForbidden,
+		},
+		{
+			name:     "existing personal workspace",
+			wantID:   1,
+			wantRole: entity.WorkspaceRoleOwner,
+		},
+		{
+			name:        "personal workspace is created on first use",
+			personalErr: []error{store.ErrNotFound},
+			wantID:      2,
+			wantRole:    entity.WorkspaceRoleOwner,
+			wantCreate:  true,
+		},
+		{
+			name:        "personal workspace created concurrently",
+			personalErr: []error{store.ErrNotFound, nil},
+			addErr:      store.ErrAlreadyExists,
+			wantID:      1,
+			wantRole:    entity.WorkspaceRoleOwner,
+			wantCreate:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			if tt.wantCreate {
+				mock.ExpectBegin()
+				if tt.addErr != nil {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+
+			calls := 0
+			repo := &WorkspaceResolverMock{
+				GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+					if tt.memberErr != nil {
+						return nil, tt.memberErr
+					}
+					return &entity.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: entity.WorkspaceRoleMember}, nil
+				},
+				GetPersonalWorkspaceFunc: func(ctx context.Context, db store.Queryer, userID entity.UserID) (*entity.Workspace, error) {
+					defer func() { calls++ }()
+					if calls < len(tt.personalErr) && tt.personalErr[calls] != nil {
+						return nil, tt.personalErr[calls]
+					}
+					return &entity.Workspace{ID: 1, PersonalUserID: &userID}, nil
+				},
+				AddWorkspaceFunc: func(ctx context.Context, db store.Execer, ws *entity.Workspace) error {
+					if tt.addErr != nil {
+						return tt.addErr
+					}
+					ws.ID = 2
+					return nil
+				},
+				AddWorkspaceMemberFunc: func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+					return nil
+				},
+			}
+			sut := &ResolveWorkspace{DB: sqlx.NewDb(db, "mysql"), Repo: repo}
+			got, err := sut.ResolveWorkspace(auth.SetUserID(context.Background(), 1), tt.id)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ResolveWorkspace() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveWorkspace() unexpected error: %v", err)
+			}
+			if got.WorkspaceID != tt.wantID || got.Role != tt.wantRole {
+				t.Errorf("ResolveWorkspace() = %+v, want workspace %d as %q", got, tt.wantID, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestAddWorkspaceMember_AddWorkspaceMember(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		myRole    entity.WorkspaceRole
+		memberErr error
+		userErr   error
+		wantErrIs error
+	}{
+		{
+			name:   "owner adds a member",
+			myRole: entity.WorkspaceRoleOwner,
+		},
+		{
+			name:   "admin adds a member",
+			myRole: entity.WorkspaceRoleAdmin,
+		},
+		{
+			name:      "member cannot add members",
+			myRole:    entity.WorkspaceRoleMember,
+			wantErrIs: ErrForbidden,
+		},
+		{
+			name:      "outsider cannot add members",
+			memberErr: store.ErrNotFound,
+			wantErrIs: ErrForbidden,
+		},
+		{
+			name:      "unknown user",
+			myRole:    entity.WorkspaceRoleOwner,
+			userErr:   sql.ErrNoRows,
+			wantErrIs: store.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			added := false
+			repo := &WorkspaceMemberAdderMock{
+				GetWorkspaceMemberFunc: func(ctx context.Context, db store.Queryer, workspaceID entity.WorkspaceID, userID entity.UserID) (*entity.WorkspaceMember, error) {
+					if tt.memberErr != nil {
+						return nil, tt.memberErr
+					}
+					return &entity.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: tt.myRole}, nil
+				},
+				GetUserFunc: func(ctx context.Context, db store.Queryer, userName string) (*entity.User, error) {
+					if tt.userErr != nil {
+						return nil, tt.userErr
+					}
+					return &entity.User{ID: 2, Name: userName}, nil
+				},
+				AddWorkspaceMemberFunc: func(ctx context.Context, db store.Execer, m *entity.WorkspaceMember) error {
+					added = true
+					return nil
+				},
+			}
+			sut := &AddWorkspaceMember{Repo: repo}
+			got, err := sut.AddWorkspaceMember(auth.SetUserID(context.Background(), 1), 5, "bob", entity.WorkspaceRoleMember)
+
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("AddWorkspaceMember() error = %v, want %v", err, tt.wantErrIs)
+				}
+				if added {
+					t.Errorf("AddWorkspaceMember() added a member despite error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddWorkspaceMember() unexpected error: %v", err)
+			}
+			if got.UserID != 2 || got.WorkspaceID != 5 || got.Role != entity.WorkspaceRoleMember {
+				t.Errorf("AddWorkspaceMember() = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_AssignTask(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	author := prepareUser(ctx, t, tx)
+	assignee := prepareUser(ctx, t, tx)
+	wsID := prepareWorkspace(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, wsID)
+	for _, u := range []entity.UserID{author, assignee} {
+		m := &entity.WorkspaceMember{WorkspaceID: wsID, UserID: u, Role: entity.WorkspaceRoleMember}
+		if err := sut.AddWorkspaceMember(ctx, tx, m); err != nil {
+			t.Fatalf("failed to add member: %s", err)
+		}
+	}
+
+	open := &entity.Task{UserID: author, Title: "open", Status: entity.TaskStatusTodo}
+	done := &entity.Task{UserID: author, Title: "done", Status: entity.TaskStatusDone}
+	mine := &entity.Task{UserID: author, Title: "mine", Status: entity.TaskStatusTodo}
+	for _, task := range []*entity.Task{open, done, mine} {
+		if err := sut.AddTask(ctx, tx, task); err != nil {
+			t.Fatalf("failed to add task: %s", err)
+		}
+	}
+	for _, task := range []*entity.Task{open, done} {
+		if err := sut.AssignTask(ctx, tx, task.ID, &assignee); err != nil {
+			t.Fatalf("failed to assign task: %s", err)
+		}
+	}
+
+	got, err := sut.GetTask(ctx, tx, open.ID)
+	if err != nil {
+		t.Fatalf("failed to get task: %s", err)
+	}
+	if got.AssigneeID == nil || *got.AssigneeID != assignee {
+		t.Errorf("GetTask().AssigneeID = %v, want %d", got.AssigneeID, assignee)
+	}
+
+	assigned, err := sut.ListAssignedTasks(ctx, tx, assignee)
+	if err != nil {
+		t.Fatalf("failed to list assigned tasks: %s", err)
+	}
+	if ids := taskIDs(assigned); !cmp.Equal(ids, []entity.TaskID{open.ID, done.ID}) {
+		t.Errorf("ListAssignedTasks() = %v, want [%d %d]", ids, open.ID, done.ID)
+	}
+
+	// 担当者は自分が作成していないタスクでも一覧で見え、ステータスを変更できる
+	visible, err := sut.ListTasks(ctx, tx, assignee)
+	if err != nil {
+		t.Fatalf("failed to list tasks: %s", err)
+	}
+	if ids := taskIDs(visible); !cmp.Equal(ids, []entity.TaskID{open.ID, done.ID}) {
+		t.Errorf("ListTasks() for assignee = %v, want [%d %d]", ids, open.ID, done.ID)
+	}
+	if err := sut.UpdateTaskStatus(ctx, tx, assignee, open.ID, entity.TaskStatusDoing); err != nil {
+		t.Errorf("UpdateTaskStatus() by assignee: %v", err)
+	}
+
+	workload, err := sut.GetWorkload(ctx, tx)
+	if err != nil {
+		t.Fatalf("failed to get workload: %s", err)
+	}
+	want := []entity.UserID{assignee, author}
+	counts := map[entity.UserID]int{assignee: 1, author: 0}
+	if len(workload) != len(want) {
+		t.Fatalf("GetWorkload() = %+v", workload)
+	}
+	for i, w := range workload {
+		if w.AssigneeID != want[i] || w.OpenTasks != counts[w.AssigneeID] {
+			t.Errorf("GetWorkload()[%d] = %+v, want user %d with %d open tasks", i, w, want[i], counts[want[i]])
+		}
+	}
+
+	if err := sut.AssignTask(ctx, tx, open.ID, nil); err != nil {
+		t.Fatalf("failed to unassign task: %s", err)
+	}
+	got, err = sut.GetTask(ctx, tx, open.ID)
+	if err != nil {
+		t.Fatalf("failed to get task: %s", err)
+	}
+	if got.AssigneeID != nil {
+		t.Errorf("GetTask().AssigneeID = %d after unassign, want nil", *got.AssigneeID)
+	}
+}
+
+func taskIDs(tasks entity.Tasks) []entity.TaskID {
+	ids := make([]entity.TaskID, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
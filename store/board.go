@@ -0,0 +1,254 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func (r *Repository) ListColumns(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (entity.Columns, error) {
+	columns := entity.Columns{}
+	query := `SELECT id, user_id, name, status, position, wip_limit, created_at, modified_at
+		FROM board_columns
+		WHERE user_id = ?
+		ORDER BY position;`
+	if err := db.SelectContext(ctx, &columns, query, userID); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// AddColumn は列を登録する。同じ位置の列が既にあればErrAlreadyExistsを返す
+func (r *Repository) AddColumn(
+	ctx context.Context, db Execer, c *entity.Column,
+) error {
+	c.CreatedAt = r.Clocker.Now()
+	c.ModifiedAt = r.Clocker.Now()
+
+	query := `INSERT INTO board_columns
+		(user_id, name, status, position, wip_limit, created_at, modified_at) VALUES (?, ?, ?, ?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, c.UserID, c.Name, c.Status, c.Position, c.WIPLimit, c.CreatedAt, c.ModifiedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("cannot create same position column: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	c.ID = entity.ColumnID(id)
+	return nil
+}
+
+// GetColumnForUpdate はユーザーの列を行ロックを取って取得する。
+// WIP上限の確認と移動を直列化するため、トランザクション内で使うこと。
+func (r *Repository) GetColumnForUpdate(
+	ctx context.Context, db Queryer, userID entity.UserID, id entity.ColumnID,
+) (*entity.Column, error) {
+	c := &entity.Column{}
+	query := `SELECT id, user_id, name, status, position, wip_limit, created_at, modified_at
+		FROM board_columns
+		WHERE id = ? AND user_id = ?
+		FOR UPDATE;`
+	if err := db.GetContext(ctx, c, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("column %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetDefaultColumnForUpdate はstatusのタスクが列を明示せずに置かれる、同じステータスの先頭の列を行ロックを取って取得する。
+// 同じステータスの列がなければErrNotFoundを返す。トランザクション内で使うこと。
+func (r *Repository) GetDefaultColumnForUpdate(
+	ctx context.Context, db Queryer, userID entity.UserID, status entity.TaskStatus,
+) (*entity.Column, error) {
+	c := &entity.Column{}
+	query := `SELECT id, user_id, name, status, position, wip_limit, created_at, modified_at
+		FROM board_columns
+		WHERE user_id = ? AND status = ?
+		ORDER BY position
+		LIMIT 1
+		FOR UPDATE;`
+	if err := db.GetContext(ctx, c, query, userID, status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s column: %w", status, ErrNotFound)
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// 列を明示していないタスクは、同じステータスの列のうち先頭の列に置かれる。
+// 担当者が自分の列に移したタスクのように、ほかのユーザーの列にあるタスクも列を明示していないものとして扱う
+const columnMembership = `(t.column_id = c.id OR (` + foreignColumn + ` AND t.status = c.status
+	AND c.position = (SELECT MIN(c2.position) FROM board_columns c2 WHERE c2.user_id = c.user_id AND c2.status = c.status)))`
+
+// foreignColumn はタスクの列が、列cのユーザーの列でないことを表す条件
+const foreignColumn = `(t.column_id IS NULL OR NOT EXISTS (
+	SELECT 1 FROM board_columns c3 WHERE c3.id = t.column_id AND c3.user_id = c.user_id))`
+
+// CountColumnTasks は列に置かれているタスクの数を数える。excludeのタスクは数えない
+func (r *Repository) CountColumnTasks(
+	ctx context.Context, db Queryer, c *entity.Column, exclude entity.TaskID,
+) (int, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	query := `SELECT COUNT(*)
+		FROM board_columns c
+		JOIN tasks t ON (t.user_id = c.user_id OR t.assignee_id = c.user_id) AND ` + columnMembership + `
+		WHERE c.id = ? AND t.workspace_id = ? AND t.id <> ?;`
+	if err := db.GetContext(ctx, &n, query, c.ID, wsID, exclude); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// MoveTask はタスクを列に移動し、ステータスを列の基本ステータスにそろえる
+func (r *Repository) MoveTask(
+	ctx context.Context, db Execer, userID entity.UserID, id entity.TaskID, c *entity.Column,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	query := `UPDATE tasks
+		SET column_id = ?,
+			status = ?,
+			completed_at = CASE WHEN ? = 'done' THEN COALESCE(completed_at, ?) ELSE NULL END,
+			modified_at = ?
+		WHERE id = ? AND workspace_id = ? AND (user_id = ? OR assignee_id = ?);`
+	result, err := db.ExecContext(ctx, query, c.ID, c.Status, c.Status, now, now, id, wsID, userID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+type boardRow struct {
+	ColumnID     entity.ColumnID   `db:"column_id"`
+	ColumnName   string            `db:"column_name"`
+	ColumnStatus entity.TaskStatus `db:"column_status"`
+	Position     int               `db:"position"`
+	WIPLimit     *int              `db:"wip_limit"`
+	TaskID       *entity.TaskID    `db:"task_id"`
+	TaskUserID   *entity.UserID    `db:"task_user_id"`
+	TaskColumnID *entity.ColumnID  `db:"task_column_id"`
+	TaskTitle    *string           `db:"task_title"`
+	TaskStatus   *string           `db:"task_status"`
+	TaskPriority *string           `db:"task_priority"`
+	TaskDueAt    *time.Time        `db:"task_due_at"`
+	TaskCreated  *time.Time        `db:"task_created_at"`
+	TaskModified *time.Time        `db:"task_modified_at"`
+}
+
+// GetBoard は列とそこに置かれたタスクを1回のクエリで取得する。
+// 列はユーザーごとの定義で、タスクはコンテキストのワークスペースのものだけを置く。
+// 列を1つも定義していないユーザーには基本ステータスごとの列(IDは0)を返す。
+// ほかのユーザーの列にあるタスクは同じステータスの先頭の列に置き、その列のIDは返さない。
+func (r *Repository) GetBoard(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (*entity.Board, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `WITH c AS (
+			SELECT id, user_id, name, status, position, wip_limit
+			FROM board_columns
+			WHERE user_id = ?
+			UNION ALL
+			SELECT 0, ?, d.status, d.status, d.position, NULL
+			FROM (SELECT 'todo' AS status, 0 AS position
+				UNION ALL SELECT 'doing', 1
+				UNION ALL SELECT 'done', 2) d
+			WHERE NOT EXISTS (SELECT 1 FROM board_columns WHERE user_id = ?)
+		)
+		SELECT
+			c.id AS column_id,
+			c.name AS column_name,
+			c.status AS column_status,
+			c.position,
+			c.wip_limit,
+			t.id AS task_id,
+			t.user_id AS task_user_id,
+			t.title AS task_title,
+			t.status AS task_status,
+			t.column_id AS task_column_id,
+			t.priority AS task_priority,
+			t.due_at AS task_due_at,
+			t.created_at AS task_created_at,
+			t.modified_at AS task_modified_at
+		FROM c
+		LEFT JOIN tasks t ON (t.user_id = c.user_id OR t.assignee_id = c.user_id) AND t.workspace_id = ?
+			AND (t.column_id = c.id OR (` + foreignColumn + ` AND t.status = c.status
+				AND c.position = (SELECT MIN(c2.position) FROM c c2 WHERE c2.status = c.status)))
+		ORDER BY c.position, t.id;`
+	var rows []*boardRow
+	if err := db.SelectContext(ctx, &rows, query, userID, userID, userID, wsID); err != nil {
+		return nil, err
+	}
+
+	board := &entity.Board{Columns: []*entity.BoardColumn{}}
+	var cur *entity.BoardColumn
+	for _, row := range rows {
+		if cur == nil || cur.Position != row.Position {
+			cur = &entity.BoardColumn{
+				Column: entity.Column{
+					ID:       row.ColumnID,
+					UserID:   userID,
+					Name:     row.ColumnName,
+					Status:   row.ColumnStatus,
+					Position: row.Position,
+					WIPLimit: row.WIPLimit,
+				},
+				Tasks: entity.Tasks{},
+			}
+			board.Columns = append(board.Columns, cur)
+		}
+		if row.TaskID == nil {
+			continue
+		}
+		t := &entity.Task{
+			ID:       *row.TaskID,
+			UserID:   *row.TaskUserID,
+			Title:    *row.TaskTitle,
+			Status:   entity.TaskStatus(*row.TaskStatus),
+			Priority: entity.TaskPriority(*row.TaskPriority),
+			DueAt:    row.TaskDueAt,
+		}
+		if row.TaskColumnID != nil && *row.TaskColumnID == row.ColumnID {
+			t.ColumnID = row.TaskColumnID
+		}
+		if row.TaskCreated != nil {
+			t.CreatedAt = *row.TaskCreated
+		}
+		if row.TaskModified != nil {
+			t.ModifiedAt = *row.TaskModified
+		}
+		cur.Tasks = append(cur.Tasks, t)
+	}
+	return board, nil
+}
@@ -0,0 +1,237 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_GetBoard(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	otherUserID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	limit := 2
+	columns := entity.Columns{
+		{UserID: userID, Name: "Backlog", Status: entity.TaskStatusTodo, Position: 0},
+		{UserID: userID, Name: "Ready", Status: entity.TaskStatusTodo, Position: 1, WIPLimit: &limit},
+		{UserID: userID, Name: "Doing", Status: entity.TaskStatusDoing, Position: 2},
+		{UserID: userID, Name: "Done", Status: entity.TaskStatusDone, Position: 3},
+	}
+	for _, c := range columns {
+		if err := sut.AddColumn(ctx, tx, c); err != nil {
+			t.Fatalf("failed to add column: %s", err)
+		}
+	}
+
+	tasks := entity.Tasks{
+		{UserID: userID, Title: "backlog", Status: entity.TaskStatusTodo},
+		{UserID: userID, Title: "ready", Status: entity.TaskStatusTodo},
+		{UserID: userID, Title: "doing", Status: entity.TaskStatusDoing},
+		{UserID: otherUserID, Title: "other user", Status: entity.TaskStatusTodo},
+	}
+	for _, task := range tasks {
+		if err := sut.AddTask(ctx, tx, task); err != nil {
+			t.Fatalf("failed to add task: %s", err)
+		}
+	}
+	if err := sut.MoveTask(ctx, tx, userID, tasks[1].ID, columns[1]); err != nil {
+		t.Fatalf("failed to move task: %s", err)
+	}
+
+	board, err := sut.GetBoard(ctx, tx, userID)
+	if err != nil {
+		t.Fatalf("failed to get board: %s", err)
+	}
+	wantTitles := map[string][]string{
+		"Backlog": {"backlog"},
+		"Ready":   {"ready"},
+		"Doing":   {"doing"},
+		"Done":    {},
+	}
+	if len(board.Columns) != len(wantTitles) {
+		t.Fatalf("want %d columns, but got %d", len(wantTitles), len(board.Columns))
+	}
+	for i, c := range board.Columns {
+		if c.ID != columns[i].ID || c.Name != columns[i].Name {
+			t.Errorf("column %d: want %q(%d), but got %q(%d)", i, columns[i].Name, columns[i].ID, c.Name, c.ID)
+		}
+		var got []string
+		for _, task := range c.Tasks {
+			got = append(got, task.Title)
+		}
+		if want := wantTitles[c.Name]; len(got) != len(want) || (len(want) > 0 && got[0] != want[0]) {
+			t.Errorf("column %q: want tasks %v, but got %v", c.Name, want, got)
+		}
+	}
+	if board.Columns[1].WIPLimit == nil || *board.Columns[1].WIPLimit != limit {
+		t.Errorf("want wip limit %d, but got %v", limit, board.Columns[1].WIPLimit)
+	}
+
+	n, err := sut.CountColumnTasks(ctx, tx, columns[0], 0)
+	if err != nil {
+		t.Fatalf("failed to count column tasks: %s", err)
+	}
+	if n != 1 {
+		t.Errorf("want 1 task in backlog, but got %d", n)
+	}
+
+	// 列を明示していないタスクは同じステータスの先頭の列に置かれる
+	def, err := sut.GetDefaultColumnForUpdate(ctx, tx, userID, entity.TaskStatusDoing)
+	if err != nil {
+		t.Fatalf("failed to get default column: %s", err)
+	}
+	if def.ID != columns[2].ID {
+		t.Errorf("want default doing column %d, but got %d", columns[2].ID, def.ID)
+	}
+	if err := sut.UpdateTaskStatus(ctx, tx, userID, tasks[1].ID, entity.TaskStatusDoing); err != nil {
+		t.Fatalf("failed to update task status: %s", err)
+	}
+	if n, err := sut.CountColumnTasks(ctx, tx, def, 0); err != nil || n != 2 {
+		t.Errorf("want 2 tasks in doing after the status update, but got %d (%v)", n, err)
+	}
+	if _, err := sut.GetDefaultColumnForUpdate(ctx, tx, otherUserID, entity.TaskStatusTodo); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for a user without columns, but got %v", err)
+	}
+
+	// 列を定義していないユーザーには基本ステータスの列を返す
+	board, err = sut.GetBoard(ctx, tx, otherUserID)
+	if err != nil {
+		t.Fatalf("failed to get board: %s", err)
+	}
+	if len(board.Columns) != 3 {
+		t.Fatalf("want 3 default columns, but got %d", len(board.Columns))
+	}
+	if c := board.Columns[0]; c.ID != 0 || c.Status != entity.TaskStatusTodo || len(c.Tasks) != 1 {
+		t.Errorf("unexpected default column: %+v", c)
+	}
+}
+
+// TestRepository_GetBoard_MovedByAssignee は担当者が自分の列に移したタスクが、作成者のボードから消えないことを確かめる
+func TestRepository_GetBoard_MovedByAssignee(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	creatorID := prepareUser(ctx, t, tx)
+	assigneeID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	limit := 1
+	creatorColumns := entity.Columns{
+		{UserID: creatorID, Name: "Todo", Status: entity.TaskStatusTodo, Position: 0},
+		{UserID: creatorID, Name: "Doing", Status: entity.TaskStatusDoing, Position: 1, WIPLimit: &limit},
+	}
+	assigneeColumn := &entity.Column{UserID: assigneeID, Name: "Working", Status: entity.TaskStatusDoing, Position: 0}
+	for _, c := range append(creatorColumns, assigneeColumn) {
+		if err := sut.AddColumn(ctx, tx, c); err != nil {
+			t.Fatalf("failed to add column: %s", err)
+		}
+	}
+	task := &entity.Task{UserID: creatorID, Title: "shared", Status: entity.TaskStatusTodo}
+	if err := sut.AddTask(ctx, tx, task); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	if err := sut.AssignTask(ctx, tx, task.ID, &assigneeID); err != nil {
+		t.Fatalf("failed to assign task: %s", err)
+	}
+	if err := sut.MoveTask(ctx, tx, assigneeID, task.ID, assigneeColumn); err != nil {
+		t.Fatalf("failed to move task: %s", err)
+	}
+
+	// 作成者のボードでは、同じステータスの先頭の列に置く
+	board, err := sut.GetBoard(ctx, tx, creatorID)
+	if err != nil {
+		t.Fatalf("failed to get board: %s", err)
+	}
+	doing := board.Columns[1]
+	if len(board.Columns[0].Tasks) != 0 || len(doing.Tasks) != 1 {
+		t.Fatalf("want the task in the creator's doing column, but got %d and %d tasks", len(board.Columns[0].Tasks), len(doing.Tasks))
+	}
+	if got := doing.Tasks[0]; got.ID != task.ID || got.UserID != creatorID || got.ColumnID != nil {
+		t.Errorf("want task %d by user %d without the assignee's column, but got %+v", task.ID, creatorID, got)
+	}
+	// 作成者の列のWIPもボードと同じく数える
+	if n, err := sut.CountColumnTasks(ctx, tx, creatorColumns[1], 0); err != nil || n != 1 {
+		t.Errorf("want 1 task in the creator's doing column, but got %d (%v)", n, err)
+	}
+
+	// 担当者のボードでは、移した列に置く
+	board, err = sut.GetBoard(ctx, tx, assigneeID)
+	if err != nil {
+		t.Fatalf("failed to get board: %s", err)
+	}
+	if got := board.Columns[0].Tasks; len(got) != 1 || got[0].ColumnID == nil || *got[0].ColumnID != assigneeColumn.ID || got[0].UserID != creatorID {
+		t.Errorf("want the task in the assignee's column, but got %+v", got)
+	}
+}
+
+func TestRepository_AddColumn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := clock.FixedClocker{}
+
+	tests := map[string]struct {
+		execErr error
+		wantErr error
+	}{
+		"ok":                 {},
+		"duplicate position": {execErr: &mysql.MySQLError{Number: ErrCodeSQLDuplicateEntry}, wantErr: ErrAlreadyExists},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			col := &entity.Column{UserID: 1, Name: "Review", Status: entity.TaskStatusDoing, Position: 3}
+			exp := mock.ExpectExec("INSERT INTO board_columns").
+				WithArgs(col.UserID, col.Name, col.Status, col.Position, col.WIPLimit, c.Now(), c.Now())
+			if tt.execErr != nil {
+				exp.WillReturnError(tt.execErr)
+			} else {
+				exp.WillReturnResult(sqlmock.NewResult(7, 1))
+			}
+
+			r := &Repository{Clocker: c}
+			err = r.AddColumn(ctx, sqlx.NewDb(db, "mysql"), col)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && col.ID != 7 {
+				t.Errorf("want column id 7, but got %d", col.ID)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/zakisanbaiman/go-handson01/config"
@@ -26,8 +30,41 @@ type KVS struct {
 	Cli *redis.Client
 }
 
-func (kvs *KVS) Save(ctx context.Context, key string, userID entity.UserID) error {
-	return kvs.Cli.Set(ctx, key, userID, 0).Err()
+// userTokensKey はユーザーのトークンのjtiの集合のキー。ログアウトですべてのトークンを消すのに使う
+func userTokensKey(userID entity.UserID) string {
+	return fmt.Sprintf("user_tokens:%d", userID)
+}
+
+// saveSession はトークンをttlの間保存し、ユーザーのトークンの集合に加える。
+// 集合はいちばん長く残るトークンと同時に消えるよう、ttlが残り時間より長ければ延ばす
+var saveSession = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+redis.call('SADD', KEYS[2], KEYS[1])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[2], ARGV[2])
+end
+return 1
+`)
+
+// touchSession は保存済みのトークンの有効期間をttlにし、ユーザーのトークンの集合も必要なら延ばす
+var touchSession = redis.NewScript(`
+if redis.call('PEXPIRE', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[2], ARGV[1])
+end
+return 1
+`)
+
+// Save はトークンをttlの間保存する。ttlはトークンのexpまでの時間にする
+func (kvs *KVS) Save(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	return saveSession.Run(ctx, kvs.Cli, []string{key, userTokensKey(userID)}, userID, ttl.Milliseconds()).Err()
+}
+
+// Touch はトークンの有効期間をttlに変える。トークンが既に消えていれば何もしない
+func (kvs *KVS) Touch(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	return touchSession.Run(ctx, kvs.Cli, []string{key, userTokensKey(userID)}, ttl.Milliseconds()).Err()
 }
 
 func (kvs *KVS) Load(ctx context.Context, key string) (entity.UserID, error) {
@@ -40,3 +77,110 @@ func (kvs *KVS) Load(ctx context.Context, key string) (entity.UserID, error) {
 	}
 	return entity.UserID(userID), nil
 }
+
+// Delete はトークンを削除し、ユーザーのトークンの集合からも外す。存在しなければ何もしない
+func (kvs *KVS) Delete(ctx context.Context, key string) error {
+	userID, err := kvs.Load(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	pipe := kvs.Cli.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SRem(ctx, userTokensKey(userID), key)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// DeleteByUser はユーザーのトークンをすべて削除する
+func (kvs *KVS) DeleteByUser(ctx context.Context, userID entity.UserID) error {
+	uk := userTokensKey(userID)
+	keys, err := kvs.Cli.SMembers(ctx, uk).Result()
+	if err != nil {
+		return err
+	}
+	return kvs.Cli.Del(ctx, append(keys, uk)...).Err()
+}
+
+// sessionKey はSaveで保存したトークンのキー(jti)かユーザーのトークンの集合のキーにマッチする
+var sessionKey = regexp.MustCompile(`^([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|user_tokens:[0-9]+)$`)
+
+// ExpirePersistentSessions はTTLなしで保存されたトークンとユーザーのトークンの集合にttlを設定し、設定したキーの数を返す。
+// TTLを付ける前に保存したキーを片付けるためのもので、dryRunなら数えるだけで変更しない
+func (kvs *KVS) ExpirePersistentSessions(ctx context.Context, ttl time.Duration, dryRun bool) (int, error) {
+	var n int
+	iter := kvs.Cli.Scan(ctx, 0, "*", 1000).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if !sessionKey.MatchString(key) {
+			continue
+		}
+		// -1はTTLなし。-2は走査中に消えたキー
+		pttl, err := kvs.Cli.PTTL(ctx, key).Result()
+		if err != nil {
+			return n, err
+		}
+		if pttl != -1 {
+			continue
+		}
+		if !dryRun {
+			if err := kvs.Cli.Expire(ctx, key, ttl).Err(); err != nil {
+				return n, err
+			}
+		}
+		n++
+	}
+	if err := iter.Err(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// statsTTL は統計キャッシュの保持期間。タスクの更新時には明示的に破棄するが、
+// 日付の切り替わりで内容が古くならないよう短めにしておく
+const statsTTL = 10 * time.Minute
+
+// statsKey は統計をワークスペースごとに集計するので、ユーザーとワークスペースの組でキーを分ける
+func statsKey(workspaceID entity.WorkspaceID, userID entity.UserID) string {
+	return fmt.Sprintf("stats:%d:%d", workspaceID, userID)
+}
+
+// LoadStats はワークスペースでのユーザーの統計キャッシュを集計期間ごとに取得する
+func (kvs *KVS) LoadStats(
+	ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int,
+) (*entity.Stats, error) {
+	b, err := kvs.Cli.HGet(ctx, statsKey(workspaceID, userID), strconv.Itoa(windowDays)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	stats := &entity.Stats{}
+	if err := json.Unmarshal(b, stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (kvs *KVS) SaveStats(
+	ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID, windowDays int, stats *entity.Stats,
+) error {
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+	key := statsKey(workspaceID, userID)
+	pipe := kvs.Cli.TxPipeline()
+	pipe.HSet(ctx, key, strconv.Itoa(windowDays), b)
+	pipe.Expire(ctx, key, statsTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// InvalidateStats はワークスペースでのユーザーの統計キャッシュを集計期間に関係なく破棄する
+func (kvs *KVS) InvalidateStats(ctx context.Context, workspaceID entity.WorkspaceID, userID entity.UserID) error {
+	return kvs.Cli.Del(ctx, statsKey(workspaceID, userID)).Err()
+}
@@ -24,12 +24,105 @@ func TestKVS_Save(t *testing.T) {
 	ctx := context.Background()
 
 	t.Cleanup(func() {
-		client.Del(ctx, key)
+		client.Del(ctx, key, userTokensKey(userID))
 	})
 
-	if err := sut.Save(ctx, key, userID); err != nil {
+	if err := sut.Save(ctx, key, userID, time.Minute); err != nil {
 		t.Errorf("want no error, but got %v", err)
 	}
+	for _, k := range []string{key, userTokensKey(userID)} {
+		if ttl := client.TTL(ctx, k).Val(); ttl <= 0 || ttl > time.Minute {
+			t.Errorf("%s: want ttl within a minute, but got %v", k, ttl)
+		}
+	}
+}
+
+func TestKVS_Touch(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{
+		Cli: client,
+	}
+
+	userID := entity.UserID(987651)
+	short, long := "TestKVS_Touch_short", "TestKVS_Touch_long"
+	ctx := context.Background()
+	t.Cleanup(func() {
+		client.Del(ctx, short, long, userTokensKey(userID))
+	})
+
+	if err := sut.Save(ctx, long, userID, time.Hour); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if err := sut.Save(ctx, short, userID, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// 短いトークンを保存しても集合の期限は縮めない
+	if ttl := client.TTL(ctx, userTokensKey(userID)).Val(); ttl <= time.Minute {
+		t.Errorf("want the index to outlive the longest token, but got %v", ttl)
+	}
+
+	if err := sut.Touch(ctx, short, userID, 2*time.Hour); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for _, k := range []string{short, userTokensKey(userID)} {
+		if ttl := client.TTL(ctx, k).Val(); ttl <= time.Hour {
+			t.Errorf("%s: want ttl extended to 2h, but got %v", k, ttl)
+		}
+	}
+
+	// 消えたトークンは作り直さない
+	client.Del(ctx, short)
+	if err := sut.Touch(ctx, short, userID, time.Hour); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n := client.Exists(ctx, short).Val(); n != 0 {
+		t.Errorf("want the deleted token to stay deleted")
+	}
+}
+
+func TestKVS_ExpirePersistentSessions(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{
+		Cli: client,
+	}
+
+	jti := "0d2b7a4e-6c1f-4e0a-9d8b-3f5e2a1c7b90"
+	index := userTokensKey(987650)
+	other := "TestKVS_ExpirePersistentSessions"
+	ctx := context.Background()
+	t.Cleanup(func() {
+		client.Del(ctx, jti, index, other)
+	})
+	client.Set(ctx, jti, 987650, 0)
+	client.SAdd(ctx, index, jti)
+	client.Set(ctx, other, "x", 0)
+
+	n, err := sut.ExpirePersistentSessions(ctx, time.Minute, true)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n < 2 {
+		t.Errorf("want at least 2 keys, but got %d", n)
+	}
+	if ttl := client.TTL(ctx, jti).Val(); ttl != -1 {
+		t.Errorf("dry run must not change ttl, but got %v", ttl)
+	}
+
+	if _, err := sut.ExpirePersistentSessions(ctx, time.Minute, false); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for _, k := range []string{jti, index} {
+		if ttl := client.TTL(ctx, k).Val(); ttl <= 0 || ttl > time.Minute {
+			t.Errorf("%s: want ttl within a minute, but got %v", k, ttl)
+		}
+	}
+	if ttl := client.TTL(ctx, other).Val(); ttl != -1 {
+		t.Errorf("want other keys untouched, but got ttl %v", ttl)
+	}
 }
 
 func TestKVS_Load(t *testing.T) {
@@ -69,3 +162,90 @@ func TestKVS_Load(t *testing.T) {
 		}
 	})
 }
+
+func TestKVS_Delete(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{
+		Cli: client,
+	}
+
+	userID := entity.UserID(987653)
+	keys := []string{"TestKVS_Delete_1", "TestKVS_Delete_2", "TestKVS_Delete_3"}
+	ctx := context.Background()
+	t.Cleanup(func() {
+		client.Del(ctx, append(keys, userTokensKey(userID))...)
+	})
+	for _, k := range keys {
+		if err := sut.Save(ctx, k, userID, time.Minute); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+
+	if err := sut.Delete(ctx, keys[0]); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.Load(ctx, keys[0]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v, but got %v", ErrNotFound, err)
+	}
+	if n := client.SCard(ctx, userTokensKey(userID)).Val(); n != 2 {
+		t.Errorf("want 2 tokens left in the index, but got %d", n)
+	}
+	// 削除済みのトークンを消してもエラーにしない
+	if err := sut.Delete(ctx, keys[0]); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+
+	if err := sut.DeleteByUser(ctx, userID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for _, k := range keys {
+		if _, err := sut.Load(ctx, k); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: want %v, but got %v", k, ErrNotFound, err)
+		}
+	}
+	if n := client.Exists(ctx, userTokensKey(userID)).Val(); n != 0 {
+		t.Errorf("want the index to be deleted")
+	}
+}
+
+func TestKVS_Stats(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{
+		Cli: client,
+	}
+
+	userID := entity.UserID(987654)
+	wsID := entity.WorkspaceID(1)
+	otherWsID := entity.WorkspaceID(2)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		client.Del(ctx, statsKey(wsID, userID), statsKey(otherWsID, userID))
+	})
+
+	want := &entity.Stats{WindowDays: 7, CurrentStreak: 2}
+	if err := sut.SaveStats(ctx, wsID, userID, 7, want); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	// 別のワークスペースの統計は共有しない
+	if _, err := sut.LoadStats(ctx, otherWsID, userID, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v, but got %v", ErrNotFound, err)
+	}
+	got, err := sut.LoadStats(ctx, wsID, userID, 7)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got.CurrentStreak != want.CurrentStreak {
+		t.Errorf("want %+v, but got %+v", want, got)
+	}
+
+	if err := sut.InvalidateStats(ctx, wsID, userID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.LoadStats(ctx, wsID, userID, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v, but got %v", ErrNotFound, err)
+	}
+}
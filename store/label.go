@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// AddTaskLabels はタスクにラベルを付ける。まだないラベルはワークスペースごとに作成する。
+// 複数のINSERTを行うため、呼び出し側でトランザクションを張ること。
+func (r *Repository) AddTaskLabels(
+	ctx context.Context, db Execer, taskID entity.TaskID, names []string,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	// 既存のラベルならLAST_INSERT_IDでそのIDを返させる
+	labelSQL := `INSERT INTO labels (workspace_id, name, created_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);`
+	// 別のワークスペースのタスクには付けられないようにする
+	taskLabelSQL := `INSERT INTO task_labels (task_id, label_id)
+		SELECT id, ? FROM tasks WHERE id = ? AND workspace_id = ?;`
+	for _, name := range names {
+		result, err := db.ExecContext(ctx, labelSQL, wsID, name, now)
+		if err != nil {
+			return err
+		}
+		labelID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		result, err = db.ExecContext(ctx, taskLabelSQL, labelID, taskID, wsID)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+				// 既に付いているラベル
+				continue
+			}
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("task %d: %w", taskID, ErrNotFound)
+		}
+	}
+	return nil
+}
+
+// ListTaskLabels はコンテキストのワークスペースで、タスクに付いているラベルをまとめて返す
+func (r *Repository) ListTaskLabels(
+	ctx context.Context, db Queryer, taskIDs []entity.TaskID,
+) ([]*entity.TaskLabel, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	labels := []*entity.TaskLabel{}
+	if len(taskIDs) == 0 {
+		return labels, nil
+	}
+	query, args, err := sqlx.In(`SELECT tl.task_id, l.name
+		FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id
+		WHERE l.workspace_id = ? AND tl.task_id IN (?)
+		ORDER BY tl.task_id, l.name;`, wsID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SelectContext(ctx, &labels, query, args...); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func TestRepository_AddTaskLabels(t *testing.T) {
+	t.Parallel()
+
+	ctx := auth.SetWorkspaceID(context.Background(), 1)
+	c := clock.FixedClocker{}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// 既存ラベルでもLAST_INSERT_IDで既存のIDが返る
+	mock.ExpectExec("INSERT INTO labels").
+		WithArgs(entity.WorkspaceID(1), "home", c.Now()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec("INSERT INTO task_labels").
+		WithArgs(int64(3), entity.TaskID(10), entity.WorkspaceID(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO labels").
+		WithArgs(entity.WorkspaceID(1), "money", c.Now()).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectExec("INSERT INTO task_labels").
+		WithArgs(int64(4), entity.TaskID(10), entity.WorkspaceID(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := &Repository{Clocker: c}
+	if err := r.AddTaskLabels(ctx, sqlx.NewDb(db, "mysql"), 10, []string{"home", "money"}); err != nil {
+		t.Fatalf("failed to add task labels: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func loginFailuresKey(key string) string {
+	return "login_failures:" + key
+}
+
+func loginLockKey(key string) string {
+	return "login_lock:" + key
+}
+
+// loginFailures はウィンドウから外れた失敗を消し、失敗の回数、最後に失敗したミリ秒、ロックの残りのミリ秒を返す
+var loginFailures = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', tonumber(ARGV[1]) - tonumber(ARGV[2]))
+local n = redis.call('ZCARD', KEYS[1])
+local last = 0
+if n > 0 then
+	last = tonumber(redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')[2])
+end
+local locked = redis.call('PTTL', KEYS[2])
+if locked < 0 then
+	locked = 0
+end
+return {n, last, locked}
+`)
+
+// addLoginFailure は失敗を記録して回数を返す。回数がARGV[4]以上になり、まだロックしていなければARGV[5]ミリ秒ロックして1を返す
+var addLoginFailure = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+redis.call('ZADD', KEYS[1], now, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], window)
+local n = redis.call('ZCARD', KEYS[1])
+local locked = 0
+local threshold = tonumber(ARGV[4])
+if threshold > 0 and n >= threshold then
+	if redis.call('SET', KEYS[2], n, 'NX', 'PX', ARGV[5]) then
+		locked = 1
+	end
+end
+return {n, locked}
+`)
+
+// reserveLoginAttempt はロック中か、最後の失敗からARGV[5+n]ミリ秒(n回の失敗のあとに待つ時間、nが表より多ければ最後の値)が
+// 経っていなければ、待つ残りのミリ秒を返す。試せるなら、結果を待たずにこの試行を失敗として数えて0を返す。
+// 回数がARGV[4]以上になれば、ARGV[5]ミリ秒ロックする。ロックの値の'new'は、まだロックを記録していないことを表す
+var reserveLoginAttempt = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local locked = redis.call('PTTL', KEYS[2])
+if locked > 0 then
+	return locked
+end
+local n = redis.call('ZCARD', KEYS[1])
+if n > 0 then
+	local last = tonumber(redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')[2])
+	local delay = tonumber(ARGV[5 + math.min(n, #ARGV - 5)])
+	local wait = delay - (now - last)
+	if wait > 0 then
+		return wait
+	end
+end
+redis.call('ZADD', KEYS[1], now, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], window)
+local threshold = tonumber(ARGV[4])
+if threshold > 0 and n + 1 >= threshold then
+	redis.call('SET', KEYS[2], 'new', 'PX', ARGV[5])
+end
+return 0
+`)
+
+// takeLoginLockout はreserveLoginAttemptがかけて、まだ記録していないロックがあれば、記録済みにして失敗の回数とロックの残りのミリ秒を返す
+var takeLoginLockout = redis.NewScript(`
+local locked = redis.call('PTTL', KEYS[2])
+if locked <= 0 or redis.call('GET', KEYS[2]) ~= 'new' then
+	return {0, 0}
+end
+redis.call('SET', KEYS[2], 'recorded', 'PX', locked)
+return {redis.call('ZCARD', KEYS[1]), locked}
+`)
+
+// LoginFailures はkeyの直前のwindowの間のログインの失敗の回数と最後に失敗した時刻、ロックが解けるまでの時間を返す
+func (kvs *KVS) LoginFailures(ctx context.Context, key string, window time.Duration, now time.Time) (int64, time.Time, time.Duration, error) {
+	v, err := loginFailures.Run(ctx, kvs.Cli, []string{loginFailuresKey(key), loginLockKey(key)},
+		now.UnixMilli(), window.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return 0, time.Time{}, 0, err
+	}
+	if len(v) != 3 {
+		return 0, time.Time{}, 0, fmt.Errorf("unexpected login failures: %v", v)
+	}
+	var last time.Time
+	if v[0] > 0 {
+		last = time.UnixMilli(v[1])
+	}
+	return v[0], last, time.Duration(v[2]) * time.Millisecond, nil
+}
+
+// AddLoginFailure はkeyのログインの失敗をnowに記録し、直前のwindowの間の回数を返す。
+// 回数がlockAfter以上になればlockForの間ロックし、新しくロックしたときだけtrueを返す。
+// ロックが解けても失敗はウィンドウから外れるまで残るので、続けて失敗すればすぐにまたロックする
+func (kvs *KVS) AddLoginFailure(
+	ctx context.Context, key string, window time.Duration, now time.Time, lockAfter int64, lockFor time.Duration,
+) (int64, bool, error) {
+	member, err := loginFailureMember(now)
+	if err != nil {
+		return 0, false, err
+	}
+	v, err := addLoginFailure.Run(ctx, kvs.Cli, []string{loginFailuresKey(key), loginLockKey(key)},
+		now.UnixMilli(), window.Milliseconds(), member, lockAfter, lockFor.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	if len(v) != 2 {
+		return 0, false, fmt.Errorf("unexpected login failure: %v", v)
+	}
+	return v[0], v[1] == 1, nil
+}
+
+// ReserveLoginAttempt はkeyでパスワードを試す前に呼ぶ。ロック中か、最後の失敗からdelays[n-1]
+// (n回の失敗のあとに待つ時間。nがdelaysより多ければ最後の値)が経っていなければ、待つ残りの時間を返す。
+// 試せるなら、確認と同時にこの試行を失敗としてnowに記録して0を返すので、同時に送られたリクエストも1つずつしか通らない。
+// 回数がlockAfter以上になればlockForの間ロックする。成功したらClearLoginFailuresで消すこと
+func (kvs *KVS) ReserveLoginAttempt(
+	ctx context.Context, key string, window time.Duration, now time.Time,
+	delays []time.Duration, lockAfter int64, lockFor time.Duration,
+) (time.Duration, error) {
+	member, err := loginFailureMember(now)
+	if err != nil {
+		return 0, err
+	}
+	args := []interface{}{now.UnixMilli(), window.Milliseconds(), member, lockAfter, lockFor.Milliseconds()}
+	if len(delays) == 0 {
+		args = append(args, 0)
+	}
+	for _, d := range delays {
+		args = append(args, d.Milliseconds())
+	}
+	wait, err := reserveLoginAttempt.Run(ctx, kvs.Cli, []string{loginFailuresKey(key), loginLockKey(key)}, args...).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// TakeLoginLockout はReserveLoginAttemptがkeyにかけたロックを、1度だけ失敗の回数とロックの残りの時間で返す。
+// まだ返していないロックがなければfalseを返す
+func (kvs *KVS) TakeLoginLockout(ctx context.Context, key string) (int64, time.Duration, bool, error) {
+	v, err := takeLoginLockout.Run(ctx, kvs.Cli, []string{loginFailuresKey(key), loginLockKey(key)}).Int64Slice()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if len(v) != 2 {
+		return 0, 0, false, fmt.Errorf("unexpected login lockout: %v", v)
+	}
+	return v[0], time.Duration(v[1]) * time.Millisecond, v[1] > 0, nil
+}
+
+// ClearLoginFailures はログインに成功したkeyの失敗とロックを消す
+func (kvs *KVS) ClearLoginFailures(ctx context.Context, key string) error {
+	return kvs.Cli.Del(ctx, loginFailuresKey(key), loginLockKey(key)).Err()
+}
+
+// loginFailureMember は同じ時刻の失敗も別々に数えるよう、時刻に乱数を付けたメンバー
+func loginFailureMember(now time.Time) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%s", now.UnixMilli(), hex.EncodeToString(b)), nil
+}
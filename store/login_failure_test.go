@@ -0,0 +1,171 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_LoginFailures(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	key := "TestKVS_LoginFailures"
+	t.Cleanup(func() { client.Del(ctx, loginFailuresKey(key), loginLockKey(key)) })
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	window := 15 * time.Minute
+	n, last, locked, err := sut.LoginFailures(ctx, key, window, now)
+	if err != nil || n != 0 || !last.IsZero() || locked != 0 {
+		t.Fatalf("want no failures, but got %d, %v, %v, %v", n, last, locked, err)
+	}
+
+	for i := int64(1); i <= 4; i++ {
+		at := now.Add(time.Duration(i) * time.Minute)
+		got, newlyLocked, err := sut.AddLoginFailure(ctx, key, window, at, 3, time.Hour)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		// 3回目でロックし、4回目はロック済みなので新しくはロックしない
+		if got != i || newlyLocked != (i == 3) {
+			t.Errorf("failure %d: want %d and locked %v, but got %d and %v", i, i, i == 3, got, newlyLocked)
+		}
+	}
+
+	n, last, locked, err = sut.LoginFailures(ctx, key, window, now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if n != 4 || !last.Equal(now.Add(4*time.Minute)) {
+		t.Errorf("want 4 failures until %v, but got %d until %v", now.Add(4*time.Minute), n, last)
+	}
+	if locked <= 0 || locked > time.Hour {
+		t.Errorf("want locked within an hour, but got %v", locked)
+	}
+
+	// ウィンドウから外れた失敗は数えない
+	n, _, _, err = sut.LoginFailures(ctx, key, window, now.Add(17*time.Minute+30*time.Second))
+	if err != nil || n != 2 {
+		t.Errorf("want 2 failures in the window, but got %d, %v", n, err)
+	}
+
+	if err := sut.ClearLoginFailures(ctx, key); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	n, _, _, err = sut.LoginFailures(ctx, key, window, now.Add(5*time.Minute))
+	if err != nil || n != 0 {
+		t.Errorf("want no failures after clear, but got %d, %v", n, err)
+	}
+}
+
+func TestKVS_ReserveLoginAttempt(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	key := "TestKVS_ReserveLoginAttempt"
+	t.Cleanup(func() { client.Del(ctx, loginFailuresKey(key), loginLockKey(key)) })
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	window := 15 * time.Minute
+	// 1回目の失敗のあとは待たず、2回目から1秒、2秒待つ
+	delays := []time.Duration{0, time.Second, 2 * time.Second}
+	reserve := func(at time.Time) time.Duration {
+		t.Helper()
+		wait, err := sut.ReserveLoginAttempt(ctx, key, window, at, delays, 4, time.Hour)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		return wait
+	}
+
+	if wait := reserve(now); wait != 0 {
+		t.Fatalf("want the first attempt reserved, but got wait %v", wait)
+	}
+	if wait := reserve(now); wait != 0 {
+		t.Fatalf("want the second attempt reserved, but got wait %v", wait)
+	}
+	// 試行は確かめた時点で失敗として数える
+	if wait := reserve(now.Add(300 * time.Millisecond)); wait != 700*time.Millisecond {
+		t.Errorf("want wait 700ms after 2 attempts, but got %v", wait)
+	}
+	if wait := reserve(now.Add(time.Second)); wait != 0 {
+		t.Fatalf("want the third attempt reserved, but got wait %v", wait)
+	}
+	if _, _, ok, err := sut.TakeLoginLockout(ctx, key); err != nil || ok {
+		t.Errorf("want no lockout before the limit, but got %v, %v", ok, err)
+	}
+
+	// 4回目で上限に達するので、その試行を通してからロックする
+	if wait := reserve(now.Add(3 * time.Second)); wait != 0 {
+		t.Fatalf("want the fourth attempt reserved, but got wait %v", wait)
+	}
+	if wait := reserve(now.Add(10 * time.Second)); wait <= 0 || wait > time.Hour {
+		t.Errorf("want locked within an hour, but got wait %v", wait)
+	}
+	n, locked, ok, err := sut.TakeLoginLockout(ctx, key)
+	if err != nil || !ok || n != 4 || locked <= 0 || locked > time.Hour {
+		t.Errorf("want lockout after 4 attempts within an hour, but got %d, %v, %v, %v", n, locked, ok, err)
+	}
+	// ロックは1度だけ返す
+	if _, _, ok, err := sut.TakeLoginLockout(ctx, key); err != nil || ok {
+		t.Errorf("want the lockout taken only once, but got %v, %v", ok, err)
+	}
+
+	// 成功すれば失敗とロックを消す
+	if err := sut.ClearLoginFailures(ctx, key); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if wait := reserve(now.Add(11 * time.Second)); wait != 0 {
+		t.Errorf("want reserved after clear, but got wait %v", wait)
+	}
+}
+
+// TestKVS_ReserveLoginAttempt_Concurrent は同時に送られた試行のうち、待つ時間をすり抜けるのが1つだけなことを確かめる
+func TestKVS_ReserveLoginAttempt_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	key := "TestKVS_ReserveLoginAttempt_Concurrent"
+	t.Cleanup(func() { client.Del(ctx, loginFailuresKey(key), loginLockKey(key)) })
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	delays := []time.Duration{time.Second}
+	const attempts = 20
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int32
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait, err := sut.ReserveLoginAttempt(ctx, key, 15*time.Minute, now, delays, 0, time.Hour)
+			if err != nil {
+				t.Errorf("want no error, but got %v", err)
+				return
+			}
+			if wait == 0 {
+				reserved.Add(1)
+			} else if wait != time.Second {
+				t.Errorf("want wait 1s, but got %v", wait)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := reserved.Load(); got != 1 {
+		t.Errorf("want only 1 of %d concurrent attempts reserved, but got %d", attempts, got)
+	}
+}
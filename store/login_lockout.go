@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// AddLoginLockout はログインをロックした記録を残す
+func (r *Repository) AddLoginLockout(ctx context.Context, db Execer, l *entity.LoginLockout) error {
+	l.CreatedAt = r.Clocker.Now()
+	query := `INSERT INTO login_lockouts (scope, subject, failures, locked_until, created_at) VALUES (?, ?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, l.Scope, l.Subject, l.Failures, l.LockedUntil, l.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	l.ID = entity.LoginLockoutID(id)
+	return nil
+}
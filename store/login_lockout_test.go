@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_AddLoginLockout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	c := clock.FixedClocker{}
+	sut := &Repository{Clocker: c}
+	l := &entity.LoginLockout{
+		Scope:       entity.LoginLockoutScopeIP,
+		Subject:     "192.0.2.1",
+		Failures:    100,
+		LockedUntil: c.Now().Add(15 * time.Minute),
+	}
+	if err := sut.AddLoginLockout(ctx, tx, l); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if l.ID == 0 {
+		t.Fatal("want the id to be set")
+	}
+
+	got := &entity.LoginLockout{}
+	query := `SELECT id, scope, subject, failures, locked_until, created_at FROM login_lockouts WHERE id = ?;`
+	if err := tx.GetContext(ctx, got, query, l.ID); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(l, got); diff != "" {
+		t.Errorf("lockout (-want +got):\n%s", diff)
+	}
+}
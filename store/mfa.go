@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// GetUserMFA はユーザーのTOTPの設定を返す。登録していなければErrNotFoundを返す
+func (r *Repository) GetUserMFA(ctx context.Context, db Queryer, userID entity.UserID) (*entity.UserMFA, error) {
+	m := &entity.UserMFA{}
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at, modified_at
+		FROM user_mfa WHERE user_id = ?;`
+	if err := db.GetContext(ctx, m, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// SaveUserMFA は確認前のTOTPのシークレットを保存する。登録の途中のシークレットがあれば置き換える
+func (r *Repository) SaveUserMFA(ctx context.Context, db Execer, m *entity.UserMFA) error {
+	m.ConfirmedAt = nil
+	m.LastUsedStep = 0
+	m.CreatedAt = r.Clocker.Now()
+	m.ModifiedAt = r.Clocker.Now()
+	query := `INSERT INTO user_mfa (user_id, secret, confirmed_at, last_used_step, created_at, modified_at)
+		VALUES (?, ?, NULL, 0, ?, ?)
+		ON DUPLICATE KEY UPDATE secret = VALUES(secret), confirmed_at = NULL, last_used_step = 0, modified_at = VALUES(modified_at);`
+	_, err := db.ExecContext(ctx, query, m.UserID, m.Secret, m.CreatedAt, m.ModifiedAt)
+	return err
+}
+
+// ConfirmUserMFA は登録の途中のTOTPを確認済みにし、確認に使ったコードのタイムステップを記録する。
+// 登録の途中のシークレットがなければErrNotFoundを返す
+func (r *Repository) ConfirmUserMFA(ctx context.Context, db Execer, userID entity.UserID, step int64) error {
+	now := r.Clocker.Now()
+	query := `UPDATE user_mfa SET confirmed_at = ?, last_used_step = ?, modified_at = ?
+		WHERE user_id = ? AND confirmed_at IS NULL;`
+	result, err := db.ExecContext(ctx, query, now, step, now, userID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("pending mfa of user %d: %w", userID, ErrNotFound)
+	}
+	return nil
+}
+
+// UseTOTPStep は受け付けたコードのタイムステップを記録する。
+// そのステップか、より後のステップのコードを既に受け付けていればErrNotFoundを返す
+func (r *Repository) UseTOTPStep(ctx context.Context, db Execer, userID entity.UserID, step int64) error {
+	query := `UPDATE user_mfa SET last_used_step = ?, modified_at = ?
+		WHERE user_id = ? AND confirmed_at IS NOT NULL AND last_used_step < ?;`
+	result, err := db.ExecContext(ctx, query, step, r.Clocker.Now(), userID, step)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("totp step %d of user %d: %w", step, userID, ErrNotFound)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes はユーザーのリカバリーコードを消し、ハッシュにしたコードで置き換える
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, db Execer, userID entity.UserID, hashes []string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?;`, userID); err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	query := `INSERT INTO mfa_recovery_codes (user_id, code_hash, created_at) VALUES (?, ?, ?);`
+	for _, h := range hashes {
+		if _, err := db.ExecContext(ctx, query, userID, h, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode はハッシュが一致する未使用のリカバリーコードを使用済みにする。なければErrNotFoundを返す
+func (r *Repository) UseRecoveryCode(ctx context.Context, db Execer, userID entity.UserID, hash string) error {
+	query := `UPDATE mfa_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL;`
+	result, err := db.ExecContext(ctx, query, r.Clocker.Now(), userID, hash)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("recovery code of user %d: %w", userID, ErrNotFound)
+	}
+	return nil
+}
+
+// IsMFARequired はロールで2要素認証が必須か返す
+func (r *Repository) IsMFARequired(ctx context.Context, db Queryer, role string) (bool, error) {
+	var required bool
+	if err := db.GetContext(ctx, &required, `SELECT EXISTS(SELECT 1 FROM mfa_required_roles WHERE role = ?);`, role); err != nil {
+		return false, err
+	}
+	return required, nil
+}
+
+// ListMFARequiredRoles は2要素認証が必須のロールを名前の順に返す
+func (r *Repository) ListMFARequiredRoles(ctx context.Context, db Queryer) ([]string, error) {
+	roles := []string{}
+	if err := db.SelectContext(ctx, &roles, `SELECT role FROM mfa_required_roles ORDER BY role;`); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// SetMFARequiredRoles は2要素認証が必須のロールをrolesで置き換える
+func (r *Repository) SetMFARequiredRoles(ctx context.Context, db Execer, roles []string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM mfa_required_roles;`); err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	for _, role := range roles {
+		if _, err := db.ExecContext(ctx, `INSERT INTO mfa_required_roles (role, created_at) VALUES (?, ?);`, role, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func mfaChallengeKey(key string) string {
+	return "mfa_challenge:" + key
+}
+
+// SaveMFAChallenge はパスワードを確かめたユーザーの2要素認証の待ちをttlの間保存する。
+// keyは生のトークンではなくハッシュを渡す
+func (kvs *KVS) SaveMFAChallenge(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	k := mfaChallengeKey(key)
+	pipe := kvs.Cli.TxPipeline()
+	pipe.HSet(ctx, k, "user_id", userID, "attempts", 0)
+	pipe.Expire(ctx, k, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// LoadMFAChallenge は試行回数を数えずに2要素認証を待っているユーザーを返す
+func (kvs *KVS) LoadMFAChallenge(ctx context.Context, key string) (entity.UserID, error) {
+	uid, err := kvs.Cli.HGet(ctx, mfaChallengeKey(key), "user_id").Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("failed to get mfa challenge: %w", ErrNotFound)
+		}
+		return 0, err
+	}
+	var userID entity.UserID
+	if err := userID.UnmarshalBinary([]byte(uid)); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// attemptMFAChallenge は試行回数を増やし、ユーザーと試行回数を返す
+var attemptMFAChallenge = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+local attempts = redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+return {redis.call('HGET', KEYS[1], 'user_id'), attempts}
+`)
+
+// AttemptMFAChallenge はコードを確かめる前に呼び、試行回数を増やして2要素認証を待っているユーザーと試行回数を返す。
+// 同時に試されても試行回数は1回ずつ増える
+func (kvs *KVS) AttemptMFAChallenge(ctx context.Context, key string) (entity.UserID, int64, error) {
+	v, err := attemptMFAChallenge.Run(ctx, kvs.Cli, []string{mfaChallengeKey(key)}).Slice()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, 0, fmt.Errorf("failed to attempt mfa challenge: %w", ErrNotFound)
+		}
+		return 0, 0, err
+	}
+	if len(v) != 2 {
+		return 0, 0, fmt.Errorf("unexpected mfa challenge record: %v", v)
+	}
+	var userID entity.UserID
+	uid, _ := v[0].(string)
+	if err := userID.UnmarshalBinary([]byte(uid)); err != nil {
+		return 0, 0, err
+	}
+	attempts, _ := v[1].(int64)
+	return userID, attempts, nil
+}
+
+// DeleteMFAChallenge は2要素認証の待ちを削除する。成功したときや試行回数が上限を超えたときに呼ぶ
+func (kvs *KVS) DeleteMFAChallenge(ctx context.Context, key string) error {
+	return kvs.Cli.Del(ctx, mfaChallengeKey(key)).Err()
+}
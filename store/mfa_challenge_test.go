@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_MFAChallenge(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	key := "TestKVS_MFAChallenge"
+	t.Cleanup(func() { client.Del(ctx, mfaChallengeKey(key)) })
+
+	if err := sut.SaveMFAChallenge(ctx, key, entity.UserID(7), time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if ttl := client.TTL(ctx, mfaChallengeKey(key)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("want ttl within a minute, but got %v", ttl)
+	}
+
+	// 読むだけでは試行回数は増えない
+	got, err := sut.LoadMFAChallenge(ctx, key)
+	if err != nil || got != 7 {
+		t.Fatalf("want user 7, but got %d, %v", got, err)
+	}
+	for want := int64(1); want <= 2; want++ {
+		got, attempts, err := sut.AttemptMFAChallenge(ctx, key)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if got != 7 || attempts != want {
+			t.Errorf("want user 7 and attempts %d, but got %d and %d", want, got, attempts)
+		}
+	}
+
+	if err := sut.DeleteMFAChallenge(ctx, key); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.LoadMFAChallenge(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound after delete, but got %v", err)
+	}
+	if _, _, err := sut.AttemptMFAChallenge(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound after delete, but got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_UserMFA(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+
+	if _, err := sut.GetUserMFA(ctx, tx, userID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, but got %v", err)
+	}
+	if err := sut.SaveUserMFA(ctx, tx, &entity.UserMFA{UserID: userID, Secret: "OLD"}); err != nil {
+		t.Fatalf("failed to save mfa: %s", err)
+	}
+	// 登録をやり直すとシークレットを置き換える
+	if err := sut.SaveUserMFA(ctx, tx, &entity.UserMFA{UserID: userID, Secret: "NEW"}); err != nil {
+		t.Fatalf("failed to save mfa again: %s", err)
+	}
+	got, err := sut.GetUserMFA(ctx, tx, userID)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got.Secret != "NEW" || got.Enabled() {
+		t.Errorf("want pending mfa with new secret, but got %+v", got)
+	}
+	// 確認前のコードは受け付けない
+	if err := sut.UseTOTPStep(ctx, tx, userID, 10); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound before confirmation, but got %v", err)
+	}
+
+	if err := sut.ConfirmUserMFA(ctx, tx, userID, 10); err != nil {
+		t.Fatalf("failed to confirm: %s", err)
+	}
+	if err := sut.ConfirmUserMFA(ctx, tx, userID, 11); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound on second confirmation, but got %v", err)
+	}
+	got, err = sut.GetUserMFA(ctx, tx, userID)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if !got.Enabled() || got.LastUsedStep != 10 {
+		t.Errorf("want confirmed mfa at step 10, but got %+v", got)
+	}
+
+	for step, wantErr := range map[int64]bool{10: true, 9: true, 11: false} {
+		err := sut.UseTOTPStep(ctx, tx, userID, step)
+		if wantErr != errors.Is(err, ErrNotFound) {
+			t.Errorf("step %d: want ErrNotFound %v, but got %v", step, wantErr, err)
+		}
+	}
+}
+
+func TestRepository_RecoveryCodes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	other := prepareUser(ctx, t, tx)
+
+	if err := sut.ReplaceRecoveryCodes(ctx, tx, userID, []string{"old"}); err != nil {
+		t.Fatalf("failed to save codes: %s", err)
+	}
+	if err := sut.ReplaceRecoveryCodes(ctx, tx, userID, []string{"a", "b"}); err != nil {
+		t.Fatalf("failed to replace codes: %s", err)
+	}
+	if err := sut.UseRecoveryCode(ctx, tx, userID, "old"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for replaced code, but got %v", err)
+	}
+	if err := sut.UseRecoveryCode(ctx, tx, other, "a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for another user, but got %v", err)
+	}
+	if err := sut.UseRecoveryCode(ctx, tx, userID, "a"); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+	if err := sut.UseRecoveryCode(ctx, tx, userID, "a"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for used code, but got %v", err)
+	}
+	if err := sut.UseRecoveryCode(ctx, tx, userID, "b"); err != nil {
+		t.Errorf("want no error, but got %v", err)
+	}
+}
+
+func TestRepository_MFARequiredRoles(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	if err := sut.SetMFARequiredRoles(ctx, tx, []string{"user", "admin"}); err != nil {
+		t.Fatalf("failed to set roles: %s", err)
+	}
+	if err := sut.SetMFARequiredRoles(ctx, tx, []string{"admin"}); err != nil {
+		t.Fatalf("failed to replace roles: %s", err)
+	}
+	got, err := sut.ListMFARequiredRoles(ctx, tx)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if diff := cmp.Diff([]string{"admin"}, got); diff != "" {
+		t.Errorf("roles (-want +got):\n%s", diff)
+	}
+	for role, want := range map[string]bool{"admin": true, "user": false} {
+		required, err := sut.IsMFARequired(ctx, tx, role)
+		if err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+		if required != want {
+			t.Errorf("%s: want %v, but got %v", role, want, required)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// notificationListLimit は一覧で返す通知の最大件数
+const notificationListLimit = 50
+
+// 通知はユーザー個人のものなので、ワークスペースでは絞り込まない
+
+// AddNotification は通知を登録する
+func (r *Repository) AddNotification(
+	ctx context.Context, db Execer, n *entity.Notification,
+) error {
+	n.CreatedAt = r.Clocker.Now()
+	query := `INSERT INTO notifications (user_id, type, task_id, message, created_at) VALUES (?, ?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, n.UserID, n.Type, n.TaskID, n.Message, n.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	n.ID = entity.NotificationID(id)
+	return nil
+}
+
+// ListNotifications はユーザーの通知を新しい順に返す。unreadOnlyなら未読のものだけを返す
+func (r *Repository) ListNotifications(
+	ctx context.Context, db Queryer, userID entity.UserID, unreadOnly bool,
+) (entity.Notifications, error) {
+	notifications := entity.Notifications{}
+	query := `SELECT id, user_id, type, task_id, message, read_at, created_at
+		FROM notifications
+		WHERE user_id = ? AND (? = FALSE OR read_at IS NULL)
+		ORDER BY id DESC
+		LIMIT ?;`
+	if err := db.SelectContext(ctx, &notifications, query, userID, unreadOnly, notificationListLimit); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnreadNotifications はユーザーの未読の通知の数を返す
+func (r *Repository) CountUnreadNotifications(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (int, error) {
+	var n int
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL;`
+	if err := db.GetContext(ctx, &n, query, userID); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// MarkNotificationRead はユーザーの通知を既読にする。既に既読なら既読にした日時は変えない。
+// 既読の通知を更新しても変更した行の数は0なので、通知があるかは別に確かめる
+func (r *Repository) MarkNotificationRead(
+	ctx context.Context, db ExecQueryer, userID entity.UserID, id entity.NotificationID,
+) error {
+	query := `UPDATE notifications SET read_at = ? WHERE id = ? AND user_id = ? AND read_at IS NULL;`
+	result, err := db.ExecContext(ctx, query, r.Clocker.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	var found int
+	query = `SELECT 1 FROM notifications WHERE id = ? AND user_id = ?;`
+	if err := db.GetContext(ctx, &found, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("notification %d: %w", id, ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead はユーザーの未読の通知をすべて既読にし、既読にした件数を返す
+func (r *Repository) MarkAllNotificationsRead(
+	ctx context.Context, db Execer, userID entity.UserID,
+) (int64, error) {
+	query := `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL;`
+	result, err := db.ExecContext(ctx, query, r.Clocker.Now(), userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ListNotificationPreferences はユーザーが保存した通知の設定を返す。保存していない種類は含まない
+func (r *Repository) ListNotificationPreferences(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) ([]*entity.NotificationPreference, error) {
+	prefs := []*entity.NotificationPreference{}
+	query := `SELECT type, enabled FROM notification_preferences WHERE user_id = ? ORDER BY type;`
+	if err := db.SelectContext(ctx, &prefs, query, userID); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SaveNotificationPreference は通知の設定を保存する。既に設定があれば上書きする
+func (r *Repository) SaveNotificationPreference(
+	ctx context.Context, db Execer, userID entity.UserID, p *entity.NotificationPreference,
+) error {
+	query := `INSERT INTO notification_preferences (user_id, type, enabled, modified_at) VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE enabled = VALUES(enabled), modified_at = VALUES(modified_at);`
+	_, err := db.ExecContext(ctx, query, userID, p.Type, p.Enabled, r.Clocker.Now())
+	return err
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_Notifications(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	otherID := prepareUser(ctx, t, tx)
+
+	var ids []entity.NotificationID
+	for _, n := range []*entity.Notification{
+		{UserID: userID, Type: entity.NotificationTypeTaskAssigned, Message: "first"},
+		{UserID: userID, Type: entity.NotificationTypeDueReminder, Message: "second"},
+		{UserID: otherID, Type: entity.NotificationTypeTaskAssigned, Message: "other user"},
+	} {
+		if err := sut.AddNotification(ctx, tx, n); err != nil {
+			t.Fatalf("failed to add notification: %s", err)
+		}
+		ids = append(ids, n.ID)
+	}
+
+	if err := sut.MarkNotificationRead(ctx, tx, userID, ids[0]); err != nil {
+		t.Fatalf("failed to mark read: %s", err)
+	}
+	// 既読の通知をもう一度既読にしてもエラーにしない
+	if err := sut.MarkNotificationRead(ctx, tx, userID, ids[0]); err != nil {
+		t.Errorf("MarkNotificationRead() of a read notification: want no error, but got %v", err)
+	}
+	if err := sut.MarkNotificationRead(ctx, tx, userID, ids[2]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("MarkNotificationRead() of other user's notification: want %v, but got %v", ErrNotFound, err)
+	}
+
+	all, err := sut.ListNotifications(ctx, tx, userID, false)
+	if err != nil {
+		t.Fatalf("failed to list notifications: %s", err)
+	}
+	if got := notificationIDs(all); !cmp.Equal(got, []entity.NotificationID{ids[1], ids[0]}) {
+		t.Errorf("ListNotifications() = %v, want newest first [%d %d]", got, ids[1], ids[0])
+	}
+	if all[1].ReadAt == nil || all[0].ReadAt != nil {
+		t.Errorf("read_at = %v, %v, want only the first notification read", all[1].ReadAt, all[0].ReadAt)
+	}
+	unread, err := sut.ListNotifications(ctx, tx, userID, true)
+	if err != nil {
+		t.Fatalf("failed to list notifications: %s", err)
+	}
+	if got := notificationIDs(unread); !cmp.Equal(got, []entity.NotificationID{ids[1]}) {
+		t.Errorf("ListNotifications(unreadOnly) = %v, want [%d]", got, ids[1])
+	}
+
+	n, err := sut.MarkAllNotificationsRead(ctx, tx, userID)
+	if err != nil || n != 1 {
+		t.Errorf("MarkAllNotificationsRead() = %d, %v, want 1", n, err)
+	}
+	count, err := sut.CountUnreadNotifications(ctx, tx, userID)
+	if err != nil || count != 0 {
+		t.Errorf("CountUnreadNotifications() = %d, %v, want 0", count, err)
+	}
+	count, err = sut.CountUnreadNotifications(ctx, tx, otherID)
+	if err != nil || count != 1 {
+		t.Errorf("CountUnreadNotifications() of other user = %d, %v, want 1", count, err)
+	}
+}
+
+// TestRepository_MarkNotificationRead_AlreadyRead はclientFoundRowsなしのMySQLのように、
+// 既読の通知の更新で変更した行の数が0でも、通知があればエラーにしないことを確かめる
+func TestRepository_MarkNotificationRead_AlreadyRead(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		exists  bool
+		wantErr error
+	}{
+		"already read": {exists: true},
+		"not found":    {wantErr: ErrNotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+			c := clock.FixedClocker{}
+			mock.ExpectExec("UPDATE notifications SET read_at").
+				WithArgs(c.Now(), 7, 1).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			rows := sqlmock.NewRows([]string{"1"})
+			if tt.exists {
+				rows.AddRow(1)
+			}
+			mock.ExpectQuery("SELECT 1 FROM notifications").WithArgs(7, 1).WillReturnRows(rows)
+
+			sut := &Repository{Clocker: c}
+			err = sut.MarkNotificationRead(context.Background(), sqlx.NewDb(db, "mysql"), 1, 7)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRepository_NotificationPreferences(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+
+	for _, p := range []*entity.NotificationPreference{
+		{Type: entity.NotificationTypeDueReminder, Enabled: false},
+		{Type: entity.NotificationTypeTaskAssigned, Enabled: false},
+		{Type: entity.NotificationTypeTaskAssigned, Enabled: true},
+	} {
+		if err := sut.SaveNotificationPreference(ctx, tx, userID, p); err != nil {
+			t.Fatalf("failed to save preference: %s", err)
+		}
+	}
+
+	got, err := sut.ListNotificationPreferences(ctx, tx, userID)
+	if err != nil {
+		t.Fatalf("failed to list preferences: %s", err)
+	}
+	want := []*entity.NotificationPreference{
+		{Type: entity.NotificationTypeDueReminder, Enabled: false},
+		{Type: entity.NotificationTypeTaskAssigned, Enabled: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListNotificationPreferences() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func notificationIDs(ns entity.Notifications) []entity.NotificationID {
+	ids := make([]entity.NotificationID, 0, len(ns))
+	for _, n := range ns {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
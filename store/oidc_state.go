@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func oidcStateKey(state string) string {
+	return "oidc_state:" + state
+}
+
+// SaveOIDCState はログインのstateに対応する値をttlの間保存する
+func (kvs *KVS) SaveOIDCState(ctx context.Context, state string, s *entity.OIDCState, ttl time.Duration) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal oidc state: %w", err)
+	}
+	return kvs.Cli.Set(ctx, oidcStateKey(state), b, ttl).Err()
+}
+
+// TakeOIDCState はstateに対応する値を削除して返す。同じstateは2度使えない
+func (kvs *KVS) TakeOIDCState(ctx context.Context, state string) (*entity.OIDCState, error) {
+	b, err := kvs.Cli.GetDel(ctx, oidcStateKey(state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to get oidc state: %w", ErrNotFound)
+		}
+		return nil, err
+	}
+	s := &entity.OIDCState{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oidc state: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_OIDCState(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	state := "TestKVS_OIDCState"
+	t.Cleanup(func() { client.Del(ctx, oidcStateKey(state)) })
+
+	want := &entity.OIDCState{Nonce: "nonce", CodeVerifier: "verifier"}
+	if err := sut.SaveOIDCState(ctx, state, want, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if ttl := client.TTL(ctx, oidcStateKey(state)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("want ttl within a minute, but got %v", ttl)
+	}
+
+	got, err := sut.TakeOIDCState(ctx, state)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("take (-want +got):\n%s", diff)
+	}
+	if _, err := sut.TakeOIDCState(ctx, state); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound on second take, but got %v", err)
+	}
+}
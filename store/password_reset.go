@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func passwordResetKey(key string) string {
+	return "password_reset:" + key
+}
+
+// userPasswordResetKey はユーザーの有効な再設定トークンのキー。新しいトークンを発行したら古いトークンを消すのに使う
+func userPasswordResetKey(userID entity.UserID) string {
+	return fmt.Sprintf("user_password_reset:%d", userID)
+}
+
+// savePasswordReset はトークンをttlの間保存し、同じユーザーの前のトークンを消す
+var savePasswordReset = redis.NewScript(`
+local old = redis.call('GET', KEYS[2])
+if old then
+	redis.call('DEL', ARGV[3] .. old)
+end
+redis.call('SET', ARGV[3] .. ARGV[1], ARGV[2], 'PX', ARGV[4])
+redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[4])
+return 1
+`)
+
+// SavePasswordResetToken はパスワードの再設定トークンをttlの間保存する。keyは生のトークンではなくハッシュを渡す。
+// ユーザーごとに有効なトークンはひとつだけで、前に発行したトークンは使えなくなる
+func (kvs *KVS) SavePasswordResetToken(ctx context.Context, key string, userID entity.UserID, ttl time.Duration) error {
+	return savePasswordReset.Run(ctx, kvs.Cli,
+		[]string{passwordResetKey(key), userPasswordResetKey(userID)},
+		key, userID, passwordResetKey(""), ttl.Milliseconds(),
+	).Err()
+}
+
+// TakePasswordResetToken はトークンを削除してユーザーを返す。同じトークンは2度使えない
+func (kvs *KVS) TakePasswordResetToken(ctx context.Context, key string) (entity.UserID, error) {
+	b, err := kvs.Cli.GetDel(ctx, passwordResetKey(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("failed to get password reset token: %w", ErrNotFound)
+		}
+		return 0, err
+	}
+	var userID entity.UserID
+	if err := userID.UnmarshalBinary(b); err != nil {
+		return 0, err
+	}
+	if err := kvs.Cli.Del(ctx, userPasswordResetKey(userID)).Err(); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_PasswordResetToken(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	userID := entity.UserID(9001)
+	first, second := "TestKVS_PasswordResetToken_1", "TestKVS_PasswordResetToken_2"
+	t.Cleanup(func() {
+		client.Del(ctx, passwordResetKey(first), passwordResetKey(second), userPasswordResetKey(userID))
+	})
+
+	if err := sut.SavePasswordResetToken(ctx, first, userID, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if ttl := client.TTL(ctx, passwordResetKey(first)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("want ttl within a minute, but got %v", ttl)
+	}
+	// 新しいトークンを発行すると前のトークンは使えない
+	if err := sut.SavePasswordResetToken(ctx, second, userID, time.Minute); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if _, err := sut.TakePasswordResetToken(ctx, first); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for the replaced token, but got %v", err)
+	}
+
+	got, err := sut.TakePasswordResetToken(ctx, second)
+	if err != nil || got != userID {
+		t.Fatalf("want user %d, but got %d, %v", userID, got, err)
+	}
+	if _, err := sut.TakePasswordResetToken(ctx, second); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for the used token, but got %v", err)
+	}
+	if n := client.Exists(ctx, userPasswordResetKey(userID)).Val(); n != 0 {
+		t.Errorf("want the user key to be deleted")
+	}
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// AddProject はコンテキストのワークスペースにプロジェクトを登録する
+func (r *Repository) AddProject(
+	ctx context.Context, db Execer, p *entity.Project,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	p.WorkspaceID = wsID
+	p.CreatedAt = r.Clocker.Now()
+	p.ModifiedAt = r.Clocker.Now()
+
+	query := `INSERT INTO projects (workspace_id, name, created_at, modified_at) VALUES (?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, p.WorkspaceID, p.Name, p.CreatedAt, p.ModifiedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("cannot create same name project: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	p.ID = entity.ProjectID(id)
+	return nil
+}
+
+func (r *Repository) ListProjects(
+	ctx context.Context, db Queryer,
+) (entity.Projects, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects := entity.Projects{}
+	query := `SELECT id, workspace_id, name, created_at, modified_at
+		FROM projects
+		WHERE workspace_id = ?
+		ORDER BY id;`
+	if err := db.SelectContext(ctx, &projects, query, wsID); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// GetProject はコンテキストのワークスペースに属するプロジェクトを取得する
+func (r *Repository) GetProject(
+	ctx context.Context, db Queryer, id entity.ProjectID,
+) (*entity.Project, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p := &entity.Project{}
+	query := `SELECT id, workspace_id, name, created_at, modified_at
+		FROM projects
+		WHERE id = ? AND workspace_id = ?;`
+	if err := db.GetContext(ctx, p, query, id, wsID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("project %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListProjectsByID はコンテキストのワークスペースのプロジェクトのうち、idsのものをまとめて返す
+func (r *Repository) ListProjectsByID(
+	ctx context.Context, db Queryer, ids []entity.ProjectID,
+) (entity.Projects, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects := entity.Projects{}
+	if len(ids) == 0 {
+		return projects, nil
+	}
+	query, args, err := sqlx.In(`SELECT id, workspace_id, name, created_at, modified_at
+		FROM projects
+		WHERE workspace_id = ? AND id IN (?)
+		ORDER BY id;`, wsID, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SelectContext(ctx, &projects, query, args...); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
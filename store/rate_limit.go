@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func rateLimitKey(key string) string {
+	return "rate_limit:" + key
+}
+
+// takeRateLimit はスライディングウィンドウで試行を数える。
+// ウィンドウ内の試行がlimit未満なら記録して0を返し、そうでなければ記録せずに次に試せるまでのミリ秒を返す
+var takeRateLimit = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local n = redis.call('ZCARD', KEYS[1])
+if n >= limit then
+	local oldest = redis.call('ZRANGE', KEYS[1], n - limit, n - limit, 'WITHSCORES')
+	return tonumber(oldest[2]) + window - now
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], window)
+return 0
+`)
+
+// TakeRateLimit はkeyの試行をnowに記録する。直前のwindowの間にlimit回試行していれば記録せず、
+// 次に試せるまでの時間を返す。試せるなら0を返す
+func (kvs *KVS) TakeRateLimit(ctx context.Context, key string, limit int64, window time.Duration, now time.Time) (time.Duration, error) {
+	// 同じ時刻の試行も別々に数えるよう、メンバーに乱数を付ける
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+	member := fmt.Sprintf("%d-%s", now.UnixMilli(), hex.EncodeToString(b))
+	ms, err := takeRateLimit.Run(ctx, kvs.Cli, []string{rateLimitKey(key)},
+		now.UnixMilli(), window.Milliseconds(), limit, member,
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_TakeRateLimit(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	key := "TestKVS_TakeRateLimit"
+	t.Cleanup(func() { client.Del(ctx, rateLimitKey(key)) })
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	window := time.Minute
+	hits := []struct {
+		at   time.Duration
+		want time.Duration
+	}{
+		{at: 0, want: 0},
+		{at: 10 * time.Second, want: 0},
+		{at: 20 * time.Second, want: 0},
+		// 3回目までで上限。最初の試行がウィンドウから外れるまで待つ
+		{at: 30 * time.Second, want: 30 * time.Second},
+		{at: 50 * time.Second, want: 10 * time.Second},
+		// 最初の試行が外れたので、1回だけ試せる
+		{at: 61 * time.Second, want: 0},
+		{at: 62 * time.Second, want: 8 * time.Second},
+	}
+	for _, h := range hits {
+		got, err := sut.TakeRateLimit(ctx, key, 3, window, now.Add(h.at))
+		if err != nil {
+			t.Fatalf("at %v: want no error, but got %v", h.at, err)
+		}
+		if got != h.want {
+			t.Errorf("at %v: want retry after %v, but got %v", h.at, h.want, got)
+		}
+	}
+}
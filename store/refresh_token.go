@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func refreshTokenKey(key string) string {
+	return "refresh_token:" + key
+}
+
+func refreshTokenFamilyKey(family string) string {
+	return "refresh_token_family:" + family
+}
+
+// userRefreshTokenFamiliesKey はユーザーのFamilyの集合のキー
+func userRefreshTokenFamiliesKey(userID entity.UserID) string {
+	return fmt.Sprintf("user_refresh_token_families:%d", userID)
+}
+
+// SaveRefreshToken はリフレッシュトークンをttlの間保存し、Familyのトークンの一覧とユーザーのFamilyの一覧に加える。
+// keyは生のトークンではなくハッシュを渡す
+func (kvs *KVS) SaveRefreshToken(ctx context.Context, key string, token *entity.RefreshToken, ttl time.Duration) error {
+	k := refreshTokenKey(key)
+	fk := refreshTokenFamilyKey(token.Family)
+	pipe := kvs.Cli.TxPipeline()
+	pipe.HSet(ctx, k, "user_id", token.UserID, "family", token.Family)
+	pipe.Expire(ctx, k, ttl)
+	pipe.SAdd(ctx, fk, key)
+	pipe.Expire(ctx, fk, ttl)
+	uk := userRefreshTokenFamiliesKey(token.UserID)
+	pipe.SAdd(ctx, uk, token.Family)
+	pipe.Expire(ctx, uk, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// LoadRefreshToken は使用済みにせずにリフレッシュトークンを返す
+func (kvs *KVS) LoadRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error) {
+	v, err := kvs.Cli.HGetAll(ctx, refreshTokenKey(key)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, ErrNotFound
+	}
+	var userID entity.UserID
+	if err := userID.UnmarshalBinary([]byte(v["user_id"])); err != nil {
+		return nil, err
+	}
+	return &entity.RefreshToken{UserID: userID, Family: v["family"], Used: v["used"] != ""}, nil
+}
+
+// useRefreshToken はトークンを使用済みにし、使用回数とともに内容を返す
+var useRefreshToken = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return false
+end
+local used = redis.call('HINCRBY', KEYS[1], 'used', 1)
+return {redis.call('HGET', KEYS[1], 'user_id'), redis.call('HGET', KEYS[1], 'family'), used}
+`)
+
+// UseRefreshToken はリフレッシュトークンを使用済みにして返す。
+// 同時に使われても使用済みでないと判定されるのは1回だけで、2回目以降はUsedがtrueになる
+func (kvs *KVS) UseRefreshToken(ctx context.Context, key string) (*entity.RefreshToken, error) {
+	v, err := useRefreshToken.Run(ctx, kvs.Cli, []string{refreshTokenKey(key)}).Slice()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if len(v) != 3 {
+		return nil, fmt.Errorf("unexpected refresh token record: %v", v)
+	}
+	var userID entity.UserID
+	uid, _ := v[0].(string)
+	if err := userID.UnmarshalBinary([]byte(uid)); err != nil {
+		return nil, err
+	}
+	family, _ := v[1].(string)
+	used, _ := v[2].(int64)
+	return &entity.RefreshToken{UserID: userID, Family: family, Used: used > 1}, nil
+}
+
+// RevokeRefreshTokenFamily はFamilyのリフレッシュトークンをすべて削除する
+func (kvs *KVS) RevokeRefreshTokenFamily(ctx context.Context, family string) error {
+	fk := refreshTokenFamilyKey(family)
+	members, err := kvs.Cli.SMembers(ctx, fk).Result()
+	if err != nil {
+		return err
+	}
+	keys := []string{fk}
+	for _, m := range members {
+		keys = append(keys, refreshTokenKey(m))
+	}
+	return kvs.Cli.Del(ctx, keys...).Err()
+}
+
+// RevokeUserRefreshTokens はユーザーのすべてのFamilyのリフレッシュトークンを削除する
+func (kvs *KVS) RevokeUserRefreshTokens(ctx context.Context, userID entity.UserID) error {
+	uk := userRefreshTokenFamiliesKey(userID)
+	families, err := kvs.Cli.SMembers(ctx, uk).Result()
+	if err != nil {
+		return err
+	}
+	for _, f := range families {
+		if err := kvs.RevokeRefreshTokenFamily(ctx, f); err != nil {
+			return err
+		}
+	}
+	return kvs.Cli.Del(ctx, uk).Err()
+}
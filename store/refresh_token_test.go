@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestKVS_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	family := "TestKVS_RefreshToken"
+	first, second := family+"_first", family+"_second"
+	t.Cleanup(func() {
+		client.Del(ctx, refreshTokenKey(first), refreshTokenKey(second), refreshTokenFamilyKey(family), userRefreshTokenFamiliesKey(3))
+	})
+
+	for _, key := range []string{first, second} {
+		if err := sut.SaveRefreshToken(ctx, key, &entity.RefreshToken{UserID: 3, Family: family}, time.Minute); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+	if ttl := client.TTL(ctx, refreshTokenKey(first)).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("want ttl within a minute, but got %v", ttl)
+	}
+
+	got, err := sut.LoadRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if diff := cmp.Diff(&entity.RefreshToken{UserID: 3, Family: family}, got); diff != "" {
+		t.Errorf("load (-want +got):\n%s", diff)
+	}
+
+	got, err = sut.UseRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if diff := cmp.Diff(&entity.RefreshToken{UserID: 3, Family: family}, got); diff != "" {
+		t.Errorf("first use (-want +got):\n%s", diff)
+	}
+	got, err = sut.UseRefreshToken(ctx, first)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if !got.Used {
+		t.Errorf("want second use to be reported as used")
+	}
+
+	if err := sut.RevokeRefreshTokenFamily(ctx, family); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for _, key := range []string{first, second} {
+		if _, err := sut.UseRefreshToken(ctx, key); !errors.Is(err, ErrNotFound) {
+			t.Errorf("want ErrNotFound after revocation, but got %v", err)
+		}
+	}
+}
+
+func TestKVS_RevokeUserRefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	client := testutil.OpenRedisForTest(t)
+	sut := &KVS{Cli: client}
+	ctx := context.Background()
+
+	userID := entity.UserID(987652)
+	keys := map[string]string{"TestKVS_RevokeUserRefreshTokens_a": "TestKVS_RevokeUserRefreshTokens_family_a", "TestKVS_RevokeUserRefreshTokens_b": "TestKVS_RevokeUserRefreshTokens_family_b"}
+	t.Cleanup(func() {
+		for k, f := range keys {
+			client.Del(ctx, refreshTokenKey(k), refreshTokenFamilyKey(f))
+		}
+		client.Del(ctx, userRefreshTokenFamiliesKey(userID))
+	})
+	for k, f := range keys {
+		if err := sut.SaveRefreshToken(ctx, k, &entity.RefreshToken{UserID: userID, Family: f}, time.Minute); err != nil {
+			t.Fatalf("want no error, but got %v", err)
+		}
+	}
+
+	if err := sut.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	for k := range keys {
+		if _, err := sut.LoadRefreshToken(ctx, k); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: want ErrNotFound, but got %v", k, err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// 期限の通知はタスクのワークスペースに関係なく、通知を受け取るユーザーごとに扱う
+
+// ListReminderOffsets はユーザーが選んだ通知のタイミングを早い順に返す
+func (r *Repository) ListReminderOffsets(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) ([]entity.ReminderOffset, error) {
+	offsets := []entity.ReminderOffset{}
+	query := `SELECT offset_minutes FROM reminder_offsets WHERE user_id = ? ORDER BY offset_minutes DESC;`
+	if err := db.SelectContext(ctx, &offsets, query, userID); err != nil {
+		return nil, err
+	}
+	return offsets, nil
+}
+
+// SaveReminderOffsets はユーザーの通知のタイミングを置き換える。
+// 複数のクエリを実行するため、呼び出し側でトランザクションを張ること。
+func (r *Repository) SaveReminderOffsets(
+	ctx context.Context, db Execer, userID entity.UserID, offsets []entity.ReminderOffset,
+) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM reminder_offsets WHERE user_id = ?;`, userID); err != nil {
+		return err
+	}
+	for _, o := range offsets {
+		query := `INSERT INTO reminder_offsets (user_id, offset_minutes) VALUES (?, ?);`
+		if _, err := db.ExecContext(ctx, query, userID, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reminderTarget は通知を受け取るユーザー。担当者がいれば担当者、いなければ作成者
+const reminderTarget = `COALESCE(t.assignee_id, t.user_id)`
+
+// ScheduleReminders は期限がsinceより後の未完了のタスクについて、通知する予定を作る。
+// 実行の間隔の間やスケジューラーが止まっている間に通知の日時を過ぎた予定も作り、遅れて送る。
+// 期限や担当者が変わったタスクには新しい予定を作る。作った予定の数を返す。
+func (r *Repository) ScheduleReminders(
+	ctx context.Context, db Execer, since time.Time,
+) (int64, error) {
+	query := `INSERT IGNORE INTO task_reminders (task_id, user_id, offset_minutes, remind_at)
+		SELECT t.id, o.user_id, o.offset_minutes, DATE_SUB(t.due_at, INTERVAL o.offset_minutes MINUTE)
+		FROM tasks t
+		JOIN reminder_offsets o ON o.user_id = ` + reminderTarget + `
+		WHERE t.due_at IS NOT NULL
+			AND t.status <> 'done'
+			AND t.due_at > ?;`
+	result, err := db.ExecContext(ctx, query, since)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ClaimDueReminders はnowまでに送るべき未送信の通知を最大limit件取得し、行をロックする。
+// 他のインスタンスがロックしている通知は飛ばすので、複数のインスタンスで同時に実行できる。
+// タスクの期限・担当者・ユーザーの設定が予定を作ったときから変わっている通知は取得しない。
+// 呼び出し側でトランザクションを張ること。
+func (r *Repository) ClaimDueReminders(
+	ctx context.Context, db Queryer, now time.Time, limit int,
+) ([]*entity.Reminder, error) {
+	reminders := []*entity.Reminder{}
+	query := `SELECT r.id, r.task_id, r.user_id, r.offset_minutes, r.remind_at, r.sent_at, t.title, t.due_at
+		FROM task_reminders r
+		JOIN tasks t ON t.id = r.task_id
+		JOIN reminder_offsets o ON o.user_id = r.user_id AND o.offset_minutes = r.offset_minutes
+		WHERE r.sent_at IS NULL
+			AND r.remind_at <= ?
+			AND t.status <> 'done'
+			AND r.user_id = ` + reminderTarget + `
+			AND r.remind_at = DATE_SUB(t.due_at, INTERVAL r.offset_minutes MINUTE)
+		ORDER BY r.remind_at, r.id
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED;`
+	if err := db.SelectContext(ctx, &reminders, query, now, limit); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// MarkReminderSent は通知を送信済みにする
+func (r *Repository) MarkReminderSent(
+	ctx context.Context, db Execer, id entity.ReminderID, now time.Time,
+) error {
+	query := `UPDATE task_reminders SET sent_at = ? WHERE id = ?;`
+	_, err := db.ExecContext(ctx, query, now, id)
+	return err
+}
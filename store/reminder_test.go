@@ -0,0 +1,271 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_Reminders(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	offsets := []entity.ReminderOffset{entity.ReminderAtDue, entity.ReminderHourBefore}
+	if err := sut.SaveReminderOffsets(ctx, tx, userID, offsets); err != nil {
+		t.Fatalf("failed to save offsets: %s", err)
+	}
+	got, err := sut.ListReminderOffsets(ctx, tx, userID)
+	if err != nil {
+		t.Fatalf("failed to list offsets: %s", err)
+	}
+	if diff := cmp.Diff([]entity.ReminderOffset{entity.ReminderHourBefore, entity.ReminderAtDue}, got); diff != "" {
+		t.Errorf("ListReminderOffsets() mismatch (-want +got):\n%s", diff)
+	}
+
+	base := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	due := base.Add(2 * time.Hour)
+	task := &entity.Task{UserID: userID, Title: "report", Status: entity.TaskStatusTodo, DueAt: &due}
+	if err := sut.AddTask(ctx, tx, task); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+
+	// claimAt はtaskについて、nowに取得できる通知のオフセットを返す
+	claimAt := func(now time.Time) []entity.ReminderOffset {
+		t.Helper()
+		if _, err := sut.ScheduleReminders(ctx, tx, now); err != nil {
+			t.Fatalf("failed to schedule reminders: %s", err)
+		}
+		rs, err := sut.ClaimDueReminders(ctx, tx, now, 100)
+		if err != nil {
+			t.Fatalf("failed to claim reminders: %s", err)
+		}
+		got := []entity.ReminderOffset{}
+		for _, r := range rs {
+			if r.TaskID != task.ID {
+				continue
+			}
+			got = append(got, r.Offset)
+			if err := sut.MarkReminderSent(ctx, tx, r.ID, now); err != nil {
+				t.Fatalf("failed to mark sent: %s", err)
+			}
+		}
+		return got
+	}
+
+	steps := []struct {
+		name string
+		now  time.Time
+		due  *time.Time
+		want []entity.ReminderOffset
+	}{
+		{name: "before any reminder", now: base, want: []entity.ReminderOffset{}},
+		{name: "1 hour before", now: base.Add(time.Hour), want: []entity.ReminderOffset{entity.ReminderHourBefore}},
+		{name: "already sent", now: base.Add(time.Hour), want: []entity.ReminderOffset{}},
+		// 期限を延ばすと、元の期限の通知は送られず、新しい期限で通知される
+		{name: "due postponed", now: base.Add(2 * time.Hour), due: ptr(base.Add(5 * time.Hour)), want: []entity.ReminderOffset{}},
+		{name: "1 hour before new due", now: base.Add(4 * time.Hour), want: []entity.ReminderOffset{entity.ReminderHourBefore}},
+		{name: "at new due", now: base.Add(5 * time.Hour), want: []entity.ReminderOffset{entity.ReminderAtDue}},
+	}
+	// 最初の予定は期限を変える前に作っておく
+	claimAt(base.Add(-time.Minute))
+	for _, s := range steps {
+		if s.due != nil {
+			if _, err := tx.ExecContext(ctx, `UPDATE tasks SET due_at = ? WHERE id = ?;`, *s.due, task.ID); err != nil {
+				t.Fatalf("failed to update due: %s", err)
+			}
+		}
+		if got := claimAt(s.now); !cmp.Equal(s.want, got) {
+			t.Errorf("%s: claimed %v, want %v", s.name, got, s.want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// TestRepository_ScheduleReminders_Late は通知の日時を過ぎてから予定を作っても、期限前なら遅れて送ることを確かめる
+func TestRepository_ScheduleReminders_Late(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+	offsets := []entity.ReminderOffset{entity.ReminderAtDue, entity.ReminderDayBefore}
+	if err := sut.SaveReminderOffsets(ctx, tx, userID, offsets); err != nil {
+		t.Fatalf("failed to save offsets: %s", err)
+	}
+
+	// 期限の30分前に作ったタスクの1日前の通知と、期限を過ぎてから確認した期限ちょうどの通知
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	due := now.Add(30 * time.Minute)
+	task := &entity.Task{UserID: userID, Title: "report", Status: entity.TaskStatusTodo, DueAt: &due}
+	if err := sut.AddTask(ctx, tx, task); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	claim := func(since, now time.Time) []entity.ReminderOffset {
+		t.Helper()
+		if _, err := sut.ScheduleReminders(ctx, tx, since); err != nil {
+			t.Fatalf("failed to schedule reminders: %s", err)
+		}
+		rs, err := sut.ClaimDueReminders(ctx, tx, now, 100)
+		if err != nil {
+			t.Fatalf("failed to claim reminders: %s", err)
+		}
+		got := []entity.ReminderOffset{}
+		for _, r := range rs {
+			if r.TaskID != task.ID {
+				continue
+			}
+			got = append(got, r.Offset)
+			if err := sut.MarkReminderSent(ctx, tx, r.ID, now); err != nil {
+				t.Fatalf("failed to mark sent: %s", err)
+			}
+		}
+		return got
+	}
+
+	if got := claim(now.Add(-time.Minute), now); !cmp.Equal([]entity.ReminderOffset{entity.ReminderDayBefore}, got) {
+		t.Errorf("claimed %v, want the day before reminder", got)
+	}
+	late := due.Add(30 * time.Second)
+	if got := claim(late.Add(-time.Minute), late); !cmp.Equal([]entity.ReminderOffset{entity.ReminderAtDue}, got) {
+		t.Errorf("claimed %v, want the at due reminder", got)
+	}
+}
+
+// TestRepository_ClaimDueReminders_SkipLocked は別のトランザクションが取得中の通知を取得しないことを確かめる。
+// トランザクションをまたぐので、データはコミットして最後に消す
+func TestRepository_ClaimDueReminders_SkipLocked(t *testing.T) {
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, db)
+	wsID := prepareWorkspace(ctx, t, db)
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(ctx, `DELETE FROM tasks WHERE user_id = ?;`, userID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM reminder_offsets WHERE user_id = ?;`, userID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM users WHERE id = ?;`, userID)
+		_, _ = db.ExecContext(ctx, `DELETE FROM workspaces WHERE id = ?;`, wsID)
+	})
+	ctx = auth.SetWorkspaceID(ctx, wsID)
+	if err := sut.SaveReminderOffsets(ctx, db, userID, []entity.ReminderOffset{entity.ReminderAtDue}); err != nil {
+		t.Fatalf("failed to save offsets: %s", err)
+	}
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	due := now.Add(time.Hour)
+	tasks := map[entity.TaskID]bool{}
+	for i := 0; i < 2; i++ {
+		task := &entity.Task{UserID: userID, Title: "report", Status: entity.TaskStatusTodo, DueAt: &due}
+		if err := sut.AddTask(ctx, db, task); err != nil {
+			t.Fatalf("failed to add task: %s", err)
+		}
+		tasks[task.ID] = true
+	}
+	if _, err := sut.ScheduleReminders(ctx, db, now); err != nil {
+		t.Fatalf("failed to schedule reminders: %s", err)
+	}
+
+	// claim はtxで通知を1件取得し、このテストのタスクの通知なら返す
+	claim := func(tx *sqlx.Tx) []*entity.Reminder {
+		t.Helper()
+		rs, err := sut.ClaimDueReminders(ctx, tx, due, 1000)
+		if err != nil {
+			t.Fatalf("failed to claim reminders: %s", err)
+		}
+		got := []*entity.Reminder{}
+		for _, r := range rs {
+			if tasks[r.TaskID] {
+				got = append(got, r)
+			}
+		}
+		return got
+	}
+	tx1, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx1.Rollback() })
+	first := claim(tx1)
+	if !rowLocked(ctx, t, db, first[0].ID) {
+		t.Skip("the database does not enforce row locks")
+	}
+	if len(first) != 2 {
+		t.Fatalf("first claim got %d reminders, want 2", len(first))
+	}
+
+	tx2, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx2.Rollback() })
+	if got := claim(tx2); len(got) != 0 {
+		t.Errorf("second claim got %d reminders locked by the first, want 0", len(got))
+	}
+	if err := tx2.Rollback(); err != nil {
+		t.Fatalf("failed to rollback: %s", err)
+	}
+
+	for _, r := range first {
+		if err := sut.MarkReminderSent(ctx, tx1, r.ID, due); err != nil {
+			t.Fatalf("failed to mark sent: %s", err)
+		}
+	}
+	if err := tx1.Commit(); err != nil {
+		t.Fatalf("failed to commit: %s", err)
+	}
+	tx3, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx3.Rollback() })
+	if got := claim(tx3); len(got) != 0 {
+		t.Errorf("claim after commit got %d sent reminders, want 0", len(got))
+	}
+}
+
+// rowLocked は通知の行が別のトランザクションにロックされているかを返す。
+// 行ロックに対応していないDBでは、ロック中でもNOWAITで取得できてしまう
+func rowLocked(ctx context.Context, t *testing.T, db *sqlx.DB, id entity.ReminderID) bool {
+	t.Helper()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	var got entity.ReminderID
+	err = tx.GetContext(ctx, &got, `SELECT id FROM task_reminders WHERE id = ? FOR UPDATE NOWAIT;`, id)
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errCodeSQLLockNowait
+}
+
+// errCodeSQLLockNowait はNOWAITで取得しようとした行がロックされていたときのエラー番号
+const errCodeSQLLockNowait = 3572
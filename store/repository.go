@@ -41,6 +41,11 @@ type Beginner interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
 
+// TxBeginner はsqlxのトランザクションを開始できるDB
+type TxBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
 type Execer interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
@@ -53,11 +58,22 @@ type Queryer interface {
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...any) error
 }
 
+// ExecQueryer は同じ接続やトランザクションで更新と参照の両方をするDB
+type ExecQueryer interface {
+	Execer
+	Queryer
+}
+
 var (
-	_ Beginner = (*sqlx.DB)(nil)
-	_ Queryer  = (*sqlx.DB)(nil)
-	_ Execer   = (*sqlx.DB)(nil)
-	_ Execer   = (*sqlx.Tx)(nil)
+	_ Beginner   = (*sqlx.DB)(nil)
+	_ TxBeginner = (*sqlx.DB)(nil)
+	_ Queryer    = (*sqlx.DB)(nil)
+	_ Queryer    = (*sqlx.Tx)(nil)
+	_ Execer     = (*sqlx.DB)(nil)
+	_ Execer     = (*sqlx.Tx)(nil)
+
+	_ ExecQueryer = (*sqlx.DB)(nil)
+	_ ExecQueryer = (*sqlx.Tx)(nil)
 )
 
 type Repository struct {
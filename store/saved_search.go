@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// AddSavedSearch はコンテキストのワークスペースに検索条件を保存する
+func (r *Repository) AddSavedSearch(
+	ctx context.Context, db Execer, s *entity.SavedSearch,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	s.WorkspaceID = wsID
+	s.CreatedAt = r.Clocker.Now()
+	s.ModifiedAt = r.Clocker.Now()
+
+	query := `INSERT INTO saved_searches (user_id, workspace_id, name, definition, created_at, modified_at)
+		VALUES (?, ?, ?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, s.UserID, s.WorkspaceID, s.Name, s.Filter, s.CreatedAt, s.ModifiedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("cannot create same name saved search: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	s.ID = entity.SavedSearchID(id)
+	return nil
+}
+
+// ListSavedSearches はコンテキストのワークスペースでユーザーが保存した検索条件を返す
+func (r *Repository) ListSavedSearches(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (entity.SavedSearches, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	searches := entity.SavedSearches{}
+	query := `SELECT id, user_id, workspace_id, name, definition, created_at, modified_at
+		FROM saved_searches
+		WHERE user_id = ? AND workspace_id = ?
+		ORDER BY id;`
+	if err := db.SelectContext(ctx, &searches, query, userID, wsID); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// GetSavedSearch はコンテキストのワークスペースでユーザーが保存した検索条件を取得する
+func (r *Repository) GetSavedSearch(
+	ctx context.Context, db Queryer, userID entity.UserID, id entity.SavedSearchID,
+) (*entity.SavedSearch, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s := &entity.SavedSearch{}
+	query := `SELECT id, user_id, workspace_id, name, definition, created_at, modified_at
+		FROM saved_searches
+		WHERE id = ? AND user_id = ? AND workspace_id = ?;`
+	if err := db.GetContext(ctx, s, query, id, userID, wsID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("saved search %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSavedSearch はユーザーが保存した検索条件を削除する
+func (r *Repository) DeleteSavedSearch(
+	ctx context.Context, db Execer, userID entity.UserID, id entity.SavedSearchID,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `DELETE FROM saved_searches WHERE id = ? AND user_id = ? AND workspace_id = ?;`
+	result, err := db.ExecContext(ctx, query, id, userID, wsID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("saved search %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// FilterTasks はListTasksと同じくユーザーに見えるタスクのうち、検索条件を満たすものを返す。
+// 条件はすべてプレースホルダで渡す。
+func (r *Repository) FilterTasks(
+	ctx context.Context, db Queryer, userID entity.UserID, f *entity.TaskFilter,
+) (entity.Tasks, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where := []string{"workspace_id = ?", "(user_id = ? OR assignee_id = ?)"}
+	args := []any{wsID, userID, userID}
+
+	if len(f.Statuses) > 0 {
+		q, a, err := sqlx.In("status IN (?)", f.Statuses)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, q)
+		args = append(args, a...)
+	}
+	if labels := uniqueStrings(f.Labels); len(labels) > 0 {
+		// すべてのラベルが付いているタスクだけを残す。同じラベルを重ねて指定しても、付いているラベルの数と比べられるよう重複は除く
+		q, a, err := sqlx.In(`id IN (
+			SELECT tl.task_id FROM task_labels tl
+			JOIN labels l ON l.id = tl.label_id
+			WHERE l.workspace_id = ? AND l.name IN (?)
+			GROUP BY tl.task_id
+			HAVING COUNT(DISTINCT l.id) = ?)`, wsID, labels, len(labels))
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, q)
+		args = append(args, a...)
+	}
+	if f.DueFrom != nil {
+		where = append(where, "due_at >= ?")
+		args = append(args, *f.DueFrom)
+	}
+	if f.DueTo != nil {
+		where = append(where, "due_at <= ?")
+		args = append(args, *f.DueTo)
+	}
+	if f.Text != "" {
+		where = append(where, `title LIKE ? ESCAPE '\\'`)
+		args = append(args, "%"+escapeLike(f.Text)+"%")
+	}
+
+	tasks := entity.Tasks{}
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY id;`
+	if err := db.SelectContext(ctx, &tasks, query, args...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// uniqueStrings は最初に現れた順のまま、重複を除いた値を返す
+func uniqueStrings(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	u := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			u = append(u, s)
+		}
+	}
+	return u
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike はLIKEのパターンで特別な意味を持つ文字をエスケープする
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_SavedSearches(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	otherID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	due := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+	s := &entity.SavedSearch{
+		UserID: userID,
+		Name:   "this week",
+		Filter: entity.TaskFilter{Version: 1, Statuses: []entity.TaskStatus{entity.TaskStatusTodo}, DueTo: &due},
+	}
+	if err := sut.AddSavedSearch(ctx, tx, s); err != nil {
+		t.Fatalf("failed to add saved search: %s", err)
+	}
+	if err := sut.AddSavedSearch(ctx, tx, &entity.SavedSearch{UserID: userID, Name: "this week"}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("same name: want %v, but got %v", ErrAlreadyExists, err)
+	}
+
+	got, err := sut.GetSavedSearch(ctx, tx, userID, s.ID)
+	if err != nil {
+		t.Fatalf("failed to get saved search: %s", err)
+	}
+	if diff := cmp.Diff(s.Filter, got.Filter); diff != "" {
+		t.Errorf("GetSavedSearch().Filter mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := sut.GetSavedSearch(ctx, tx, otherID, s.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("other user's saved search: want %v, but got %v", ErrNotFound, err)
+	}
+	list, err := sut.ListSavedSearches(ctx, tx, userID)
+	if err != nil || len(list) != 1 || list[0].Name != "this week" {
+		t.Errorf("ListSavedSearches() = %+v, %v", list, err)
+	}
+	if err := sut.DeleteSavedSearch(ctx, tx, userID, s.ID); err != nil {
+		t.Errorf("failed to delete saved search: %s", err)
+	}
+	if err := sut.DeleteSavedSearch(ctx, tx, userID, s.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted twice: want %v, but got %v", ErrNotFound, err)
+	}
+}
+
+func TestRepository_FilterTasks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	otherID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	day := func(d int) *time.Time {
+		v := time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	// IDの昇順で返るので、期待値も登録順に並べる
+	names := []string{"report", "review", "done", "private"}
+	tasks := map[string]*entity.Task{
+		"report":  {UserID: userID, Title: "quarterly report", Status: entity.TaskStatusTodo, DueAt: day(5)},
+		"review":  {UserID: userID, Title: "review 100%_done", Status: entity.TaskStatusDoing, DueAt: day(15)},
+		"done":    {UserID: userID, Title: "old report", Status: entity.TaskStatusDone},
+		"private": {UserID: otherID, Title: "other report", Status: entity.TaskStatusTodo},
+	}
+	labels := map[string][]string{
+		"report": {"work", "urgent"},
+		"review": {"work"},
+	}
+	for _, name := range names {
+		if err := sut.AddTask(ctx, tx, tasks[name]); err != nil {
+			t.Fatalf("failed to add task: %s", err)
+		}
+		if err := sut.AddTaskLabels(ctx, tx, tasks[name].ID, labels[name]); err != nil {
+			t.Fatalf("failed to add labels: %s", err)
+		}
+	}
+
+	tests := map[string]struct {
+		filter entity.TaskFilter
+		want   []string
+	}{
+		"no condition":    {want: []string{"report", "review", "done"}},
+		"status":          {filter: entity.TaskFilter{Statuses: []entity.TaskStatus{entity.TaskStatusTodo, entity.TaskStatusDoing}}, want: []string{"report", "review"}},
+		"single label":    {filter: entity.TaskFilter{Labels: []string{"work"}}, want: []string{"report", "review"}},
+		"all labels":      {filter: entity.TaskFilter{Labels: []string{"work", "urgent"}}, want: []string{"report"}},
+		"duplicate label": {filter: entity.TaskFilter{Labels: []string{"work", "urgent", "work"}}, want: []string{"report"}},
+		"due range":       {filter: entity.TaskFilter{DueFrom: day(5), DueTo: day(10)}, want: []string{"report"}},
+		"text":            {filter: entity.TaskFilter{Text: "report"}, want: []string{"report", "done"}},
+		"text is literal": {filter: entity.TaskFilter{Text: "100%_"}, want: []string{"review"}},
+		"combined":        {filter: entity.TaskFilter{Statuses: []entity.TaskStatus{entity.TaskStatusTodo}, Text: "report", Labels: []string{"urgent"}}, want: []string{"report"}},
+	}
+	for name, tt := range tests {
+		got, err := sut.FilterTasks(ctx, tx, userID, &tt.filter)
+		if err != nil {
+			t.Fatalf("%s: failed to filter tasks: %s", name, err)
+		}
+		want := []entity.TaskID{}
+		for _, n := range tt.want {
+			want = append(want, tasks[n].ID)
+		}
+		if diff := cmp.Diff(want, taskIDs(got)); diff != "" {
+			t.Errorf("%s: FilterTasks() mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// GetStats はユーザーのタスク統計をSQLの集計で求める。
+// 日付の区切りはlocの現在のUTCオフセットで計算し、直近windowDays日分(今日を含む)を日別に返す。
+// コンテキストのワークスペースのタスクだけを集計する。
+func (r *Repository) GetStats(
+	ctx context.Context, db Queryer, userID entity.UserID, windowDays int, loc *time.Location,
+) (*entity.Stats, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := r.Clocker.Now().In(loc)
+	offset := now.Format("-07:00")
+	today := now.Format(time.DateOnly)
+	first := now.AddDate(0, 0, -(windowDays - 1)).Format(time.DateOnly)
+
+	stats := &entity.Stats{
+		WindowDays: windowDays,
+		StatusCounts: map[entity.TaskStatus]int{
+			entity.TaskStatusTodo:  0,
+			entity.TaskStatusDoing: 0,
+			entity.TaskStatusDone:  0,
+		},
+		Days: []*entity.DailyStats{},
+	}
+
+	var counts []struct {
+		Status entity.TaskStatus `db:"status"`
+		Count  int               `db:"count"`
+	}
+	countSQL := `SELECT status, COUNT(*) AS count
+		FROM tasks
+		WHERE workspace_id = ? AND user_id = ?
+		GROUP BY status;`
+	if err := db.SelectContext(ctx, &counts, countSQL, wsID, userID); err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		stats.StatusCounts[c.Status] = c.Count
+	}
+
+	// 作成も完了もない日も0件として返すため、再帰CTEで日付の列を作ってから結合する
+	dailySQL := `WITH RECURSIVE days (day) AS (
+			SELECT CAST(? AS DATE)
+			UNION ALL
+			SELECT day + INTERVAL 1 DAY FROM days WHERE day < CAST(? AS DATE)
+		),
+		created AS (
+			SELECT DATE(CONVERT_TZ(created_at, '+00:00', ?)) AS day, COUNT(*) AS cnt
+			FROM tasks
+			WHERE workspace_id = ? AND user_id = ? AND created_at >= CONVERT_TZ(CAST(? AS DATETIME), ?, '+00:00')
+			GROUP BY day
+		),
+		completed AS (
+			SELECT DATE(CONVERT_TZ(completed_at, '+00:00', ?)) AS day, COUNT(*) AS cnt
+			FROM tasks
+			WHERE workspace_id = ? AND user_id = ? AND completed_at >= CONVERT_TZ(CAST(? AS DATETIME), ?, '+00:00')
+			GROUP BY day
+		)
+		SELECT
+			DATE_FORMAT(d.day, '%Y-%m-%d') AS day,
+			COALESCE(c.cnt, 0) AS created,
+			COALESCE(f.cnt, 0) AS completed
+		FROM days d
+		LEFT JOIN created c ON c.day = d.day
+		LEFT JOIN completed f ON f.day = d.day
+		ORDER BY d.day;`
+	if err := db.SelectContext(ctx, &stats.Days, dailySQL,
+		first, today,
+		offset, wsID, userID, first, offset,
+		offset, wsID, userID, first, offset,
+	); err != nil {
+		return nil, err
+	}
+
+	leadSQL := `SELECT AVG(TIMESTAMPDIFF(MICROSECOND, created_at, completed_at)) / 1000000
+		FROM tasks
+		WHERE workspace_id = ? AND user_id = ? AND status = 'done' AND completed_at IS NOT NULL;`
+	if err := db.GetContext(ctx, &stats.AvgLeadTimeSec, leadSQL, wsID, userID); err != nil {
+		return nil, err
+	}
+
+	// 完了のあった日を新しい順に並べ、最新日から日付が連続している間を数える。
+	// 最新日が今日か昨日でなければ連続は途切れている。
+	streakSQL := `SELECT COUNT(*) FROM (
+			SELECT
+				day,
+				FIRST_VALUE(day) OVER (ORDER BY day DESC) AS top,
+				ROW_NUMBER() OVER (ORDER BY day DESC) AS rn
+			FROM (
+				SELECT DISTINCT DATE(CONVERT_TZ(completed_at, '+00:00', ?)) AS day
+				FROM tasks
+				WHERE workspace_id = ? AND user_id = ? AND completed_at IS NOT NULL
+			) completed_days
+			WHERE day <= CAST(? AS DATE)
+		) s
+		WHERE DATEDIFF(top, day) = rn - 1
+			AND DATEDIFF(CAST(? AS DATE), top) <= 1;`
+	if err := db.GetContext(ctx, &stats.CurrentStreak, streakSQL, offset, wsID, userID, today, today); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func TestRepository_GetStats(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// FixedClockerは2022-05-10 12:34:56 UTC = 2022-05-10 21:34:56 JST
+	c := clock.FixedClocker{}
+	userID := entity.UserID(1)
+	wsID := entity.WorkspaceID(3)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	mock.ExpectQuery("SELECT status, COUNT\\(\\*\\) AS count FROM tasks").
+		WithArgs(wsID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+			AddRow("todo", 2).
+			AddRow("done", 5))
+	mock.ExpectQuery("WITH RECURSIVE days").
+		WithArgs("2022-05-09", "2022-05-10",
+			"+09:00", wsID, userID, "2022-05-09", "+09:00",
+			"+09:00", wsID, userID, "2022-05-09", "+09:00").
+		WillReturnRows(sqlmock.NewRows([]string{"day", "created", "completed"}).
+			AddRow("2022-05-09", 3, 0).
+			AddRow("2022-05-10", 1, 2))
+	mock.ExpectQuery("SELECT AVG\\(TIMESTAMPDIFF").
+		WithArgs(wsID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"avg"}).AddRow(5400.5))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM").
+		WithArgs("+09:00", wsID, userID, "2022-05-10", "2022-05-10").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	r := &Repository{Clocker: c}
+	got, err := r.GetStats(auth.SetWorkspaceID(context.Background(), wsID), sqlx.NewDb(db, "mysql"), userID, 2, tokyo)
+	if err != nil {
+		t.Fatalf("GetStats() unexpected error: %v", err)
+	}
+
+	avg := 5400.5
+	want := &entity.Stats{
+		WindowDays: 2,
+		StatusCounts: map[entity.TaskStatus]int{
+			entity.TaskStatusTodo:  2,
+			entity.TaskStatusDoing: 0,
+			entity.TaskStatusDone:  5,
+		},
+		Days: []*entity.DailyStats{
+			{Date: "2022-05-09", Created: 3, Completed: 0},
+			{Date: "2022-05-10", Created: 1, Completed: 2},
+		},
+		AvgLeadTimeSec: &avg,
+		CurrentStreak:  4,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetStats() mismatch (-want +got):\n%s", diff)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
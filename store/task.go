@@ -2,42 +2,104 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/zakisanbaiman/go-handson01/entity"
 )
 
-func (r *Repository) ListTasks(
-	ctx context.Context, db Queryer, userID entity.UserID,
-) (entity.Tasks, error) {
-	tasks := entity.Tasks{}
-	sql := `SELECT
-		id,
+const taskColumns = `id,
+		workspace_id,
 		user_id,
+		project_id,
+		assignee_id,
 		title,
 		status,
+		priority,
+		column_id,
+		due_at,
+		completed_at,
 		created_at,
-		modified_at
+		modified_at`
+
+// ListTasks はユーザーが作成したタスクと、ユーザーが担当者になっているタスクを返す
+func (r *Repository) ListTasks(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (entity.Tasks, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks := entity.Tasks{}
+	sql := `SELECT ` + taskColumns + `
 	FROM tasks
-	WHERE user_id = ?;`
+	WHERE workspace_id = ? AND (user_id = ? OR assignee_id = ?)
+	ORDER BY id;`
 
-	if err := db.SelectContext(ctx, &tasks, sql, userID); err != nil {
+	if err := db.SelectContext(ctx, &tasks, sql, wsID, userID, userID); err != nil {
 		return nil, err
 	}
 
 	return tasks, nil
 }
 
+// ListAssignedTasks はワークスペースでassigneeIDが担当しているタスクを返す
+func (r *Repository) ListAssignedTasks(
+	ctx context.Context, db Queryer, assigneeID entity.UserID,
+) (entity.Tasks, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks := entity.Tasks{}
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE workspace_id = ? AND assignee_id = ?
+		ORDER BY id;`
+	if err := db.SelectContext(ctx, &tasks, query, wsID, assigneeID); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTask はワークスペースのタスクを取得する
+func (r *Repository) GetTask(
+	ctx context.Context, db Queryer, id entity.TaskID,
+) (*entity.Task, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := &entity.Task{}
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = ? AND workspace_id = ?;`
+	if err := db.GetContext(ctx, t, query, id, wsID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("task %d: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
 func (r *Repository) AddTask(
 	ctx context.Context, db Execer, t *entity.Task,
 ) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	t.WorkspaceID = wsID
 	t.CreatedAt = r.Clocker.Now()
 	t.ModifiedAt = r.Clocker.Now()
 
 	sql := `INSERT INTO tasks
-		(user_id, title, status, created_at, modified_at) VALUES (?, ?, ?, ?, ?);`
+		(workspace_id, user_id, title, status, priority, due_at, created_at, modified_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
 
 	result, err := db.ExecContext(
-		ctx, sql, t.UserID, t.Title, t.Status, t.CreatedAt, t.ModifiedAt,
+		ctx, sql, t.WorkspaceID, t.UserID, t.Title, t.Status, t.Priority, t.DueAt, t.CreatedAt, t.ModifiedAt,
 	)
 	if err != nil {
 		return err
@@ -52,3 +114,105 @@ func (r *Repository) AddTask(
 
 	return nil
 }
+
+// UpdateTaskStatus はユーザーが作成したか担当しているタスクのステータスを変更する。
+// doneになった時点の時刻をcompleted_atに記録し、done以外に戻した場合は消去する。
+// ステータスが変わったタスクはボードの列指定を外し、新しいステータスの先頭の列に戻す。
+func (r *Repository) UpdateTaskStatus(
+	ctx context.Context, db Execer, userID entity.UserID, id entity.TaskID, status entity.TaskStatus,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	now := r.Clocker.Now()
+	query := `UPDATE tasks
+		SET column_id = IF(status = ?, column_id, NULL),
+			status = ?,
+			completed_at = CASE WHEN ? = 'done' THEN COALESCE(completed_at, ?) ELSE NULL END,
+			modified_at = ?
+		WHERE id = ? AND workspace_id = ? AND (user_id = ? OR assignee_id = ?);`
+	result, err := db.ExecContext(ctx, query, status, status, status, now, now, id, wsID, userID, userID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// AssignTask はタスクの担当者を変更する。assigneeIDがnilなら担当者を外す
+func (r *Repository) AssignTask(
+	ctx context.Context, db Execer, id entity.TaskID, assigneeID *entity.UserID,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE tasks SET assignee_id = ?, modified_at = ? WHERE id = ? AND workspace_id = ?;`
+	result, err := db.ExecContext(ctx, query, assigneeID, r.Clocker.Now(), id, wsID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// SetTaskProject はタスクを同じワークスペースのプロジェクトに入れる。projectIDがnilならプロジェクトから外す
+func (r *Repository) SetTaskProject(
+	ctx context.Context, db Execer, id entity.TaskID, projectID *entity.ProjectID,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE tasks SET project_id = ?, modified_at = ? WHERE id = ? AND workspace_id = ?;`
+	result, err := db.ExecContext(ctx, query, projectID, r.Clocker.Now(), id, wsID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// GetWorkload はワークスペースのメンバーごとに担当している未完了タスクの数を返す。
+// 担当タスクのないメンバーも0件として含める。
+func (r *Repository) GetWorkload(
+	ctx context.Context, db Queryer,
+) ([]*entity.Workload, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workload := []*entity.Workload{}
+	query := `SELECT m.user_id AS assignee_id, u.name, COUNT(t.id) AS open_tasks
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN tasks t ON t.workspace_id = m.workspace_id
+			AND t.assignee_id = m.user_id
+			AND t.status <> 'done'
+		WHERE m.workspace_id = ?
+		GROUP BY m.user_id, u.name
+		ORDER BY open_tasks DESC, m.user_id;`
+	if err := db.SelectContext(ctx, &workload, query, wsID); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
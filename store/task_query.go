@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+)
+
+// SearchTasks はListTasksと同じくユーザーに見えるタスクのうち、検索クエリにマッチするものを返す。
+// 相対的な期限はClockerの現在時刻から、日付はlocでのその日として解釈する。
+func (r *Repository) SearchTasks(
+	ctx context.Context, db Queryer, userID entity.UserID, q taskquery.Node, loc *time.Location,
+) (entity.Tasks, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c := &taskQueryCompiler{workspaceID: wsID, userID: userID, now: r.Clocker.Now(), loc: loc}
+	cond, err := c.compile(q)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := entity.Tasks{}
+	query := `SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE workspace_id = ? AND (user_id = ? OR assignee_id = ?) AND ` + cond + `
+		ORDER BY id;`
+	args := append([]any{wsID, userID, userID}, c.args...)
+	if err := db.SelectContext(ctx, &tasks, query, args...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// taskQueryCompiler は検索クエリの構文木をWHERE句の条件にする。
+// SQLには固定の断片だけを組み立て、クエリに書かれた値はすべてargsでプレースホルダに渡す。
+// 否定しても期限や担当者がNULLのタスクを取りこぼさないよう、NULLになる比較は書かない。
+type taskQueryCompiler struct {
+	workspaceID entity.WorkspaceID
+	userID      entity.UserID
+	now         time.Time
+	loc         *time.Location
+	args        []any
+}
+
+func (c *taskQueryCompiler) compile(n taskquery.Node) (string, error) {
+	switch n := n.(type) {
+	case *taskquery.And:
+		return c.join(n.Terms, " AND ")
+	case *taskquery.Or:
+		return c.join(n.Terms, " OR ")
+	case *taskquery.Not:
+		x, err := c.compile(n.X)
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + x + ")", nil
+	case *taskquery.Text:
+		c.args = append(c.args, "%"+escapeLike(n.Value)+"%")
+		return `(title LIKE ? ESCAPE '\\')`, nil
+	case *taskquery.Status:
+		c.args = append(c.args, n.Status)
+		return "(status = ?)", nil
+	case *taskquery.Label:
+		c.args = append(c.args, c.workspaceID, n.Name)
+		return `(id IN (
+			SELECT tl.task_id FROM task_labels tl
+			JOIN labels l ON l.id = tl.label_id
+			WHERE l.workspace_id = ? AND l.name = ?))`, nil
+	case *taskquery.Priority:
+		c.args = append(c.args, n.Priority)
+		return "(priority = ?)", nil
+	case *taskquery.Assignee:
+		switch {
+		case n.None:
+			return "(assignee_id IS NULL)", nil
+		case n.Me:
+			c.args = append(c.args, c.userID)
+		default:
+			c.args = append(c.args, n.UserID)
+		}
+		return "(assignee_id IS NOT NULL AND assignee_id = ?)", nil
+	case *taskquery.Project:
+		if n.None {
+			return "(project_id IS NULL)", nil
+		}
+		c.args = append(c.args, n.ProjectID)
+		return "(project_id IS NOT NULL AND project_id = ?)", nil
+	case *taskquery.Due:
+		return c.due(n)
+	}
+	return "", fmt.Errorf("unsupported query node %T", n)
+}
+
+func (c *taskQueryCompiler) join(terms []taskquery.Node, sep string) (string, error) {
+	conds := make([]string, 0, len(terms))
+	for _, t := range terms {
+		cond, err := c.compile(t)
+		if err != nil {
+			return "", err
+		}
+		conds = append(conds, cond)
+	}
+	return "(" + strings.Join(conds, sep) + ")", nil
+}
+
+// due は日付をその日の0時から翌日の0時までの範囲として比べる
+func (c *taskQueryCompiler) due(n *taskquery.Due) (string, error) {
+	if n.None {
+		return "(due_at IS NULL)", nil
+	}
+	if n.Date == nil {
+		c.args = append(c.args, c.now.Add(n.Relative))
+		switch n.Op {
+		case taskquery.OpLt:
+			return "(due_at IS NOT NULL AND due_at < ?)", nil
+		case taskquery.OpLe:
+			return "(due_at IS NOT NULL AND due_at <= ?)", nil
+		case taskquery.OpGt:
+			return "(due_at IS NOT NULL AND due_at > ?)", nil
+		case taskquery.OpGe:
+			return "(due_at IS NOT NULL AND due_at >= ?)", nil
+		}
+		return "", fmt.Errorf("unsupported operator %q for relative due", n.Op.String())
+	}
+
+	start := n.Date.Start(c.loc)
+	end := start.AddDate(0, 0, 1)
+	switch n.Op {
+	case taskquery.OpEq:
+		c.args = append(c.args, start, end)
+		return "(due_at IS NOT NULL AND due_at >= ? AND due_at < ?)", nil
+	case taskquery.OpLt:
+		c.args = append(c.args, start)
+		return "(due_at IS NOT NULL AND due_at < ?)", nil
+	case taskquery.OpLe:
+		c.args = append(c.args, end)
+		return "(due_at IS NOT NULL AND due_at < ?)", nil
+	case taskquery.OpGt:
+		c.args = append(c.args, end)
+		return "(due_at IS NOT NULL AND due_at >= ?)", nil
+	case taskquery.OpGe:
+		c.args = append(c.args, start)
+		return "(due_at IS NOT NULL AND due_at >= ?)", nil
+	}
+	return "", fmt.Errorf("unsupported operator %q for due date", n.Op.String())
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/taskquery"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_SearchTasks(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	// 現在時刻は2022-05-10 12:34:56 UTC
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	otherID := prepareUser(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2022, 5, day, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+	// IDの昇順で返るので、期待値も登録順に並べる
+	names := []string{"report", "overdue", "nodue", "done", "assigned"}
+	tasks := map[string]*entity.Task{
+		"report":   {UserID: userID, Title: "quarterly report", Status: entity.TaskStatusDoing, Priority: entity.TaskPriorityHigh, DueAt: at(12, 9)},
+		"overdue":  {UserID: userID, Title: "pay rent", Status: entity.TaskStatusTodo, DueAt: at(9, 20)},
+		"nodue":    {UserID: userID, Title: "write report", Status: entity.TaskStatusTodo},
+		"done":     {UserID: userID, Title: "old report", Status: entity.TaskStatusDone, DueAt: at(20, 9)},
+		"assigned": {UserID: otherID, Title: "review", Status: entity.TaskStatusTodo},
+	}
+	labels := map[string][]string{
+		"report": {"work"},
+		"nodue":  {"home"},
+	}
+	for _, name := range names {
+		if err := sut.AddTask(ctx, tx, tasks[name]); err != nil {
+			t.Fatalf("failed to add task: %s", err)
+		}
+		if err := sut.AddTaskLabels(ctx, tx, tasks[name].ID, labels[name]); err != nil {
+			t.Fatalf("failed to add labels: %s", err)
+		}
+	}
+	if err := sut.AssignTask(ctx, tx, tasks["assigned"].ID, &userID); err != nil {
+		t.Fatalf("failed to assign task: %s", err)
+	}
+	// 他のユーザーだけのタスクは検索しても見えない
+	if err := sut.AddTask(ctx, tx, &entity.Task{UserID: otherID, Title: "other report", Status: entity.TaskStatusTodo}); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]struct {
+		query string
+		loc   *time.Location
+		want  []string
+	}{
+		"full example":          {query: `status:doing label:work due<7d "quarterly report"`, want: []string{"report"}},
+		"text":                  {query: "report", want: []string{"report", "nodue", "done"}},
+		"overdue":               {query: "due<now", want: []string{"overdue"}},
+		"negation keeps no due": {query: "-due<7d", want: []string{"nodue", "done", "assigned"}},
+		"due none":              {query: "due:none", want: []string{"nodue", "assigned"}},
+		"assignee me":           {query: "assignee:me", want: []string{"assigned"}},
+		"not assigned":          {query: "-assignee:me", want: []string{"report", "overdue", "nodue", "done"}},
+		"or":                    {query: "label:work OR label:home", want: []string{"report", "nodue"}},
+		"group":                 {query: "report -(status:done OR priority:high)", want: []string{"nodue"}},
+		"date in utc":           {query: "due:2022-05-10", loc: time.UTC, want: []string{}},
+		"date in tokyo":         {query: "due:2022-05-10", loc: tokyo, want: []string{"overdue"}},
+		"date range":            {query: "due>=2022-05-09 due<=2022-05-12", loc: time.UTC, want: []string{"report", "overdue"}},
+		"project none":          {query: "project:none status:todo", want: []string{"overdue", "nodue", "assigned"}},
+	}
+	for name, tt := range tests {
+		q, err := taskquery.Parse(tt.query)
+		if err != nil {
+			t.Fatalf("%s: failed to parse %q: %s", name, tt.query, err)
+		}
+		loc := tt.loc
+		if loc == nil {
+			loc = time.UTC
+		}
+		got, err := sut.SearchTasks(ctx, tx, userID, q, loc)
+		if err != nil {
+			t.Fatalf("%s: failed to search tasks: %s", name, err)
+		}
+		want := []entity.TaskID{}
+		for _, n := range tt.want {
+			want = append(want, tasks[n].ID)
+		}
+		if diff := cmp.Diff(want, taskIDs(got)); diff != "" {
+			t.Errorf("%s: SearchTasks(%q) mismatch (-want +got):\n%s", name, tt.query, diff)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
 	"github.com/zakisanbaiman/go-handson01/clock"
 	"github.com/zakisanbaiman/go-handson01/entity"
 	"github.com/zakisanbaiman/go-handson01/testutil"
@@ -35,10 +36,10 @@ func TestRepository_ListTasks(t *testing.T) {
 		t.Fatalf("failed to begin tx: %s", err)
 	}
 
-	wantUserID, wants := prepareTasks(ctx, t, tx)
+	wantUserID, wantWorkspaceID, wants := prepareTasks(ctx, t, tx)
 
 	sut := &Repository{}
-	gots, err := sut.ListTasks(ctx, tx, wantUserID)
+	gots, err := sut.ListTasks(auth.SetWorkspaceID(ctx, wantWorkspaceID), tx, wantUserID)
 	if err != nil {
 		t.Fatalf("failed to list tasks: %s", err)
 	}
@@ -72,13 +73,13 @@ func TestRepository_AddTask(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = db.Close() })
 
-	mock.ExpectExec("INSERT INTO tasks \\(user_id, title, status, created_at, modified_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\);").
-		WithArgs(okTask.UserID, okTask.Title, okTask.Status, okTask.CreatedAt, okTask.ModifiedAt).
+	mock.ExpectExec("INSERT INTO tasks \\(workspace_id, user_id, title, status, priority, due_at, created_at, modified_at\\) VALUES \\(\\?, \\?, \\?, \\?, \\?, \\?, \\?, \\?\\);").
+		WithArgs(entity.WorkspaceID(1), okTask.UserID, okTask.Title, okTask.Status, okTask.Priority, okTask.DueAt, okTask.CreatedAt, okTask.ModifiedAt).
 		WillReturnResult(sqlmock.NewResult(wantID, 1))
 
 	xdb := sqlx.NewDb(db, "mysql")
 	r := &Repository{Clocker: c}
-	if err := r.AddTask(ctx, xdb, okTask); err != nil {
+	if err := r.AddTask(auth.SetWorkspaceID(ctx, 1), xdb, okTask); err != nil {
 		t.Errorf("failed to add task: %s", err)
 	}
 }
@@ -104,49 +105,83 @@ func prepareUser(ctx context.Context, t *testing.T, db Execer) entity.UserID {
 	return entity.UserID(id)
 }
 
-func prepareTasks(ctx context.Context, t *testing.T, con Execer) (entity.UserID, entity.Tasks) {
+func prepareWorkspace(ctx context.Context, t *testing.T, db Execer) entity.WorkspaceID {
+	t.Helper()
+
+	c := clock.FixedClocker{}
+	result, err := db.ExecContext(ctx,
+		`INSERT INTO workspaces (name, created_at, modified_at) VALUES (?, ?, ?);`,
+		"team", c.Now(), c.Now(),
+	)
+	if err != nil {
+		t.Fatalf("failed to insert workspace: %s", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("failed to get last insert id: %s", err)
+	}
+	return entity.WorkspaceID(id)
+}
+
+func prepareTasks(ctx context.Context, t *testing.T, con Execer) (entity.UserID, entity.WorkspaceID, entity.Tasks) {
 	t.Helper()
 
 	userID := prepareUser(ctx, t, con)
 	ohterUserID := prepareUser(ctx, t, con)
+	wsID := prepareWorkspace(ctx, t, con)
+	otherWsID := prepareWorkspace(ctx, t, con)
 	c := clock.FixedClocker{}
 	wants := entity.Tasks{
 		{
-			UserID:     userID,
-			Title:      "test1",
-			Status:     entity.TaskStatusTodo,
-			CreatedAt:  c.Now(),
-			ModifiedAt: c.Now(),
+			WorkspaceID: wsID,
+			UserID:      userID,
+			Title:       "test1",
+			Status:      entity.TaskStatusTodo,
+			CreatedAt:   c.Now(),
+			ModifiedAt:  c.Now(),
 		},
 		{
-			UserID:     userID,
-			Title:      "test2",
-			Status:     entity.TaskStatusDone,
-			CreatedAt:  c.Now(),
-			ModifiedAt: c.Now(),
+			WorkspaceID: wsID,
+			UserID:      userID,
+			Title:       "test2",
+			Status:      entity.TaskStatusDone,
+			CreatedAt:   c.Now(),
+			ModifiedAt:  c.Now(),
 		},
 	}
 
 	tasks := entity.Tasks{
 		wants[0],
 		{
-			UserID:     ohterUserID,
-			Title:      "test3 not want",
-			Status:     entity.TaskStatusTodo,
-			CreatedAt:  c.Now(),
-			ModifiedAt: c.Now(),
+			WorkspaceID: wsID,
+			UserID:      ohterUserID,
+			Title:       "test3 not want",
+			Status:      entity.TaskStatusTodo,
+			CreatedAt:   c.Now(),
+			ModifiedAt:  c.Now(),
 		},
 		wants[1],
+		{
+			// 同じユーザーでも別のワークスペースのタスクは含まない
+			WorkspaceID: otherWsID,
+			UserID:      userID,
+			Title:       "test4 other workspace",
+			Status:      entity.TaskStatusTodo,
+			CreatedAt:   c.Now(),
+			ModifiedAt:  c.Now(),
+		},
 	}
 
 	result, err := con.ExecContext(ctx,
-		`INSERT INTO tasks (user_id, title, status, created_at, modified_at) VALUES
-		(?, ?, ?, ?, ?),
-		(?, ?, ?, ?, ?),
-		(?, ?, ?, ?, ?);`,
-		tasks[0].UserID, tasks[0].Title, tasks[0].Status, tasks[0].CreatedAt, tasks[0].ModifiedAt,
-		tasks[1].UserID, tasks[1].Title, tasks[1].Status, tasks[1].CreatedAt, tasks[1].ModifiedAt,
-		tasks[2].UserID, tasks[2].Title, tasks[2].Status, tasks[2].CreatedAt, tasks[2].ModifiedAt,
+		`INSERT INTO tasks (workspace_id, user_id, title, status, created_at, modified_at) VALUES
+		(?, ?, ?, ?, ?, ?),
+		(?, ?, ?, ?, ?, ?),
+		(?, ?, ?, ?, ?, ?),
+		(?, ?, ?, ?, ?, ?);`,
+		tasks[0].WorkspaceID, tasks[0].UserID, tasks[0].Title, tasks[0].Status, tasks[0].CreatedAt, tasks[0].ModifiedAt,
+		tasks[1].WorkspaceID, tasks[1].UserID, tasks[1].Title, tasks[1].Status, tasks[1].CreatedAt, tasks[1].ModifiedAt,
+		tasks[2].WorkspaceID, tasks[2].UserID, tasks[2].Title, tasks[2].Status, tasks[2].CreatedAt, tasks[2].ModifiedAt,
+		tasks[3].WorkspaceID, tasks[3].UserID, tasks[3].Title, tasks[3].Status, tasks[3].CreatedAt, tasks[3].ModifiedAt,
 	)
 	if err != nil {
 		t.Fatalf("failed to insert tasks: %s", err)
@@ -160,10 +195,11 @@ func prepareTasks(ctx context.Context, t *testing.T, con Execer) (entity.UserID,
 	tasks[0].ID = entity.TaskID(id)
 	tasks[1].ID = entity.TaskID(id + 1)
 	tasks[2].ID = entity.TaskID(id + 2)
+	tasks[3].ID = entity.TaskID(id + 3)
 
 	// wantsにIDを設定
 	wants[0].ID = tasks[0].ID
 	wants[1].ID = tasks[2].ID
 
-	return userID, wants
+	return userID, wsID, wants
 }
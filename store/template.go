@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// AddTemplate はテンプレートと項目を登録する。
+// 複数のINSERTを行うため、呼び出し側でトランザクションを張ること。
+func (r *Repository) AddTemplate(
+	ctx context.Context, db Execer, t *entity.Template,
+) error {
+	t.CreatedAt = r.Clocker.Now()
+	t.ModifiedAt = r.Clocker.Now()
+
+	sql := `INSERT INTO task_templates
+		(user_id, title, created_at, modified_at) VALUES (?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, sql, t.UserID, t.Title, t.CreatedAt, t.ModifiedAt)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = entity.TemplateID(id)
+
+	itemSQL := `INSERT INTO task_template_items
+		(template_id, position, title, due_offset_minutes) VALUES (?, ?, ?, ?);`
+	for i, item := range t.Items {
+		item.TemplateID = t.ID
+		item.Position = i
+		result, err := db.ExecContext(ctx, itemSQL, item.TemplateID, item.Position, item.Title, item.DueOffsetMinutes)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		item.ID = id
+	}
+	return nil
+}
+
+func (r *Repository) ListTemplates(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (entity.Templates, error) {
+	templates := entity.Templates{}
+	sql := `SELECT id, user_id, title, created_at, modified_at
+		FROM task_templates
+		WHERE user_id = ?
+		ORDER BY id;`
+	if err := db.SelectContext(ctx, &templates, sql, userID); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetTemplate はユーザーが所有するテンプレートを項目ごと取得する
+func (r *Repository) GetTemplate(
+	ctx context.Context, db Queryer, userID entity.UserID, id entity.TemplateID,
+) (*entity.Template, error) {
+	t := &entity.Template{}
+	query := `SELECT id, user_id, title, created_at, modified_at
+		FROM task_templates
+		WHERE id = ? AND user_id = ?;`
+	if err := db.GetContext(ctx, t, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	itemSQL := `SELECT id, template_id, position, title, due_offset_minutes
+		FROM task_template_items
+		WHERE template_id = ?
+		ORDER BY position;`
+	if err := db.SelectContext(ctx, &t.Items, itemSQL, t.ID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func TestRepository_AddTemplate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := clock.FixedClocker{}
+	offset := 60
+
+	tpl := &entity.Template{
+		UserID: 1,
+		Title:  "onboarding",
+		Items: []*entity.TemplateItem{
+			{Title: "create account"},
+			{Title: "read handbook", DueOffsetMinutes: &offset},
+		},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	mock.ExpectExec("INSERT INTO task_templates").
+		WithArgs(tpl.UserID, tpl.Title, c.Now(), c.Now()).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec("INSERT INTO task_template_items").
+		WithArgs(entity.TemplateID(10), 0, "create account", nil).
+		WillReturnResult(sqlmock.NewResult(100, 1))
+	mock.ExpectExec("INSERT INTO task_template_items").
+		WithArgs(entity.TemplateID(10), 1, "read handbook", offset).
+		WillReturnResult(sqlmock.NewResult(101, 1))
+
+	r := &Repository{Clocker: c}
+	if err := r.AddTemplate(ctx, sqlx.NewDb(db, "mysql"), tpl); err != nil {
+		t.Fatalf("failed to add template: %s", err)
+	}
+	if tpl.ID != 10 {
+		t.Errorf("want template id 10, but got %d", tpl.ID)
+	}
+	if tpl.Items[1].ID != 101 || tpl.Items[1].Position != 1 {
+		t.Errorf("unexpected item: %+v", tpl.Items[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRepository_GetTemplate_NotFound(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	mock.ExpectQuery("SELECT id, user_id, title, created_at, modified_at FROM task_templates").
+		WithArgs(entity.TemplateID(1), entity.UserID(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "title", "created_at", "modified_at"}))
+
+	r := &Repository{Clocker: clock.FixedClocker{}}
+	_, err = r.GetTemplate(context.Background(), sqlx.NewDb(db, "mysql"), 2, 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("want %v, but got %v", ErrNotFound, err)
+	}
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// StartTimer はコンテキストのワークスペースにある、ユーザーが作成したか担当しているタスクに対して計測中の記録を作成する。
+// 計測中の記録は1ユーザーにつき1件までで、既にある場合はErrAlreadyExistsを返す。
+func (r *Repository) StartTimer(
+	ctx context.Context, db Execer, e *entity.TimeEntry,
+) error {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return err
+	}
+	e.CreatedAt = r.Clocker.Now()
+	e.ModifiedAt = r.Clocker.Now()
+	e.StoppedAt = nil
+
+	query := `INSERT INTO time_entries
+		(user_id, task_id, started_at, created_at, modified_at)
+		SELECT ?, id, ?, ?, ? FROM tasks WHERE id = ? AND workspace_id = ? AND (user_id = ? OR assignee_id = ?);`
+	result, err := db.ExecContext(ctx, query,
+		e.UserID, e.StartedAt, e.CreatedAt, e.ModifiedAt, e.TaskID, wsID, e.UserID, e.UserID,
+	)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("timer is already running: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("task %d: %w", e.TaskID, ErrNotFound)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	e.ID = entity.TimeEntryID(id)
+	return nil
+}
+
+// GetRunningTimeEntry は計測中の記録を行ロックを取って取得する。
+// トランザクション内で呼び出すこと。
+func (r *Repository) GetRunningTimeEntry(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (*entity.TimeEntry, error) {
+	e := &entity.TimeEntry{}
+	query := `SELECT id, user_id, task_id, started_at, stopped_at, created_at, modified_at
+		FROM time_entries
+		WHERE user_id = ? AND stopped_at IS NULL
+		FOR UPDATE;`
+	if err := db.GetContext(ctx, e, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *Repository) GetTimeEntry(
+	ctx context.Context, db Queryer, userID entity.UserID, id entity.TimeEntryID,
+) (*entity.TimeEntry, error) {
+	e := &entity.TimeEntry{}
+	query := `SELECT id, user_id, task_id, started_at, stopped_at, created_at, modified_at
+		FROM time_entries
+		WHERE id = ? AND user_id = ?;`
+	if err := db.GetContext(ctx, e, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *Repository) UpdateTimeEntry(
+	ctx context.Context, db Execer, e *entity.TimeEntry,
+) error {
+	e.ModifiedAt = r.Clocker.Now()
+
+	query := `UPDATE time_entries
+		SET started_at = ?, stopped_at = ?, modified_at = ?
+		WHERE id = ? AND user_id = ?;`
+	if _, err := db.ExecContext(ctx, query, e.StartedAt, e.StoppedAt, e.ModifiedAt, e.ID, e.UserID); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("timer is already running: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	return nil
+}
+
+// ListTimeEntries は[from, to)と重なる記録をタスク名付きで取得する。
+// コンテキストのワークスペースのタスクの記録だけを返す。
+func (r *Repository) ListTimeEntries(
+	ctx context.Context, db Queryer, userID entity.UserID, from, to time.Time,
+) (entity.TimeEntries, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := entity.TimeEntries{}
+	query := `SELECT
+			e.id, e.user_id, e.task_id, t.title AS task_title,
+			e.started_at, e.stopped_at, e.created_at, e.modified_at
+		FROM time_entries e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE e.user_id = ?
+			AND t.workspace_id = ?
+			AND e.started_at < ?
+			AND (e.stopped_at IS NULL OR e.stopped_at > ?)
+		ORDER BY e.started_at;`
+	if err := db.SelectContext(ctx, &entries, query, userID, wsID, to, from); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+func TestRepository_StartTimer(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	tests := map[string]struct {
+		result  driver.Result
+		err     error
+		wantID  entity.TimeEntryID
+		wantErr error
+	}{
+		"ok": {
+			result: sqlmock.NewResult(5, 1),
+			wantID: 5,
+		},
+		"task of another user": {
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: ErrNotFound,
+		},
+		"timer already running": {
+			err:     &mysql.MySQLError{Number: ErrCodeSQLDuplicateEntry},
+			wantErr: ErrAlreadyExists,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = db.Close() })
+
+			e := &entity.TimeEntry{UserID: 1, TaskID: 2, StartedAt: c.Now()}
+			exp := mock.ExpectExec("INSERT INTO time_entries").
+				WithArgs(e.UserID, e.StartedAt, c.Now(), c.Now(), e.TaskID, entity.WorkspaceID(1), e.UserID, e.UserID)
+			if tt.err != nil {
+				exp.WillReturnError(tt.err)
+			} else {
+				exp.WillReturnResult(tt.result)
+			}
+
+			r := &Repository{Clocker: c}
+			err = r.StartTimer(auth.SetWorkspaceID(context.Background(), 1), sqlx.NewDb(db, "mysql"), e)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("want %v, but got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e.ID != tt.wantID {
+				t.Errorf("want id %d, but got %d", tt.wantID, e.ID)
+			}
+		})
+	}
+}
+
+func TestRepository_ListTimeEntries_Workspace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	c := clock.FixedClocker{}
+	sut := &Repository{Clocker: c}
+	userID := prepareUser(ctx, t, tx)
+	wsID := prepareWorkspace(ctx, t, tx)
+	otherWsID := prepareWorkspace(ctx, t, tx)
+	ctx = auth.SetWorkspaceID(ctx, wsID)
+	otherCtx := auth.SetWorkspaceID(context.Background(), otherWsID)
+
+	mine := &entity.Task{UserID: userID, Title: "mine", Status: entity.TaskStatusTodo}
+	if err := sut.AddTask(ctx, tx, mine); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	other := &entity.Task{UserID: userID, Title: "other workspace", Status: entity.TaskStatusTodo}
+	if err := sut.AddTask(otherCtx, tx, other); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	for _, id := range []entity.TaskID{mine.ID, other.ID} {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO time_entries (user_id, task_id, started_at, stopped_at, created_at, modified_at)
+			VALUES (?, ?, ?, ?, ?, ?);`,
+			userID, id, c.Now(), c.Now().Add(time.Hour), c.Now(), c.Now(),
+		); err != nil {
+			t.Fatalf("failed to insert time entry: %s", err)
+		}
+	}
+
+	// 同じユーザーの記録でも、別のワークスペースのタスクの記録は含まない
+	got, err := sut.ListTimeEntries(ctx, tx, userID, c.Now().Add(-time.Hour), c.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("ListTimeEntries() unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].TaskID != mine.ID {
+		t.Errorf("ListTimeEntries() = %+v, want only the entry of task %d", got, mine.ID)
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 
@@ -13,9 +14,10 @@ func (r *Repository) RegisterUser(ctx context.Context, db Execer, user *entity.U
 	user.CreatedAt = r.Clocker.Now()
 	user.ModifiedAt = r.Clocker.Now()
 
-	sql := `INSERT INTO users (name, password, role, created_at, modified_at) VALUES (?, ?, ?, ?, ?);`
+	// メールアドレスは一意なので、未設定は空文字ではなくNULLで保存する
+	sql := `INSERT INTO users (name, password, role, timezone, email, created_at, modified_at) VALUES (?, ?, ?, ?, NULLIF(?, ''), ?, ?);`
 
-	result, err := db.ExecContext(ctx, sql, user.Name, user.Password, user.Role, user.CreatedAt, user.ModifiedAt)
+	result, err := db.ExecContext(ctx, sql, user.Name, user.Password, user.Role, user.Timezone, user.Email, user.CreatedAt, user.ModifiedAt)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
@@ -40,9 +42,92 @@ func (r *Repository) GetUser(
 	name string,
 ) (*entity.User, error) {
 	user := &entity.User{}
-	sql := `SELECT id, name, password, role, created_at, modified_at FROM users WHERE name = ?;`
+	sql := `SELECT id, name, password, role, timezone, COALESCE(email, '') AS email, created_at, modified_at FROM users WHERE name = ?;`
 	if err := db.GetContext(ctx, user, sql, name); err != nil {
 		return nil, err
 	}
 	return user, nil
 }
+
+func (r *Repository) GetUserByID(
+	ctx context.Context,
+	db Queryer,
+	id entity.UserID,
+) (*entity.User, error) {
+	user := &entity.User{}
+	query := `SELECT id, name, password, role, timezone, COALESCE(email, '') AS email, created_at, modified_at FROM users WHERE id = ?;`
+	if err := db.GetContext(ctx, user, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUserByEmail はメールアドレスのユーザーを返す
+func (r *Repository) GetUserByEmail(
+	ctx context.Context,
+	db Queryer,
+	email string,
+) (*entity.User, error) {
+	user := &entity.User{}
+	query := `SELECT id, name, password, role, timezone, COALESCE(email, '') AS email, created_at, modified_at FROM users WHERE email = ?;`
+	if err := db.GetContext(ctx, user, query, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdatePassword はユーザーのパスワードをハッシュ済みのpasswordに置き換える
+func (r *Repository) UpdatePassword(ctx context.Context, db Execer, id entity.UserID, password string) error {
+	query := `UPDATE users SET password = ?, modified_at = ? WHERE id = ?;`
+	result, err := db.ExecContext(ctx, query, password, r.Clocker.Now(), id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("failed to update password of user %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+// GetUserByIdentity はIDプロバイダーのissとsubに対応するユーザーを返す
+func (r *Repository) GetUserByIdentity(
+	ctx context.Context,
+	db Queryer,
+	issuer, subject string,
+) (*entity.User, error) {
+	user := &entity.User{}
+	query := `SELECT u.id, u.name, u.password, u.role, u.timezone, COALESCE(u.email, '') AS email, u.created_at, u.modified_at
+		FROM users u JOIN user_identities i ON i.user_id = u.id
+		WHERE i.issuer = ? AND i.subject = ?;`
+	if err := db.GetContext(ctx, user, query, issuer, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// AddUserIdentity はIDプロバイダーのユーザーをユーザーに対応付ける
+func (r *Repository) AddUserIdentity(ctx context.Context, db Execer, identity *entity.UserIdentity) error {
+	identity.CreatedAt = r.Clocker.Now()
+	query := `INSERT INTO user_identities (issuer, subject, user_id, created_at) VALUES (?, ?, ?, ?);`
+	if _, err := db.ExecContext(ctx, query, identity.Issuer, identity.Subject, identity.UserID, identity.CreatedAt); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("failed to add user identity: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+	"github.com/zakisanbaiman/go-handson01/testutil/fixture"
+)
+
+func TestRepository_UserIdentity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	issuer := "https://idp.example.com"
+
+	if _, err := sut.GetUserByIdentity(ctx, tx, issuer, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, but got %v", err)
+	}
+	if err := sut.AddUserIdentity(ctx, tx, &entity.UserIdentity{Issuer: issuer, Subject: "alice", UserID: userID}); err != nil {
+		t.Fatalf("failed to add identity: %s", err)
+	}
+	got, err := sut.GetUserByIdentity(ctx, tx, issuer, "alice")
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got.ID != userID {
+		t.Errorf("want user %d, but got %d", userID, got.ID)
+	}
+	// 同じsubでも別のIDプロバイダーなら別のユーザー
+	if _, err := sut.GetUserByIdentity(ctx, tx, "https://other.example.com", "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for another issuer, but got %v", err)
+	}
+	err = sut.AddUserIdentity(ctx, tx, &entity.UserIdentity{Issuer: issuer, Subject: "alice", UserID: userID})
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("want ErrAlreadyExists, but got %v", err)
+	}
+}
+
+func TestRepository_UserEmailAndPassword(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	email := fmt.Sprintf("user%d@example.com", rand.Int())
+	user := fixture.User(func(u *entity.User) { u.Email = email })
+	if err := sut.RegisterUser(ctx, tx, user); err != nil {
+		t.Fatalf("failed to register user: %s", err)
+	}
+	// メールアドレスのないユーザーは何人でも登録できる
+	for i := 0; i < 2; i++ {
+		if err := sut.RegisterUser(ctx, tx, fixture.User(nil)); err != nil {
+			t.Fatalf("failed to register user without email: %s", err)
+		}
+	}
+	err = sut.RegisterUser(ctx, tx, fixture.User(func(u *entity.User) { u.Email = email }))
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("want ErrAlreadyExists for the same email, but got %v", err)
+	}
+
+	got, err := sut.GetUserByEmail(ctx, tx, email)
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if got.ID != user.ID || got.Email != email {
+		t.Errorf("want user %d with %s, but got %d with %s", user.ID, email, got.ID, got.Email)
+	}
+	if _, err := sut.GetUserByEmail(ctx, tx, "nobody@example.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, but got %v", err)
+	}
+
+	if err := sut.UpdatePassword(ctx, tx, user.ID, "hashed"); err != nil {
+		t.Fatalf("failed to update password: %s", err)
+	}
+	got, err = sut.GetUserByID(ctx, tx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Password != "hashed" {
+		t.Errorf("want the new password, but got %q", got.Password)
+	}
+	if err := sut.UpdatePassword(ctx, tx, user.ID+1000000, "hashed"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound for an unknown user, but got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// ErrNoWorkspace はコンテキストにワークスペースが設定されていないときのエラー。
+// タスク・プロジェクト・ラベルはワークスペースなしでは読み書きできない。
+var ErrNoWorkspace = errors.New("workspace_id not found in context")
+
+// workspaceID はリクエストのコンテキストからワークスペースIDを取り出す
+func workspaceID(ctx context.Context) (entity.WorkspaceID, error) {
+	id, ok := auth.GetWorkspaceID(ctx)
+	if !ok {
+		return 0, ErrNoWorkspace
+	}
+	return id, nil
+}
+
+// AddWorkspace はワークスペースを登録する。個人用ワークスペースが既にあればErrAlreadyExistsを返す
+func (r *Repository) AddWorkspace(
+	ctx context.Context, db Execer, ws *entity.Workspace,
+) error {
+	ws.CreatedAt = r.Clocker.Now()
+	ws.ModifiedAt = r.Clocker.Now()
+
+	query := `INSERT INTO workspaces (name, personal_user_id, created_at, modified_at) VALUES (?, ?, ?, ?);`
+	result, err := db.ExecContext(ctx, query, ws.Name, ws.PersonalUserID, ws.CreatedAt, ws.ModifiedAt)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("cannot create same personal workspace: %w", ErrAlreadyExists)
+		}
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	ws.ID = entity.WorkspaceID(id)
+	return nil
+}
+
+// GetPersonalWorkspace はユーザーの個人用ワークスペースを取得する
+func (r *Repository) GetPersonalWorkspace(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) (*entity.Workspace, error) {
+	ws := &entity.Workspace{}
+	query := `SELECT id, name, personal_user_id, created_at, modified_at
+		FROM workspaces
+		WHERE personal_user_id = ?;`
+	if err := db.GetContext(ctx, ws, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return ws, nil
+}
+
+// ListWorkspaces はユーザーが所属するワークスペースを、そこでのロールとともに返す
+func (r *Repository) ListWorkspaces(
+	ctx context.Context, db Queryer, userID entity.UserID,
+) ([]*entity.WorkspaceMembership, error) {
+	workspaces := []*entity.WorkspaceMembership{}
+	query := `SELECT w.id, w.name, w.personal_user_id, w.created_at, w.modified_at, m.role
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = ?
+		ORDER BY w.id;`
+	if err := db.SelectContext(ctx, &workspaces, query, userID); err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+func (r *Repository) AddWorkspaceMember(
+	ctx context.Context, db Execer, m *entity.WorkspaceMember,
+) error {
+	m.CreatedAt = r.Clocker.Now()
+
+	query := `INSERT INTO workspace_members (workspace_id, user_id, role, created_at) VALUES (?, ?, ?, ?);`
+	if _, err := db.ExecContext(ctx, query, m.WorkspaceID, m.UserID, m.Role, m.CreatedAt); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == ErrCodeSQLDuplicateEntry {
+			return fmt.Errorf("user %d is already a member: %w", m.UserID, ErrAlreadyExists)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetWorkspaceMember はユーザーのワークスペースでのメンバー情報を取得する。
+// メンバーでなければErrNotFoundを返す。
+func (r *Repository) GetWorkspaceMember(
+	ctx context.Context, db Queryer, workspaceID entity.WorkspaceID, userID entity.UserID,
+) (*entity.WorkspaceMember, error) {
+	m := &entity.WorkspaceMember{}
+	query := `SELECT workspace_id, user_id, role, created_at
+		FROM workspace_members
+		WHERE workspace_id = ? AND user_id = ?;`
+	if err := db.GetContext(ctx, m, query, workspaceID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("workspace %d: %w", workspaceID, ErrNotFound)
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListWorkspaceMembers はコンテキストのワークスペースのメンバーのうち、userIDsのユーザーを名前付きで返す。
+// メンバーでないユーザーは含めない。
+func (r *Repository) ListWorkspaceMembers(
+	ctx context.Context, db Queryer, userIDs []entity.UserID,
+) ([]*entity.WorkspaceMember, error) {
+	wsID, err := workspaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members := []*entity.WorkspaceMember{}
+	if len(userIDs) == 0 {
+		return members, nil
+	}
+	query, args, err := sqlx.In(`SELECT m.workspace_id, m.user_id, u.name, m.role, m.created_at
+		FROM workspace_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.workspace_id = ? AND m.user_id IN (?)
+		ORDER BY m.user_id;`, wsID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SelectContext(ctx, &members, query, args...); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
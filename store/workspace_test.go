@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zakisanbaiman/go-handson01/auth"
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/entity"
+	"github.com/zakisanbaiman/go-handson01/testutil"
+)
+
+// 別のワークスペースのタスク・プロジェクト・ラベルは読み書きできないことを確認する
+func TestRepository_WorkspaceIsolation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	userID := prepareUser(ctx, t, tx)
+	ctxA := auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+	ctxB := auth.SetWorkspaceID(ctx, prepareWorkspace(ctx, t, tx))
+
+	taskA := &entity.Task{UserID: userID, Title: "task in A", Status: entity.TaskStatusTodo}
+	if err := sut.AddTask(ctxA, tx, taskA); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	taskB := &entity.Task{UserID: userID, Title: "task in B", Status: entity.TaskStatusTodo}
+	if err := sut.AddTask(ctxB, tx, taskB); err != nil {
+		t.Fatalf("failed to add task: %s", err)
+	}
+	projectB := &entity.Project{Name: "project in B"}
+	if err := sut.AddProject(ctxB, tx, projectB); err != nil {
+		t.Fatalf("failed to add project: %s", err)
+	}
+	if err := sut.AddTaskLabels(ctxB, tx, taskB.ID, []string{"secret"}); err != nil {
+		t.Fatalf("failed to add labels: %s", err)
+	}
+
+	tasks, err := sut.ListTasks(ctxA, tx, userID)
+	if err != nil {
+		t.Fatalf("failed to list tasks: %s", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != taskA.ID {
+		t.Errorf("ListTasks() in A returned %+v, want only task %d", tasks, taskA.ID)
+	}
+
+	projects, err := sut.ListProjects(ctxA, tx)
+	if err != nil {
+		t.Fatalf("failed to list projects: %s", err)
+	}
+	if len(projects) != 0 {
+		t.Errorf("ListProjects() in A returned %+v, want none", projects)
+	}
+
+	// まとめて読むときも、他のワークスペースのものはIDを指定しても返さない
+	labels, err := sut.ListTaskLabels(ctxA, tx, []entity.TaskID{taskB.ID})
+	if err != nil {
+		t.Fatalf("failed to list task labels: %s", err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("ListTaskLabels() in A returned %+v, want none", labels)
+	}
+	projects, err = sut.ListProjectsByID(ctxA, tx, []entity.ProjectID{projectB.ID})
+	if err != nil {
+		t.Fatalf("failed to list projects by id: %s", err)
+	}
+	if len(projects) != 0 {
+		t.Errorf("ListProjectsByID() in A returned %+v, want none", projects)
+	}
+
+	board, err := sut.GetBoard(ctxA, tx, userID)
+	if err != nil {
+		t.Fatalf("failed to get board: %s", err)
+	}
+	for _, c := range board.Columns {
+		for _, task := range c.Tasks {
+			if task.ID == taskB.ID {
+				t.Errorf("GetBoard() in A contains task %d of B", taskB.ID)
+			}
+		}
+	}
+
+	// Aのコンテキストから、Bのデータへの操作はすべて見つからない扱いになる
+	notFound := map[string]error{
+		"GetProject":       func() error { _, err := sut.GetProject(ctxA, tx, projectB.ID); return err }(),
+		"UpdateTaskStatus": sut.UpdateTaskStatus(ctxA, tx, userID, taskB.ID, entity.TaskStatusDone),
+		"MoveTask":         sut.MoveTask(ctxA, tx, userID, taskB.ID, &entity.Column{ID: 1, Status: entity.TaskStatusDoing}),
+		"AddTaskLabels":    sut.AddTaskLabels(ctxA, tx, taskB.ID, []string{"secret"}),
+		"StartTimer":       sut.StartTimer(ctxA, tx, &entity.TimeEntry{UserID: userID, TaskID: taskB.ID}),
+		"GetTask":          func() error { _, err := sut.GetTask(ctxA, tx, taskB.ID); return err }(),
+		"AssignTask":       sut.AssignTask(ctxA, tx, taskB.ID, &userID),
+		"SetTaskProject":   sut.SetTaskProject(ctxA, tx, taskB.ID, nil),
+	}
+	for name, err := range notFound {
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s() across workspaces: want %v, but got %v", name, ErrNotFound, err)
+		}
+	}
+
+	// ワークスペースのないコンテキストでは読み書きできない
+	noWorkspace := map[string]error{
+		"ListTasks":    func() error { _, err := sut.ListTasks(ctx, tx, userID); return err }(),
+		"AddTask":      sut.AddTask(ctx, tx, &entity.Task{UserID: userID, Title: "no workspace"}),
+		"ListProjects": func() error { _, err := sut.ListProjects(ctx, tx); return err }(),
+		"GetBoard":     func() error { _, err := sut.GetBoard(ctx, tx, userID); return err }(),
+	}
+	for name, err := range noWorkspace {
+		if !errors.Is(err, ErrNoWorkspace) {
+			t.Errorf("%s() without workspace: want %v, but got %v", name, ErrNoWorkspace, err)
+		}
+	}
+}
+
+func TestRepository_WorkspaceMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	owner := prepareUser(ctx, t, tx)
+	outsider := prepareUser(ctx, t, tx)
+
+	ws := &entity.Workspace{Name: "personal", PersonalUserID: &owner}
+	if err := sut.AddWorkspace(ctx, tx, ws); err != nil {
+		t.Fatalf("failed to add workspace: %s", err)
+	}
+	if err := sut.AddWorkspace(ctx, tx, &entity.Workspace{Name: "personal", PersonalUserID: &owner}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("second personal workspace: want %v, but got %v", ErrAlreadyExists, err)
+	}
+	m := &entity.WorkspaceMember{WorkspaceID: ws.ID, UserID: owner, Role: entity.WorkspaceRoleOwner}
+	if err := sut.AddWorkspaceMember(ctx, tx, m); err != nil {
+		t.Fatalf("failed to add member: %s", err)
+	}
+	if err := sut.AddWorkspaceMember(ctx, tx, m); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("duplicate member: want %v, but got %v", ErrAlreadyExists, err)
+	}
+
+	got, err := sut.GetPersonalWorkspace(ctx, tx, owner)
+	if err != nil || got.ID != ws.ID {
+		t.Errorf("GetPersonalWorkspace() = %+v, %v, want workspace %d", got, err, ws.ID)
+	}
+	if _, err := sut.GetWorkspaceMember(ctx, tx, ws.ID, outsider); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetWorkspaceMember() for outsider: want %v, but got %v", ErrNotFound, err)
+	}
+	list, err := sut.ListWorkspaces(ctx, tx, owner)
+	if err != nil {
+		t.Fatalf("failed to list workspaces: %s", err)
+	}
+	if len(list) != 1 || list[0].ID != ws.ID || list[0].Role != entity.WorkspaceRoleOwner {
+		t.Errorf("ListWorkspaces() = %+v", list)
+	}
+}
+
+func TestRepository_ListWorkspaceMembers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	db := testutil.OpenDBForTest(t)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin tx: %s", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	sut := &Repository{Clocker: clock.FixedClocker{}}
+	member := prepareUser(ctx, t, tx)
+	outsider := prepareUser(ctx, t, tx)
+	wsID := prepareWorkspace(ctx, t, tx)
+	if err := sut.AddWorkspaceMember(ctx, tx, &entity.WorkspaceMember{WorkspaceID: wsID, UserID: member, Role: entity.WorkspaceRoleMember}); err != nil {
+		t.Fatalf("failed to add member: %s", err)
+	}
+
+	got, err := sut.ListWorkspaceMembers(auth.SetWorkspaceID(ctx, wsID), tx, []entity.UserID{member, outsider})
+	if err != nil {
+		t.Fatalf("failed to list members: %s", err)
+	}
+	if len(got) != 1 || got[0].UserID != member || got[0].Name == "" {
+		t.Errorf("ListWorkspaceMembers() = %+v, want only user %d with a name", got, member)
+	}
+}
@@ -0,0 +1,222 @@
+package taskquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// Node は検索クエリの構文木のノード
+type Node interface {
+	// Pos はノードが始まる入力上の位置(0始まりの文字数)
+	Pos() int
+	// String はノードを再びParseできる正規化したクエリにする
+	String() string
+}
+
+// Op は条件の比較演算子
+type Op int
+
+const (
+	OpEq Op = iota // :
+	OpLt           // <
+	OpLe           // <=
+	OpGt           // >
+	OpGe           // >=
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpLt:
+		return "<"
+	case OpLe:
+		return "<="
+	case OpGt:
+		return ">"
+	case OpGe:
+		return ">="
+	default:
+		return ":"
+	}
+}
+
+// And はすべての条件にマッチする。空白またはANDで区切った条件になる
+type And struct {
+	Terms []Node
+}
+
+// Or はいずれかの条件にマッチする。ORで区切った条件になり、Andより結合が弱い
+type Or struct {
+	Terms []Node
+}
+
+// Not は条件にマッチしない。先頭に"-"を付けた条件になる
+type Not struct {
+	At int
+	X  Node
+}
+
+// Text はタイトルにその文字列を含むタスクにマッチする。空白を含むなら"で囲む
+type Text struct {
+	At    int
+	Value string
+}
+
+// Status はstatus:doing
+type Status struct {
+	At     int
+	Status entity.TaskStatus
+}
+
+// Label はlabel:work。そのラベルが付いたタスクにマッチする
+type Label struct {
+	At   int
+	Name string
+}
+
+// Priority はpriority:high。priority:noneなら優先度が未指定のタスクにマッチする
+type Priority struct {
+	At       int
+	Priority entity.TaskPriority
+}
+
+// Assignee はassignee:me、assignee:none、assignee:<ユーザーID>
+type Assignee struct {
+	At     int
+	Me     bool
+	None   bool
+	UserID entity.UserID
+}
+
+// Project はproject:none、project:<プロジェクトID>
+type Project struct {
+	At        int
+	None      bool
+	ProjectID entity.ProjectID
+}
+
+// Date はタイムゾーンを持たない日付。どのタイムゾーンの日付かは検索するときに決める
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// Start はlocでのその日の0時を返す
+func (d Date) Start(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Due は期限の条件。次のいずれかになる
+//   - due:none は期限のないタスク
+//   - due:2024-04-01 や due<2024-04-01 はその日との比較
+//   - due<7d や due>-3d は現在時刻からの相対的な時刻との比較。due<now は期限切れ
+type Due struct {
+	At       int
+	Op       Op
+	None     bool
+	Date     *Date
+	Relative time.Duration
+}
+
+func (n *And) Pos() int      { return n.Terms[0].Pos() }
+func (n *Or) Pos() int       { return n.Terms[0].Pos() }
+func (n *Not) Pos() int      { return n.At }
+func (n *Text) Pos() int     { return n.At }
+func (n *Status) Pos() int   { return n.At }
+func (n *Label) Pos() int    { return n.At }
+func (n *Priority) Pos() int { return n.At }
+func (n *Assignee) Pos() int { return n.At }
+func (n *Project) Pos() int  { return n.At }
+func (n *Due) Pos() int      { return n.At }
+
+func (n *And) String() string {
+	terms := make([]string, 0, len(n.Terms))
+	for _, t := range n.Terms {
+		if _, ok := t.(*Or); ok {
+			terms = append(terms, "("+t.String()+")")
+			continue
+		}
+		terms = append(terms, t.String())
+	}
+	return strings.Join(terms, " ")
+}
+
+func (n *Or) String() string {
+	terms := make([]string, 0, len(n.Terms))
+	for _, t := range n.Terms {
+		terms = append(terms, t.String())
+	}
+	return strings.Join(terms, " OR ")
+}
+
+func (n *Not) String() string {
+	switch n.X.(type) {
+	case *And, *Or:
+		return "-(" + n.X.String() + ")"
+	}
+	return "-" + n.X.String()
+}
+
+func (n *Text) String() string { return quote(n.Value) }
+
+func (n *Status) String() string { return "status:" + string(n.Status) }
+
+func (n *Label) String() string { return "label:" + value(n.Name) }
+
+func (n *Priority) String() string {
+	if n.Priority == entity.TaskPriorityNone {
+		return "priority:none"
+	}
+	return "priority:" + string(n.Priority)
+}
+
+func (n *Assignee) String() string {
+	switch {
+	case n.Me:
+		return "assignee:me"
+	case n.None:
+		return "assignee:none"
+	}
+	return "assignee:" + strconv.FormatInt(int64(n.UserID), 10)
+}
+
+func (n *Project) String() string {
+	if n.None {
+		return "project:none"
+	}
+	return "project:" + strconv.FormatInt(int64(n.ProjectID), 10)
+}
+
+func (n *Due) String() string {
+	switch {
+	case n.None:
+		return "due:none"
+	case n.Date != nil:
+		return "due" + n.Op.String() + n.Date.String()
+	case n.Relative == 0:
+		return "due" + n.Op.String() + "now"
+	case n.Relative%(24*time.Hour) == 0:
+		return fmt.Sprintf("due%s%dd", n.Op, n.Relative/(24*time.Hour))
+	}
+	return fmt.Sprintf("due%s%dh", n.Op, n.Relative/time.Hour)
+}
+
+// value は条件の値を、区切り文字を含むときだけ"で囲む
+func value(s string) string {
+	if s == "" || strings.ContainsFunc(s, isDelimiter) {
+		return quote(s)
+	}
+	return s
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
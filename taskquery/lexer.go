@@ -0,0 +1,147 @@
+package taskquery
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokWord             // 区切り文字までの語
+	tokPhrase           // "で囲んだ語句
+	tokField            // status:doing や due<7d のような条件
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	// text は語・語句の中身、または条件のフィールド名
+	text     string
+	op       Op
+	value    string
+	valuePos int
+}
+
+// lexer は位置を文字数で数えられるよう、入力をruneのスライスで持つ
+type lexer struct {
+	src []rune
+	pos int
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(l.src[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	switch c := l.src[l.pos]; c {
+	case '(':
+		l.pos++
+		return token{kind: tokLParen, pos: start}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokRParen, pos: start}, nil
+	case '"':
+		s, err := l.phrase()
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokPhrase, pos: start, text: s}, nil
+	case '-':
+		// 直後が空白や閉じ括弧なら否定ではなくただの語
+		if l.pos+1 < len(l.src) && !unicode.IsSpace(l.src[l.pos+1]) && l.src[l.pos+1] != ')' {
+			l.pos++
+			return token{kind: tokNot, pos: start}, nil
+		}
+	}
+
+	for l.pos < len(l.src) && !isDelimiter(l.src[l.pos]) {
+		l.pos++
+	}
+	word := l.src[start:l.pos]
+	switch string(word) {
+	case "AND":
+		return token{kind: tokAnd, pos: start}, nil
+	case "OR":
+		return token{kind: tokOr, pos: start}, nil
+	}
+
+	name, op, n, ok := splitField(word)
+	if !ok {
+		return token{kind: tokWord, pos: start, text: string(word)}, nil
+	}
+	t := token{kind: tokField, pos: start, text: name, op: op, value: string(word[n:]), valuePos: start + n}
+	// label:"needs review" のように値を"で囲める
+	if t.value == "" && l.pos < len(l.src) && l.src[l.pos] == '"' {
+		s, err := l.phrase()
+		if err != nil {
+			return token{}, err
+		}
+		t.value = s
+	}
+	return t, nil
+}
+
+// phrase は"で囲んだ語句を読む。\" と \\ はエスケープとして扱う
+func (l *lexer) phrase() (string, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\\' && l.pos+1 < len(l.src) && (l.src[l.pos+1] == '"' || l.src[l.pos+1] == '\\'):
+			b.WriteRune(l.src[l.pos+1])
+			l.pos += 2
+		case c == '"':
+			l.pos++
+			return b.String(), nil
+		default:
+			b.WriteRune(c)
+			l.pos++
+		}
+	}
+	return "", &SyntaxError{Pos: start, Msg: "unterminated quoted string"}
+}
+
+// splitField は英字のフィールド名に演算子が続く語を、名前・演算子・値の開始位置に分ける
+func splitField(word []rune) (string, Op, int, bool) {
+	i := 0
+	for i < len(word) && (word[i] >= 'a' && word[i] <= 'z' || word[i] >= 'A' && word[i] <= 'Z') {
+		i++
+	}
+	if i == 0 || i == len(word) {
+		return "", 0, 0, false
+	}
+	name := strings.ToLower(string(word[:i]))
+	next := func(r rune) bool { return i+1 < len(word) && word[i+1] == r }
+	switch word[i] {
+	case ':':
+		return name, OpEq, i + 1, true
+	case '<':
+		if next('=') {
+			return name, OpLe, i + 2, true
+		}
+		return name, OpLt, i + 1, true
+	case '>':
+		if next('=') {
+			return name, OpGe, i + 2, true
+		}
+		return name, OpGt, i + 1, true
+	}
+	return "", 0, 0, false
+}
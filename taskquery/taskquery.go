@@ -0,0 +1,328 @@
+// Package taskquery は `status:doing label:work due<7d "quarterly report"` のような
+// タスクの検索クエリを構文木にする。構文木をSQLにするのはstore.Repositoryの役割。
+//
+// 空白で区切った条件はすべて満たすタスクに、ORで区切った条件はいずれかを満たすタスクにマッチする。
+// 先頭に"-"を付けると否定になり、括弧でまとめられる。
+package taskquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+const (
+	// MaxLength はクエリの最大のバイト数
+	MaxLength = 1024
+	// MaxTerms はクエリに書ける条件の数
+	MaxTerms = 32
+	// MaxDepth は括弧と否定を入れ子にできる深さ
+	MaxDepth = 8
+	// maxRelative は相対的な期限に書ける数の上限
+	maxRelative = 9999
+)
+
+// SyntaxError はクエリの誤りと、その入力上の位置
+type SyntaxError struct {
+	// Pos は0始まりの文字数での位置
+	Pos int
+	Msg string
+}
+
+// Error は位置を1始まりで表す
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// Parse はクエリを構文木にする
+func Parse(input string) (Node, error) {
+	if len(input) > MaxLength {
+		return nil, &SyntaxError{Pos: 0, Msg: fmt.Sprintf("query is longer than %d bytes", MaxLength)}
+	}
+	if !utf8.ValidString(input) {
+		return nil, &SyntaxError{Pos: 0, Msg: "query is not valid UTF-8"}
+	}
+	p := &parser{lex: &lexer{src: []rune(input)}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, p.errorf(p.tok.pos, "empty query")
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return n, nil
+}
+
+type parser struct {
+	lex *lexer
+	// tok は先読みしたトークン
+	tok   token
+	depth int
+	terms int
+}
+
+func (p *parser) advance() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) error {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) unexpected() error {
+	switch p.tok.kind {
+	case tokEOF:
+		return p.errorf(p.tok.pos, "unexpected end of query")
+	case tokAnd:
+		return p.errorf(p.tok.pos, "unexpected AND")
+	case tokOr:
+		return p.errorf(p.tok.pos, "unexpected OR")
+	case tokRParen:
+		return p.errorf(p.tok.pos, `unexpected ")"`)
+	}
+	return p.errorf(p.tok.pos, "unexpected token")
+}
+
+// or = and { "OR" and }
+func (p *parser) or() (Node, error) {
+	var terms []Node
+	for {
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		if or, ok := n.(*Or); ok {
+			terms = append(terms, or.Terms...)
+		} else {
+			terms = append(terms, n)
+		}
+		if p.tok.kind != tokOr {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &Or{Terms: terms}, nil
+}
+
+// and = unary { ["AND"] unary }
+func (p *parser) and() (Node, error) {
+	var terms []Node
+	for {
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if and, ok := n.(*And); ok {
+			terms = append(terms, and.Terms...)
+		} else {
+			terms = append(terms, n)
+		}
+
+		if p.tok.kind == tokAnd {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !p.startsTerm() {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &And{Terms: terms}, nil
+}
+
+func (p *parser) startsTerm() bool {
+	switch p.tok.kind {
+	case tokWord, tokPhrase, tokField, tokNot, tokLParen:
+		return true
+	}
+	return false
+}
+
+// unary = "-" unary | "(" or ")" | 語 | 語句 | 条件
+func (p *parser) unary() (Node, error) {
+	t := p.tok
+	switch t.kind {
+	case tokNot, tokLParen:
+		if p.depth >= MaxDepth {
+			return nil, p.errorf(t.pos, "query is nested deeper than %d", MaxDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if t.kind == tokNot {
+			x, err := p.unary()
+			if err != nil {
+				return nil, err
+			}
+			return &Not{At: t.pos, X: x}, nil
+		}
+		if p.tok.kind == tokRParen {
+			return nil, p.errorf(t.pos, "empty parentheses")
+		}
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf(t.pos, `unclosed "("`)
+		}
+		return x, p.advance()
+	case tokWord, tokPhrase, tokField:
+		p.terms++
+		if p.terms > MaxTerms {
+			return nil, p.errorf(t.pos, "query has more than %d conditions", MaxTerms)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if t.kind == tokField {
+			return p.field(t)
+		}
+		if t.text == "" {
+			return nil, p.errorf(t.pos, "empty quoted string")
+		}
+		return &Text{At: t.pos, Value: t.text}, nil
+	}
+	return nil, p.unexpected()
+}
+
+// fields は条件に書けるフィールド名
+var fields = map[string]bool{
+	"status": true, "label": true, "priority": true, "assignee": true, "project": true, "due": true,
+}
+
+func (p *parser) field(t token) (Node, error) {
+	if !fields[t.text] {
+		return nil, p.errorf(t.pos, "unknown field %q", t.text)
+	}
+	if t.value == "" {
+		return nil, p.errorf(t.valuePos, "missing value for %q", t.text)
+	}
+	if t.text != "due" && t.op != OpEq {
+		return nil, p.errorf(t.pos, "%q does not support %q", t.text, t.op.String())
+	}
+	v := strings.ToLower(t.value)
+
+	switch t.text {
+	case "status":
+		s := entity.TaskStatus(v)
+		if !s.Valid() {
+			return nil, p.errorf(t.valuePos, "unknown status %q", t.value)
+		}
+		return &Status{At: t.pos, Status: s}, nil
+	case "label":
+		return &Label{At: t.pos, Name: t.value}, nil
+	case "priority":
+		switch pr := entity.TaskPriority(v); pr {
+		case entity.TaskPriorityLow, entity.TaskPriorityMedium, entity.TaskPriorityHigh:
+			return &Priority{At: t.pos, Priority: pr}, nil
+		}
+		if v == "none" {
+			return &Priority{At: t.pos, Priority: entity.TaskPriorityNone}, nil
+		}
+		return nil, p.errorf(t.valuePos, "unknown priority %q", t.value)
+	case "assignee":
+		switch v {
+		case "me":
+			return &Assignee{At: t.pos, Me: true}, nil
+		case "none":
+			return &Assignee{At: t.pos, None: true}, nil
+		}
+		id, ok := parseID(v)
+		if !ok {
+			return nil, p.errorf(t.valuePos, `assignee must be "me", "none" or a user id: %q`, t.value)
+		}
+		return &Assignee{At: t.pos, UserID: entity.UserID(id)}, nil
+	case "project":
+		if v == "none" {
+			return &Project{At: t.pos, None: true}, nil
+		}
+		id, ok := parseID(v)
+		if !ok {
+			return nil, p.errorf(t.valuePos, `project must be "none" or a project id: %q`, t.value)
+		}
+		return &Project{At: t.pos, ProjectID: entity.ProjectID(id)}, nil
+	}
+	return p.due(t, v)
+}
+
+func (p *parser) due(t token, v string) (Node, error) {
+	if v == "none" {
+		if t.op != OpEq {
+			return nil, p.errorf(t.pos, `"due:none" does not support %q`, t.op.String())
+		}
+		return &Due{At: t.pos, None: true}, nil
+	}
+	if d, err := time.Parse(time.DateOnly, v); err == nil {
+		return &Due{At: t.pos, Op: t.op, Date: &Date{Year: d.Year(), Month: d.Month(), Day: d.Day()}}, nil
+	}
+	r, ok := parseRelative(v)
+	if !ok {
+		return nil, p.errorf(t.valuePos, `due must be "none", a date (2006-01-02), "now" or relative like 7d, 12h, 2w: %q`, t.value)
+	}
+	if t.op == OpEq {
+		return nil, p.errorf(t.pos, `relative due %q needs "<", "<=", ">" or ">="`, t.value)
+	}
+	return &Due{At: t.pos, Op: t.op, Relative: r}, nil
+}
+
+func parseID(v string) (int64, bool) {
+	id, err := strconv.ParseInt(v, 10, 64)
+	return id, err == nil && id > 0
+}
+
+// parseRelative は now、7d、-3d、12h、2w のような現在時刻からの時間を読む
+func parseRelative(v string) (time.Duration, bool) {
+	if v == "now" {
+		return 0, true
+	}
+	if len(v) < 2 {
+		return 0, false
+	}
+	var unit time.Duration
+	switch v[len(v)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+	digits := strings.TrimPrefix(v[:len(v)-1], "-")
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n > maxRelative || n < -maxRelative {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
@@ -0,0 +1,187 @@
+package taskquery
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input string
+		want  Node
+	}{
+		"full example": {
+			input: `status:doing label:work due<7d "quarterly report"`,
+			want: &And{Terms: []Node{
+				&Status{At: 0, Status: entity.TaskStatusDoing},
+				&Label{At: 13, Name: "work"},
+				&Due{At: 24, Op: OpLt, Relative: 7 * 24 * time.Hour},
+				&Text{At: 31, Value: "quarterly report"},
+			}},
+		},
+		"or binds weaker than and": {
+			input: "status:todo label:home OR priority:high",
+			want: &Or{Terms: []Node{
+				&And{Terms: []Node{
+					&Status{At: 0, Status: entity.TaskStatusTodo},
+					&Label{At: 12, Name: "home"},
+				}},
+				&Priority{At: 26, Priority: entity.TaskPriorityHigh},
+			}},
+		},
+		"negated group": {
+			input: "-(status:done OR assignee:none)",
+			want: &Not{At: 0, X: &Or{Terms: []Node{
+				&Status{At: 2, Status: entity.TaskStatusDone},
+				&Assignee{At: 17, None: true},
+			}}},
+		},
+		"quoted label and date": {
+			input: `label:"needs review" due<=2024-04-01`,
+			want: &And{Terms: []Node{
+				&Label{At: 0, Name: "needs review"},
+				&Due{At: 21, Op: OpLe, Date: &Date{Year: 2024, Month: time.April, Day: 1}},
+			}},
+		},
+		"positions count characters": {
+			input: "締め切り project:3",
+			want: &And{Terms: []Node{
+				&Text{At: 0, Value: "締め切り"},
+				&Project{At: 5, ProjectID: 3},
+			}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse(%q) mismatch (-want +got):\n%s", tt.input, diff)
+			}
+		})
+	}
+}
+
+func TestNode_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		`report`:                               `"report"`,
+		`Status:DOING AND label:work`:          `status:doing label:work`,
+		`(a OR b) c`:                           `("a" OR "b") "c"`,
+		`a (b OR (c OR d))`:                    `"a" ("b" OR "c" OR "d")`,
+		`-(a b) -c`:                            `-("a" "b") -"c"`,
+		`due<now due>=-3d due<36h due<2w`:      `due<now due>=-3d due<36h due<14d`,
+		`due:none priority:none assignee:me`:   `due:none priority:none assignee:me`,
+		`label:"a (b)" "say \"hi\" \\ bye"`:    `label:"a (b)" "say \"hi\" \\ bye"`,
+		`project:007 assignee:42 label:a:b`:    `project:7 assignee:42 label:a:b`,
+		`"OR" - "a"`:                           `"OR" "-" "a"`,
+		`due:2024-04-01 due>2024-12-31`:        `due:2024-04-01 due>2024-12-31`,
+		`status:todo OR status:doing`:          `status:todo OR status:doing`,
+		`-label:work assignee:none project:12`: `-label:work assignee:none project:12`,
+	}
+	for input, want := range tests {
+		got, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", input, err)
+			continue
+		}
+		if got.String() != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", input, got.String(), want)
+		}
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"empty":               {input: "  ", want: "empty query at position 3"},
+		"unknown field":       {input: "status:todo colour:red", want: `unknown field "colour" at position 13`},
+		"unknown status":      {input: "status:doen", want: `unknown status "doen" at position 8`},
+		"missing value":       {input: "label: work", want: `missing value for "label" at position 7`},
+		"unsupported op":      {input: "status<todo", want: `"status" does not support "<" at position 1`},
+		"relative with colon": {input: "due:7d", want: `relative due "7d" needs "<", "<=", ">" or ">=" at position 1`},
+		"bad due":             {input: "due<soon", want: `due must be "none", a date (2006-01-02), "now" or relative like 7d, 12h, 2w: "soon" at position 5`},
+		"bad assignee":        {input: "assignee:bob", want: `assignee must be "me", "none" or a user id: "bob" at position 10`},
+		"unterminated quote":  {input: `label:work "quarterly`, want: "unterminated quoted string at position 12"},
+		"unclosed paren":      {input: "(a OR b", want: `unclosed "(" at position 1`},
+		"stray paren":         {input: "a b)", want: `unexpected ")" at position 4`},
+		"empty parens":        {input: "a ()", want: "empty parentheses at position 3"},
+		"leading or":          {input: "OR a", want: "unexpected OR at position 1"},
+		"trailing and":        {input: "a AND", want: "unexpected end of query at position 6"},
+		"empty phrase":        {input: `a ""`, want: "empty quoted string at position 3"},
+		"position in runes":   {input: "締め切り status:later", want: `unknown status "later" at position 13`},
+		"too deep":            {input: strings.Repeat("-", MaxDepth+1) + "a", want: "query is nested deeper than 8 at position 9"},
+		"too many terms":      {input: strings.Repeat("a ", MaxTerms+1), want: "query has more than 32 conditions at position 65"},
+		"too long":            {input: strings.Repeat("a", MaxLength+1), want: "query is longer than 1024 bytes at position 1"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tt.input)
+			var serr *SyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("want *SyntaxError, but got %v", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Parse(%q) error = %q, want %q", tt.input, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+// FuzzParse はどんな入力でもパニックせず、誤りの位置が入力の範囲に収まり、
+// 構文木を文字列に戻して再びParseすると同じ構文木になることを確かめる
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		`status:doing label:work due<7d "quarterly report"`,
+		`-(status:done OR assignee:none) priority:high`,
+		`label:"needs review" due<=2024-04-01 project:3`,
+		`due>=-3d AND "say \"hi\"" OR 締め切り`,
+		`((a) OR -b`,
+		`due:`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		n, err := Parse(input)
+		if err != nil {
+			var serr *SyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("Parse(%q) returned %T, want *SyntaxError", input, err)
+			}
+			if serr.Pos < 0 || serr.Pos > len([]rune(input)) {
+				t.Fatalf("Parse(%q) error position %d is out of range", input, serr.Pos)
+			}
+			return
+		}
+		s := n.String()
+		if len(s) > MaxLength {
+			// 引用符を補うと長さの上限を超えることがある
+			return
+		}
+		again, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed to re-parse %q: %v", input, s, err)
+		}
+		if again.String() != s {
+			t.Fatalf("Parse(%q) round trip mismatch: %q != %q", input, again.String(), s)
+		}
+	})
+}
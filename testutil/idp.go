@@ -0,0 +1,205 @@
+package testutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/zakisanbaiman/go-handson01/entity"
+)
+
+// FakeIdP はテスト用にプロセス内で動くOpenID ConnectのIDプロバイダー。
+// 認可コードフローとPKCE(S256)だけに対応し、/authorizeはユーザーの同意を待たずにすぐコードを返す。
+type FakeIdP struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	// TamperIDToken を設定すると、署名する前のIDトークンを書き換えられる
+	TamperIDToken func(tok jwt.Token)
+
+	key jwk.Key
+
+	mu    sync.Mutex
+	user  entity.OIDCIdentity
+	codes map[string]*idpCode
+}
+
+// idpCode は発行した認可コードに対応するリクエスト
+type idpCode struct {
+	clientID    string
+	redirectURI string
+	challenge   string
+	nonce       string
+	user        entity.OIDCIdentity
+}
+
+// StartIdP はFakeIdPを起動し、テストの終了時に停止する
+func StartIdP(t *testing.T) *FakeIdP {
+	t.Helper()
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		t.Fatalf("failed to create jwk: %v", err)
+	}
+	_ = key.Set(jwk.KeyIDKey, "fake-idp")
+	_ = key.Set(jwk.AlgorithmKey, jwa.RS256)
+
+	idp := &FakeIdP{
+		ClientID:     "test-client",
+		ClientSecret: "test-secret",
+		key:          key,
+		user:         entity.OIDCIdentity{Subject: "fake-user", Email: "fake@example.com", Name: "Fake User", PreferredUsername: "fake"},
+		codes:        map[string]*idpCode{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	idp.URL = srv.URL
+	return idp
+}
+
+// SetUser は次にログインするユーザーを設定する。IssuerはFakeIdPのURLになる
+func (idp *FakeIdP) SetUser(user entity.OIDCIdentity) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.user = user
+}
+
+func (idp *FakeIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeIdPJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                idp.URL,
+		"authorization_endpoint":                idp.URL + "/authorize",
+		"token_endpoint":                        idp.URL + "/token",
+		"jwks_uri":                              idp.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (idp *FakeIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || q.Get("redirect_uri") == "" || q.Get("client_id") != idp.ClientID ||
+		q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	idp.mu.Lock()
+	idp.codes[code] = &idpCode{
+		clientID:    q.Get("client_id"),
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		user:        idp.user,
+	}
+	idp.mu.Unlock()
+
+	rq := redirectURI.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	redirectURI.RawQuery = rq.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *FakeIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.Method != http.MethodPost {
+		writeIdPJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != idp.ClientID || secret != idp.ClientSecret {
+		writeIdPJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	idp.mu.Lock()
+	c, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" || c.clientID != clientID ||
+		c.redirectURI != r.PostForm.Get("redirect_uri") || c.challenge != base64.RawURLEncoding.EncodeToString(sum[:]) {
+		writeIdPJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	tok, err := jwt.NewBuilder().
+		Issuer(idp.URL).
+		Subject(c.user.Subject).
+		Audience([]string{clientID}).
+		IssuedAt(now).
+		Expiration(now.Add(5*time.Minute)).
+		Claim("nonce", c.nonce).
+		Claim("email", c.user.Email).
+		Claim("name", c.user.Name).
+		Claim("preferred_username", c.user.PreferredUsername).
+		Build()
+	if err != nil {
+		writeIdPJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	if idp.TamperIDToken != nil {
+		idp.TamperIDToken(tok)
+	}
+	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, idp.key))
+	if err != nil {
+		writeIdPJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeIdPJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     string(signed),
+	})
+}
+
+func (idp *FakeIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	pub, err := idp.key.PublicKey()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	set := jwk.NewSet()
+	_ = set.AddKey(pub)
+	writeIdPJSON(w, http.StatusOK, set)
+}
+
+func writeIdPJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,127 @@
+package testutil
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// SMTPMessage はFakeSMTPServerが受け取ったメール
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// FakeSMTPServer はテスト用にプロセス内で動くSMTPサーバー。
+// STARTTLSと認証には対応せず、受け取ったメールをメモリに保持する。
+type FakeSMTPServer struct {
+	Host string
+	Port int
+
+	mu       sync.Mutex
+	messages []*SMTPMessage
+	failures []int
+}
+
+// StartSMTPServer はFakeSMTPServerを起動し、テストの終了時に停止する
+func StartSMTPServer(t *testing.T) *FakeSMTPServer {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	addr := l.Addr().(*net.TCPAddr)
+	s := &FakeSMTPServer{Host: addr.IP.String(), Port: addr.Port}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// FailNext は次に受け取るメールに、codesの応答コードを順に返して失敗させる
+func (s *FakeSMTPServer) FailNext(codes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, codes...)
+}
+
+// Messages は受け取ったメールを受け取った順に返す
+func (s *FakeSMTPServer) Messages() []*SMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*SMTPMessage(nil), s.messages...)
+}
+
+func (s *FakeSMTPServer) serve(conn net.Conn) {
+	tc := textproto.NewConn(conn)
+	defer tc.Close()
+
+	_ = tc.PrintfLine("220 fake ESMTP")
+	msg := &SMTPMessage{}
+	for {
+		line, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = tc.PrintfLine("250 fake")
+		case "MAIL":
+			msg = &SMTPMessage{From: address(line)}
+			_ = tc.PrintfLine("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(line))
+			_ = tc.PrintfLine("250 OK")
+		case "DATA":
+			_ = tc.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tc.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = data
+			_ = tc.PrintfLine("%s", s.receive(msg))
+		case "RSET", "NOOP":
+			_ = tc.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tc.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tc.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func (s *FakeSMTPServer) receive(msg *SMTPMessage) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) > 0 {
+		code := s.failures[0]
+		s.failures = s.failures[1:]
+		return fmt.Sprintf("%d Failed by test", code)
+	}
+	s.messages = append(s.messages, msg)
+	return "250 OK"
+}
+
+// address は"MAIL FROM:<a@example.com>"のようなコマンドからアドレスを取り出す
+func address(line string) string {
+	start, end := strings.Index(line, "<"), strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
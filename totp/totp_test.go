@@ -0,0 +1,123 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfcSecret はRFC 6238の付録Bのテストベクトルのシークレット
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6238の8桁の値の下6桁
+	tests := map[string]struct {
+		unix int64
+		want string
+	}{
+		"59":         {unix: 59, want: "287082"},
+		"1111111109": {unix: 1111111109, want: "081804"},
+		"1111111111": {unix: 1111111111, want: "050471"},
+		"1234567890": {unix: 1234567890, want: "005924"},
+		"2000000000": {unix: 2000000000, want: "279037"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("want %q, but got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	codeAt := func(s int64) string {
+		c, err := Code(rfcSecret, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		"current":     {code: codeAt(step), wantStep: step, wantOK: true},
+		"previous":    {code: codeAt(step - 1), wantStep: step - 1, wantOK: true},
+		"next":        {code: codeAt(step + 1), wantStep: step + 1, wantOK: true},
+		"tooOld":      {code: codeAt(step - 2)},
+		"tooNew":      {code: codeAt(step + 2)},
+		"wrongLength": {code: "12345"},
+	}
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok, err := Validate(rfcSecret, tt.code, now, 1)
+			if err != nil {
+				t.Fatalf("want no error, but got %v", err)
+			}
+			if ok != tt.wantOK || got != tt.wantStep {
+				t.Errorf("want step %d ok %v, but got step %d ok %v", tt.wantStep, tt.wantOK, got, ok)
+			}
+		})
+	}
+
+	if _, _, err := Validate("not base32!", "123456", now, 1); err == nil {
+		t.Errorf("want error for invalid secret")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	b, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("want no error, but got %v", err)
+	}
+	if len(a) != 32 || a == b {
+		t.Errorf("want distinct 32 characters secrets, but got %q and %q", a, b)
+	}
+	if _, err := Code(a, 1); err != nil {
+		t.Errorf("want usable secret, but got %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	got, err := url.Parse(URI("go-handson01", "alice", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Scheme != "otpauth" || got.Host != "totp" || got.Path != "/go-handson01:alice" {
+		t.Errorf("unexpected uri %s", got)
+	}
+	q := got.Query()
+	for k, want := range map[string]string{"secret": "JBSWY3DPEHPK3PXP", "issuer": "go-handson01", "algorithm": "SHA1", "digits": "6", "period": "30"} {
+		if q.Get(k) != want {
+			t.Errorf("want %s=%q, but got %q", k, want, q.Get(k))
+		}
+	}
+}
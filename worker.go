@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+
+	"github.com/zakisanbaiman/go-handson01/clock"
+	"github.com/zakisanbaiman/go-handson01/config"
+	"github.com/zakisanbaiman/go-handson01/service"
+	"github.com/zakisanbaiman/go-handson01/store"
+)
+
+// NewReminderScheduler は期限の通知を送るスケジューラーを作る。HTTPサーバーとは別のDB接続を使う
+func NewReminderScheduler(ctx context.Context, cfg *config.Config) (*service.ReminderScheduler, func(), error) {
+	db, cleanup, err := store.New(ctx, cfg)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	clocker := clock.RealClocker{}
+	r := store.Repository{Clocker: clocker}
+	return &service.ReminderScheduler{
+		DB:            db,
+		Repo:          &r,
+		Notifications: &r,
+		Clocker:       clocker,
+		Interval:      cfg.ReminderInterval,
+	}, cleanup, nil
+}